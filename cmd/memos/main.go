@@ -66,6 +66,7 @@ func init() {
 	rootCmd.Flags().String("instance-url", "", "canonical external URL of the Memos instance")
 	rootCmd.Flags().Bool("allow-private-webhooks", false, "allow webhooks to access any private/reserved IP address")
 	rootCmd.Flags().StringSlice("webhook-private-network-allowlist", nil, "private webhook destinations to allow (exact hostname, IP, or CIDR)")
+	rootCmd.Flags().Bool("external-diagram-renderers", false, "render Mermaid and PlantUML diagrams with mmdc and plantuml on PATH")
 	rootCmd.Flags().String("log-level", "info", "log verbosity level (debug, info, warn, error)")
 
 	if err := rootCmd.Flags().MarkDeprecated("allow-private-webhooks", "use --webhook-private-network-allowlist to allow only required destinations"); err != nil {
@@ -82,6 +83,7 @@ func init() {
 		"instance-url",
 		"allow-private-webhooks",
 		"webhook-private-network-allowlist",
+		"external-diagram-renderers",
 		"log-level",
 	} {
 		if err := viper.BindPFlag(key, rootCmd.Flags().Lookup(key)); err != nil {
@@ -98,16 +100,17 @@ func init() {
 
 func runServer() error {
 	instanceProfile := &profile.Profile{
-		Demo:        viper.GetBool("demo"),
-		Addr:        viper.GetString("addr"),
-		Port:        viper.GetInt("port"),
-		UNIXSock:    viper.GetString("unix-sock"),
-		Data:        viper.GetString("data"),
-		Driver:      viper.GetString("driver"),
-		DSN:         viper.GetString("dsn"),
-		InstanceURL: viper.GetString("instance-url"),
-		Version:     version.GetCurrentVersion(),
-		Commit:      version.Commit,
+		Demo:                     viper.GetBool("demo"),
+		Addr:                     viper.GetString("addr"),
+		Port:                     viper.GetInt("port"),
+		UNIXSock:                 viper.GetString("unix-sock"),
+		Data:                     viper.GetString("data"),
+		Driver:                   viper.GetString("driver"),
+		DSN:                      viper.GetString("dsn"),
+		InstanceURL:              viper.GetString("instance-url"),
+		ExternalDiagramRenderers: viper.GetBool("external-diagram-renderers"),
+		Version:                  version.GetCurrentVersion(),
+		Commit:                   version.Commit,
 	}
 
 	allowPrivateWebhooks := viper.GetBool("allow-private-webhooks")
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/internal/version"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db"
+)
+
+var migrateAttachmentsCmd = &cobra.Command{
+	Use:   "migrate-attachments",
+	Short: "Move attachments from one configured storage to another",
+	Long: `Move attachments from one configured storage to another, e.g. database blobs to S3.
+
+Storages are referenced by the IDs of the instance storage setting; the built-in
+storages are "database" and "local". An interrupted migration can be resumed by
+running the same command again, or from the printed page token.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runMigrateAttachments(cmd)
+	},
+}
+
+func init() {
+	flags := migrateAttachmentsCmd.Flags()
+	flags.String("data", "", "data directory")
+	flags.String("driver", "sqlite", "database driver")
+	flags.String("dsn", "", "database source name (DSN)")
+	flags.String("from", "", "ID of the storage to move attachments from")
+	flags.String("to", "", "ID of the storage to move attachments to")
+	flags.String("filter", "", "only move attachments matching this attachment filter")
+	flags.Int32("batch-size", 50, "number of attachments examined per batch")
+	flags.String("page-token", "", "resume from the page token printed by an interrupted run")
+	for _, name := range []string{"from", "to"} {
+		if err := migrateAttachmentsCmd.MarkFlagRequired(name); err != nil {
+			panic(err)
+		}
+	}
+
+	rootCmd.AddCommand(migrateAttachmentsCmd)
+}
+
+func runMigrateAttachments(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	// Database flags fall back to the MEMOS_* environment used by the server.
+	databaseFlag := func(name string) string {
+		value, _ := flags.GetString(name)
+		if !flags.Changed(name) && viper.IsSet(name) {
+			value = viper.GetString(name)
+		}
+		return value
+	}
+	instanceProfile := &profile.Profile{
+		Data:    databaseFlag("data"),
+		Driver:  databaseFlag("driver"),
+		DSN:     databaseFlag("dsn"),
+		Version: version.GetCurrentVersion(),
+		Commit:  version.Commit,
+	}
+	if err := instanceProfile.Validate(); err != nil {
+		return errors.Wrap(err, "failed to validate profile")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	dbDriver, err := db.NewDBDriver(instanceProfile)
+	if err != nil {
+		return errors.Wrap(err, "failed to create database driver")
+	}
+	storeInstance := store.New(dbDriver, instanceProfile)
+	defer func() {
+		if err := storeInstance.Close(); err != nil {
+			slog.Error("failed to close store", "error", err)
+		}
+	}()
+	if err := storeInstance.Migrate(ctx); err != nil {
+		return errors.Wrap(err, "failed to migrate database")
+	}
+	// Storages may be provisioned by deployment files rather than the database.
+	if err := storeInstance.LoadDeploymentConfiguration(ctx); err != nil {
+		return errors.Wrap(err, "failed to load deployment configuration")
+	}
+
+	request := &v1pb.MigrateAttachmentsRequest{}
+	request.SourceStorageId, _ = flags.GetString("from")
+	request.TargetStorageId, _ = flags.GetString("to")
+	request.Filter, _ = flags.GetString("filter")
+	request.PageSize, _ = flags.GetInt32("batch-size")
+	request.PageToken, _ = flags.GetString("page-token")
+
+	var migrated, failed int32
+	for {
+		response, err := apiv1.MigrateAttachmentBatch(ctx, instanceProfile, storeInstance, request)
+		if err != nil {
+			if request.PageToken != "" {
+				fmt.Fprintf(os.Stderr, "Resume with --page-token=%s\n", request.PageToken)
+			}
+			return errors.Wrap(err, "failed to migrate attachments")
+		}
+		migrated += response.MigratedCount
+		failed += int32(len(response.Failures))
+		for _, failure := range response.Failures {
+			fmt.Fprintf(os.Stderr, "Failed to migrate %s: %s\n", failure.Attachment, failure.Error)
+		}
+		fmt.Printf("Processed %d/%d attachments (%d migrated, %d failed)\n", response.ProcessedCount, response.TotalCount, migrated, failed)
+		if response.NextPageToken == "" {
+			break
+		}
+		request.PageToken = response.NextPageToken
+	}
+	if failed > 0 {
+		return errors.Errorf("%d attachments were not migrated; run the command again to retry them", failed)
+	}
+	return nil
+}
@@ -1,5 +1,7 @@
 package ai
 
+import "time"
+
 // ProviderType identifies an AI provider implementation.
 type ProviderType string
 
@@ -8,8 +10,19 @@ const (
 	ProviderOpenAI ProviderType = "OPENAI"
 	// ProviderGemini is Google's Gemini API.
 	ProviderGemini ProviderType = "GEMINI"
+	// ProviderOpenAICompatible is any server implementing the OpenAI REST API,
+	// such as vLLM, LocalAI, LM Studio or a self-hosted faster-whisper.
+	ProviderOpenAICompatible ProviderType = "OPENAI_COMPATIBLE"
+	// ProviderOllama is an Ollama server reached through its native API.
+	ProviderOllama ProviderType = "OLLAMA"
 )
 
+// RequiresAPIKey reports whether the provider type cannot be called without an API key.
+// Self-hosted servers commonly run unauthenticated, so their key is optional.
+func (t ProviderType) RequiresAPIKey() bool {
+	return t == ProviderOpenAI || t == ProviderGemini
+}
+
 // ProviderConfig configures a callable AI provider connection.
 type ProviderConfig struct {
 	ID       string
@@ -17,4 +30,8 @@ type ProviderConfig struct {
 	Type     ProviderType
 	Endpoint string
 	APIKey   string
+	// Timeout bounds a single request to the provider. Zero uses the capability default.
+	Timeout time.Duration
+	// MaxConcurrentRequests caps in-flight requests to the provider. Zero means unlimited.
+	MaxConcurrentRequests int
 }
@@ -0,0 +1,18 @@
+// Package catalog defines the model-discovery capability for AI providers.
+// Implementations list the models a provider serves so administrators can pick
+// one without knowing its identifier in advance, and so a provider connection
+// can be verified with a cheap, side-effect-free request.
+package catalog
+
+import "context"
+
+// Lister lists the models served by a provider.
+type Lister interface {
+	ListModels(ctx context.Context) ([]Model, error)
+}
+
+// Model describes one model served by a provider.
+type Model struct {
+	ID          string // identifier to pass as the model in capability requests
+	DisplayName string // empty if the provider did not return one
+}
@@ -0,0 +1,89 @@
+// Package gemini implements catalog.Lister against the Gemini models endpoint.
+package gemini
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genai"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/catalog"
+)
+
+const (
+	defaultEndpoint   = "https://generativelanguage.googleapis.com/v1beta"
+	defaultAPIVersion = "v1beta"
+)
+
+// Lister implements catalog.Lister for Gemini.
+type Lister struct {
+	client *genai.Client
+}
+
+// New constructs a Lister from a provider config.
+func New(cfg ai.ProviderConfig, options catalog.Options) (*Lister, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("Gemini API key is required")
+	}
+	baseURL, apiVersion, err := splitEndpoint(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	httpOptions := genai.HTTPOptions{BaseURL: baseURL, APIVersion: apiVersion}
+	if options.HTTPClient != nil && options.HTTPClient.Timeout > 0 {
+		timeout := options.HTTPClient.Timeout
+		httpOptions.Timeout = &timeout
+	}
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey:      cfg.APIKey,
+		Backend:     genai.BackendGeminiAPI,
+		HTTPClient:  options.HTTPClient,
+		HTTPOptions: httpOptions,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Gemini client")
+	}
+	return &Lister{client: client}, nil
+}
+
+// ListModels pages through the Gemini models list.
+func (l *Lister) ListModels(ctx context.Context) ([]catalog.Model, error) {
+	var models []catalog.Model
+	for model, err := range l.client.Models.All(ctx) {
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list Gemini models")
+		}
+		models = append(models, catalog.Model{
+			ID:          strings.TrimPrefix(model.Name, "models/"),
+			DisplayName: model.DisplayName,
+		})
+	}
+	return models, nil
+}
+
+func splitEndpoint(endpoint string) (string, string, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return "", "", errors.Wrap(err, "invalid Gemini endpoint")
+	}
+	parsed, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil {
+		return "", "", errors.Wrap(err, "invalid Gemini endpoint")
+	}
+	path := strings.TrimRight(parsed.Path, "/")
+	apiVersion := defaultAPIVersion
+	for _, supported := range []string{"v1alpha", "v1beta", "v1"} {
+		if path == "/"+supported || strings.HasSuffix(path, "/"+supported) {
+			apiVersion = supported
+			parsed.Path = strings.TrimSuffix(path, "/"+supported)
+			break
+		}
+	}
+	return strings.TrimRight(parsed.String(), "/"), apiVersion, nil
+}
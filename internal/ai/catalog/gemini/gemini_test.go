@@ -0,0 +1,47 @@
+package gemini_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/catalog"
+	cataloggemini "github.com/usememos/memos/internal/ai/catalog/gemini"
+)
+
+func TestListModels(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v1beta/models", r.URL.Path)
+		require.Equal(t, "test-key", r.Header.Get("x-goog-api-key"))
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"models": []map[string]any{
+				{"name": "models/gemini-2.5-flash", "displayName": "Gemini 2.5 Flash"},
+			},
+		}))
+	}))
+	defer server.Close()
+
+	lister, err := cataloggemini.New(ai.ProviderConfig{
+		Type:     ai.ProviderGemini,
+		Endpoint: server.URL + "/v1beta",
+		APIKey:   "test-key",
+	}, catalog.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	models, err := lister.ListModels(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []catalog.Model{{ID: "gemini-2.5-flash", DisplayName: "Gemini 2.5 Flash"}}, models)
+}
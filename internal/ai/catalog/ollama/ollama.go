@@ -0,0 +1,103 @@
+// Package ollama implements catalog.Lister against the native Ollama API
+// (GET /api/tags), which lists the models pulled onto the server.
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/catalog"
+)
+
+const (
+	// DefaultEndpoint is where a local Ollama server listens.
+	DefaultEndpoint = "http://localhost:11434"
+
+	maxErrorBodySize = 4 * 1024
+)
+
+// Lister implements catalog.Lister for Ollama.
+type Lister struct {
+	httpClient *http.Client
+	endpoint   string
+	apiKey     string
+}
+
+// New constructs a Lister from a provider config. The API key is optional and
+// is only sent when Ollama sits behind an authenticating proxy.
+func New(cfg ai.ProviderConfig, options catalog.Options) (*Lister, error) {
+	endpoint, err := normalizeEndpoint(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &Lister{
+		httpClient: options.HTTPClient,
+		endpoint:   endpoint,
+		apiKey:     cfg.APIKey,
+	}, nil
+}
+
+type tagsResponse struct {
+	Models []struct {
+		Name  string `json:"name"`
+		Model string `json:"model"`
+	} `json:"models"`
+}
+
+// ListModels returns the models available on the Ollama server.
+func (l *Lister) ListModels(ctx context.Context) ([]catalog.Model, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.endpoint+"/api/tags", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Ollama request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if l.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+l.apiKey)
+	}
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send Ollama request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return nil, errors.Errorf("Ollama returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var tags tagsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, errors.Wrap(err, "failed to decode Ollama response")
+	}
+	models := make([]catalog.Model, 0, len(tags.Models))
+	for _, model := range tags.Models {
+		id := model.Model
+		if id == "" {
+			id = model.Name
+		}
+		models = append(models, catalog.Model{ID: id, DisplayName: model.Name})
+	}
+	return models, nil
+}
+
+// normalizeEndpoint accepts both the server root and the OpenAI-compatible
+// "/v1" base URL that users often copy from other tools.
+func normalizeEndpoint(endpoint string) (string, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return "", errors.Wrap(err, "invalid Ollama endpoint")
+	}
+	endpoint = strings.TrimRight(endpoint, "/")
+	return strings.TrimSuffix(endpoint, "/v1"), nil
+}
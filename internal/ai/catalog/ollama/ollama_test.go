@@ -0,0 +1,109 @@
+package ollama_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/catalog"
+	catalogollama "github.com/usememos/memos/internal/ai/catalog/ollama"
+)
+
+func TestListModels(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/tags", r.URL.Path)
+		require.Empty(t, r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"models": []map[string]any{
+				{"name": "llama3.2:latest", "model": "llama3.2:latest", "size": 2019393189},
+				{"name": "qwen2.5:7b", "size": 4683087332},
+			},
+		}))
+	}))
+	defer server.Close()
+
+	// The OpenAI-compatible "/v1" base URL is accepted and mapped to the native API.
+	lister, err := catalogollama.New(ai.ProviderConfig{
+		Type:     ai.ProviderOllama,
+		Endpoint: server.URL + "/v1/",
+	}, catalog.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	models, err := lister.ListModels(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []catalog.Model{
+		{ID: "llama3.2:latest", DisplayName: "llama3.2:latest"},
+		{ID: "qwen2.5:7b", DisplayName: "qwen2.5:7b"},
+	}, models)
+}
+
+func TestListModelsSendsOptionalAPIKey(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer proxy-key", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"models":[]}`))
+	}))
+	defer server.Close()
+
+	lister, err := catalogollama.New(ai.ProviderConfig{
+		Type:     ai.ProviderOllama,
+		Endpoint: server.URL,
+		APIKey:   "proxy-key",
+	}, catalog.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	models, err := lister.ListModels(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, models)
+}
+
+func TestListModelsReportsServerErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	lister, err := catalogollama.New(ai.ProviderConfig{Type: ai.ProviderOllama, Endpoint: server.URL}, catalog.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	_, err = lister.ListModels(context.Background())
+	require.ErrorContains(t, err, "status 403: forbidden")
+}
+
+func TestListModelsTimesOut(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	lister, err := catalogollama.New(ai.ProviderConfig{Type: ai.ProviderOllama, Endpoint: server.URL},
+		catalog.ApplyOptions([]catalog.ListerOption{catalog.WithTimeout(50 * time.Millisecond)}))
+	require.NoError(t, err)
+
+	_, err = lister.ListModels(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,65 @@
+// Package openai implements catalog.Lister against the OpenAI /models endpoint
+// and any OpenAI-compatible server exposing it (vLLM, LocalAI, LM Studio, ...).
+package openai
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	openaisdk "github.com/openai/openai-go/v3"
+	openaioption "github.com/openai/openai-go/v3/option"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/catalog"
+)
+
+const defaultEndpoint = "https://api.openai.com/v1"
+
+// Lister implements catalog.Lister for OpenAI-compatible endpoints.
+type Lister struct {
+	client openaisdk.Client
+}
+
+// New constructs a Lister from a provider config.
+func New(cfg ai.ProviderConfig, options catalog.Options) (*Lister, error) {
+	endpoint, err := normalizeEndpoint(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.APIKey == "" && cfg.Type != ai.ProviderOpenAICompatible {
+		return nil, errors.New("OpenAI API key is required")
+	}
+	return &Lister{
+		client: openaisdk.NewClient(
+			openaioption.WithAPIKey(cfg.APIKey),
+			openaioption.WithBaseURL(endpoint),
+			openaioption.WithHTTPClient(options.HTTPClient),
+		),
+	}, nil
+}
+
+// ListModels pages through GET /models.
+func (l *Lister) ListModels(ctx context.Context) ([]catalog.Model, error) {
+	var models []catalog.Model
+	iter := l.client.Models.ListAutoPaging(ctx)
+	for iter.Next() {
+		models = append(models, catalog.Model{ID: iter.Current().ID})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to list OpenAI models")
+	}
+	return models, nil
+}
+
+func normalizeEndpoint(endpoint string) (string, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return "", errors.Wrap(err, "invalid OpenAI endpoint")
+	}
+	return strings.TrimRight(endpoint, "/"), nil
+}
@@ -0,0 +1,95 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/catalog"
+	catalogopenai "github.com/usememos/memos/internal/ai/catalog/openai"
+)
+
+func TestListModels(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v1/models", r.URL.Path)
+		require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"object": "list",
+			"data": []map[string]any{
+				{"id": "whisper-1", "object": "model", "created": 1, "owned_by": "openai"},
+				{"id": "gpt-4o-transcribe", "object": "model", "created": 2, "owned_by": "openai"},
+			},
+		}))
+	}))
+	defer server.Close()
+
+	lister, err := catalogopenai.New(ai.ProviderConfig{
+		Type:     ai.ProviderOpenAI,
+		Endpoint: server.URL + "/v1/",
+		APIKey:   "test-key",
+	}, catalog.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	models, err := lister.ListModels(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []catalog.Model{{ID: "whisper-1"}, {ID: "gpt-4o-transcribe"}}, models)
+}
+
+func TestListModelsOpenAICompatibleWithoutAPIKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "env-key")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"object": "list",
+			"data":   []map[string]any{{"id": "local-whisper", "object": "model"}},
+		}))
+	}))
+	defer server.Close()
+
+	lister, err := catalogopenai.New(ai.ProviderConfig{
+		Type:     ai.ProviderOpenAICompatible,
+		Endpoint: server.URL,
+	}, catalog.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	models, err := lister.ListModels(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []catalog.Model{{ID: "local-whisper"}}, models)
+}
+
+func TestListModelsReportsServerErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":{"message":"invalid key"}}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	lister, err := catalogopenai.New(ai.ProviderConfig{
+		Type:     ai.ProviderOpenAI,
+		Endpoint: server.URL,
+		APIKey:   "bad-key",
+	}, catalog.ApplyOptions([]catalog.ListerOption{
+		catalog.WithHTTPClient(&http.Client{Timeout: time.Second}),
+	}))
+	require.NoError(t, err)
+
+	_, err = lister.ListModels(context.Background())
+	require.ErrorContains(t, err, "failed to list OpenAI models")
+}
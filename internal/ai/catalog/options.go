@@ -0,0 +1,48 @@
+package catalog
+
+import (
+	"net/http"
+	"time"
+)
+
+const defaultHTTPTimeout = 30 * time.Second
+
+// Options is the resolved option set passed to provider implementations.
+type Options struct {
+	HTTPClient *http.Client
+	Timeout    time.Duration
+}
+
+// ListerOption customizes a Lister.
+type ListerOption func(*Options)
+
+// WithHTTPClient overrides the HTTP client used by the lister.
+func WithHTTPClient(client *http.Client) ListerOption {
+	return func(o *Options) {
+		if client != nil {
+			o.HTTPClient = client
+		}
+	}
+}
+
+// WithTimeout overrides the per-request timeout of the default HTTP client.
+// A non-positive timeout keeps the default.
+func WithTimeout(timeout time.Duration) ListerOption {
+	return func(o *Options) {
+		if timeout > 0 {
+			o.Timeout = timeout
+		}
+	}
+}
+
+// ApplyOptions resolves a ListerOption slice into Options with defaults.
+func ApplyOptions(opts []ListerOption) Options {
+	resolved := Options{Timeout: defaultHTTPTimeout}
+	for _, apply := range opts {
+		apply(&resolved)
+	}
+	if resolved.HTTPClient == nil {
+		resolved.HTTPClient = &http.Client{Timeout: resolved.Timeout}
+	}
+	return resolved
+}
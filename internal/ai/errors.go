@@ -7,6 +7,8 @@ var (
 	ErrProviderNotFound = errors.New("AI provider not found")
 	// ErrCapabilityUnsupported indicates that the provider does not support the requested capability.
 	ErrCapabilityUnsupported = errors.New("AI provider capability unsupported")
+	// ErrModelRequired indicates that the provider has no built-in model for the capability.
+	ErrModelRequired = errors.New("AI provider model is required")
 	// ErrSTTNotSupported indicates that the provider does not have a dedicated
 	// speech-to-text endpoint. Use the audiollm package for multimodal audio
 	// understanding when this is returned.
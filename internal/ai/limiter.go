@@ -0,0 +1,52 @@
+package ai
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// ProviderLimiter bounds concurrent requests per provider according to
+// ProviderConfig.MaxConcurrentRequests. The zero value is ready to use.
+type ProviderLimiter struct {
+	mu    sync.Mutex
+	slots map[string]*providerSlots
+}
+
+type providerSlots struct {
+	limit int
+	sem   *semaphore.Weighted
+}
+
+// Acquire blocks until the provider has a free request slot or ctx is done.
+// The returned release function must be called once the request finishes.
+func (l *ProviderLimiter) Acquire(ctx context.Context, provider ProviderConfig) (func(), error) {
+	if provider.MaxConcurrentRequests <= 0 {
+		return func() {}, nil
+	}
+
+	sem := l.semaphore(provider.ID, provider.MaxConcurrentRequests)
+	if err := sem.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { sem.Release(1) }, nil
+}
+
+// semaphore returns the semaphore for a provider, replacing it when the
+// configured limit changed. Requests holding the previous semaphore release
+// into it, so a lowered limit only applies to new requests.
+func (l *ProviderLimiter) semaphore(providerID string, limit int) *semaphore.Weighted {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.slots == nil {
+		l.slots = map[string]*providerSlots{}
+	}
+	slots, ok := l.slots[providerID]
+	if !ok || slots.limit != limit {
+		slots = &providerSlots{limit: limit, sem: semaphore.NewWeighted(int64(limit))}
+		l.slots[providerID] = slots
+	}
+	return slots.sem
+}
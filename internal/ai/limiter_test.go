@@ -0,0 +1,48 @@
+package ai_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/ai"
+)
+
+func TestProviderLimiter(t *testing.T) {
+	t.Parallel()
+
+	var limiter ai.ProviderLimiter
+	provider := ai.ProviderConfig{ID: "local", MaxConcurrentRequests: 1}
+
+	release, err := limiter.Acquire(context.Background(), provider)
+	require.NoError(t, err)
+
+	// A second request waits for the slot and gives up with its context.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(ctx, provider)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Other providers are limited independently.
+	releaseOther, err := limiter.Acquire(context.Background(), ai.ProviderConfig{ID: "other", MaxConcurrentRequests: 1})
+	require.NoError(t, err)
+	releaseOther()
+
+	release()
+	release, err = limiter.Acquire(context.Background(), provider)
+	require.NoError(t, err)
+	release()
+}
+
+func TestProviderLimiterUnlimited(t *testing.T) {
+	t.Parallel()
+
+	var limiter ai.ProviderLimiter
+	provider := ai.ProviderConfig{ID: "hosted"}
+	for range 10 {
+		_, err := limiter.Acquire(context.Background(), provider)
+		require.NoError(t, err)
+	}
+}
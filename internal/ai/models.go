@@ -7,15 +7,47 @@ const (
 	DefaultOpenAITranscriptionModel = "whisper-1"
 	// DefaultGeminiTranscriptionModel is the built-in Gemini transcription model.
 	DefaultGeminiTranscriptionModel = "gemini-2.5-flash"
+	// DefaultOpenAIVisionModel is the built-in OpenAI image-analysis model.
+	DefaultOpenAIVisionModel = "gpt-4o-mini"
+	// DefaultGeminiVisionModel is the built-in Gemini image-analysis model.
+	DefaultGeminiVisionModel = "gemini-2.5-flash"
 )
 
+// SupportsTranscription reports whether a provider type can transcribe audio.
+func SupportsTranscription(providerType ProviderType) bool {
+	switch providerType {
+	case ProviderOpenAI, ProviderOpenAICompatible, ProviderGemini:
+		return true
+	default:
+		return false
+	}
+}
+
 // DefaultTranscriptionModel returns the built-in transcription model for a provider.
+// OpenAI-compatible servers host arbitrary models, so they have no default.
 func DefaultTranscriptionModel(providerType ProviderType) (string, error) {
 	switch providerType {
 	case ProviderOpenAI:
 		return DefaultOpenAITranscriptionModel, nil
 	case ProviderGemini:
 		return DefaultGeminiTranscriptionModel, nil
+	case ProviderOpenAICompatible:
+		return "", errors.Wrapf(ErrModelRequired, "provider type %q", providerType)
+	default:
+		return "", errors.Wrapf(ErrCapabilityUnsupported, "provider type %q", providerType)
+	}
+}
+
+// DefaultVisionModel returns the built-in image-analysis model for a provider.
+// Self-hosted servers host arbitrary models, so they have no default.
+func DefaultVisionModel(providerType ProviderType) (string, error) {
+	switch providerType {
+	case ProviderOpenAI:
+		return DefaultOpenAIVisionModel, nil
+	case ProviderGemini:
+		return DefaultGeminiVisionModel, nil
+	case ProviderOpenAICompatible, ProviderOllama:
+		return "", errors.Wrapf(ErrModelRequired, "provider type %q", providerType)
 	default:
 		return "", errors.Wrapf(ErrCapabilityUnsupported, "provider type %q", providerType)
 	}
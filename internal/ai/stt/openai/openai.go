@@ -20,6 +20,8 @@ import (
 const defaultEndpoint = "https://api.openai.com/v1"
 
 // Transcriber implements stt.Transcriber for OpenAI-compatible STT endpoints.
+// The API key is optional for ai.ProviderOpenAICompatible, in which case no
+// Authorization header is sent.
 type Transcriber struct {
 	client openaisdk.Client
 }
@@ -30,7 +32,7 @@ func New(cfg ai.ProviderConfig, options stt.Options) (*Transcriber, error) {
 	if err != nil {
 		return nil, err
 	}
-	if cfg.APIKey == "" {
+	if cfg.APIKey == "" && cfg.Type != ai.ProviderOpenAICompatible {
 		return nil, errors.New("OpenAI API key is required")
 	}
 	return &Transcriber{
@@ -67,15 +69,34 @@ func (t *Transcriber) Transcribe(ctx context.Context, req stt.Request) (*stt.Res
 	if req.Language != "" {
 		params.Language = openaisdk.String(req.Language)
 	}
+	if req.Timestamps && supportsVerboseJSON(req.Model) {
+		params.ResponseFormat = openaisdk.AudioResponseFormatVerboseJSON
+		params.TimestampGranularities = []string{"segment"}
+	}
 
 	resp, err := t.client.Audio.Transcriptions.New(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to send OpenAI transcription request")
 	}
-	return &stt.Response{
+	response := &stt.Response{
 		Text:     resp.Text,
 		Language: resp.Language,
-	}, nil
+	}
+	for _, segment := range resp.Segments {
+		response.Segments = append(response.Segments, stt.Segment{
+			Text:  strings.TrimSpace(segment.Text),
+			Start: segment.Start,
+			End:   segment.End,
+		})
+	}
+	return response, nil
+}
+
+// supportsVerboseJSON reports whether the model accepts the verbose_json
+// response format. OpenAI's gpt-4o transcription models only return json;
+// whisper-1 and Whisper-compatible third-party models return segments.
+func supportsVerboseJSON(model string) bool {
+	return !strings.HasPrefix(strings.ToLower(strings.TrimSpace(model)), "gpt-4o")
 }
 
 func normalizeEndpoint(endpoint string) (string, error) {
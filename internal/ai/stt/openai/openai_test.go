@@ -66,3 +66,115 @@ func TestTranscribe(t *testing.T) {
 	require.Equal(t, "en", response.Language)
 	// Note: Duration intentionally omitted from stt.Response — not exposed in the new contract.
 }
+
+func TestTranscribeWithTimestamps(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(10<<20))
+		require.Equal(t, "whisper-1", r.FormValue("model"))
+		require.Equal(t, "verbose_json", r.FormValue("response_format"))
+		require.Equal(t, []string{"segment"}, r.MultipartForm.Value["timestamp_granularities[]"])
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"text":     "hello world",
+			"language": "english",
+			"duration": 2.0,
+			"segments": []map[string]any{
+				{"id": 0, "start": 0.0, "end": 1.0, "text": " hello"},
+				{"id": 1, "start": 1.0, "end": 2.0, "text": " world"},
+			},
+		}))
+	}))
+	defer server.Close()
+
+	transcriber, err := sttopenai.New(ai.ProviderConfig{
+		Type:     ai.ProviderOpenAI,
+		Endpoint: server.URL,
+		APIKey:   "test-key",
+	}, stt.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := transcriber.Transcribe(ctx, stt.Request{
+		Model:      "whisper-1",
+		Filename:   "voice.wav",
+		Audio:      strings.NewReader("RIFF"),
+		Timestamps: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "hello world", response.Text)
+	require.Equal(t, []stt.Segment{
+		{Text: "hello", Start: 0, End: 1},
+		{Text: "world", Start: 1, End: 2},
+	}, response.Segments)
+}
+
+func TestTranscribeWithTimestampsKeepsJSONForGPT4o(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(10<<20))
+		require.Equal(t, "json", r.FormValue("response_format"))
+		require.Empty(t, r.MultipartForm.Value["timestamp_granularities[]"])
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"text": "hello world"}))
+	}))
+	defer server.Close()
+
+	transcriber, err := sttopenai.New(ai.ProviderConfig{
+		Type:     ai.ProviderOpenAI,
+		Endpoint: server.URL,
+		APIKey:   "test-key",
+	}, stt.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := transcriber.Transcribe(ctx, stt.Request{
+		Model:      "gpt-4o-transcribe",
+		Audio:      strings.NewReader("RIFF"),
+		Timestamps: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "hello world", response.Text)
+	require.Empty(t, response.Segments)
+}
+
+func TestTranscribeOpenAICompatibleWithoutAPIKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "env-key")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/audio/transcriptions", r.URL.Path)
+		require.Empty(t, r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"text": "local transcript"}))
+	}))
+	defer server.Close()
+
+	transcriber, err := sttopenai.New(ai.ProviderConfig{
+		Type:     ai.ProviderOpenAICompatible,
+		Endpoint: server.URL + "/v1",
+	}, stt.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := transcriber.Transcribe(ctx, stt.Request{
+		Model: "Systran/faster-whisper-small",
+		Audio: strings.NewReader("RIFF"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "local transcript", response.Text)
+}
+
+func TestNewRequiresAPIKeyForOpenAI(t *testing.T) {
+	t.Parallel()
+
+	_, err := sttopenai.New(ai.ProviderConfig{Type: ai.ProviderOpenAI}, stt.ApplyOptions(nil))
+	require.ErrorContains(t, err, "API key is required")
+}
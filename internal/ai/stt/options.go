@@ -10,6 +10,7 @@ const defaultHTTPTimeout = 2 * time.Minute
 // Options is the resolved option set passed to provider implementations.
 type Options struct {
 	HTTPClient *http.Client
+	Timeout    time.Duration
 }
 
 // TranscriberOption customizes a Transcriber.
@@ -24,11 +25,24 @@ func WithHTTPClient(client *http.Client) TranscriberOption {
 	}
 }
 
+// WithTimeout overrides the per-request timeout of the default HTTP client.
+// A non-positive timeout keeps the default.
+func WithTimeout(timeout time.Duration) TranscriberOption {
+	return func(o *Options) {
+		if timeout > 0 {
+			o.Timeout = timeout
+		}
+	}
+}
+
 // ApplyOptions resolves a TranscriberOption slice into Options with defaults.
 func ApplyOptions(opts []TranscriberOption) Options {
-	resolved := Options{HTTPClient: &http.Client{Timeout: defaultHTTPTimeout}}
+	resolved := Options{Timeout: defaultHTTPTimeout}
 	for _, apply := range opts {
 		apply(&resolved)
 	}
+	if resolved.HTTPClient == nil {
+		resolved.HTTPClient = &http.Client{Timeout: resolved.Timeout}
+	}
 	return resolved
 }
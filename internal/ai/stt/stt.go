@@ -23,6 +23,9 @@ type Request struct {
 	Model       string // provider-specific model id (e.g. "whisper-1", "gpt-4o-transcribe")
 	Prompt      string // soft spelling/vocabulary hint (Whisper "prompt" parameter)
 	Language    string // ISO 639-1, optional
+	// Timestamps requests segment timestamps. Models that cannot return them
+	// ignore the flag and respond without segments.
+	Timestamps bool
 }
 
 // Response is the output of a transcription call.
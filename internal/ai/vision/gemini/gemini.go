@@ -0,0 +1,122 @@
+// Package gemini implements vision.Analyzer against the Gemini generateContent
+// endpoint with the image sent inline.
+package gemini
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genai"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/vision"
+)
+
+const (
+	defaultEndpoint   = "https://generativelanguage.googleapis.com/v1beta"
+	defaultAPIVersion = "v1beta"
+	maxInlineSize     = 14 * 1024 * 1024
+)
+
+var supportedContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/webp": true,
+	"image/heic": true,
+	"image/heif": true,
+}
+
+// Analyzer implements vision.Analyzer for Gemini generateContent.
+type Analyzer struct {
+	client *genai.Client
+}
+
+// New constructs an Analyzer from a provider config.
+func New(cfg ai.ProviderConfig, options vision.Options) (*Analyzer, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("Gemini API key is required")
+	}
+	baseURL, apiVersion, err := splitEndpoint(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	httpOptions := genai.HTTPOptions{BaseURL: baseURL, APIVersion: apiVersion}
+	if options.HTTPClient != nil && options.HTTPClient.Timeout > 0 {
+		timeout := options.HTTPClient.Timeout
+		httpOptions.Timeout = &timeout
+	}
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey:      cfg.APIKey,
+		Backend:     genai.BackendGeminiAPI,
+		HTTPClient:  options.HTTPClient,
+		HTTPOptions: httpOptions,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Gemini client")
+	}
+	return &Analyzer{client: client}, nil
+}
+
+// AnalyzeImage calls Gemini generateContent with the image attached and a JSON
+// response type.
+func (a *Analyzer) AnalyzeImage(ctx context.Context, req vision.Request) (*vision.Response, error) {
+	if strings.TrimSpace(req.Model) == "" {
+		return nil, errors.New("model is required")
+	}
+	if req.Image == nil {
+		return nil, errors.New("image is required")
+	}
+	mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(req.ContentType))
+	if err != nil || !supportedContentTypes[strings.ToLower(mediaType)] {
+		return nil, errors.Errorf("image content type %q is not supported by Gemini", req.ContentType)
+	}
+	image, err := io.ReadAll(req.Image)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read image")
+	}
+	if len(image) > maxInlineSize {
+		return nil, errors.Errorf("image is too large for Gemini inline request; maximum size is %d bytes", maxInlineSize)
+	}
+
+	resp, err := a.client.Models.GenerateContent(ctx, strings.TrimPrefix(strings.TrimSpace(req.Model), "models/"), []*genai.Content{
+		genai.NewContentFromParts([]*genai.Part{
+			genai.NewPartFromBytes(image, strings.ToLower(mediaType)),
+			genai.NewPartFromText(vision.BuildInstructions(req.Language)),
+		}, genai.RoleUser),
+	}, &genai.GenerateContentConfig{ResponseMIMEType: "application/json"})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send Gemini request")
+	}
+	if len(resp.Candidates) == 0 || resp.Candidates[0].FinishReason != genai.FinishReasonStop {
+		return nil, errors.New("image analysis incomplete")
+	}
+	return vision.ParseModelResponse(resp.Text())
+}
+
+func splitEndpoint(endpoint string) (string, string, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return "", "", errors.Wrap(err, "invalid Gemini endpoint")
+	}
+	parsed, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil {
+		return "", "", errors.Wrap(err, "invalid Gemini endpoint")
+	}
+	path := strings.TrimRight(parsed.Path, "/")
+	apiVersion := defaultAPIVersion
+	for _, supported := range []string{"v1alpha", "v1beta", "v1"} {
+		if path == "/"+supported || strings.HasSuffix(path, "/"+supported) {
+			apiVersion = supported
+			parsed.Path = strings.TrimSuffix(path, "/"+supported)
+			break
+		}
+	}
+	return strings.TrimRight(parsed.String(), "/"), apiVersion, nil
+}
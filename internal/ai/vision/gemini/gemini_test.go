@@ -0,0 +1,69 @@
+package gemini_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/vision"
+	visiongemini "github.com/usememos/memos/internal/ai/vision/gemini"
+)
+
+func TestAnalyzeImage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1beta/models/gemini-2.5-flash:generateContent", r.URL.Path)
+
+		var request struct {
+			Contents []struct {
+				Parts []struct {
+					InlineData *struct {
+						MIMEType string `json:"mimeType"`
+					} `json:"inlineData"`
+				} `json:"parts"`
+			} `json:"contents"`
+			GenerationConfig struct {
+				ResponseMIMEType string `json:"responseMimeType"`
+			} `json:"generationConfig"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		require.Equal(t, "application/json", request.GenerationConfig.ResponseMIMEType)
+		require.Equal(t, "image/jpeg", request.Contents[0].Parts[0].InlineData.MIMEType)
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"candidates": []map[string]any{{
+				"finishReason": "STOP",
+				"content": map[string]any{
+					"parts": []map[string]string{{"text": `{"text":"EXIT","caption":"A green exit sign."}`}},
+				},
+			}},
+		}))
+	}))
+	defer server.Close()
+
+	analyzer, err := visiongemini.New(ai.ProviderConfig{
+		Type:     ai.ProviderGemini,
+		Endpoint: server.URL + "/v1beta",
+		APIKey:   "test-key",
+	}, vision.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := analyzer.AnalyzeImage(ctx, vision.Request{
+		Image:       strings.NewReader("jpeg bytes"),
+		ContentType: "image/jpeg",
+		Model:       "gemini-2.5-flash",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &vision.Response{Text: "EXIT", Caption: "A green exit sign."}, resp)
+}
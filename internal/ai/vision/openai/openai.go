@@ -0,0 +1,104 @@
+// Package openai implements vision.Analyzer against the OpenAI chat completions
+// endpoint with image input. The same endpoint is served by OpenAI-compatible
+// servers and by Ollama under /v1, so all three provider types use it.
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/url"
+	"strings"
+
+	openaisdk "github.com/openai/openai-go/v3"
+	openaioption "github.com/openai/openai-go/v3/option"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/vision"
+)
+
+const defaultEndpoint = "https://api.openai.com/v1"
+
+// Analyzer implements vision.Analyzer for OpenAI-compatible chat endpoints.
+type Analyzer struct {
+	client openaisdk.Client
+}
+
+// New constructs an Analyzer from a provider config.
+func New(cfg ai.ProviderConfig, options vision.Options) (*Analyzer, error) {
+	endpoint, err := normalizeEndpoint(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.APIKey == "" && cfg.Type.RequiresAPIKey() {
+		return nil, errors.New("OpenAI API key is required")
+	}
+	return &Analyzer{
+		client: openaisdk.NewClient(
+			openaioption.WithAPIKey(cfg.APIKey),
+			openaioption.WithBaseURL(endpoint),
+			openaioption.WithHTTPClient(options.HTTPClient),
+		),
+	}, nil
+}
+
+// AnalyzeImage sends the image inline as a data URL and decodes the JSON reply.
+func (a *Analyzer) AnalyzeImage(ctx context.Context, req vision.Request) (*vision.Response, error) {
+	if strings.TrimSpace(req.Model) == "" {
+		return nil, errors.New("model is required")
+	}
+	if req.Image == nil {
+		return nil, errors.New("image is required")
+	}
+	mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(req.ContentType))
+	if err != nil || !strings.HasPrefix(mediaType, "image/") {
+		return nil, errors.Errorf("image content type %q is not supported", req.ContentType)
+	}
+	image, err := io.ReadAll(req.Image)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read image")
+	}
+
+	dataURL := "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(image)
+	resp, err := a.client.Chat.Completions.New(ctx, openaisdk.ChatCompletionNewParams{
+		Model: openaisdk.ChatModel(req.Model),
+		Messages: []openaisdk.ChatCompletionMessageParamUnion{
+			openaisdk.UserMessage([]openaisdk.ChatCompletionContentPartUnionParam{
+				openaisdk.TextContentPart(vision.BuildInstructions(req.Language)),
+				openaisdk.ImageContentPart(openaisdk.ChatCompletionContentPartImageImageURLParam{URL: dataURL}),
+			}),
+		},
+		ResponseFormat: openaisdk.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONObject: &openaisdk.ResponseFormatJSONObjectParam{},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send OpenAI image analysis request")
+	}
+	if len(resp.Choices) == 0 {
+		return nil, errors.New("image analysis response did not include a choice")
+	}
+	if reason := resp.Choices[0].FinishReason; reason != "stop" {
+		return nil, errors.Errorf("image analysis incomplete (finish reason: %s)", reason)
+	}
+	return vision.ParseModelResponse(resp.Choices[0].Message.Content)
+}
+
+// normalizeEndpoint resolves the chat completions base URL. Ollama endpoints
+// point at the server root, where the OpenAI-compatible API lives under /v1.
+func normalizeEndpoint(cfg ai.ProviderConfig) (string, error) {
+	endpoint := strings.TrimSpace(cfg.Endpoint)
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return "", errors.Wrap(err, "invalid OpenAI endpoint")
+	}
+	endpoint = strings.TrimRight(endpoint, "/")
+	if cfg.Type == ai.ProviderOllama && !strings.HasSuffix(endpoint, "/v1") {
+		endpoint += "/v1"
+	}
+	return endpoint, nil
+}
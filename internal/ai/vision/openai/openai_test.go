@@ -0,0 +1,128 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/vision"
+	visionopenai "github.com/usememos/memos/internal/ai/vision/openai"
+)
+
+func newChatServer(t *testing.T, wantPath string, content string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, wantPath, r.URL.Path)
+
+		var request struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Role    string `json:"role"`
+				Content []struct {
+					Type     string `json:"type"`
+					Text     string `json:"text"`
+					ImageURL struct {
+						URL string `json:"url"`
+					} `json:"image_url"`
+				} `json:"content"`
+			} `json:"messages"`
+			ResponseFormat struct {
+				Type string `json:"type"`
+			} `json:"response_format"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		require.Equal(t, "gpt-4o-mini", request.Model)
+		require.Equal(t, "json_object", request.ResponseFormat.Type)
+		require.Len(t, request.Messages, 1)
+		require.Len(t, request.Messages[0].Content, 2)
+		require.Contains(t, request.Messages[0].Content[0].Text, `"caption"`)
+		require.Equal(t, "image_url", request.Messages[0].Content[1].Type)
+		// "png bytes" in base64.
+		require.Equal(t, "data:image/png;base64,cG5nIGJ5dGVz", request.Messages[0].Content[1].ImageURL.URL)
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"id":     "chatcmpl-1",
+			"object": "chat.completion",
+			"model":  request.Model,
+			"choices": []map[string]any{{
+				"index":         0,
+				"finish_reason": "stop",
+				"message":       map[string]any{"role": "assistant", "content": content},
+			}},
+		}))
+	}))
+}
+
+func TestAnalyzeImage(t *testing.T) {
+	t.Parallel()
+
+	server := newChatServer(t, "/chat/completions", `{"text":"Q3 roadmap\n- ship search","caption":"A whiteboard listing Q3 goals."}`)
+	defer server.Close()
+
+	analyzer, err := visionopenai.New(ai.ProviderConfig{
+		Type:     ai.ProviderOpenAI,
+		Endpoint: server.URL,
+		APIKey:   "test-key",
+	}, vision.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := analyzer.AnalyzeImage(ctx, vision.Request{
+		Image:       strings.NewReader("png bytes"),
+		ContentType: "image/png",
+		Model:       "gpt-4o-mini",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Q3 roadmap\n- ship search", resp.Text)
+	require.Equal(t, "A whiteboard listing Q3 goals.", resp.Caption)
+}
+
+func TestAnalyzeImageWithOllama(t *testing.T) {
+	t.Parallel()
+
+	server := newChatServer(t, "/v1/chat/completions", "```json\n{\"text\":\"\",\"caption\":\"A cat.\"}\n```")
+	defer server.Close()
+
+	analyzer, err := visionopenai.New(ai.ProviderConfig{
+		Type:     ai.ProviderOllama,
+		Endpoint: server.URL,
+	}, vision.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	resp, err := analyzer.AnalyzeImage(context.Background(), vision.Request{
+		Image:       strings.NewReader("png bytes"),
+		ContentType: "image/png",
+		Model:       "gpt-4o-mini",
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Text)
+	require.Equal(t, "A cat.", resp.Caption)
+}
+
+func TestAnalyzeImageRejectsNonImages(t *testing.T) {
+	t.Parallel()
+
+	analyzer, err := visionopenai.New(ai.ProviderConfig{
+		Type:     ai.ProviderOpenAI,
+		Endpoint: "https://example.com/v1",
+		APIKey:   "test-key",
+	}, vision.ApplyOptions(nil))
+	require.NoError(t, err)
+
+	_, err = analyzer.AnalyzeImage(context.Background(), vision.Request{
+		Image:       strings.NewReader("%PDF"),
+		ContentType: "application/pdf",
+		Model:       "gpt-4o-mini",
+	})
+	require.ErrorContains(t, err, "not supported")
+}
@@ -0,0 +1,48 @@
+package vision
+
+import (
+	"net/http"
+	"time"
+)
+
+const defaultHTTPTimeout = 2 * time.Minute
+
+// Options is the resolved option set passed to provider implementations.
+type Options struct {
+	HTTPClient *http.Client
+	Timeout    time.Duration
+}
+
+// AnalyzerOption customizes an Analyzer.
+type AnalyzerOption func(*Options)
+
+// WithHTTPClient overrides the HTTP client used by the analyzer.
+func WithHTTPClient(client *http.Client) AnalyzerOption {
+	return func(o *Options) {
+		if client != nil {
+			o.HTTPClient = client
+		}
+	}
+}
+
+// WithTimeout overrides the per-request timeout of the default HTTP client.
+// A non-positive timeout keeps the default.
+func WithTimeout(timeout time.Duration) AnalyzerOption {
+	return func(o *Options) {
+		if timeout > 0 {
+			o.Timeout = timeout
+		}
+	}
+}
+
+// ApplyOptions resolves an AnalyzerOption slice into Options with defaults.
+func ApplyOptions(opts []AnalyzerOption) Options {
+	resolved := Options{Timeout: defaultHTTPTimeout}
+	for _, apply := range opts {
+		apply(&resolved)
+	}
+	if resolved.HTTPClient == nil {
+		resolved.HTTPClient = &http.Client{Timeout: resolved.Timeout}
+	}
+	return resolved
+}
@@ -0,0 +1,64 @@
+// Package tesseract implements vision.Analyzer with the tesseract OCR command
+// line tool. It runs entirely on the server, so images never leave the network,
+// but it recognizes text only and leaves the caption empty.
+package tesseract
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/ai/vision"
+)
+
+// DefaultCommand is the tesseract executable looked up on PATH.
+const DefaultCommand = "tesseract"
+
+// languagePattern matches tesseract language codes such as "eng" or "chi_sim+eng".
+var languagePattern = regexp.MustCompile(`^[A-Za-z_]+(\+[A-Za-z_]+)*$`)
+
+// Analyzer implements vision.Analyzer by running tesseract.
+type Analyzer struct {
+	command string
+}
+
+// New constructs an Analyzer running command, or DefaultCommand when empty.
+// It fails when the command cannot be found.
+func New(command string) (*Analyzer, error) {
+	if command == "" {
+		command = DefaultCommand
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil, errors.Wrapf(err, "OCR engine %q is not installed", command)
+	}
+	return &Analyzer{command: path}, nil
+}
+
+// AnalyzeImage pipes the image through "tesseract stdin stdout".
+func (a *Analyzer) AnalyzeImage(ctx context.Context, req vision.Request) (*vision.Response, error) {
+	if req.Image == nil {
+		return nil, errors.New("image is required")
+	}
+	args := []string{"stdin", "stdout"}
+	if language := strings.TrimSpace(req.Language); language != "" {
+		if !languagePattern.MatchString(language) {
+			return nil, errors.Errorf("invalid tesseract language %q", language)
+		}
+		args = append(args, "-l", language)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, a.command, args...)
+	cmd.Stdin = req.Image
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "tesseract failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return &vision.Response{Text: strings.TrimSpace(stdout.String())}, nil
+}
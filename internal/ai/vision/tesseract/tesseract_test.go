@@ -0,0 +1,73 @@
+package tesseract_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/ai/vision"
+	"github.com/usememos/memos/internal/ai/vision/tesseract"
+)
+
+// writeFakeTesseract installs a shell script that echoes its arguments and
+// input, standing in for the real binary.
+func writeFakeTesseract(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake tesseract requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "tesseract")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func TestAnalyzeImage(t *testing.T) {
+	t.Parallel()
+
+	command := writeFakeTesseract(t, `echo "args: $*"; cat`)
+	analyzer, err := tesseract.New(command)
+	require.NoError(t, err)
+
+	resp, err := analyzer.AnalyzeImage(context.Background(), vision.Request{
+		Image:    strings.NewReader("WHITEBOARD TEXT\n"),
+		Language: "eng+deu",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "args: stdin stdout -l eng+deu\nWHITEBOARD TEXT", resp.Text)
+	require.Empty(t, resp.Caption)
+}
+
+func TestAnalyzeImageRejectsInvalidLanguage(t *testing.T) {
+	t.Parallel()
+
+	analyzer, err := tesseract.New(writeFakeTesseract(t, "cat"))
+	require.NoError(t, err)
+
+	_, err = analyzer.AnalyzeImage(context.Background(), vision.Request{
+		Image:    strings.NewReader("image"),
+		Language: "eng --tessdata-dir /etc",
+	})
+	require.ErrorContains(t, err, "invalid tesseract language")
+}
+
+func TestAnalyzeImageReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	analyzer, err := tesseract.New(writeFakeTesseract(t, "echo 'Error: unsupported image' >&2; exit 1"))
+	require.NoError(t, err)
+
+	_, err = analyzer.AnalyzeImage(context.Background(), vision.Request{Image: strings.NewReader("image")})
+	require.ErrorContains(t, err, "unsupported image")
+}
+
+func TestNewRequiresInstalledCommand(t *testing.T) {
+	t.Parallel()
+
+	_, err := tesseract.New(filepath.Join(t.TempDir(), "missing-tesseract"))
+	require.ErrorContains(t, err, "is not installed")
+}
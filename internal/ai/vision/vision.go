@@ -0,0 +1,69 @@
+// Package vision defines the image-analysis capability: recognizing the text in
+// an image (OCR) and describing it in one sentence for use as alt text.
+// Implementations either call a vision-capable AI model or run a local OCR
+// engine; local engines only fill Response.Text.
+package vision
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Analyzer extracts text and a caption from an image.
+type Analyzer interface {
+	AnalyzeImage(ctx context.Context, req Request) (*Response, error)
+}
+
+// Request is the input to an image-analysis call.
+type Request struct {
+	Image       io.Reader
+	Size        int64
+	ContentType string // IANA media type, e.g. "image/png"
+	Model       string // provider-specific model id; ignored by local engines
+	Language    string // optional language hint
+}
+
+// Response is the output of an image-analysis call.
+type Response struct {
+	Text    string // recognized text, empty if the image contains none
+	Caption string // short description, empty for OCR-only engines
+}
+
+// BuildInstructions returns the prompt sent to vision models. The model is
+// asked for a JSON object so the text and caption can be told apart reliably.
+func BuildInstructions(language string) string {
+	parts := []string{
+		"Analyze the image and respond with a JSON object with two string fields. " +
+			`"text": all text visible in the image, transcribed verbatim with line breaks preserved, or "" if there is none. ` +
+			`"caption": one sentence describing the image for someone who cannot see it, suitable as alt text.`,
+	}
+	if language = strings.TrimSpace(language); language != "" {
+		parts = append(parts, "Write the caption in the language "+language+".")
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ParseModelResponse decodes the JSON object requested by BuildInstructions.
+// Markdown code fences that some models wrap around JSON are tolerated.
+func ParseModelResponse(raw string) (*Response, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "```json")
+	raw = strings.TrimPrefix(raw, "```")
+	raw = strings.TrimSuffix(raw, "```")
+
+	var decoded struct {
+		Text    string `json:"text"`
+		Caption string `json:"caption"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &decoded); err != nil {
+		return nil, errors.Wrap(err, "failed to decode image analysis response")
+	}
+	return &Response{
+		Text:    strings.TrimSpace(decoded.Text),
+		Caption: strings.TrimSpace(decoded.Caption),
+	}, nil
+}
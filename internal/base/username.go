@@ -1,8 +1,19 @@
 package base
 
+import "strings"
+
 // MaxUsernameLength is the maximum number of ASCII characters in a writable username.
 const MaxUsernameLength = 36
 
+// HereMention is the username of the @here mention, which notifies everyone
+// taking part in a memo thread. It is reserved and cannot be registered.
+const HereMention = "here"
+
+// IsHereMention reports whether a mentioned username is the @here mention.
+func IsHereMention(username string) bool {
+	return strings.EqualFold(username, HereMention)
+}
+
 // IsValidUsername reports whether username satisfies the writable username format.
 func IsValidUsername(username string) bool {
 	if len(username) == 0 || len(username) > MaxUsernameLength || !isASCIIAlphanumeric(username[0]) || !isASCIIAlphanumeric(username[len(username)-1]) {
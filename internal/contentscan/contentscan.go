@@ -0,0 +1,179 @@
+// Package contentscan checks uploaded content for malware, either with a
+// ClamAV daemon or with an HTTP scanning endpoint.
+package contentscan
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Result is the verdict of a scan.
+type Result struct {
+	Infected bool
+	// Signature names the detected malware; empty for clean content.
+	Signature string
+}
+
+// Scanner checks content for malware.
+type Scanner interface {
+	// Name identifies the scanner in recorded scan results.
+	Name() string
+	Scan(ctx context.Context, content io.Reader) (*Result, error)
+}
+
+const (
+	// clamdChunkSize is the size of the chunks streamed to clamd.
+	clamdChunkSize = 64 * 1024
+	// maxResponseBytes bounds the verdict read from a scanner.
+	maxResponseBytes = 64 * 1024
+)
+
+// Clamd scans content with a ClamAV daemon using the INSTREAM command.
+type Clamd struct {
+	network string
+	address string
+}
+
+// NewClamd creates a clamd client. The address is "host:port" for TCP, or
+// "unix:" followed by the path of the daemon socket.
+func NewClamd(address string) *Clamd {
+	if path, ok := strings.CutPrefix(address, "unix:"); ok {
+		return &Clamd{network: "unix", address: path}
+	}
+	return &Clamd{network: "tcp", address: address}
+}
+
+func (*Clamd) Name() string {
+	return "clamd"
+}
+
+func (c *Clamd) Scan(ctx context.Context, content io.Reader) (*Result, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to clamd")
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, errors.Wrap(err, "failed to set clamd deadline")
+		}
+	}
+
+	// The content is sent in length-prefixed chunks and terminated by an
+	// empty chunk.
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, errors.Wrap(err, "failed to send clamd command")
+	}
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, readErr := io.ReadFull(content, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				// clamd closes the connection when the stream exceeds its
+				// size limit; its reply explains why.
+				break
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+				return nil, errors.Wrap(err, "failed to finish clamd stream")
+			}
+			break
+		}
+		if readErr != nil {
+			return nil, errors.Wrap(readErr, "failed to read content")
+		}
+	}
+
+	response, err := io.ReadAll(io.LimitReader(conn, maxResponseBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read clamd response")
+	}
+	return parseClamdResponse(string(response))
+}
+
+// parseClamdResponse parses replies such as "stream: OK" and
+// "stream: Eicar-Signature FOUND".
+func parseClamdResponse(response string) (*Result, error) {
+	response = strings.TrimSpace(strings.TrimRight(response, "\x00"))
+	verdict := response
+	if _, after, ok := strings.Cut(response, ": "); ok {
+		verdict = after
+	}
+	switch {
+	case verdict == "OK":
+		return &Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &Result{Infected: true, Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	default:
+		return nil, errors.Errorf("clamd failed: %s", response)
+	}
+}
+
+// HTTP scans content by posting it to an endpoint that answers with a JSON
+// verdict: {"infected": true, "signature": "Eicar-Signature"}.
+type HTTP struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// NewHTTP creates a client for an HTTP scanning endpoint. A non-empty token is
+// sent as a bearer token.
+func NewHTTP(endpoint, token string) *HTTP {
+	return &HTTP{
+		endpoint: endpoint,
+		token:    token,
+		client:   &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+func (*HTTP) Name() string {
+	return "http"
+}
+
+func (h *HTTP) Scan(ctx context.Context, content io.Reader) (*Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint, content)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create scan request")
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send scan request")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read scan response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("scanner responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(bytes.ToValidUTF8(body, nil))))
+	}
+	var verdict struct {
+		Infected  *bool  `json:"infected"`
+		Signature string `json:"signature"`
+	}
+	if err := json.Unmarshal(body, &verdict); err != nil {
+		return nil, errors.Wrap(err, "failed to parse scan response")
+	}
+	if verdict.Infected == nil {
+		return nil, errors.New("scan response has no verdict")
+	}
+	return &Result{Infected: *verdict.Infected, Signature: verdict.Signature}, nil
+}
@@ -0,0 +1,186 @@
+package contentscan_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/contentscan"
+)
+
+// fakeClamd accepts one INSTREAM session, hands the received content to
+// verdict and writes back its reply.
+func fakeClamd(t *testing.T, verdict func(content []byte) string) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		command := make([]byte, len("zINSTREAM\x00"))
+		if _, err := io.ReadFull(conn, command); err != nil || string(command) != "zINSTREAM\x00" {
+			conn.Write([]byte("UNKNOWN COMMAND\x00"))
+			return
+		}
+		var content bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&content, conn, int64(size)); err != nil {
+				return
+			}
+		}
+		conn.Write([]byte(verdict(content.Bytes()) + "\x00"))
+	}()
+	return listener.Addr().String()
+}
+
+func TestClamdScan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		reply     string
+		infected  bool
+		signature string
+		wantErr   string
+	}{
+		{name: "clean", reply: "stream: OK"},
+		{name: "infected", reply: "stream: Eicar-Signature FOUND", infected: true, signature: "Eicar-Signature"},
+		{name: "error", reply: "INSTREAM size limit exceeded. ERROR", wantErr: "size limit exceeded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Content spanning several chunks arrives intact.
+			content := strings.Repeat("memos", 30000)
+			var received string
+			address := fakeClamd(t, func(data []byte) string {
+				received = string(data)
+				return tt.reply
+			})
+
+			result, err := contentscan.NewClamd(address).Scan(context.Background(), strings.NewReader(content))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, content, received)
+			require.Equal(t, tt.infected, result.Infected)
+			require.Equal(t, tt.signature, result.Signature)
+		})
+	}
+}
+
+func TestClamdUnreachable(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	_, err = contentscan.NewClamd(address).Scan(context.Background(), strings.NewReader("memos"))
+	require.ErrorContains(t, err, "failed to connect to clamd")
+}
+
+func TestClamdTimeout(t *testing.T) {
+	t.Parallel()
+
+	// A daemon that never answers.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(io.Discard, conn)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err = contentscan.NewClamd(listener.Addr().String()).Scan(ctx, strings.NewReader("memos"))
+	require.Error(t, err)
+}
+
+func TestHTTPScan(t *testing.T) {
+	t.Parallel()
+
+	var authorization, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		if strings.Contains(body, "EICAR") {
+			w.Write([]byte(`{"infected": true, "signature": "Eicar-Signature"}`))
+			return
+		}
+		w.Write([]byte(`{"infected": false}`))
+	}))
+	t.Cleanup(server.Close)
+
+	scanner := contentscan.NewHTTP(server.URL, "secret")
+	result, err := scanner.Scan(context.Background(), strings.NewReader("hello"))
+	require.NoError(t, err)
+	require.False(t, result.Infected)
+	require.Equal(t, "Bearer secret", authorization)
+	require.Equal(t, "hello", body)
+
+	result, err = scanner.Scan(context.Background(), strings.NewReader("EICAR test"))
+	require.NoError(t, err)
+	require.True(t, result.Infected)
+	require.Equal(t, "Eicar-Signature", result.Signature)
+}
+
+func TestHTTPScanFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "server error", status: http.StatusInternalServerError, body: "boom", wantErr: "status 500"},
+		{name: "invalid json", status: http.StatusOK, body: "clean", wantErr: "failed to parse"},
+		{name: "missing verdict", status: http.StatusOK, body: `{"signature": ""}`, wantErr: "no verdict"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(server.Close)
+
+			_, err := contentscan.NewHTTP(server.URL, "").Scan(context.Background(), strings.NewReader("memos"))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
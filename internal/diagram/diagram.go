@@ -0,0 +1,306 @@
+// Package diagram renders diagram code blocks to SVG and caches the result
+// on disk by content hash. Graphviz DOT graphs are laid out natively; Mermaid
+// and PlantUML rely on their command line tools, which run only when enabled
+// and are unsupported when not installed.
+package diagram
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// CacheFolder is the folder under the data directory that holds rendered
+	// diagrams.
+	CacheFolder = ".diagram_cache"
+	// DefaultCacheMaxBytes bounds the size of the diagram cache when the
+	// config leaves it unset.
+	DefaultCacheMaxBytes = 256 << 20
+
+	// DefaultMermaidCommand is the Mermaid CLI looked up on PATH.
+	DefaultMermaidCommand = "mmdc"
+	// DefaultPlantUMLCommand is the PlantUML launcher looked up on PATH.
+	DefaultPlantUMLCommand = "plantuml"
+	// PlantUMLSecurityProfile keeps PlantUML from reading local files and
+	// URLs through !include and similar directives, since diagrams come from
+	// memo content and are served without authentication.
+	PlantUMLSecurityProfile = "SANDBOX"
+
+	// MaxSourceBytes bounds the diagram source that is rendered.
+	MaxSourceBytes = 64 << 10
+	// MaxSVGBytes bounds the SVG a renderer may produce.
+	MaxSVGBytes = 4 << 20
+	// renderTimeout bounds a single render, including external commands.
+	renderTimeout = 20 * time.Second
+)
+
+// keyPattern matches the cache keys Render returns.
+var keyPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Renderer renders the source of one diagram language to SVG.
+type Renderer interface {
+	Render(ctx context.Context, source []byte) ([]byte, error)
+}
+
+// CommandRenderer renders diagrams with an external command that reads the
+// source on stdin and writes SVG to stdout. Env is added to the environment
+// of the server.
+type CommandRenderer struct {
+	Path string
+	Args []string
+	Env  []string
+}
+
+// NewCommandRenderer looks the command up on PATH. It returns nil when the
+// command cannot be found.
+func NewCommandRenderer(command string, args ...string) *CommandRenderer {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil
+	}
+	return &CommandRenderer{Path: path, Args: args}
+}
+
+// Render implements Renderer.
+func (r *CommandRenderer) Render(ctx context.Context, source []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.Path, r.Args...)
+	if len(r.Env) > 0 {
+		cmd.Env = append(os.Environ(), r.Env...)
+	}
+	cmd.Stdin = bytes.NewReader(source)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "%s failed: %s", filepath.Base(r.Path), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// Config selects the external commands. Empty commands use the defaults.
+type Config struct {
+	// EnableCommands renders Mermaid and PlantUML diagrams with their
+	// commands. They run on memo content, so they are off unless enabled.
+	EnableCommands  bool
+	MermaidCommand  string
+	PlantUMLCommand string
+	// GraphvizCommand, when set, renders DOT graphs with Graphviz dot instead
+	// of the native layout.
+	GraphvizCommand string
+	// CacheMaxBytes bounds the total size of the cached SVGs. The least
+	// recently rendered diagrams are evicted beyond it; zero uses
+	// DefaultCacheMaxBytes.
+	CacheMaxBytes int64
+}
+
+// Service renders diagrams by code block language and caches the SVG.
+type Service struct {
+	cacheDir      string
+	cacheMaxBytes int64
+	renderers     map[string]Renderer
+	group         singleflight.Group
+	// evictMu serializes cache evictions.
+	evictMu sync.Mutex
+}
+
+// New creates a service that caches diagrams in cacheDir. Mermaid and
+// PlantUML are left out unless config enables commands, as are renderers
+// whose command cannot be found.
+func New(cacheDir string, config Config) *Service {
+	s := &Service{cacheDir: cacheDir, cacheMaxBytes: config.CacheMaxBytes, renderers: map[string]Renderer{}}
+	if s.cacheMaxBytes <= 0 {
+		s.cacheMaxBytes = DefaultCacheMaxBytes
+	}
+
+	var dot Renderer = DOTRenderer{}
+	if config.GraphvizCommand != "" {
+		if renderer := NewCommandRenderer(config.GraphvizCommand, "-Tsvg"); renderer != nil {
+			dot = renderer
+		}
+	}
+	s.Register(dot, "dot", "graphviz")
+	if !config.EnableCommands {
+		return s
+	}
+
+	if config.MermaidCommand == "" {
+		config.MermaidCommand = DefaultMermaidCommand
+	}
+	if renderer := NewCommandRenderer(config.MermaidCommand, "--quiet", "--input", "-", "--output", "-", "--outputFormat", "svg"); renderer != nil {
+		s.Register(renderer, "mermaid")
+	}
+	if config.PlantUMLCommand == "" {
+		config.PlantUMLCommand = DefaultPlantUMLCommand
+	}
+	if renderer := NewCommandRenderer(config.PlantUMLCommand, "-DPLANTUML_SECURITY_PROFILE="+PlantUMLSecurityProfile, "-tsvg", "-pipe", "-charset", "UTF-8"); renderer != nil {
+		// The plantuml launcher script may not forward -D options to Java,
+		// so the profile is also set through the environment.
+		renderer.Env = []string{"PLANTUML_SECURITY_PROFILE=" + PlantUMLSecurityProfile}
+		s.Register(renderer, "plantuml", "puml")
+	}
+	return s
+}
+
+// Register renders the code block languages with renderer, replacing any
+// renderer registered for them before.
+func (s *Service) Register(renderer Renderer, languages ...string) {
+	for _, language := range languages {
+		s.renderers[strings.ToLower(language)] = renderer
+	}
+}
+
+// Supports reports whether code blocks of the language are rendered.
+func (s *Service) Supports(language string) bool {
+	_, ok := s.renderers[strings.ToLower(language)]
+	return ok
+}
+
+// Key returns the cache key of a diagram: the hex SHA-256 of its language
+// and source.
+func Key(language string, source []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(strings.ToLower(language)))
+	hash.Write([]byte{0})
+	hash.Write(source)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Render renders the diagram unless it is cached and returns its cache key.
+// A cache hit marks the diagram as recently used.
+func (s *Service) Render(ctx context.Context, language string, source []byte) (string, error) {
+	renderer, ok := s.renderers[strings.ToLower(language)]
+	if !ok {
+		return "", errors.Errorf("rendering %s diagrams is not supported", language)
+	}
+	if len(source) > MaxSourceBytes {
+		return "", errors.Errorf("diagram source exceeds %d bytes", MaxSourceBytes)
+	}
+	key := Key(language, source)
+	path := s.path(key)
+	if _, err := os.Stat(path); err == nil {
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+		return key, nil
+	}
+
+	// Concurrent renders of the same diagram share one run.
+	_, err, _ := s.group.Do(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(ctx, renderTimeout)
+		defer cancel()
+		svg, err := renderer.Render(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		if len(svg) > MaxSVGBytes {
+			return nil, errors.Errorf("rendered diagram exceeds %d bytes", MaxSVGBytes)
+		}
+		if !bytes.Contains(svg, []byte("<svg")) {
+			return nil, errors.New("renderer did not produce SVG")
+		}
+		if err := s.store(path, svg); err != nil {
+			return nil, err
+		}
+		s.evict()
+		return nil, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// Open opens the cached SVG of the key. It returns an error satisfying
+// os.IsNotExist when the key is malformed or the diagram is not cached.
+func (s *Service) Open(key string) (*os.File, error) {
+	if !keyPattern.MatchString(key) {
+		return nil, os.ErrNotExist
+	}
+	return os.Open(s.path(key))
+}
+
+func (s *Service) path(key string) string {
+	return filepath.Join(s.cacheDir, key+".svg")
+}
+
+// store writes the SVG through a temporary file so readers never see a
+// partial diagram.
+func (s *Service) store(path string, svg []byte) error {
+	if err := os.MkdirAll(s.cacheDir, os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create diagram cache folder")
+	}
+	file, err := os.CreateTemp(s.cacheDir, "render-*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create diagram file")
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(svg); err != nil {
+		file.Close()
+		return errors.Wrap(err, "failed to write diagram file")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "failed to write diagram file")
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return errors.Wrap(err, "failed to store diagram file")
+	}
+	return nil
+}
+
+// evict removes the least recently used diagrams until the cache fits
+// cacheMaxBytes. Eviction is best effort; a diagram that cannot be removed
+// is skipped.
+func (s *Service) evict() {
+	s.evictMu.Lock()
+	defer s.evictMu.Unlock()
+
+	entries, err := os.ReadDir(s.cacheDir)
+	if err != nil {
+		return
+	}
+	type cached struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var diagrams []cached
+	var total int64
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".svg" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		diagrams = append(diagrams, cached{path: filepath.Join(s.cacheDir, entry.Name()), size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+	}
+	if total <= s.cacheMaxBytes {
+		return
+	}
+	slices.SortFunc(diagrams, func(a, b cached) int {
+		return a.modTime.Compare(b.modTime)
+	})
+	for _, diagram := range diagrams {
+		if total <= s.cacheMaxBytes {
+			return
+		}
+		if err := os.Remove(diagram.path); err != nil && !os.IsNotExist(err) {
+			continue
+		}
+		total -= diagram.size
+	}
+}
@@ -0,0 +1,215 @@
+package diagram
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDOT(t *testing.T) {
+	graph, err := parseDOT(`
+		// Build pipeline
+		digraph G {
+			rankdir=LR;
+			node [shape=box];
+			start [label="Start here", shape=ellipse];
+			start -> build -> { test lint } [label="then"];
+			"quoted \"id\"" -- deploy;
+			/* block
+			   comment */
+			subgraph cluster_x { deploy; }
+		}
+	`)
+	require.NoError(t, err)
+	require.True(t, graph.directed)
+	require.Equal(t, "LR", graph.attrs["rankdir"])
+
+	ids := []string{}
+	for _, node := range graph.nodes {
+		ids = append(ids, node.id)
+	}
+	require.Equal(t, []string{"start", "build", "test", "lint", `quoted "id"`, "deploy"}, ids)
+	require.Equal(t, "Start here", graph.byID["start"].label())
+	require.Equal(t, "ellipse", graph.byID["start"].attrs["shape"])
+	require.Equal(t, "box", graph.byID["build"].attrs["shape"])
+
+	edges := []string{}
+	for _, edge := range graph.edges {
+		edges = append(edges, edge.from.id+">"+edge.to.id+":"+edge.attrs["label"])
+	}
+	require.Equal(t, []string{"start>build:then", "build>test:then", "build>lint:then", `quoted "id">deploy:`}, edges)
+}
+
+func TestParseDOTErrors(t *testing.T) {
+	for _, source := range []string{
+		"",
+		"flowchart TD",
+		"digraph { a -> }",
+		"digraph { a -> b",
+		`digraph { a [label="open }`,
+		"digraph { a [label=<<b>x</b>>] }",
+	} {
+		_, err := parseDOT(source)
+		require.Error(t, err, source)
+	}
+}
+
+func TestLayoutDOTRanks(t *testing.T) {
+	graph, err := parseDOT("digraph { a -> b; b -> c; a -> c; c -> a }")
+	require.NoError(t, err)
+	layout := layoutDOT(graph)
+
+	// The cycle-closing edge c -> a is ignored, so a, b and c descend.
+	a, b, c := layout.boxes[graph.byID["a"]], layout.boxes[graph.byID["b"]], layout.boxes[graph.byID["c"]]
+	require.Less(t, a.y, b.y)
+	require.Less(t, b.y, c.y)
+	require.Greater(t, layout.height, c.y+c.height/2)
+
+	graph, err = parseDOT("digraph { rankdir=LR; a -> b }")
+	require.NoError(t, err)
+	layout = layoutDOT(graph)
+	require.Less(t, layout.boxes[graph.byID["a"]].x, layout.boxes[graph.byID["b"]].x)
+	require.Equal(t, layout.boxes[graph.byID["a"]].y, layout.boxes[graph.byID["b"]].y)
+}
+
+func TestDOTRendererSVG(t *testing.T) {
+	svg, err := DOTRenderer{}.Render(context.Background(), []byte(`digraph { a [label="x < y", style=filled, fillcolor="#ffcc00"]; a -> b [color=red, style=dashed] }`))
+	require.NoError(t, err)
+	rendered := string(svg)
+	require.True(t, strings.HasPrefix(rendered, `<svg xmlns="http://www.w3.org/2000/svg"`))
+	require.Contains(t, rendered, ">x &lt; y</text>")
+	require.Contains(t, rendered, `fill="#ffcc00"`)
+	require.Contains(t, rendered, `stroke="red" stroke-dasharray="6,4"`)
+	require.Contains(t, rendered, "<polygon")
+	require.NotContains(t, rendered, "<script")
+
+	// Undirected graphs have no arrowheads.
+	svg, err = DOTRenderer{}.Render(context.Background(), []byte("graph { a -- b }"))
+	require.NoError(t, err)
+	require.NotContains(t, string(svg), "<polygon")
+
+	_, err = DOTRenderer{}.Render(context.Background(), []byte("not a graph"))
+	require.Error(t, err)
+}
+
+func TestServiceRenderCaches(t *testing.T) {
+	dir := t.TempDir()
+	s := New(dir, Config{MermaidCommand: "memos-missing-mmdc", PlantUMLCommand: "memos-missing-plantuml"})
+	require.True(t, s.Supports("dot"))
+	require.True(t, s.Supports("Graphviz"))
+	require.False(t, s.Supports("mermaid"))
+	require.False(t, s.Supports("go"))
+
+	source := []byte("digraph { a -> b }")
+	key, err := s.Render(context.Background(), "dot", source)
+	require.NoError(t, err)
+	require.Equal(t, Key("dot", source), key)
+
+	file, err := s.Open(key)
+	require.NoError(t, err)
+	info, err := file.Stat()
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// A second render is served from the cache.
+	counter := &countingRenderer{}
+	s.Register(counter, "dot")
+	again, err := s.Render(context.Background(), "dot", source)
+	require.NoError(t, err)
+	require.Equal(t, key, again)
+	require.Zero(t, counter.calls)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, key+".svg", entries[0].Name())
+	require.NotZero(t, info.Size())
+
+	_, err = s.Render(context.Background(), "mermaid", []byte("graph TD; A-->B"))
+	require.Error(t, err)
+	_, err = s.Render(context.Background(), "dot", []byte("digraph {"))
+	require.Error(t, err)
+
+	_, err = s.Open("../secret")
+	require.True(t, os.IsNotExist(err))
+	_, err = s.Open(Key("dot", []byte("uncached")))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestServiceCommandsOptIn(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake plantuml requires a POSIX shell")
+	}
+	// The fake plantuml echoes its security profile setting as SVG.
+	plantuml := filepath.Join(t.TempDir(), "plantuml")
+	require.NoError(t, os.WriteFile(plantuml, []byte(`#!/bin/sh
+echo "<svg>$PLANTUML_SECURITY_PROFILE $1</svg>"
+`), 0o755))
+
+	s := New(t.TempDir(), Config{PlantUMLCommand: plantuml})
+	require.False(t, s.Supports("plantuml"))
+
+	dir := t.TempDir()
+	s = New(dir, Config{EnableCommands: true, MermaidCommand: "memos-missing-mmdc", PlantUMLCommand: plantuml})
+	require.True(t, s.Supports("plantuml"))
+	require.False(t, s.Supports("mermaid"))
+	key, err := s.Render(context.Background(), "plantuml", []byte("@startuml\n!include /etc/passwd\n@enduml"))
+	require.NoError(t, err)
+	svg, err := os.ReadFile(filepath.Join(dir, key+".svg"))
+	require.NoError(t, err)
+	require.Equal(t, "<svg>SANDBOX -DPLANTUML_SECURITY_PROFILE=SANDBOX</svg>\n", string(svg))
+}
+
+func TestServiceRejectsNonSVG(t *testing.T) {
+	s := New(t.TempDir(), Config{})
+	s.Register(&countingRenderer{output: []byte("plain text")}, "text")
+	_, err := s.Render(context.Background(), "text", []byte("hello"))
+	require.Error(t, err)
+}
+
+func TestServiceCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	svg := []byte("<svg>" + strings.Repeat(" ", 95) + "</svg>")
+	s := New(dir, Config{CacheMaxBytes: int64(3 * len(svg))})
+	s.Register(&countingRenderer{output: svg}, "text")
+	ctx := context.Background()
+
+	keys := map[string]string{}
+	for i, name := range []string{"a", "b", "c"} {
+		key, err := s.Render(ctx, "text", []byte(name))
+		require.NoError(t, err)
+		keys[name] = key
+		age := time.Now().Add(-time.Duration(3-i) * time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, key+".svg"), age, age))
+	}
+
+	// Rendering a again marks it as used, so b is the least recently used.
+	_, err := s.Render(ctx, "text", []byte("a"))
+	require.NoError(t, err)
+	keys["d"], err = s.Render(ctx, "text", []byte("d"))
+	require.NoError(t, err)
+
+	for name, key := range keys {
+		_, err := os.Stat(filepath.Join(dir, key+".svg"))
+		if name == "b" {
+			require.True(t, os.IsNotExist(err), name)
+		} else {
+			require.NoError(t, err, name)
+		}
+	}
+}
+
+type countingRenderer struct {
+	calls  int
+	output []byte
+}
+
+func (r *countingRenderer) Render(context.Context, []byte) ([]byte, error) {
+	r.calls++
+	return r.output, nil
+}
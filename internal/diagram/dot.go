@@ -0,0 +1,484 @@
+package diagram
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Layout metrics of DOT diagrams, in SVG user units.
+const (
+	dotFontSize     = 14.0
+	dotCharWidth    = 7.8
+	dotLineHeight   = 18.0
+	dotNodePaddingX = 16.0
+	dotNodePaddingY = 10.0
+	dotNodeMinWidth = 54.0
+	dotNodeSpacing  = 28.0
+	dotRankSpacing  = 56.0
+	dotMargin       = 12.0
+	dotArrowSize    = 9.0
+	// dotOrderingSweeps is the number of barycenter passes, each one down
+	// and one up the ranks, used to reduce edge crossings.
+	dotOrderingSweeps = 4
+	// dotMaxNodes bounds the size of graphs that are laid out.
+	dotMaxNodes = 500
+)
+
+// DOTRenderer lays out Graphviz DOT graphs natively. It ranks nodes along
+// the longest path, orders each rank by the barycenter of its neighbours and
+// draws straight edges, which suits the small graphs written in memos. The
+// graph attribute rankdir=LR lays the ranks out left to right.
+type DOTRenderer struct{}
+
+// Render implements Renderer.
+func (DOTRenderer) Render(_ context.Context, source []byte) ([]byte, error) {
+	graph, err := parseDOT(string(source))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid DOT graph")
+	}
+	if len(graph.nodes) > dotMaxNodes {
+		return nil, errors.Errorf("graph has more than %d nodes", dotMaxNodes)
+	}
+	return layoutDOT(graph).svg(), nil
+}
+
+// dotLayout is a graph with positions assigned to its nodes.
+type dotLayout struct {
+	graph  *dotGraph
+	boxes  map[*dotNode]*dotBox
+	width  float64
+	height float64
+}
+
+// dotBox is the bounding box of a node, centered on x and y.
+type dotBox struct {
+	x, y          float64
+	width, height float64
+	lines         []string
+	shape         string
+}
+
+func layoutDOT(graph *dotGraph) *dotLayout {
+	layout := &dotLayout{graph: graph, boxes: map[*dotNode]*dotBox{}}
+	horizontal := strings.EqualFold(graph.attrs["rankdir"], "LR") || strings.EqualFold(graph.attrs["rankdir"], "RL")
+	for _, node := range graph.nodes {
+		layout.boxes[node] = newDOTBox(node)
+	}
+
+	ranks := orderRanks(graph, rankNodes(graph))
+
+	// Ranks stack along the main axis; nodes of a rank sit side by side on
+	// the cross axis. Boxes are measured transposed for left to right graphs.
+	extent := func(box *dotBox) (along, across float64) {
+		if horizontal {
+			return box.width, box.height
+		}
+		return box.height, box.width
+	}
+	rankWidths := make([]float64, len(ranks))
+	rankDepths := make([]float64, len(ranks))
+	maxWidth := 0.0
+	for i, rank := range ranks {
+		for j, node := range rank {
+			along, across := extent(layout.boxes[node])
+			rankDepths[i] = math.Max(rankDepths[i], along)
+			rankWidths[i] += across
+			if j > 0 {
+				rankWidths[i] += dotNodeSpacing
+			}
+		}
+		maxWidth = math.Max(maxWidth, rankWidths[i])
+	}
+
+	position := dotMargin
+	for i, rank := range ranks {
+		cross := dotMargin + (maxWidth-rankWidths[i])/2
+		for _, node := range rank {
+			box := layout.boxes[node]
+			_, across := extent(box)
+			main := position + rankDepths[i]/2
+			if horizontal {
+				box.x, box.y = main, cross+across/2
+			} else {
+				box.x, box.y = cross+across/2, main
+			}
+			cross += across + dotNodeSpacing
+		}
+		position += rankDepths[i] + dotRankSpacing
+	}
+	depth := position - dotRankSpacing + dotMargin
+	if len(ranks) == 0 {
+		depth = 2 * dotMargin
+	}
+	if horizontal {
+		layout.width, layout.height = depth, maxWidth+2*dotMargin
+	} else {
+		layout.width, layout.height = maxWidth+2*dotMargin, depth
+	}
+
+	if strings.EqualFold(graph.attrs["rankdir"], "BT") || strings.EqualFold(graph.attrs["rankdir"], "RL") {
+		for _, box := range layout.boxes {
+			if horizontal {
+				box.x = layout.width - box.x
+			} else {
+				box.y = layout.height - box.y
+			}
+		}
+	}
+	if label := graph.attrs["label"]; label != "" {
+		layout.height += float64(len(strings.Split(label, "\n"))) * dotLineHeight
+	}
+	return layout
+}
+
+func newDOTBox(node *dotNode) *dotBox {
+	lines := strings.Split(node.label(), "\n")
+	longest := 0
+	for _, line := range lines {
+		longest = max(longest, utf8.RuneCountInString(line))
+	}
+	box := &dotBox{
+		width:  math.Max(dotNodeMinWidth, float64(longest)*dotCharWidth+2*dotNodePaddingX),
+		height: float64(len(lines))*dotLineHeight + 2*dotNodePaddingY,
+		lines:  lines,
+		shape:  strings.ToLower(node.attrs["shape"]),
+	}
+	switch box.shape {
+	case "", "ellipse", "oval":
+		box.shape = "ellipse"
+		// An ellipse needs more room than a box around the same text.
+		box.width *= 1.2
+		box.height *= 1.2
+	case "circle", "doublecircle":
+		box.width = math.Max(box.width, box.height)
+		box.height = box.width
+	case "diamond":
+		box.width *= 1.6
+		box.height *= 1.6
+	case "box", "rect", "rectangle", "square", "plaintext", "plain", "none", "note", "record", "mrecord":
+	default:
+		box.shape = "box"
+	}
+	return box
+}
+
+// rankNodes assigns each node the length of the longest path reaching it.
+// Edges that close a cycle are ignored, so every graph can be ranked.
+func rankNodes(graph *dotGraph) map[*dotNode]int {
+	successors := map[*dotNode][]*dotNode{}
+	for _, edge := range graph.edges {
+		if edge.from != edge.to {
+			successors[edge.from] = append(successors[edge.from], edge.to)
+		}
+	}
+
+	// A depth-first search finds the back edges that close cycles and gives
+	// the remaining acyclic edges in reverse topological order.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[*dotNode]int{}
+	var order []*dotNode
+	acyclic := map[*dotNode][]*dotNode{}
+	var visit func(node *dotNode)
+	visit = func(node *dotNode) {
+		state[node] = visiting
+		for _, next := range successors[node] {
+			switch state[next] {
+			case visiting:
+				continue
+			case unvisited:
+				visit(next)
+			}
+			acyclic[node] = append(acyclic[node], next)
+		}
+		state[node] = visited
+		order = append(order, node)
+	}
+	for _, node := range graph.nodes {
+		if state[node] == unvisited {
+			visit(node)
+		}
+	}
+
+	ranks := map[*dotNode]int{}
+	for i := len(order) - 1; i >= 0; i-- {
+		node := order[i]
+		for _, next := range acyclic[node] {
+			ranks[next] = max(ranks[next], ranks[node]+1)
+		}
+	}
+	return ranks
+}
+
+// orderRanks groups nodes by rank and orders every rank so that nodes sit
+// near the average position of their neighbours in the adjacent ranks.
+func orderRanks(graph *dotGraph, rankOf map[*dotNode]int) [][]*dotNode {
+	var ranks [][]*dotNode
+	for _, node := range graph.nodes {
+		rank := rankOf[node]
+		for len(ranks) <= rank {
+			ranks = append(ranks, nil)
+		}
+		ranks[rank] = append(ranks[rank], node)
+	}
+
+	neighbours := map[*dotNode][]*dotNode{}
+	for _, edge := range graph.edges {
+		if edge.from != edge.to {
+			neighbours[edge.from] = append(neighbours[edge.from], edge.to)
+			neighbours[edge.to] = append(neighbours[edge.to], edge.from)
+		}
+	}
+	index := map[*dotNode]int{}
+	reindex := func(rank []*dotNode) {
+		for i, node := range rank {
+			index[node] = i
+		}
+	}
+	for _, rank := range ranks {
+		reindex(rank)
+	}
+	reorder := func(rank []*dotNode, adjacent int) {
+		barycenter := map[*dotNode]float64{}
+		for _, node := range rank {
+			sum, count := 0.0, 0
+			for _, neighbour := range neighbours[node] {
+				if rankOf[neighbour] == adjacent {
+					sum += float64(index[neighbour])
+					count++
+				}
+			}
+			if count == 0 {
+				barycenter[node] = float64(index[node])
+				continue
+			}
+			barycenter[node] = sum / float64(count)
+		}
+		sort.SliceStable(rank, func(i, j int) bool { return barycenter[rank[i]] < barycenter[rank[j]] })
+		reindex(rank)
+	}
+	for sweep := 0; sweep < dotOrderingSweeps; sweep++ {
+		for i := 1; i < len(ranks); i++ {
+			reorder(ranks[i], i-1)
+		}
+		for i := len(ranks) - 2; i >= 0; i-- {
+			reorder(ranks[i], i+1)
+		}
+	}
+	return ranks
+}
+
+// svg draws the layout. Colors come from the color, fillcolor and fontcolor
+// attributes; the SVG carries no scripts or external references.
+func (l *dotLayout) svg() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="sans-serif" font-size="%s">`,
+		formatNumber(l.width), formatNumber(l.height), formatNumber(l.width), formatNumber(l.height), formatNumber(dotFontSize))
+	b.WriteString("\n")
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`, svgColor(l.graph.attrs["bgcolor"], "white"))
+	b.WriteString("\n")
+
+	for _, edge := range l.graph.edges {
+		l.writeEdge(&b, edge)
+	}
+	for _, node := range l.graph.nodes {
+		l.writeNode(&b, node)
+	}
+	if label := l.graph.attrs["label"]; label != "" {
+		lines := strings.Split(label, "\n")
+		writeText(&b, l.width/2, l.height-dotMargin/2-float64(len(lines))*dotLineHeight/2, lines, "black")
+	}
+	b.WriteString("</svg>\n")
+	return []byte(b.String())
+}
+
+func (l *dotLayout) writeNode(b *strings.Builder, node *dotNode) {
+	box := l.boxes[node]
+	stroke := svgColor(node.attrs["color"], "black")
+	fill := "none"
+	if strings.Contains(node.attrs["style"], "filled") {
+		fill = svgColor(node.attrs["fillcolor"], svgColor(node.attrs["color"], "lightgrey"))
+	}
+	dash := dashArray(node.attrs["style"])
+	left, top := box.x-box.width/2, box.y-box.height/2
+	switch box.shape {
+	case "ellipse":
+		fmt.Fprintf(b, `<ellipse cx="%s" cy="%s" rx="%s" ry="%s" fill="%s" stroke="%s"%s/>`,
+			formatNumber(box.x), formatNumber(box.y), formatNumber(box.width/2), formatNumber(box.height/2), fill, stroke, dash)
+	case "circle", "doublecircle":
+		fmt.Fprintf(b, `<circle cx="%s" cy="%s" r="%s" fill="%s" stroke="%s"%s/>`,
+			formatNumber(box.x), formatNumber(box.y), formatNumber(box.width/2), fill, stroke, dash)
+		if box.shape == "doublecircle" {
+			fmt.Fprintf(b, `<circle cx="%s" cy="%s" r="%s" fill="none" stroke="%s"/>`,
+				formatNumber(box.x), formatNumber(box.y), formatNumber(box.width/2-4), stroke)
+		}
+	case "diamond":
+		fmt.Fprintf(b, `<polygon points="%s,%s %s,%s %s,%s %s,%s" fill="%s" stroke="%s"%s/>`,
+			formatNumber(box.x), formatNumber(top), formatNumber(left+box.width), formatNumber(box.y),
+			formatNumber(box.x), formatNumber(top+box.height), formatNumber(left), formatNumber(box.y), fill, stroke, dash)
+	case "plaintext", "plain", "none":
+	default:
+		radius := ""
+		if box.shape == "mrecord" || strings.Contains(node.attrs["style"], "rounded") {
+			radius = ` rx="6"`
+		}
+		fmt.Fprintf(b, `<rect x="%s" y="%s" width="%s" height="%s"%s fill="%s" stroke="%s"%s/>`,
+			formatNumber(left), formatNumber(top), formatNumber(box.width), formatNumber(box.height), radius, fill, stroke, dash)
+	}
+	b.WriteString("\n")
+	writeText(b, box.x, box.y, box.lines, svgColor(node.attrs["fontcolor"], "black"))
+}
+
+func (l *dotLayout) writeEdge(b *strings.Builder, edge *dotEdge) {
+	stroke := svgColor(edge.attrs["color"], "black")
+	dash := dashArray(edge.attrs["style"])
+	from, to := l.boxes[edge.from], l.boxes[edge.to]
+	if edge.from == edge.to {
+		// A self loop is drawn as an arc on the right of the node.
+		x, y := from.x+from.width/2, from.y
+		fmt.Fprintf(b, `<path d="M %s %s C %s %s %s %s %s %s" fill="none" stroke="%s"%s/>`,
+			formatNumber(x), formatNumber(y-from.height/4),
+			formatNumber(x+30), formatNumber(y-from.height/2), formatNumber(x+30), formatNumber(y+from.height/2),
+			formatNumber(x), formatNumber(y+from.height/4), stroke, dash)
+		b.WriteString("\n")
+		return
+	}
+
+	x1, y1 := from.clip(to.x, to.y)
+	x2, y2 := to.clip(from.x, from.y)
+	direction := strings.ToLower(edge.attrs["dir"])
+	if direction == "" && l.graph.directed {
+		direction = "forward"
+	}
+	forward := direction == "forward" || direction == "both"
+	back := direction == "back" || direction == "both"
+	// The line stops short of an arrowhead so its end does not show through.
+	lineX1, lineY1, lineX2, lineY2 := x1, y1, x2, y2
+	if forward {
+		lineX2, lineY2 = shorten(x2, y2, x1, y1, dotArrowSize)
+	}
+	if back {
+		lineX1, lineY1 = shorten(x1, y1, x2, y2, dotArrowSize)
+	}
+	fmt.Fprintf(b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"%s/>`,
+		formatNumber(lineX1), formatNumber(lineY1), formatNumber(lineX2), formatNumber(lineY2), stroke, dash)
+	b.WriteString("\n")
+	if forward {
+		writeArrowhead(b, x1, y1, x2, y2, stroke)
+	}
+	if back {
+		writeArrowhead(b, x2, y2, x1, y1, stroke)
+	}
+	if label := edge.attrs["label"]; label != "" {
+		writeText(b, (x1+x2)/2+6, (y1+y2)/2, strings.Split(label, "\n"), svgColor(edge.attrs["fontcolor"], "black"), "start")
+	}
+}
+
+// clip returns where the line from the center of the box toward x, y leaves
+// the box outline.
+func (box *dotBox) clip(x, y float64) (float64, float64) {
+	dx, dy := x-box.x, y-box.y
+	if dx == 0 && dy == 0 {
+		return box.x, box.y
+	}
+	halfWidth, halfHeight := box.width/2, box.height/2
+	var scale float64
+	switch box.shape {
+	case "ellipse", "circle", "doublecircle":
+		scale = 1 / math.Sqrt(dx*dx/(halfWidth*halfWidth)+dy*dy/(halfHeight*halfHeight))
+	case "diamond":
+		scale = 1 / (math.Abs(dx)/halfWidth + math.Abs(dy)/halfHeight)
+	default:
+		scale = math.Min(halfWidth/math.Abs(dx), halfHeight/math.Abs(dy))
+	}
+	return box.x + dx*scale, box.y + dy*scale
+}
+
+// shorten moves x, y toward towardX, towardY by length.
+func shorten(x, y, towardX, towardY, length float64) (float64, float64) {
+	dx, dy := towardX-x, towardY-y
+	distance := math.Hypot(dx, dy)
+	if distance <= length {
+		return x, y
+	}
+	return x + dx/distance*length, y + dy/distance*length
+}
+
+// writeArrowhead draws an arrowhead at x2, y2 pointing away from x1, y1.
+func writeArrowhead(b *strings.Builder, x1, y1, x2, y2 float64, color string) {
+	angle := math.Atan2(y2-y1, x2-x1)
+	const spread = math.Pi / 7
+	leftX, leftY := x2-dotArrowSize*math.Cos(angle-spread), y2-dotArrowSize*math.Sin(angle-spread)
+	rightX, rightY := x2-dotArrowSize*math.Cos(angle+spread), y2-dotArrowSize*math.Sin(angle+spread)
+	fmt.Fprintf(b, `<polygon points="%s,%s %s,%s %s,%s" fill="%s" stroke="%s"/>`,
+		formatNumber(x2), formatNumber(y2), formatNumber(leftX), formatNumber(leftY), formatNumber(rightX), formatNumber(rightY), color, color)
+	b.WriteString("\n")
+}
+
+// writeText writes lines of text centered vertically on y. The anchor
+// defaults to middle.
+func writeText(b *strings.Builder, x, y float64, lines []string, color string, anchor ...string) {
+	textAnchor := "middle"
+	if len(anchor) > 0 {
+		textAnchor = anchor[0]
+	}
+	top := y - float64(len(lines)-1)*dotLineHeight/2
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		fmt.Fprintf(b, `<text x="%s" y="%s" text-anchor="%s" dominant-baseline="central" fill="%s">%s</text>`,
+			formatNumber(x), formatNumber(top+float64(i)*dotLineHeight), textAnchor, color, escapeXML(line))
+		b.WriteString("\n")
+	}
+}
+
+func dashArray(style string) string {
+	switch {
+	case strings.Contains(style, "dashed"):
+		return ` stroke-dasharray="6,4"`
+	case strings.Contains(style, "dotted"):
+		return ` stroke-dasharray="1,3"`
+	default:
+		return ""
+	}
+}
+
+// svgColor returns a DOT color usable in SVG: a color name or a #rrggbb
+// value. Anything else, including the HSV and color list forms, falls back.
+func svgColor(color, fallback string) string {
+	color = strings.TrimSpace(color)
+	if color == "" {
+		return fallback
+	}
+	if strings.HasPrefix(color, "#") {
+		if _, err := strconv.ParseUint(color[1:], 16, 32); err == nil && (len(color) == 4 || len(color) == 7 || len(color) == 9) {
+			return color
+		}
+		return fallback
+	}
+	for _, r := range color {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return fallback
+		}
+	}
+	return strings.ToLower(color)
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+}
+
+func escapeXML(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#39;").Replace(text)
+}
@@ -0,0 +1,411 @@
+package diagram
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// dotGraph is a parsed Graphviz DOT graph. Subgraphs are flattened into it.
+type dotGraph struct {
+	directed bool
+	attrs    map[string]string
+	nodes    []*dotNode
+	edges    []*dotEdge
+	byID     map[string]*dotNode
+}
+
+type dotNode struct {
+	id    string
+	attrs map[string]string
+}
+
+type dotEdge struct {
+	from  *dotNode
+	to    *dotNode
+	attrs map[string]string
+}
+
+// label returns the text shown for the node.
+func (n *dotNode) label() string {
+	if label, ok := n.attrs["label"]; ok {
+		return label
+	}
+	return n.id
+}
+
+// dotParser parses the statement subset of DOT that diagrams in memos use:
+// node, edge and attribute statements, edge chains, attribute lists and
+// subgraphs. HTML-like labels and ports are not supported.
+type dotParser struct {
+	tokens []dotToken
+	pos    int
+	graph  *dotGraph
+}
+
+type dotTokenKind int
+
+const (
+	dotTokenID dotTokenKind = iota
+	dotTokenPunct
+	dotTokenEdgeOp
+)
+
+type dotToken struct {
+	kind  dotTokenKind
+	value string
+}
+
+// parseDOT parses DOT source into a graph.
+func parseDOT(source string) (*dotGraph, error) {
+	tokens, err := tokenizeDOT(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &dotParser{tokens: tokens, graph: &dotGraph{attrs: map[string]string{}, byID: map[string]*dotNode{}}}
+	if err := p.parseGraph(); err != nil {
+		return nil, err
+	}
+	return p.graph, nil
+}
+
+func (p *dotParser) parseGraph() error {
+	if p.peekKeyword("strict") {
+		p.pos++
+	}
+	switch {
+	case p.peekKeyword("digraph"):
+		p.graph.directed = true
+	case p.peekKeyword("graph"):
+	default:
+		return errors.New("expected graph or digraph")
+	}
+	p.pos++
+	if p.peekKind(dotTokenID) {
+		p.pos++
+	}
+	if !p.accept("{") {
+		return errors.New("expected { after graph header")
+	}
+	if err := p.parseStatements(map[string]string{}, map[string]string{}); err != nil {
+		return err
+	}
+	if p.pos < len(p.tokens) {
+		return errors.Errorf("unexpected %q after graph", p.tokens[p.pos].value)
+	}
+	return nil
+}
+
+// parseStatements parses statements up to the closing brace of the current
+// graph or subgraph. Default attributes set inside a subgraph stay in it.
+func (p *dotParser) parseStatements(nodeDefaults, edgeDefaults map[string]string) error {
+	nodeDefaults = copyAttrs(nodeDefaults)
+	edgeDefaults = copyAttrs(edgeDefaults)
+	for {
+		if p.pos >= len(p.tokens) {
+			return errors.New("unexpected end of graph, expected }")
+		}
+		if p.accept("}") {
+			return nil
+		}
+		if p.accept(";") || p.accept(",") {
+			continue
+		}
+		if err := p.parseStatement(nodeDefaults, edgeDefaults); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *dotParser) parseStatement(nodeDefaults, edgeDefaults map[string]string) error {
+	switch {
+	case p.peekKeyword("node"), p.peekKeyword("edge"), p.peekKeyword("graph"):
+		keyword := strings.ToLower(p.tokens[p.pos].value)
+		p.pos++
+		attrs, err := p.parseAttrLists()
+		if err != nil {
+			return err
+		}
+		target := map[string]map[string]string{"node": nodeDefaults, "edge": edgeDefaults, "graph": p.graph.attrs}[keyword]
+		for key, value := range attrs {
+			target[key] = value
+		}
+		return nil
+	case p.peekKeyword("subgraph"), p.peek("{"):
+		_, err := p.parseEndpoint(nodeDefaults, edgeDefaults)
+		if err != nil {
+			return err
+		}
+		return p.parseEdgeChain(nil, nodeDefaults, edgeDefaults)
+	case p.peekKind(dotTokenID):
+	default:
+		return errors.Errorf("unexpected %q", p.tokens[p.pos].value)
+	}
+
+	// ID '=' ID sets a graph attribute.
+	if p.pos+2 < len(p.tokens) && p.tokens[p.pos+1].value == "=" && p.tokens[p.pos+1].kind == dotTokenPunct {
+		p.graph.attrs[p.tokens[p.pos].value] = p.tokens[p.pos+2].value
+		p.pos += 3
+		return nil
+	}
+
+	nodes, err := p.parseEndpoint(nodeDefaults, edgeDefaults)
+	if err != nil {
+		return err
+	}
+	if !p.peekKind(dotTokenEdgeOp) {
+		attrs, err := p.parseAttrLists()
+		if err != nil {
+			return err
+		}
+		for key, value := range attrs {
+			nodes[0].attrs[key] = value
+		}
+		return nil
+	}
+	return p.parseEdgeChain(nodes, nodeDefaults, edgeDefaults)
+}
+
+// parseEdgeChain parses the rest of an edge statement such as a -> b -> c
+// [attrs], where from holds the nodes of the first endpoint.
+func (p *dotParser) parseEdgeChain(from []*dotNode, nodeDefaults, edgeDefaults map[string]string) error {
+	type pair struct{ from, to *dotNode }
+	var pairs []pair
+	for p.peekKind(dotTokenEdgeOp) {
+		p.pos++
+		to, err := p.parseEndpoint(nodeDefaults, edgeDefaults)
+		if err != nil {
+			return err
+		}
+		for _, source := range from {
+			for _, target := range to {
+				pairs = append(pairs, pair{source, target})
+			}
+		}
+		from = to
+	}
+	attrs, err := p.parseAttrLists()
+	if err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		edgeAttrs := copyAttrs(edgeDefaults)
+		for key, value := range attrs {
+			edgeAttrs[key] = value
+		}
+		p.graph.edges = append(p.graph.edges, &dotEdge{from: pair.from, to: pair.to, attrs: edgeAttrs})
+	}
+	return nil
+}
+
+// parseEndpoint parses a node ID, optionally with a port that is ignored, or
+// a subgraph, whose nodes are all returned.
+func (p *dotParser) parseEndpoint(nodeDefaults, edgeDefaults map[string]string) ([]*dotNode, error) {
+	if p.peekKeyword("subgraph") || p.peek("{") {
+		if p.peekKeyword("subgraph") {
+			p.pos++
+			if p.peekKind(dotTokenID) {
+				p.pos++
+			}
+		}
+		if !p.accept("{") {
+			return nil, errors.New("expected { after subgraph")
+		}
+		first := len(p.graph.nodes)
+		seen := map[*dotNode]bool{}
+		edgesBefore := len(p.graph.edges)
+		if err := p.parseStatements(nodeDefaults, edgeDefaults); err != nil {
+			return nil, err
+		}
+		var nodes []*dotNode
+		for _, node := range p.graph.nodes[first:] {
+			seen[node] = true
+			nodes = append(nodes, node)
+		}
+		// Nodes declared before the subgraph but used in it belong to it too.
+		for _, edge := range p.graph.edges[edgesBefore:] {
+			for _, node := range []*dotNode{edge.from, edge.to} {
+				if !seen[node] {
+					seen[node] = true
+					nodes = append(nodes, node)
+				}
+			}
+		}
+		return nodes, nil
+	}
+	if !p.peekKind(dotTokenID) {
+		if p.pos >= len(p.tokens) {
+			return nil, errors.New("unexpected end of graph, expected a node")
+		}
+		return nil, errors.Errorf("expected a node, got %q", p.tokens[p.pos].value)
+	}
+	id := p.tokens[p.pos].value
+	p.pos++
+	// Ports such as a:n or a:port:sw only affect edge placement.
+	for p.accept(":") {
+		if p.peekKind(dotTokenID) {
+			p.pos++
+		}
+	}
+	return []*dotNode{p.node(id, nodeDefaults)}, nil
+}
+
+// node returns the node with the ID, declaring it with the current defaults
+// the first time it is used.
+func (p *dotParser) node(id string, defaults map[string]string) *dotNode {
+	if node, ok := p.graph.byID[id]; ok {
+		return node
+	}
+	node := &dotNode{id: id, attrs: copyAttrs(defaults)}
+	p.graph.byID[id] = node
+	p.graph.nodes = append(p.graph.nodes, node)
+	return node
+}
+
+// parseAttrLists parses zero or more [key=value, ...] lists.
+func (p *dotParser) parseAttrLists() (map[string]string, error) {
+	attrs := map[string]string{}
+	for p.accept("[") {
+		for !p.accept("]") {
+			if p.accept(",") || p.accept(";") {
+				continue
+			}
+			if !p.peekKind(dotTokenID) {
+				return nil, errors.New("expected an attribute name")
+			}
+			key := p.tokens[p.pos].value
+			p.pos++
+			value := "true"
+			if p.accept("=") {
+				if !p.peekKind(dotTokenID) {
+					return nil, errors.Errorf("expected a value for attribute %q", key)
+				}
+				value = p.tokens[p.pos].value
+				p.pos++
+			}
+			attrs[key] = value
+		}
+	}
+	return attrs, nil
+}
+
+func (p *dotParser) peek(punct string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == dotTokenPunct && p.tokens[p.pos].value == punct
+}
+
+func (p *dotParser) peekKind(kind dotTokenKind) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind
+}
+
+func (p *dotParser) peekKeyword(keyword string) bool {
+	return p.peekKind(dotTokenID) && strings.EqualFold(p.tokens[p.pos].value, keyword)
+}
+
+func (p *dotParser) accept(punct string) bool {
+	if !p.peek(punct) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+// tokenizeDOT splits DOT source into IDs, punctuation and edge operators,
+// dropping comments. Quoted strings become IDs without their quotes.
+func tokenizeDOT(source string) ([]dotToken, error) {
+	var tokens []dotToken
+	for i := 0; i < len(source); {
+		r, size := utf8.DecodeRuneInString(source[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(source[i:], "//"), r == '#' && (i == 0 || source[i-1] == '\n'):
+			end := strings.IndexByte(source[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.New("unterminated comment")
+			}
+			i += end + 4
+		case strings.HasPrefix(source[i:], "->"), strings.HasPrefix(source[i:], "--"):
+			tokens = append(tokens, dotToken{kind: dotTokenEdgeOp, value: source[i : i+2]})
+			i += 2
+		case r == '"':
+			var value strings.Builder
+			j := i + 1
+			for ; j < len(source) && source[j] != '"'; j++ {
+				if source[j] == '\\' && j+1 < len(source) {
+					j++
+					switch source[j] {
+					case '"':
+						value.WriteByte('"')
+					case 'n', 'l', 'r':
+						value.WriteByte('\n')
+					case '\n':
+						// A backslash before a line break continues the string.
+					default:
+						value.WriteByte('\\')
+						value.WriteByte(source[j])
+					}
+					continue
+				}
+				value.WriteByte(source[j])
+			}
+			if j >= len(source) {
+				return nil, errors.New("unterminated string")
+			}
+			tokens = append(tokens, dotToken{kind: dotTokenID, value: value.String()})
+			i = j + 1
+			// "a" + "b" concatenates strings.
+			if n := len(tokens); n >= 3 && tokens[n-2].value == "+" && tokens[n-2].kind == dotTokenPunct {
+				tokens[n-3].value += tokens[n-1].value
+				tokens = tokens[:n-2]
+			}
+		case r == '<':
+			return nil, errors.New("HTML-like labels are not supported")
+		case strings.ContainsRune("{}[]=;,:+", r):
+			tokens = append(tokens, dotToken{kind: dotTokenPunct, value: string(r)})
+			i += size
+		case isDOTIDRune(r) || r == '-' || r == '.':
+			j := i
+			numeric := r == '-' || r == '.' || unicode.IsDigit(r)
+			for j < len(source) {
+				next, nextSize := utf8.DecodeRuneInString(source[j:])
+				if numeric && (unicode.IsDigit(next) || next == '.' || (j == i && next == '-')) {
+					j += nextSize
+					continue
+				}
+				if !numeric && isDOTIDRune(next) {
+					j += nextSize
+					continue
+				}
+				break
+			}
+			if j == i {
+				return nil, errors.Errorf("unexpected %q", r)
+			}
+			tokens = append(tokens, dotToken{kind: dotTokenID, value: source[i:j]})
+			i = j
+		default:
+			return nil, errors.Errorf("unexpected %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+func isDOTIDRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || r >= 0x80
+}
+
+func copyAttrs(attrs map[string]string) map[string]string {
+	result := make(map[string]string, len(attrs))
+	for key, value := range attrs {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+// Package docextract extracts the plain text of document attachments. DOCX,
+// XLSX, plain text and CSV files are read natively; PDFs rely on the poppler
+// pdftotext command line tool and are unsupported when it is not installed.
+package docextract
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultPDFCommand is the poppler text extractor looked up on PATH.
+	DefaultPDFCommand = "pdftotext"
+
+	// MaxTextBytes bounds the extracted text kept for a document.
+	MaxTextBytes = 1 << 20
+	// maxPartBytes bounds how much of a single compressed part of a DOCX or
+	// XLSX archive is read, guarding against zip bombs.
+	maxPartBytes = 64 << 20
+)
+
+// Document is the text of a document.
+type Document struct {
+	Text string
+	// PageCount is the number of pages of PDF and DOCX documents, or zero
+	// when the format has no pages or does not record them.
+	PageCount int
+	// Truncated reports whether Text was cut at MaxTextBytes.
+	Truncated bool
+}
+
+const (
+	pdfType  = "application/pdf"
+	docxType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	xlsxType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// textTypes are the plain text formats whose content is the text itself.
+var textTypes = map[string]bool{
+	"text/plain":    true,
+	"text/csv":      true,
+	"text/markdown": true,
+}
+
+// Extractor extracts document text, using pdftotext when it was found.
+type Extractor struct {
+	pdfCommand string
+}
+
+// New constructs an Extractor. An empty command uses DefaultPDFCommand, and a
+// command that cannot be found disables PDF extraction instead of failing.
+func New(pdfCommand string) *Extractor {
+	if pdfCommand == "" {
+		pdfCommand = DefaultPDFCommand
+	}
+	path, err := exec.LookPath(pdfCommand)
+	if err != nil {
+		path = ""
+	}
+	return &Extractor{pdfCommand: path}
+}
+
+// Supports reports whether text can be extracted from documents of the type.
+func (e *Extractor) Supports(mimeType string) bool {
+	switch {
+	case mimeType == pdfType:
+		return e.pdfCommand != ""
+	case mimeType == docxType, mimeType == xlsxType:
+		return true
+	default:
+		return textTypes[mimeType]
+	}
+}
+
+// Extract returns the text of the document in the file.
+func (e *Extractor) Extract(ctx context.Context, mimeType, path string) (*Document, error) {
+	var (
+		document *Document
+		err      error
+	)
+	switch {
+	case mimeType == pdfType:
+		document, err = e.extractPDF(ctx, path)
+	case mimeType == docxType:
+		document, err = extractDOCX(path)
+	case mimeType == xlsxType:
+		document, err = extractXLSX(path)
+	case textTypes[mimeType]:
+		document, err = extractText(path)
+	default:
+		return nil, errors.Errorf("extracting text from %s is not supported", mimeType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	document.Text, document.Truncated = truncateText(strings.TrimSpace(document.Text))
+	return document, nil
+}
+
+// extractPDF runs pdftotext, which ends every page with a form feed.
+func (e *Extractor) extractPDF(ctx context.Context, path string) (*Document, error) {
+	if e.pdfCommand == "" {
+		return nil, errors.New("pdftotext is not available")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.pdfCommand, "-q", "-enc", "UTF-8", path, "-")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "pdftotext failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	text := stdout.String()
+	return &Document{
+		Text:      strings.ReplaceAll(text, "\f", "\n\n"),
+		PageCount: strings.Count(text, "\f"),
+	}, nil
+}
+
+func extractText(path string) (*Document, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open document")
+	}
+	defer file.Close()
+	// Read one byte past the limit to learn whether the text is truncated.
+	data, err := io.ReadAll(io.LimitReader(file, MaxTextBytes+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read document")
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	return &Document{Text: string(bytes.ToValidUTF8(data, []byte("�")))}, nil
+}
+
+// truncateText cuts text at MaxTextBytes without splitting a character.
+func truncateText(text string) (string, bool) {
+	if len(text) <= MaxTextBytes {
+		return text, false
+	}
+	cut := MaxTextBytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut], true
+}
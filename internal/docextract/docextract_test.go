@@ -0,0 +1,136 @@
+package docextract_test
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/docextract"
+)
+
+const (
+	docxType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	xlsxType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// writeArchive writes a zip archive with the given parts.
+func writeArchive(t *testing.T, name string, parts map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	writer := zip.NewWriter(file)
+	for partName, content := range parts {
+		part, err := writer.Create(partName)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, file.Close())
+	return path
+}
+
+func TestExtractDOCX(t *testing.T) {
+	t.Parallel()
+
+	path := writeArchive(t, "report.docx", map[string]string{
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:body>
+    <w:p><w:r><w:t>Quarterly</w:t></w:r><w:r><w:t xml:space="preserve"> report</w:t></w:r></w:p>
+    <w:p><w:r><w:t>Revenue</w:t><w:tab/><w:t>42</w:t><w:br/><w:t>Costs</w:t></w:r></w:p>
+    <w:p><w:del><w:r><w:delText>removed</w:delText></w:r></w:del></w:p>
+  </w:body>
+</w:document>`,
+		"docProps/app.xml": `<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"><Pages>3</Pages></Properties>`,
+	})
+
+	document, err := docextract.New("missing-pdftotext").Extract(context.Background(), docxType, path)
+	require.NoError(t, err)
+	require.Equal(t, "Quarterly report\nRevenue\t42\nCosts", document.Text)
+	require.Equal(t, 3, document.PageCount)
+	require.False(t, document.Truncated)
+}
+
+func TestExtractXLSX(t *testing.T) {
+	t.Parallel()
+
+	path := writeArchive(t, "budget.xlsx", map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets><sheet name="Summary" sheetId="1" r:id="rId2"/><sheet name="Detail" sheetId="2" r:id="rId1"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="worksheet" Target="worksheets/sheet2.xml"/>
+  <Relationship Id="rId2" Type="worksheet" Target="/xl/worksheets/sheet1.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>Item</t></si>
+  <si><r><t>Tot</t></r><r><t>al</t></r><rPh><t>ignored</t></rPh></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+  <row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+  <row r="2"><c r="A2" t="inlineStr"><is><t>Coffee</t></is></c><c r="B2"><v>12.5</v></c><c r="C2" t="b"><v>1</v></c></row>
+</sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+  <row r="1"><c r="A1" t="str"><f>A2</f><v>Beans</v></c></row>
+</sheetData></worksheet>`,
+	})
+
+	document, err := docextract.New("missing-pdftotext").Extract(context.Background(), xlsxType, path)
+	require.NoError(t, err)
+	require.Equal(t, "Summary\nItem\tTotal\nCoffee\t12.5\tTRUE\n\nDetail\nBeans", document.Text)
+	require.Zero(t, document.PageCount)
+}
+
+func TestExtractText(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "notes.csv")
+	require.NoError(t, os.WriteFile(path, []byte("\xef\xbb\xbfname,amount\ncafé,3\n\xff"), 0o600))
+	document, err := docextract.New("missing-pdftotext").Extract(context.Background(), "text/csv", path)
+	require.NoError(t, err)
+	require.Equal(t, "name,amount\ncafé,3\n�", document.Text)
+
+	// Long text is cut without splitting a character.
+	long := strings.Repeat("é", docextract.MaxTextBytes)
+	require.NoError(t, os.WriteFile(path, []byte(long), 0o600))
+	document, err = docextract.New("missing-pdftotext").Extract(context.Background(), "text/plain", path)
+	require.NoError(t, err)
+	require.True(t, document.Truncated)
+	require.Len(t, document.Text, docextract.MaxTextBytes)
+	require.True(t, strings.HasSuffix(document.Text, "é"))
+}
+
+func TestExtractPDF(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, docextract.New("missing-pdftotext").Supports("application/pdf"))
+	if runtime.GOOS == "windows" {
+		t.Skip("fake pdftotext requires a POSIX shell")
+	}
+	command := filepath.Join(t.TempDir(), "pdftotext")
+	require.NoError(t, os.WriteFile(command, []byte("#!/bin/sh\nprintf 'First page\\fSecond page\\f'\n"), 0o755))
+
+	extractor := docextract.New(command)
+	require.True(t, extractor.Supports("application/pdf"))
+	document, err := extractor.Extract(context.Background(), "application/pdf", "input.pdf")
+	require.NoError(t, err)
+	require.Equal(t, "First page\n\nSecond page", document.Text)
+	require.Equal(t, 2, document.PageCount)
+}
+
+func TestExtractUnsupported(t *testing.T) {
+	t.Parallel()
+
+	extractor := docextract.New("missing-pdftotext")
+	require.False(t, extractor.Supports("image/png"))
+	_, err := extractor.Extract(context.Background(), "image/png", "image.png")
+	require.ErrorContains(t, err, "not supported")
+}
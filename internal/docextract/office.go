@@ -0,0 +1,366 @@
+package docextract
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Office Open XML namespaces of the elements read from DOCX and XLSX files.
+const (
+	wordprocessingNamespace = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	spreadsheetNamespace    = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	relationshipsNamespace  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+// extractDOCX reads the paragraphs of the main document part. Headers,
+// footers, comments and tracked deletions are left out.
+func extractDOCX(filePath string) (*Document, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open DOCX archive")
+	}
+	defer archive.Close()
+
+	decoder, closePart, err := openPart(&archive.Reader, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+	defer closePart()
+
+	var text strings.Builder
+	inText := false
+	for text.Len() <= MaxTextBytes {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse DOCX document")
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if token.Name.Space != wordprocessingNamespace {
+				continue
+			}
+			switch token.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				text.WriteByte('\t')
+			case "br", "cr":
+				text.WriteByte('\n')
+			}
+		case xml.EndElement:
+			if token.Name.Space != wordprocessingNamespace {
+				continue
+			}
+			switch token.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(token)
+			}
+		}
+	}
+
+	return &Document{
+		Text:      text.String(),
+		PageCount: readDOCXPageCount(&archive.Reader),
+	}, nil
+}
+
+// readDOCXPageCount returns the page count Word recorded when the document
+// was last saved, or zero when it is missing.
+func readDOCXPageCount(archive *zip.Reader) int {
+	decoder, closePart, err := openPart(archive, "docProps/app.xml")
+	if err != nil {
+		return 0
+	}
+	defer closePart()
+	var properties struct {
+		Pages int `xml:"Pages"`
+	}
+	if err := decoder.Decode(&properties); err != nil {
+		return 0
+	}
+	return properties.Pages
+}
+
+// extractXLSX reads the cell values of every worksheet in workbook order.
+// Each sheet starts with its name; cells are separated by tabs and rows by
+// line breaks.
+func extractXLSX(filePath string) (*Document, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open XLSX archive")
+	}
+	defer archive.Close()
+
+	sharedStrings, err := readSharedStrings(&archive.Reader)
+	if err != nil {
+		return nil, err
+	}
+	sheets, err := readWorkbookSheets(&archive.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var text strings.Builder
+	for _, sheet := range sheets {
+		if text.Len() > MaxTextBytes {
+			break
+		}
+		if text.Len() > 0 {
+			text.WriteByte('\n')
+		}
+		text.WriteString(sheet.name)
+		text.WriteByte('\n')
+		if err := readWorksheet(&archive.Reader, sheet.part, sharedStrings, &text); err != nil {
+			return nil, err
+		}
+	}
+	return &Document{Text: text.String()}, nil
+}
+
+type workbookSheet struct {
+	name string
+	part string
+}
+
+// readWorkbookSheets lists the worksheets of a workbook with the archive
+// parts holding them.
+func readWorkbookSheets(archive *zip.Reader) ([]workbookSheet, error) {
+	decoder, closePart, err := openPart(archive, "xl/_rels/workbook.xml.rels")
+	if err != nil {
+		return nil, err
+	}
+	var relationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	err = decoder.Decode(&relationships)
+	closePart()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse XLSX relationships")
+	}
+	targets := make(map[string]string, len(relationships.Relationships))
+	for _, relationship := range relationships.Relationships {
+		target := relationship.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[relationship.ID] = target
+	}
+
+	decoder, closePart, err = openPart(archive, "xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+	defer closePart()
+	var workbook struct {
+		Sheets []struct {
+			Name string     `xml:"name,attr"`
+			Attr []xml.Attr `xml:",any,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decoder.Decode(&workbook); err != nil {
+		return nil, errors.Wrap(err, "failed to parse XLSX workbook")
+	}
+	sheets := make([]workbookSheet, 0, len(workbook.Sheets))
+	for _, sheet := range workbook.Sheets {
+		for _, attr := range sheet.Attr {
+			if attr.Name.Space == relationshipsNamespace && attr.Name.Local == "id" {
+				if target, ok := targets[attr.Value]; ok {
+					sheets = append(sheets, workbookSheet{name: sheet.Name, part: target})
+				}
+			}
+		}
+	}
+	return sheets, nil
+}
+
+// readSharedStrings returns the shared string table that cells refer to by
+// index. Workbooks without shared strings have an empty table.
+func readSharedStrings(archive *zip.Reader) ([]string, error) {
+	decoder, closePart, err := openPart(archive, "xl/sharedStrings.xml")
+	if errors.Is(err, errPartNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer closePart()
+
+	var (
+		sharedStrings []string
+		current       strings.Builder
+		inText        bool
+		inPhonetic    bool
+	)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return sharedStrings, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse XLSX shared strings")
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if token.Name.Space != spreadsheetNamespace {
+				continue
+			}
+			switch token.Name.Local {
+			case "si":
+				current.Reset()
+			case "t":
+				inText = true
+			case "rPh":
+				// Phonetic guides repeat the text in another script.
+				inPhonetic = true
+			}
+		case xml.EndElement:
+			if token.Name.Space != spreadsheetNamespace {
+				continue
+			}
+			switch token.Name.Local {
+			case "si":
+				sharedStrings = append(sharedStrings, current.String())
+			case "t":
+				inText = false
+			case "rPh":
+				inPhonetic = false
+			}
+		case xml.CharData:
+			if inText && !inPhonetic {
+				current.Write(token)
+			}
+		}
+	}
+}
+
+// readWorksheet appends the cell values of a worksheet to text.
+func readWorksheet(archive *zip.Reader, part string, sharedStrings []string, text *strings.Builder) error {
+	decoder, closePart, err := openPart(archive, part)
+	if err != nil {
+		return err
+	}
+	defer closePart()
+
+	var (
+		row       []string
+		cellType  string
+		value     strings.Builder
+		inValue   bool
+		inlineStr bool
+	)
+	for text.Len() <= MaxTextBytes {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to parse XLSX worksheet")
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if token.Name.Space != spreadsheetNamespace {
+				continue
+			}
+			switch token.Name.Local {
+			case "row":
+				row = row[:0]
+			case "c":
+				cellType = ""
+				for _, attr := range token.Attr {
+					if attr.Name.Local == "t" {
+						cellType = attr.Value
+					}
+				}
+				value.Reset()
+			case "v":
+				inValue = true
+			case "is":
+				inlineStr = true
+			case "t":
+				inValue = inlineStr
+			}
+		case xml.EndElement:
+			if token.Name.Space != spreadsheetNamespace {
+				continue
+			}
+			switch token.Name.Local {
+			case "v", "t":
+				inValue = false
+			case "is":
+				inlineStr = false
+			case "c":
+				if cell := formatCell(cellType, value.String(), sharedStrings); cell != "" {
+					row = append(row, cell)
+				}
+			case "row":
+				if len(row) > 0 {
+					text.WriteString(strings.Join(row, "\t"))
+					text.WriteByte('\n')
+				}
+			}
+		case xml.CharData:
+			if inValue {
+				value.Write(token)
+			}
+		}
+	}
+	return nil
+}
+
+// formatCell returns the text of a cell from its type and raw value.
+func formatCell(cellType, value string, sharedStrings []string) string {
+	switch cellType {
+	case "s":
+		index, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || index < 0 || index >= len(sharedStrings) {
+			return ""
+		}
+		return sharedStrings[index]
+	case "b":
+		if value == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		return value
+	}
+}
+
+var errPartNotFound = errors.New("archive part not found")
+
+// openPart returns a decoder for an XML part of an Office Open XML archive.
+// The part is read up to maxPartBytes.
+func openPart(archive *zip.Reader, name string) (*xml.Decoder, func(), error) {
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to open %s", name)
+		}
+		decoder := xml.NewDecoder(io.LimitReader(reader, maxPartBytes))
+		return decoder, func() { reader.Close() }, nil
+	}
+	return nil, nil, errors.Wrapf(errPartNotFound, "%s", name)
+}
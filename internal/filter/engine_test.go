@@ -83,9 +83,10 @@ func TestCompileContainsEscapesLikeWildcards(t *testing.T) {
 	stmt, err := engine.CompileToStatement(context.Background(), `content.contains("50%_off")`, RenderOptions{Dialect: DialectSQLite})
 	require.NoError(t, err)
 	// The % and _ in the value must be escaped so they are matched literally,
-	// and SQLite needs an explicit ESCAPE clause.
+	// and SQLite needs an explicit ESCAPE clause. The pattern is bound once for
+	// the content, once for attachment transcripts and once for document text.
 	require.Contains(t, stmt.SQL, `ESCAPE '\'`)
-	require.Equal(t, []any{`%50\%\_off%`}, stmt.Args)
+	require.Equal(t, []any{`%50\%\_off%`, `%50\%\_off%`, `%50\%\_off%`}, stmt.Args)
 }
 
 func TestRenderTagMembershipIsExactPerDialect(t *testing.T) {
@@ -462,7 +463,7 @@ func TestRenderTextMatchEscaping(t *testing.T) {
 	// Both % and _ in the value must be escaped so they match literally.
 	stmt, err := engine.CompileToStatement(context.Background(), `content.contains("a%b_c")`, RenderOptions{Dialect: DialectSQLite})
 	require.NoError(t, err)
-	require.Equal(t, []any{`%a\%b\_c%`}, stmt.Args)
+	require.Equal(t, []any{`%a\%b\_c%`, `%a\%b\_c%`, `%a\%b\_c%`}, stmt.Args)
 }
 
 func TestRenderAllRejectsUnsupportedPredicate(t *testing.T) {
@@ -616,3 +617,208 @@ func TestHasLocationSQLiteBehavior(t *testing.T) {
 		require.Equal(t, tc.want, selectMemoIDs(t, db, stmt), tc.expr)
 	}
 }
+
+func TestRenderTranscriptPerDialect(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewSchema())
+	require.NoError(t, err)
+
+	cases := []struct {
+		dialect DialectName
+		sql     string
+	}{
+		{DialectSQLite, "EXISTS (SELECT 1 FROM `attachment` WHERE `attachment`.`memo_id` = `memo`.`id` AND memos_unicode_lower(JSON_EXTRACT(`attachment`.`payload`, '$.transcript.text')) LIKE memos_unicode_lower(?) ESCAPE '\\')"},
+		{DialectMySQL, "EXISTS (SELECT 1 FROM `attachment` WHERE `attachment`.`memo_id` = `memo`.`id` AND JSON_UNQUOTE(JSON_EXTRACT(`attachment`.`payload`, '$.transcript.text')) LIKE ?)"},
+		{DialectPostgres, "EXISTS (SELECT 1 FROM attachment WHERE attachment.memo_id = memo.id AND ((attachment.payload)::jsonb->'transcript'->>'text') ILIKE $1)"},
+	}
+	for _, tc := range cases {
+		stmt, err := engine.CompileToStatement(context.Background(), `transcript.contains("standup")`, RenderOptions{Dialect: tc.dialect})
+		require.NoError(t, err, tc.dialect)
+		require.Equal(t, tc.sql, stmt.SQL, tc.dialect)
+		require.Equal(t, []any{"%standup%"}, stmt.Args, tc.dialect)
+	}
+}
+
+func TestRenderContentContainsIncludesAttachmentText(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewSchema())
+	require.NoError(t, err)
+
+	stmt, err := engine.CompileToStatement(context.Background(), `content.contains("standup")`, RenderOptions{Dialect: DialectPostgres})
+	require.NoError(t, err)
+	require.Equal(t, "(memo.content ILIKE $1 OR EXISTS (SELECT 1 FROM attachment WHERE attachment.memo_id = memo.id AND ((attachment.payload)::jsonb->'transcript'->>'text') ILIKE $2) OR EXISTS (SELECT 1 FROM attachment WHERE attachment.memo_id = memo.id AND ((attachment.payload)::jsonb->'document'->>'text') ILIKE $3))", stmt.SQL)
+	require.Equal(t, []any{"%standup%", "%standup%", "%standup%"}, stmt.Args)
+
+	// Prefix and suffix matches stay anchored to the memo content itself.
+	stmt, err = engine.CompileToStatement(context.Background(), `content.startsWith("standup")`, RenderOptions{Dialect: DialectPostgres})
+	require.NoError(t, err)
+	require.Equal(t, "memo.content ILIKE $1", stmt.SQL)
+}
+
+func TestCompileRejectsTranscriptComparison(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewSchema())
+	require.NoError(t, err)
+
+	for _, expression := range []string{`transcript == "standup"`, `size(transcript) > 0`, `transcript in ["standup"]`} {
+		_, err = engine.Compile(context.Background(), expression)
+		require.Error(t, err, expression)
+		require.Contains(t, err.Error(), "only supports text matching", expression)
+	}
+}
+
+func TestRenderAttachmentTranscriptPerDialect(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewAttachmentSchema())
+	require.NoError(t, err)
+
+	cases := []struct {
+		dialect DialectName
+		sql     string
+	}{
+		{DialectSQLite, "memos_unicode_lower(JSON_EXTRACT(`attachment`.`payload`, '$.transcript.text')) LIKE memos_unicode_lower(?) ESCAPE '\\'"},
+		{DialectMySQL, "JSON_UNQUOTE(JSON_EXTRACT(`attachment`.`payload`, '$.transcript.text')) LIKE ?"},
+		{DialectPostgres, "((attachment.payload)::jsonb->'transcript'->>'text') ILIKE $1"},
+	}
+	for _, tc := range cases {
+		stmt, err := engine.CompileToStatement(context.Background(), `transcript.contains("standup")`, RenderOptions{Dialect: tc.dialect})
+		require.NoError(t, err, tc.dialect)
+		require.Equal(t, tc.sql, stmt.SQL, tc.dialect)
+	}
+}
+
+func TestRenderAttachmentDocumentTextPerDialect(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewAttachmentSchema())
+	require.NoError(t, err)
+
+	cases := []struct {
+		dialect DialectName
+		sql     string
+	}{
+		{DialectSQLite, "memos_unicode_lower(JSON_EXTRACT(`attachment`.`payload`, '$.document.text')) LIKE memos_unicode_lower(?) ESCAPE '\\'"},
+		{DialectMySQL, "JSON_UNQUOTE(JSON_EXTRACT(`attachment`.`payload`, '$.document.text')) LIKE ?"},
+		{DialectPostgres, "((attachment.payload)::jsonb->'document'->>'text') ILIKE $1"},
+	}
+	for _, tc := range cases {
+		stmt, err := engine.CompileToStatement(context.Background(), `document_text.contains("invoice")`, RenderOptions{Dialect: tc.dialect})
+		require.NoError(t, err, tc.dialect)
+		require.Equal(t, tc.sql, stmt.SQL, tc.dialect)
+	}
+}
+
+func TestRenderAttachmentImageAnalysisPerDialect(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewAttachmentSchema())
+	require.NoError(t, err)
+
+	cases := []struct {
+		dialect DialectName
+		sql     string
+	}{
+		{DialectSQLite, "(memos_unicode_lower(JSON_EXTRACT(`attachment`.`payload`, '$.imageAnalysis.text')) LIKE memos_unicode_lower(?) ESCAPE '\\' OR memos_unicode_lower(JSON_EXTRACT(`attachment`.`payload`, '$.imageAnalysis.caption')) LIKE memos_unicode_lower(?) ESCAPE '\\')"},
+		{DialectMySQL, "(JSON_UNQUOTE(JSON_EXTRACT(`attachment`.`payload`, '$.imageAnalysis.text')) LIKE ? OR JSON_UNQUOTE(JSON_EXTRACT(`attachment`.`payload`, '$.imageAnalysis.caption')) LIKE ?)"},
+		{DialectPostgres, "(((attachment.payload)::jsonb->'imageAnalysis'->>'text') ILIKE $1 OR ((attachment.payload)::jsonb->'imageAnalysis'->>'caption') ILIKE $2)"},
+	}
+	for _, tc := range cases {
+		stmt, err := engine.CompileToStatement(context.Background(), `image_text.contains("invoice") || image_caption.contains("invoice")`, RenderOptions{Dialect: tc.dialect})
+		require.NoError(t, err, tc.dialect)
+		require.Equal(t, tc.sql, stmt.SQL, tc.dialect)
+	}
+}
+
+func TestRenderMemoLinksPerDialect(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewSchema())
+	require.NoError(t, err)
+
+	cases := []struct {
+		dialect DialectName
+		sql     string
+	}{
+		{DialectSQLite, "(EXISTS (SELECT 1 FROM `memo_relation` WHERE `memo_relation`.`memo_id` = `memo`.`id` AND `memo_relation`.`type` = ? AND `memo_relation`.`related_memo_id` IN (SELECT `linked_memo`.`id` FROM `memo` AS `linked_memo` WHERE `linked_memo`.`uid` = ?)) AND (SELECT COUNT(*) FROM `memo_relation` WHERE `memo_relation`.`related_memo_id` = `memo`.`id` AND `memo_relation`.`type` = ?) > ?)"},
+		{DialectMySQL, "(EXISTS (SELECT 1 FROM `memo_relation` WHERE `memo_relation`.`memo_id` = `memo`.`id` AND `memo_relation`.`type` = ? AND `memo_relation`.`related_memo_id` IN (SELECT `linked_memo`.`id` FROM `memo` AS `linked_memo` WHERE `linked_memo`.`uid` = ?)) AND (SELECT COUNT(*) FROM `memo_relation` WHERE `memo_relation`.`related_memo_id` = `memo`.`id` AND `memo_relation`.`type` = ?) > ?)"},
+		{DialectPostgres, "(EXISTS (SELECT 1 FROM memo_relation WHERE memo_relation.memo_id = memo.id AND memo_relation.type = $1 AND memo_relation.related_memo_id IN (SELECT linked_memo.id FROM memo AS linked_memo WHERE linked_memo.uid = $2)) AND (SELECT COUNT(*) FROM memo_relation WHERE memo_relation.related_memo_id = memo.id AND memo_relation.type = $3) > $4)"},
+	}
+	for _, tc := range cases {
+		stmt, err := engine.CompileToStatement(context.Background(), `"memos/abc123" in references && size(referenced_by) > 0`, RenderOptions{Dialect: tc.dialect})
+		require.NoError(t, err, tc.dialect)
+		require.Equal(t, tc.sql, stmt.SQL, tc.dialect)
+		require.Equal(t, []any{"REFERENCE", "abc123", "REFERENCE", int64(0)}, stmt.Args, tc.dialect)
+	}
+}
+
+func TestRenderMemoLinksRejectsOtherNames(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewSchema())
+	require.NoError(t, err)
+
+	stmt, err := engine.CompileToStatement(context.Background(), `"users/abc123" in references`, RenderOptions{Dialect: DialectSQLite})
+	require.NoError(t, err)
+	require.Equal(t, "1 = 0", stmt.SQL)
+}
+
+func TestRenderMentions(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewSchema())
+	require.NoError(t, err)
+
+	stmt, err := engine.CompileToStatement(context.Background(), `"users/alice" in mentions`, RenderOptions{Dialect: DialectSQLite})
+	require.NoError(t, err)
+	require.Equal(t, "EXISTS (SELECT 1 FROM json_each(COALESCE(JSON_EXTRACT(`memo`.`payload`, '$.mentions'), JSON_ARRAY())) AS tag_item WHERE (tag_item.value COLLATE BINARY) = (? COLLATE BINARY))", stmt.SQL)
+	require.Equal(t, []any{"alice"}, stmt.Args)
+
+	stmt, err = engine.CompileToStatement(context.Background(), `"alice" in mentions`, RenderOptions{Dialect: DialectSQLite})
+	require.NoError(t, err)
+	require.Equal(t, "1 = 0", stmt.SQL)
+
+	_, err = engine.CompileToStatement(context.Background(), `mentions.exists(m, m.startsWith("users/a"))`, RenderOptions{Dialect: DialectSQLite})
+	require.Error(t, err)
+}
+
+func TestRenderPropsPerDialect(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewSchema())
+	require.NoError(t, err)
+
+	cases := []struct {
+		dialect DialectName
+		sql     string
+	}{
+		{DialectSQLite, "(JSON_EXTRACT(`memo`.`payload`, '$.properties.status.stringValue') = ? AND COALESCE(JSON_EXTRACT(`memo`.`payload`, '$.properties.due.numberValue'), CAST(JSON_EXTRACT(`memo`.`payload`, '$.properties.due.dateTs') AS INTEGER)) < ?)"},
+		{DialectMySQL, "(JSON_UNQUOTE(JSON_EXTRACT(`memo`.`payload`, '$.properties.status.stringValue')) = ? AND COALESCE(CAST(JSON_EXTRACT(`memo`.`payload`, '$.properties.due.numberValue') AS DOUBLE), CAST(JSON_UNQUOTE(JSON_EXTRACT(`memo`.`payload`, '$.properties.due.dateTs')) AS SIGNED)) < ?)"},
+		{DialectPostgres, "(memo.payload->'properties'->'status'->>'stringValue' = $1 AND COALESCE((memo.payload->'properties'->'due'->>'numberValue')::double precision, (memo.payload->'properties'->'due'->>'dateTs')::bigint) < $2)"},
+	}
+	for _, tc := range cases {
+		stmt, err := engine.CompileToStatement(context.Background(), `props.status == "done" && props.due < timestamp("2026-01-01T00:00:00Z")`, RenderOptions{Dialect: tc.dialect})
+		require.NoError(t, err, tc.dialect)
+		require.Equal(t, tc.sql, stmt.SQL, tc.dialect)
+		require.Equal(t, []any{"done", int64(1767225600)}, stmt.Args, tc.dialect)
+	}
+}
+
+func TestRenderPropsRejectsInvalidKeys(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(NewSchema())
+	require.NoError(t, err)
+
+	for _, filter := range []string{
+		`props["due date"] == "x"`,
+		`props["a'b"] == "x"`,
+		`has(props.status)`,
+	} {
+		_, err := engine.CompileToStatement(context.Background(), filter, RenderOptions{Dialect: DialectSQLite})
+		require.Error(t, err, filter)
+	}
+}
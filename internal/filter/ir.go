@@ -65,10 +65,12 @@ type InCondition struct {
 
 func (*InCondition) isCondition() {}
 
-// ElementInCondition represents the CEL syntax `"value" in field`.
+// ElementInCondition represents the CEL syntax `"value" in field`, or
+// `"value" in field.key` when Key names an entry of a property map field.
 type ElementInCondition struct {
 	Element ValueExpr
 	Field   string
+	Key     string
 }
 
 func (*ElementInCondition) isCondition() {}
@@ -134,6 +136,15 @@ type FunctionValue struct {
 
 func (*FunctionValue) isValueExpr() {}
 
+// PropertyRef references one entry of a property map field, such as
+// props.status.
+type PropertyRef struct {
+	Field string
+	Key   string
+}
+
+func (*PropertyRef) isValueExpr() {}
+
 // FieldAccessorValue captures a CEL timestamp accessor on a field, such as
 // created_ts.getMonth(). It renders to a dialect-specific date-part extraction.
 type FieldAccessorValue struct {
@@ -1,6 +1,7 @@
 package filter
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/pkg/errors"
@@ -40,6 +41,8 @@ func buildCondition(expr *exprv1.Expr, pc parseContext) (Condition, error) {
 		return &FieldPredicateCondition{Field: name}, nil
 	case *exprv1.Expr_ComprehensionExpr:
 		return buildComprehensionCondition(v.ComprehensionExpr, pc.schema)
+	case *exprv1.Expr_SelectExpr:
+		return buildPropertyPredicate(expr, pc.schema)
 	default:
 		return nil, errors.New("unsupported top-level expression")
 	}
@@ -104,6 +107,8 @@ func buildCallCondition(call *exprv1.Expr_Call, pc parseContext) (Condition, err
 		return buildMatchesCondition(call, pc.schema)
 	case "sets.contains", "sets.intersects", "sets.equivalent":
 		return buildSetCondition(call, pc)
+	case "_[_]":
+		return buildPropertyPredicate(&exprv1.Expr{ExprKind: &exprv1.Expr_CallExpr{CallExpr: call}}, pc.schema)
 	default:
 		val, ok, err := evaluateBool(call)
 		if err != nil {
@@ -272,6 +277,8 @@ func buildInCondition(call *exprv1.Expr_Call, pc parseContext) (Condition, error
 			}
 		} else if !ok {
 			return nil, errors.Errorf("unknown identifier %q", identName)
+		} else if field.Relation != nil {
+			return nil, errors.Errorf("identifier %q only supports text matching", identName)
 		}
 
 		if listExpr := call.Args[1].GetListExpr(); listExpr != nil {
@@ -290,6 +297,21 @@ func buildInCondition(call *exprv1.Expr_Call, pc parseContext) (Condition, error
 		}
 	}
 
+	// Handle "value in props.key" syntax.
+	if ref, ok, err := buildPropertyRef(call.Args[1], pc.schema); err != nil {
+		return nil, err
+	} else if ok {
+		element, err := buildValueExpr(call.Args[0], pc)
+		if err != nil {
+			return nil, err
+		}
+		return &ElementInCondition{
+			Element: element,
+			Field:   ref.Field,
+			Key:     ref.Key,
+		}, nil
+	}
+
 	// Handle "value in identifier" syntax.
 	if identName, err := getIdentName(call.Args[1]); err == nil {
 		if _, ok := pc.schema.Field(identName); !ok {
@@ -376,14 +398,24 @@ func buildMatchesCondition(call *exprv1.Expr_Call, schema Schema) (Condition, er
 }
 
 func buildValueExpr(expr *exprv1.Expr, pc parseContext) (ValueExpr, error) {
+	if ref, ok, err := buildPropertyRef(expr, pc.schema); err != nil {
+		return nil, err
+	} else if ok {
+		return ref, nil
+	}
+
 	if identName, err := getIdentName(expr); err == nil {
 		// `now` is not a schema field; it folds to the frozen evaluation time.
 		if identName == "now" {
 			return &LiteralValue{Value: pc.now.Unix()}, nil
 		}
-		if _, ok := pc.schema.Field(identName); !ok {
+		field, ok := pc.schema.Field(identName)
+		if !ok {
 			return nil, errors.Errorf("unknown identifier %q", identName)
 		}
+		if field.Relation != nil {
+			return nil, errors.Errorf("identifier %q only supports text matching", identName)
+		}
 		return &FieldRef{Name: identName}, nil
 	}
 
@@ -436,6 +468,68 @@ func buildValueExpr(expr *exprv1.Expr, pc parseContext) (ValueExpr, error) {
 	return nil, errors.New("unsupported value expression")
 }
 
+// propertyKeyPattern limits property keys to identifiers so that they can be
+// spliced into JSON paths on every dialect.
+var propertyKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// buildPropertyRef converts props.key and props["key"] on a property map
+// field into a PropertyRef. It reports false for any other expression.
+func buildPropertyRef(expr *exprv1.Expr, schema Schema) (*PropertyRef, bool, error) {
+	var operand *exprv1.Expr
+	var key string
+	if selectExpr := expr.GetSelectExpr(); selectExpr != nil {
+		if selectExpr.TestOnly {
+			return nil, false, errors.New("has() is not supported")
+		}
+		operand, key = selectExpr.Operand, selectExpr.Field
+	} else if call := expr.GetCallExpr(); call != nil && call.Function == "_[_]" && len(call.Args) == 2 {
+		value, err := getConstValue(call.Args[1])
+		if err != nil {
+			return nil, false, errors.Wrap(err, "property index must be a literal")
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, false, errors.New("property index must be a string")
+		}
+		operand, key = call.Args[0], str
+	} else {
+		return nil, false, nil
+	}
+
+	name, err := getIdentName(operand)
+	if err != nil {
+		return nil, false, errors.New("property access requires a field operand")
+	}
+	field, ok := schema.Field(name)
+	if !ok {
+		return nil, false, errors.Errorf("unknown identifier %q", name)
+	}
+	if field.Kind != FieldKindPropertyMap {
+		return nil, false, errors.Errorf("identifier %q has no properties", name)
+	}
+	if !propertyKeyPattern.MatchString(key) {
+		return nil, false, errors.Errorf("invalid property key %q", key)
+	}
+	return &PropertyRef{Field: name, Key: key}, true, nil
+}
+
+// buildPropertyPredicate treats a bare property reference as a check that the
+// property is true.
+func buildPropertyPredicate(expr *exprv1.Expr, schema Schema) (Condition, error) {
+	ref, ok, err := buildPropertyRef(expr, schema)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("unsupported top-level expression")
+	}
+	return &ComparisonCondition{
+		Left:     ref,
+		Operator: CompareEq,
+		Right:    &LiteralValue{Value: true},
+	}, nil
+}
+
 func toComparisonOperator(fn string) (ComparisonOperator, error) {
 	switch fn {
 	case "_==_":
@@ -703,7 +797,7 @@ func foldNowAccessor(accessor string, now time.Time) int64 {
 // buildSetCondition desugars ext.Sets() operations over a JSON list field into
 // existing IR: membership reduces to ElementInCondition, and equivalence adds a
 // length check. This relies on the list field being a set (no duplicates), which
-// holds for memo tags.
+// holds for memo tags and memo links.
 func buildSetCondition(call *exprv1.Expr_Call, pc parseContext) (Condition, error) {
 	if len(call.Args) != 2 {
 		return nil, errors.Errorf("%s expects two arguments", call.Function)
@@ -717,7 +811,7 @@ func buildSetCondition(call *exprv1.Expr_Call, pc parseContext) (Condition, erro
 	if !ok {
 		return nil, errors.Errorf("unknown identifier %q", fieldName)
 	}
-	if field.Kind != FieldKindJSONList {
+	if field.Kind != FieldKindJSONList && field.Kind != FieldKindMemoLinkList {
 		return nil, errors.Errorf("set operations require a list field, got %q", fieldName)
 	}
 
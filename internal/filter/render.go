@@ -2,6 +2,7 @@ package filter
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -177,6 +178,8 @@ func (r *renderer) renderComparison(cond *ComparisonCondition) (renderResult, er
 		return r.renderFunctionComparison(left, cond.Operator, cond.Right)
 	case *FieldAccessorValue:
 		return r.renderAccessorComparison(left, cond.Operator, cond.Right)
+	case *PropertyRef:
+		return r.renderPropertyComparison(left, cond.Operator, cond.Right)
 	default:
 		return renderResult{}, errors.New("comparison must start with a field reference or supported function")
 	}
@@ -286,6 +289,8 @@ func (r *renderer) renderFunctionComparison(fn *FunctionValue, op ComparisonOper
 	switch {
 	case field.Kind == FieldKindJSONList:
 		expr = jsonArrayLengthExpr(r.dialect, field)
+	case field.Kind == FieldKindMemoLinkList:
+		expr = fmt.Sprintf("(SELECT COUNT(*) %s)", r.memoLinkRows(field))
 	case field.Kind == FieldKindScalar && field.Type == FieldTypeString:
 		expr = stringLengthExpr(r.dialect, field.columnExpr(r.dialect))
 	default:
@@ -479,8 +484,11 @@ func (r *renderer) renderElementInCondition(cond *ElementInCondition) (renderRes
 	if !ok {
 		return renderResult{}, errors.Errorf("unknown field %q", cond.Field)
 	}
-	if field.Kind != FieldKindJSONList {
-		return renderResult{}, errors.Errorf("field %q is not a tag list", cond.Field)
+	if cond.Key != "" {
+		return r.renderPropertyListContains(field, cond.Key, cond.Element)
+	}
+	if field.Kind != FieldKindJSONList && field.Kind != FieldKindMemoLinkList {
+		return renderResult{}, errors.Errorf("field %q is not a list", cond.Field)
 	}
 
 	lit, err := expectLiteral(cond.Element)
@@ -489,12 +497,137 @@ func (r *renderer) renderElementInCondition(cond *ElementInCondition) (renderRes
 	}
 	str, ok := lit.(string)
 	if !ok {
-		return renderResult{}, errors.New("tags membership requires string literal")
+		return renderResult{}, errors.Errorf("%s membership requires string literal", cond.Field)
 	}
 
+	if field.Kind == FieldKindMemoLinkList {
+		return r.renderMemoLinkContains(field, str), nil
+	}
+	if field.ElementPrefix != "" {
+		element, ok := strings.CutPrefix(str, field.ElementPrefix)
+		if !ok || element == "" {
+			return renderResult{sql: "1 = 0", unsatisfiable: true}, nil
+		}
+		str = element
+	}
 	return r.renderJSONListContains(field, str)
 }
 
+// renderMemoLinkContains matches rows linked to the memo with the given
+// resource name. Names outside the memos/ collection never match.
+func (r *renderer) renderMemoLinkContains(field Field, name string) renderResult {
+	uid, ok := strings.CutPrefix(name, "memos/")
+	if !ok || uid == "" {
+		return renderResult{sql: "1 = 0", unsatisfiable: true}
+	}
+	rows := r.memoLinkRows(field)
+	linked := qualifyColumn(r.dialect, Column{Table: "memo_relation", Name: field.MemoLink.To})
+	return renderResult{
+		sql: fmt.Sprintf("EXISTS (SELECT 1 %s AND %s IN (SELECT %s FROM %s AS %s WHERE %s = %s))",
+			rows, linked,
+			qualifyColumn(r.dialect, Column{Table: "linked_memo", Name: "id"}),
+			quoteTable(r.dialect, "memo"), quoteTable(r.dialect, "linked_memo"),
+			qualifyColumn(r.dialect, Column{Table: "linked_memo", Name: "uid"}), r.addArg(uid)),
+	}
+}
+
+// memoLinkRows renders the FROM/WHERE clause selecting the memo_relation rows
+// that link the current memo row to the memos listed by field.
+func (r *renderer) memoLinkRows(field Field) string {
+	link := field.MemoLink
+	return fmt.Sprintf("FROM %s WHERE %s = %s AND %s = %s",
+		quoteTable(r.dialect, "memo_relation"),
+		qualifyColumn(r.dialect, Column{Table: "memo_relation", Name: link.From}),
+		qualifyColumn(r.dialect, Column{Table: "memo", Name: "id"}),
+		qualifyColumn(r.dialect, Column{Table: "memo_relation", Name: "type"}),
+		r.addArg(link.Type))
+}
+
+// renderPropertyComparison compares one typed property with a literal. The
+// literal selects the value kind: strings match string values, booleans match
+// boolean values, and numbers, including folded timestamps such as now, match
+// number and date values. Memos without a value of that kind never match.
+func (r *renderer) renderPropertyComparison(ref *PropertyRef, op ComparisonOperator, right ValueExpr) (renderResult, error) {
+	field, ok := r.schema.Field(ref.Field)
+	if !ok || field.Kind != FieldKindPropertyMap {
+		return renderResult{}, errors.Errorf("field %q has no properties", ref.Field)
+	}
+	lit, err := expectLiteral(right)
+	if err != nil {
+		return renderResult{}, err
+	}
+
+	var expr string
+	switch value := lit.(type) {
+	case string:
+		expr = jsonExtractExpr(r.dialect, propertyField(field, ref.Key, "stringValue"))
+		if r.dialect == DialectMySQL {
+			expr = fmt.Sprintf("JSON_UNQUOTE(%s)", expr)
+		}
+		return renderResult{sql: fmt.Sprintf("%s %s %s", expr, sqlOperator(op), r.addArg(value))}, nil
+	case bool:
+		if op != CompareEq && op != CompareNeq {
+			return renderResult{}, errors.Errorf("operator %s not supported for boolean property %q", op, ref.Key)
+		}
+		expr = jsonExtractExpr(r.dialect, propertyField(field, ref.Key, "boolValue"))
+		switch r.dialect {
+		case DialectSQLite:
+			return renderResult{sql: fmt.Sprintf("%s %s %s", expr, sqlOperator(op), r.addBoolArg(value))}, nil
+		case DialectMySQL:
+			return renderResult{sql: fmt.Sprintf("%s %s CAST('%t' AS JSON)", expr, sqlOperator(op), value)}, nil
+		case DialectPostgres:
+			return renderResult{sql: fmt.Sprintf("(%s)::boolean %s %s", expr, sqlOperator(op), r.addArg(value))}, nil
+		default:
+			return renderResult{}, errors.Errorf("unsupported dialect %s", r.dialect)
+		}
+	case int64, float64:
+		number := jsonExtractExpr(r.dialect, propertyField(field, ref.Key, "numberValue"))
+		// Dates are int64 seconds, which protojson writes as JSON strings.
+		date := jsonExtractExpr(r.dialect, propertyField(field, ref.Key, "dateTs"))
+		switch r.dialect {
+		case DialectSQLite:
+			expr = fmt.Sprintf("COALESCE(%s, CAST(%s AS INTEGER))", number, date)
+		case DialectMySQL:
+			expr = fmt.Sprintf("COALESCE(CAST(%s AS DOUBLE), CAST(JSON_UNQUOTE(%s) AS SIGNED))", number, date)
+		case DialectPostgres:
+			expr = fmt.Sprintf("COALESCE((%s)::double precision, (%s)::bigint)", number, date)
+		default:
+			return renderResult{}, errors.Errorf("unsupported dialect %s", r.dialect)
+		}
+		return renderResult{sql: fmt.Sprintf("%s %s %s", expr, sqlOperator(op), r.addArg(value))}, nil
+	default:
+		return renderResult{}, errors.Errorf("property %q cannot be compared with %T", ref.Key, lit)
+	}
+}
+
+// renderPropertyListContains matches rows whose list property key contains
+// the string literal element.
+func (r *renderer) renderPropertyListContains(field Field, key string, element ValueExpr) (renderResult, error) {
+	if field.Kind != FieldKindPropertyMap {
+		return renderResult{}, errors.Errorf("field %q has no properties", field.Name)
+	}
+	lit, err := expectLiteral(element)
+	if err != nil {
+		return renderResult{}, err
+	}
+	str, ok := lit.(string)
+	if !ok {
+		return renderResult{}, errors.Errorf("property %q membership requires string literal", key)
+	}
+	return r.renderTagComprehension(propertyField(field, key, "listValue", "values"), &EqualsPredicate{Value: str}, ComprehensionExists)
+}
+
+// propertyField addresses the JSON value at path inside the key entry of a
+// property map field.
+func propertyField(field Field, key string, path ...string) Field {
+	jsonPath := append(append(slices.Clone(field.JSONPath), key), path...)
+	return Field{
+		Name:     field.Name + "." + key,
+		Column:   field.Column,
+		JSONPath: jsonPath,
+	}
+}
+
 func (r *renderer) renderJSONListContains(field Field, value string) (renderResult, error) {
 	return r.renderTagComprehension(field, &EqualsPredicate{Value: value}, ComprehensionExists)
 }
@@ -536,9 +669,21 @@ func (r *renderer) renderTextMatch(cond *TextMatchCondition) (renderResult, erro
 	if !ok {
 		return renderResult{}, errors.Errorf("unknown field %q", cond.Field)
 	}
-	column := field.columnExpr(r.dialect)
 	pattern := likePattern(cond.Mode, cond.Value)
-	return renderResult{sql: r.foldedLike(column, pattern)}, nil
+	sql := r.relatedRowsMatch(field, r.foldedLike(field.columnExpr(r.dialect), pattern))
+	if cond.Mode != TextMatchContains || len(field.ContainsAlso) == 0 {
+		return renderResult{sql: sql}, nil
+	}
+
+	conditions := []string{sql}
+	for _, name := range field.ContainsAlso {
+		also, ok := r.schema.Field(name)
+		if !ok {
+			return renderResult{}, errors.Errorf("unknown field %q", name)
+		}
+		conditions = append(conditions, r.relatedRowsMatch(also, r.foldedLike(also.columnExpr(r.dialect), pattern)))
+	}
+	return renderResult{sql: fmt.Sprintf("(%s)", strings.Join(conditions, " OR "))}, nil
 }
 
 func (r *renderer) renderRegex(cond *RegexCondition) (renderResult, error) {
@@ -550,15 +695,27 @@ func (r *renderer) renderRegex(cond *RegexCondition) (renderResult, error) {
 	switch r.dialect {
 	case DialectPostgres:
 		// POSIX regex match operator.
-		return renderResult{sql: fmt.Sprintf("%s ~ %s", column, r.addArg(cond.Pattern))}, nil
+		return renderResult{sql: r.relatedRowsMatch(field, fmt.Sprintf("%s ~ %s", column, r.addArg(cond.Pattern)))}, nil
 	case DialectMySQL, DialectSQLite:
 		// MySQL has a native REGEXP operator; SQLite uses the registered regexp() function.
-		return renderResult{sql: fmt.Sprintf("%s REGEXP %s", column, r.addArg(cond.Pattern))}, nil
+		return renderResult{sql: r.relatedRowsMatch(field, fmt.Sprintf("%s REGEXP %s", column, r.addArg(cond.Pattern)))}, nil
 	default:
 		return renderResult{}, errors.Errorf("unsupported dialect %s", r.dialect)
 	}
 }
 
+// relatedRowsMatch scopes a predicate on a related table to the rows that
+// reference the current row. Fields without a relation are returned as-is.
+func (r *renderer) relatedRowsMatch(field Field, cond string) string {
+	relation := field.Relation
+	if relation == nil {
+		return cond
+	}
+	foreignKey := qualifyColumn(r.dialect, Column{Table: relation.Table, Name: relation.ForeignKey})
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE %s = %s AND %s)",
+		quoteTable(r.dialect, relation.Table), foreignKey, qualifyColumn(r.dialect, relation.Parent), cond)
+}
+
 // foldedLike renders a case-insensitive LIKE comparison of colExpr against a
 // (already metacharacter-escaped) pattern, using each dialect's case-folding.
 func (r *renderer) foldedLike(colExpr, pattern string) string {
@@ -603,6 +760,9 @@ func (r *renderer) renderListComprehension(cond *ListComprehensionCondition) (re
 	if field.Kind != FieldKindJSONList {
 		return renderResult{}, errors.Errorf("field %q is not a JSON list", cond.Field)
 	}
+	if field.ElementPrefix != "" {
+		return renderResult{}, errors.Errorf("field %q supports membership tests only", cond.Field)
+	}
 
 	return r.renderTagComprehension(field, cond.Predicate, cond.Kind)
 }
@@ -906,6 +1066,13 @@ func qualifyColumn(d DialectName, col Column) string {
 	}
 }
 
+func quoteTable(d DialectName, table string) string {
+	if d == DialectPostgres {
+		return table
+	}
+	return fmt.Sprintf("`%s`", table)
+}
+
 func jsonPath(field Field) string {
 	return "$." + strings.Join(field.JSONPath, ".")
 }
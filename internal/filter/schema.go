@@ -34,8 +34,14 @@ const (
 	FieldKindBoolColumn FieldKind = "bool_column"
 	FieldKindJSONBool   FieldKind = "json_bool"
 	// FieldKindJSONExists represents a boolean derived from the presence of a non-null JSON value.
-	FieldKindJSONExists   FieldKind = "json_exists"
-	FieldKindJSONList     FieldKind = "json_list"
+	FieldKindJSONExists FieldKind = "json_exists"
+	FieldKindJSONList   FieldKind = "json_list"
+	// FieldKindMemoLinkList represents the names of memos linked to the row
+	// through memo_relation rows.
+	FieldKindMemoLinkList FieldKind = "memo_link_list"
+	// FieldKindPropertyMap represents a JSON object of typed memo properties
+	// whose entries are addressed as field.key.
+	FieldKindPropertyMap  FieldKind = "property_map"
 	FieldKindVirtualAlias FieldKind = "virtual_alias"
 )
 
@@ -45,6 +51,28 @@ type Column struct {
 	Name  string
 }
 
+// Relation describes rows of another table that reference the schema's row.
+// Fields with a relation match when any referencing row matches.
+type Relation struct {
+	// Table is the referencing table, e.g. "attachment".
+	Table string
+	// ForeignKey is the column on Table that references Parent.
+	ForeignKey string
+	// Parent is the referenced column on the schema's table.
+	Parent Column
+}
+
+// MemoLink describes how a memo reaches the memos listed by a
+// FieldKindMemoLinkList field.
+type MemoLink struct {
+	// From is the memo_relation column that references the filtered memo.
+	From string
+	// To is the memo_relation column that references the listed memos.
+	To string
+	// Type is the memo_relation type to follow, e.g. "REFERENCE".
+	Type string
+}
+
 // Field captures the schema metadata for an exposed CEL identifier.
 type Field struct {
 	Name                 string
@@ -56,6 +84,49 @@ type Field struct {
 	SupportsContains     bool
 	Expressions          map[DialectName]string
 	AllowedComparisonOps map[ComparisonOperator]bool
+	// Relation scopes the field to rows of another table; only text matching
+	// is supported on such fields.
+	Relation *Relation
+	// ContainsAlso lists fields whose contains() matches are OR-ed into this
+	// field's contains() match, so searching content also finds transcripts
+	// and document text.
+	ContainsAlso []string
+	// MemoLink is set on FieldKindMemoLinkList fields.
+	MemoLink *MemoLink
+	// ElementPrefix is the resource name prefix that values compared with
+	// the elements of a FieldKindJSONList field carry but the stored elements
+	// omit, such as "users/" for usernames. Values without it never match.
+	ElementPrefix string
+}
+
+// attachmentTranscriptExpressions extract the transcript text from the
+// attachment payload. The payload column is TEXT on MySQL and Postgres.
+var attachmentTranscriptExpressions = map[DialectName]string{
+	DialectSQLite:   "JSON_EXTRACT(%s, '$.transcript.text')",
+	DialectMySQL:    "JSON_UNQUOTE(JSON_EXTRACT(%s, '$.transcript.text'))",
+	DialectPostgres: "((%s)::jsonb->'transcript'->>'text')",
+}
+
+// attachmentImageTextExpressions and attachmentImageCaptionExpressions extract
+// the OCR text and the caption produced by image analysis.
+var attachmentImageTextExpressions = map[DialectName]string{
+	DialectSQLite:   "JSON_EXTRACT(%s, '$.imageAnalysis.text')",
+	DialectMySQL:    "JSON_UNQUOTE(JSON_EXTRACT(%s, '$.imageAnalysis.text'))",
+	DialectPostgres: "((%s)::jsonb->'imageAnalysis'->>'text')",
+}
+
+var attachmentImageCaptionExpressions = map[DialectName]string{
+	DialectSQLite:   "JSON_EXTRACT(%s, '$.imageAnalysis.caption')",
+	DialectMySQL:    "JSON_UNQUOTE(JSON_EXTRACT(%s, '$.imageAnalysis.caption'))",
+	DialectPostgres: "((%s)::jsonb->'imageAnalysis'->>'caption')",
+}
+
+// attachmentDocumentTextExpressions extract the text extracted from PDF,
+// Office and plain text attachments.
+var attachmentDocumentTextExpressions = map[DialectName]string{
+	DialectSQLite:   "JSON_EXTRACT(%s, '$.document.text')",
+	DialectMySQL:    "JSON_UNQUOTE(JSON_EXTRACT(%s, '$.document.text'))",
+	DialectPostgres: "((%s)::jsonb->'document'->>'text')",
 }
 
 // Schema collects CEL environment options and field metadata.
@@ -97,6 +168,33 @@ func NewSchema() Schema {
 			Column:           Column{Table: "memo", Name: "content"},
 			SupportsContains: true,
 			Expressions:      map[DialectName]string{},
+			ContainsAlso:     []string{"transcript", "document_text"},
+		},
+		"transcript": {
+			Name:             "transcript",
+			Kind:             FieldKindScalar,
+			Type:             FieldTypeString,
+			Column:           Column{Table: "attachment", Name: "payload"},
+			SupportsContains: true,
+			Expressions:      attachmentTranscriptExpressions,
+			Relation: &Relation{
+				Table:      "attachment",
+				ForeignKey: "memo_id",
+				Parent:     Column{Table: "memo", Name: "id"},
+			},
+		},
+		"document_text": {
+			Name:             "document_text",
+			Kind:             FieldKindScalar,
+			Type:             FieldTypeString,
+			Column:           Column{Table: "attachment", Name: "payload"},
+			SupportsContains: true,
+			Expressions:      attachmentDocumentTextExpressions,
+			Relation: &Relation{
+				Table:      "attachment",
+				ForeignKey: "memo_id",
+				Parent:     Column{Table: "memo", Name: "id"},
+			},
 		},
 		"creator": {
 			Name:   "creator",
@@ -185,6 +283,40 @@ func NewSchema() Schema {
 			Type:     FieldTypeString,
 			AliasFor: "tags",
 		},
+		"mentions": {
+			Name:          "mentions",
+			Kind:          FieldKindJSONList,
+			Type:          FieldTypeString,
+			Column:        Column{Table: "memo", Name: "payload"},
+			JSONPath:      []string{"mentions"},
+			ElementPrefix: "users/",
+		},
+		"references": {
+			Name: "references",
+			Kind: FieldKindMemoLinkList,
+			Type: FieldTypeString,
+			MemoLink: &MemoLink{
+				From: "memo_id",
+				To:   "related_memo_id",
+				Type: "REFERENCE",
+			},
+		},
+		"referenced_by": {
+			Name: "referenced_by",
+			Kind: FieldKindMemoLinkList,
+			Type: FieldTypeString,
+			MemoLink: &MemoLink{
+				From: "related_memo_id",
+				To:   "memo_id",
+				Type: "REFERENCE",
+			},
+		},
+		"props": {
+			Name:     "props",
+			Kind:     FieldKindPropertyMap,
+			Column:   Column{Table: "memo", Name: "payload"},
+			JSONPath: []string{"properties"},
+		},
 		"has_task_list": {
 			Name:     "has_task_list",
 			Kind:     FieldKindJSONBool,
@@ -244,6 +376,8 @@ func NewSchema() Schema {
 
 	envOptions := []cel.EnvOption{
 		cel.Variable("content", cel.StringType),
+		cel.Variable("transcript", cel.StringType),
+		cel.Variable("document_text", cel.StringType),
 		cel.Variable("creator", cel.StringType),
 		cel.Variable("creator_id", cel.IntType),
 		cel.Variable("created_ts", cel.TimestampType),
@@ -252,6 +386,10 @@ func NewSchema() Schema {
 		cel.Variable("tag", cel.StringType),
 		cel.Variable("tags", cel.ListType(cel.StringType)),
 		cel.Variable("visibility", cel.StringType),
+		cel.Variable("mentions", cel.ListType(cel.StringType)),
+		cel.Variable("references", cel.ListType(cel.StringType)),
+		cel.Variable("referenced_by", cel.ListType(cel.StringType)),
+		cel.Variable("props", cel.MapType(cel.StringType, cel.DynType)),
 		cel.Variable("has_task_list", cel.BoolType),
 		cel.Variable("has_link", cel.BoolType),
 		cel.Variable("has_code", cel.BoolType),
@@ -301,6 +439,38 @@ func NewAttachmentSchema() Schema {
 				DialectSQLite:   "%s",
 			},
 		},
+		"transcript": {
+			Name:             "transcript",
+			Kind:             FieldKindScalar,
+			Type:             FieldTypeString,
+			Column:           Column{Table: "attachment", Name: "payload"},
+			SupportsContains: true,
+			Expressions:      attachmentTranscriptExpressions,
+		},
+		"image_text": {
+			Name:             "image_text",
+			Kind:             FieldKindScalar,
+			Type:             FieldTypeString,
+			Column:           Column{Table: "attachment", Name: "payload"},
+			SupportsContains: true,
+			Expressions:      attachmentImageTextExpressions,
+		},
+		"image_caption": {
+			Name:             "image_caption",
+			Kind:             FieldKindScalar,
+			Type:             FieldTypeString,
+			Column:           Column{Table: "attachment", Name: "payload"},
+			SupportsContains: true,
+			Expressions:      attachmentImageCaptionExpressions,
+		},
+		"document_text": {
+			Name:             "document_text",
+			Kind:             FieldKindScalar,
+			Type:             FieldTypeString,
+			Column:           Column{Table: "attachment", Name: "payload"},
+			SupportsContains: true,
+			Expressions:      attachmentDocumentTextExpressions,
+		},
 		"memo_id": {
 			Name:        "memo_id",
 			Kind:        FieldKindScalar,
@@ -317,6 +487,10 @@ func NewAttachmentSchema() Schema {
 	envOptions := []cel.EnvOption{
 		cel.Variable("filename", cel.StringType),
 		cel.Variable("mime_type", cel.StringType),
+		cel.Variable("transcript", cel.StringType),
+		cel.Variable("image_text", cel.StringType),
+		cel.Variable("image_caption", cel.StringType),
+		cel.Variable("document_text", cel.StringType),
 		cel.Variable("create_time", cel.TimestampType),
 		cel.Variable("memo_id", cel.AnyType),
 		cel.Variable("now", cel.TimestampType),
@@ -0,0 +1,210 @@
+// Package imageconv encodes images in the formats the file server negotiates
+// with browsers, decodes HEIC photos and renders the first page of PDFs. JPEG
+// is encoded natively; WebP, AVIF, HEIC and PDF rely on the libwebp, libavif,
+// libheif and poppler command line tools and are unavailable when those are
+// not installed.
+package imageconv
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/pkg/errors"
+)
+
+// Format is an output image format.
+type Format string
+
+const (
+	JPEG Format = "jpeg"
+	WebP Format = "webp"
+	AVIF Format = "avif"
+)
+
+// MIMEType returns the media type of images in the format.
+func (f Format) MIMEType() string {
+	return "image/" + string(f)
+}
+
+const (
+	// DefaultWebPCommand is the libwebp encoder looked up on PATH.
+	DefaultWebPCommand = "cwebp"
+	// DefaultAVIFCommand is the libavif encoder looked up on PATH.
+	DefaultAVIFCommand = "avifenc"
+	// DefaultHEIFCommand is the libheif decoder looked up on PATH.
+	DefaultHEIFCommand = "heif-convert"
+	// DefaultPDFCommand is the poppler renderer looked up on PATH.
+	DefaultPDFCommand = "pdftoppm"
+
+	// PDFPageMaxSize bounds the width and height of rendered PDF pages.
+	PDFPageMaxSize = 1280
+
+	jpegQuality = 85
+	webpQuality = 80
+	avifQuality = 60
+)
+
+// Commands names the command line tools used for conversion. Empty fields use
+// the default commands.
+type Commands struct {
+	WebP string
+	AVIF string
+	HEIF string
+	PDF  string
+}
+
+// Converter encodes and decodes images, using the command line tools that
+// were found when it was created.
+type Converter struct {
+	webpCommand string
+	avifCommand string
+	heifCommand string
+	pdfCommand  string
+}
+
+// New constructs a Converter. A tool that cannot be found disables the format
+// it handles instead of failing.
+func New(commands Commands) *Converter {
+	return &Converter{
+		webpCommand: lookPath(commands.WebP, DefaultWebPCommand),
+		avifCommand: lookPath(commands.AVIF, DefaultAVIFCommand),
+		heifCommand: lookPath(commands.HEIF, DefaultHEIFCommand),
+		pdfCommand:  lookPath(commands.PDF, DefaultPDFCommand),
+	}
+}
+
+func lookPath(command, defaultCommand string) string {
+	if command == "" {
+		command = defaultCommand
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// CanEncode reports whether images can be encoded in the format.
+func (c *Converter) CanEncode(format Format) bool {
+	switch format {
+	case JPEG:
+		return true
+	case WebP:
+		return c.webpCommand != ""
+	case AVIF:
+		return c.avifCommand != ""
+	default:
+		return false
+	}
+}
+
+// CanDecodeHEIF reports whether HEIC and HEIF images can be decoded.
+func (c *Converter) CanDecodeHEIF() bool {
+	return c.heifCommand != ""
+}
+
+// CanDecodePDF reports whether the first page of PDFs can be rendered.
+func (c *Converter) CanDecodePDF() bool {
+	return c.pdfCommand != ""
+}
+
+// Encode encodes an image in the format.
+func (c *Converter) Encode(ctx context.Context, img image.Image, format Format) ([]byte, error) {
+	if !c.CanEncode(format) {
+		return nil, errors.Errorf("encoding %s images is not supported", format)
+	}
+	if format == JPEG {
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, img, imaging.JPEG, imaging.JPEGQuality(jpegQuality)); err != nil {
+			return nil, errors.Wrap(err, "failed to encode JPEG")
+		}
+		return buf.Bytes(), nil
+	}
+
+	// The encoders read a lossless PNG rendition of the image.
+	var input bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := encoder.Encode(&input, img); err != nil {
+		return nil, errors.Wrap(err, "failed to encode intermediate PNG")
+	}
+	if format == WebP {
+		return runTool(ctx, c.webpCommand, input.Bytes(), "input.png", "output.webp", func(in, out string) []string {
+			return []string{"-quiet", "-metadata", "none", "-q", strconv.Itoa(webpQuality), in, "-o", out}
+		})
+	}
+	return runTool(ctx, c.avifCommand, input.Bytes(), "input.png", "output.avif", func(in, out string) []string {
+		return []string{"-q", strconv.Itoa(avifQuality), "--ignore-exif", "--ignore-xmp", in, out}
+	})
+}
+
+// DecodeHEIF decodes a HEIC or HEIF image. The decoder applies the rotation
+// recorded in the image.
+func (c *Converter) DecodeHEIF(ctx context.Context, data []byte) (image.Image, error) {
+	if !c.CanDecodeHEIF() {
+		return nil, errors.New("decoding HEIF images is not supported")
+	}
+	output, err := runTool(ctx, c.heifCommand, data, "input.heic", "output.png", func(in, out string) []string {
+		return []string{in, out}
+	})
+	if err != nil {
+		return nil, err
+	}
+	img, err := imaging.Decode(bytes.NewReader(output))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode converted HEIF image")
+	}
+	return img, nil
+}
+
+// DecodePDF renders the first page of a PDF to fit within PDFPageMaxSize.
+func (c *Converter) DecodePDF(ctx context.Context, data []byte) (image.Image, error) {
+	if !c.CanDecodePDF() {
+		return nil, errors.New("rendering PDF pages is not supported")
+	}
+	output, err := runTool(ctx, c.pdfCommand, data, "input.pdf", "output.png", func(in, out string) []string {
+		// pdftoppm appends the extension to the output name.
+		return []string{"-png", "-f", "1", "-l", "1", "-singlefile", "-scale-to", strconv.Itoa(PDFPageMaxSize), in, strings.TrimSuffix(out, ".png")}
+	})
+	if err != nil {
+		return nil, err
+	}
+	img, err := imaging.Decode(bytes.NewReader(output))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode rendered PDF page")
+	}
+	return img, nil
+}
+
+// runTool runs a conversion command on files in a temporary directory, since
+// the tools do not reliably support standard input and output.
+func runTool(ctx context.Context, command string, input []byte, inputName, outputName string, args func(in, out string) []string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "memos-imageconv-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create conversion directory")
+	}
+	defer os.RemoveAll(dir)
+
+	inputPath, outputPath := filepath.Join(dir, inputName), filepath.Join(dir, outputName)
+	if err := os.WriteFile(inputPath, input, 0o600); err != nil {
+		return nil, errors.Wrap(err, "failed to write conversion input")
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args(inputPath, outputPath)...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "%s failed: %s", filepath.Base(command), strings.TrimSpace(stderr.String()))
+	}
+	output, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read conversion output")
+	}
+	return output, nil
+}
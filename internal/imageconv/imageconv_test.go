@@ -0,0 +1,119 @@
+package imageconv_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/imageconv"
+)
+
+// writeFakeTool installs a shell script standing in for a conversion tool.
+func writeFakeTool(t *testing.T, name, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake conversion tools require a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func testImage(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := range width {
+		for y := range height {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func TestEncodeJPEGWithoutTools(t *testing.T) {
+	t.Parallel()
+
+	converter := imageconv.New(imageconv.Commands{WebP: "missing-cwebp", AVIF: "missing-avifenc", HEIF: "missing-heif-convert", PDF: "missing-pdftoppm"})
+	require.True(t, converter.CanEncode(imageconv.JPEG))
+	require.False(t, converter.CanEncode(imageconv.WebP))
+	require.False(t, converter.CanEncode(imageconv.AVIF))
+	require.False(t, converter.CanDecodeHEIF())
+	require.False(t, converter.CanDecodePDF())
+
+	encoded, err := converter.Encode(context.Background(), testImage(8, 4), imageconv.JPEG)
+	require.NoError(t, err)
+	decoded, err := jpeg.Decode(bytes.NewReader(encoded))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 8, 4), decoded.Bounds())
+
+	_, err = converter.Encode(context.Background(), testImage(8, 4), imageconv.WebP)
+	require.ErrorContains(t, err, "not supported")
+}
+
+func TestEncodeWithTools(t *testing.T) {
+	t.Parallel()
+
+	// The fake encoders record their arguments in the output file.
+	converter := imageconv.New(imageconv.Commands{
+		WebP: writeFakeTool(t, "cwebp", `eval out=\${$#}; echo "webp $*" > "$out"`),
+		AVIF: writeFakeTool(t, "avifenc", `eval out=\${$#}; echo "avif $*" > "$out"`),
+	})
+	require.Equal(t, "image/webp", imageconv.WebP.MIMEType())
+
+	webp, err := converter.Encode(context.Background(), testImage(4, 4), imageconv.WebP)
+	require.NoError(t, err)
+	require.Regexp(t, `^webp -quiet -metadata none -q 80 \S+/input\.png -o \S+/output\.webp`, string(webp))
+
+	avif, err := converter.Encode(context.Background(), testImage(4, 4), imageconv.AVIF)
+	require.NoError(t, err)
+	require.Regexp(t, `^avif -q 60 --ignore-exif --ignore-xmp \S+/input\.png \S+/output\.avif`, string(avif))
+}
+
+func TestDecodeHEIF(t *testing.T) {
+	t.Parallel()
+
+	decoded := filepath.Join(t.TempDir(), "decoded.png")
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(6, 3)))
+	require.NoError(t, os.WriteFile(decoded, buf.Bytes(), 0o600))
+
+	converter := imageconv.New(imageconv.Commands{
+		HEIF: writeFakeTool(t, "heif-convert", `test "$(cat "$1")" = "heic data" && cp "`+decoded+`" "$2"`),
+	})
+	require.True(t, converter.CanDecodeHEIF())
+	img, err := converter.DecodeHEIF(context.Background(), []byte("heic data"))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 6, 3), img.Bounds())
+
+	_, err = converter.DecodeHEIF(context.Background(), []byte("corrupt"))
+	require.ErrorContains(t, err, "heif-convert failed")
+}
+
+func TestDecodePDF(t *testing.T) {
+	t.Parallel()
+
+	rendered := filepath.Join(t.TempDir(), "rendered.png")
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(4, 6)))
+	require.NoError(t, os.WriteFile(rendered, buf.Bytes(), 0o600))
+
+	// The fake renderer receives the output name without its extension.
+	converter := imageconv.New(imageconv.Commands{
+		PDF: writeFakeTool(t, "pdftoppm", `eval in=\${$(($#-1))}; eval out=\${$#}; test "$*" = "-png -f 1 -l 1 -singlefile -scale-to 1280 $in $out" && test "$(cat "$in")" = "pdf data" && cp "`+rendered+`" "$out.png"`),
+	})
+	require.True(t, converter.CanDecodePDF())
+	img, err := converter.DecodePDF(context.Background(), []byte("pdf data"))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 4, 6), img.Bounds())
+
+	_, err = converter.DecodePDF(context.Background(), []byte("corrupt"))
+	require.ErrorContains(t, err, "pdftoppm failed")
+}
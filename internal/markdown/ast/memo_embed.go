@@ -0,0 +1,34 @@
+package ast
+
+import (
+	gast "github.com/yuin/goldmark/ast"
+)
+
+// MemoEmbedNode represents a ![[target]] embed of another memo in the markdown AST.
+type MemoEmbedNode struct {
+	gast.BaseInline
+
+	// Target is the memo UID or title between the brackets, trimmed of spaces.
+	Target []byte
+	// Source is the exact recognized source spelling, including ! and the brackets.
+	Source []byte
+	// Embedded is the rendered content of the embedded memo. It is set only
+	// while rendering with an embed resolver; nil renders Source literally.
+	Embedded []byte
+}
+
+// KindMemoEmbed is the NodeKind for MemoEmbedNode.
+var KindMemoEmbed = gast.NewNodeKind("MemoEmbed")
+
+// Kind returns KindMemoEmbed.
+func (*MemoEmbedNode) Kind() gast.NodeKind {
+	return KindMemoEmbed
+}
+
+// Dump implements Node.Dump for debugging.
+func (n *MemoEmbedNode) Dump(source []byte, level int) {
+	gast.DumpHelper(n, source, level, map[string]string{
+		"Target": string(n.Target),
+		"Source": string(n.Source),
+	}, nil)
+}
@@ -0,0 +1,137 @@
+package extensions
+
+import (
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+
+	mast "github.com/usememos/memos/internal/markdown/ast"
+	mparser "github.com/usememos/memos/internal/markdown/parser"
+)
+
+type wikiLinkExtension struct{}
+
+// WikiLinkExtension is a goldmark extension for [[memo]] links and ![[memo]]
+// embeds using a memo UID or title.
+var WikiLinkExtension = &wikiLinkExtension{}
+
+// Extend extends the goldmark parser with wiki-link support.
+func (*wikiLinkExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithASTTransformers(
+			// Run before GFM email (950) and tag (1000) recognition so that a
+			// title such as [[Release #2]] stays one link.
+			util.Prioritized(&wikiLinkASTTransformer{}, 900),
+		),
+	)
+	m.Renderer().AddOptions(
+		renderer.WithNodeRenderers(
+			util.Prioritized(&wikiLinkNodeRenderer{}, 500),
+		),
+	)
+}
+
+type wikiLinkASTTransformer struct{}
+
+func (*wikiLinkASTTransformer) Transform(document *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+	textNodes := eligibleLiteralTextNodes(document)
+	mergeAdjacentLiteralText(textNodes, source)
+	for _, textNode := range textNodes {
+		if textNode.Parent() == nil {
+			continue
+		}
+		replaceWikiLinksInText(textNode, source)
+	}
+}
+
+func replaceWikiLinksInText(textNode *ast.Text, source []byte) {
+	segment := textNode.Segment
+	matches := mparser.FindWikiLinkMatches(segment.Value(source))
+	if len(matches) == 0 {
+		return
+	}
+
+	parent := textNode.Parent()
+	cursor := segment.Start
+	padding := segment.Padding
+	for _, match := range matches {
+		start := segment.Start + match.Start - segment.Padding
+		end := segment.Start + match.End - segment.Padding
+		if start > cursor || padding > 0 {
+			insertSplitTextBefore(parent, textNode, textNode, text.NewSegmentPadding(cursor, start, padding), false)
+		}
+
+		var node ast.Node
+		if match.Embed {
+			node = &mast.MemoEmbedNode{
+				Target: append([]byte(nil), match.Target...),
+				Source: append([]byte(nil), source[start:end]...),
+			}
+		} else {
+			node = &mast.WikiLinkNode{
+				Target: append([]byte(nil), match.Target...),
+				Source: append([]byte(nil), source[start:end]...),
+			}
+		}
+		node.SetPos(start)
+		parent.InsertBefore(parent, textNode, node)
+		cursor = end
+		padding = 0
+	}
+
+	if cursor < segment.Stop || textNode.SoftLineBreak() || textNode.HardLineBreak() {
+		insertSplitTextBefore(parent, textNode, textNode, text.NewSegment(cursor, segment.Stop), true)
+	}
+	parent.RemoveChild(parent, textNode)
+}
+
+type wikiLinkNodeRenderer struct{}
+
+func (*wikiLinkNodeRenderer) RegisterFuncs(registerer renderer.NodeRendererFuncRegisterer) {
+	registerer.Register(mast.KindWikiLink, renderWikiLinkNode)
+	registerer.Register(mast.KindMemoEmbed, renderMemoEmbedNode)
+}
+
+func renderWikiLinkNode(writer util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	wikiLinkNode, ok := node.(*mast.WikiLinkNode)
+	if !ok {
+		return ast.WalkContinue, nil
+	}
+	spelling := wikiLinkNode.Source
+	if len(spelling) == 0 {
+		spelling = append(append([]byte("[["), wikiLinkNode.Target...), ']', ']')
+	}
+	_, _ = writer.Write(util.EscapeHTML(spelling))
+	return ast.WalkContinue, nil
+}
+
+func renderMemoEmbedNode(writer util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	embedNode, ok := node.(*mast.MemoEmbedNode)
+	if !ok {
+		return ast.WalkContinue, nil
+	}
+	if embedNode.Embedded == nil {
+		spelling := embedNode.Source
+		if len(spelling) == 0 {
+			spelling = append(append([]byte("![["), embedNode.Target...), ']', ']')
+		}
+		_, _ = writer.Write(util.EscapeHTML(spelling))
+		return ast.WalkContinue, nil
+	}
+	_, _ = writer.WriteString(`<div class="memo-embed" data-target="`)
+	_, _ = writer.Write(util.EscapeHTML(embedNode.Target))
+	_, _ = writer.WriteString(`">`)
+	_, _ = writer.Write(embedNode.Embedded)
+	_, _ = writer.WriteString("</div>")
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,109 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// frontMatterDelimiter opens a front matter block and, with "...", closes it.
+const frontMatterDelimiter = "---"
+
+// extractFrontMatter parses the YAML front matter block at the start of
+// content. It returns the typed properties and the length of the block,
+// including its closing delimiter line. A block that is unclosed or is not a
+// YAML mapping is not front matter, and the returned length is zero.
+func extractFrontMatter(content []byte) (map[string]*storepb.MemoPayload_PropertyValue, int) {
+	line, rest, ok := cutLine(content)
+	if !ok || string(line) != frontMatterDelimiter {
+		return nil, 0
+	}
+	bodyStart := len(content) - len(rest)
+	offset := bodyStart
+	for len(rest) > 0 {
+		line, rest, _ = cutLine(rest)
+		lineStart := offset
+		offset = len(content) - len(rest)
+		if string(line) != frontMatterDelimiter && string(line) != "..." {
+			continue
+		}
+
+		var values map[string]any
+		if err := yaml.Unmarshal(content[bodyStart:lineStart], &values); err != nil {
+			return nil, 0
+		}
+		properties := make(map[string]*storepb.MemoPayload_PropertyValue, len(values))
+		for key, value := range values {
+			if property := convertFrontMatterValue(value); property != nil {
+				properties[key] = property
+			}
+		}
+		return properties, offset
+	}
+	return nil, 0
+}
+
+// cutLine splits off the first line of content without its line ending. It
+// reports false when content is empty.
+func cutLine(content []byte) (line, rest []byte, ok bool) {
+	if len(content) == 0 {
+		return nil, nil, false
+	}
+	line, rest, found := bytes.Cut(content, []byte("\n"))
+	if !found {
+		rest = content[len(content):]
+	}
+	return bytes.TrimRight(line, " \t\r"), rest, true
+}
+
+// convertFrontMatterValue converts a decoded YAML value to a property value.
+// Lists keep their scalar elements as strings; nulls and nested mappings are
+// not properties.
+func convertFrontMatterValue(value any) *storepb.MemoPayload_PropertyValue {
+	switch v := value.(type) {
+	case string:
+		return &storepb.MemoPayload_PropertyValue{Kind: &storepb.MemoPayload_PropertyValue_StringValue{StringValue: v}}
+	case int:
+		return &storepb.MemoPayload_PropertyValue{Kind: &storepb.MemoPayload_PropertyValue_NumberValue{NumberValue: float64(v)}}
+	case int64:
+		return &storepb.MemoPayload_PropertyValue{Kind: &storepb.MemoPayload_PropertyValue_NumberValue{NumberValue: float64(v)}}
+	case float64:
+		return &storepb.MemoPayload_PropertyValue{Kind: &storepb.MemoPayload_PropertyValue_NumberValue{NumberValue: v}}
+	case bool:
+		return &storepb.MemoPayload_PropertyValue{Kind: &storepb.MemoPayload_PropertyValue_BoolValue{BoolValue: v}}
+	case time.Time:
+		return &storepb.MemoPayload_PropertyValue{Kind: &storepb.MemoPayload_PropertyValue_DateTs{DateTs: v.Unix()}}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, element := range v {
+			switch e := element.(type) {
+			case string, int, int64, float64, bool:
+				values = append(values, fmt.Sprint(e))
+			case time.Time:
+				values = append(values, e.UTC().Format(time.RFC3339))
+			default:
+				// Nested lists, mappings and nulls have no string form.
+			}
+		}
+		return &storepb.MemoPayload_PropertyValue{Kind: &storepb.MemoPayload_PropertyValue_ListValue{ListValue: &storepb.MemoPayload_StringList{Values: values}}}
+	default:
+		return nil
+	}
+}
+
+// maskFrontMatter returns a copy of content in which the first length bytes
+// are blank, so the front matter block parses as blank lines while every
+// other byte keeps its offset.
+func maskFrontMatter(content []byte, length int) []byte {
+	masked := append([]byte(nil), content...)
+	for i := 0; i < length; i++ {
+		if masked[i] != '\n' {
+			masked[i] = ' '
+		}
+	}
+	return masked
+}
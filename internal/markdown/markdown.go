@@ -3,6 +3,7 @@ package markdown
 import (
 	"bytes"
 	"cmp"
+	"context"
 	"net/url"
 	"slices"
 	"strings"
@@ -12,7 +13,10 @@ import (
 	"github.com/yuin/goldmark/extension"
 	east "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/parser"
+	goldmarkrenderer "github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
 	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
 
 	"github.com/usememos/memos/internal/base"
 	mast "github.com/usememos/memos/internal/markdown/ast"
@@ -30,12 +34,25 @@ type ManagedAttachmentReference struct {
 type ExtractedData struct {
 	Tags                               []string
 	Mentions                           []string
+	WikiLinks                          []string
+	Embeds                             []string
 	ImageDestinations                  []string
 	ManagedAttachmentReferences        []ManagedAttachmentReference
 	InvalidManagedAttachmentReferences []string
 	Property                           *storepb.MemoPayload_Property
+	Properties                         map[string]*storepb.MemoPayload_PropertyValue
+	Tasks                              []*storepb.MemoPayload_Task
 }
 
+// EmbedResolver returns the rendered form of the memo that ![[target]] embeds.
+// It reports false to keep the embed as literal text.
+type EmbedResolver func(target string) (rendered string, ok bool, err error)
+
+// DiagramResolver renders a diagram code block and returns the root-relative
+// URL of its image, or false when the language is no diagram or rendering
+// failed, in which case the block is shown as code.
+type DiagramResolver func(ctx context.Context, language string, source []byte) (string, bool)
+
 // Service handles markdown metadata extraction.
 // It uses goldmark to parse markdown and extract tags, properties, and snippets.
 // HTML rendering is primarily done on frontend using markdown-it, but backend provides
@@ -53,30 +70,51 @@ type Service interface {
 	// RenderMarkdown renders goldmark AST back to markdown text
 	RenderMarkdown(content []byte) (string, error)
 
-	// RenderHTML renders markdown content to HTML
-	RenderHTML(content []byte) (string, error)
+	// RenderHTML renders markdown content to sanitized HTML
+	RenderHTML(ctx context.Context, content []byte) (string, error)
+
+	// RenderHTMLWithEmbeds renders markdown content to HTML, replacing each
+	// ![[memo]] embed with the HTML returned by resolve
+	RenderHTMLWithEmbeds(ctx context.Context, content []byte, resolve EmbedResolver) (string, error)
 
 	// GenerateSnippet creates plain text summary
 	GenerateSnippet(content []byte, maxLength int) (string, error)
 
+	// GenerateSnippetWithEmbeds creates plain text summary, replacing each
+	// ![[memo]] embed with the text returned by resolve
+	GenerateSnippetWithEmbeds(content []byte, maxLength int, resolve EmbedResolver) (string, error)
+
 	// ValidateContent checks for syntax errors
 	ValidateContent(content []byte) error
 
 	// RenameTag renames all occurrences of oldTag to newTag in content
 	RenameTag(content []byte, oldTag, newTag string) (string, error)
+
+	// RewriteTags replaces every tag for which rewrite reports true with the
+	// returned tag; an empty replacement removes the tag from content
+	RewriteTags(content []byte, rewrite func(tag string) (string, bool)) (string, error)
+
+	// SetTaskState checks or unchecks the task with the given ID, returning
+	// ErrTaskNotFound when there is no such task
+	SetTaskState(content []byte, taskID string, checked bool) (string, error)
 }
 
 // service implements the Service interface.
 type service struct {
-	md goldmark.Markdown
+	md              goldmark.Markdown
+	htmlRenderer    *renderer.HTMLRenderer
+	diagramResolver DiagramResolver
 }
 
 // Option configures the markdown service.
 type Option func(*config)
 
 type config struct {
-	enableTags     bool
-	enableMentions bool
+	enableTags      bool
+	enableMentions  bool
+	enableWikiLinks bool
+	instanceURL     string
+	diagramResolver DiagramResolver
 }
 
 // WithTagExtension enables #tag parsing.
@@ -93,6 +131,29 @@ func WithMentionExtension() Option {
 	}
 }
 
+// WithWikiLinkExtension enables [[memo]] link and ![[memo]] embed parsing.
+func WithWikiLinkExtension() Option {
+	return func(c *config) {
+		c.enableWikiLinks = true
+	}
+}
+
+// WithInstanceURL sets the base URL that root-relative links, such as
+// managed attachment images and mentions, are resolved against in HTML.
+func WithInstanceURL(instanceURL string) Option {
+	return func(c *config) {
+		c.instanceURL = instanceURL
+	}
+}
+
+// WithDiagramResolver renders fenced code blocks that resolver accepts, such
+// as mermaid or dot blocks, as diagram images in HTML.
+func WithDiagramResolver(resolver DiagramResolver) Option {
+	return func(c *config) {
+		c.diagramResolver = resolver
+	}
+}
+
 // NewService creates a new markdown service with the given options.
 func NewService(opts ...Option) Service {
 	cfg := &config{}
@@ -114,21 +175,38 @@ func NewService(opts ...Option) Service {
 	if cfg.enableMentions {
 		exts = append(exts, extensions.MentionExtension)
 	}
+	if cfg.enableWikiLinks {
+		exts = append(exts, extensions.WikiLinkExtension)
+	}
 
+	htmlRenderer := renderer.NewHTMLRenderer(cfg.instanceURL)
 	md := goldmark.New(
 		goldmark.WithExtensions(exts...),
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(), // Generate heading IDs
 		),
+		goldmark.WithRendererOptions(
+			// Line breaks and raw HTML follow the web client; sanitizeHTML
+			// removes unsafe markup from the result.
+			html.WithHardWraps(),
+			html.WithUnsafe(),
+			goldmarkrenderer.WithNodeRenderers(util.Prioritized(htmlRenderer, 100)),
+		),
 	)
 
 	return &service{
-		md: md,
+		md:              md,
+		htmlRenderer:    htmlRenderer,
+		diagramResolver: cfg.diagramResolver,
 	}
 }
 
-// parse is an internal helper to parse content into AST.
+// parse is an internal helper to parse content into AST. A YAML front matter
+// block parses as blank lines, so it yields no nodes.
 func (s *service) parse(content []byte) (gast.Node, error) {
+	if _, length := extractFrontMatter(content); length > 0 {
+		content = maskFrontMatter(content, length)
+	}
 	reader := text.NewReader(content)
 	doc := s.md.Parser().Parse(reader)
 	if masked := maskInvalidLinkReferenceDefinitions(doc, content); masked != nil {
@@ -220,6 +298,14 @@ func extractTextFromNode(n gast.Node, source []byte, buf *strings.Builder) {
 		buf.Write(emailNode.Address)
 		return
 	}
+	if wikiLinkNode, ok := n.(*mast.WikiLinkNode); ok {
+		buf.Write(wikiLinkNode.Target)
+		return
+	}
+	if embedNode, ok := n.(*mast.MemoEmbedNode); ok {
+		buf.Write(embedNode.Source)
+		return
+	}
 	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
 		extractTextFromNode(child, source, buf)
 	}
@@ -284,29 +370,50 @@ func (s *service) RenderMarkdown(content []byte) (string, error) {
 	}
 
 	mdRenderer := renderer.NewMarkdownRenderer()
-	return mdRenderer.Render(root, content), nil
+	_, length := extractFrontMatter(content)
+	return string(content[:length]) + mdRenderer.Render(root, content), nil
 }
 
-// RenderHTML renders markdown content to HTML using goldmark's built-in HTML renderer.
-func (s *service) RenderHTML(content []byte) (string, error) {
+// RenderHTML renders markdown content to sanitized HTML.
+func (s *service) RenderHTML(ctx context.Context, content []byte) (string, error) {
+	return s.RenderHTMLWithEmbeds(ctx, content, nil)
+}
+
+// RenderHTMLWithEmbeds renders markdown content to HTML. Embeds that resolve
+// are wrapped in a memo-embed container; the rest render as literal text.
+func (s *service) RenderHTMLWithEmbeds(ctx context.Context, content []byte, resolve EmbedResolver) (string, error) {
 	root, err := s.parse(content)
 	if err != nil {
 		return "", err
 	}
+	if err := resolveEmbeds(root, resolve); err != nil {
+		return "", err
+	}
+	s.resolveDiagrams(ctx, root, content)
+	s.resolveLinkDestinations(root)
 
 	var buf bytes.Buffer
 	if err := s.md.Renderer().Render(&buf, content, root); err != nil {
 		return "", err
 	}
-	return buf.String(), nil
+	return string(sanitizeHTML(buf.Bytes())), nil
 }
 
 // GenerateSnippet creates a plain text summary from markdown content.
 func (s *service) GenerateSnippet(content []byte, maxLength int) (string, error) {
+	return s.GenerateSnippetWithEmbeds(content, maxLength, nil)
+}
+
+// GenerateSnippetWithEmbeds creates a plain text summary from markdown content
+// in which resolved embeds contribute the text of the embedded memo.
+func (s *service) GenerateSnippetWithEmbeds(content []byte, maxLength int, resolve EmbedResolver) (string, error) {
 	root, err := s.parse(content)
 	if err != nil {
 		return "", err
 	}
+	if err := resolveEmbeds(root, resolve); err != nil {
+		return "", err
+	}
 
 	var buf strings.Builder
 	var lastNodeWasBlock bool
@@ -365,6 +472,14 @@ func (s *service) GenerateSnippet(content []byte, maxLength int) (string, error)
 			}
 		case *mast.GFMEmailNode:
 			buf.Write(node.Address)
+		case *mast.WikiLinkNode:
+			buf.Write(node.Target)
+		case *mast.MemoEmbedNode:
+			if node.Embedded != nil {
+				buf.Write(node.Embedded)
+			} else {
+				buf.Write(node.Source)
+			}
 		case *mast.InlineMathNode:
 			buf.Write(node.Source)
 		case *mast.BlockMathNode:
@@ -414,11 +529,19 @@ func (s *service) ExtractAll(content []byte) (*ExtractedData, error) {
 	data := &ExtractedData{
 		Tags:                        []string{},
 		Mentions:                    []string{},
+		WikiLinks:                   []string{},
+		Embeds:                      []string{},
 		ImageDestinations:           []string{},
 		ManagedAttachmentReferences: []ManagedAttachmentReference{},
 		Property:                    &storepb.MemoPayload_Property{},
+		Properties:                  map[string]*storepb.MemoPayload_PropertyValue{},
+		Tasks:                       []*storepb.MemoPayload_Task{},
+	}
+	if properties, length := extractFrontMatter(content); length > 0 {
+		data.Properties = properties
 	}
 
+	tasks := newTaskCollector()
 	firstBlockChecked := false
 	// Single walk to collect all data
 	err = gast.Walk(root, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
@@ -432,6 +555,12 @@ func (s *service) ExtractAll(content []byte) (*ExtractedData, error) {
 		if mentionNode, ok := n.(*mast.MentionNode); ok {
 			data.Mentions = append(data.Mentions, string(mentionNode.Username))
 		}
+		if wikiLinkNode, ok := n.(*mast.WikiLinkNode); ok {
+			data.WikiLinks = append(data.WikiLinks, string(wikiLinkNode.Target))
+		}
+		if embedNode, ok := n.(*mast.MemoEmbedNode); ok {
+			data.Embeds = append(data.Embeds, string(embedNode.Target))
+		}
 		if imageNode, ok := n.(*gast.Image); ok {
 			destination := string(imageNode.Destination)
 			data.ImageDestinations = append(data.ImageDestinations, destination)
@@ -473,6 +602,8 @@ func (s *service) ExtractAll(content []byte) (*ExtractedData, error) {
 				if !checkBox.IsChecked {
 					data.Property.HasIncompleteTasks = true
 				}
+				task, _ := tasks.collect(checkBox, content)
+				data.Tasks = append(data.Tasks, task)
 			}
 		default:
 			// No special handling for other node types
@@ -488,12 +619,82 @@ func (s *service) ExtractAll(content []byte) (*ExtractedData, error) {
 	// Deduplicate tags while preserving original case
 	data.Tags = uniquePreserveCase(data.Tags)
 	data.Mentions = uniquePreserveCase(data.Mentions)
+	data.WikiLinks = uniquePreserveCase(data.WikiLinks)
+	data.Embeds = uniquePreserveCase(data.Embeds)
 	data.ManagedAttachmentReferences = uniqueManagedAttachmentReferences(data.ManagedAttachmentReferences)
 	data.InvalidManagedAttachmentReferences = uniquePreserveCase(data.InvalidManagedAttachmentReferences)
 
 	return data, nil
 }
 
+// resolveLinkDestinations makes the root-relative destinations of links and
+// images absolute against the instance URL.
+func (s *service) resolveLinkDestinations(root gast.Node) {
+	_ = gast.Walk(root, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
+		if !entering {
+			return gast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *gast.Link:
+			node.Destination = s.htmlRenderer.AbsoluteURL(node.Destination)
+		case *gast.Image:
+			node.Destination = s.htmlRenderer.AbsoluteURL(node.Destination)
+		default:
+		}
+		return gast.WalkContinue, nil
+	})
+}
+
+// resolveEmbeds sets the embedded content of every ![[memo]] embed that
+// resolve accepts. A nil resolve leaves all embeds literal.
+func resolveEmbeds(root gast.Node, resolve EmbedResolver) error {
+	if resolve == nil {
+		return nil
+	}
+	return gast.Walk(root, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
+		embedNode, ok := n.(*mast.MemoEmbedNode)
+		if !entering || !ok {
+			return gast.WalkContinue, nil
+		}
+		rendered, ok, err := resolve(string(embedNode.Target))
+		if err != nil {
+			return gast.WalkStop, err
+		}
+		if ok {
+			embedNode.Embedded = []byte(rendered)
+		}
+		return gast.WalkContinue, nil
+	})
+}
+
+// resolveDiagrams renders the fenced code blocks the diagram resolver accepts
+// and marks them with the URL of their image.
+func (s *service) resolveDiagrams(ctx context.Context, root gast.Node, source []byte) {
+	if s.diagramResolver == nil {
+		return
+	}
+	_ = gast.Walk(root, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
+		fenced, ok := n.(*gast.FencedCodeBlock)
+		if !entering || !ok {
+			return gast.WalkContinue, nil
+		}
+		language := fenced.Language(source)
+		if len(language) == 0 {
+			return gast.WalkSkipChildren, nil
+		}
+		lines := fenced.Lines()
+		var code bytes.Buffer
+		for i := 0; i < lines.Len(); i++ {
+			line := lines.At(i)
+			code.Write(line.Value(source))
+		}
+		if url, ok := s.diagramResolver(ctx, string(language), code.Bytes()); ok {
+			fenced.SetAttributeString(renderer.DiagramURLAttribute, []byte(url))
+		}
+		return gast.WalkSkipChildren, nil
+	})
+}
+
 func extractRawHTML(node gast.Node, source []byte) (string, bool) {
 	switch node := node.(type) {
 	case *gast.RawHTML:
@@ -549,14 +750,24 @@ func uniqueManagedAttachmentReferences(references []ManagedAttachmentReference)
 
 // RenameTag renames all occurrences of oldTag to newTag in content.
 func (s *service) RenameTag(content []byte, oldTag, newTag string) (string, error) {
+	return s.RewriteTags(content, func(tag string) (string, bool) {
+		return newTag, tag == oldTag
+	})
+}
+
+// RewriteTags replaces or removes the tags selected by rewrite. Only the
+// source span of each recognized tag changes, so the rest of the content is
+// kept byte for byte. A removed tag also takes one adjacent space with it.
+func (s *service) RewriteTags(content []byte, rewrite func(tag string) (string, bool)) (string, error) {
 	root, err := s.parse(content)
 	if err != nil {
 		return "", err
 	}
 
 	type sourceRange struct {
-		start int
-		end   int
+		start       int
+		end         int
+		replacement string
 	}
 	var ranges []sourceRange
 	err = gast.Walk(root, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
@@ -564,9 +775,9 @@ func (s *service) RenameTag(content []byte, oldTag, newTag string) (string, erro
 			return gast.WalkContinue, nil
 		}
 
-		if tagNode, ok := asMemoTagNode(n); ok {
-			if string(tagNode.Tag) == oldTag && len(tagNode.Source) > 0 {
-				ranges = append(ranges, sourceRange{start: tagNode.Pos(), end: tagNode.Pos() + len(tagNode.Source)})
+		if tagNode, ok := asMemoTagNode(n); ok && len(tagNode.Source) > 0 {
+			if replacement, ok := rewrite(string(tagNode.Tag)); ok {
+				ranges = append(ranges, sourceRange{start: tagNode.Pos(), end: tagNode.Pos() + len(tagNode.Source), replacement: replacement})
 			}
 		}
 
@@ -582,15 +793,31 @@ func (s *service) RenameTag(content []byte, oldTag, newTag string) (string, erro
 	output.Grow(len(content))
 	cursor := 0
 	for _, sourceRange := range ranges {
-		output.Write(content[cursor:sourceRange.start])
-		output.WriteByte('#')
-		output.WriteString(newTag)
-		cursor = sourceRange.end
+		start, end := sourceRange.start, sourceRange.end
+		if sourceRange.replacement == "" {
+			if end < len(content) && isTagSpace(content[end]) {
+				end++
+			} else if start > cursor && isTagSpace(content[start-1]) {
+				start--
+			}
+		}
+		output.Write(content[cursor:start])
+		if sourceRange.replacement != "" {
+			output.WriteByte('#')
+			output.WriteString(sourceRange.replacement)
+		}
+		cursor = end
 	}
 	output.Write(content[cursor:])
 	return output.String(), nil
 }
 
+// isTagSpace reports whether b is a space that separates a tag from its
+// neighbours on the same line.
+func isTagSpace(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
 // uniquePreserveCase returns unique strings from input while preserving case.
 func uniquePreserveCase(strs []string) []string {
 	seen := make(map[string]struct{})
@@ -1,6 +1,7 @@
 package markdown
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -361,6 +362,124 @@ func TestExtractAllMentions(t *testing.T) {
 	assert.Empty(t, data.Mentions)
 }
 
+func TestExtractAllWikiLinks(t *testing.T) {
+	svc := NewService(WithTagExtension(), WithMentionExtension(), WithWikiLinkExtension())
+
+	data, err := svc.ExtractAll([]byte("See [[abc123]], [[Release #2]] and [[abc123]] again. #tag\n\n`[[code]]` [label]([[link]])"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc123", "Release #2"}, data.WikiLinks)
+	assert.Equal(t, []string{"tag"}, data.Tags)
+
+	data, err = NewService(WithTagExtension()).ExtractAll([]byte("[[abc123]]"))
+	require.NoError(t, err)
+	assert.Empty(t, data.WikiLinks)
+}
+
+func TestWikiLinkSourceSpelling(t *testing.T) {
+	svc := NewService(WithTagExtension(), WithWikiLinkExtension())
+	content := "# [[ Weekly Review ]]\n\nLinks to [[a&b]] and #tag"
+
+	rendered, err := svc.RenderMarkdown([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, content, rendered)
+
+	html, err := svc.RenderHTML(context.Background(), []byte("[[a&b]]"))
+	require.NoError(t, err)
+	assert.Equal(t, "<p>[[a&amp;b]]</p>\n", html)
+
+	data, err := svc.ExtractAll([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly Review", data.Property.Title)
+}
+
+func TestMemoEmbeds(t *testing.T) {
+	svc := NewService(WithWikiLinkExtension())
+	content := "Checklist:\n\n![[ checklist ]] and ![[missing]] beside [[link]]"
+
+	rendered, err := svc.RenderMarkdown([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, content, rendered)
+
+	data, err := svc.ExtractAll([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checklist", "missing"}, data.Embeds)
+	assert.Equal(t, []string{"link"}, data.WikiLinks)
+
+	resolve := func(rendered string) EmbedResolver {
+		return func(target string) (string, bool, error) {
+			if target != "checklist" {
+				return "", false, nil
+			}
+			return rendered, true, nil
+		}
+	}
+
+	html, err := svc.RenderHTML(context.Background(), []byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Checklist:</p>\n<p>![[ checklist ]] and ![[missing]] beside [[link]]</p>\n", html)
+
+	html, err = svc.RenderHTMLWithEmbeds(context.Background(), []byte(content), resolve("<ul>\n<li>step</li>\n</ul>\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Checklist:</p>\n<p><div class=\"memo-embed\" data-target=\"checklist\"><ul>\n<li>step</li>\n</ul>\n</div> and ![[missing]] beside [[link]]</p>\n", html)
+
+	snippet, err := svc.GenerateSnippetWithEmbeds([]byte(content), 100, resolve("step one"))
+	require.NoError(t, err)
+	assert.Equal(t, "Checklist: step one and ![[missing]] beside link", snippet)
+}
+
+func TestFrontMatterProperties(t *testing.T) {
+	svc := NewService(WithTagExtension())
+	content := strings.Join([]string{
+		"---",
+		"status: done",
+		"priority: 2",
+		"due: 2026-01-02",
+		"shared: true",
+		"people: [alice, bob]",
+		"notes: ~",
+		"---",
+		"# Weekly Review",
+		"",
+		"Went well #review",
+	}, "\n")
+
+	data, err := svc.ExtractAll([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly Review", data.Property.Title)
+	assert.Equal(t, []string{"review"}, data.Tags)
+	require.Len(t, data.Properties, 5)
+	assert.Equal(t, "done", data.Properties["status"].GetStringValue())
+	assert.Equal(t, float64(2), data.Properties["priority"].GetNumberValue())
+	assert.Equal(t, int64(1767312000), data.Properties["due"].GetDateTs())
+	assert.True(t, data.Properties["shared"].GetBoolValue())
+	assert.Equal(t, []string{"alice", "bob"}, data.Properties["people"].GetListValue().GetValues())
+
+	rendered, err := svc.RenderMarkdown([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, content, rendered)
+
+	snippet, err := svc.GenerateSnippet([]byte(content), 100)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly Review Went well #review", snippet)
+
+	renamed, err := svc.RenameTag([]byte(content), "review", "retro")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(content, "#review", "#retro", 1), renamed)
+}
+
+func TestFrontMatterRequiresClosedMapping(t *testing.T) {
+	svc := NewService()
+	for _, content := range []string{
+		"---\nstatus: done\n",
+		"---\njust a line\n---\n",
+		"text\n---\nstatus: done\n---\n",
+	} {
+		data, err := svc.ExtractAll([]byte(content))
+		require.NoError(t, err)
+		assert.Empty(t, data.Properties, content)
+	}
+}
+
 func TestExtractAllManagedAttachmentImages(t *testing.T) {
 	svc := NewService()
 	data, err := svc.ExtractAll([]byte(strings.Join([]string{
@@ -487,7 +606,7 @@ func TestMentionSourceSpelling(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, content, rendered)
 
-	html, err := svc.RenderHTML([]byte(content))
+	html, err := svc.RenderHTML(context.Background(), []byte(content))
 	require.NoError(t, err)
 	assert.Contains(t, html, "@Alice-2")
 }
@@ -847,16 +966,16 @@ func TestTagSourceSpelling(t *testing.T) {
 
 func TestRenderHTMLPreservesTagSourceSpelling(t *testing.T) {
 	svc := NewService(WithTagExtension())
-	html, err := svc.RenderHTML([]byte("#R&D #A\u200dB"))
+	html, err := svc.RenderHTML(context.Background(), []byte("#R&D #A\u200dB"))
 	require.NoError(t, err)
-	assert.Equal(t, "<p>#R&amp;D #A\u200dB</p>\n", html)
+	assert.Equal(t, "<p><span class=\"tag\" data-tag=\"R&amp;D\">#R&amp;D</span> <span class=\"tag\" data-tag=\"AB\">#A\u200dB</span></p>\n", html)
 }
 
 func TestRenderHTMLRejectsUnclosedReferenceDestination(t *testing.T) {
 	svc := NewService(WithTagExtension())
-	html, err := svc.RenderHTML([]byte("[#use][bad]\n\n[bad]:("))
+	html, err := svc.RenderHTML(context.Background(), []byte("[#use][bad]\n\n[bad]:("))
 	require.NoError(t, err)
-	assert.Equal(t, "<p>[#use][bad]</p>\n<p>[bad]:(</p>\n", html)
+	assert.Equal(t, "<p>[<span class=\"tag\" data-tag=\"use\">#use</span>][bad]</p>\n<p>[bad]:(</p>\n", html)
 }
 
 func TestRenderHTMLRecognizesGFMEmails(t *testing.T) {
@@ -871,11 +990,11 @@ func TestRenderHTMLRecognizesGFMEmails(t *testing.T) {
 		},
 		{
 			content:  "#foo/bar_baz@example.com",
-			expected: "<p>#foo/<a href=\"mailto:bar_baz@example.com\">bar_baz@example.com</a></p>\n",
+			expected: "<p><span class=\"tag\" data-tag=\"foo\">#foo</span>/<a href=\"mailto:bar_baz@example.com\">bar_baz@example.com</a></p>\n",
 		},
 		{
 			content:  "_foo@example.com #tag_",
-			expected: "<p><em><a href=\"mailto:foo@example.com\">foo@example.com</a> #tag</em></p>\n",
+			expected: "<p><em><a href=\"mailto:foo@example.com\">foo@example.com</a> <span class=\"tag\" data-tag=\"tag\">#tag</span></em></p>\n",
 		},
 		{
 			content:  "foo@bar.com@baz.example",
@@ -907,7 +1026,7 @@ func TestRenderHTMLRecognizesGFMEmails(t *testing.T) {
 		},
 	}
 	for _, test := range tests {
-		html, err := svc.RenderHTML([]byte(test.content))
+		html, err := svc.RenderHTML(context.Background(), []byte(test.content))
 		require.NoError(t, err)
 		assert.Equal(t, test.expected, html)
 	}
@@ -915,7 +1034,7 @@ func TestRenderHTMLRecognizesGFMEmails(t *testing.T) {
 
 func TestRenderHTMLRecognizesGFMEmailWithoutTagExtension(t *testing.T) {
 	svc := NewService()
-	html, err := svc.RenderHTML([]byte("mail@example.com"))
+	html, err := svc.RenderHTML(context.Background(), []byte("mail@example.com"))
 	require.NoError(t, err)
 	assert.Equal(t, "<p><a href=\"mailto:mail@example.com\">mail@example.com</a></p>\n", html)
 }
@@ -928,7 +1047,7 @@ func TestRenderHTMLRecognizesGFMURLsInsideUnresolvedBracketText(t *testing.T) {
 		"![ https://example.com/#hidden]",
 		"[text https://example.com/#hidden",
 	} {
-		html, err := svc.RenderHTML([]byte(content))
+		html, err := svc.RenderHTML(context.Background(), []byte(content))
 		require.NoError(t, err)
 		assert.Contains(t, html, "<a href=\"https://example.com/#hidden")
 
@@ -968,10 +1087,63 @@ func TestMathRenderingPreservesLiteralSource(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, content, rendered)
 	}
+}
+
+func TestRenderHTMLMathML(t *testing.T) {
+	svc := NewService(WithTagExtension())
+	html, err := svc.RenderHTML(context.Background(), []byte("$x < y$\n\n$$meta\n\\frac{a}{b}\n$$"))
+	require.NoError(t, err)
+	assert.Equal(t, `<p><math xmlns="http://www.w3.org/1998/Math/MathML"><semantics><mrow><mi>x</mi><mo>&lt;</mo><mi>y</mi></mrow>`+
+		`<annotation encoding="application/x-tex">x &lt; y</annotation></semantics></math></p>`+"\n"+
+		`<math xmlns="http://www.w3.org/1998/Math/MathML" display="block"><semantics><mrow><mfrac><mrow><mi>a</mi></mrow><mrow><mi>b</mi></mrow></mfrac></mrow>`+
+		`<annotation encoding="application/x-tex">\frac{a}{b}</annotation></semantics></math>`+"\n", html)
+}
 
-	html, err := svc.RenderHTML([]byte("$x < y$\n\n$$meta\nx < y\n$$"))
+func TestRenderHTMLMemoNodes(t *testing.T) {
+	svc := NewService(WithTagExtension(), WithMentionExtension(), WithInstanceURL("https://memos.example.com/"))
+	html, err := svc.RenderHTML(context.Background(), []byte("#work @alice\n![photo](/file/attachments/abc/photo.png) [memo](/memos/abc) [site](https://example.com)"))
+	require.NoError(t, err)
+	assert.Equal(t, `<p><span class="tag" data-tag="work">#work</span> `+
+		`<a class="mention" href="https://memos.example.com/u/alice" data-mention="alice">@alice</a><br>`+"\n"+
+		`<img src="https://memos.example.com/file/attachments/abc/photo.png" alt="photo"> `+
+		`<a href="https://memos.example.com/memos/abc">memo</a> <a href="https://example.com">site</a></p>`+"\n", html)
+}
+
+func TestRenderHTMLHighlightsCode(t *testing.T) {
+	svc := NewService()
+	html, err := svc.RenderHTML(context.Background(), []byte("```go\nreturn nil\n```\n\n```unknown\na < b\n```"))
+	require.NoError(t, err)
+	assert.Contains(t, html, `<pre><code class="language-go"><span style="color: #cf222e">return</span>`)
+	assert.Contains(t, html, `<pre><code class="language-unknown">a &lt; b`+"\n"+`</code></pre>`)
+}
+
+func TestRenderHTMLDiagrams(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+	var resolved []string
+	svc := NewService(
+		WithInstanceURL("https://memos.example.com"),
+		WithDiagramResolver(func(ctx context.Context, language string, source []byte) (string, bool) {
+			assert.Equal(t, "request", ctx.Value(ctxKey{}))
+			resolved = append(resolved, language+":"+string(source))
+			if language != "dot" {
+				return "", false
+			}
+			return "/file/diagrams/abc.svg", true
+		}),
+	)
+	html, err := svc.RenderHTML(ctx, []byte("```dot\ndigraph { a -> b }\n```\n\n```mermaid\ngraph TD\n```\n\n    indented"))
 	require.NoError(t, err)
-	assert.Equal(t, "<p>$x &lt; y$</p>\n$$meta\nx &lt; y\n$$", html)
+	assert.Contains(t, html, `<p><img class="diagram" src="https://memos.example.com/file/diagrams/abc.svg" alt="dot diagram"></p>`)
+	assert.Contains(t, html, `<pre><code class="language-mermaid">graph TD`)
+	assert.Equal(t, []string{"dot:digraph { a -> b }\n", "mermaid:graph TD\n"}, resolved)
+}
+
+func TestRenderHTMLSanitizesRawHTML(t *testing.T) {
+	svc := NewService()
+	html, err := svc.RenderHTML(context.Background(), []byte(`<b onclick="steal()">bold</b><script>alert(1)</script> <img src="x" onerror="steal()"> [link](javascript:alert(1)) <iframe src="https://example.com"></iframe>`))
+	require.NoError(t, err)
+	assert.Equal(t, `<p><b>bold</b> <img src="x"> link </p>`+"\n", html)
 }
 
 func TestRenderMarkdownPreservesLineBreakAfterTag(t *testing.T) {
@@ -1018,6 +1190,109 @@ func TestRenameTagReplacesEveryExactSourceSpan(t *testing.T) {
 	assert.Equal(t, "#new #new #new#new [#old](url) https://example.com/#old #Old", result)
 }
 
+func TestRewriteTagsRenamesHierarchies(t *testing.T) {
+	svc := NewService(WithTagExtension())
+	content := "#proj/alpha #proj/alpha/docs #project #proj"
+
+	result, err := svc.RewriteTags([]byte(content), func(tag string) (string, bool) {
+		if tag == "proj" || strings.HasPrefix(tag, "proj/") {
+			return "work" + strings.TrimPrefix(tag, "proj"), true
+		}
+		return "", false
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "#work/alpha #work/alpha/docs #project #work", result)
+}
+
+func TestRewriteTagsRemovesTags(t *testing.T) {
+	svc := NewService(WithTagExtension())
+	remove := func(tag string) (string, bool) { return "", tag == "old" }
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{content: "#old", expected: ""},
+		{content: "#old keep", expected: "keep"},
+		{content: "keep #old", expected: "keep"},
+		{content: "keep #old here", expected: "keep here"},
+		{content: "#old #old\n#keep", expected: "\n#keep"},
+		{content: "`#old` #old", expected: "`#old`"},
+	}
+	for _, test := range tests {
+		result, err := svc.RewriteTags([]byte(test.content), remove)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, result, test.content)
+	}
+}
+
+func TestExtractTasks(t *testing.T) {
+	svc := NewService(WithTagExtension(), WithMentionExtension())
+	content := strings.Join([]string{
+		"- [ ] Buy milk due:2026-01-02 !1",
+		"  for the #home",
+		"  - [x] Call @alice @2026-01-03",
+		"- [ ] Buy milk !3",
+		"1. [X] Numbered",
+		"> - [ ] Quoted",
+	}, "\n")
+
+	data, err := svc.ExtractAll([]byte(content))
+	require.NoError(t, err)
+	require.Len(t, data.Tasks, 5)
+
+	assert.Equal(t, "Buy milk for the #home", data.Tasks[0].Text)
+	assert.False(t, data.Tasks[0].Checked)
+	assert.Equal(t, int64(1767312000), data.Tasks[0].DueTs)
+	assert.Equal(t, int32(1), data.Tasks[0].Priority)
+
+	assert.Equal(t, "Call @alice", data.Tasks[1].Text)
+	assert.True(t, data.Tasks[1].Checked)
+	assert.Equal(t, int64(1767398400), data.Tasks[1].DueTs)
+
+	assert.Equal(t, "Buy milk", data.Tasks[2].Text)
+	assert.Equal(t, int32(3), data.Tasks[2].Priority)
+	assert.True(t, data.Tasks[3].Checked)
+	assert.Equal(t, "Quoted", data.Tasks[4].Text)
+
+	ids := map[string]bool{}
+	for _, task := range data.Tasks {
+		assert.False(t, ids[task.Id], "duplicate task ID %s", task.Id)
+		ids[task.Id] = true
+	}
+
+	// Checking a task keeps its ID.
+	checked, err := svc.SetTaskState([]byte(content), data.Tasks[0].Id, true)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(content, "- [ ] Buy milk due", "- [x] Buy milk due", 1), checked)
+	next, err := svc.ExtractAll([]byte(checked))
+	require.NoError(t, err)
+	assert.Equal(t, data.Tasks[0].Id, next.Tasks[0].Id)
+	assert.True(t, next.Tasks[0].Checked)
+}
+
+func TestSetTaskState(t *testing.T) {
+	svc := NewService()
+	content := "---\ndone: false\n---\n- [ ] one\n- [X] two\n- [ ] one"
+	data, err := svc.ExtractAll([]byte(content))
+	require.NoError(t, err)
+	require.Len(t, data.Tasks, 3)
+
+	unchecked, err := svc.SetTaskState([]byte(content), data.Tasks[1].Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, "---\ndone: false\n---\n- [ ] one\n- [ ] two\n- [ ] one", unchecked)
+
+	duplicate, err := svc.SetTaskState([]byte(content), data.Tasks[2].Id, true)
+	require.NoError(t, err)
+	assert.Equal(t, "---\ndone: false\n---\n- [ ] one\n- [X] two\n- [x] one", duplicate)
+
+	same, err := svc.SetTaskState([]byte(content), data.Tasks[1].Id, true)
+	require.NoError(t, err)
+	assert.Equal(t, content, same)
+
+	_, err = svc.SetTaskState([]byte(content), "missing", true)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
 func TestUniquePreserveCase(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/util"
+)
+
+// WikiLinkMatch is one [[target]] link or ![[target]] embed in an eligible
+// literal-source run.
+type WikiLinkMatch struct {
+	// Start is the byte offset of the opening brackets, or of the ! introducer
+	// of an embed, within the source run.
+	Start int
+	// End is the exclusive byte offset after the closing brackets.
+	End int
+	// Target is the text between the brackets without surrounding spaces.
+	Target []byte
+	// Embed reports whether the link was written as ![[target]].
+	Embed bool
+}
+
+// FindWikiLinkMatches enumerates [[target]] links and ![[target]] embeds in one
+// eligible literal-source run.
+// A target is a memo UID or title on a single line; it cannot contain brackets
+// and cannot be blank.
+func FindWikiLinkMatches(source []byte) []WikiLinkMatch {
+	var matches []WikiLinkMatch
+	for pos := 0; pos < len(source); {
+		if source[pos] == '\\' && pos+1 < len(source) && util.IsPunct(source[pos+1]) {
+			pos += 2
+			continue
+		}
+		if !bytes.HasPrefix(source[pos:], []byte("[[")) {
+			pos++
+			continue
+		}
+
+		end := pos + 2
+		for end < len(source) && !isWikiLinkTerminator(source[end]) {
+			end++
+		}
+		if !bytes.HasPrefix(source[end:], []byte("]]")) {
+			pos++
+			continue
+		}
+		target := bytes.TrimSpace(source[pos+2 : end])
+		if len(target) == 0 {
+			pos++
+			continue
+		}
+		match := WikiLinkMatch{
+			Start:  pos,
+			End:    end + 2,
+			Target: append([]byte(nil), target...),
+		}
+		if pos > 0 && source[pos-1] == '!' && !isEscaped(source, pos-1) {
+			match.Start--
+			match.Embed = true
+		}
+		matches = append(matches, match)
+		pos = end + 2
+	}
+	return matches
+}
+
+func isWikiLinkTerminator(b byte) bool {
+	return b == '[' || b == ']' || b == '\n' || b == '\r'
+}
+
+// isEscaped reports whether the byte at pos is preceded by an odd number of
+// backslashes.
+func isEscaped(source []byte, pos int) bool {
+	backslashes := 0
+	for i := pos - 1; i >= 0 && source[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindWikiLinkMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{name: "uid and title", source: "see [[abc123]] and [[Weekly Review]]", want: []string{"abc123", "Weekly Review"}},
+		{name: "trimmed target", source: "[[  Reading List ]]", want: []string{"Reading List"}},
+		{name: "blank target", source: "[[]] [[  ]]"},
+		{name: "unclosed", source: "[[abc123] and [[def"},
+		{name: "nested brackets", source: "[[[abc123]]]", want: []string{"abc123"}},
+		{name: "no line breaks", source: "[[Weekly\nReview]]"},
+		{name: "escaped opener", source: `\[[abc123]] [[def456]]`, want: []string{"def456"}},
+		{name: "unicode title", source: "[[读书笔记]]", want: []string{"读书笔记"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matches := FindWikiLinkMatches([]byte(test.source))
+			var got []string
+			for _, match := range matches {
+				assert.Equal(t, "[[", test.source[match.Start:match.Start+2])
+				assert.Equal(t, "]]", test.source[match.End-2:match.End])
+				got = append(got, string(match.Target))
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestFindWikiLinkMatchesEmbeds(t *testing.T) {
+	source := `![[snippet]] [[link]] \![[escaped]] !![[double]]`
+	matches := FindWikiLinkMatches([]byte(source))
+	if !assert.Len(t, matches, 4) {
+		return
+	}
+
+	assert.Equal(t, "![[snippet]]", source[matches[0].Start:matches[0].End])
+	assert.True(t, matches[0].Embed)
+	assert.Equal(t, "[[link]]", source[matches[1].Start:matches[1].End])
+	assert.False(t, matches[1].Embed)
+	assert.Equal(t, "[[escaped]]", source[matches[2].Start:matches[2].End])
+	assert.False(t, matches[2].Embed)
+	assert.Equal(t, "![[double]]", source[matches[3].Start:matches[3].End])
+	assert.True(t, matches[3].Embed)
+}
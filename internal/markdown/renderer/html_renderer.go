@@ -0,0 +1,219 @@
+package renderer
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	gast "github.com/yuin/goldmark/ast"
+	grenderer "github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+
+	mast "github.com/usememos/memos/internal/markdown/ast"
+)
+
+// codeHighlightStyle is the chroma style of highlighted code blocks. It
+// matches the light highlight.js theme of the web client.
+const codeHighlightStyle = "github"
+
+// DiagramURLAttribute is the attribute of a fenced code block that holds the
+// root-relative URL of its rendered diagram. Blocks that carry it render as
+// the diagram image instead of code.
+const DiagramURLAttribute = "diagram-url"
+
+// HTMLRenderer renders code blocks and the memo-specific inline and block
+// nodes to HTML the way the web client shows them. Highlighting uses inline
+// styles because feed readers and mail clients load no stylesheet.
+type HTMLRenderer struct {
+	instanceURL string
+	formatter   *chromahtml.Formatter
+	style       *chroma.Style
+}
+
+// NewHTMLRenderer creates an HTML renderer. A non-empty instanceURL turns the
+// root-relative links it writes into absolute ones.
+func NewHTMLRenderer(instanceURL string) *HTMLRenderer {
+	return &HTMLRenderer{
+		instanceURL: strings.TrimRight(instanceURL, "/"),
+		formatter:   chromahtml.New(chromahtml.WithClasses(false), chromahtml.PreventSurroundingPre(true)),
+		style:       styles.Get(codeHighlightStyle),
+	}
+}
+
+// RegisterFuncs implements goldmark's renderer.NodeRenderer.
+func (r *HTMLRenderer) RegisterFuncs(registerer grenderer.NodeRendererFuncRegisterer) {
+	registerer.Register(mast.KindTag, r.renderTag)
+	registerer.Register(mast.KindMention, r.renderMention)
+	registerer.Register(mast.KindGFMEmail, r.renderGFMEmail)
+	registerer.Register(mast.KindInlineMath, r.renderMath)
+	registerer.Register(mast.KindBlockMath, r.renderMath)
+	registerer.Register(gast.KindCodeBlock, r.renderCodeBlock)
+	registerer.Register(gast.KindFencedCodeBlock, r.renderCodeBlock)
+}
+
+// AbsoluteURL returns destination with the instance URL prefixed when it is
+// root-relative, such as /file/attachments/abc or /u/alice.
+func (r *HTMLRenderer) AbsoluteURL(destination []byte) []byte {
+	if r.instanceURL == "" || !bytes.HasPrefix(destination, []byte("/")) || bytes.HasPrefix(destination, []byte("//")) {
+		return destination
+	}
+	return append([]byte(r.instanceURL), destination...)
+}
+
+func (*HTMLRenderer) renderTag(writer util.BufWriter, _ []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	tagNode, ok := node.(*mast.TagNode)
+	if !entering || !ok {
+		return gast.WalkContinue, nil
+	}
+	spelling := tagNode.Source
+	if len(spelling) == 0 {
+		spelling = append([]byte{'#'}, tagNode.Tag...)
+	}
+	_, _ = writer.WriteString(`<span class="tag" data-tag="`)
+	_, _ = writer.Write(util.EscapeHTML(tagNode.Tag))
+	_, _ = writer.WriteString(`">`)
+	_, _ = writer.Write(util.EscapeHTML(spelling))
+	_, _ = writer.WriteString(`</span>`)
+	return gast.WalkContinue, nil
+}
+
+func (r *HTMLRenderer) renderMention(writer util.BufWriter, _ []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	mentionNode, ok := node.(*mast.MentionNode)
+	if !entering || !ok {
+		return gast.WalkContinue, nil
+	}
+	spelling := mentionNode.Source
+	if len(spelling) == 0 {
+		spelling = append([]byte{'@'}, mentionNode.Username...)
+	}
+	href := r.AbsoluteURL(append([]byte("/u/"), mentionNode.Username...))
+	_, _ = writer.WriteString(`<a class="mention" href="`)
+	_, _ = writer.Write(util.EscapeHTML(util.URLEscape(href, true)))
+	_, _ = writer.WriteString(`" data-mention="`)
+	_, _ = writer.Write(util.EscapeHTML(mentionNode.Username))
+	_, _ = writer.WriteString(`">`)
+	_, _ = writer.Write(util.EscapeHTML(spelling))
+	_, _ = writer.WriteString(`</a>`)
+	return gast.WalkContinue, nil
+}
+
+func (*HTMLRenderer) renderGFMEmail(writer util.BufWriter, _ []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	emailNode, ok := node.(*mast.GFMEmailNode)
+	if !entering || !ok {
+		return gast.WalkContinue, nil
+	}
+	_, _ = writer.WriteString(`<a href="mailto:`)
+	_, _ = writer.Write(util.EscapeHTML(emailNode.Address))
+	_, _ = writer.WriteString(`">`)
+	_, _ = writer.Write(util.EscapeHTML(emailNode.Address))
+	_, _ = writer.WriteString(`</a>`)
+	return gast.WalkContinue, nil
+}
+
+func (*HTMLRenderer) renderMath(writer util.BufWriter, _ []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkContinue, nil
+	}
+	switch mathNode := node.(type) {
+	case *mast.InlineMathNode:
+		_, _ = writer.WriteString(RenderMathML(inlineMathTeX(mathNode), false))
+	case *mast.BlockMathNode:
+		_, _ = writer.WriteString(RenderMathML(blockMathTeX(mathNode), true))
+		_ = writer.WriteByte('\n')
+	default:
+	}
+	return gast.WalkContinue, nil
+}
+
+// inlineMathTeX returns the TeX between the dollar delimiters of node.
+func inlineMathTeX(node *mast.InlineMathNode) string {
+	source := node.Source
+	fence := 0
+	for fence < len(source) && source[fence] == '$' {
+		fence++
+	}
+	if len(source) < 2*fence {
+		return ""
+	}
+	return string(source[fence : len(source)-fence])
+}
+
+// blockMathTeX returns the TeX between the dollar fence lines of node. An
+// unclosed block runs to the end of its container.
+func blockMathTeX(node *mast.BlockMathNode) string {
+	lines := strings.SplitAfter(string(node.Source), "\n")[1:]
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 0 {
+		closing := strings.TrimSpace(lines[len(lines)-1])
+		if len(closing) >= node.FenceLength() && strings.Trim(closing, "$") == "" {
+			lines = lines[:len(lines)-1]
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, ""))
+}
+
+func (r *HTMLRenderer) renderCodeBlock(writer util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkSkipChildren, nil
+	}
+	var code bytes.Buffer
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		code.Write(line.Value(source))
+	}
+
+	var language []byte
+	if fenced, ok := node.(*gast.FencedCodeBlock); ok {
+		language = fenced.Language(source)
+	}
+	if url, ok := node.AttributeString(DiagramURLAttribute); ok {
+		if url, ok := url.([]byte); ok {
+			_, _ = writer.WriteString(`<p><img class="diagram" src="`)
+			_, _ = writer.Write(util.EscapeHTML(util.URLEscape(r.AbsoluteURL(url), true)))
+			_, _ = writer.WriteString(`" alt="`)
+			_, _ = writer.Write(util.EscapeHTML(language))
+			_, _ = writer.WriteString(` diagram"></p>` + "\n")
+			return gast.WalkSkipChildren, nil
+		}
+	}
+	_, _ = writer.WriteString("<pre><code")
+	if len(language) > 0 {
+		_, _ = writer.WriteString(` class="language-`)
+		_, _ = writer.Write(util.EscapeHTML(language))
+		_, _ = writer.WriteString(`"`)
+	}
+	_, _ = writer.WriteString(">")
+	if !r.writeHighlightedCode(writer, string(language), code.String()) {
+		_, _ = writer.Write(util.EscapeHTML(code.Bytes()))
+	}
+	_, _ = writer.WriteString("</code></pre>\n")
+	return gast.WalkSkipChildren, nil
+}
+
+// writeHighlightedCode writes code highlighted as language. It reports false
+// when the language is unknown, leaving the code to be written plain.
+func (r *HTMLRenderer) writeHighlightedCode(writer util.BufWriter, language, code string) bool {
+	if language == "" {
+		return false
+	}
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		return false
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return false
+	}
+	var highlighted bytes.Buffer
+	if err := r.formatter.Format(&highlighted, r.style, iterator); err != nil {
+		return false
+	}
+	_, _ = writer.Write(highlighted.Bytes())
+	return true
+}
@@ -202,6 +202,24 @@ func (r *MarkdownRenderer) renderNode(node gast.Node, source []byte, depth int)
 			r.buf.Write(n.Username)
 		}
 
+	case *mast.WikiLinkNode:
+		if len(n.Source) > 0 {
+			r.buf.Write(n.Source)
+		} else {
+			r.buf.WriteString("[[")
+			r.buf.Write(n.Target)
+			r.buf.WriteString("]]")
+		}
+
+	case *mast.MemoEmbedNode:
+		if len(n.Source) > 0 {
+			r.buf.Write(n.Source)
+		} else {
+			r.buf.WriteString("![[")
+			r.buf.Write(n.Target)
+			r.buf.WriteString("]]")
+		}
+
 	default:
 		// For unknown nodes, try to render children
 		r.renderChildren(n, source, depth)
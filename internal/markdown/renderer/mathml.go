@@ -0,0 +1,366 @@
+package renderer
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// mathMLNamespace is the XML namespace of MathML elements.
+const mathMLNamespace = "http://www.w3.org/1998/Math/MathML"
+
+// mathIdentifiers maps TeX commands to the characters of MathML identifiers.
+var mathIdentifiers = map[string]string{
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ϵ", "varepsilon": "ε",
+	"zeta": "ζ", "eta": "η", "theta": "θ", "vartheta": "ϑ", "iota": "ι", "kappa": "κ",
+	"lambda": "λ", "mu": "μ", "nu": "ν", "xi": "ξ", "pi": "π", "varpi": "ϖ", "rho": "ρ",
+	"varrho": "ϱ", "sigma": "σ", "varsigma": "ς", "tau": "τ", "upsilon": "υ", "phi": "ϕ",
+	"varphi": "φ", "chi": "χ", "psi": "ψ", "omega": "ω",
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ", "Pi": "Π",
+	"Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ", "Omega": "Ω",
+	"infty": "∞", "partial": "∂", "nabla": "∇", "ell": "ℓ", "hbar": "ℏ", "emptyset": "∅",
+	"Re": "ℜ", "Im": "ℑ", "aleph": "ℵ",
+}
+
+// mathOperators maps TeX commands to the characters of MathML operators.
+var mathOperators = map[string]string{
+	"times": "×", "cdot": "⋅", "div": "÷", "pm": "±", "mp": "∓", "ast": "∗", "star": "⋆",
+	"circ": "∘", "bullet": "∙", "leq": "≤", "le": "≤", "geq": "≥", "ge": "≥", "neq": "≠",
+	"ne": "≠", "approx": "≈", "equiv": "≡", "sim": "∼", "simeq": "≃", "cong": "≅",
+	"propto": "∝", "ll": "≪", "gg": "≫", "in": "∈", "notin": "∉", "ni": "∋",
+	"subset": "⊂", "supset": "⊃", "subseteq": "⊆", "supseteq": "⊇", "cup": "∪", "cap": "∩",
+	"setminus": "∖", "wedge": "∧", "land": "∧", "vee": "∨", "lor": "∨", "neg": "¬",
+	"lnot": "¬", "forall": "∀", "exists": "∃", "to": "→", "rightarrow": "→",
+	"leftarrow": "←", "gets": "←", "leftrightarrow": "↔", "Rightarrow": "⇒",
+	"Leftarrow": "⇐", "Leftrightarrow": "⇔", "implies": "⟹", "iff": "⟺", "mapsto": "↦",
+	"sum": "∑", "prod": "∏", "coprod": "∐", "int": "∫", "iint": "∬", "oint": "∮",
+	"bigcup": "⋃", "bigcap": "⋂", "ldots": "…", "cdots": "⋯", "vdots": "⋮", "ddots": "⋱",
+	"dots": "…", "langle": "⟨", "rangle": "⟩", "lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈",
+	"rceil": "⌉", "mid": "∣", "parallel": "∥", "perp": "⊥", "angle": "∠", "prime": "′",
+	"lbrace": "{", "rbrace": "}", "vert": "|", "Vert": "‖",
+}
+
+// mathFunctions are the TeX commands that name functions set in upright type.
+var mathFunctions = map[string]bool{
+	"sin": true, "cos": true, "tan": true, "cot": true, "sec": true, "csc": true,
+	"arcsin": true, "arccos": true, "arctan": true, "sinh": true, "cosh": true, "tanh": true,
+	"log": true, "ln": true, "lg": true, "exp": true, "lim": true, "liminf": true,
+	"limsup": true, "max": true, "min": true, "sup": true, "inf": true, "det": true,
+	"dim": true, "gcd": true, "deg": true, "arg": true, "ker": true, "Pr": true,
+}
+
+// mathAccents maps TeX accent commands to the operator placed over their argument.
+var mathAccents = map[string]string{
+	"hat": "^", "widehat": "^", "bar": "¯", "overline": "¯", "vec": "→", "dot": "˙",
+	"ddot": "¨", "tilde": "~", "widetilde": "~",
+}
+
+// mathVariants maps TeX font commands to MathML mathvariant values.
+var mathVariants = map[string]string{
+	"mathbf": "bold", "boldsymbol": "bold-italic", "mathit": "italic", "mathrm": "normal",
+	"mathbb": "double-struck", "mathcal": "script", "mathfrak": "fraktur",
+	"mathsf": "sans-serif", "mathtt": "monospace",
+}
+
+// mathSpaces maps TeX spacing commands to MathML space widths.
+var mathSpaces = map[string]string{
+	",": "0.1667em", ":": "0.2222em", ";": "0.2778em", " ": "0.2778em",
+	"quad": "1em", "qquad": "2em",
+}
+
+// RenderMathML converts a TeX math expression to presentation MathML. The TeX
+// source is kept as an annotation. Commands outside the supported subset
+// render as errors showing the command, so the rest of the expression stays
+// readable.
+func RenderMathML(tex string, display bool) string {
+	p := &mathParser{source: []rune(tex)}
+	var buf strings.Builder
+	buf.WriteString(`<math xmlns="` + mathMLNamespace + `"`)
+	if display {
+		buf.WriteString(` display="block"`)
+	}
+	buf.WriteString("><semantics><mrow>")
+	for p.pos < len(p.source) {
+		buf.WriteString(p.parseExpression())
+		if p.pos < len(p.source) {
+			// An unmatched closing brace is shown rather than dropped.
+			buf.WriteString("<mo>}</mo>")
+			p.pos++
+		}
+	}
+	buf.WriteString(`</mrow><annotation encoding="application/x-tex">`)
+	buf.WriteString(html.EscapeString(tex))
+	buf.WriteString("</annotation></semantics></math>")
+	return buf.String()
+}
+
+// mathParser is a recursive descent parser for the TeX math subset.
+type mathParser struct {
+	source []rune
+	pos    int
+}
+
+// parseExpression parses atoms with their scripts up to the end of the
+// source or a closing brace, which it leaves unread.
+func (p *mathParser) parseExpression() string {
+	var buf strings.Builder
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.source) || p.source[p.pos] == '}' {
+			return buf.String()
+		}
+		buf.WriteString(p.parseScripts(p.parseAtom()))
+	}
+}
+
+// parseScripts attaches the subscript, superscript and primes that follow
+// base to it.
+func (p *mathParser) parseScripts(base string) string {
+	var sub, sup string
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.source) {
+			break
+		}
+		switch p.source[p.pos] {
+		case '_':
+			p.pos++
+			sub = p.parseArgument()
+			continue
+		case '^':
+			p.pos++
+			sup += p.parseArgument()
+			continue
+		case '\'':
+			p.pos++
+			sup += "<mo>′</mo>"
+			continue
+		default:
+		}
+		break
+	}
+	switch {
+	case sub != "" && sup != "":
+		return "<msubsup>" + base + wrapMathRow(sub) + wrapMathRow(sup) + "</msubsup>"
+	case sub != "":
+		return "<msub>" + base + wrapMathRow(sub) + "</msub>"
+	case sup != "":
+		return "<msup>" + base + wrapMathRow(sup) + "</msup>"
+	default:
+		return base
+	}
+}
+
+// parseArgument parses a braced group or a single atom.
+func (p *mathParser) parseArgument() string {
+	p.skipSpace()
+	if p.pos >= len(p.source) {
+		return "<mrow></mrow>"
+	}
+	return p.parseAtom()
+}
+
+// parseAtom parses one identifier, number, operator, group or command.
+func (p *mathParser) parseAtom() string {
+	r := p.source[p.pos]
+	switch {
+	case r == '{':
+		p.pos++
+		inner := p.parseExpression()
+		if p.pos < len(p.source) {
+			p.pos++
+		}
+		return "<mrow>" + inner + "</mrow>"
+	case r == '\\':
+		return p.parseCommand()
+	case unicode.IsDigit(r):
+		start := p.pos
+		for p.pos < len(p.source) && (unicode.IsDigit(p.source[p.pos]) || p.source[p.pos] == '.' && p.pos+1 < len(p.source) && unicode.IsDigit(p.source[p.pos+1])) {
+			p.pos++
+		}
+		return "<mn>" + string(p.source[start:p.pos]) + "</mn>"
+	case unicode.IsLetter(r):
+		p.pos++
+		return "<mi>" + html.EscapeString(string(r)) + "</mi>"
+	case r == '&':
+		p.pos++
+		return `<mspace width="1em"></mspace>`
+	case r == '~':
+		p.pos++
+		return `<mspace width="0.2778em"></mspace>`
+	default:
+		p.pos++
+		return "<mo>" + html.EscapeString(string(r)) + "</mo>"
+	}
+}
+
+// parseCommand parses a command starting at a backslash.
+func (p *mathParser) parseCommand() string {
+	p.pos++
+	if p.pos >= len(p.source) {
+		return `<mo>\</mo>`
+	}
+	if r := p.source[p.pos]; !unicode.IsLetter(r) {
+		p.pos++
+		if r == '\\' {
+			return `<mspace linebreak="newline"></mspace>`
+		}
+		if width, ok := mathSpaces[string(r)]; ok {
+			return `<mspace width="` + width + `"></mspace>`
+		}
+		if r == '!' {
+			return ""
+		}
+		return "<mo>" + html.EscapeString(string(r)) + "</mo>"
+	}
+
+	start := p.pos
+	for p.pos < len(p.source) && unicode.IsLetter(p.source[p.pos]) {
+		p.pos++
+	}
+	name := string(p.source[start:p.pos])
+
+	if value, ok := mathIdentifiers[name]; ok {
+		return "<mi>" + value + "</mi>"
+	}
+	if value, ok := mathOperators[name]; ok {
+		return "<mo>" + html.EscapeString(value) + "</mo>"
+	}
+	if mathFunctions[name] {
+		return "<mi>" + name + "</mi>"
+	}
+	if width, ok := mathSpaces[name]; ok {
+		return `<mspace width="` + width + `"></mspace>`
+	}
+	if value, ok := mathAccents[name]; ok {
+		return `<mover accent="true">` + wrapMathRow(p.parseArgument()) + "<mo>" + html.EscapeString(value) + "</mo></mover>"
+	}
+	if variant, ok := mathVariants[name]; ok {
+		return p.parseVariant(variant)
+	}
+	switch name {
+	case "frac", "dfrac", "tfrac":
+		numerator := p.parseArgument()
+		denominator := p.parseArgument()
+		return "<mfrac>" + wrapMathRow(numerator) + wrapMathRow(denominator) + "</mfrac>"
+	case "sqrt":
+		index, ok := p.parseOptionalArgument()
+		radicand := p.parseArgument()
+		if ok {
+			return "<mroot>" + wrapMathRow(radicand) + wrapMathRow(index) + "</mroot>"
+		}
+		return "<msqrt>" + radicand + "</msqrt>"
+	case "text", "textrm", "mbox", "operatorname":
+		text := p.parseRawArgument()
+		if name == "operatorname" {
+			return "<mi>" + html.EscapeString(text) + "</mi>"
+		}
+		return "<mtext>" + html.EscapeString(text) + "</mtext>"
+	case "left", "right", "big", "Big", "bigg", "Bigg":
+		return p.parseDelimiter()
+	default:
+		return `<merror><mtext>\` + html.EscapeString(name) + "</mtext></merror>"
+	}
+}
+
+// parseVariant parses the argument of a font command. Plain letters and
+// digits become one identifier in that font.
+func (p *mathParser) parseVariant(variant string) string {
+	start := p.pos
+	text := p.parseRawArgument()
+	plain := text != ""
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return `<mi mathvariant="` + variant + `">` + html.EscapeString(text) + "</mi>"
+	}
+	p.pos = start
+	return p.parseArgument()
+}
+
+// parseDelimiter parses the delimiter after \left, \right or a sizing command.
+func (p *mathParser) parseDelimiter() string {
+	p.skipSpace()
+	if p.pos >= len(p.source) {
+		return ""
+	}
+	if p.source[p.pos] == '.' {
+		p.pos++
+		return ""
+	}
+	atom := p.parseAtom()
+	return strings.Replace(atom, "<mo>", `<mo stretchy="true">`, 1)
+}
+
+// parseOptionalArgument parses a [bracketed] argument if one follows.
+func (p *mathParser) parseOptionalArgument() (string, bool) {
+	p.skipSpace()
+	if p.pos >= len(p.source) || p.source[p.pos] != '[' {
+		return "", false
+	}
+	p.pos++
+	var buf strings.Builder
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.source) {
+			break
+		}
+		if p.source[p.pos] == ']' {
+			p.pos++
+			break
+		}
+		if p.source[p.pos] == '}' {
+			break
+		}
+		buf.WriteString(p.parseScripts(p.parseAtom()))
+	}
+	return buf.String(), true
+}
+
+// parseRawArgument reads the literal text of a braced argument or a single
+// character.
+func (p *mathParser) parseRawArgument() string {
+	p.skipSpace()
+	if p.pos >= len(p.source) {
+		return ""
+	}
+	if p.source[p.pos] != '{' {
+		p.pos++
+		return string(p.source[p.pos-1])
+	}
+	depth := 0
+	start := p.pos + 1
+	for ; p.pos < len(p.source); p.pos++ {
+		switch p.source[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return string(p.source[start : p.pos-1])
+			}
+		default:
+		}
+	}
+	return string(p.source[start:])
+}
+
+func (p *mathParser) skipSpace() {
+	for p.pos < len(p.source) && unicode.IsSpace(p.source[p.pos]) {
+		p.pos++
+	}
+}
+
+// wrapMathRow makes a sequence of elements a single script or fraction
+// argument.
+func wrapMathRow(elements string) string {
+	if strings.HasPrefix(elements, "<mrow>") && strings.HasSuffix(elements, "</mrow>") && strings.Count(elements, "<mrow>") == 1 {
+		return elements
+	}
+	return "<mrow>" + elements + "</mrow>"
+}
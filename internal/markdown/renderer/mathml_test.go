@@ -0,0 +1,69 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMathML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "identifiers numbers and operators",
+			input:    "2x + 3.5 = y",
+			expected: "<mn>2</mn><mi>x</mi><mo>+</mo><mn>3.5</mn><mo>=</mo><mi>y</mi>",
+		},
+		{
+			name:     "scripts",
+			input:    "x_i^2 + f'",
+			expected: "<msubsup><mi>x</mi><mrow><mi>i</mi></mrow><mrow><mn>2</mn></mrow></msubsup><mo>+</mo><msup><mi>f</mi><mrow><mo>′</mo></mrow></msup>",
+		},
+		{
+			name:     "fraction and roots",
+			input:    `\frac{1}{2} \sqrt{x} \sqrt[3]{y}`,
+			expected: "<mfrac><mrow><mn>1</mn></mrow><mrow><mn>2</mn></mrow></mfrac><msqrt><mrow><mi>x</mi></mrow></msqrt><mroot><mrow><mi>y</mi></mrow><mrow><mn>3</mn></mrow></mroot>",
+		},
+		{
+			name:     "symbols functions and text",
+			input:    `\alpha \leq \sin\theta \text{if } \mathbb{R}`,
+			expected: `<mi>α</mi><mo>≤</mo><mi>sin</mi><mi>θ</mi><mtext>if </mtext><mi mathvariant="double-struck">R</mi>`,
+		},
+		{
+			name:     "stretchy delimiters",
+			input:    `\left( x \right.`,
+			expected: `<mo stretchy="true">(</mo><mi>x</mi>`,
+		},
+		{
+			name:     "unknown command",
+			input:    `\unknown{x} < 1`,
+			expected: `<merror><mtext>\unknown</mtext></merror><mrow><mi>x</mi></mrow><mo>&lt;</mo><mn>1</mn>`,
+		},
+		{
+			name:     "unmatched closing brace",
+			input:    "x}",
+			expected: "<mi>x</mi><mo>}</mo>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenderMathML(tt.input, false)
+			prefix := `<math xmlns="http://www.w3.org/1998/Math/MathML"><semantics><mrow>`
+			assert.True(t, strings.HasPrefix(result, prefix), result)
+			body := strings.TrimPrefix(result, prefix)
+			body = body[:strings.Index(body, "</mrow><annotation")]
+			assert.Equal(t, tt.expected, body)
+		})
+	}
+}
+
+func TestRenderMathMLDisplayKeepsSource(t *testing.T) {
+	result := RenderMathML("a<b", true)
+	assert.Equal(t, `<math xmlns="http://www.w3.org/1998/Math/MathML" display="block"><semantics><mrow><mi>a</mi><mo>&lt;</mo><mi>b</mi></mrow>`+
+		`<annotation encoding="application/x-tex">a&lt;b</annotation></semantics></math>`, result)
+}
@@ -0,0 +1,70 @@
+package markdown
+
+import (
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// mathMLElements are the presentation MathML elements the math renderer writes.
+var mathMLElements = []string{
+	"math", "semantics", "annotation", "mrow", "mi", "mn", "mo", "mtext", "mspace",
+	"mfrac", "msqrt", "mroot", "msub", "msup", "msubsup", "mover", "merror",
+}
+
+// htmlPolicy is the allowlist applied to rendered HTML. It admits the
+// elements Markdown produces, raw HTML limited to the same elements, and the
+// markup of tags, mentions, embeds, math, diagrams and highlighted code;
+// every other element and attribute is dropped.
+var htmlPolicy = newHTMLPolicy()
+
+func newHTMLPolicy() *bluemonday.Policy {
+	policy := bluemonday.NewPolicy()
+	// Standard URLs are parseable http, https, mailto or relative URLs.
+	policy.AllowStandardURLs()
+	policy.RequireNoFollowOnLinks(false)
+
+	policy.AllowElements(
+		"p", "br", "hr", "blockquote", "pre", "b", "i", "em", "strong", "del", "s", "sub", "sup",
+		"ul", "ol", "li", "dl", "dt", "dd", "details", "summary", "kbd", "mark",
+		"table", "thead", "tbody", "tr",
+	)
+	policy.AllowAttrs("id").Matching(regexp.MustCompile(`^[\w-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	policy.AllowElements("h1", "h2", "h3", "h4", "h5", "h6")
+	policy.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	policy.AllowStyles("text-align").MatchingEnum("left", "center", "right").OnElements("th", "td")
+	policy.AllowElements("th", "td")
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^(|checked|disabled)$`)).OnElements("input")
+
+	policy.AllowAttrs("href", "title").OnElements("a")
+	policy.AllowAttrs("src", "alt", "title", "width", "height").OnElements("img")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^diagram$`)).OnElements("img")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^mention$`)).OnElements("a")
+	policy.AllowAttrs("data-mention").OnElements("a")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^tag$`)).OnElements("span")
+	policy.AllowAttrs("data-tag").OnElements("span")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^memo-embed$`)).OnElements("div")
+	policy.AllowAttrs("data-target").OnElements("div")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+	policy.AllowElements("code", "span", "div")
+	policy.AllowStyles("color", "background-color", "font-weight", "font-style", "text-decoration").OnElements("span")
+
+	// MathML elements mostly have no attributes, which bluemonday otherwise
+	// treats as a reason to drop an element.
+	policy.AllowNoAttrs().OnElements(mathMLElements...)
+	policy.AllowAttrs("xmlns").Matching(regexp.MustCompile(`^http://www\.w3\.org/1998/Math/MathML$`)).OnElements("math")
+	policy.AllowAttrs("display").Matching(regexp.MustCompile(`^(block|inline)$`)).OnElements("math")
+	policy.AllowAttrs("encoding").Matching(regexp.MustCompile(`^application/x-tex$`)).OnElements("annotation")
+	policy.AllowAttrs("mathvariant").Matching(regexp.MustCompile(`^[a-z-]+$`)).OnElements("mi")
+	policy.AllowAttrs("stretchy").Matching(regexp.MustCompile(`^(true|false)$`)).OnElements("mo")
+	policy.AllowAttrs("width").Matching(regexp.MustCompile(`^[\d.]+em$`)).OnElements("mspace")
+	policy.AllowAttrs("linebreak").Matching(regexp.MustCompile(`^newline$`)).OnElements("mspace")
+	policy.AllowAttrs("accent").Matching(regexp.MustCompile(`^true$`)).OnElements("mover")
+	return policy
+}
+
+// sanitizeHTML removes everything outside htmlPolicy from rendered HTML.
+func sanitizeHTML(rendered []byte) []byte {
+	return htmlPolicy.SanitizeBytes(rendered)
+}
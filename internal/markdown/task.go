@@ -0,0 +1,141 @@
+package markdown
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	gast "github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// ErrTaskNotFound is returned by SetTaskState when content has no task with
+// the given ID.
+var ErrTaskNotFound = errors.New("task not found")
+
+// taskDueDateLayout is the date format of due:YYYY-MM-DD and @YYYY-MM-DD markers.
+const taskDueDateLayout = "2006-01-02"
+
+// taskCollector extracts tasks in document order and gives each one an ID
+// that is unique within the content.
+type taskCollector struct {
+	seen map[string]int
+}
+
+func newTaskCollector() *taskCollector {
+	return &taskCollector{seen: map[string]int{}}
+}
+
+// collect extracts the task that checkBox belongs to. It also returns the
+// source offset of the checkbox mark, the byte between "[" and "]".
+func (c *taskCollector) collect(checkBox *east.TaskCheckBox, source []byte) (*storepb.MemoPayload_Task, int) {
+	// The task text is the source of the paragraph that holds the checkbox,
+	// so tags, mentions and inline markup keep their written form.
+	offset := -1
+	var buf strings.Builder
+	lines := checkBox.Parent().Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		if i == 0 {
+			offset = line.Start + 1
+			line = line.WithStart(min(line.Start+3, line.Stop))
+		}
+		buf.Write(line.Value(source))
+		buf.WriteByte(' ')
+	}
+
+	task := &storepb.MemoPayload_Task{Checked: checkBox.IsChecked}
+	words := []string{}
+	for _, word := range strings.Fields(buf.String()) {
+		if dueTs, ok := parseTaskDueMarker(word); ok {
+			task.DueTs = dueTs
+			continue
+		}
+		if priority, ok := parseTaskPriorityMarker(word); ok {
+			task.Priority = priority
+			continue
+		}
+		words = append(words, word)
+	}
+	task.Text = strings.Join(words, " ")
+
+	sum := sha256.Sum256([]byte(task.Text))
+	task.Id = hex.EncodeToString(sum[:6])
+	c.seen[task.Id]++
+	if count := c.seen[task.Id]; count > 1 {
+		task.Id = fmt.Sprintf("%s-%d", task.Id, count)
+	}
+	return task, offset
+}
+
+// parseTaskDueMarker parses a due:YYYY-MM-DD or @YYYY-MM-DD marker into
+// midnight UTC of that day.
+func parseTaskDueMarker(word string) (int64, bool) {
+	value, ok := strings.CutPrefix(word, "due:")
+	if !ok {
+		value, ok = strings.CutPrefix(word, "@")
+	}
+	if !ok {
+		return 0, false
+	}
+	date, err := time.Parse(taskDueDateLayout, value)
+	if err != nil {
+		return 0, false
+	}
+	return date.Unix(), true
+}
+
+// parseTaskPriorityMarker parses a !1, !2 or !3 priority marker.
+func parseTaskPriorityMarker(word string) (int32, bool) {
+	if len(word) != 2 || word[0] != '!' || word[1] < '1' || word[1] > '3' {
+		return 0, false
+	}
+	return int32(word[1] - '0'), true
+}
+
+// SetTaskState checks or unchecks the task with the given ID. Only the
+// checkbox mark changes, so the rest of the content is kept byte for byte.
+func (s *service) SetTaskState(content []byte, taskID string, checked bool) (string, error) {
+	root, err := s.parse(content)
+	if err != nil {
+		return "", err
+	}
+
+	collector := newTaskCollector()
+	offset := -1
+	err = gast.Walk(root, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
+		checkBox, ok := n.(*east.TaskCheckBox)
+		if !entering || !ok {
+			return gast.WalkContinue, nil
+		}
+		task, taskOffset := collector.collect(checkBox, content)
+		if task.Id == taskID {
+			offset = taskOffset
+			return gast.WalkStop, nil
+		}
+		return gast.WalkContinue, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if offset < 0 || offset >= len(content) {
+		return "", ErrTaskNotFound
+	}
+
+	mark := content[offset]
+	if checked == (mark == 'x' || mark == 'X') {
+		return string(content), nil
+	}
+	next := append([]byte(nil), content...)
+	if checked {
+		next[offset] = 'x'
+	} else {
+		next[offset] = ' '
+	}
+	return string(next), nil
+}
@@ -0,0 +1,145 @@
+// Package mediapreview extracts poster frames from videos and waveform peaks
+// from audio recordings with the ffmpeg command line tool. Previews are
+// unavailable when ffmpeg is not installed.
+package mediapreview
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultCommand is the ffmpeg binary looked up on PATH.
+	DefaultCommand = "ffmpeg"
+
+	// PosterMaxSize bounds the width and height of poster frames.
+	PosterMaxSize = 1280
+	// WaveformPeakCount is the number of peaks in a waveform.
+	WaveformPeakCount = 100
+
+	posterJPEGQuality = 85
+	// waveformSampleRate is the rate audio is resampled to before measuring
+	// peaks; waveforms do not need more detail.
+	waveformSampleRate = 8000
+)
+
+// Generator creates media previews with ffmpeg.
+type Generator struct {
+	command string
+}
+
+// New constructs a Generator. An empty command uses DefaultCommand, and a
+// command that cannot be found disables previews instead of failing.
+func New(command string) *Generator {
+	if command == "" {
+		command = DefaultCommand
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		path = ""
+	}
+	return &Generator{command: path}
+}
+
+// Available reports whether ffmpeg was found.
+func (g *Generator) Available() bool {
+	return g.command != ""
+}
+
+// Poster extracts the frame of a video at the offset and returns it as a JPEG
+// fitting within PosterMaxSize. Offsets past the end yield the last frame.
+func (g *Generator) Poster(ctx context.Context, inputPath string, offset time.Duration) ([]byte, error) {
+	output, err := g.run(ctx, "poster.png",
+		"-ss", formatSeconds(offset), "-i", inputPath,
+		// -update writes a single image even when the offset is past the end.
+		"-frames:v", "1", "-update", "1", "-f", "image2", "-c:v", "png")
+	if err != nil {
+		return nil, err
+	}
+	img, err := imaging.Decode(bytes.NewReader(output))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode poster frame")
+	}
+	if img.Bounds().Dx() > PosterMaxSize || img.Bounds().Dy() > PosterMaxSize {
+		img = imaging.Fit(img, PosterMaxSize, PosterMaxSize, imaging.Lanczos)
+	}
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, img, imaging.JPEG, imaging.JPEGQuality(posterJPEGQuality)); err != nil {
+		return nil, errors.Wrap(err, "failed to encode poster")
+	}
+	return buf.Bytes(), nil
+}
+
+// Waveform decodes an audio recording and returns WaveformPeakCount peak
+// levels between 0 and 1.
+func (g *Generator) Waveform(ctx context.Context, inputPath string) ([]float32, error) {
+	output, err := g.run(ctx, "samples.pcm",
+		"-i", inputPath, "-vn", "-ac", "1", "-ar", strconv.Itoa(waveformSampleRate), "-f", "s16le", "-c:a", "pcm_s16le")
+	if err != nil {
+		return nil, err
+	}
+	if len(output) < 2 {
+		return nil, errors.New("audio has no samples")
+	}
+	return Peaks(output, WaveformPeakCount), nil
+}
+
+// Peaks splits signed 16-bit little-endian mono samples into count equally
+// long slices and returns the peak level of each, between 0 and 1. Recordings
+// with fewer samples than slices yield one peak per sample.
+func Peaks(samples []byte, count int) []float32 {
+	total := len(samples) / 2
+	count = min(count, total)
+	peaks := make([]float32, count)
+	for i := range count {
+		start, end := i*total/count, (i+1)*total/count
+		var peak int32
+		for j := start; j < end; j++ {
+			sample := int32(int16(binary.LittleEndian.Uint16(samples[2*j:])))
+			peak = max(peak, sample, -sample)
+		}
+		peaks[i] = min(float32(peak)/32768, 1)
+	}
+	return peaks
+}
+
+// run runs ffmpeg with the arguments followed by an output file in a
+// temporary directory, and returns the content of the output file.
+func (g *Generator) run(ctx context.Context, outputName string, args ...string) ([]byte, error) {
+	if !g.Available() {
+		return nil, errors.New("ffmpeg is not available")
+	}
+	dir, err := os.MkdirTemp("", "memos-mediapreview-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create preview directory")
+	}
+	defer os.RemoveAll(dir)
+
+	outputPath := filepath.Join(dir, outputName)
+	args = append([]string{"-nostdin", "-hide_banner", "-loglevel", "error", "-y"}, args...)
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, g.command, append(args, outputPath)...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "ffmpeg failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	output, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read ffmpeg output")
+	}
+	return output, nil
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
@@ -0,0 +1,89 @@
+package mediapreview_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/mediapreview"
+)
+
+// writeFakeFFmpeg installs a shell script standing in for ffmpeg. The script
+// receives the output file as $out.
+func writeFakeFFmpeg(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "ffmpeg")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\neval out=\\${$#}\n"+script), 0o755))
+	return path
+}
+
+func TestUnavailableWithoutFFmpeg(t *testing.T) {
+	t.Parallel()
+
+	generator := mediapreview.New("missing-ffmpeg")
+	require.False(t, generator.Available())
+	_, err := generator.Poster(context.Background(), "video.mp4", 0)
+	require.ErrorContains(t, err, "not available")
+}
+
+func TestPoster(t *testing.T) {
+	t.Parallel()
+
+	frame := filepath.Join(t.TempDir(), "frame.png")
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 2560, 1440))))
+	require.NoError(t, os.WriteFile(frame, buf.Bytes(), 0o600))
+	args := filepath.Join(t.TempDir(), "args")
+
+	generator := mediapreview.New(writeFakeFFmpeg(t, `echo "$@" > "`+args+`"; cp "`+frame+`" "$out"`))
+	require.True(t, generator.Available())
+	poster, err := generator.Poster(context.Background(), "/videos/clip.mov", 1500*time.Millisecond)
+	require.NoError(t, err)
+	img, err := jpeg.Decode(bytes.NewReader(poster))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, mediapreview.PosterMaxSize, 720), img.Bounds())
+
+	recorded, err := os.ReadFile(args)
+	require.NoError(t, err)
+	require.Contains(t, string(recorded), "-ss 1.500 -i /videos/clip.mov -frames:v 1")
+}
+
+func TestWaveform(t *testing.T) {
+	t.Parallel()
+
+	generator := mediapreview.New(writeFakeFFmpeg(t, `printf '\000\100\000\300\000\000\377\177' > "$out"`))
+	peaks, err := generator.Waveform(context.Background(), "/audio/note.m4a")
+	require.NoError(t, err)
+	require.Equal(t, []float32{0.5, 0.5, 0, 32767.0 / 32768}, peaks)
+
+	failing := mediapreview.New(writeFakeFFmpeg(t, `echo "invalid data" >&2; exit 1`))
+	_, err = failing.Waveform(context.Background(), "/audio/broken.m4a")
+	require.ErrorContains(t, err, "ffmpeg failed: invalid data")
+}
+
+func TestPeaks(t *testing.T) {
+	t.Parallel()
+
+	samples := make([]byte, 2*1000)
+	for i := range 1000 {
+		binary.LittleEndian.PutUint16(samples[2*i:], uint16(int16(i*16)))
+	}
+	peaks := mediapreview.Peaks(samples, 10)
+	require.Len(t, peaks, 10)
+	require.InDelta(t, 99*16/32768.0, peaks[0], 1e-6)
+	require.InDelta(t, 999*16/32768.0, peaks[9], 1e-6)
+	require.Empty(t, mediapreview.Peaks(nil, 10))
+}
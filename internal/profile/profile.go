@@ -35,6 +35,9 @@ type Profile struct {
 	Commit string
 	// InstanceURL is the canonical external URL of the Memos instance.
 	InstanceURL string
+	// ExternalDiagramRenderers enables rendering Mermaid and PlantUML diagrams
+	// with the mmdc and plantuml commands on PATH
+	ExternalDiagramRenderers bool
 }
 
 func checkDataDir(dataDir string) (string, error) {
@@ -3,23 +3,37 @@ package storage
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/internal/storage/local"
+	"github.com/usememos/memos/internal/storage/object"
 	"github.com/usememos/memos/internal/storage/s3"
+	"github.com/usememos/memos/internal/storage/sftp"
+	"github.com/usememos/memos/internal/storage/webdav"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
 // ErrRangeNotSatisfiable reports a ranged read whose byte range falls outside
 // the object, so HTTP handlers can answer 416 instead of 500.
-var ErrRangeNotSatisfiable = s3.ErrRangeNotSatisfiable
+var ErrRangeNotSatisfiable = object.ErrRangeNotSatisfiable
+
+// ErrObjectNotFound reports that the requested object does not exist.
+var ErrObjectNotFound = object.ErrNotFound
 
 // RangeNotSatisfiableError carries response metadata for an unsatisfied range.
-type RangeNotSatisfiableError = s3.RangeNotSatisfiableError
+type RangeNotSatisfiableError = object.RangeNotSatisfiableError
 
 // ObjectStream is object content with the metadata needed to answer HTTP
 // range requests. Aliased so driver consumers never import a concrete backend.
-type ObjectStream = s3.ObjectStream
+type ObjectStream = object.Stream
+
+// CompletedPart identifies an uploaded part of a multipart upload.
+type CompletedPart = s3.CompletedPart
+
+// PresignGetOptions overrides response headers of a presigned download.
+type PresignGetOptions = s3.PresignGetOptions
 
 // Driver provides object operations for a configured attachment storage.
 type Driver interface {
@@ -32,7 +46,41 @@ type Driver interface {
 	DeleteObject(ctx context.Context, key string) error
 }
 
-// NewDriver creates the driver for a configured storage.
+// MultipartDriver is implemented by drivers that can assemble an object from
+// separately uploaded parts, so large uploads never have to be buffered whole.
+type MultipartDriver interface {
+	Driver
+	CreateMultipartUpload(ctx context.Context, key string, fileType string) (string, error)
+	// UploadPart uploads part partNumber (starting at 1) and returns its ETag.
+	UploadPart(ctx context.Context, key string, uploadID string, partNumber int32, content io.ReadSeeker) (string, error)
+	CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []CompletedPart) error
+	AbortMultipartUpload(ctx context.Context, key string, uploadID string) error
+}
+
+// PresignDriver is implemented by drivers that can hand clients short-lived
+// URLs to transfer objects without proxying the content through the server.
+type PresignDriver interface {
+	Driver
+	// PresignEnabled reports whether the storage opted into presigned transfers.
+	PresignEnabled() bool
+	PresignPutObject(ctx context.Context, key string, fileType string, expires time.Duration) (string, error)
+	PresignGetObject(ctx context.Context, key string, expires time.Duration, opts PresignGetOptions) (string, error)
+	// StatObject returns the size of an object in bytes.
+	StatObject(ctx context.Context, key string) (int64, error)
+	// CopyObject copies an object within the storage without downloading it.
+	CopyObject(ctx context.Context, srcKey string, dstKey string) error
+}
+
+// FileDriver is implemented by drivers whose objects are files on the local
+// file system, so they can be served with full conditional request support.
+type FileDriver interface {
+	Driver
+	FilePath(key string) (string, error)
+}
+
+// NewDriver creates the driver for a configured storage. Local storage must
+// carry its directory; the store fills in the data directory for the
+// built-in local storage.
 func NewDriver(ctx context.Context, configuredStorage *storepb.Storage) (Driver, error) {
 	if configuredStorage == nil {
 		return nil, errors.New("storage is required")
@@ -43,6 +91,21 @@ func NewDriver(ctx context.Context, configuredStorage *storepb.Storage) (Driver,
 			return nil, errors.Errorf("S3 config is missing for storage %q", configuredStorage.Id)
 		}
 		return s3.NewDriver(ctx, configuredStorage.GetS3Config())
+	case storepb.StorageType_STORAGE_TYPE_LOCAL:
+		if configuredStorage.GetLocalConfig().GetPath() == "" {
+			return nil, errors.Errorf("local path is missing for storage %q", configuredStorage.Id)
+		}
+		return local.NewDriver(configuredStorage.GetLocalConfig().GetPath())
+	case storepb.StorageType_STORAGE_TYPE_WEBDAV:
+		if configuredStorage.GetWebdavConfig() == nil {
+			return nil, errors.Errorf("WebDAV config is missing for storage %q", configuredStorage.Id)
+		}
+		return webdav.NewDriver(configuredStorage.GetWebdavConfig())
+	case storepb.StorageType_STORAGE_TYPE_SFTP:
+		if configuredStorage.GetSftpConfig() == nil {
+			return nil, errors.Errorf("SFTP config is missing for storage %q", configuredStorage.Id)
+		}
+		return sftp.NewDriver(configuredStorage.GetSftpConfig())
 	default:
 		return nil, errors.Errorf("storage %q has unsupported driver type %s", configuredStorage.Id, configuredStorage.Type.String())
 	}
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/usememos/memos/internal/testutil/fakes3"
+	"github.com/usememos/memos/internal/testutil/fakesftp"
+	"github.com/usememos/memos/internal/testutil/fakewebdav"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
@@ -31,6 +33,40 @@ func TestNewDriver(t *testing.T) {
 		require.Equal(t, content, downloaded)
 	})
 
+	t.Run("creates local driver", func(t *testing.T) {
+		driver, err := NewDriver(ctx, &storepb.Storage{
+			Id:     "local-nas",
+			Type:   storepb.StorageType_STORAGE_TYPE_LOCAL,
+			Config: &storepb.Storage_LocalConfig{LocalConfig: &storepb.StorageLocalConfig{Path: t.TempDir()}},
+		})
+		require.NoError(t, err)
+		require.Implements(t, (*FileDriver)(nil), driver)
+	})
+
+	t.Run("creates WebDAV driver", func(t *testing.T) {
+		server := fakewebdav.New(t)
+		driver, err := NewDriver(ctx, &storepb.Storage{
+			Id:     "webdav-nas",
+			Type:   storepb.StorageType_STORAGE_TYPE_WEBDAV,
+			Config: &storepb.Storage_WebdavConfig{WebdavConfig: server.Config("memos")},
+		})
+		require.NoError(t, err)
+		_, err = driver.UploadObject(ctx, "factory/object.txt", "text/plain", bytes.NewReader([]byte("webdav")))
+		require.NoError(t, err)
+	})
+
+	t.Run("creates SFTP driver", func(t *testing.T) {
+		server := fakesftp.New(t)
+		driver, err := NewDriver(ctx, &storepb.Storage{
+			Id:     "sftp-nas",
+			Type:   storepb.StorageType_STORAGE_TYPE_SFTP,
+			Config: &storepb.Storage_SftpConfig{SftpConfig: server.Config("/memos")},
+		})
+		require.NoError(t, err)
+		_, err = driver.UploadObject(ctx, "factory/object.txt", "text/plain", bytes.NewReader([]byte("sftp")))
+		require.NoError(t, err)
+	})
+
 	tests := []struct {
 		name    string
 		storage *storepb.Storage
@@ -43,9 +79,24 @@ func TestNewDriver(t *testing.T) {
 			wantErr: `S3 config is missing for storage "s3-missing"`,
 		},
 		{
-			name:    "unsupported storage type",
+			name:    "missing local path",
 			storage: &storepb.Storage{Id: "local", Type: storepb.StorageType_STORAGE_TYPE_LOCAL},
-			wantErr: `storage "local" has unsupported driver type STORAGE_TYPE_LOCAL`,
+			wantErr: `local path is missing for storage "local"`,
+		},
+		{
+			name:    "missing WebDAV config",
+			storage: &storepb.Storage{Id: "nas", Type: storepb.StorageType_STORAGE_TYPE_WEBDAV},
+			wantErr: `WebDAV config is missing for storage "nas"`,
+		},
+		{
+			name:    "missing SFTP config",
+			storage: &storepb.Storage{Id: "nas", Type: storepb.StorageType_STORAGE_TYPE_SFTP},
+			wantErr: `SFTP config is missing for storage "nas"`,
+		},
+		{
+			name:    "unsupported storage type",
+			storage: &storepb.Storage{Id: "database", Type: storepb.StorageType_STORAGE_TYPE_DATABASE},
+			wantErr: `storage "database" has unsupported driver type STORAGE_TYPE_DATABASE`,
 		},
 	}
 	for _, test := range tests {
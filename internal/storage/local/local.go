@@ -0,0 +1,115 @@
+// Package local stores attachment objects as files in a local directory.
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/storage/object"
+)
+
+// Driver stores attachment objects under a root directory. Keys are
+// slash-separated paths relative to the root.
+type Driver struct {
+	Root string
+}
+
+// NewDriver creates a local storage driver rooted at dir.
+func NewDriver(dir string) (*Driver, error) {
+	if dir == "" {
+		return nil, errors.New("local storage path is required")
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve local storage path")
+	}
+	return &Driver{Root: root}, nil
+}
+
+// FilePath returns the file that holds an object. Absolute keys, written by
+// path templates that pointed outside the data directory, are used as is;
+// relative keys must stay within the root.
+func (d *Driver) FilePath(key string) (string, error) {
+	path := filepath.FromSlash(key)
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+	if !filepath.IsLocal(path) {
+		return "", errors.Errorf("object key %q escapes the storage root", key)
+	}
+	return filepath.Join(d.Root, path), nil
+}
+
+// UploadObject writes an object, creating its parent directories.
+func (d *Driver) UploadObject(_ context.Context, key string, _ string, content io.Reader) (string, error) {
+	path, err := d.FilePath(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return "", errors.Wrap(err, "failed to create directory")
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create file")
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return "", errors.Wrap(err, "failed to write file")
+	}
+	if err := file.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to write file")
+	}
+	return key, nil
+}
+
+// GetObject reads a whole object.
+func (d *Driver) GetObject(ctx context.Context, key string) ([]byte, error) {
+	stream, err := d.GetObjectStream(ctx, key, "")
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Body.Close()
+	data, err := io.ReadAll(stream.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+	return data, nil
+}
+
+// GetObjectStream opens an object, honoring a single "bytes=" range.
+func (d *Driver) GetObjectStream(_ context.Context, key string, byteRange string) (*object.Stream, error) {
+	path, err := d.FilePath(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrap(object.ErrNotFound, "file not found")
+		}
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "failed to stat file")
+	}
+	return object.SeekStream(file, info.Size(), byteRange)
+}
+
+// DeleteObject removes an object; a missing object is not an error.
+func (d *Driver) DeleteObject(_ context.Context, key string) error {
+	path, err := d.FilePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to delete file")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/storage/object"
+)
+
+func TestDriverObjectLifecycle(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	driver, err := NewDriver(root)
+	require.NoError(t, err)
+
+	key := "assets/2026/note.txt"
+	content := []byte("attachment stored on the local file system")
+	uploadedKey, err := driver.UploadObject(ctx, key, "text/plain", bytes.NewReader(content))
+	require.NoError(t, err)
+	require.Equal(t, key, uploadedKey)
+	onDisk, err := os.ReadFile(filepath.Join(root, "assets", "2026", "note.txt"))
+	require.NoError(t, err)
+	require.Equal(t, content, onDisk)
+
+	downloaded, err := driver.GetObject(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, content, downloaded)
+
+	partial, err := driver.GetObjectStream(ctx, key, "bytes=4-9")
+	require.NoError(t, err)
+	partialContent, err := io.ReadAll(partial.Body)
+	require.NoError(t, err)
+	require.NoError(t, partial.Body.Close())
+	require.Equal(t, content[4:10], partialContent)
+	require.Equal(t, "bytes 4-9/42", partial.ContentRange)
+
+	require.NoError(t, driver.DeleteObject(ctx, key))
+	require.NoError(t, driver.DeleteObject(ctx, key))
+	_, err = driver.GetObject(ctx, key)
+	require.ErrorIs(t, err, object.ErrNotFound)
+}
+
+func TestDriverFilePath(t *testing.T) {
+	root := t.TempDir()
+	driver, err := NewDriver(root)
+	require.NoError(t, err)
+
+	path, err := driver.FilePath("assets/a.txt")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "assets", "a.txt"), path)
+
+	// Absolute references from templates outside the data directory still resolve.
+	absolute := filepath.Join(t.TempDir(), "elsewhere.txt")
+	path, err = driver.FilePath(filepath.ToSlash(absolute))
+	require.NoError(t, err)
+	require.Equal(t, absolute, path)
+
+	_, err = driver.FilePath("../outside.txt")
+	require.Error(t, err)
+	_, err = NewDriver("")
+	require.Error(t, err)
+}
@@ -0,0 +1,110 @@
+// Package object holds the types shared by attachment storage drivers.
+package object
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrRangeNotSatisfiable reports a ranged read whose byte range falls outside
+// the object, so HTTP handlers can answer 416 instead of 500.
+var ErrRangeNotSatisfiable = errors.New("requested range not satisfiable")
+
+// ErrNotFound reports that the requested object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// RangeNotSatisfiableError carries response metadata for an unsatisfied range.
+type RangeNotSatisfiableError struct {
+	ContentRange string
+}
+
+func (*RangeNotSatisfiableError) Error() string {
+	return ErrRangeNotSatisfiable.Error()
+}
+
+func (*RangeNotSatisfiableError) Unwrap() error {
+	return ErrRangeNotSatisfiable
+}
+
+// Stream is object content with the metadata needed to answer HTTP range
+// requests.
+type Stream struct {
+	Body io.ReadCloser
+	// ContentLength is the number of bytes in Body, or -1 when unknown.
+	ContentLength int64
+	// ContentRange echoes the backend's Content-Range header for partial reads;
+	// empty when the whole object is returned.
+	ContentRange string
+}
+
+// SeekStream answers a read of an object of the given size from a seekable
+// source, honoring a single "bytes=" range the way an HTTP server would.
+// Malformed ranges are ignored and the whole object is returned. The source
+// is closed when the stream is closed or when an error is returned.
+func SeekStream(source io.ReadSeekCloser, size int64, byteRange string) (*Stream, error) {
+	start, length, ok, err := parseRange(byteRange, size)
+	if err != nil {
+		source.Close()
+		return nil, err
+	}
+	if !ok {
+		return &Stream{Body: source, ContentLength: size}, nil
+	}
+	if _, err := source.Seek(start, io.SeekStart); err != nil {
+		source.Close()
+		return nil, errors.Wrap(err, "failed to seek object")
+	}
+	return &Stream{
+		Body: struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(source, length), source},
+		ContentLength: length,
+		ContentRange:  fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, size),
+	}, nil
+}
+
+// parseRange resolves a single "bytes=" range against an object size. ok is
+// false when the range is absent or malformed and should be ignored.
+func parseRange(byteRange string, size int64) (start, length int64, ok bool, err error) {
+	spec, found := strings.CutPrefix(strings.TrimSpace(byteRange), "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false, nil
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false, nil
+	}
+	unsatisfiable := &RangeNotSatisfiableError{ContentRange: fmt.Sprintf("bytes */%d", size)}
+	if first == "" {
+		suffix, parseErr := strconv.ParseInt(last, 10, 64)
+		if parseErr != nil || suffix < 0 {
+			return 0, 0, false, nil
+		}
+		if suffix == 0 || size == 0 {
+			return 0, 0, false, unsatisfiable
+		}
+		suffix = min(suffix, size)
+		return size - suffix, suffix, true, nil
+	}
+	start, parseErr := strconv.ParseInt(first, 10, 64)
+	if parseErr != nil || start < 0 {
+		return 0, 0, false, nil
+	}
+	end := size - 1
+	if last != "" {
+		end, parseErr = strconv.ParseInt(last, 10, 64)
+		if parseErr != nil || end < start {
+			return 0, 0, false, nil
+		}
+		end = min(end, size-1)
+	}
+	if start >= size {
+		return 0, 0, false, unsatisfiable
+	}
+	return start, end - start + 1, true, nil
+}
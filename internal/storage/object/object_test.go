@@ -0,0 +1,55 @@
+package object
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeekStream(t *testing.T) {
+	content := []byte("0123456789")
+	path := filepath.Join(t.TempDir(), "object")
+	require.NoError(t, os.WriteFile(path, content, 0600))
+
+	tests := []struct {
+		byteRange    string
+		want         []byte
+		contentRange string
+	}{
+		{byteRange: "", want: content},
+		{byteRange: "bytes=2-4", want: content[2:5], contentRange: "bytes 2-4/10"},
+		{byteRange: "bytes=7-", want: content[7:], contentRange: "bytes 7-9/10"},
+		{byteRange: "bytes=-3", want: content[7:], contentRange: "bytes 7-9/10"},
+		{byteRange: "bytes=8-100", want: content[8:], contentRange: "bytes 8-9/10"},
+		// Malformed and multipart ranges are ignored.
+		{byteRange: "bytes=4-2", want: content},
+		{byteRange: "bytes=0-1,4-5", want: content},
+		{byteRange: "items=0-1", want: content},
+	}
+	for _, test := range tests {
+		t.Run(test.byteRange, func(t *testing.T) {
+			file, err := os.Open(path)
+			require.NoError(t, err)
+			stream, err := SeekStream(file, int64(len(content)), test.byteRange)
+			require.NoError(t, err)
+			got, err := io.ReadAll(stream.Body)
+			require.NoError(t, err)
+			require.NoError(t, stream.Body.Close())
+			require.True(t, bytes.Equal(test.want, got), "got %q", got)
+			require.Equal(t, int64(len(test.want)), stream.ContentLength)
+			require.Equal(t, test.contentRange, stream.ContentRange)
+		})
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	_, err = SeekStream(file, int64(len(content)), "bytes=10-")
+	require.ErrorIs(t, err, ErrRangeNotSatisfiable)
+	var rangeErr *RangeNotSatisfiableError
+	require.ErrorAs(t, err, &rangeErr)
+	require.Equal(t, "bytes */10", rangeErr.ContentRange)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4" //nolint:revive // goimports insists on aliasing versioned import paths
@@ -13,46 +15,44 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/internal/storage/object"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
 // ErrRangeNotSatisfiable reports a ranged read whose byte range falls outside
 // the object, so HTTP handlers can answer 416 instead of 500.
-var ErrRangeNotSatisfiable = errors.New("requested range not satisfiable")
+var ErrRangeNotSatisfiable = object.ErrRangeNotSatisfiable
 
-// RangeNotSatisfiableError carries response metadata for an unsatisfied range.
-type RangeNotSatisfiableError struct {
-	ContentRange string
-}
-
-func (*RangeNotSatisfiableError) Error() string {
-	return ErrRangeNotSatisfiable.Error()
-}
+// ErrObjectNotFound reports that the requested object does not exist.
+var ErrObjectNotFound = object.ErrNotFound
 
-func (*RangeNotSatisfiableError) Unwrap() error {
-	return ErrRangeNotSatisfiable
-}
+// RangeNotSatisfiableError carries response metadata for an unsatisfied range.
+type RangeNotSatisfiableError = object.RangeNotSatisfiableError
 
 // ObjectStream is object content with the metadata needed to answer HTTP
 // range requests.
-type ObjectStream struct {
-	Body io.ReadCloser
-	// ContentLength is the number of bytes in Body, or -1 when unknown.
-	ContentLength int64
-	// ContentRange echoes the backend's Content-Range header for partial reads;
-	// empty when the whole object is returned.
-	ContentRange string
+type ObjectStream = object.Stream
+
+// PresignGetOptions overrides response headers of a presigned GET so the
+// bucket serves the object the way the server would.
+type PresignGetOptions struct {
+	ContentType        string
+	ContentDisposition string
 }
 
 // Driver stores attachment objects in an S3-compatible object store.
 type Driver struct {
-	Client *s3.Client
-	Bucket *string
+	Client        *s3.Client
+	PresignClient *s3.PresignClient
+	Bucket        *string
+	// Presigned reports whether presigned transfers are enabled for the storage.
+	Presigned bool
 }
 
 // NewDriver creates an S3 storage driver from the supplied configuration.
@@ -82,9 +82,17 @@ func NewDriver(ctx context.Context, s3Config *storepb.StorageS3Config) (*Driver,
 		o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
 		o.APIOptions = append(o.APIOptions, excludeAcceptEncodingFromSigning, forceSignedPayload)
 	})
+	// Presigned requests are sent by clients, so they must not inherit the
+	// signing tweaks above: a signed payload hash cannot be known up front.
+	presignClient := s3.NewPresignClient(s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(s3Config.Endpoint)
+		o.UsePathStyle = s3Config.UsePathStyle
+	}))
 	return &Driver{
-		Client: client,
-		Bucket: aws.String(s3Config.Bucket),
+		Client:        client,
+		PresignClient: presignClient,
+		Bucket:        aws.String(s3Config.Bucket),
+		Presigned:     s3Config.PresignedTransfers,
 	}, nil
 }
 
@@ -225,3 +233,190 @@ func (c *Driver) DeleteObject(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+// CompletedPart identifies an uploaded part of a multipart upload.
+type CompletedPart struct {
+	PartNumber int32
+	ETag       string
+}
+
+// CreateMultipartUpload starts a multipart upload and returns its upload id.
+func (c *Driver) CreateMultipartUpload(ctx context.Context, key string, fileType string) (string, error) {
+	output, err := c.Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      c.Bucket,
+		Key:         aws.String(key),
+		ContentType: aws.String(fileType),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create multipart upload")
+	}
+	return aws.ToString(output.UploadId), nil
+}
+
+// UploadPart uploads one part of a multipart upload and returns its ETag.
+// The content must be seekable because payloads are signed.
+func (c *Driver) UploadPart(ctx context.Context, key string, uploadID string, partNumber int32, content io.ReadSeeker) (string, error) {
+	output, err := c.Client.UploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     c.Bucket,
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(partNumber),
+		Body:       content,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to upload part")
+	}
+	return aws.ToString(output.ETag), nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the final object.
+func (c *Driver) CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []CompletedPart) error {
+	completedParts := make([]types.CompletedPart, 0, len(parts))
+	for _, part := range parts {
+		completedParts = append(completedParts, types.CompletedPart{
+			PartNumber: aws.Int32(part.PartNumber),
+			ETag:       aws.String(part.ETag),
+		})
+	}
+	_, err := c.Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          c.Bucket,
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completedParts},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to complete multipart upload")
+	}
+	return nil
+}
+
+// AbortMultipartUpload discards a multipart upload and its uploaded parts.
+func (c *Driver) AbortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	_, err := c.Client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   c.Bucket,
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to abort multipart upload")
+	}
+	return nil
+}
+
+// PresignEnabled reports whether clients may transfer objects directly with
+// presigned URLs.
+func (c *Driver) PresignEnabled() bool {
+	return c.Presigned
+}
+
+// PresignPutObject returns a URL that accepts a single PUT of the object.
+func (c *Driver) PresignPutObject(ctx context.Context, key string, fileType string, expires time.Duration) (string, error) {
+	request, err := c.PresignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      c.Bucket,
+		Key:         aws.String(key),
+		ContentType: aws.String(fileType),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to presign put object")
+	}
+	return request.URL, nil
+}
+
+// PresignGetObject returns a URL that downloads the object until it expires.
+func (c *Driver) PresignGetObject(ctx context.Context, key string, expires time.Duration, opts PresignGetOptions) (string, error) {
+	input := &s3.GetObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(key),
+	}
+	if opts.ContentType != "" {
+		input.ResponseContentType = aws.String(opts.ContentType)
+	}
+	if opts.ContentDisposition != "" {
+		input.ResponseContentDisposition = aws.String(opts.ContentDisposition)
+	}
+	request, err := c.PresignClient.PresignGetObject(ctx, input, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to presign get object")
+	}
+	return request.URL, nil
+}
+
+// maxCopyObjectSize is the largest object S3 copies in a single request;
+// larger objects are copied part by part.
+const maxCopyObjectSize = 5 << 30
+
+// copyPartSize is the size of the parts a large object is copied in.
+const copyPartSize = 1 << 30
+
+// CopyObject copies an object within the bucket without downloading it.
+func (c *Driver) CopyObject(ctx context.Context, srcKey string, dstKey string) error {
+	head, err := c.Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(srcKey),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NotFound" || apiErr.ErrorCode() == "NoSuchKey") {
+			return ErrObjectNotFound
+		}
+		return errors.Wrap(err, "failed to stat object")
+	}
+	size := aws.ToInt64(head.ContentLength)
+	copySource := aws.String(aws.ToString(c.Bucket) + "/" + url.PathEscape(srcKey))
+	if size <= maxCopyObjectSize {
+		_, err := c.Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     c.Bucket,
+			Key:        aws.String(dstKey),
+			CopySource: copySource,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to copy object")
+		}
+		return nil
+	}
+
+	uploadID, err := c.CreateMultipartUpload(ctx, dstKey, aws.ToString(head.ContentType))
+	if err != nil {
+		return err
+	}
+	parts := make([]CompletedPart, 0, size/copyPartSize+1)
+	for offset, partNumber := int64(0), int32(1); offset < size; offset, partNumber = offset+copyPartSize, partNumber+1 {
+		end := min(offset+copyPartSize, size) - 1
+		output, err := c.Client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+			Bucket:          c.Bucket,
+			Key:             aws.String(dstKey),
+			UploadId:        aws.String(uploadID),
+			PartNumber:      aws.Int32(partNumber),
+			CopySource:      copySource,
+			CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", offset, end)),
+			// Every part must come from the same version of the source.
+			CopySourceIfMatch: head.ETag,
+		})
+		if err != nil {
+			_ = c.AbortMultipartUpload(ctx, dstKey, uploadID)
+			return errors.Wrap(err, "failed to copy object part")
+		}
+		parts = append(parts, CompletedPart{PartNumber: partNumber, ETag: aws.ToString(output.CopyPartResult.ETag)})
+	}
+	if err := c.CompleteMultipartUpload(ctx, dstKey, uploadID, parts); err != nil {
+		_ = c.AbortMultipartUpload(ctx, dstKey, uploadID)
+		return err
+	}
+	return nil
+}
+
+// StatObject returns the size of an object in bytes.
+func (c *Driver) StatObject(ctx context.Context, key string) (int64, error) {
+	output, err := c.Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NotFound" || apiErr.ErrorCode() == "NoSuchKey") {
+			return 0, ErrObjectNotFound
+		}
+		return 0, errors.Wrap(err, "failed to stat object")
+	}
+	return aws.ToInt64(output.ContentLength), nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -67,6 +68,95 @@ func TestDriverObjectLifecycle(t *testing.T) {
 	assertObjectLifecycle(ctx, t, driver, "assets/notes/test.txt", []byte("attachment stored in fake S3"))
 }
 
+func TestDriverMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	fake := fakes3.New(t, "attachments")
+	driver, err := NewDriver(ctx, fake.Config("attachments"))
+	require.NoError(t, err)
+
+	// Every part except the last must be at least 5 MiB.
+	first := bytes.Repeat([]byte("a"), 5<<20)
+	second := []byte("tail of the multipart object")
+	key := "assets/multipart.bin"
+
+	uploadID, err := driver.CreateMultipartUpload(ctx, key, "application/octet-stream")
+	require.NoError(t, err)
+	require.NotEmpty(t, uploadID)
+	firstETag, err := driver.UploadPart(ctx, key, uploadID, 1, bytes.NewReader(first))
+	require.NoError(t, err)
+	secondETag, err := driver.UploadPart(ctx, key, uploadID, 2, bytes.NewReader(second))
+	require.NoError(t, err)
+	require.NoError(t, driver.CompleteMultipartUpload(ctx, key, uploadID, []CompletedPart{
+		{PartNumber: 1, ETag: firstETag},
+		{PartNumber: 2, ETag: secondETag},
+	}))
+
+	downloaded, err := fake.GetObject("attachments", key)
+	require.NoError(t, err)
+	require.Equal(t, append(first, second...), downloaded)
+
+	abortedKey := "assets/aborted.bin"
+	uploadID, err = driver.CreateMultipartUpload(ctx, abortedKey, "application/octet-stream")
+	require.NoError(t, err)
+	_, err = driver.UploadPart(ctx, abortedKey, uploadID, 1, bytes.NewReader(second))
+	require.NoError(t, err)
+	require.NoError(t, driver.AbortMultipartUpload(ctx, abortedKey, uploadID))
+	_, err = fake.GetObject("attachments", abortedKey)
+	require.Error(t, err)
+}
+
+func TestDriverPresignedTransfers(t *testing.T) {
+	ctx := context.Background()
+	fake := fakes3.New(t, "attachments")
+	config := fake.Config("attachments")
+	config.PresignedTransfers = true
+	driver, err := NewDriver(ctx, config)
+	require.NoError(t, err)
+	require.True(t, driver.PresignEnabled())
+
+	key := "assets/direct.txt"
+	content := []byte("uploaded without the server in the middle")
+	putURL, err := driver.PresignPutObject(ctx, key, "text/plain", time.Minute)
+	require.NoError(t, err)
+	require.Contains(t, putURL, "X-Amz-Signature=")
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, bytes.NewReader(content))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "text/plain")
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	size, err := driver.StatObject(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), size)
+
+	getURL, err := driver.PresignGetObject(ctx, key, time.Minute, PresignGetOptions{
+		ContentType:        "text/plain",
+		ContentDisposition: `attachment; filename="direct.txt"`,
+	})
+	require.NoError(t, err)
+	require.Contains(t, getURL, "response-content-disposition=")
+	request, err = http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	require.NoError(t, err)
+	response, err = http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	downloaded, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	require.Equal(t, content, downloaded)
+
+	copyKey := "assets/copied.txt"
+	require.NoError(t, driver.CopyObject(ctx, key, copyKey))
+	copied, err := fake.GetObject("attachments", copyKey)
+	require.NoError(t, err)
+	require.Equal(t, content, copied)
+
+	_, err = driver.StatObject(ctx, "assets/missing.txt")
+	require.ErrorIs(t, err, ErrObjectNotFound)
+	require.ErrorIs(t, driver.CopyObject(ctx, "assets/missing.txt", copyKey), ErrObjectNotFound)
+}
+
 func TestDriverMinIOCompatibility(t *testing.T) {
 	ctx := context.Background()
 	server := testminio.New(t, "attachments")
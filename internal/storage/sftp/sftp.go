@@ -0,0 +1,243 @@
+// Package sftp stores attachment objects on an SFTP server.
+package sftp
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/usememos/memos/internal/storage/object"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+const (
+	defaultPort = 22
+	dialTimeout = 15 * time.Second
+)
+
+// Driver stores attachment objects below a directory of an SFTP server. The
+// SSH connection is opened on first use and re-established after it drops.
+type Driver struct {
+	Address string
+	Root    string
+
+	clientConfig *ssh.ClientConfig
+
+	mu        sync.Mutex
+	sshClient *ssh.Client
+	client    *sftp.Client
+}
+
+// NewDriver creates an SFTP storage driver from the supplied configuration.
+func NewDriver(config *storepb.StorageSFTPConfig) (*Driver, error) {
+	host := strings.TrimSpace(config.Host)
+	if host == "" {
+		return nil, errors.New("SFTP host is required")
+	}
+	if config.Username == "" {
+		return nil, errors.New("SFTP username is required")
+	}
+
+	var auth []ssh.AuthMethod
+	switch {
+	case config.PrivateKey != "":
+		signer, err := ssh.ParsePrivateKey([]byte(config.PrivateKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid SFTP private key")
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	case config.Password != "":
+		auth = append(auth, ssh.Password(config.Password))
+	default:
+		return nil, errors.New("SFTP password or private key is required")
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	switch {
+	case strings.TrimSpace(config.HostKey) != "":
+		hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(config.HostKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid SFTP host key")
+		}
+		hostKeyCallback = ssh.FixedHostKey(hostKey)
+	case config.InsecureIgnoreHostKey:
+		// This is opt-in and removes protection against man-in-the-middle attacks.
+		hostKeyCallback = ssh.InsecureIgnoreHostKey() // #nosec G106 -- opt-in when no host key is configured
+	default:
+		return nil, errors.New("SFTP host key is required")
+	}
+
+	port := int(config.Port)
+	if port == 0 {
+		port = defaultPort
+	}
+	// An empty or relative path is resolved against the login directory.
+	root := strings.TrimSpace(config.Path)
+	if root != "" {
+		root = path.Clean(root)
+	}
+	return &Driver{
+		Address: net.JoinHostPort(host, strconv.Itoa(port)),
+		Root:    root,
+		clientConfig: &ssh.ClientConfig{
+			User:            config.Username,
+			Auth:            auth,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         dialTimeout,
+		},
+	}, nil
+}
+
+// UploadObject uploads an object, creating its parent directories.
+func (d *Driver) UploadObject(_ context.Context, key string, _ string, content io.Reader) (string, error) {
+	objectPath, err := d.objectPath(key)
+	if err != nil {
+		return "", err
+	}
+	var file *sftp.File
+	if err := d.withClient(func(client *sftp.Client) error {
+		if err := client.MkdirAll(path.Dir(objectPath)); err != nil {
+			return errors.Wrap(err, "failed to create directory")
+		}
+		file, err = client.Create(objectPath)
+		return err
+	}); err != nil {
+		return "", errors.Wrap(err, "failed to create file")
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return "", errors.Wrap(err, "failed to write file")
+	}
+	if err := file.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to write file")
+	}
+	return key, nil
+}
+
+// GetObject downloads a whole object.
+func (d *Driver) GetObject(ctx context.Context, key string) ([]byte, error) {
+	stream, err := d.GetObjectStream(ctx, key, "")
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Body.Close()
+	data, err := io.ReadAll(stream.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+	return data, nil
+}
+
+// GetObjectStream opens an object, honoring a single "bytes=" range.
+func (d *Driver) GetObjectStream(_ context.Context, key string, byteRange string) (*object.Stream, error) {
+	objectPath, err := d.objectPath(key)
+	if err != nil {
+		return nil, err
+	}
+	var file *sftp.File
+	if err := d.withClient(func(client *sftp.Client) error {
+		file, err = client.Open(objectPath)
+		return err
+	}); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.Wrap(object.ErrNotFound, "file not found")
+		}
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "failed to stat file")
+	}
+	return object.SeekStream(file, info.Size(), byteRange)
+}
+
+// DeleteObject removes an object; a missing object is not an error.
+func (d *Driver) DeleteObject(_ context.Context, key string) error {
+	objectPath, err := d.objectPath(key)
+	if err != nil {
+		return err
+	}
+	if err := d.withClient(func(client *sftp.Client) error {
+		return client.Remove(objectPath)
+	}); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "failed to delete file")
+	}
+	return nil
+}
+
+// Close releases the SSH connection. The driver reconnects on next use.
+func (d *Driver) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.disconnectLocked()
+}
+
+func (d *Driver) objectPath(key string) (string, error) {
+	if key == "" || strings.Contains("/"+key+"/", "/../") {
+		return "", errors.Errorf("invalid object key %q", key)
+	}
+	return path.Join(d.Root, strings.TrimLeft(key, "/")), nil
+}
+
+// withClient runs fn with a connected client, reconnecting and retrying once
+// when the connection turns out to be lost.
+func (d *Driver) withClient(fn func(*sftp.Client) error) error {
+	client, err := d.connect()
+	if err != nil {
+		return err
+	}
+	err = fn(client)
+	if !errors.Is(err, sftp.ErrSSHFxConnectionLost) {
+		return err
+	}
+	d.mu.Lock()
+	if d.client == client {
+		_ = d.disconnectLocked()
+	}
+	d.mu.Unlock()
+	client, err = d.connect()
+	if err != nil {
+		return err
+	}
+	return fn(client)
+}
+
+func (d *Driver) connect() (*sftp.Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.client != nil {
+		return d.client, nil
+	}
+	sshClient, err := ssh.Dial("tcp", d.Address, d.clientConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to SFTP server")
+	}
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		return nil, errors.Wrap(err, "failed to start SFTP session")
+	}
+	d.sshClient, d.client = sshClient, client
+	return client, nil
+}
+
+func (d *Driver) disconnectLocked() error {
+	if d.client == nil {
+		return nil
+	}
+	d.client.Close()
+	err := d.sshClient.Close()
+	d.sshClient, d.client = nil, nil
+	return err
+}
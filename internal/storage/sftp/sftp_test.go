@@ -0,0 +1,72 @@
+package sftp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/storage/object"
+	"github.com/usememos/memos/internal/testutil/fakesftp"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestDriverObjectLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := fakesftp.New(t)
+	driver, err := NewDriver(server.Config("/memos"))
+	require.NoError(t, err)
+	defer driver.Close()
+
+	key := "assets/2026/note.txt"
+	content := []byte("attachment stored on an SFTP server")
+	uploadedKey, err := driver.UploadObject(ctx, key, "text/plain", bytes.NewReader(content))
+	require.NoError(t, err)
+	require.Equal(t, key, uploadedKey)
+	stored, err := server.GetObject("/memos/assets/2026/note.txt")
+	require.NoError(t, err)
+	require.Equal(t, content, stored)
+
+	downloaded, err := driver.GetObject(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, content, downloaded)
+
+	partial, err := driver.GetObjectStream(ctx, key, "bytes=4-9")
+	require.NoError(t, err)
+	partialContent, err := io.ReadAll(partial.Body)
+	require.NoError(t, err)
+	require.NoError(t, partial.Body.Close())
+	require.Equal(t, content[4:10], partialContent)
+	require.Equal(t, fmt.Sprintf("bytes 4-9/%d", len(content)), partial.ContentRange)
+
+	// The driver reconnects after its connection is closed.
+	require.NoError(t, driver.Close())
+	require.NoError(t, driver.DeleteObject(ctx, key))
+	require.NoError(t, driver.DeleteObject(ctx, key))
+	_, err = driver.GetObject(ctx, key)
+	require.ErrorIs(t, err, object.ErrNotFound)
+}
+
+func TestDriverVerifiesHostKey(t *testing.T) {
+	server := fakesftp.New(t)
+	other := fakesftp.New(t)
+	config := server.Config("/memos")
+	config.HostKey = other.HostKey
+	driver, err := NewDriver(config)
+	require.NoError(t, err)
+	_, err = driver.UploadObject(context.Background(), "note.txt", "text/plain", bytes.NewReader([]byte("x")))
+	require.ErrorContains(t, err, "host key")
+
+	config.HostKey = ""
+	_, err = NewDriver(config)
+	require.ErrorContains(t, err, "host key is required")
+	config.InsecureIgnoreHostKey = true
+	_, err = NewDriver(config)
+	require.NoError(t, err)
+
+	_, err = NewDriver(&storepb.StorageSFTPConfig{Host: "nas.local", Username: "memos", HostKey: server.HostKey})
+	require.ErrorContains(t, err, "password or private key")
+}
@@ -0,0 +1,197 @@
+// Package webdav stores attachment objects on a WebDAV server.
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/storage/object"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// Driver stores attachment objects below a WebDAV collection.
+type Driver struct {
+	Client   *http.Client
+	Endpoint *url.URL
+	Username string
+	Password string
+}
+
+// NewDriver creates a WebDAV storage driver from the supplied configuration.
+func NewDriver(config *storepb.StorageWebDAVConfig) (*Driver, error) {
+	endpoint, err := url.Parse(strings.TrimSpace(config.Endpoint))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid WebDAV endpoint")
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, errors.Errorf("WebDAV endpoint %q must use http or https", config.Endpoint)
+	}
+	endpoint.Path = strings.TrimRight(endpoint.Path, "/")
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.InsecureSkipTlsVerify {
+		// This is opt-in and removes protection against man-in-the-middle attacks.
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402 -- opt-in for self-signed WebDAV servers
+	}
+	return &Driver{
+		Client:   &http.Client{Transport: transport},
+		Endpoint: endpoint,
+		Username: config.Username,
+		Password: config.Password,
+	}, nil
+}
+
+// UploadObject uploads an object, creating its parent collections.
+func (d *Driver) UploadObject(ctx context.Context, key string, fileType string, content io.Reader) (string, error) {
+	if err := d.makeCollections(ctx, path.Dir(key)); err != nil {
+		return "", err
+	}
+	request, err := d.newRequest(ctx, http.MethodPut, key, content)
+	if err != nil {
+		return "", err
+	}
+	if fileType != "" {
+		request.Header.Set("Content-Type", fileType)
+	}
+	response, err := d.Client.Do(request)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to upload object")
+	}
+	defer drain(response)
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to upload object: %s", response.Status)
+	}
+	return key, nil
+}
+
+// GetObject downloads a whole object.
+func (d *Driver) GetObject(ctx context.Context, key string) ([]byte, error) {
+	stream, err := d.GetObjectStream(ctx, key, "")
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Body.Close()
+	data, err := io.ReadAll(stream.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read object body")
+	}
+	return data, nil
+}
+
+// GetObjectStream downloads an object as a stream, forwarding a non-empty
+// byteRange as an HTTP Range header.
+func (d *Driver) GetObjectStream(ctx context.Context, key string, byteRange string) (*object.Stream, error) {
+	request, err := d.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	if byteRange != "" {
+		request.Header.Set("Range", byteRange)
+	}
+	response, err := d.Client.Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		stream := &object.Stream{
+			Body:          response.Body,
+			ContentLength: response.ContentLength,
+		}
+		if response.StatusCode == http.StatusPartialContent {
+			stream.ContentRange = response.Header.Get("Content-Range")
+		}
+		return stream, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		drain(response)
+		return nil, &object.RangeNotSatisfiableError{ContentRange: response.Header.Get("Content-Range")}
+	case http.StatusNotFound:
+		drain(response)
+		return nil, errors.Wrap(object.ErrNotFound, "failed to get object")
+	default:
+		drain(response)
+		return nil, errors.Errorf("failed to get object: %s", response.Status)
+	}
+}
+
+// DeleteObject deletes an object; a missing object is not an error.
+func (d *Driver) DeleteObject(ctx context.Context, key string) error {
+	request, err := d.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	response, err := d.Client.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete object")
+	}
+	defer drain(response)
+	if response.StatusCode >= 300 && response.StatusCode != http.StatusNotFound {
+		return errors.Errorf("failed to delete object: %s", response.Status)
+	}
+	return nil
+}
+
+// makeCollections creates every missing collection on the way to dir. MKCOL
+// answers 405 for a collection that already exists.
+func (d *Driver) makeCollections(ctx context.Context, dir string) error {
+	if dir == "." || dir == "/" || dir == "" {
+		return nil
+	}
+	collection := ""
+	for _, segment := range strings.Split(strings.Trim(dir, "/"), "/") {
+		collection = path.Join(collection, segment)
+		request, err := d.newRequest(ctx, "MKCOL", collection+"/", nil)
+		if err != nil {
+			return err
+		}
+		response, err := d.Client.Do(request)
+		if err != nil {
+			return errors.Wrap(err, "failed to create collection")
+		}
+		drain(response)
+		if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusMethodNotAllowed {
+			return errors.Errorf("failed to create collection %q: %s", collection, response.Status)
+		}
+	}
+	return nil
+}
+
+func (d *Driver) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" || strings.Contains("/"+key+"/", "/../") {
+		return nil, errors.Errorf("invalid object key %q", key)
+	}
+	target := *d.Endpoint
+	target.Path = d.Endpoint.Path + "/" + strings.TrimLeft(key, "/")
+	if body == nil {
+		body = http.NoBody
+	} else if _, ok := body.(io.Seeker); !ok {
+		// Buffer streams of unknown length so redirects and auth retries can
+		// replay the body and servers receive a Content-Length.
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read object content")
+		}
+		body = bytes.NewReader(data)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	if d.Username != "" || d.Password != "" {
+		request.SetBasicAuth(d.Username, d.Password)
+	}
+	return request, nil
+}
+
+func drain(response *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+	response.Body.Close()
+}
@@ -0,0 +1,65 @@
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/storage/object"
+	"github.com/usememos/memos/internal/testutil/fakewebdav"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestDriverObjectLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := fakewebdav.New(t)
+	driver, err := NewDriver(server.Config("memos"))
+	require.NoError(t, err)
+
+	key := "assets/2026/note.txt"
+	content := []byte("attachment stored on a WebDAV server")
+	uploadedKey, err := driver.UploadObject(ctx, key, "text/plain", bytes.NewReader(content))
+	require.NoError(t, err)
+	require.Equal(t, key, uploadedKey)
+	stored, err := server.GetObject("/memos/assets/2026/note.txt")
+	require.NoError(t, err)
+	require.Equal(t, content, stored)
+
+	downloaded, err := driver.GetObject(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, content, downloaded)
+
+	partial, err := driver.GetObjectStream(ctx, key, "bytes=4-9")
+	require.NoError(t, err)
+	partialContent, err := io.ReadAll(partial.Body)
+	require.NoError(t, err)
+	require.NoError(t, partial.Body.Close())
+	require.Equal(t, content[4:10], partialContent)
+	require.Equal(t, fmt.Sprintf("bytes 4-9/%d", len(content)), partial.ContentRange)
+
+	_, err = driver.GetObjectStream(ctx, key, fmt.Sprintf("bytes=%d-", len(content)*2))
+	require.ErrorIs(t, err, object.ErrRangeNotSatisfiable)
+
+	require.NoError(t, driver.DeleteObject(ctx, key))
+	require.NoError(t, driver.DeleteObject(ctx, key))
+	_, err = driver.GetObject(ctx, key)
+	require.ErrorIs(t, err, object.ErrNotFound)
+}
+
+func TestDriverRejectsBadCredentials(t *testing.T) {
+	server := fakewebdav.New(t)
+	config := server.Config("memos")
+	config.Password = "wrong"
+	driver, err := NewDriver(config)
+	require.NoError(t, err)
+
+	_, err = driver.UploadObject(context.Background(), "note.txt", "text/plain", bytes.NewReader([]byte("x")))
+	require.ErrorContains(t, err, "401")
+
+	_, err = NewDriver(&storepb.StorageWebDAVConfig{Endpoint: "ftp://nas.local/memos"})
+	require.Error(t, err)
+}
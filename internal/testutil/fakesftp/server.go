@@ -0,0 +1,146 @@
+// Package fakesftp provides an in-process SFTP service for tests.
+package fakesftp
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+const (
+	username = "test-user"
+	password = "test-password"
+)
+
+// Server is an in-memory SFTP service that accepts password authentication.
+type Server struct {
+	Host string
+	Port int32
+	// HostKey is the server public key in authorized_keys format.
+	HostKey string
+
+	clientConfig *ssh.ClientConfig
+}
+
+// New starts an in-process SFTP service listening on the loopback interface.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate host key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create host key signer: %v", err)
+	}
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if conn.User() == username && string(pass) == password {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	}
+	serverConfig.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	// Every connection shares one in-memory file system.
+	handlers := sftp.InMemHandler()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveConn(conn, serverConfig, handlers)
+		}
+	}()
+
+	address := listener.Addr().(*net.TCPAddr)
+	return &Server{
+		Host:    address.IP.String(),
+		Port:    int32(address.Port),
+		HostKey: string(ssh.MarshalAuthorizedKey(signer.PublicKey())),
+		clientConfig: &ssh.ClientConfig{
+			User:            username,
+			Auth:            []ssh.AuthMethod{ssh.Password(password)},
+			HostKeyCallback: ssh.FixedHostKey(signer.PublicKey()),
+		},
+	}
+}
+
+func serveConn(conn net.Conn, config *ssh.ServerConfig, handlers sftp.Handlers) {
+	defer conn.Close()
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for request := range channelRequests {
+				ok := request.Type == "subsystem" && len(request.Payload) > 4 && string(request.Payload[4:]) == "sftp"
+				_ = request.Reply(ok, nil)
+			}
+		}()
+		go func() {
+			server := sftp.NewRequestServer(channel, handlers)
+			_ = server.Serve()
+			server.Close()
+		}()
+	}
+}
+
+// Config returns a storage configuration that writes below dir.
+func (s *Server) Config(dir string) *storepb.StorageSFTPConfig {
+	return &storepb.StorageSFTPConfig{
+		Host:     s.Host,
+		Port:     s.Port,
+		Username: username,
+		Password: password,
+		HostKey:  s.HostKey,
+		Path:     dir,
+	}
+}
+
+// GetObject reads a file over a separate SFTP connection.
+func (s *Server) GetObject(name string) ([]byte, error) {
+	sshClient, err := ssh.Dial("tcp", net.JoinHostPort(s.Host, strconv.Itoa(int(s.Port))), s.clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer sshClient.Close()
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	file, err := client.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
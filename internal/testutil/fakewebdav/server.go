@@ -0,0 +1,76 @@
+// Package fakewebdav provides an in-process WebDAV service for tests.
+package fakewebdav
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"golang.org/x/net/webdav"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+const (
+	username = "test-user"
+	password = "test-password"
+)
+
+// Server is an in-memory WebDAV service that requires basic authentication.
+type Server struct {
+	URL string
+
+	t          testing.TB
+	fileSystem webdav.FileSystem
+}
+
+// New starts an in-process WebDAV service.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	fileSystem := webdav.NewMemFS()
+	handler := &webdav.Handler{
+		FileSystem: fileSystem,
+		LockSystem: webdav.NewMemLS(),
+	}
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != username || pass != password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="fakewebdav"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(httpServer.Close)
+	return &Server{
+		URL:        httpServer.URL,
+		t:          t,
+		fileSystem: fileSystem,
+	}
+}
+
+// Config creates collection and returns a storage configuration that writes
+// below it.
+func (s *Server) Config(collection string) *storepb.StorageWebDAVConfig {
+	if err := s.fileSystem.Mkdir(context.Background(), collection, 0755); err != nil && !os.IsExist(err) {
+		s.t.Fatalf("failed to create WebDAV collection %q: %v", collection, err)
+	}
+	return &storepb.StorageWebDAVConfig{
+		Endpoint: s.URL + "/" + collection,
+		Username: username,
+		Password: password,
+	}
+}
+
+// GetObject reads a file directly from the in-memory file system.
+func (s *Server) GetObject(name string) ([]byte, error) {
+	file, err := s.fileSystem.OpenFile(context.Background(), name, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// Activity types posted to webhooks.
+const (
+	ActivityMemoCreated        = "memos.memo.created"
+	ActivityMemoUpdated        = "memos.memo.updated"
+	ActivityMemoDeleted        = "memos.memo.deleted"
+	ActivityMemoPinned         = "memos.memo.pinned"
+	ActivityMemoArchived       = "memos.memo.archived"
+	ActivityMemoCommentCreated = "memos.memo.comment.created"
+	ActivityReactionUpserted   = "memos.memo.reaction.upserted"
+	ActivityReactionDeleted    = "memos.memo.reaction.deleted"
+	ActivityShareCreated       = "memos.memo.share.created"
+	ActivityAttachmentCreated  = "memos.attachment.created"
+)
+
+// SupportedActivityTypes lists every activity type a webhook can subscribe to.
+var SupportedActivityTypes = []string{
+	ActivityMemoCreated,
+	ActivityMemoUpdated,
+	ActivityMemoDeleted,
+	ActivityMemoPinned,
+	ActivityMemoArchived,
+	ActivityMemoCommentCreated,
+	ActivityReactionUpserted,
+	ActivityReactionDeleted,
+	ActivityShareCreated,
+	ActivityAttachmentCreated,
+}
+
+// DefaultActivityTypes are delivered to webhooks without explicit
+// subscriptions, matching the events posted before subscriptions existed.
+var DefaultActivityTypes = []string{
+	ActivityMemoCreated,
+	ActivityMemoUpdated,
+	ActivityMemoDeleted,
+	ActivityMemoCommentCreated,
+}
+
+// NormalizeActivityTypes validates subscribed activity types and removes duplicates.
+func NormalizeActivityTypes(activityTypes []string) ([]string, error) {
+	normalized := make([]string, 0, len(activityTypes))
+	for _, activityType := range activityTypes {
+		if !slices.Contains(SupportedActivityTypes, activityType) {
+			return nil, errors.Errorf("unsupported activity type %q", activityType)
+		}
+		if !slices.Contains(normalized, activityType) {
+			normalized = append(normalized, activityType)
+		}
+	}
+	return normalized, nil
+}
+
+// Subscribes reports whether a webhook subscribed to activityTypes receives activityType.
+func Subscribes(activityTypes []string, activityType string) bool {
+	if len(activityTypes) == 0 {
+		return slices.Contains(DefaultActivityTypes, activityType)
+	}
+	return slices.Contains(activityTypes, activityType)
+}
@@ -9,7 +9,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"io"
-	"log/slog"
 	"net"
 	"net/http"
 	"net/netip"
@@ -36,24 +35,10 @@ var (
 			DialContext: safeDialContext,
 		},
 	}
-
-	asyncPostQueue = make(chan *WebhookRequestPayload, 128)
 )
 
-func init() {
-	for range 4 {
-		go func() {
-			for payload := range asyncPostQueue {
-				if err := Post(payload); err != nil {
-					slog.Warn("Failed to dispatch webhook asynchronously",
-						slog.String("url", payload.URL),
-						slog.String("activityType", payload.ActivityType),
-						slog.Any("err", err))
-				}
-			}
-		}()
-	}
-}
+// maxResponseExcerptLength caps the response body kept in DeliveryResult.
+const maxResponseExcerptLength = 1024
 
 // safeDialContext is a net.Dialer.DialContext replacement that resolves the target
 // hostname, rejects disallowed reserved/private addresses, and dials an already
@@ -108,6 +93,12 @@ type WebhookRequestPayload struct {
 	Creator string `json:"creator"`
 	// The memo that triggered this webhook (if applicable).
 	Memo *v1pb.Memo `json:"memo"`
+	// The reaction for reaction activities.
+	Reaction *v1pb.Reaction `json:"reaction,omitempty"`
+	// The attachment for attachment activities.
+	Attachment *v1pb.Attachment `json:"attachment,omitempty"`
+	// The share link for share activities.
+	MemoShare *v1pb.MemoShare `json:"memoShare,omitempty"`
 	// Optional signing secret for HMAC-SHA256 signature. Not serialized to JSON.
 	SigningSecret string `json:"-"`
 }
@@ -139,52 +130,78 @@ func GenerateSigningSecret() (string, error) {
 	return "whsec_" + base64.StdEncoding.EncodeToString(buf), nil
 }
 
+// DeliveryResult describes one delivery attempt. It is populated as far as the
+// attempt got, so a failed attempt still reports its status code and latency.
+type DeliveryResult struct {
+	StatusCode      int
+	Latency         time.Duration
+	ResponseExcerpt string
+}
+
+// NewMessageID returns a new Standard Webhooks message id. Retries of the same
+// event must reuse the id so receivers can deduplicate them.
+func NewMessageID() string {
+	return "msg_" + uuid.NewV4().String()
+}
+
 // Post posts the message to webhook endpoint.
 func Post(requestPayload *WebhookRequestPayload) error {
 	body, err := json.Marshal(requestPayload)
 	if err != nil {
 		return errors.Wrapf(err, "failed to marshal webhook request to %s", requestPayload.URL)
 	}
+	_, err = Deliver(context.Background(), requestPayload.URL, requestPayload.SigningSecret, NewMessageID(), body)
+	return err
+}
 
-	req, err := http.NewRequest("POST", requestPayload.URL, bytes.NewBuffer(body))
+// Deliver posts a serialized payload to url once, signing it with
+// signingSecret when set. It fails unless the receiver answers with a 2xx
+// status and a JSON body whose code is 0.
+func Deliver(ctx context.Context, url, signingSecret, messageID string, body []byte) (*DeliveryResult, error) {
+	result := &DeliveryResult{}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
 	if err != nil {
-		return errors.Wrapf(err, "failed to construct webhook request to %s", requestPayload.URL)
+		return result, errors.Wrapf(err, "failed to construct webhook request to %s", url)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	if requestPayload.SigningSecret != "" {
-		key, err := resolveSigningKey(requestPayload.SigningSecret)
+	if signingSecret != "" {
+		key, err := resolveSigningKey(signingSecret)
 		if err != nil {
-			return errors.Wrapf(err, "failed to derive signing key for webhook to %s", requestPayload.URL)
+			return result, errors.Wrapf(err, "failed to derive signing key for webhook to %s", url)
 		}
 
-		msgID := "msg_" + uuid.NewV4().String()
 		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
 
 		mac := hmac.New(sha256.New, key)
-		mac.Write([]byte(msgID + "." + timestamp + "."))
+		mac.Write([]byte(messageID + "." + timestamp + "."))
 		mac.Write(body)
 		signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
 
-		req.Header.Set("webhook-id", msgID)
+		req.Header.Set("webhook-id", messageID)
 		req.Header.Set("webhook-timestamp", timestamp)
 		req.Header.Set("webhook-signature", "v1,"+signature)
 	}
 
+	start := time.Now()
 	resp, err := safeClient.Do(req)
 	if err != nil {
-		return errors.Wrapf(err, "failed to post webhook to %s", requestPayload.URL)
+		result.Latency = time.Since(start)
+		return result, errors.Wrapf(err, "failed to post webhook to %s", url)
 	}
 	defer resp.Body.Close()
 
 	b, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	result.Latency = time.Since(start)
+	result.StatusCode = resp.StatusCode
+	result.ResponseExcerpt = responseExcerpt(b)
 	if err != nil {
-		return errors.Wrapf(err, "failed to read webhook response from %s", requestPayload.URL)
+		return result, errors.Wrapf(err, "failed to read webhook response from %s", url)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return errors.Errorf("failed to post webhook %s, status code: %d", requestPayload.URL, resp.StatusCode)
+		return result, errors.Errorf("failed to post webhook %s, status code: %d", url, resp.StatusCode)
 	}
 
 	response := &struct {
@@ -192,28 +209,20 @@ func Post(requestPayload *WebhookRequestPayload) error {
 		Message string `json:"message"`
 	}{}
 	if err := json.Unmarshal(b, response); err != nil {
-		return errors.Wrapf(err, "failed to unmarshal webhook response from %s", requestPayload.URL)
+		return result, errors.Wrapf(err, "failed to unmarshal webhook response from %s", url)
 	}
 
 	if response.Code != 0 {
-		return errors.Errorf("receive error code sent by webhook server, code %d, msg: %s", response.Code, response.Message)
+		return result, errors.Errorf("receive error code sent by webhook server, code %d, msg: %s", response.Code, response.Message)
 	}
 
-	return nil
+	return result, nil
 }
 
-// PostAsync posts the message to webhook endpoint asynchronously.
-// It enqueues the request for bounded asynchronous dispatch and does not wait for the response.
-func PostAsync(requestPayload *WebhookRequestPayload) {
-	if requestPayload == nil {
-		slog.Warn("Dropped webhook dispatch because payload is nil")
-		return
-	}
-	select {
-	case asyncPostQueue <- requestPayload:
-	default:
-		slog.Warn("Dropped webhook dispatch because the async queue is full",
-			slog.String("url", requestPayload.URL),
-			slog.String("activityType", requestPayload.ActivityType))
+// responseExcerpt returns the beginning of a response body as valid UTF-8.
+func responseExcerpt(body []byte) string {
+	if len(body) > maxResponseExcerptLength {
+		body = body[:maxResponseExcerptLength]
 	}
+	return strings.ToValidUTF8(string(body), "")
 }
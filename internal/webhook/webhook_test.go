@@ -154,12 +154,6 @@ func TestPostAllowsExplicitPrivateIPPolicyAtDialTime(t *testing.T) {
 	}
 }
 
-func TestPostAsyncNilPayloadDoesNotPanic(t *testing.T) {
-	require.NotPanics(t, func() {
-		PostAsync(nil)
-	})
-}
-
 func TestResolveSigningKey(t *testing.T) {
 	rawKey := []byte("0123456789abcdef")
 	whsec := "whsec_" + base64.StdEncoding.EncodeToString(rawKey)
@@ -300,3 +294,22 @@ func TestPostWithoutSecretSetsNoSignatureHeaders(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, hasSignatureHeaders, "no signature headers should be set when no secret is configured")
 }
+
+func TestSubscribes(t *testing.T) {
+	require.True(t, Subscribes(nil, ActivityMemoCreated))
+	require.True(t, Subscribes(nil, ActivityMemoCommentCreated))
+	require.False(t, Subscribes(nil, ActivityReactionUpserted), "new activity types require an explicit subscription")
+
+	subscribed := []string{ActivityReactionUpserted, ActivityMemoPinned}
+	require.True(t, Subscribes(subscribed, ActivityMemoPinned))
+	require.False(t, Subscribes(subscribed, ActivityMemoCreated))
+}
+
+func TestNormalizeActivityTypes(t *testing.T) {
+	normalized, err := NormalizeActivityTypes([]string{ActivityMemoPinned, ActivityShareCreated, ActivityMemoPinned})
+	require.NoError(t, err)
+	require.Equal(t, []string{ActivityMemoPinned, ActivityShareCreated}, normalized)
+
+	_, err = NormalizeActivityTypes([]string{"memos.memo.exploded"})
+	require.Error(t, err)
+}
@@ -52,6 +52,21 @@ const (
 	// AttachmentServiceBatchDeleteAttachmentsProcedure is the fully-qualified name of the
 	// AttachmentService's BatchDeleteAttachments RPC.
 	AttachmentServiceBatchDeleteAttachmentsProcedure = "/memos.api.v1.AttachmentService/BatchDeleteAttachments"
+	// AttachmentServiceMigrateAttachmentsProcedure is the fully-qualified name of the
+	// AttachmentService's MigrateAttachments RPC.
+	AttachmentServiceMigrateAttachmentsProcedure = "/memos.api.v1.AttachmentService/MigrateAttachments"
+	// AttachmentServiceCreateUploadSessionProcedure is the fully-qualified name of the
+	// AttachmentService's CreateUploadSession RPC.
+	AttachmentServiceCreateUploadSessionProcedure = "/memos.api.v1.AttachmentService/CreateUploadSession"
+	// AttachmentServiceGetUploadSessionProcedure is the fully-qualified name of the AttachmentService's
+	// GetUploadSession RPC.
+	AttachmentServiceGetUploadSessionProcedure = "/memos.api.v1.AttachmentService/GetUploadSession"
+	// AttachmentServiceCompleteUploadSessionProcedure is the fully-qualified name of the
+	// AttachmentService's CompleteUploadSession RPC.
+	AttachmentServiceCompleteUploadSessionProcedure = "/memos.api.v1.AttachmentService/CompleteUploadSession"
+	// AttachmentServiceDeleteUploadSessionProcedure is the fully-qualified name of the
+	// AttachmentService's DeleteUploadSession RPC.
+	AttachmentServiceDeleteUploadSessionProcedure = "/memos.api.v1.AttachmentService/DeleteUploadSession"
 )
 
 // AttachmentServiceClient is a client for the memos.api.v1.AttachmentService service.
@@ -68,6 +83,22 @@ type AttachmentServiceClient interface {
 	DeleteAttachment(context.Context, *connect.Request[v1.DeleteAttachmentRequest]) (*connect.Response[emptypb.Empty], error)
 	// BatchDeleteAttachments deletes multiple attachments in one request.
 	BatchDeleteAttachments(context.Context, *connect.Request[v1.BatchDeleteAttachmentsRequest]) (*connect.Response[emptypb.Empty], error)
+	// MigrateAttachments moves a batch of attachments from one configured
+	// storage to another. Call it again with `next_page_token` until the token
+	// is empty; a migration stopped midway resumes from its last token.
+	// Admin only.
+	MigrateAttachments(context.Context, *connect.Request[v1.MigrateAttachmentsRequest]) (*connect.Response[v1.MigrateAttachmentsResponse], error)
+	// CreateUploadSession starts a resumable upload. The content is sent in
+	// chunks with `PUT {upload_url}` and an `Upload-Offset` header, then
+	// finalized with CompleteUploadSession.
+	CreateUploadSession(context.Context, *connect.Request[v1.CreateUploadSessionRequest]) (*connect.Response[v1.UploadSession], error)
+	// GetUploadSession returns an upload session, including the number of bytes
+	// received so far.
+	GetUploadSession(context.Context, *connect.Request[v1.GetUploadSessionRequest]) (*connect.Response[v1.UploadSession], error)
+	// CompleteUploadSession finalizes a fully received upload into an attachment.
+	CompleteUploadSession(context.Context, *connect.Request[v1.CompleteUploadSessionRequest]) (*connect.Response[v1.Attachment], error)
+	// DeleteUploadSession cancels an upload and discards the received content.
+	DeleteUploadSession(context.Context, *connect.Request[v1.DeleteUploadSessionRequest]) (*connect.Response[emptypb.Empty], error)
 }
 
 // NewAttachmentServiceClient constructs a client for the memos.api.v1.AttachmentService service. By
@@ -117,6 +148,36 @@ func NewAttachmentServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(attachmentServiceMethods.ByName("BatchDeleteAttachments")),
 			connect.WithClientOptions(opts...),
 		),
+		migrateAttachments: connect.NewClient[v1.MigrateAttachmentsRequest, v1.MigrateAttachmentsResponse](
+			httpClient,
+			baseURL+AttachmentServiceMigrateAttachmentsProcedure,
+			connect.WithSchema(attachmentServiceMethods.ByName("MigrateAttachments")),
+			connect.WithClientOptions(opts...),
+		),
+		createUploadSession: connect.NewClient[v1.CreateUploadSessionRequest, v1.UploadSession](
+			httpClient,
+			baseURL+AttachmentServiceCreateUploadSessionProcedure,
+			connect.WithSchema(attachmentServiceMethods.ByName("CreateUploadSession")),
+			connect.WithClientOptions(opts...),
+		),
+		getUploadSession: connect.NewClient[v1.GetUploadSessionRequest, v1.UploadSession](
+			httpClient,
+			baseURL+AttachmentServiceGetUploadSessionProcedure,
+			connect.WithSchema(attachmentServiceMethods.ByName("GetUploadSession")),
+			connect.WithClientOptions(opts...),
+		),
+		completeUploadSession: connect.NewClient[v1.CompleteUploadSessionRequest, v1.Attachment](
+			httpClient,
+			baseURL+AttachmentServiceCompleteUploadSessionProcedure,
+			connect.WithSchema(attachmentServiceMethods.ByName("CompleteUploadSession")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteUploadSession: connect.NewClient[v1.DeleteUploadSessionRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AttachmentServiceDeleteUploadSessionProcedure,
+			connect.WithSchema(attachmentServiceMethods.ByName("DeleteUploadSession")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -128,6 +189,11 @@ type attachmentServiceClient struct {
 	updateAttachment       *connect.Client[v1.UpdateAttachmentRequest, v1.Attachment]
 	deleteAttachment       *connect.Client[v1.DeleteAttachmentRequest, emptypb.Empty]
 	batchDeleteAttachments *connect.Client[v1.BatchDeleteAttachmentsRequest, emptypb.Empty]
+	migrateAttachments     *connect.Client[v1.MigrateAttachmentsRequest, v1.MigrateAttachmentsResponse]
+	createUploadSession    *connect.Client[v1.CreateUploadSessionRequest, v1.UploadSession]
+	getUploadSession       *connect.Client[v1.GetUploadSessionRequest, v1.UploadSession]
+	completeUploadSession  *connect.Client[v1.CompleteUploadSessionRequest, v1.Attachment]
+	deleteUploadSession    *connect.Client[v1.DeleteUploadSessionRequest, emptypb.Empty]
 }
 
 // CreateAttachment calls memos.api.v1.AttachmentService.CreateAttachment.
@@ -160,6 +226,31 @@ func (c *attachmentServiceClient) BatchDeleteAttachments(ctx context.Context, re
 	return c.batchDeleteAttachments.CallUnary(ctx, req)
 }
 
+// MigrateAttachments calls memos.api.v1.AttachmentService.MigrateAttachments.
+func (c *attachmentServiceClient) MigrateAttachments(ctx context.Context, req *connect.Request[v1.MigrateAttachmentsRequest]) (*connect.Response[v1.MigrateAttachmentsResponse], error) {
+	return c.migrateAttachments.CallUnary(ctx, req)
+}
+
+// CreateUploadSession calls memos.api.v1.AttachmentService.CreateUploadSession.
+func (c *attachmentServiceClient) CreateUploadSession(ctx context.Context, req *connect.Request[v1.CreateUploadSessionRequest]) (*connect.Response[v1.UploadSession], error) {
+	return c.createUploadSession.CallUnary(ctx, req)
+}
+
+// GetUploadSession calls memos.api.v1.AttachmentService.GetUploadSession.
+func (c *attachmentServiceClient) GetUploadSession(ctx context.Context, req *connect.Request[v1.GetUploadSessionRequest]) (*connect.Response[v1.UploadSession], error) {
+	return c.getUploadSession.CallUnary(ctx, req)
+}
+
+// CompleteUploadSession calls memos.api.v1.AttachmentService.CompleteUploadSession.
+func (c *attachmentServiceClient) CompleteUploadSession(ctx context.Context, req *connect.Request[v1.CompleteUploadSessionRequest]) (*connect.Response[v1.Attachment], error) {
+	return c.completeUploadSession.CallUnary(ctx, req)
+}
+
+// DeleteUploadSession calls memos.api.v1.AttachmentService.DeleteUploadSession.
+func (c *attachmentServiceClient) DeleteUploadSession(ctx context.Context, req *connect.Request[v1.DeleteUploadSessionRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteUploadSession.CallUnary(ctx, req)
+}
+
 // AttachmentServiceHandler is an implementation of the memos.api.v1.AttachmentService service.
 type AttachmentServiceHandler interface {
 	// CreateAttachment creates a new attachment.
@@ -174,6 +265,22 @@ type AttachmentServiceHandler interface {
 	DeleteAttachment(context.Context, *connect.Request[v1.DeleteAttachmentRequest]) (*connect.Response[emptypb.Empty], error)
 	// BatchDeleteAttachments deletes multiple attachments in one request.
 	BatchDeleteAttachments(context.Context, *connect.Request[v1.BatchDeleteAttachmentsRequest]) (*connect.Response[emptypb.Empty], error)
+	// MigrateAttachments moves a batch of attachments from one configured
+	// storage to another. Call it again with `next_page_token` until the token
+	// is empty; a migration stopped midway resumes from its last token.
+	// Admin only.
+	MigrateAttachments(context.Context, *connect.Request[v1.MigrateAttachmentsRequest]) (*connect.Response[v1.MigrateAttachmentsResponse], error)
+	// CreateUploadSession starts a resumable upload. The content is sent in
+	// chunks with `PUT {upload_url}` and an `Upload-Offset` header, then
+	// finalized with CompleteUploadSession.
+	CreateUploadSession(context.Context, *connect.Request[v1.CreateUploadSessionRequest]) (*connect.Response[v1.UploadSession], error)
+	// GetUploadSession returns an upload session, including the number of bytes
+	// received so far.
+	GetUploadSession(context.Context, *connect.Request[v1.GetUploadSessionRequest]) (*connect.Response[v1.UploadSession], error)
+	// CompleteUploadSession finalizes a fully received upload into an attachment.
+	CompleteUploadSession(context.Context, *connect.Request[v1.CompleteUploadSessionRequest]) (*connect.Response[v1.Attachment], error)
+	// DeleteUploadSession cancels an upload and discards the received content.
+	DeleteUploadSession(context.Context, *connect.Request[v1.DeleteUploadSessionRequest]) (*connect.Response[emptypb.Empty], error)
 }
 
 // NewAttachmentServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -219,6 +326,36 @@ func NewAttachmentServiceHandler(svc AttachmentServiceHandler, opts ...connect.H
 		connect.WithSchema(attachmentServiceMethods.ByName("BatchDeleteAttachments")),
 		connect.WithHandlerOptions(opts...),
 	)
+	attachmentServiceMigrateAttachmentsHandler := connect.NewUnaryHandler(
+		AttachmentServiceMigrateAttachmentsProcedure,
+		svc.MigrateAttachments,
+		connect.WithSchema(attachmentServiceMethods.ByName("MigrateAttachments")),
+		connect.WithHandlerOptions(opts...),
+	)
+	attachmentServiceCreateUploadSessionHandler := connect.NewUnaryHandler(
+		AttachmentServiceCreateUploadSessionProcedure,
+		svc.CreateUploadSession,
+		connect.WithSchema(attachmentServiceMethods.ByName("CreateUploadSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	attachmentServiceGetUploadSessionHandler := connect.NewUnaryHandler(
+		AttachmentServiceGetUploadSessionProcedure,
+		svc.GetUploadSession,
+		connect.WithSchema(attachmentServiceMethods.ByName("GetUploadSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	attachmentServiceCompleteUploadSessionHandler := connect.NewUnaryHandler(
+		AttachmentServiceCompleteUploadSessionProcedure,
+		svc.CompleteUploadSession,
+		connect.WithSchema(attachmentServiceMethods.ByName("CompleteUploadSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	attachmentServiceDeleteUploadSessionHandler := connect.NewUnaryHandler(
+		AttachmentServiceDeleteUploadSessionProcedure,
+		svc.DeleteUploadSession,
+		connect.WithSchema(attachmentServiceMethods.ByName("DeleteUploadSession")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/memos.api.v1.AttachmentService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AttachmentServiceCreateAttachmentProcedure:
@@ -233,6 +370,16 @@ func NewAttachmentServiceHandler(svc AttachmentServiceHandler, opts ...connect.H
 			attachmentServiceDeleteAttachmentHandler.ServeHTTP(w, r)
 		case AttachmentServiceBatchDeleteAttachmentsProcedure:
 			attachmentServiceBatchDeleteAttachmentsHandler.ServeHTTP(w, r)
+		case AttachmentServiceMigrateAttachmentsProcedure:
+			attachmentServiceMigrateAttachmentsHandler.ServeHTTP(w, r)
+		case AttachmentServiceCreateUploadSessionProcedure:
+			attachmentServiceCreateUploadSessionHandler.ServeHTTP(w, r)
+		case AttachmentServiceGetUploadSessionProcedure:
+			attachmentServiceGetUploadSessionHandler.ServeHTTP(w, r)
+		case AttachmentServiceCompleteUploadSessionProcedure:
+			attachmentServiceCompleteUploadSessionHandler.ServeHTTP(w, r)
+		case AttachmentServiceDeleteUploadSessionProcedure:
+			attachmentServiceDeleteUploadSessionHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -265,3 +412,23 @@ func (UnimplementedAttachmentServiceHandler) DeleteAttachment(context.Context, *
 func (UnimplementedAttachmentServiceHandler) BatchDeleteAttachments(context.Context, *connect.Request[v1.BatchDeleteAttachmentsRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.AttachmentService.BatchDeleteAttachments is not implemented"))
 }
+
+func (UnimplementedAttachmentServiceHandler) MigrateAttachments(context.Context, *connect.Request[v1.MigrateAttachmentsRequest]) (*connect.Response[v1.MigrateAttachmentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.AttachmentService.MigrateAttachments is not implemented"))
+}
+
+func (UnimplementedAttachmentServiceHandler) CreateUploadSession(context.Context, *connect.Request[v1.CreateUploadSessionRequest]) (*connect.Response[v1.UploadSession], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.AttachmentService.CreateUploadSession is not implemented"))
+}
+
+func (UnimplementedAttachmentServiceHandler) GetUploadSession(context.Context, *connect.Request[v1.GetUploadSessionRequest]) (*connect.Response[v1.UploadSession], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.AttachmentService.GetUploadSession is not implemented"))
+}
+
+func (UnimplementedAttachmentServiceHandler) CompleteUploadSession(context.Context, *connect.Request[v1.CompleteUploadSessionRequest]) (*connect.Response[v1.Attachment], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.AttachmentService.CompleteUploadSession is not implemented"))
+}
+
+func (UnimplementedAttachmentServiceHandler) DeleteUploadSession(context.Context, *connect.Request[v1.DeleteUploadSessionRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.AttachmentService.DeleteUploadSession is not implemented"))
+}
@@ -49,9 +49,15 @@ const (
 	// InstanceServiceTestInstanceEmailSettingProcedure is the fully-qualified name of the
 	// InstanceService's TestInstanceEmailSetting RPC.
 	InstanceServiceTestInstanceEmailSettingProcedure = "/memos.api.v1.InstanceService/TestInstanceEmailSetting"
+	// InstanceServiceTestAIProviderProcedure is the fully-qualified name of the InstanceService's
+	// TestAIProvider RPC.
+	InstanceServiceTestAIProviderProcedure = "/memos.api.v1.InstanceService/TestAIProvider"
 	// InstanceServiceGetInstanceStatsProcedure is the fully-qualified name of the InstanceService's
 	// GetInstanceStats RPC.
 	InstanceServiceGetInstanceStatsProcedure = "/memos.api.v1.InstanceService/GetInstanceStats"
+	// InstanceServiceListStorageConsumersProcedure is the fully-qualified name of the InstanceService's
+	// ListStorageConsumers RPC.
+	InstanceServiceListStorageConsumersProcedure = "/memos.api.v1.InstanceService/ListStorageConsumers"
 )
 
 // InstanceServiceClient is a client for the memos.api.v1.InstanceService service.
@@ -66,8 +72,12 @@ type InstanceServiceClient interface {
 	UpdateInstanceSetting(context.Context, *connect.Request[v1.UpdateInstanceSettingRequest]) (*connect.Response[v1.InstanceSetting], error)
 	// Tests notification email delivery with the provided or stored SMTP settings.
 	TestInstanceEmailSetting(context.Context, *connect.Request[v1.TestInstanceEmailSettingRequest]) (*connect.Response[emptypb.Empty], error)
+	// Tests an AI provider connection by listing the models it serves. Admin only.
+	TestAIProvider(context.Context, *connect.Request[v1.TestAIProviderRequest]) (*connect.Response[v1.TestAIProviderResponse], error)
 	// GetInstanceStats returns resource usage statistics for the instance. Admin only.
 	GetInstanceStats(context.Context, *connect.Request[v1.GetInstanceStatsRequest]) (*connect.Response[v1.InstanceStats], error)
+	// ListStorageConsumers returns the users storing the most attachment data. Admin only.
+	ListStorageConsumers(context.Context, *connect.Request[v1.ListStorageConsumersRequest]) (*connect.Response[v1.ListStorageConsumersResponse], error)
 }
 
 // NewInstanceServiceClient constructs a client for the memos.api.v1.InstanceService service. By
@@ -111,12 +121,24 @@ func NewInstanceServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(instanceServiceMethods.ByName("TestInstanceEmailSetting")),
 			connect.WithClientOptions(opts...),
 		),
+		testAIProvider: connect.NewClient[v1.TestAIProviderRequest, v1.TestAIProviderResponse](
+			httpClient,
+			baseURL+InstanceServiceTestAIProviderProcedure,
+			connect.WithSchema(instanceServiceMethods.ByName("TestAIProvider")),
+			connect.WithClientOptions(opts...),
+		),
 		getInstanceStats: connect.NewClient[v1.GetInstanceStatsRequest, v1.InstanceStats](
 			httpClient,
 			baseURL+InstanceServiceGetInstanceStatsProcedure,
 			connect.WithSchema(instanceServiceMethods.ByName("GetInstanceStats")),
 			connect.WithClientOptions(opts...),
 		),
+		listStorageConsumers: connect.NewClient[v1.ListStorageConsumersRequest, v1.ListStorageConsumersResponse](
+			httpClient,
+			baseURL+InstanceServiceListStorageConsumersProcedure,
+			connect.WithSchema(instanceServiceMethods.ByName("ListStorageConsumers")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -127,7 +149,9 @@ type instanceServiceClient struct {
 	batchGetInstanceSettings *connect.Client[v1.BatchGetInstanceSettingsRequest, v1.BatchGetInstanceSettingsResponse]
 	updateInstanceSetting    *connect.Client[v1.UpdateInstanceSettingRequest, v1.InstanceSetting]
 	testInstanceEmailSetting *connect.Client[v1.TestInstanceEmailSettingRequest, emptypb.Empty]
+	testAIProvider           *connect.Client[v1.TestAIProviderRequest, v1.TestAIProviderResponse]
 	getInstanceStats         *connect.Client[v1.GetInstanceStatsRequest, v1.InstanceStats]
+	listStorageConsumers     *connect.Client[v1.ListStorageConsumersRequest, v1.ListStorageConsumersResponse]
 }
 
 // GetInstanceProfile calls memos.api.v1.InstanceService.GetInstanceProfile.
@@ -155,11 +179,21 @@ func (c *instanceServiceClient) TestInstanceEmailSetting(ctx context.Context, re
 	return c.testInstanceEmailSetting.CallUnary(ctx, req)
 }
 
+// TestAIProvider calls memos.api.v1.InstanceService.TestAIProvider.
+func (c *instanceServiceClient) TestAIProvider(ctx context.Context, req *connect.Request[v1.TestAIProviderRequest]) (*connect.Response[v1.TestAIProviderResponse], error) {
+	return c.testAIProvider.CallUnary(ctx, req)
+}
+
 // GetInstanceStats calls memos.api.v1.InstanceService.GetInstanceStats.
 func (c *instanceServiceClient) GetInstanceStats(ctx context.Context, req *connect.Request[v1.GetInstanceStatsRequest]) (*connect.Response[v1.InstanceStats], error) {
 	return c.getInstanceStats.CallUnary(ctx, req)
 }
 
+// ListStorageConsumers calls memos.api.v1.InstanceService.ListStorageConsumers.
+func (c *instanceServiceClient) ListStorageConsumers(ctx context.Context, req *connect.Request[v1.ListStorageConsumersRequest]) (*connect.Response[v1.ListStorageConsumersResponse], error) {
+	return c.listStorageConsumers.CallUnary(ctx, req)
+}
+
 // InstanceServiceHandler is an implementation of the memos.api.v1.InstanceService service.
 type InstanceServiceHandler interface {
 	// Gets the instance profile.
@@ -172,8 +206,12 @@ type InstanceServiceHandler interface {
 	UpdateInstanceSetting(context.Context, *connect.Request[v1.UpdateInstanceSettingRequest]) (*connect.Response[v1.InstanceSetting], error)
 	// Tests notification email delivery with the provided or stored SMTP settings.
 	TestInstanceEmailSetting(context.Context, *connect.Request[v1.TestInstanceEmailSettingRequest]) (*connect.Response[emptypb.Empty], error)
+	// Tests an AI provider connection by listing the models it serves. Admin only.
+	TestAIProvider(context.Context, *connect.Request[v1.TestAIProviderRequest]) (*connect.Response[v1.TestAIProviderResponse], error)
 	// GetInstanceStats returns resource usage statistics for the instance. Admin only.
 	GetInstanceStats(context.Context, *connect.Request[v1.GetInstanceStatsRequest]) (*connect.Response[v1.InstanceStats], error)
+	// ListStorageConsumers returns the users storing the most attachment data. Admin only.
+	ListStorageConsumers(context.Context, *connect.Request[v1.ListStorageConsumersRequest]) (*connect.Response[v1.ListStorageConsumersResponse], error)
 }
 
 // NewInstanceServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -213,12 +251,24 @@ func NewInstanceServiceHandler(svc InstanceServiceHandler, opts ...connect.Handl
 		connect.WithSchema(instanceServiceMethods.ByName("TestInstanceEmailSetting")),
 		connect.WithHandlerOptions(opts...),
 	)
+	instanceServiceTestAIProviderHandler := connect.NewUnaryHandler(
+		InstanceServiceTestAIProviderProcedure,
+		svc.TestAIProvider,
+		connect.WithSchema(instanceServiceMethods.ByName("TestAIProvider")),
+		connect.WithHandlerOptions(opts...),
+	)
 	instanceServiceGetInstanceStatsHandler := connect.NewUnaryHandler(
 		InstanceServiceGetInstanceStatsProcedure,
 		svc.GetInstanceStats,
 		connect.WithSchema(instanceServiceMethods.ByName("GetInstanceStats")),
 		connect.WithHandlerOptions(opts...),
 	)
+	instanceServiceListStorageConsumersHandler := connect.NewUnaryHandler(
+		InstanceServiceListStorageConsumersProcedure,
+		svc.ListStorageConsumers,
+		connect.WithSchema(instanceServiceMethods.ByName("ListStorageConsumers")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/memos.api.v1.InstanceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case InstanceServiceGetInstanceProfileProcedure:
@@ -231,8 +281,12 @@ func NewInstanceServiceHandler(svc InstanceServiceHandler, opts ...connect.Handl
 			instanceServiceUpdateInstanceSettingHandler.ServeHTTP(w, r)
 		case InstanceServiceTestInstanceEmailSettingProcedure:
 			instanceServiceTestInstanceEmailSettingHandler.ServeHTTP(w, r)
+		case InstanceServiceTestAIProviderProcedure:
+			instanceServiceTestAIProviderHandler.ServeHTTP(w, r)
 		case InstanceServiceGetInstanceStatsProcedure:
 			instanceServiceGetInstanceStatsHandler.ServeHTTP(w, r)
+		case InstanceServiceListStorageConsumersProcedure:
+			instanceServiceListStorageConsumersHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -262,6 +316,14 @@ func (UnimplementedInstanceServiceHandler) TestInstanceEmailSetting(context.Cont
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.InstanceService.TestInstanceEmailSetting is not implemented"))
 }
 
+func (UnimplementedInstanceServiceHandler) TestAIProvider(context.Context, *connect.Request[v1.TestAIProviderRequest]) (*connect.Response[v1.TestAIProviderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.InstanceService.TestAIProvider is not implemented"))
+}
+
 func (UnimplementedInstanceServiceHandler) GetInstanceStats(context.Context, *connect.Request[v1.GetInstanceStatsRequest]) (*connect.Response[v1.InstanceStats], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.InstanceService.GetInstanceStats is not implemented"))
 }
+
+func (UnimplementedInstanceServiceHandler) ListStorageConsumers(context.Context, *connect.Request[v1.ListStorageConsumersRequest]) (*connect.Response[v1.ListStorageConsumersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.InstanceService.ListStorageConsumers is not implemented"))
+}
@@ -56,6 +56,9 @@ const (
 	// MemoServiceListMemoRelationsProcedure is the fully-qualified name of the MemoService's
 	// ListMemoRelations RPC.
 	MemoServiceListMemoRelationsProcedure = "/memos.api.v1.MemoService/ListMemoRelations"
+	// MemoServiceListMemoBacklinksProcedure is the fully-qualified name of the MemoService's
+	// ListMemoBacklinks RPC.
+	MemoServiceListMemoBacklinksProcedure = "/memos.api.v1.MemoService/ListMemoBacklinks"
 	// MemoServiceCreateMemoCommentProcedure is the fully-qualified name of the MemoService's
 	// CreateMemoComment RPC.
 	MemoServiceCreateMemoCommentProcedure = "/memos.api.v1.MemoService/CreateMemoComment"
@@ -89,6 +92,26 @@ const (
 	// MemoServiceBatchGetLinkMetadataProcedure is the fully-qualified name of the MemoService's
 	// BatchGetLinkMetadata RPC.
 	MemoServiceBatchGetLinkMetadataProcedure = "/memos.api.v1.MemoService/BatchGetLinkMetadata"
+	// MemoServiceRenameTagProcedure is the fully-qualified name of the MemoService's RenameTag RPC.
+	MemoServiceRenameTagProcedure = "/memos.api.v1.MemoService/RenameTag"
+	// MemoServiceMergeTagsProcedure is the fully-qualified name of the MemoService's MergeTags RPC.
+	MemoServiceMergeTagsProcedure = "/memos.api.v1.MemoService/MergeTags"
+	// MemoServiceDeleteTagProcedure is the fully-qualified name of the MemoService's DeleteTag RPC.
+	MemoServiceDeleteTagProcedure = "/memos.api.v1.MemoService/DeleteTag"
+	// MemoServiceListTasksProcedure is the fully-qualified name of the MemoService's ListTasks RPC.
+	MemoServiceListTasksProcedure = "/memos.api.v1.MemoService/ListTasks"
+	// MemoServiceSetTaskStateProcedure is the fully-qualified name of the MemoService's SetTaskState
+	// RPC.
+	MemoServiceSetTaskStateProcedure = "/memos.api.v1.MemoService/SetTaskState"
+	// MemoServiceGetDailyMemoProcedure is the fully-qualified name of the MemoService's GetDailyMemo
+	// RPC.
+	MemoServiceGetDailyMemoProcedure = "/memos.api.v1.MemoService/GetDailyMemo"
+	// MemoServiceAppendDailyMemoProcedure is the fully-qualified name of the MemoService's
+	// AppendDailyMemo RPC.
+	MemoServiceAppendDailyMemoProcedure = "/memos.api.v1.MemoService/AppendDailyMemo"
+	// MemoServiceListDailyMemosProcedure is the fully-qualified name of the MemoService's
+	// ListDailyMemos RPC.
+	MemoServiceListDailyMemosProcedure = "/memos.api.v1.MemoService/ListDailyMemos"
 )
 
 // MemoServiceClient is a client for the memos.api.v1.MemoService service.
@@ -117,6 +140,10 @@ type MemoServiceClient interface {
 	SetMemoRelations(context.Context, *connect.Request[v1.SetMemoRelationsRequest]) (*connect.Response[emptypb.Empty], error)
 	// ListMemoRelations lists relations for a memo.
 	ListMemoRelations(context.Context, *connect.Request[v1.ListMemoRelationsRequest]) (*connect.Response[v1.ListMemoRelationsResponse], error)
+	// ListMemoBacklinks lists the memos that link to a memo, either with a
+	// [[memo]] link in their content or an explicit reference relation.
+	// Comments are not included.
+	ListMemoBacklinks(context.Context, *connect.Request[v1.ListMemoBacklinksRequest]) (*connect.Response[v1.ListMemoBacklinksResponse], error)
 	// CreateMemoComment creates a comment for a memo.
 	CreateMemoComment(context.Context, *connect.Request[v1.CreateMemoCommentRequest]) (*connect.Response[v1.Memo], error)
 	// ListMemoComments lists comments for a memo.
@@ -140,6 +167,35 @@ type MemoServiceClient interface {
 	GetLinkMetadata(context.Context, *connect.Request[v1.GetLinkMetadataRequest]) (*connect.Response[v1.LinkMetadata], error)
 	// BatchGetLinkMetadata gets metadata for links.
 	BatchGetLinkMetadata(context.Context, *connect.Request[v1.BatchGetLinkMetadataRequest]) (*connect.Response[v1.BatchGetLinkMetadataResponse], error)
+	// RenameTag renames a tag and its descendants in the caller's memos, e.g.
+	// proj/alpha to projects/alpha also turns proj/alpha/docs into
+	// projects/alpha/docs. Matching tag metadata moves with it.
+	RenameTag(context.Context, *connect.Request[v1.RenameTagRequest]) (*connect.Response[v1.RenameTagResponse], error)
+	// MergeTags renames several tags and their descendants to one target tag in
+	// the caller's memos.
+	MergeTags(context.Context, *connect.Request[v1.MergeTagsRequest]) (*connect.Response[v1.MergeTagsResponse], error)
+	// DeleteTag removes a tag and its descendants from the caller's memos and
+	// drops their tag metadata. The rest of the content is kept.
+	DeleteTag(context.Context, *connect.Request[v1.DeleteTagRequest]) (*connect.Response[v1.DeleteTagResponse], error)
+	// ListTasks lists the task list items of the memos visible to the caller,
+	// newest memo first. Completed tasks are left out unless show_completed is
+	// set.
+	ListTasks(context.Context, *connect.Request[v1.ListTasksRequest]) (*connect.Response[v1.ListTasksResponse], error)
+	// SetTaskState checks or unchecks a single task. Only the checkbox of the
+	// task changes in the memo content. Requires the memo creator or an admin.
+	SetTaskState(context.Context, *connect.Request[v1.SetTaskStateRequest]) (*connect.Response[v1.Task], error)
+	// GetDailyMemo returns the caller's daily memo for a day, creating it when
+	// the day has none yet. Every call for the same user and day resolves to the
+	// same memo.
+	GetDailyMemo(context.Context, *connect.Request[v1.GetDailyMemoRequest]) (*connect.Response[v1.Memo], error)
+	// AppendDailyMemo appends content to the caller's daily memo for a day,
+	// creating the memo when the day has none yet, so entries can be logged to
+	// the day from anywhere. It fails with ABORTED when the memo is edited
+	// concurrently.
+	AppendDailyMemo(context.Context, *connect.Request[v1.AppendDailyMemoRequest]) (*connect.Response[v1.Memo], error)
+	// ListDailyMemos lists the days of a calendar month that have a daily memo
+	// of the caller.
+	ListDailyMemos(context.Context, *connect.Request[v1.ListDailyMemosRequest]) (*connect.Response[v1.ListDailyMemosResponse], error)
 }
 
 // NewMemoServiceClient constructs a client for the memos.api.v1.MemoService service. By default, it
@@ -207,6 +263,12 @@ func NewMemoServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(memoServiceMethods.ByName("ListMemoRelations")),
 			connect.WithClientOptions(opts...),
 		),
+		listMemoBacklinks: connect.NewClient[v1.ListMemoBacklinksRequest, v1.ListMemoBacklinksResponse](
+			httpClient,
+			baseURL+MemoServiceListMemoBacklinksProcedure,
+			connect.WithSchema(memoServiceMethods.ByName("ListMemoBacklinks")),
+			connect.WithClientOptions(opts...),
+		),
 		createMemoComment: connect.NewClient[v1.CreateMemoCommentRequest, v1.Memo](
 			httpClient,
 			baseURL+MemoServiceCreateMemoCommentProcedure,
@@ -273,6 +335,54 @@ func NewMemoServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(memoServiceMethods.ByName("BatchGetLinkMetadata")),
 			connect.WithClientOptions(opts...),
 		),
+		renameTag: connect.NewClient[v1.RenameTagRequest, v1.RenameTagResponse](
+			httpClient,
+			baseURL+MemoServiceRenameTagProcedure,
+			connect.WithSchema(memoServiceMethods.ByName("RenameTag")),
+			connect.WithClientOptions(opts...),
+		),
+		mergeTags: connect.NewClient[v1.MergeTagsRequest, v1.MergeTagsResponse](
+			httpClient,
+			baseURL+MemoServiceMergeTagsProcedure,
+			connect.WithSchema(memoServiceMethods.ByName("MergeTags")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTag: connect.NewClient[v1.DeleteTagRequest, v1.DeleteTagResponse](
+			httpClient,
+			baseURL+MemoServiceDeleteTagProcedure,
+			connect.WithSchema(memoServiceMethods.ByName("DeleteTag")),
+			connect.WithClientOptions(opts...),
+		),
+		listTasks: connect.NewClient[v1.ListTasksRequest, v1.ListTasksResponse](
+			httpClient,
+			baseURL+MemoServiceListTasksProcedure,
+			connect.WithSchema(memoServiceMethods.ByName("ListTasks")),
+			connect.WithClientOptions(opts...),
+		),
+		setTaskState: connect.NewClient[v1.SetTaskStateRequest, v1.Task](
+			httpClient,
+			baseURL+MemoServiceSetTaskStateProcedure,
+			connect.WithSchema(memoServiceMethods.ByName("SetTaskState")),
+			connect.WithClientOptions(opts...),
+		),
+		getDailyMemo: connect.NewClient[v1.GetDailyMemoRequest, v1.Memo](
+			httpClient,
+			baseURL+MemoServiceGetDailyMemoProcedure,
+			connect.WithSchema(memoServiceMethods.ByName("GetDailyMemo")),
+			connect.WithClientOptions(opts...),
+		),
+		appendDailyMemo: connect.NewClient[v1.AppendDailyMemoRequest, v1.Memo](
+			httpClient,
+			baseURL+MemoServiceAppendDailyMemoProcedure,
+			connect.WithSchema(memoServiceMethods.ByName("AppendDailyMemo")),
+			connect.WithClientOptions(opts...),
+		),
+		listDailyMemos: connect.NewClient[v1.ListDailyMemosRequest, v1.ListDailyMemosResponse](
+			httpClient,
+			baseURL+MemoServiceListDailyMemosProcedure,
+			connect.WithSchema(memoServiceMethods.ByName("ListDailyMemos")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -287,6 +397,7 @@ type memoServiceClient struct {
 	listMemoAttachments  *connect.Client[v1.ListMemoAttachmentsRequest, v1.ListMemoAttachmentsResponse]
 	setMemoRelations     *connect.Client[v1.SetMemoRelationsRequest, emptypb.Empty]
 	listMemoRelations    *connect.Client[v1.ListMemoRelationsRequest, v1.ListMemoRelationsResponse]
+	listMemoBacklinks    *connect.Client[v1.ListMemoBacklinksRequest, v1.ListMemoBacklinksResponse]
 	createMemoComment    *connect.Client[v1.CreateMemoCommentRequest, v1.Memo]
 	listMemoComments     *connect.Client[v1.ListMemoCommentsRequest, v1.ListMemoCommentsResponse]
 	listMemoReactions    *connect.Client[v1.ListMemoReactionsRequest, v1.ListMemoReactionsResponse]
@@ -298,6 +409,14 @@ type memoServiceClient struct {
 	getSharedMemo        *connect.Client[v1.GetSharedMemoRequest, v1.Memo]
 	getLinkMetadata      *connect.Client[v1.GetLinkMetadataRequest, v1.LinkMetadata]
 	batchGetLinkMetadata *connect.Client[v1.BatchGetLinkMetadataRequest, v1.BatchGetLinkMetadataResponse]
+	renameTag            *connect.Client[v1.RenameTagRequest, v1.RenameTagResponse]
+	mergeTags            *connect.Client[v1.MergeTagsRequest, v1.MergeTagsResponse]
+	deleteTag            *connect.Client[v1.DeleteTagRequest, v1.DeleteTagResponse]
+	listTasks            *connect.Client[v1.ListTasksRequest, v1.ListTasksResponse]
+	setTaskState         *connect.Client[v1.SetTaskStateRequest, v1.Task]
+	getDailyMemo         *connect.Client[v1.GetDailyMemoRequest, v1.Memo]
+	appendDailyMemo      *connect.Client[v1.AppendDailyMemoRequest, v1.Memo]
+	listDailyMemos       *connect.Client[v1.ListDailyMemosRequest, v1.ListDailyMemosResponse]
 }
 
 // CreateMemo calls memos.api.v1.MemoService.CreateMemo.
@@ -345,6 +464,11 @@ func (c *memoServiceClient) ListMemoRelations(ctx context.Context, req *connect.
 	return c.listMemoRelations.CallUnary(ctx, req)
 }
 
+// ListMemoBacklinks calls memos.api.v1.MemoService.ListMemoBacklinks.
+func (c *memoServiceClient) ListMemoBacklinks(ctx context.Context, req *connect.Request[v1.ListMemoBacklinksRequest]) (*connect.Response[v1.ListMemoBacklinksResponse], error) {
+	return c.listMemoBacklinks.CallUnary(ctx, req)
+}
+
 // CreateMemoComment calls memos.api.v1.MemoService.CreateMemoComment.
 func (c *memoServiceClient) CreateMemoComment(ctx context.Context, req *connect.Request[v1.CreateMemoCommentRequest]) (*connect.Response[v1.Memo], error) {
 	return c.createMemoComment.CallUnary(ctx, req)
@@ -400,6 +524,46 @@ func (c *memoServiceClient) BatchGetLinkMetadata(ctx context.Context, req *conne
 	return c.batchGetLinkMetadata.CallUnary(ctx, req)
 }
 
+// RenameTag calls memos.api.v1.MemoService.RenameTag.
+func (c *memoServiceClient) RenameTag(ctx context.Context, req *connect.Request[v1.RenameTagRequest]) (*connect.Response[v1.RenameTagResponse], error) {
+	return c.renameTag.CallUnary(ctx, req)
+}
+
+// MergeTags calls memos.api.v1.MemoService.MergeTags.
+func (c *memoServiceClient) MergeTags(ctx context.Context, req *connect.Request[v1.MergeTagsRequest]) (*connect.Response[v1.MergeTagsResponse], error) {
+	return c.mergeTags.CallUnary(ctx, req)
+}
+
+// DeleteTag calls memos.api.v1.MemoService.DeleteTag.
+func (c *memoServiceClient) DeleteTag(ctx context.Context, req *connect.Request[v1.DeleteTagRequest]) (*connect.Response[v1.DeleteTagResponse], error) {
+	return c.deleteTag.CallUnary(ctx, req)
+}
+
+// ListTasks calls memos.api.v1.MemoService.ListTasks.
+func (c *memoServiceClient) ListTasks(ctx context.Context, req *connect.Request[v1.ListTasksRequest]) (*connect.Response[v1.ListTasksResponse], error) {
+	return c.listTasks.CallUnary(ctx, req)
+}
+
+// SetTaskState calls memos.api.v1.MemoService.SetTaskState.
+func (c *memoServiceClient) SetTaskState(ctx context.Context, req *connect.Request[v1.SetTaskStateRequest]) (*connect.Response[v1.Task], error) {
+	return c.setTaskState.CallUnary(ctx, req)
+}
+
+// GetDailyMemo calls memos.api.v1.MemoService.GetDailyMemo.
+func (c *memoServiceClient) GetDailyMemo(ctx context.Context, req *connect.Request[v1.GetDailyMemoRequest]) (*connect.Response[v1.Memo], error) {
+	return c.getDailyMemo.CallUnary(ctx, req)
+}
+
+// AppendDailyMemo calls memos.api.v1.MemoService.AppendDailyMemo.
+func (c *memoServiceClient) AppendDailyMemo(ctx context.Context, req *connect.Request[v1.AppendDailyMemoRequest]) (*connect.Response[v1.Memo], error) {
+	return c.appendDailyMemo.CallUnary(ctx, req)
+}
+
+// ListDailyMemos calls memos.api.v1.MemoService.ListDailyMemos.
+func (c *memoServiceClient) ListDailyMemos(ctx context.Context, req *connect.Request[v1.ListDailyMemosRequest]) (*connect.Response[v1.ListDailyMemosResponse], error) {
+	return c.listDailyMemos.CallUnary(ctx, req)
+}
+
 // MemoServiceHandler is an implementation of the memos.api.v1.MemoService service.
 type MemoServiceHandler interface {
 	// CreateMemo creates a memo. The request body is a Memo; set its content
@@ -426,6 +590,10 @@ type MemoServiceHandler interface {
 	SetMemoRelations(context.Context, *connect.Request[v1.SetMemoRelationsRequest]) (*connect.Response[emptypb.Empty], error)
 	// ListMemoRelations lists relations for a memo.
 	ListMemoRelations(context.Context, *connect.Request[v1.ListMemoRelationsRequest]) (*connect.Response[v1.ListMemoRelationsResponse], error)
+	// ListMemoBacklinks lists the memos that link to a memo, either with a
+	// [[memo]] link in their content or an explicit reference relation.
+	// Comments are not included.
+	ListMemoBacklinks(context.Context, *connect.Request[v1.ListMemoBacklinksRequest]) (*connect.Response[v1.ListMemoBacklinksResponse], error)
 	// CreateMemoComment creates a comment for a memo.
 	CreateMemoComment(context.Context, *connect.Request[v1.CreateMemoCommentRequest]) (*connect.Response[v1.Memo], error)
 	// ListMemoComments lists comments for a memo.
@@ -449,6 +617,35 @@ type MemoServiceHandler interface {
 	GetLinkMetadata(context.Context, *connect.Request[v1.GetLinkMetadataRequest]) (*connect.Response[v1.LinkMetadata], error)
 	// BatchGetLinkMetadata gets metadata for links.
 	BatchGetLinkMetadata(context.Context, *connect.Request[v1.BatchGetLinkMetadataRequest]) (*connect.Response[v1.BatchGetLinkMetadataResponse], error)
+	// RenameTag renames a tag and its descendants in the caller's memos, e.g.
+	// proj/alpha to projects/alpha also turns proj/alpha/docs into
+	// projects/alpha/docs. Matching tag metadata moves with it.
+	RenameTag(context.Context, *connect.Request[v1.RenameTagRequest]) (*connect.Response[v1.RenameTagResponse], error)
+	// MergeTags renames several tags and their descendants to one target tag in
+	// the caller's memos.
+	MergeTags(context.Context, *connect.Request[v1.MergeTagsRequest]) (*connect.Response[v1.MergeTagsResponse], error)
+	// DeleteTag removes a tag and its descendants from the caller's memos and
+	// drops their tag metadata. The rest of the content is kept.
+	DeleteTag(context.Context, *connect.Request[v1.DeleteTagRequest]) (*connect.Response[v1.DeleteTagResponse], error)
+	// ListTasks lists the task list items of the memos visible to the caller,
+	// newest memo first. Completed tasks are left out unless show_completed is
+	// set.
+	ListTasks(context.Context, *connect.Request[v1.ListTasksRequest]) (*connect.Response[v1.ListTasksResponse], error)
+	// SetTaskState checks or unchecks a single task. Only the checkbox of the
+	// task changes in the memo content. Requires the memo creator or an admin.
+	SetTaskState(context.Context, *connect.Request[v1.SetTaskStateRequest]) (*connect.Response[v1.Task], error)
+	// GetDailyMemo returns the caller's daily memo for a day, creating it when
+	// the day has none yet. Every call for the same user and day resolves to the
+	// same memo.
+	GetDailyMemo(context.Context, *connect.Request[v1.GetDailyMemoRequest]) (*connect.Response[v1.Memo], error)
+	// AppendDailyMemo appends content to the caller's daily memo for a day,
+	// creating the memo when the day has none yet, so entries can be logged to
+	// the day from anywhere. It fails with ABORTED when the memo is edited
+	// concurrently.
+	AppendDailyMemo(context.Context, *connect.Request[v1.AppendDailyMemoRequest]) (*connect.Response[v1.Memo], error)
+	// ListDailyMemos lists the days of a calendar month that have a daily memo
+	// of the caller.
+	ListDailyMemos(context.Context, *connect.Request[v1.ListDailyMemosRequest]) (*connect.Response[v1.ListDailyMemosResponse], error)
 }
 
 // NewMemoServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -512,6 +709,12 @@ func NewMemoServiceHandler(svc MemoServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(memoServiceMethods.ByName("ListMemoRelations")),
 		connect.WithHandlerOptions(opts...),
 	)
+	memoServiceListMemoBacklinksHandler := connect.NewUnaryHandler(
+		MemoServiceListMemoBacklinksProcedure,
+		svc.ListMemoBacklinks,
+		connect.WithSchema(memoServiceMethods.ByName("ListMemoBacklinks")),
+		connect.WithHandlerOptions(opts...),
+	)
 	memoServiceCreateMemoCommentHandler := connect.NewUnaryHandler(
 		MemoServiceCreateMemoCommentProcedure,
 		svc.CreateMemoComment,
@@ -578,6 +781,54 @@ func NewMemoServiceHandler(svc MemoServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(memoServiceMethods.ByName("BatchGetLinkMetadata")),
 		connect.WithHandlerOptions(opts...),
 	)
+	memoServiceRenameTagHandler := connect.NewUnaryHandler(
+		MemoServiceRenameTagProcedure,
+		svc.RenameTag,
+		connect.WithSchema(memoServiceMethods.ByName("RenameTag")),
+		connect.WithHandlerOptions(opts...),
+	)
+	memoServiceMergeTagsHandler := connect.NewUnaryHandler(
+		MemoServiceMergeTagsProcedure,
+		svc.MergeTags,
+		connect.WithSchema(memoServiceMethods.ByName("MergeTags")),
+		connect.WithHandlerOptions(opts...),
+	)
+	memoServiceDeleteTagHandler := connect.NewUnaryHandler(
+		MemoServiceDeleteTagProcedure,
+		svc.DeleteTag,
+		connect.WithSchema(memoServiceMethods.ByName("DeleteTag")),
+		connect.WithHandlerOptions(opts...),
+	)
+	memoServiceListTasksHandler := connect.NewUnaryHandler(
+		MemoServiceListTasksProcedure,
+		svc.ListTasks,
+		connect.WithSchema(memoServiceMethods.ByName("ListTasks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	memoServiceSetTaskStateHandler := connect.NewUnaryHandler(
+		MemoServiceSetTaskStateProcedure,
+		svc.SetTaskState,
+		connect.WithSchema(memoServiceMethods.ByName("SetTaskState")),
+		connect.WithHandlerOptions(opts...),
+	)
+	memoServiceGetDailyMemoHandler := connect.NewUnaryHandler(
+		MemoServiceGetDailyMemoProcedure,
+		svc.GetDailyMemo,
+		connect.WithSchema(memoServiceMethods.ByName("GetDailyMemo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	memoServiceAppendDailyMemoHandler := connect.NewUnaryHandler(
+		MemoServiceAppendDailyMemoProcedure,
+		svc.AppendDailyMemo,
+		connect.WithSchema(memoServiceMethods.ByName("AppendDailyMemo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	memoServiceListDailyMemosHandler := connect.NewUnaryHandler(
+		MemoServiceListDailyMemosProcedure,
+		svc.ListDailyMemos,
+		connect.WithSchema(memoServiceMethods.ByName("ListDailyMemos")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/memos.api.v1.MemoService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case MemoServiceCreateMemoProcedure:
@@ -598,6 +849,8 @@ func NewMemoServiceHandler(svc MemoServiceHandler, opts ...connect.HandlerOption
 			memoServiceSetMemoRelationsHandler.ServeHTTP(w, r)
 		case MemoServiceListMemoRelationsProcedure:
 			memoServiceListMemoRelationsHandler.ServeHTTP(w, r)
+		case MemoServiceListMemoBacklinksProcedure:
+			memoServiceListMemoBacklinksHandler.ServeHTTP(w, r)
 		case MemoServiceCreateMemoCommentProcedure:
 			memoServiceCreateMemoCommentHandler.ServeHTTP(w, r)
 		case MemoServiceListMemoCommentsProcedure:
@@ -620,6 +873,22 @@ func NewMemoServiceHandler(svc MemoServiceHandler, opts ...connect.HandlerOption
 			memoServiceGetLinkMetadataHandler.ServeHTTP(w, r)
 		case MemoServiceBatchGetLinkMetadataProcedure:
 			memoServiceBatchGetLinkMetadataHandler.ServeHTTP(w, r)
+		case MemoServiceRenameTagProcedure:
+			memoServiceRenameTagHandler.ServeHTTP(w, r)
+		case MemoServiceMergeTagsProcedure:
+			memoServiceMergeTagsHandler.ServeHTTP(w, r)
+		case MemoServiceDeleteTagProcedure:
+			memoServiceDeleteTagHandler.ServeHTTP(w, r)
+		case MemoServiceListTasksProcedure:
+			memoServiceListTasksHandler.ServeHTTP(w, r)
+		case MemoServiceSetTaskStateProcedure:
+			memoServiceSetTaskStateHandler.ServeHTTP(w, r)
+		case MemoServiceGetDailyMemoProcedure:
+			memoServiceGetDailyMemoHandler.ServeHTTP(w, r)
+		case MemoServiceAppendDailyMemoProcedure:
+			memoServiceAppendDailyMemoHandler.ServeHTTP(w, r)
+		case MemoServiceListDailyMemosProcedure:
+			memoServiceListDailyMemosHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -665,6 +934,10 @@ func (UnimplementedMemoServiceHandler) ListMemoRelations(context.Context, *conne
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.ListMemoRelations is not implemented"))
 }
 
+func (UnimplementedMemoServiceHandler) ListMemoBacklinks(context.Context, *connect.Request[v1.ListMemoBacklinksRequest]) (*connect.Response[v1.ListMemoBacklinksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.ListMemoBacklinks is not implemented"))
+}
+
 func (UnimplementedMemoServiceHandler) CreateMemoComment(context.Context, *connect.Request[v1.CreateMemoCommentRequest]) (*connect.Response[v1.Memo], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.CreateMemoComment is not implemented"))
 }
@@ -708,3 +981,35 @@ func (UnimplementedMemoServiceHandler) GetLinkMetadata(context.Context, *connect
 func (UnimplementedMemoServiceHandler) BatchGetLinkMetadata(context.Context, *connect.Request[v1.BatchGetLinkMetadataRequest]) (*connect.Response[v1.BatchGetLinkMetadataResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.BatchGetLinkMetadata is not implemented"))
 }
+
+func (UnimplementedMemoServiceHandler) RenameTag(context.Context, *connect.Request[v1.RenameTagRequest]) (*connect.Response[v1.RenameTagResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.RenameTag is not implemented"))
+}
+
+func (UnimplementedMemoServiceHandler) MergeTags(context.Context, *connect.Request[v1.MergeTagsRequest]) (*connect.Response[v1.MergeTagsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.MergeTags is not implemented"))
+}
+
+func (UnimplementedMemoServiceHandler) DeleteTag(context.Context, *connect.Request[v1.DeleteTagRequest]) (*connect.Response[v1.DeleteTagResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.DeleteTag is not implemented"))
+}
+
+func (UnimplementedMemoServiceHandler) ListTasks(context.Context, *connect.Request[v1.ListTasksRequest]) (*connect.Response[v1.ListTasksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.ListTasks is not implemented"))
+}
+
+func (UnimplementedMemoServiceHandler) SetTaskState(context.Context, *connect.Request[v1.SetTaskStateRequest]) (*connect.Response[v1.Task], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.SetTaskState is not implemented"))
+}
+
+func (UnimplementedMemoServiceHandler) GetDailyMemo(context.Context, *connect.Request[v1.GetDailyMemoRequest]) (*connect.Response[v1.Memo], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.GetDailyMemo is not implemented"))
+}
+
+func (UnimplementedMemoServiceHandler) AppendDailyMemo(context.Context, *connect.Request[v1.AppendDailyMemoRequest]) (*connect.Response[v1.Memo], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.AppendDailyMemo is not implemented"))
+}
+
+func (UnimplementedMemoServiceHandler) ListDailyMemos(context.Context, *connect.Request[v1.ListDailyMemosRequest]) (*connect.Response[v1.ListDailyMemosResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.MemoService.ListDailyMemos is not implemented"))
+}
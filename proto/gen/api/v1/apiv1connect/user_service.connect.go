@@ -98,6 +98,12 @@ const (
 	// UserServiceGetUserWebhookSigningSecretProcedure is the fully-qualified name of the UserService's
 	// GetUserWebhookSigningSecret RPC.
 	UserServiceGetUserWebhookSigningSecretProcedure = "/memos.api.v1.UserService/GetUserWebhookSigningSecret"
+	// UserServiceListUserWebhookDeliveriesProcedure is the fully-qualified name of the UserService's
+	// ListUserWebhookDeliveries RPC.
+	UserServiceListUserWebhookDeliveriesProcedure = "/memos.api.v1.UserService/ListUserWebhookDeliveries"
+	// UserServiceRedeliverWebhookProcedure is the fully-qualified name of the UserService's
+	// RedeliverWebhook RPC.
+	UserServiceRedeliverWebhookProcedure = "/memos.api.v1.UserService/RedeliverWebhook"
 	// UserServiceListUserNotificationsProcedure is the fully-qualified name of the UserService's
 	// ListUserNotifications RPC.
 	UserServiceListUserNotificationsProcedure = "/memos.api.v1.UserService/ListUserNotifications"
@@ -162,6 +168,10 @@ type UserServiceClient interface {
 	// The secret is returned only through this explicit, owner-gated call; it is
 	// never included in List/Create/Update responses.
 	GetUserWebhookSigningSecret(context.Context, *connect.Request[v1.GetUserWebhookSigningSecretRequest]) (*connect.Response[v1.GetUserWebhookSigningSecretResponse], error)
+	// ListUserWebhookDeliveries lists the delivery history of a webhook, newest first.
+	ListUserWebhookDeliveries(context.Context, *connect.Request[v1.ListUserWebhookDeliveriesRequest]) (*connect.Response[v1.ListUserWebhookDeliveriesResponse], error)
+	// RedeliverWebhook queues a new delivery of a previously recorded event.
+	RedeliverWebhook(context.Context, *connect.Request[v1.RedeliverWebhookRequest]) (*connect.Response[v1.WebhookDelivery], error)
 	// ListUserNotifications lists notifications for a user.
 	ListUserNotifications(context.Context, *connect.Request[v1.ListUserNotificationsRequest]) (*connect.Response[v1.ListUserNotificationsResponse], error)
 	// UpdateUserNotification updates a notification.
@@ -319,6 +329,18 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(userServiceMethods.ByName("GetUserWebhookSigningSecret")),
 			connect.WithClientOptions(opts...),
 		),
+		listUserWebhookDeliveries: connect.NewClient[v1.ListUserWebhookDeliveriesRequest, v1.ListUserWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+UserServiceListUserWebhookDeliveriesProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListUserWebhookDeliveries")),
+			connect.WithClientOptions(opts...),
+		),
+		redeliverWebhook: connect.NewClient[v1.RedeliverWebhookRequest, v1.WebhookDelivery](
+			httpClient,
+			baseURL+UserServiceRedeliverWebhookProcedure,
+			connect.WithSchema(userServiceMethods.ByName("RedeliverWebhook")),
+			connect.WithClientOptions(opts...),
+		),
 		listUserNotifications: connect.NewClient[v1.ListUserNotificationsRequest, v1.ListUserNotificationsResponse](
 			httpClient,
 			baseURL+UserServiceListUserNotificationsProcedure,
@@ -365,6 +387,8 @@ type userServiceClient struct {
 	updateUserWebhook           *connect.Client[v1.UpdateUserWebhookRequest, v1.UserWebhook]
 	deleteUserWebhook           *connect.Client[v1.DeleteUserWebhookRequest, emptypb.Empty]
 	getUserWebhookSigningSecret *connect.Client[v1.GetUserWebhookSigningSecretRequest, v1.GetUserWebhookSigningSecretResponse]
+	listUserWebhookDeliveries   *connect.Client[v1.ListUserWebhookDeliveriesRequest, v1.ListUserWebhookDeliveriesResponse]
+	redeliverWebhook            *connect.Client[v1.RedeliverWebhookRequest, v1.WebhookDelivery]
 	listUserNotifications       *connect.Client[v1.ListUserNotificationsRequest, v1.ListUserNotificationsResponse]
 	updateUserNotification      *connect.Client[v1.UpdateUserNotificationRequest, v1.UserNotification]
 	deleteUserNotification      *connect.Client[v1.DeleteUserNotificationRequest, emptypb.Empty]
@@ -485,6 +509,16 @@ func (c *userServiceClient) GetUserWebhookSigningSecret(ctx context.Context, req
 	return c.getUserWebhookSigningSecret.CallUnary(ctx, req)
 }
 
+// ListUserWebhookDeliveries calls memos.api.v1.UserService.ListUserWebhookDeliveries.
+func (c *userServiceClient) ListUserWebhookDeliveries(ctx context.Context, req *connect.Request[v1.ListUserWebhookDeliveriesRequest]) (*connect.Response[v1.ListUserWebhookDeliveriesResponse], error) {
+	return c.listUserWebhookDeliveries.CallUnary(ctx, req)
+}
+
+// RedeliverWebhook calls memos.api.v1.UserService.RedeliverWebhook.
+func (c *userServiceClient) RedeliverWebhook(ctx context.Context, req *connect.Request[v1.RedeliverWebhookRequest]) (*connect.Response[v1.WebhookDelivery], error) {
+	return c.redeliverWebhook.CallUnary(ctx, req)
+}
+
 // ListUserNotifications calls memos.api.v1.UserService.ListUserNotifications.
 func (c *userServiceClient) ListUserNotifications(ctx context.Context, req *connect.Request[v1.ListUserNotificationsRequest]) (*connect.Response[v1.ListUserNotificationsResponse], error) {
 	return c.listUserNotifications.CallUnary(ctx, req)
@@ -553,6 +587,10 @@ type UserServiceHandler interface {
 	// The secret is returned only through this explicit, owner-gated call; it is
 	// never included in List/Create/Update responses.
 	GetUserWebhookSigningSecret(context.Context, *connect.Request[v1.GetUserWebhookSigningSecretRequest]) (*connect.Response[v1.GetUserWebhookSigningSecretResponse], error)
+	// ListUserWebhookDeliveries lists the delivery history of a webhook, newest first.
+	ListUserWebhookDeliveries(context.Context, *connect.Request[v1.ListUserWebhookDeliveriesRequest]) (*connect.Response[v1.ListUserWebhookDeliveriesResponse], error)
+	// RedeliverWebhook queues a new delivery of a previously recorded event.
+	RedeliverWebhook(context.Context, *connect.Request[v1.RedeliverWebhookRequest]) (*connect.Response[v1.WebhookDelivery], error)
 	// ListUserNotifications lists notifications for a user.
 	ListUserNotifications(context.Context, *connect.Request[v1.ListUserNotificationsRequest]) (*connect.Response[v1.ListUserNotificationsResponse], error)
 	// UpdateUserNotification updates a notification.
@@ -706,6 +744,18 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(userServiceMethods.ByName("GetUserWebhookSigningSecret")),
 		connect.WithHandlerOptions(opts...),
 	)
+	userServiceListUserWebhookDeliveriesHandler := connect.NewUnaryHandler(
+		UserServiceListUserWebhookDeliveriesProcedure,
+		svc.ListUserWebhookDeliveries,
+		connect.WithSchema(userServiceMethods.ByName("ListUserWebhookDeliveries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceRedeliverWebhookHandler := connect.NewUnaryHandler(
+		UserServiceRedeliverWebhookProcedure,
+		svc.RedeliverWebhook,
+		connect.WithSchema(userServiceMethods.ByName("RedeliverWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
 	userServiceListUserNotificationsHandler := connect.NewUnaryHandler(
 		UserServiceListUserNotificationsProcedure,
 		svc.ListUserNotifications,
@@ -772,6 +822,10 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 			userServiceDeleteUserWebhookHandler.ServeHTTP(w, r)
 		case UserServiceGetUserWebhookSigningSecretProcedure:
 			userServiceGetUserWebhookSigningSecretHandler.ServeHTTP(w, r)
+		case UserServiceListUserWebhookDeliveriesProcedure:
+			userServiceListUserWebhookDeliveriesHandler.ServeHTTP(w, r)
+		case UserServiceRedeliverWebhookProcedure:
+			userServiceRedeliverWebhookHandler.ServeHTTP(w, r)
 		case UserServiceListUserNotificationsProcedure:
 			userServiceListUserNotificationsHandler.ServeHTTP(w, r)
 		case UserServiceUpdateUserNotificationProcedure:
@@ -879,6 +933,14 @@ func (UnimplementedUserServiceHandler) GetUserWebhookSigningSecret(context.Conte
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.UserService.GetUserWebhookSigningSecret is not implemented"))
 }
 
+func (UnimplementedUserServiceHandler) ListUserWebhookDeliveries(context.Context, *connect.Request[v1.ListUserWebhookDeliveriesRequest]) (*connect.Response[v1.ListUserWebhookDeliveriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.UserService.ListUserWebhookDeliveries is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) RedeliverWebhook(context.Context, *connect.Request[v1.RedeliverWebhookRequest]) (*connect.Response[v1.WebhookDelivery], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.UserService.RedeliverWebhook is not implemented"))
+}
+
 func (UnimplementedUserServiceHandler) ListUserNotifications(context.Context, *connect.Request[v1.ListUserNotificationsRequest]) (*connect.Response[v1.ListUserNotificationsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("memos.api.v1.UserService.ListUserNotifications is not implemented"))
 }
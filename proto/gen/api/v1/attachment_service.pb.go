@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.11
+// 	protoc-gen-go v1.36.12
 // 	protoc        (unknown)
 // source: api/v1/attachment_service.proto
 
@@ -126,6 +126,61 @@ func (MotionMediaRole) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{1}
 }
 
+type ContentScan_Status int32
+
+const (
+	ContentScan_STATUS_UNSPECIFIED ContentScan_Status = 0
+	// No malware was detected.
+	ContentScan_CLEAN ContentScan_Status = 1
+	// Malware was detected; the attachment is quarantined.
+	ContentScan_INFECTED ContentScan_Status = 2
+	// The scanner failed and the upload was accepted unscanned.
+	ContentScan_FAILED ContentScan_Status = 3
+)
+
+// Enum value maps for ContentScan_Status.
+var (
+	ContentScan_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "CLEAN",
+		2: "INFECTED",
+		3: "FAILED",
+	}
+	ContentScan_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"CLEAN":              1,
+		"INFECTED":           2,
+		"FAILED":             3,
+	}
+)
+
+func (x ContentScan_Status) Enum() *ContentScan_Status {
+	p := new(ContentScan_Status)
+	*p = x
+	return p
+}
+
+func (x ContentScan_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContentScan_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_attachment_service_proto_enumTypes[2].Descriptor()
+}
+
+func (ContentScan_Status) Type() protoreflect.EnumType {
+	return &file_api_v1_attachment_service_proto_enumTypes[2]
+}
+
+func (x ContentScan_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContentScan_Status.Descriptor instead.
+func (ContentScan_Status) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{10, 0}
+}
+
 type MotionMedia struct {
 	state                   protoimpl.MessageState `protogen:"open.v1"`
 	Family                  MotionMediaFamily      `protobuf:"varint,1,opt,name=family,proto3,enum=memos.api.v1.MotionMediaFamily" json:"family,omitempty"`
@@ -610,6 +665,27 @@ type Attachment struct {
 	MotionMedia *MotionMedia `protobuf:"bytes,9,opt,name=motion_media,json=motionMedia,proto3" json:"motion_media,omitempty"`
 	// Optional. Immutable normalized media metadata explicitly supplied by the client at creation time.
 	MediaMetadata *MediaMetadata `protobuf:"bytes,10,opt,name=media_metadata,json=mediaMetadata,proto3" json:"media_metadata,omitempty"`
+	// Output only. The transcript generated for audio attachments when automatic
+	// transcription is enabled.
+	Transcript *AudioTranscript `protobuf:"bytes,11,opt,name=transcript,proto3" json:"transcript,omitempty"`
+	// Output only. The recognized text and caption generated for image
+	// attachments when image analysis is enabled.
+	ImageAnalysis *ImageAnalysis `protobuf:"bytes,12,opt,name=image_analysis,json=imageAnalysis,proto3" json:"image_analysis,omitempty"`
+	// Optional. The hex-encoded SHA-256 digest of the stored content; empty when
+	// the content was uploaded straight to object storage. Creating an
+	// attachment with a digest and no content reuses the content of one of the
+	// caller's attachments with that digest, so clients can skip uploading
+	// content the server already has.
+	Sha256 string `protobuf:"bytes,13,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// Output only. The poster frame or waveform generated in the background for
+	// video and audio attachments.
+	MediaPreview *MediaPreview `protobuf:"bytes,14,opt,name=media_preview,json=mediaPreview,proto3" json:"media_preview,omitempty"`
+	// Output only. The malware scan verdict of the content, when upload scanning
+	// is enabled. Infected attachments are quarantined and never served.
+	ContentScan *ContentScan `protobuf:"bytes,15,opt,name=content_scan,json=contentScan,proto3" json:"content_scan,omitempty"`
+	// Output only. The text extracted in the background from PDF, DOCX, XLSX,
+	// plain text and CSV attachments.
+	Document      *DocumentExtraction `protobuf:"bytes,16,opt,name=document,proto3" json:"document,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -714,32 +790,77 @@ func (x *Attachment) GetMediaMetadata() *MediaMetadata {
 	return nil
 }
 
-type CreateAttachmentRequest struct {
+func (x *Attachment) GetTranscript() *AudioTranscript {
+	if x != nil {
+		return x.Transcript
+	}
+	return nil
+}
+
+func (x *Attachment) GetImageAnalysis() *ImageAnalysis {
+	if x != nil {
+		return x.ImageAnalysis
+	}
+	return nil
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetMediaPreview() *MediaPreview {
+	if x != nil {
+		return x.MediaPreview
+	}
+	return nil
+}
+
+func (x *Attachment) GetContentScan() *ContentScan {
+	if x != nil {
+		return x.ContentScan
+	}
+	return nil
+}
+
+func (x *Attachment) GetDocument() *DocumentExtraction {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+// AudioTranscript is the speech-to-text result of an audio attachment.
+type AudioTranscript struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The attachment to create.
-	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
-	// Optional. The attachment ID to use for this attachment.
-	// If empty, a unique ID will be generated.
-	// Format: ^[a-zA-Z0-9]([a-zA-Z0-9-]{0,34}[a-zA-Z0-9])?$
-	AttachmentId  string `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	// The full transcript text.
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// The language reported by the provider, if any.
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	// Timestamped portions of the transcript, when the provider returns them.
+	Segments []*AudioTranscript_Segment `protobuf:"bytes,3,rep,name=segments,proto3" json:"segments,omitempty"`
+	// The time the transcript was generated.
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAttachmentRequest) Reset() {
-	*x = CreateAttachmentRequest{}
+func (x *AudioTranscript) Reset() {
+	*x = AudioTranscript{}
 	mi := &file_api_v1_attachment_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAttachmentRequest) String() string {
+func (x *AudioTranscript) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAttachmentRequest) ProtoMessage() {}
+func (*AudioTranscript) ProtoMessage() {}
 
-func (x *CreateAttachmentRequest) ProtoReflect() protoreflect.Message {
+func (x *AudioTranscript) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_attachment_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -751,61 +872,68 @@ func (x *CreateAttachmentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAttachmentRequest.ProtoReflect.Descriptor instead.
-func (*CreateAttachmentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AudioTranscript.ProtoReflect.Descriptor instead.
+func (*AudioTranscript) Descriptor() ([]byte, []int) {
 	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CreateAttachmentRequest) GetAttachment() *Attachment {
+func (x *AudioTranscript) GetText() string {
 	if x != nil {
-		return x.Attachment
+		return x.Text
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateAttachmentRequest) GetAttachmentId() string {
+func (x *AudioTranscript) GetLanguage() string {
 	if x != nil {
-		return x.AttachmentId
+		return x.Language
 	}
 	return ""
 }
 
-type ListAttachmentsRequest struct {
+func (x *AudioTranscript) GetSegments() []*AudioTranscript_Segment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *AudioTranscript) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// MediaPreview is the preview generated for a video or audio attachment.
+type MediaPreview struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Optional. The maximum number of attachments to return.
-	// The service may return fewer than this value.
-	// If unspecified, at most 50 attachments will be returned.
-	// The maximum value is 1000; values above 1000 will be coerced to 1000.
-	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	// Optional. A page token, received from a previous `ListAttachments` call.
-	// Provide this to retrieve the subsequent page.
-	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	// Optional. Filter to apply to the list results.
-	// Example: "mime_type==\"image/png\"" or "filename.contains(\"test\")"
-	// Supported operators: =, !=, <, <=, >, >=, : (contains), in
-	// Supported fields: filename, mime_type, create_time, memo
-	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
-	// Optional. The order to sort results by.
-	// Example: "create_time desc" or "filename asc"
-	OrderBy       string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// The URL of a JPEG poster frame of the video, served by the file server.
+	// Empty when no poster is available.
+	PosterUrl string `protobuf:"bytes,1,opt,name=poster_url,json=posterUrl,proto3" json:"poster_url,omitempty"`
+	// The audio peak levels, from 0 to 1, of equally long consecutive slices
+	// of the recording.
+	WaveformPeaks []float32 `protobuf:"fixed32,2,rep,packed,name=waveform_peaks,json=waveformPeaks,proto3" json:"waveform_peaks,omitempty"`
+	// The time the preview was generated.
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAttachmentsRequest) Reset() {
-	*x = ListAttachmentsRequest{}
+func (x *MediaPreview) Reset() {
+	*x = MediaPreview{}
 	mi := &file_api_v1_attachment_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAttachmentsRequest) String() string {
+func (x *MediaPreview) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAttachmentsRequest) ProtoMessage() {}
+func (*MediaPreview) ProtoMessage() {}
 
-func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+func (x *MediaPreview) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_attachment_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -817,64 +945,61 @@ func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
-func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MediaPreview.ProtoReflect.Descriptor instead.
+func (*MediaPreview) Descriptor() ([]byte, []int) {
 	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListAttachmentsRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-func (x *ListAttachmentsRequest) GetPageToken() string {
+func (x *MediaPreview) GetPosterUrl() string {
 	if x != nil {
-		return x.PageToken
+		return x.PosterUrl
 	}
 	return ""
 }
 
-func (x *ListAttachmentsRequest) GetFilter() string {
+func (x *MediaPreview) GetWaveformPeaks() []float32 {
 	if x != nil {
-		return x.Filter
+		return x.WaveformPeaks
 	}
-	return ""
+	return nil
 }
 
-func (x *ListAttachmentsRequest) GetOrderBy() string {
+func (x *MediaPreview) GetCreateTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.OrderBy
+		return x.CreateTime
 	}
-	return ""
+	return nil
 }
 
-type ListAttachmentsResponse struct {
+// DocumentExtraction is the text of a document attachment.
+type DocumentExtraction struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The list of attachments.
-	Attachments []*Attachment `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
-	// A token that can be sent as `page_token` to retrieve the next page.
-	// If this field is omitted, there are no subsequent pages.
-	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// The plain text of the document.
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// The number of pages of PDF and DOCX documents; zero when unknown.
+	PageCount int32 `protobuf:"varint,2,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	// Whether the text was cut short because the document is very long.
+	Truncated bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// The time the text was extracted.
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAttachmentsResponse) Reset() {
-	*x = ListAttachmentsResponse{}
+func (x *DocumentExtraction) Reset() {
+	*x = DocumentExtraction{}
 	mi := &file_api_v1_attachment_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAttachmentsResponse) String() string {
+func (x *DocumentExtraction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAttachmentsResponse) ProtoMessage() {}
+func (*DocumentExtraction) ProtoMessage() {}
 
-func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+func (x *DocumentExtraction) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_attachment_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -886,48 +1011,65 @@ func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
-func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DocumentExtraction.ProtoReflect.Descriptor instead.
+func (*DocumentExtraction) Descriptor() ([]byte, []int) {
 	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+func (x *DocumentExtraction) GetText() string {
 	if x != nil {
-		return x.Attachments
+		return x.Text
 	}
-	return nil
+	return ""
 }
 
-func (x *ListAttachmentsResponse) GetNextPageToken() string {
+func (x *DocumentExtraction) GetPageCount() int32 {
 	if x != nil {
-		return x.NextPageToken
+		return x.PageCount
 	}
-	return ""
+	return 0
 }
 
-type GetAttachmentRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The attachment name of the attachment to retrieve.
-	// Format: attachments/{attachment}
-	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+func (x *DocumentExtraction) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *DocumentExtraction) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// ContentScan is the malware scan verdict of an attachment.
+type ContentScan struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status ContentScan_Status     `protobuf:"varint,1,opt,name=status,proto3,enum=memos.api.v1.ContentScan_Status" json:"status,omitempty"`
+	// The name of the detected malware.
+	Signature string `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// The time the content was scanned.
+	ScanTime      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=scan_time,json=scanTime,proto3" json:"scan_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAttachmentRequest) Reset() {
-	*x = GetAttachmentRequest{}
+func (x *ContentScan) Reset() {
+	*x = ContentScan{}
 	mi := &file_api_v1_attachment_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAttachmentRequest) String() string {
+func (x *ContentScan) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAttachmentRequest) ProtoMessage() {}
+func (*ContentScan) ProtoMessage() {}
 
-func (x *GetAttachmentRequest) ProtoReflect() protoreflect.Message {
+func (x *ContentScan) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_attachment_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -939,42 +1081,59 @@ func (x *GetAttachmentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAttachmentRequest.ProtoReflect.Descriptor instead.
-func (*GetAttachmentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ContentScan.ProtoReflect.Descriptor instead.
+func (*ContentScan) Descriptor() ([]byte, []int) {
 	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetAttachmentRequest) GetName() string {
+func (x *ContentScan) GetStatus() ContentScan_Status {
 	if x != nil {
-		return x.Name
+		return x.Status
+	}
+	return ContentScan_STATUS_UNSPECIFIED
+}
+
+func (x *ContentScan) GetSignature() string {
+	if x != nil {
+		return x.Signature
 	}
 	return ""
 }
 
-type UpdateAttachmentRequest struct {
+func (x *ContentScan) GetScanTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScanTime
+	}
+	return nil
+}
+
+// ImageAnalysis is the OCR and captioning result of an image attachment.
+type ImageAnalysis struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The attachment which replaces the attachment on the server.
-	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
-	// Required. The list of fields to update.
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// The text recognized in the image.
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// A short description of the image, suitable as alt text.
+	Caption string `protobuf:"bytes,2,opt,name=caption,proto3" json:"caption,omitempty"`
+	// The time the analysis was generated.
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAttachmentRequest) Reset() {
-	*x = UpdateAttachmentRequest{}
+func (x *ImageAnalysis) Reset() {
+	*x = ImageAnalysis{}
 	mi := &file_api_v1_attachment_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAttachmentRequest) String() string {
+func (x *ImageAnalysis) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAttachmentRequest) ProtoMessage() {}
+func (*ImageAnalysis) ProtoMessage() {}
 
-func (x *UpdateAttachmentRequest) ProtoReflect() protoreflect.Message {
+func (x *ImageAnalysis) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_attachment_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -986,48 +1145,58 @@ func (x *UpdateAttachmentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAttachmentRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAttachmentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImageAnalysis.ProtoReflect.Descriptor instead.
+func (*ImageAnalysis) Descriptor() ([]byte, []int) {
 	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *UpdateAttachmentRequest) GetAttachment() *Attachment {
+func (x *ImageAnalysis) GetText() string {
 	if x != nil {
-		return x.Attachment
+		return x.Text
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateAttachmentRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *ImageAnalysis) GetCaption() string {
 	if x != nil {
-		return x.UpdateMask
+		return x.Caption
+	}
+	return ""
+}
+
+func (x *ImageAnalysis) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
 	}
 	return nil
 }
 
-type DeleteAttachmentRequest struct {
+type CreateAttachmentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The attachment name of the attachment to delete.
-	// Format: attachments/{attachment}
-	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Required. The attachment to create.
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	// Optional. The attachment ID to use for this attachment.
+	// If empty, a unique ID will be generated.
+	// Format: ^[a-zA-Z0-9]([a-zA-Z0-9-]{0,34}[a-zA-Z0-9])?$
+	AttachmentId  string `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAttachmentRequest) Reset() {
-	*x = DeleteAttachmentRequest{}
+func (x *CreateAttachmentRequest) Reset() {
+	*x = CreateAttachmentRequest{}
 	mi := &file_api_v1_attachment_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAttachmentRequest) String() string {
+func (x *CreateAttachmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAttachmentRequest) ProtoMessage() {}
+func (*CreateAttachmentRequest) ProtoMessage() {}
 
-func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateAttachmentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_attachment_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1039,40 +1208,756 @@ func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateAttachmentRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *DeleteAttachmentRequest) GetName() string {
+func (x *CreateAttachmentRequest) GetAttachment() *Attachment {
 	if x != nil {
-		return x.Name
+		return x.Attachment
 	}
-	return ""
-}
-
-type BatchDeleteAttachmentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return nil
 }
 
-func (x *BatchDeleteAttachmentsRequest) Reset() {
-	*x = BatchDeleteAttachmentsRequest{}
-	mi := &file_api_v1_attachment_service_proto_msgTypes[13]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *CreateAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
 }
 
-func (x *BatchDeleteAttachmentsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
+type ListAttachmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. The maximum number of attachments to return.
+	// The service may return fewer than this value.
+	// If unspecified, at most 50 attachments will be returned.
+	// The maximum value is 1000; values above 1000 will be coerced to 1000.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. A page token, received from a previous `ListAttachments` call.
+	// Provide this to retrieve the subsequent page.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Optional. Filter to apply to the list results.
+	// Example: "mime_type==\"image/png\"" or "filename.contains(\"test\")"
+	// Supported operators: =, !=, <, <=, >, >=, : (contains), in
+	// Supported fields: filename, mime_type, create_time, memo, transcript,
+	// image_text, image_caption
+	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Optional. The order to sort results by.
+	// Example: "create_time desc" or "filename asc"
+	OrderBy       string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsRequest) ProtoMessage() {}
+
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListAttachmentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAttachmentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAttachmentsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListAttachmentsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+type ListAttachmentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The list of attachments.
+	Attachments []*Attachment `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// A token that can be sent as `page_token` to retrieve the next page.
+	// If this field is omitted, there are no subsequent pages.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *ListAttachmentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The attachment name of the attachment to retrieve.
+	// Format: attachments/{attachment}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentRequest) Reset() {
+	*x = GetAttachmentRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentRequest) ProtoMessage() {}
+
+func (x *GetAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetAttachmentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The attachment which replaces the attachment on the server.
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	// Required. The list of fields to update.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAttachmentRequest) Reset() {
+	*x = UpdateAttachmentRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAttachmentRequest) ProtoMessage() {}
+
+func (x *UpdateAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateAttachmentRequest) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *UpdateAttachmentRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The attachment name of the attachment to delete.
+	// Format: attachments/{attachment}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteAttachmentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type BatchDeleteAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteAttachmentsRequest) Reset() {
+	*x = BatchDeleteAttachmentsRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteAttachmentsRequest) ProtoMessage() {}
+
+func (x *BatchDeleteAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchDeleteAttachmentsRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type MigrateAttachmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The ID of the storage to move attachments from. The built-in
+	// storages are `database` and `local`.
+	SourceStorageId string `protobuf:"bytes,1,opt,name=source_storage_id,json=sourceStorageId,proto3" json:"source_storage_id,omitempty"`
+	// Required. The ID of the storage to move attachments to.
+	TargetStorageId string `protobuf:"bytes,2,opt,name=target_storage_id,json=targetStorageId,proto3" json:"target_storage_id,omitempty"`
+	// Optional. Restricts the migration to attachments matching the filter,
+	// using the same syntax as `ListAttachments`.
+	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Optional. The maximum number of attachments examined by this call.
+	// If unspecified, at most 50 attachments are examined.
+	// The maximum value is 1000; values above 1000 will be coerced to 1000.
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. A page token, received from a previous `MigrateAttachments`
+	// call with the same storages and filter.
+	PageToken     string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MigrateAttachmentsRequest) Reset() {
+	*x = MigrateAttachmentsRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateAttachmentsRequest) ProtoMessage() {}
+
+func (x *MigrateAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*MigrateAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MigrateAttachmentsRequest) GetSourceStorageId() string {
+	if x != nil {
+		return x.SourceStorageId
+	}
+	return ""
+}
+
+func (x *MigrateAttachmentsRequest) GetTargetStorageId() string {
+	if x != nil {
+		return x.TargetStorageId
+	}
+	return ""
+}
+
+func (x *MigrateAttachmentsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *MigrateAttachmentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *MigrateAttachmentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type MigrateAttachmentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of attachments moved by this call.
+	MigratedCount int32 `protobuf:"varint,1,opt,name=migrated_count,json=migratedCount,proto3" json:"migrated_count,omitempty"`
+	// The attachments this call could not move. They stay on the source
+	// storage and are retried by a later migration.
+	Failures []*MigrateAttachmentsResponse_Failure `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty"`
+	// A token that can be sent as `page_token` to continue the migration.
+	// If this field is omitted, the migration is complete.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// The number of matching attachments on the source storage when the
+	// migration started.
+	TotalCount int32 `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// The number of matching attachments examined so far, including failures.
+	ProcessedCount int32 `protobuf:"varint,5,opt,name=processed_count,json=processedCount,proto3" json:"processed_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MigrateAttachmentsResponse) Reset() {
+	*x = MigrateAttachmentsResponse{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateAttachmentsResponse) ProtoMessage() {}
+
+func (x *MigrateAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*MigrateAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MigrateAttachmentsResponse) GetMigratedCount() int32 {
+	if x != nil {
+		return x.MigratedCount
+	}
+	return 0
+}
+
+func (x *MigrateAttachmentsResponse) GetFailures() []*MigrateAttachmentsResponse_Failure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *MigrateAttachmentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *MigrateAttachmentsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *MigrateAttachmentsResponse) GetProcessedCount() int32 {
+	if x != nil {
+		return x.ProcessedCount
+	}
+	return 0
+}
+
+// Used internally for resuming an attachment migration.
+type AttachmentMigrationPageToken struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	LastAttachmentId int32                  `protobuf:"varint,1,opt,name=last_attachment_id,json=lastAttachmentId,proto3" json:"last_attachment_id,omitempty"`
+	TotalCount       int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	ProcessedCount   int32                  `protobuf:"varint,3,opt,name=processed_count,json=processedCount,proto3" json:"processed_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AttachmentMigrationPageToken) Reset() {
+	*x = AttachmentMigrationPageToken{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentMigrationPageToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentMigrationPageToken) ProtoMessage() {}
+
+func (x *AttachmentMigrationPageToken) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentMigrationPageToken.ProtoReflect.Descriptor instead.
+func (*AttachmentMigrationPageToken) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AttachmentMigrationPageToken) GetLastAttachmentId() int32 {
+	if x != nil {
+		return x.LastAttachmentId
+	}
+	return 0
+}
+
+func (x *AttachmentMigrationPageToken) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *AttachmentMigrationPageToken) GetProcessedCount() int32 {
+	if x != nil {
+		return x.ProcessedCount
+	}
+	return 0
+}
+
+// UploadSession is a resumable upload of a single attachment.
+type UploadSession struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the upload session.
+	// Format: uploadSessions/{upload_session}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The filename of the attachment.
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// The MIME type of the attachment.
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// The total size of the content in bytes.
+	Size int64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// Optional. The related memo. Refer to `Memo.name`.
+	// Format: memos/{memo}
+	Memo *string `protobuf:"bytes,5,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	// Output only. The number of bytes received so far. The next chunk must
+	// start at this offset.
+	Offset int64 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Output only. The required chunk size. Every chunk except the last must be
+	// exactly this size.
+	ChunkSize int64 `protobuf:"varint,7,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// Output only. The URL that accepts the content chunks.
+	UploadUrl string `protobuf:"bytes,8,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	// Output only. The creation timestamp.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Output only. The time after which an idle session is discarded.
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// Output only. The presigned URL that accepts the whole content in a single
+	// PUT straight to object storage, sent with `type` as its Content-Type.
+	// Set only for direct upload sessions, which have no upload_url. Fetch the
+	// session again for a fresh URL once it expires.
+	DirectUploadUrl string `protobuf:"bytes,11,opt,name=direct_upload_url,json=directUploadUrl,proto3" json:"direct_upload_url,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UploadSession) Reset() {
+	*x = UploadSession{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSession) ProtoMessage() {}
+
+func (x *UploadSession) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSession.ProtoReflect.Descriptor instead.
+func (*UploadSession) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UploadSession) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadSession) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadSession) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UploadSession) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
 
-func (*BatchDeleteAttachmentsRequest) ProtoMessage() {}
+func (x *UploadSession) GetMemo() string {
+	if x != nil && x.Memo != nil {
+		return *x.Memo
+	}
+	return ""
+}
 
-func (x *BatchDeleteAttachmentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_attachment_service_proto_msgTypes[13]
+func (x *UploadSession) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadSession) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *UploadSession) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *UploadSession) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *UploadSession) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *UploadSession) GetDirectUploadUrl() string {
+	if x != nil {
+		return x.DirectUploadUrl
+	}
+	return ""
+}
+
+type CreateUploadSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The upload session to create.
+	UploadSession *UploadSession `protobuf:"bytes,1,opt,name=upload_session,json=uploadSession,proto3" json:"upload_session,omitempty"`
+	// Optional. The attachment ID to use for the finalized attachment.
+	// If empty, a unique ID will be generated.
+	// Format: ^[a-zA-Z0-9]([a-zA-Z0-9-]{0,34}[a-zA-Z0-9])?$
+	AttachmentId string `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	// Optional. Request a presigned URL to upload the content directly to the
+	// default storage. Ignored unless that storage has presigned transfers
+	// enabled, in which case the session falls back to chunked uploads.
+	DirectUpload  bool `protobuf:"varint,3,opt,name=direct_upload,json=directUpload,proto3" json:"direct_upload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUploadSessionRequest) Reset() {
+	*x = CreateUploadSessionRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadSessionRequest) ProtoMessage() {}
+
+func (x *CreateUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1083,18 +1968,295 @@ func (x *BatchDeleteAttachmentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchDeleteAttachmentsRequest.ProtoReflect.Descriptor instead.
-func (*BatchDeleteAttachmentsRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use CreateUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *BatchDeleteAttachmentsRequest) GetNames() []string {
+func (x *CreateUploadSessionRequest) GetUploadSession() *UploadSession {
 	if x != nil {
-		return x.Names
+		return x.UploadSession
 	}
 	return nil
 }
 
+func (x *CreateUploadSessionRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *CreateUploadSessionRequest) GetDirectUpload() bool {
+	if x != nil {
+		return x.DirectUpload
+	}
+	return false
+}
+
+type GetUploadSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The name of the upload session.
+	// Format: uploadSessions/{upload_session}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadSessionRequest) Reset() {
+	*x = GetUploadSessionRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadSessionRequest) ProtoMessage() {}
+
+func (x *GetUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUploadSessionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CompleteUploadSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The name of the upload session.
+	// Format: uploadSessions/{upload_session}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteUploadSessionRequest) Reset() {
+	*x = CompleteUploadSessionRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteUploadSessionRequest) ProtoMessage() {}
+
+func (x *CompleteUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*CompleteUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CompleteUploadSessionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteUploadSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The name of the upload session.
+	// Format: uploadSessions/{upload_session}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUploadSessionRequest) Reset() {
+	*x = DeleteUploadSessionRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUploadSessionRequest) ProtoMessage() {}
+
+func (x *DeleteUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteUploadSessionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type AudioTranscript_Segment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// Offset of the segment start from the beginning of the audio, in seconds.
+	StartSeconds float64 `protobuf:"fixed64,2,opt,name=start_seconds,json=startSeconds,proto3" json:"start_seconds,omitempty"`
+	// Offset of the segment end from the beginning of the audio, in seconds.
+	EndSeconds float64 `protobuf:"fixed64,3,opt,name=end_seconds,json=endSeconds,proto3" json:"end_seconds,omitempty"`
+	// The speaker label, empty unless the model returns speaker labels.
+	Speaker       string `protobuf:"bytes,4,opt,name=speaker,proto3" json:"speaker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AudioTranscript_Segment) Reset() {
+	*x = AudioTranscript_Segment{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AudioTranscript_Segment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioTranscript_Segment) ProtoMessage() {}
+
+func (x *AudioTranscript_Segment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioTranscript_Segment.ProtoReflect.Descriptor instead.
+func (*AudioTranscript_Segment) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *AudioTranscript_Segment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *AudioTranscript_Segment) GetStartSeconds() float64 {
+	if x != nil {
+		return x.StartSeconds
+	}
+	return 0
+}
+
+func (x *AudioTranscript_Segment) GetEndSeconds() float64 {
+	if x != nil {
+		return x.EndSeconds
+	}
+	return 0
+}
+
+func (x *AudioTranscript_Segment) GetSpeaker() string {
+	if x != nil {
+		return x.Speaker
+	}
+	return ""
+}
+
+type MigrateAttachmentsResponse_Failure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the attachment that was not moved.
+	Attachment string `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	// The reason the attachment was not moved.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MigrateAttachmentsResponse_Failure) Reset() {
+	*x = MigrateAttachmentsResponse_Failure{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateAttachmentsResponse_Failure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateAttachmentsResponse_Failure) ProtoMessage() {}
+
+func (x *MigrateAttachmentsResponse_Failure) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateAttachmentsResponse_Failure.ProtoReflect.Descriptor instead.
+func (*MigrateAttachmentsResponse_Failure) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{20, 0}
+}
+
+func (x *MigrateAttachmentsResponse_Failure) GetAttachment() string {
+	if x != nil {
+		return x.Attachment
+	}
+	return ""
+}
+
+func (x *MigrateAttachmentsResponse_Failure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_api_v1_attachment_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_attachment_service_proto_rawDesc = "" +
@@ -1148,7 +2310,7 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\x10_altitude_meters\"T\n" +
 	"\rVideoMetadata\x12.\n" +
 	"\x10duration_seconds\x18\x01 \x01(\x01H\x00R\x0fdurationSeconds\x88\x01\x01B\x13\n" +
-	"\x11_duration_seconds\"\x8a\x04\n" +
+	"\x11_duration_seconds\"\x80\a\n" +
 	"\n" +
 	"Attachment\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12@\n" +
@@ -1162,10 +2324,58 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\x04memo\x18\b \x01(\tB\x03\xe0A\x01H\x00R\x04memo\x88\x01\x01\x12A\n" +
 	"\fmotion_media\x18\t \x01(\v2\x19.memos.api.v1.MotionMediaB\x03\xe0A\x01R\vmotionMedia\x12J\n" +
 	"\x0emedia_metadata\x18\n" +
-	" \x01(\v2\x1b.memos.api.v1.MediaMetadataB\x06\xe0A\x01\xe0A\x05R\rmediaMetadata:O\xeaAL\n" +
+	" \x01(\v2\x1b.memos.api.v1.MediaMetadataB\x06\xe0A\x01\xe0A\x05R\rmediaMetadata\x12B\n" +
+	"\n" +
+	"transcript\x18\v \x01(\v2\x1d.memos.api.v1.AudioTranscriptB\x03\xe0A\x03R\n" +
+	"transcript\x12G\n" +
+	"\x0eimage_analysis\x18\f \x01(\v2\x1b.memos.api.v1.ImageAnalysisB\x03\xe0A\x03R\rimageAnalysis\x12\x1b\n" +
+	"\x06sha256\x18\r \x01(\tB\x03\xe0A\x01R\x06sha256\x12D\n" +
+	"\rmedia_preview\x18\x0e \x01(\v2\x1a.memos.api.v1.MediaPreviewB\x03\xe0A\x03R\fmediaPreview\x12A\n" +
+	"\fcontent_scan\x18\x0f \x01(\v2\x19.memos.api.v1.ContentScanB\x03\xe0A\x03R\vcontentScan\x12A\n" +
+	"\bdocument\x18\x10 \x01(\v2 .memos.api.v1.DocumentExtractionB\x03\xe0A\x03R\bdocument:O\xeaAL\n" +
 	"\x17memos.api.v1/Attachment\x12\x18attachments/{attachment}*\vattachments2\n" +
 	"attachmentB\a\n" +
-	"\x05_memo\"\x82\x01\n" +
+	"\x05_memo\"\xc0\x02\n" +
+	"\x0fAudioTranscript\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12A\n" +
+	"\bsegments\x18\x03 \x03(\v2%.memos.api.v1.AudioTranscript.SegmentR\bsegments\x12;\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x1a}\n" +
+	"\aSegment\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12#\n" +
+	"\rstart_seconds\x18\x02 \x01(\x01R\fstartSeconds\x12\x1f\n" +
+	"\vend_seconds\x18\x03 \x01(\x01R\n" +
+	"endSeconds\x12\x18\n" +
+	"\aspeaker\x18\x04 \x01(\tR\aspeaker\"\x91\x01\n" +
+	"\fMediaPreview\x12\x1d\n" +
+	"\n" +
+	"poster_url\x18\x01 \x01(\tR\tposterUrl\x12%\n" +
+	"\x0ewaveform_peaks\x18\x02 \x03(\x02R\rwaveformPeaks\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\xa2\x01\n" +
+	"\x12DocumentExtraction\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\x02 \x01(\x05R\tpageCount\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x12;\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\xe5\x01\n" +
+	"\vContentScan\x128\n" +
+	"\x06status\x18\x01 \x01(\x0e2 .memos.api.v1.ContentScan.StatusR\x06status\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignature\x127\n" +
+	"\tscan_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bscanTime\"E\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05CLEAN\x10\x01\x12\f\n" +
+	"\bINFECTED\x10\x02\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x03\"z\n" +
+	"\rImageAnalysis\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x18\n" +
+	"\acaption\x18\x02 \x01(\tR\acaption\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\x82\x01\n" +
 	"\x17CreateAttachmentRequest\x12=\n" +
 	"\n" +
 	"attachment\x18\x01 \x01(\v2\x18.memos.api.v1.AttachmentB\x03\xe0A\x02R\n" +
@@ -1193,7 +2403,63 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tB\x1f\xe0A\x02\xfaA\x19\n" +
 	"\x17memos.api.v1/AttachmentR\x04name\":\n" +
 	"\x1dBatchDeleteAttachmentsRequest\x12\x19\n" +
-	"\x05names\x18\x01 \x03(\tB\x03\xe0A\x02R\x05names*h\n" +
+	"\x05names\x18\x01 \x03(\tB\x03\xe0A\x02R\x05names\"\xe0\x01\n" +
+	"\x19MigrateAttachmentsRequest\x12/\n" +
+	"\x11source_storage_id\x18\x01 \x01(\tB\x03\xe0A\x02R\x0fsourceStorageId\x12/\n" +
+	"\x11target_storage_id\x18\x02 \x01(\tB\x03\xe0A\x02R\x0ftargetStorageId\x12\x1b\n" +
+	"\x06filter\x18\x03 \x01(\tB\x03\xe0A\x01R\x06filter\x12 \n" +
+	"\tpage_size\x18\x04 \x01(\x05B\x03\xe0A\x01R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tB\x03\xe0A\x01R\tpageToken\"\xc4\x02\n" +
+	"\x1aMigrateAttachmentsResponse\x12%\n" +
+	"\x0emigrated_count\x18\x01 \x01(\x05R\rmigratedCount\x12L\n" +
+	"\bfailures\x18\x02 \x03(\v20.memos.api.v1.MigrateAttachmentsResponse.FailureR\bfailures\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x04 \x01(\x05R\n" +
+	"totalCount\x12'\n" +
+	"\x0fprocessed_count\x18\x05 \x01(\x05R\x0eprocessedCount\x1a?\n" +
+	"\aFailure\x12\x1e\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\tR\n" +
+	"attachment\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x96\x01\n" +
+	"\x1cAttachmentMigrationPageToken\x12,\n" +
+	"\x12last_attachment_id\x18\x01 \x01(\x05R\x10lastAttachmentId\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12'\n" +
+	"\x0fprocessed_count\x18\x03 \x01(\x05R\x0eprocessedCount\"\x9d\x04\n" +
+	"\rUploadSession\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12\x1f\n" +
+	"\bfilename\x18\x02 \x01(\tB\x03\xe0A\x02R\bfilename\x12\x17\n" +
+	"\x04type\x18\x03 \x01(\tB\x03\xe0A\x02R\x04type\x12\x17\n" +
+	"\x04size\x18\x04 \x01(\x03B\x03\xe0A\x02R\x04size\x12\x1c\n" +
+	"\x04memo\x18\x05 \x01(\tB\x03\xe0A\x01H\x00R\x04memo\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x06 \x01(\x03B\x03\xe0A\x03R\x06offset\x12\"\n" +
+	"\n" +
+	"chunk_size\x18\a \x01(\x03B\x03\xe0A\x03R\tchunkSize\x12\"\n" +
+	"\n" +
+	"upload_url\x18\b \x01(\tB\x03\xe0A\x03R\tuploadUrl\x12@\n" +
+	"\vcreate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12@\n" +
+	"\vexpire_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"expireTime\x12/\n" +
+	"\x11direct_upload_url\x18\v \x01(\tB\x03\xe0A\x03R\x0fdirectUploadUrl:_\xeaA\\\n" +
+	"\x1amemos.api.v1/UploadSession\x12\x1fuploadSessions/{upload_session}*\x0euploadSessions2\ruploadSessionB\a\n" +
+	"\x05_memo\"\xb9\x01\n" +
+	"\x1aCreateUploadSessionRequest\x12G\n" +
+	"\x0eupload_session\x18\x01 \x01(\v2\x1b.memos.api.v1.UploadSessionB\x03\xe0A\x02R\ruploadSession\x12(\n" +
+	"\rattachment_id\x18\x02 \x01(\tB\x03\xe0A\x01R\fattachmentId\x12(\n" +
+	"\rdirect_upload\x18\x03 \x01(\bB\x03\xe0A\x01R\fdirectUpload\"Q\n" +
+	"\x17GetUploadSessionRequest\x126\n" +
+	"\x04name\x18\x01 \x01(\tB\"\xe0A\x02\xfaA\x1c\n" +
+	"\x1amemos.api.v1/UploadSessionR\x04name\"V\n" +
+	"\x1cCompleteUploadSessionRequest\x126\n" +
+	"\x04name\x18\x01 \x01(\tB\"\xe0A\x02\xfaA\x1c\n" +
+	"\x1amemos.api.v1/UploadSessionR\x04name\"T\n" +
+	"\x1aDeleteUploadSessionRequest\x126\n" +
+	"\x04name\x18\x01 \x01(\tB\"\xe0A\x02\xfaA\x1c\n" +
+	"\x1amemos.api.v1/UploadSessionR\x04name*h\n" +
 	"\x11MotionMediaFamily\x12#\n" +
 	"\x1fMOTION_MEDIA_FAMILY_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10APPLE_LIVE_PHOTO\x10\x01\x12\x18\n" +
@@ -1202,7 +2468,7 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\x1dMOTION_MEDIA_ROLE_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05STILL\x10\x01\x12\t\n" +
 	"\x05VIDEO\x10\x02\x12\r\n" +
-	"\tCONTAINER\x10\x032\xd0\x06\n" +
+	"\tCONTAINER\x10\x032\xb1\f\n" +
 	"\x11AttachmentService\x12\x89\x01\n" +
 	"\x10CreateAttachment\x12%.memos.api.v1.CreateAttachmentRequest\x1a\x18.memos.api.v1.Attachment\"4\xdaA\n" +
 	"attachment\x82\xd3\xe4\x93\x02!:\n" +
@@ -1212,7 +2478,12 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\x10UpdateAttachment\x12%.memos.api.v1.UpdateAttachmentRequest\x1a\x18.memos.api.v1.Attachment\"T\xdaA\x16attachment,update_mask\x82\xd3\xe4\x93\x025:\n" +
 	"attachment2'/api/v1/{attachment.name=attachments/*}\x12~\n" +
 	"\x10DeleteAttachment\x12%.memos.api.v1.DeleteAttachmentRequest\x1a\x16.google.protobuf.Empty\"+\xdaA\x04name\x82\xd3\xe4\x93\x02\x1e*\x1c/api/v1/{name=attachments/*}\x12\x89\x01\n" +
-	"\x16BatchDeleteAttachments\x12+.memos.api.v1.BatchDeleteAttachmentsRequest\x1a\x16.google.protobuf.Empty\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/attachments:batchDeleteB\xae\x01\n" +
+	"\x16BatchDeleteAttachments\x12+.memos.api.v1.BatchDeleteAttachmentsRequest\x1a\x16.google.protobuf.Empty\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/attachments:batchDelete\x12\x8f\x01\n" +
+	"\x12MigrateAttachments\x12'.memos.api.v1.MigrateAttachmentsRequest\x1a(.memos.api.v1.MigrateAttachmentsResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/attachments:migrate\x12\x9d\x01\n" +
+	"\x13CreateUploadSession\x12(.memos.api.v1.CreateUploadSessionRequest\x1a\x1b.memos.api.v1.UploadSession\"?\xdaA\x0eupload_session\x82\xd3\xe4\x93\x02(:\x0eupload_session\"\x16/api/v1/uploadSessions\x12\x86\x01\n" +
+	"\x10GetUploadSession\x12%.memos.api.v1.GetUploadSessionRequest\x1a\x1b.memos.api.v1.UploadSession\".\xdaA\x04name\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/{name=uploadSessions/*}\x12\x99\x01\n" +
+	"\x15CompleteUploadSession\x12*.memos.api.v1.CompleteUploadSessionRequest\x1a\x18.memos.api.v1.Attachment\":\xdaA\x04name\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/{name=uploadSessions/*}:complete\x12\x87\x01\n" +
+	"\x13DeleteUploadSession\x12(.memos.api.v1.DeleteUploadSessionRequest\x1a\x16.google.protobuf.Empty\".\xdaA\x04name\x82\xd3\xe4\x93\x02!*\x1f/api/v1/{name=uploadSessions/*}B\xae\x01\n" +
 	"\x10com.memos.api.v1B\x16AttachmentServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
 var (
@@ -1227,60 +2498,102 @@ func file_api_v1_attachment_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_attachment_service_proto_rawDescData
 }
 
-var file_api_v1_attachment_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_v1_attachment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_api_v1_attachment_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_v1_attachment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_api_v1_attachment_service_proto_goTypes = []any{
-	(MotionMediaFamily)(0),                // 0: memos.api.v1.MotionMediaFamily
-	(MotionMediaRole)(0),                  // 1: memos.api.v1.MotionMediaRole
-	(*MotionMedia)(nil),                   // 2: memos.api.v1.MotionMedia
-	(*MediaMetadata)(nil),                 // 3: memos.api.v1.MediaMetadata
-	(*PhotoMetadata)(nil),                 // 4: memos.api.v1.PhotoMetadata
-	(*MediaCaptureTime)(nil),              // 5: memos.api.v1.MediaCaptureTime
-	(*MediaLocation)(nil),                 // 6: memos.api.v1.MediaLocation
-	(*VideoMetadata)(nil),                 // 7: memos.api.v1.VideoMetadata
-	(*Attachment)(nil),                    // 8: memos.api.v1.Attachment
-	(*CreateAttachmentRequest)(nil),       // 9: memos.api.v1.CreateAttachmentRequest
-	(*ListAttachmentsRequest)(nil),        // 10: memos.api.v1.ListAttachmentsRequest
-	(*ListAttachmentsResponse)(nil),       // 11: memos.api.v1.ListAttachmentsResponse
-	(*GetAttachmentRequest)(nil),          // 12: memos.api.v1.GetAttachmentRequest
-	(*UpdateAttachmentRequest)(nil),       // 13: memos.api.v1.UpdateAttachmentRequest
-	(*DeleteAttachmentRequest)(nil),       // 14: memos.api.v1.DeleteAttachmentRequest
-	(*BatchDeleteAttachmentsRequest)(nil), // 15: memos.api.v1.BatchDeleteAttachmentsRequest
-	(*timestamppb.Timestamp)(nil),         // 16: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),         // 17: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),                 // 18: google.protobuf.Empty
+	(MotionMediaFamily)(0),                     // 0: memos.api.v1.MotionMediaFamily
+	(MotionMediaRole)(0),                       // 1: memos.api.v1.MotionMediaRole
+	(ContentScan_Status)(0),                    // 2: memos.api.v1.ContentScan.Status
+	(*MotionMedia)(nil),                        // 3: memos.api.v1.MotionMedia
+	(*MediaMetadata)(nil),                      // 4: memos.api.v1.MediaMetadata
+	(*PhotoMetadata)(nil),                      // 5: memos.api.v1.PhotoMetadata
+	(*MediaCaptureTime)(nil),                   // 6: memos.api.v1.MediaCaptureTime
+	(*MediaLocation)(nil),                      // 7: memos.api.v1.MediaLocation
+	(*VideoMetadata)(nil),                      // 8: memos.api.v1.VideoMetadata
+	(*Attachment)(nil),                         // 9: memos.api.v1.Attachment
+	(*AudioTranscript)(nil),                    // 10: memos.api.v1.AudioTranscript
+	(*MediaPreview)(nil),                       // 11: memos.api.v1.MediaPreview
+	(*DocumentExtraction)(nil),                 // 12: memos.api.v1.DocumentExtraction
+	(*ContentScan)(nil),                        // 13: memos.api.v1.ContentScan
+	(*ImageAnalysis)(nil),                      // 14: memos.api.v1.ImageAnalysis
+	(*CreateAttachmentRequest)(nil),            // 15: memos.api.v1.CreateAttachmentRequest
+	(*ListAttachmentsRequest)(nil),             // 16: memos.api.v1.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),            // 17: memos.api.v1.ListAttachmentsResponse
+	(*GetAttachmentRequest)(nil),               // 18: memos.api.v1.GetAttachmentRequest
+	(*UpdateAttachmentRequest)(nil),            // 19: memos.api.v1.UpdateAttachmentRequest
+	(*DeleteAttachmentRequest)(nil),            // 20: memos.api.v1.DeleteAttachmentRequest
+	(*BatchDeleteAttachmentsRequest)(nil),      // 21: memos.api.v1.BatchDeleteAttachmentsRequest
+	(*MigrateAttachmentsRequest)(nil),          // 22: memos.api.v1.MigrateAttachmentsRequest
+	(*MigrateAttachmentsResponse)(nil),         // 23: memos.api.v1.MigrateAttachmentsResponse
+	(*AttachmentMigrationPageToken)(nil),       // 24: memos.api.v1.AttachmentMigrationPageToken
+	(*UploadSession)(nil),                      // 25: memos.api.v1.UploadSession
+	(*CreateUploadSessionRequest)(nil),         // 26: memos.api.v1.CreateUploadSessionRequest
+	(*GetUploadSessionRequest)(nil),            // 27: memos.api.v1.GetUploadSessionRequest
+	(*CompleteUploadSessionRequest)(nil),       // 28: memos.api.v1.CompleteUploadSessionRequest
+	(*DeleteUploadSessionRequest)(nil),         // 29: memos.api.v1.DeleteUploadSessionRequest
+	(*AudioTranscript_Segment)(nil),            // 30: memos.api.v1.AudioTranscript.Segment
+	(*MigrateAttachmentsResponse_Failure)(nil), // 31: memos.api.v1.MigrateAttachmentsResponse.Failure
+	(*timestamppb.Timestamp)(nil),              // 32: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),              // 33: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                      // 34: google.protobuf.Empty
 }
 var file_api_v1_attachment_service_proto_depIdxs = []int32{
 	0,  // 0: memos.api.v1.MotionMedia.family:type_name -> memos.api.v1.MotionMediaFamily
 	1,  // 1: memos.api.v1.MotionMedia.role:type_name -> memos.api.v1.MotionMediaRole
-	4,  // 2: memos.api.v1.MediaMetadata.photo:type_name -> memos.api.v1.PhotoMetadata
-	7,  // 3: memos.api.v1.MediaMetadata.video:type_name -> memos.api.v1.VideoMetadata
-	5,  // 4: memos.api.v1.PhotoMetadata.capture_time:type_name -> memos.api.v1.MediaCaptureTime
-	6,  // 5: memos.api.v1.PhotoMetadata.location:type_name -> memos.api.v1.MediaLocation
-	16, // 6: memos.api.v1.Attachment.create_time:type_name -> google.protobuf.Timestamp
-	2,  // 7: memos.api.v1.Attachment.motion_media:type_name -> memos.api.v1.MotionMedia
-	3,  // 8: memos.api.v1.Attachment.media_metadata:type_name -> memos.api.v1.MediaMetadata
-	8,  // 9: memos.api.v1.CreateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
-	8,  // 10: memos.api.v1.ListAttachmentsResponse.attachments:type_name -> memos.api.v1.Attachment
-	8,  // 11: memos.api.v1.UpdateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
-	17, // 12: memos.api.v1.UpdateAttachmentRequest.update_mask:type_name -> google.protobuf.FieldMask
-	9,  // 13: memos.api.v1.AttachmentService.CreateAttachment:input_type -> memos.api.v1.CreateAttachmentRequest
-	10, // 14: memos.api.v1.AttachmentService.ListAttachments:input_type -> memos.api.v1.ListAttachmentsRequest
-	12, // 15: memos.api.v1.AttachmentService.GetAttachment:input_type -> memos.api.v1.GetAttachmentRequest
-	13, // 16: memos.api.v1.AttachmentService.UpdateAttachment:input_type -> memos.api.v1.UpdateAttachmentRequest
-	14, // 17: memos.api.v1.AttachmentService.DeleteAttachment:input_type -> memos.api.v1.DeleteAttachmentRequest
-	15, // 18: memos.api.v1.AttachmentService.BatchDeleteAttachments:input_type -> memos.api.v1.BatchDeleteAttachmentsRequest
-	8,  // 19: memos.api.v1.AttachmentService.CreateAttachment:output_type -> memos.api.v1.Attachment
-	11, // 20: memos.api.v1.AttachmentService.ListAttachments:output_type -> memos.api.v1.ListAttachmentsResponse
-	8,  // 21: memos.api.v1.AttachmentService.GetAttachment:output_type -> memos.api.v1.Attachment
-	8,  // 22: memos.api.v1.AttachmentService.UpdateAttachment:output_type -> memos.api.v1.Attachment
-	18, // 23: memos.api.v1.AttachmentService.DeleteAttachment:output_type -> google.protobuf.Empty
-	18, // 24: memos.api.v1.AttachmentService.BatchDeleteAttachments:output_type -> google.protobuf.Empty
-	19, // [19:25] is the sub-list for method output_type
-	13, // [13:19] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	5,  // 2: memos.api.v1.MediaMetadata.photo:type_name -> memos.api.v1.PhotoMetadata
+	8,  // 3: memos.api.v1.MediaMetadata.video:type_name -> memos.api.v1.VideoMetadata
+	6,  // 4: memos.api.v1.PhotoMetadata.capture_time:type_name -> memos.api.v1.MediaCaptureTime
+	7,  // 5: memos.api.v1.PhotoMetadata.location:type_name -> memos.api.v1.MediaLocation
+	32, // 6: memos.api.v1.Attachment.create_time:type_name -> google.protobuf.Timestamp
+	3,  // 7: memos.api.v1.Attachment.motion_media:type_name -> memos.api.v1.MotionMedia
+	4,  // 8: memos.api.v1.Attachment.media_metadata:type_name -> memos.api.v1.MediaMetadata
+	10, // 9: memos.api.v1.Attachment.transcript:type_name -> memos.api.v1.AudioTranscript
+	14, // 10: memos.api.v1.Attachment.image_analysis:type_name -> memos.api.v1.ImageAnalysis
+	11, // 11: memos.api.v1.Attachment.media_preview:type_name -> memos.api.v1.MediaPreview
+	13, // 12: memos.api.v1.Attachment.content_scan:type_name -> memos.api.v1.ContentScan
+	12, // 13: memos.api.v1.Attachment.document:type_name -> memos.api.v1.DocumentExtraction
+	30, // 14: memos.api.v1.AudioTranscript.segments:type_name -> memos.api.v1.AudioTranscript.Segment
+	32, // 15: memos.api.v1.AudioTranscript.create_time:type_name -> google.protobuf.Timestamp
+	32, // 16: memos.api.v1.MediaPreview.create_time:type_name -> google.protobuf.Timestamp
+	32, // 17: memos.api.v1.DocumentExtraction.create_time:type_name -> google.protobuf.Timestamp
+	2,  // 18: memos.api.v1.ContentScan.status:type_name -> memos.api.v1.ContentScan.Status
+	32, // 19: memos.api.v1.ContentScan.scan_time:type_name -> google.protobuf.Timestamp
+	32, // 20: memos.api.v1.ImageAnalysis.create_time:type_name -> google.protobuf.Timestamp
+	9,  // 21: memos.api.v1.CreateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
+	9,  // 22: memos.api.v1.ListAttachmentsResponse.attachments:type_name -> memos.api.v1.Attachment
+	9,  // 23: memos.api.v1.UpdateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
+	33, // 24: memos.api.v1.UpdateAttachmentRequest.update_mask:type_name -> google.protobuf.FieldMask
+	31, // 25: memos.api.v1.MigrateAttachmentsResponse.failures:type_name -> memos.api.v1.MigrateAttachmentsResponse.Failure
+	32, // 26: memos.api.v1.UploadSession.create_time:type_name -> google.protobuf.Timestamp
+	32, // 27: memos.api.v1.UploadSession.expire_time:type_name -> google.protobuf.Timestamp
+	25, // 28: memos.api.v1.CreateUploadSessionRequest.upload_session:type_name -> memos.api.v1.UploadSession
+	15, // 29: memos.api.v1.AttachmentService.CreateAttachment:input_type -> memos.api.v1.CreateAttachmentRequest
+	16, // 30: memos.api.v1.AttachmentService.ListAttachments:input_type -> memos.api.v1.ListAttachmentsRequest
+	18, // 31: memos.api.v1.AttachmentService.GetAttachment:input_type -> memos.api.v1.GetAttachmentRequest
+	19, // 32: memos.api.v1.AttachmentService.UpdateAttachment:input_type -> memos.api.v1.UpdateAttachmentRequest
+	20, // 33: memos.api.v1.AttachmentService.DeleteAttachment:input_type -> memos.api.v1.DeleteAttachmentRequest
+	21, // 34: memos.api.v1.AttachmentService.BatchDeleteAttachments:input_type -> memos.api.v1.BatchDeleteAttachmentsRequest
+	22, // 35: memos.api.v1.AttachmentService.MigrateAttachments:input_type -> memos.api.v1.MigrateAttachmentsRequest
+	26, // 36: memos.api.v1.AttachmentService.CreateUploadSession:input_type -> memos.api.v1.CreateUploadSessionRequest
+	27, // 37: memos.api.v1.AttachmentService.GetUploadSession:input_type -> memos.api.v1.GetUploadSessionRequest
+	28, // 38: memos.api.v1.AttachmentService.CompleteUploadSession:input_type -> memos.api.v1.CompleteUploadSessionRequest
+	29, // 39: memos.api.v1.AttachmentService.DeleteUploadSession:input_type -> memos.api.v1.DeleteUploadSessionRequest
+	9,  // 40: memos.api.v1.AttachmentService.CreateAttachment:output_type -> memos.api.v1.Attachment
+	17, // 41: memos.api.v1.AttachmentService.ListAttachments:output_type -> memos.api.v1.ListAttachmentsResponse
+	9,  // 42: memos.api.v1.AttachmentService.GetAttachment:output_type -> memos.api.v1.Attachment
+	9,  // 43: memos.api.v1.AttachmentService.UpdateAttachment:output_type -> memos.api.v1.Attachment
+	34, // 44: memos.api.v1.AttachmentService.DeleteAttachment:output_type -> google.protobuf.Empty
+	34, // 45: memos.api.v1.AttachmentService.BatchDeleteAttachments:output_type -> google.protobuf.Empty
+	23, // 46: memos.api.v1.AttachmentService.MigrateAttachments:output_type -> memos.api.v1.MigrateAttachmentsResponse
+	25, // 47: memos.api.v1.AttachmentService.CreateUploadSession:output_type -> memos.api.v1.UploadSession
+	25, // 48: memos.api.v1.AttachmentService.GetUploadSession:output_type -> memos.api.v1.UploadSession
+	9,  // 49: memos.api.v1.AttachmentService.CompleteUploadSession:output_type -> memos.api.v1.Attachment
+	34, // 50: memos.api.v1.AttachmentService.DeleteUploadSession:output_type -> google.protobuf.Empty
+	40, // [40:51] is the sub-list for method output_type
+	29, // [29:40] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_attachment_service_proto_init() }
@@ -1297,13 +2610,14 @@ func file_api_v1_attachment_service_proto_init() {
 	file_api_v1_attachment_service_proto_msgTypes[4].OneofWrappers = []any{}
 	file_api_v1_attachment_service_proto_msgTypes[5].OneofWrappers = []any{}
 	file_api_v1_attachment_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_api_v1_attachment_service_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_attachment_service_proto_rawDesc), len(file_api_v1_attachment_service_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   14,
+			NumEnums:      3,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
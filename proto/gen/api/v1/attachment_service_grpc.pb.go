@@ -26,6 +26,11 @@ const (
 	AttachmentService_UpdateAttachment_FullMethodName       = "/memos.api.v1.AttachmentService/UpdateAttachment"
 	AttachmentService_DeleteAttachment_FullMethodName       = "/memos.api.v1.AttachmentService/DeleteAttachment"
 	AttachmentService_BatchDeleteAttachments_FullMethodName = "/memos.api.v1.AttachmentService/BatchDeleteAttachments"
+	AttachmentService_MigrateAttachments_FullMethodName     = "/memos.api.v1.AttachmentService/MigrateAttachments"
+	AttachmentService_CreateUploadSession_FullMethodName    = "/memos.api.v1.AttachmentService/CreateUploadSession"
+	AttachmentService_GetUploadSession_FullMethodName       = "/memos.api.v1.AttachmentService/GetUploadSession"
+	AttachmentService_CompleteUploadSession_FullMethodName  = "/memos.api.v1.AttachmentService/CompleteUploadSession"
+	AttachmentService_DeleteUploadSession_FullMethodName    = "/memos.api.v1.AttachmentService/DeleteUploadSession"
 )
 
 // AttachmentServiceClient is the client API for AttachmentService service.
@@ -44,6 +49,22 @@ type AttachmentServiceClient interface {
 	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// BatchDeleteAttachments deletes multiple attachments in one request.
 	BatchDeleteAttachments(ctx context.Context, in *BatchDeleteAttachmentsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// MigrateAttachments moves a batch of attachments from one configured
+	// storage to another. Call it again with `next_page_token` until the token
+	// is empty; a migration stopped midway resumes from its last token.
+	// Admin only.
+	MigrateAttachments(ctx context.Context, in *MigrateAttachmentsRequest, opts ...grpc.CallOption) (*MigrateAttachmentsResponse, error)
+	// CreateUploadSession starts a resumable upload. The content is sent in
+	// chunks with `PUT {upload_url}` and an `Upload-Offset` header, then
+	// finalized with CompleteUploadSession.
+	CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*UploadSession, error)
+	// GetUploadSession returns an upload session, including the number of bytes
+	// received so far.
+	GetUploadSession(ctx context.Context, in *GetUploadSessionRequest, opts ...grpc.CallOption) (*UploadSession, error)
+	// CompleteUploadSession finalizes a fully received upload into an attachment.
+	CompleteUploadSession(ctx context.Context, in *CompleteUploadSessionRequest, opts ...grpc.CallOption) (*Attachment, error)
+	// DeleteUploadSession cancels an upload and discards the received content.
+	DeleteUploadSession(ctx context.Context, in *DeleteUploadSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type attachmentServiceClient struct {
@@ -114,6 +135,56 @@ func (c *attachmentServiceClient) BatchDeleteAttachments(ctx context.Context, in
 	return out, nil
 }
 
+func (c *attachmentServiceClient) MigrateAttachments(ctx context.Context, in *MigrateAttachmentsRequest, opts ...grpc.CallOption) (*MigrateAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MigrateAttachmentsResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_MigrateAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*UploadSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, AttachmentService_CreateUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) GetUploadSession(ctx context.Context, in *GetUploadSessionRequest, opts ...grpc.CallOption) (*UploadSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, AttachmentService_GetUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) CompleteUploadSession(ctx context.Context, in *CompleteUploadSessionRequest, opts ...grpc.CallOption) (*Attachment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attachment)
+	err := c.cc.Invoke(ctx, AttachmentService_CompleteUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) DeleteUploadSession(ctx context.Context, in *DeleteUploadSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AttachmentService_DeleteUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AttachmentServiceServer is the server API for AttachmentService service.
 // All implementations must embed UnimplementedAttachmentServiceServer
 // for forward compatibility.
@@ -130,6 +201,22 @@ type AttachmentServiceServer interface {
 	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*emptypb.Empty, error)
 	// BatchDeleteAttachments deletes multiple attachments in one request.
 	BatchDeleteAttachments(context.Context, *BatchDeleteAttachmentsRequest) (*emptypb.Empty, error)
+	// MigrateAttachments moves a batch of attachments from one configured
+	// storage to another. Call it again with `next_page_token` until the token
+	// is empty; a migration stopped midway resumes from its last token.
+	// Admin only.
+	MigrateAttachments(context.Context, *MigrateAttachmentsRequest) (*MigrateAttachmentsResponse, error)
+	// CreateUploadSession starts a resumable upload. The content is sent in
+	// chunks with `PUT {upload_url}` and an `Upload-Offset` header, then
+	// finalized with CompleteUploadSession.
+	CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*UploadSession, error)
+	// GetUploadSession returns an upload session, including the number of bytes
+	// received so far.
+	GetUploadSession(context.Context, *GetUploadSessionRequest) (*UploadSession, error)
+	// CompleteUploadSession finalizes a fully received upload into an attachment.
+	CompleteUploadSession(context.Context, *CompleteUploadSessionRequest) (*Attachment, error)
+	// DeleteUploadSession cancels an upload and discards the received content.
+	DeleteUploadSession(context.Context, *DeleteUploadSessionRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedAttachmentServiceServer()
 }
 
@@ -158,6 +245,21 @@ func (UnimplementedAttachmentServiceServer) DeleteAttachment(context.Context, *D
 func (UnimplementedAttachmentServiceServer) BatchDeleteAttachments(context.Context, *BatchDeleteAttachmentsRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method BatchDeleteAttachments not implemented")
 }
+func (UnimplementedAttachmentServiceServer) MigrateAttachments(context.Context, *MigrateAttachmentsRequest) (*MigrateAttachmentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MigrateAttachments not implemented")
+}
+func (UnimplementedAttachmentServiceServer) CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*UploadSession, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateUploadSession not implemented")
+}
+func (UnimplementedAttachmentServiceServer) GetUploadSession(context.Context, *GetUploadSessionRequest) (*UploadSession, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUploadSession not implemented")
+}
+func (UnimplementedAttachmentServiceServer) CompleteUploadSession(context.Context, *CompleteUploadSessionRequest) (*Attachment, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteUploadSession not implemented")
+}
+func (UnimplementedAttachmentServiceServer) DeleteUploadSession(context.Context, *DeleteUploadSessionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteUploadSession not implemented")
+}
 func (UnimplementedAttachmentServiceServer) mustEmbedUnimplementedAttachmentServiceServer() {}
 func (UnimplementedAttachmentServiceServer) testEmbeddedByValue()                           {}
 
@@ -287,6 +389,96 @@ func _AttachmentService_BatchDeleteAttachments_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AttachmentService_MigrateAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).MigrateAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_MigrateAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).MigrateAttachments(ctx, req.(*MigrateAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_CreateUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).CreateUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_CreateUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).CreateUploadSession(ctx, req.(*CreateUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_GetUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).GetUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_GetUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).GetUploadSession(ctx, req.(*GetUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_CompleteUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).CompleteUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_CompleteUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).CompleteUploadSession(ctx, req.(*CompleteUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_DeleteUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).DeleteUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_DeleteUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).DeleteUploadSession(ctx, req.(*DeleteUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AttachmentService_ServiceDesc is the grpc.ServiceDesc for AttachmentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -318,6 +510,26 @@ var AttachmentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchDeleteAttachments",
 			Handler:    _AttachmentService_BatchDeleteAttachments_Handler,
 		},
+		{
+			MethodName: "MigrateAttachments",
+			Handler:    _AttachmentService_MigrateAttachments_Handler,
+		},
+		{
+			MethodName: "CreateUploadSession",
+			Handler:    _AttachmentService_CreateUploadSession_Handler,
+		},
+		{
+			MethodName: "GetUploadSession",
+			Handler:    _AttachmentService_GetUploadSession_Handler,
+		},
+		{
+			MethodName: "CompleteUploadSession",
+			Handler:    _AttachmentService_CompleteUploadSession_Handler,
+		},
+		{
+			MethodName: "DeleteUploadSession",
+			Handler:    _AttachmentService_DeleteUploadSession_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/attachment_service.proto",
@@ -158,6 +158,10 @@ const (
 	InstanceSetting_LOCAL InstanceSetting_StorageType = 2
 	// S3 stores attachment content in an S3-compatible object store.
 	InstanceSetting_S3 InstanceSetting_StorageType = 3
+	// WEBDAV stores attachment content on a WebDAV server.
+	InstanceSetting_WEBDAV InstanceSetting_StorageType = 4
+	// SFTP stores attachment content on an SFTP server.
+	InstanceSetting_SFTP InstanceSetting_StorageType = 5
 )
 
 // Enum value maps for InstanceSetting_StorageType.
@@ -167,12 +171,16 @@ var (
 		1: "DATABASE",
 		2: "LOCAL",
 		3: "S3",
+		4: "WEBDAV",
+		5: "SFTP",
 	}
 	InstanceSetting_StorageType_value = map[string]int32{
 		"STORAGE_TYPE_UNSPECIFIED": 0,
 		"DATABASE":                 1,
 		"LOCAL":                    2,
 		"S3":                       3,
+		"WEBDAV":                   4,
+		"SFTP":                     5,
 	}
 )
 
@@ -210,6 +218,11 @@ const (
 	InstanceSetting_AI_PROVIDER_TYPE_UNSPECIFIED InstanceSetting_AIProviderType = 0
 	InstanceSetting_OPENAI                       InstanceSetting_AIProviderType = 1
 	InstanceSetting_GEMINI                       InstanceSetting_AIProviderType = 2
+	// OPENAI_COMPATIBLE is any server implementing the OpenAI REST API,
+	// such as vLLM, LocalAI or LM Studio.
+	InstanceSetting_OPENAI_COMPATIBLE InstanceSetting_AIProviderType = 3
+	// OLLAMA is an Ollama server. It cannot be used for transcription.
+	InstanceSetting_OLLAMA InstanceSetting_AIProviderType = 4
 )
 
 // Enum value maps for InstanceSetting_AIProviderType.
@@ -218,11 +231,15 @@ var (
 		0: "AI_PROVIDER_TYPE_UNSPECIFIED",
 		1: "OPENAI",
 		2: "GEMINI",
+		3: "OPENAI_COMPATIBLE",
+		4: "OLLAMA",
 	}
 	InstanceSetting_AIProviderType_value = map[string]int32{
 		"AI_PROVIDER_TYPE_UNSPECIFIED": 0,
 		"OPENAI":                       1,
 		"GEMINI":                       2,
+		"OPENAI_COMPATIBLE":            3,
+		"OLLAMA":                       4,
 	}
 )
 
@@ -309,6 +326,163 @@ func (InstanceSetting_StorageSetting_StorageType) EnumDescriptor() ([]byte, []in
 	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 2, 0}
 }
 
+type InstanceSetting_ContentScanConfig_Scanner int32
+
+const (
+	// Scanning is disabled.
+	InstanceSetting_ContentScanConfig_SCANNER_UNSPECIFIED InstanceSetting_ContentScanConfig_Scanner = 0
+	// A ClamAV daemon scans the content.
+	InstanceSetting_ContentScanConfig_CLAMD InstanceSetting_ContentScanConfig_Scanner = 1
+	// An HTTP endpoint answering with a JSON verdict scans the content.
+	InstanceSetting_ContentScanConfig_HTTP InstanceSetting_ContentScanConfig_Scanner = 2
+)
+
+// Enum value maps for InstanceSetting_ContentScanConfig_Scanner.
+var (
+	InstanceSetting_ContentScanConfig_Scanner_name = map[int32]string{
+		0: "SCANNER_UNSPECIFIED",
+		1: "CLAMD",
+		2: "HTTP",
+	}
+	InstanceSetting_ContentScanConfig_Scanner_value = map[string]int32{
+		"SCANNER_UNSPECIFIED": 0,
+		"CLAMD":               1,
+		"HTTP":                2,
+	}
+)
+
+func (x InstanceSetting_ContentScanConfig_Scanner) Enum() *InstanceSetting_ContentScanConfig_Scanner {
+	p := new(InstanceSetting_ContentScanConfig_Scanner)
+	*p = x
+	return p
+}
+
+func (x InstanceSetting_ContentScanConfig_Scanner) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceSetting_ContentScanConfig_Scanner) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_instance_service_proto_enumTypes[5].Descriptor()
+}
+
+func (InstanceSetting_ContentScanConfig_Scanner) Type() protoreflect.EnumType {
+	return &file_api_v1_instance_service_proto_enumTypes[5]
+}
+
+func (x InstanceSetting_ContentScanConfig_Scanner) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceSetting_ContentScanConfig_Scanner.Descriptor instead.
+func (InstanceSetting_ContentScanConfig_Scanner) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 3, 0}
+}
+
+type InstanceSetting_ContentScanConfig_Action int32
+
+const (
+	// Infected uploads are rejected.
+	InstanceSetting_ContentScanConfig_ACTION_UNSPECIFIED InstanceSetting_ContentScanConfig_Action = 0
+	// Infected uploads are rejected.
+	InstanceSetting_ContentScanConfig_REJECT InstanceSetting_ContentScanConfig_Action = 1
+	// Infected uploads are stored but never served.
+	InstanceSetting_ContentScanConfig_QUARANTINE InstanceSetting_ContentScanConfig_Action = 2
+)
+
+// Enum value maps for InstanceSetting_ContentScanConfig_Action.
+var (
+	InstanceSetting_ContentScanConfig_Action_name = map[int32]string{
+		0: "ACTION_UNSPECIFIED",
+		1: "REJECT",
+		2: "QUARANTINE",
+	}
+	InstanceSetting_ContentScanConfig_Action_value = map[string]int32{
+		"ACTION_UNSPECIFIED": 0,
+		"REJECT":             1,
+		"QUARANTINE":         2,
+	}
+)
+
+func (x InstanceSetting_ContentScanConfig_Action) Enum() *InstanceSetting_ContentScanConfig_Action {
+	p := new(InstanceSetting_ContentScanConfig_Action)
+	*p = x
+	return p
+}
+
+func (x InstanceSetting_ContentScanConfig_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceSetting_ContentScanConfig_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_instance_service_proto_enumTypes[6].Descriptor()
+}
+
+func (InstanceSetting_ContentScanConfig_Action) Type() protoreflect.EnumType {
+	return &file_api_v1_instance_service_proto_enumTypes[6]
+}
+
+func (x InstanceSetting_ContentScanConfig_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceSetting_ContentScanConfig_Action.Descriptor instead.
+func (InstanceSetting_ContentScanConfig_Action) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 3, 1}
+}
+
+type InstanceSetting_ImageAnalysisConfig_Engine int32
+
+const (
+	// ENGINE_UNSPECIFIED disables image analysis.
+	InstanceSetting_ImageAnalysisConfig_ENGINE_UNSPECIFIED InstanceSetting_ImageAnalysisConfig_Engine = 0
+	// AI_PROVIDER sends images to the vision model of provider_id.
+	InstanceSetting_ImageAnalysisConfig_AI_PROVIDER InstanceSetting_ImageAnalysisConfig_Engine = 1
+	// TESSERACT runs the tesseract binary found on the server PATH.
+	// It recognizes text only and produces no caption.
+	InstanceSetting_ImageAnalysisConfig_TESSERACT InstanceSetting_ImageAnalysisConfig_Engine = 2
+)
+
+// Enum value maps for InstanceSetting_ImageAnalysisConfig_Engine.
+var (
+	InstanceSetting_ImageAnalysisConfig_Engine_name = map[int32]string{
+		0: "ENGINE_UNSPECIFIED",
+		1: "AI_PROVIDER",
+		2: "TESSERACT",
+	}
+	InstanceSetting_ImageAnalysisConfig_Engine_value = map[string]int32{
+		"ENGINE_UNSPECIFIED": 0,
+		"AI_PROVIDER":        1,
+		"TESSERACT":          2,
+	}
+)
+
+func (x InstanceSetting_ImageAnalysisConfig_Engine) Enum() *InstanceSetting_ImageAnalysisConfig_Engine {
+	p := new(InstanceSetting_ImageAnalysisConfig_Engine)
+	*p = x
+	return p
+}
+
+func (x InstanceSetting_ImageAnalysisConfig_Engine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceSetting_ImageAnalysisConfig_Engine) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_instance_service_proto_enumTypes[7].Descriptor()
+}
+
+func (InstanceSetting_ImageAnalysisConfig_Engine) Type() protoreflect.EnumType {
+	return &file_api_v1_instance_service_proto_enumTypes[7]
+}
+
+func (x InstanceSetting_ImageAnalysisConfig_Engine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceSetting_ImageAnalysisConfig_Engine.Descriptor instead.
+func (InstanceSetting_ImageAnalysisConfig_Engine) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 12, 0}
+}
+
 // Instance profile message containing basic instance information.
 type InstanceProfile struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -874,6 +1048,99 @@ func (x *TestInstanceEmailSettingRequest) GetRecipientEmail() string {
 	return ""
 }
 
+// Request message for TestAIProvider method.
+type TestAIProviderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. Provider settings to test. When api_key is empty and id matches a
+	// stored provider with the same type and endpoint, the stored API key is used.
+	Provider      *InstanceSetting_AIProviderConfig `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestAIProviderRequest) Reset() {
+	*x = TestAIProviderRequest{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestAIProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestAIProviderRequest) ProtoMessage() {}
+
+func (x *TestAIProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestAIProviderRequest.ProtoReflect.Descriptor instead.
+func (*TestAIProviderRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TestAIProviderRequest) GetProvider() *InstanceSetting_AIProviderConfig {
+	if x != nil {
+		return x.Provider
+	}
+	return nil
+}
+
+// Response message for TestAIProvider method.
+type TestAIProviderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The models served by the provider, sorted by id.
+	Models        []*TestAIProviderResponse_Model `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestAIProviderResponse) Reset() {
+	*x = TestAIProviderResponse{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestAIProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestAIProviderResponse) ProtoMessage() {}
+
+func (x *TestAIProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestAIProviderResponse.ProtoReflect.Descriptor instead.
+func (*TestAIProviderResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TestAIProviderResponse) GetModels() []*TestAIProviderResponse_Model {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
 // Request message for GetInstanceStats.
 type GetInstanceStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -883,7 +1150,7 @@ type GetInstanceStatsRequest struct {
 
 func (x *GetInstanceStatsRequest) Reset() {
 	*x = GetInstanceStatsRequest{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[8]
+	mi := &file_api_v1_instance_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -895,7 +1162,7 @@ func (x *GetInstanceStatsRequest) String() string {
 func (*GetInstanceStatsRequest) ProtoMessage() {}
 
 func (x *GetInstanceStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[8]
+	mi := &file_api_v1_instance_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -908,7 +1175,7 @@ func (x *GetInstanceStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInstanceStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetInstanceStatsRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{8}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{10}
 }
 
 // Resource usage statistics for the instance.
@@ -919,13 +1186,15 @@ type InstanceStats struct {
 	LocalStorageBytes int64 `protobuf:"varint,2,opt,name=local_storage_bytes,json=localStorageBytes,proto3" json:"local_storage_bytes,omitempty"`
 	// Server-side timestamp when the snapshot was generated.
 	GeneratedTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=generated_time,json=generatedTime,proto3" json:"generated_time,omitempty"`
+	// Attachment usage summed over all users.
+	Attachments   *InstanceStats_AttachmentStats `protobuf:"bytes,5,opt,name=attachments,proto3" json:"attachments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InstanceStats) Reset() {
 	*x = InstanceStats{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[9]
+	mi := &file_api_v1_instance_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -937,7 +1206,7 @@ func (x *InstanceStats) String() string {
 func (*InstanceStats) ProtoMessage() {}
 
 func (x *InstanceStats) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[9]
+	mi := &file_api_v1_instance_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,7 +1219,7 @@ func (x *InstanceStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceStats.ProtoReflect.Descriptor instead.
 func (*InstanceStats) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{9}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *InstanceStats) GetDatabase() *InstanceStats_DatabaseStats {
@@ -974,6 +1243,105 @@ func (x *InstanceStats) GetGeneratedTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *InstanceStats) GetAttachments() *InstanceStats_AttachmentStats {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+// Request message for ListStorageConsumers.
+type ListStorageConsumersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. The maximum number of users to return. Defaults to 10, at most 100.
+	PageSize      int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStorageConsumersRequest) Reset() {
+	*x = ListStorageConsumersRequest{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStorageConsumersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStorageConsumersRequest) ProtoMessage() {}
+
+func (x *ListStorageConsumersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStorageConsumersRequest.ProtoReflect.Descriptor instead.
+func (*ListStorageConsumersRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListStorageConsumersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// Response message for ListStorageConsumers.
+type ListStorageConsumersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Users ordered by attachment size, largest first.
+	Consumers     []*StorageUsage `protobuf:"bytes,1,rep,name=consumers,proto3" json:"consumers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStorageConsumersResponse) Reset() {
+	*x = ListStorageConsumersResponse{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStorageConsumersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStorageConsumersResponse) ProtoMessage() {}
+
+func (x *ListStorageConsumersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStorageConsumersResponse.ProtoReflect.Descriptor instead.
+func (*ListStorageConsumersResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListStorageConsumersResponse) GetConsumers() []*StorageUsage {
+	if x != nil {
+		return x.Consumers
+	}
+	return nil
+}
+
 // General instance settings configuration.
 type InstanceSetting_GeneralSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1001,7 +1369,7 @@ type InstanceSetting_GeneralSetting struct {
 
 func (x *InstanceSetting_GeneralSetting) Reset() {
 	*x = InstanceSetting_GeneralSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[10]
+	mi := &file_api_v1_instance_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1013,7 +1381,7 @@ func (x *InstanceSetting_GeneralSetting) String() string {
 func (*InstanceSetting_GeneralSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_GeneralSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[10]
+	mi := &file_api_v1_instance_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1096,6 +1464,9 @@ type InstanceSetting_Storage struct {
 	// Types that are valid to be assigned to Config:
 	//
 	//	*InstanceSetting_Storage_S3Config_
+	//	*InstanceSetting_Storage_LocalConfig_
+	//	*InstanceSetting_Storage_WebdavConfig
+	//	*InstanceSetting_Storage_SftpConfig
 	Config        isInstanceSetting_Storage_Config `protobuf_oneof:"config"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1103,7 +1474,7 @@ type InstanceSetting_Storage struct {
 
 func (x *InstanceSetting_Storage) Reset() {
 	*x = InstanceSetting_Storage{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[11]
+	mi := &file_api_v1_instance_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1115,7 +1486,7 @@ func (x *InstanceSetting_Storage) String() string {
 func (*InstanceSetting_Storage) ProtoMessage() {}
 
 func (x *InstanceSetting_Storage) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[11]
+	mi := &file_api_v1_instance_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1168,6 +1539,33 @@ func (x *InstanceSetting_Storage) GetS3Config() *InstanceSetting_Storage_S3Confi
 	return nil
 }
 
+func (x *InstanceSetting_Storage) GetLocalConfig() *InstanceSetting_Storage_LocalConfig {
+	if x != nil {
+		if x, ok := x.Config.(*InstanceSetting_Storage_LocalConfig_); ok {
+			return x.LocalConfig
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting_Storage) GetWebdavConfig() *InstanceSetting_Storage_WebDAVConfig {
+	if x != nil {
+		if x, ok := x.Config.(*InstanceSetting_Storage_WebdavConfig); ok {
+			return x.WebdavConfig
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting_Storage) GetSftpConfig() *InstanceSetting_Storage_SFTPConfig {
+	if x != nil {
+		if x, ok := x.Config.(*InstanceSetting_Storage_SftpConfig); ok {
+			return x.SftpConfig
+		}
+	}
+	return nil
+}
+
 type isInstanceSetting_Storage_Config interface {
 	isInstanceSetting_Storage_Config()
 }
@@ -1176,8 +1574,26 @@ type InstanceSetting_Storage_S3Config_ struct {
 	S3Config *InstanceSetting_Storage_S3Config `protobuf:"bytes,10,opt,name=s3_config,json=s3Config,proto3,oneof"`
 }
 
+type InstanceSetting_Storage_LocalConfig_ struct {
+	LocalConfig *InstanceSetting_Storage_LocalConfig `protobuf:"bytes,11,opt,name=local_config,json=localConfig,proto3,oneof"`
+}
+
+type InstanceSetting_Storage_WebdavConfig struct {
+	WebdavConfig *InstanceSetting_Storage_WebDAVConfig `protobuf:"bytes,12,opt,name=webdav_config,json=webdavConfig,proto3,oneof"`
+}
+
+type InstanceSetting_Storage_SftpConfig struct {
+	SftpConfig *InstanceSetting_Storage_SFTPConfig `protobuf:"bytes,13,opt,name=sftp_config,json=sftpConfig,proto3,oneof"`
+}
+
 func (*InstanceSetting_Storage_S3Config_) isInstanceSetting_Storage_Config() {}
 
+func (*InstanceSetting_Storage_LocalConfig_) isInstanceSetting_Storage_Config() {}
+
+func (*InstanceSetting_Storage_WebdavConfig) isInstanceSetting_Storage_Config() {}
+
+func (*InstanceSetting_Storage_SftpConfig) isInstanceSetting_Storage_Config() {}
+
 // Storage configuration settings for instance attachments.
 type InstanceSetting_StorageSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1194,13 +1610,26 @@ type InstanceSetting_StorageSetting struct {
 	Storages []*InstanceSetting_Storage `protobuf:"bytes,5,rep,name=storages,proto3" json:"storages,omitempty"`
 	// Storage used for new attachments.
 	DefaultStorageId string `protobuf:"bytes,6,opt,name=default_storage_id,json=defaultStorageId,proto3" json:"default_storage_id,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
-}
+	// Total attachment size allowed per user.
+	Quota *InstanceSetting_StorageQuota `protobuf:"bytes,7,opt,name=quota,proto3" json:"quota,omitempty"`
+	// MIME types new attachments are restricted to, when not empty.
+	// Entries are exact types or wildcards such as "image/*".
+	AllowedMimeTypes []string `protobuf:"bytes,8,rep,name=allowed_mime_types,json=allowedMimeTypes,proto3" json:"allowed_mime_types,omitempty"`
+	// MIME types rejected for new attachments; takes precedence over allowed_mime_types.
+	BlockedMimeTypes []string `protobuf:"bytes,9,rep,name=blocked_mime_types,json=blockedMimeTypes,proto3" json:"blocked_mime_types,omitempty"`
+	// Total size of cached image variants in megabytes; zero uses the default of 1024.
+	VariantCacheSizeMb int64 `protobuf:"varint,10,opt,name=variant_cache_size_mb,json=variantCacheSizeMb,proto3" json:"variant_cache_size_mb,omitempty"`
+	// Days an unused image variant stays cached; zero uses the default of 30.
+	VariantCacheMaxAgeDays int32 `protobuf:"varint,11,opt,name=variant_cache_max_age_days,json=variantCacheMaxAgeDays,proto3" json:"variant_cache_max_age_days,omitempty"`
+	// Malware scanning of new attachments.
+	ContentScan   *InstanceSetting_ContentScanConfig `protobuf:"bytes,12,opt,name=content_scan,json=contentScan,proto3" json:"content_scan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
 func (x *InstanceSetting_StorageSetting) Reset() {
 	*x = InstanceSetting_StorageSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[12]
+	mi := &file_api_v1_instance_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1212,7 +1641,7 @@ func (x *InstanceSetting_StorageSetting) String() string {
 func (*InstanceSetting_StorageSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_StorageSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[12]
+	mi := &file_api_v1_instance_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1270,6 +1699,211 @@ func (x *InstanceSetting_StorageSetting) GetDefaultStorageId() string {
 	return ""
 }
 
+func (x *InstanceSetting_StorageSetting) GetQuota() *InstanceSetting_StorageQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *InstanceSetting_StorageSetting) GetAllowedMimeTypes() []string {
+	if x != nil {
+		return x.AllowedMimeTypes
+	}
+	return nil
+}
+
+func (x *InstanceSetting_StorageSetting) GetBlockedMimeTypes() []string {
+	if x != nil {
+		return x.BlockedMimeTypes
+	}
+	return nil
+}
+
+func (x *InstanceSetting_StorageSetting) GetVariantCacheSizeMb() int64 {
+	if x != nil {
+		return x.VariantCacheSizeMb
+	}
+	return 0
+}
+
+func (x *InstanceSetting_StorageSetting) GetVariantCacheMaxAgeDays() int32 {
+	if x != nil {
+		return x.VariantCacheMaxAgeDays
+	}
+	return 0
+}
+
+func (x *InstanceSetting_StorageSetting) GetContentScan() *InstanceSetting_ContentScanConfig {
+	if x != nil {
+		return x.ContentScan
+	}
+	return nil
+}
+
+// Malware scanning of uploaded attachments.
+type InstanceSetting_ContentScanConfig struct {
+	state   protoimpl.MessageState                    `protogen:"open.v1"`
+	Scanner InstanceSetting_ContentScanConfig_Scanner `protobuf:"varint,1,opt,name=scanner,proto3,enum=memos.api.v1.InstanceSetting_ContentScanConfig_Scanner" json:"scanner,omitempty"`
+	// Address of the ClamAV daemon: "host:port", or "unix:" followed by a socket path.
+	ClamdAddress string `protobuf:"bytes,2,opt,name=clamd_address,json=clamdAddress,proto3" json:"clamd_address,omitempty"`
+	// Endpoint receiving the content in a POST request.
+	HttpEndpoint string `protobuf:"bytes,3,opt,name=http_endpoint,json=httpEndpoint,proto3" json:"http_endpoint,omitempty"`
+	// Bearer token sent to http_endpoint. Leave empty to keep the current token.
+	HttpToken string `protobuf:"bytes,4,opt,name=http_token,json=httpToken,proto3" json:"http_token,omitempty"`
+	// What happens to infected uploads.
+	InfectedAction InstanceSetting_ContentScanConfig_Action `protobuf:"varint,5,opt,name=infected_action,json=infectedAction,proto3,enum=memos.api.v1.InstanceSetting_ContentScanConfig_Action" json:"infected_action,omitempty"`
+	// Accept uploads when the scanner fails, recording the scan as failed.
+	FailOpen bool `protobuf:"varint,6,opt,name=fail_open,json=failOpen,proto3" json:"fail_open,omitempty"`
+	// Seconds one scan may take; zero uses the default of 30.
+	TimeoutSeconds int32 `protobuf:"varint,7,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_ContentScanConfig) Reset() {
+	*x = InstanceSetting_ContentScanConfig{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_ContentScanConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_ContentScanConfig) ProtoMessage() {}
+
+func (x *InstanceSetting_ContentScanConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_ContentScanConfig.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_ContentScanConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *InstanceSetting_ContentScanConfig) GetScanner() InstanceSetting_ContentScanConfig_Scanner {
+	if x != nil {
+		return x.Scanner
+	}
+	return InstanceSetting_ContentScanConfig_SCANNER_UNSPECIFIED
+}
+
+func (x *InstanceSetting_ContentScanConfig) GetClamdAddress() string {
+	if x != nil {
+		return x.ClamdAddress
+	}
+	return ""
+}
+
+func (x *InstanceSetting_ContentScanConfig) GetHttpEndpoint() string {
+	if x != nil {
+		return x.HttpEndpoint
+	}
+	return ""
+}
+
+func (x *InstanceSetting_ContentScanConfig) GetHttpToken() string {
+	if x != nil {
+		return x.HttpToken
+	}
+	return ""
+}
+
+func (x *InstanceSetting_ContentScanConfig) GetInfectedAction() InstanceSetting_ContentScanConfig_Action {
+	if x != nil {
+		return x.InfectedAction
+	}
+	return InstanceSetting_ContentScanConfig_ACTION_UNSPECIFIED
+}
+
+func (x *InstanceSetting_ContentScanConfig) GetFailOpen() bool {
+	if x != nil {
+		return x.FailOpen
+	}
+	return false
+}
+
+func (x *InstanceSetting_ContentScanConfig) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+// Total attachment size allowed per user, in megabytes. Zero means unlimited.
+type InstanceSetting_StorageQuota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Quota of users with the USER role.
+	UserQuotaMb int64 `protobuf:"varint,1,opt,name=user_quota_mb,json=userQuotaMb,proto3" json:"user_quota_mb,omitempty"`
+	// Quota of users with the ADMIN role.
+	AdminQuotaMb int64 `protobuf:"varint,2,opt,name=admin_quota_mb,json=adminQuotaMb,proto3" json:"admin_quota_mb,omitempty"`
+	// Quotas replacing the role quota for individual users.
+	UserOverrides []*InstanceSetting_StorageQuota_UserOverride `protobuf:"bytes,3,rep,name=user_overrides,json=userOverrides,proto3" json:"user_overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_StorageQuota) Reset() {
+	*x = InstanceSetting_StorageQuota{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_StorageQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_StorageQuota) ProtoMessage() {}
+
+func (x *InstanceSetting_StorageQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_StorageQuota.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_StorageQuota) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 4}
+}
+
+func (x *InstanceSetting_StorageQuota) GetUserQuotaMb() int64 {
+	if x != nil {
+		return x.UserQuotaMb
+	}
+	return 0
+}
+
+func (x *InstanceSetting_StorageQuota) GetAdminQuotaMb() int64 {
+	if x != nil {
+		return x.AdminQuotaMb
+	}
+	return 0
+}
+
+func (x *InstanceSetting_StorageQuota) GetUserOverrides() []*InstanceSetting_StorageQuota_UserOverride {
+	if x != nil {
+		return x.UserOverrides
+	}
+	return nil
+}
+
 // Memo-related instance settings and policies.
 type InstanceSetting_MemoRelatedSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1285,7 +1919,7 @@ type InstanceSetting_MemoRelatedSetting struct {
 
 func (x *InstanceSetting_MemoRelatedSetting) Reset() {
 	*x = InstanceSetting_MemoRelatedSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[13]
+	mi := &file_api_v1_instance_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1297,7 +1931,7 @@ func (x *InstanceSetting_MemoRelatedSetting) String() string {
 func (*InstanceSetting_MemoRelatedSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_MemoRelatedSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[13]
+	mi := &file_api_v1_instance_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1310,7 +1944,7 @@ func (x *InstanceSetting_MemoRelatedSetting) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use InstanceSetting_MemoRelatedSetting.ProtoReflect.Descriptor instead.
 func (*InstanceSetting_MemoRelatedSetting) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 3}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 5}
 }
 
 func (x *InstanceSetting_MemoRelatedSetting) GetContentLengthLimit() int32 {
@@ -1348,7 +1982,7 @@ type InstanceSetting_TagMetadata struct {
 
 func (x *InstanceSetting_TagMetadata) Reset() {
 	*x = InstanceSetting_TagMetadata{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[14]
+	mi := &file_api_v1_instance_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1360,7 +1994,7 @@ func (x *InstanceSetting_TagMetadata) String() string {
 func (*InstanceSetting_TagMetadata) ProtoMessage() {}
 
 func (x *InstanceSetting_TagMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[14]
+	mi := &file_api_v1_instance_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1373,7 +2007,7 @@ func (x *InstanceSetting_TagMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceSetting_TagMetadata.ProtoReflect.Descriptor instead.
 func (*InstanceSetting_TagMetadata) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 4}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 6}
 }
 
 func (x *InstanceSetting_TagMetadata) GetBackgroundColor() *color.Color {
@@ -1406,7 +2040,7 @@ type InstanceSetting_TagsSetting struct {
 
 func (x *InstanceSetting_TagsSetting) Reset() {
 	*x = InstanceSetting_TagsSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[15]
+	mi := &file_api_v1_instance_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1418,7 +2052,7 @@ func (x *InstanceSetting_TagsSetting) String() string {
 func (*InstanceSetting_TagsSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_TagsSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[15]
+	mi := &file_api_v1_instance_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1431,7 +2065,7 @@ func (x *InstanceSetting_TagsSetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceSetting_TagsSetting.ProtoReflect.Descriptor instead.
 func (*InstanceSetting_TagsSetting) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 5}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 7}
 }
 
 func (x *InstanceSetting_TagsSetting) GetTags() map[string]*InstanceSetting_TagMetadata {
@@ -1451,7 +2085,7 @@ type InstanceSetting_NotificationSetting struct {
 
 func (x *InstanceSetting_NotificationSetting) Reset() {
 	*x = InstanceSetting_NotificationSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[16]
+	mi := &file_api_v1_instance_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1463,7 +2097,7 @@ func (x *InstanceSetting_NotificationSetting) String() string {
 func (*InstanceSetting_NotificationSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_NotificationSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[16]
+	mi := &file_api_v1_instance_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1476,7 +2110,7 @@ func (x *InstanceSetting_NotificationSetting) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use InstanceSetting_NotificationSetting.ProtoReflect.Descriptor instead.
 func (*InstanceSetting_NotificationSetting) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 6}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 8}
 }
 
 func (x *InstanceSetting_NotificationSetting) GetEmail() *InstanceSetting_NotificationSetting_EmailSetting {
@@ -1494,13 +2128,16 @@ type InstanceSetting_AISetting struct {
 	// transcription is the speech-to-text feature configuration.
 	// When unset or transcription.provider_id is empty, transcription is disabled.
 	Transcription *InstanceSetting_TranscriptionConfig `protobuf:"bytes,2,opt,name=transcription,proto3" json:"transcription,omitempty"`
+	// image_analysis is the OCR and captioning configuration for image attachments.
+	// When unset or image_analysis.engine is ENGINE_UNSPECIFIED, analysis is disabled.
+	ImageAnalysis *InstanceSetting_ImageAnalysisConfig `protobuf:"bytes,3,opt,name=image_analysis,json=imageAnalysis,proto3" json:"image_analysis,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InstanceSetting_AISetting) Reset() {
 	*x = InstanceSetting_AISetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[17]
+	mi := &file_api_v1_instance_service_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1512,7 +2149,7 @@ func (x *InstanceSetting_AISetting) String() string {
 func (*InstanceSetting_AISetting) ProtoMessage() {}
 
 func (x *InstanceSetting_AISetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[17]
+	mi := &file_api_v1_instance_service_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1525,7 +2162,7 @@ func (x *InstanceSetting_AISetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceSetting_AISetting.ProtoReflect.Descriptor instead.
 func (*InstanceSetting_AISetting) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 7}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 9}
 }
 
 func (x *InstanceSetting_AISetting) GetProviders() []*InstanceSetting_AIProviderConfig {
@@ -1542,15 +2179,32 @@ func (x *InstanceSetting_AISetting) GetTranscription() *InstanceSetting_Transcri
 	return nil
 }
 
+func (x *InstanceSetting_AISetting) GetImageAnalysis() *InstanceSetting_ImageAnalysisConfig {
+	if x != nil {
+		return x.ImageAnalysis
+	}
+	return nil
+}
+
 // AIProviderConfig represents one callable AI provider connection.
 type InstanceSetting_AIProviderConfig struct {
-	state    protoimpl.MessageState         `protogen:"open.v1"`
-	Id       string                         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title    string                         `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Type     InstanceSetting_AIProviderType `protobuf:"varint,3,opt,name=type,proto3,enum=memos.api.v1.InstanceSetting_AIProviderType" json:"type,omitempty"`
-	Endpoint string                         `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	state protoimpl.MessageState         `protogen:"open.v1"`
+	Id    string                         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                         `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Type  InstanceSetting_AIProviderType `protobuf:"varint,3,opt,name=type,proto3,enum=memos.api.v1.InstanceSetting_AIProviderType" json:"type,omitempty"`
+	// endpoint is the provider base URL. Required for OPENAI_COMPATIBLE;
+	// defaults to the public API for OPENAI and GEMINI and to
+	// http://localhost:11434 for OLLAMA.
+	Endpoint string `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
 	// api_key is write-only and is never returned by GetInstanceSetting.
+	// Optional for OPENAI_COMPATIBLE and OLLAMA providers.
 	ApiKey string `protobuf:"bytes,5,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// timeout_seconds bounds a single request to the provider.
+	// Zero uses the built-in default.
+	TimeoutSeconds int32 `protobuf:"varint,6,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// max_concurrent_requests caps in-flight requests to the provider.
+	// Zero means unlimited.
+	MaxConcurrentRequests int32 `protobuf:"varint,7,opt,name=max_concurrent_requests,json=maxConcurrentRequests,proto3" json:"max_concurrent_requests,omitempty"`
 	// api_key_set indicates whether an API key is stored for this provider.
 	ApiKeySet bool `protobuf:"varint,8,opt,name=api_key_set,json=apiKeySet,proto3" json:"api_key_set,omitempty"`
 	// api_key_hint is a masked hint for the stored API key.
@@ -1561,7 +2215,7 @@ type InstanceSetting_AIProviderConfig struct {
 
 func (x *InstanceSetting_AIProviderConfig) Reset() {
 	*x = InstanceSetting_AIProviderConfig{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[18]
+	mi := &file_api_v1_instance_service_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1573,7 +2227,7 @@ func (x *InstanceSetting_AIProviderConfig) String() string {
 func (*InstanceSetting_AIProviderConfig) ProtoMessage() {}
 
 func (x *InstanceSetting_AIProviderConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[18]
+	mi := &file_api_v1_instance_service_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1586,7 +2240,7 @@ func (x *InstanceSetting_AIProviderConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceSetting_AIProviderConfig.ProtoReflect.Descriptor instead.
 func (*InstanceSetting_AIProviderConfig) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 8}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 10}
 }
 
 func (x *InstanceSetting_AIProviderConfig) GetId() string {
@@ -1624,6 +2278,20 @@ func (x *InstanceSetting_AIProviderConfig) GetApiKey() string {
 	return ""
 }
 
+func (x *InstanceSetting_AIProviderConfig) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *InstanceSetting_AIProviderConfig) GetMaxConcurrentRequests() int32 {
+	if x != nil {
+		return x.MaxConcurrentRequests
+	}
+	return 0
+}
+
 func (x *InstanceSetting_AIProviderConfig) GetApiKeySet() bool {
 	if x != nil {
 		return x.ApiKeySet
@@ -1652,14 +2320,17 @@ type InstanceSetting_TranscriptionConfig struct {
 	// Empty string lets the provider auto-detect.
 	Language string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
 	// prompt is a default spelling/vocabulary hint passed to the provider.
-	Prompt        string `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Prompt string `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	// auto_transcribe_attachments transcribes uploaded audio attachments in the
+	// background and stores the transcript on the attachment.
+	AutoTranscribeAttachments bool `protobuf:"varint,5,opt,name=auto_transcribe_attachments,json=autoTranscribeAttachments,proto3" json:"auto_transcribe_attachments,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
 }
 
 func (x *InstanceSetting_TranscriptionConfig) Reset() {
 	*x = InstanceSetting_TranscriptionConfig{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[19]
+	mi := &file_api_v1_instance_service_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1671,7 +2342,7 @@ func (x *InstanceSetting_TranscriptionConfig) String() string {
 func (*InstanceSetting_TranscriptionConfig) ProtoMessage() {}
 
 func (x *InstanceSetting_TranscriptionConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[19]
+	mi := &file_api_v1_instance_service_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1684,7 +2355,7 @@ func (x *InstanceSetting_TranscriptionConfig) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use InstanceSetting_TranscriptionConfig.ProtoReflect.Descriptor instead.
 func (*InstanceSetting_TranscriptionConfig) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 9}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 11}
 }
 
 func (x *InstanceSetting_TranscriptionConfig) GetProviderId() string {
@@ -1715,6 +2386,89 @@ func (x *InstanceSetting_TranscriptionConfig) GetPrompt() string {
 	return ""
 }
 
+func (x *InstanceSetting_TranscriptionConfig) GetAutoTranscribeAttachments() bool {
+	if x != nil {
+		return x.AutoTranscribeAttachments
+	}
+	return false
+}
+
+// ImageAnalysisConfig configures OCR and captioning of uploaded images.
+type InstanceSetting_ImageAnalysisConfig struct {
+	state  protoimpl.MessageState                     `protogen:"open.v1"`
+	Engine InstanceSetting_ImageAnalysisConfig_Engine `protobuf:"varint,1,opt,name=engine,proto3,enum=memos.api.v1.InstanceSetting_ImageAnalysisConfig_Engine" json:"engine,omitempty"`
+	// provider_id references an entry in AISetting.providers[].id.
+	// Required when engine is AI_PROVIDER.
+	ProviderId string `protobuf:"bytes,2,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	// model is the vision model identifier.
+	// Empty string falls back to the provider default
+	// (gpt-4o-mini for OPENAI providers, gemini-2.5-flash for GEMINI providers).
+	Model string `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	// language is an optional language hint. AI providers take an ISO 639-1
+	// code; TESSERACT takes its own language codes, e.g. "eng+deu".
+	Language      string `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_ImageAnalysisConfig) Reset() {
+	*x = InstanceSetting_ImageAnalysisConfig{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_ImageAnalysisConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_ImageAnalysisConfig) ProtoMessage() {}
+
+func (x *InstanceSetting_ImageAnalysisConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_ImageAnalysisConfig.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_ImageAnalysisConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 12}
+}
+
+func (x *InstanceSetting_ImageAnalysisConfig) GetEngine() InstanceSetting_ImageAnalysisConfig_Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return InstanceSetting_ImageAnalysisConfig_ENGINE_UNSPECIFIED
+}
+
+func (x *InstanceSetting_ImageAnalysisConfig) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *InstanceSetting_ImageAnalysisConfig) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *InstanceSetting_ImageAnalysisConfig) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 // Access policy configuration for the instance.
 type InstanceSetting_AccessSetting struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1725,7 +2479,7 @@ type InstanceSetting_AccessSetting struct {
 
 func (x *InstanceSetting_AccessSetting) Reset() {
 	*x = InstanceSetting_AccessSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[20]
+	mi := &file_api_v1_instance_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1737,7 +2491,7 @@ func (x *InstanceSetting_AccessSetting) String() string {
 func (*InstanceSetting_AccessSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_AccessSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[20]
+	mi := &file_api_v1_instance_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1750,7 +2504,7 @@ func (x *InstanceSetting_AccessSetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceSetting_AccessSetting.ProtoReflect.Descriptor instead.
 func (*InstanceSetting_AccessSetting) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 10}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 13}
 }
 
 func (x *InstanceSetting_AccessSetting) GetAccessMode() InstanceAccessMode {
@@ -1772,7 +2526,7 @@ type InstanceSetting_GeneralSetting_CustomProfile struct {
 
 func (x *InstanceSetting_GeneralSetting_CustomProfile) Reset() {
 	*x = InstanceSetting_GeneralSetting_CustomProfile{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[21]
+	mi := &file_api_v1_instance_service_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1784,7 +2538,7 @@ func (x *InstanceSetting_GeneralSetting_CustomProfile) String() string {
 func (*InstanceSetting_GeneralSetting_CustomProfile) ProtoMessage() {}
 
 func (x *InstanceSetting_GeneralSetting_CustomProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[21]
+	mi := &file_api_v1_instance_service_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1835,13 +2589,16 @@ type InstanceSetting_Storage_S3Config struct {
 	// to the S3 endpoint. Only enable this for trusted endpoints that use a self-signed
 	// certificate; it removes protection against man-in-the-middle attacks.
 	InsecureSkipTlsVerify bool `protobuf:"varint,7,opt,name=insecure_skip_tls_verify,json=insecureSkipTlsVerify,proto3" json:"insecure_skip_tls_verify,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// presigned_transfers lets clients upload directly to the bucket with presigned
+	// PUT URLs and redirects downloads to short-lived presigned GET URLs.
+	PresignedTransfers bool `protobuf:"varint,8,opt,name=presigned_transfers,json=presignedTransfers,proto3" json:"presigned_transfers,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *InstanceSetting_Storage_S3Config) Reset() {
 	*x = InstanceSetting_Storage_S3Config{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[22]
+	mi := &file_api_v1_instance_service_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1853,7 +2610,7 @@ func (x *InstanceSetting_Storage_S3Config) String() string {
 func (*InstanceSetting_Storage_S3Config) ProtoMessage() {}
 
 func (x *InstanceSetting_Storage_S3Config) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[22]
+	mi := &file_api_v1_instance_service_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1918,6 +2675,239 @@ func (x *InstanceSetting_Storage_S3Config) GetInsecureSkipTlsVerify() bool {
 	return false
 }
 
+func (x *InstanceSetting_Storage_S3Config) GetPresignedTransfers() bool {
+	if x != nil {
+		return x.PresignedTransfers
+	}
+	return false
+}
+
+// Local configuration for a directory on the server file system.
+type InstanceSetting_Storage_LocalConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// path is the directory attachments are written to, such as a mounted NAS
+	// share. Empty means the data directory.
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_Storage_LocalConfig) Reset() {
+	*x = InstanceSetting_Storage_LocalConfig{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_Storage_LocalConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_Storage_LocalConfig) ProtoMessage() {}
+
+func (x *InstanceSetting_Storage_LocalConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_Storage_LocalConfig.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_Storage_LocalConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 1, 1}
+}
+
+func (x *InstanceSetting_Storage_LocalConfig) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// WebDAV configuration for a collection on a WebDAV server.
+type InstanceSetting_Storage_WebDAVConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// endpoint is the URL of the collection attachments are written to.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// insecure_skip_tls_verify disables TLS certificate verification. Only enable
+	// this for trusted servers that use a self-signed certificate.
+	InsecureSkipTlsVerify bool `protobuf:"varint,4,opt,name=insecure_skip_tls_verify,json=insecureSkipTlsVerify,proto3" json:"insecure_skip_tls_verify,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_Storage_WebDAVConfig) Reset() {
+	*x = InstanceSetting_Storage_WebDAVConfig{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_Storage_WebDAVConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_Storage_WebDAVConfig) ProtoMessage() {}
+
+func (x *InstanceSetting_Storage_WebDAVConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_Storage_WebDAVConfig.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_Storage_WebDAVConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 1, 2}
+}
+
+func (x *InstanceSetting_Storage_WebDAVConfig) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *InstanceSetting_Storage_WebDAVConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *InstanceSetting_Storage_WebDAVConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *InstanceSetting_Storage_WebDAVConfig) GetInsecureSkipTlsVerify() bool {
+	if x != nil {
+		return x.InsecureSkipTlsVerify
+	}
+	return false
+}
+
+// SFTP configuration for a directory on an SFTP server.
+type InstanceSetting_Storage_SFTPConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Host  string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// port defaults to 22.
+	Port     int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	// private_key is a PEM-encoded key and takes precedence over password.
+	PrivateKey string `protobuf:"bytes,5,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	// host_key is the server public key in authorized_keys format.
+	HostKey string `protobuf:"bytes,6,opt,name=host_key,json=hostKey,proto3" json:"host_key,omitempty"`
+	// path is the directory attachments are written to.
+	Path string `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	// insecure_ignore_host_key skips server verification when no host_key is
+	// configured. It removes protection against man-in-the-middle attacks.
+	InsecureIgnoreHostKey bool `protobuf:"varint,8,opt,name=insecure_ignore_host_key,json=insecureIgnoreHostKey,proto3" json:"insecure_ignore_host_key,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) Reset() {
+	*x = InstanceSetting_Storage_SFTPConfig{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_Storage_SFTPConfig) ProtoMessage() {}
+
+func (x *InstanceSetting_Storage_SFTPConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_Storage_SFTPConfig.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_Storage_SFTPConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 1, 3}
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) GetHostKey() string {
+	if x != nil {
+		return x.HostKey
+	}
+	return ""
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *InstanceSetting_Storage_SFTPConfig) GetInsecureIgnoreHostKey() bool {
+	if x != nil {
+		return x.InsecureIgnoreHostKey
+	}
+	return false
+}
+
 // Legacy S3 configuration retained for compatibility with existing clients.
 // Reference: https://developers.cloudflare.com/r2/examples/aws/aws-sdk-go/
 type InstanceSetting_StorageSetting_S3Config struct {
@@ -1932,13 +2922,16 @@ type InstanceSetting_StorageSetting_S3Config struct {
 	// to the S3 endpoint. Only enable this for trusted endpoints that use a self-signed
 	// certificate; it removes protection against man-in-the-middle attacks.
 	InsecureSkipTlsVerify bool `protobuf:"varint,7,opt,name=insecure_skip_tls_verify,json=insecureSkipTlsVerify,proto3" json:"insecure_skip_tls_verify,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// presigned_transfers lets clients upload directly to the bucket with presigned
+	// PUT URLs and redirects downloads to short-lived presigned GET URLs.
+	PresignedTransfers bool `protobuf:"varint,8,opt,name=presigned_transfers,json=presignedTransfers,proto3" json:"presigned_transfers,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *InstanceSetting_StorageSetting_S3Config) Reset() {
 	*x = InstanceSetting_StorageSetting_S3Config{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[23]
+	mi := &file_api_v1_instance_service_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1950,7 +2943,7 @@ func (x *InstanceSetting_StorageSetting_S3Config) String() string {
 func (*InstanceSetting_StorageSetting_S3Config) ProtoMessage() {}
 
 func (x *InstanceSetting_StorageSetting_S3Config) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[23]
+	mi := &file_api_v1_instance_service_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2015,6 +3008,68 @@ func (x *InstanceSetting_StorageSetting_S3Config) GetInsecureSkipTlsVerify() boo
 	return false
 }
 
+func (x *InstanceSetting_StorageSetting_S3Config) GetPresignedTransfers() bool {
+	if x != nil {
+		return x.PresignedTransfers
+	}
+	return false
+}
+
+type InstanceSetting_StorageQuota_UserOverride struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The user the quota applies to.
+	// Format: users/{user}
+	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// The quota in megabytes; zero exempts the user from any quota.
+	QuotaMb       int64 `protobuf:"varint,2,opt,name=quota_mb,json=quotaMb,proto3" json:"quota_mb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_StorageQuota_UserOverride) Reset() {
+	*x = InstanceSetting_StorageQuota_UserOverride{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_StorageQuota_UserOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_StorageQuota_UserOverride) ProtoMessage() {}
+
+func (x *InstanceSetting_StorageQuota_UserOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_StorageQuota_UserOverride.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_StorageQuota_UserOverride) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 4, 0}
+}
+
+func (x *InstanceSetting_StorageQuota_UserOverride) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *InstanceSetting_StorageQuota_UserOverride) GetQuotaMb() int64 {
+	if x != nil {
+		return x.QuotaMb
+	}
+	return 0
+}
+
 // Email delivery configuration for notifications.
 type InstanceSetting_NotificationSetting_EmailSetting struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2034,7 +3089,7 @@ type InstanceSetting_NotificationSetting_EmailSetting struct {
 
 func (x *InstanceSetting_NotificationSetting_EmailSetting) Reset() {
 	*x = InstanceSetting_NotificationSetting_EmailSetting{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[25]
+	mi := &file_api_v1_instance_service_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2046,7 +3101,7 @@ func (x *InstanceSetting_NotificationSetting_EmailSetting) String() string {
 func (*InstanceSetting_NotificationSetting_EmailSetting) ProtoMessage() {}
 
 func (x *InstanceSetting_NotificationSetting_EmailSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[25]
+	mi := &file_api_v1_instance_service_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2059,7 +3114,7 @@ func (x *InstanceSetting_NotificationSetting_EmailSetting) ProtoReflect() protor
 
 // Deprecated: Use InstanceSetting_NotificationSetting_EmailSetting.ProtoReflect.Descriptor instead.
 func (*InstanceSetting_NotificationSetting_EmailSetting) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 6, 0}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2, 8, 0}
 }
 
 func (x *InstanceSetting_NotificationSetting_EmailSetting) GetEnabled() bool {
@@ -2132,6 +3187,61 @@ func (x *InstanceSetting_NotificationSetting_EmailSetting) GetUseSsl() bool {
 	return false
 }
 
+// Model describes one model served by the provider.
+type TestAIProviderResponse_Model struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The identifier to use as a model in AI settings.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The human-readable name, if the provider returned one.
+	DisplayName   string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestAIProviderResponse_Model) Reset() {
+	*x = TestAIProviderResponse_Model{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestAIProviderResponse_Model) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestAIProviderResponse_Model) ProtoMessage() {}
+
+func (x *TestAIProviderResponse_Model) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestAIProviderResponse_Model.ProtoReflect.Descriptor instead.
+func (*TestAIProviderResponse_Model) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *TestAIProviderResponse_Model) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TestAIProviderResponse_Model) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
 // Database size statistics.
 type InstanceStats_DatabaseStats struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2145,7 +3255,7 @@ type InstanceStats_DatabaseStats struct {
 
 func (x *InstanceStats_DatabaseStats) Reset() {
 	*x = InstanceStats_DatabaseStats{}
-	mi := &file_api_v1_instance_service_proto_msgTypes[26]
+	mi := &file_api_v1_instance_service_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2157,7 +3267,7 @@ func (x *InstanceStats_DatabaseStats) String() string {
 func (*InstanceStats_DatabaseStats) ProtoMessage() {}
 
 func (x *InstanceStats_DatabaseStats) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_instance_service_proto_msgTypes[26]
+	mi := &file_api_v1_instance_service_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2170,7 +3280,7 @@ func (x *InstanceStats_DatabaseStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceStats_DatabaseStats.ProtoReflect.Descriptor instead.
 func (*InstanceStats_DatabaseStats) Descriptor() ([]byte, []int) {
-	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{9, 0}
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{11, 0}
 }
 
 func (x *InstanceStats_DatabaseStats) GetDriver() string {
@@ -2187,6 +3297,62 @@ func (x *InstanceStats_DatabaseStats) GetSizeBytes() int64 {
 	return 0
 }
 
+// Attachment usage statistics.
+type InstanceStats_AttachmentStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count is the number of attachments; -1 if unavailable.
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// size_bytes is the total attachment size in bytes; -1 if unavailable.
+	// Deduplicated content counts once per attachment.
+	SizeBytes     int64 `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceStats_AttachmentStats) Reset() {
+	*x = InstanceStats_AttachmentStats{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceStats_AttachmentStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceStats_AttachmentStats) ProtoMessage() {}
+
+func (x *InstanceStats_AttachmentStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceStats_AttachmentStats.ProtoReflect.Descriptor instead.
+func (*InstanceStats_AttachmentStats) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{11, 1}
+}
+
+func (x *InstanceStats_AttachmentStats) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *InstanceStats_AttachmentStats) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
 var File_api_v1_instance_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_instance_service_proto_rawDesc = "" +
@@ -2203,7 +3369,7 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\vaccess_mode\x18\n" +
 	" \x01(\x0e2 .memos.api.v1.InstanceAccessModeR\n" +
 	"accessMode\"\x1b\n" +
-	"\x19GetInstanceProfileRequest\"\xd0\"\n" +
+	"\x19GetInstanceProfileRequest\"\x9d6\n" +
 	"\x0fInstanceSetting\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12W\n" +
 	"\x0fgeneral_setting\x18\x02 \x01(\v2,.memos.api.v1.InstanceSetting.GeneralSettingH\x00R\x0egeneralSetting\x12W\n" +
@@ -2226,13 +3392,17 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\rCustomProfile\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
-	"\blogo_url\x18\x03 \x01(\tR\alogoUrl\x1a\xd2\x03\n" +
+	"\blogo_url\x18\x03 \x01(\tR\alogoUrl\x1a\xd3\t\n" +
 	"\aStorage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12=\n" +
 	"\x04type\x18\x03 \x01(\x0e2).memos.api.v1.InstanceSetting.StorageTypeR\x04type\x12M\n" +
 	"\ts3_config\x18\n" +
-	" \x01(\v2..memos.api.v1.InstanceSetting.Storage.S3ConfigH\x00R\bs3Config\x1a\x8a\x02\n" +
+	" \x01(\v2..memos.api.v1.InstanceSetting.Storage.S3ConfigH\x00R\bs3Config\x12V\n" +
+	"\flocal_config\x18\v \x01(\v21.memos.api.v1.InstanceSetting.Storage.LocalConfigH\x00R\vlocalConfig\x12Y\n" +
+	"\rwebdav_config\x18\f \x01(\v22.memos.api.v1.InstanceSetting.Storage.WebDAVConfigH\x00R\fwebdavConfig\x12S\n" +
+	"\vsftp_config\x18\r \x01(\v20.memos.api.v1.InstanceSetting.Storage.SFTPConfigH\x00R\n" +
+	"sftpConfig\x1a\xbb\x02\n" +
 	"\bS3Config\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12/\n" +
 	"\x11access_key_secret\x18\x02 \x01(\tB\x03\xe0A\x04R\x0faccessKeySecret\x12\x1a\n" +
@@ -2240,15 +3410,41 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x16\n" +
 	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12$\n" +
 	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\x127\n" +
-	"\x18insecure_skip_tls_verify\x18\a \x01(\bR\x15insecureSkipTlsVerifyB\b\n" +
-	"\x06config\x1a\xeb\x05\n" +
+	"\x18insecure_skip_tls_verify\x18\a \x01(\bR\x15insecureSkipTlsVerify\x12/\n" +
+	"\x13presigned_transfers\x18\b \x01(\bR\x12presignedTransfers\x1a!\n" +
+	"\vLocalConfig\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x1a\xa0\x01\n" +
+	"\fWebDAVConfig\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1f\n" +
+	"\bpassword\x18\x03 \x01(\tB\x03\xe0A\x04R\bpassword\x127\n" +
+	"\x18insecure_skip_tls_verify\x18\x04 \x01(\bR\x15insecureSkipTlsVerify\x1a\xff\x01\n" +
+	"\n" +
+	"SFTPConfig\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1f\n" +
+	"\bpassword\x18\x04 \x01(\tB\x03\xe0A\x04R\bpassword\x12$\n" +
+	"\vprivate_key\x18\x05 \x01(\tB\x03\xe0A\x04R\n" +
+	"privateKey\x12\x19\n" +
+	"\bhost_key\x18\x06 \x01(\tR\ahostKey\x12\x12\n" +
+	"\x04path\x18\a \x01(\tR\x04path\x127\n" +
+	"\x18insecure_ignore_host_key\x18\b \x01(\bR\x15insecureIgnoreHostKeyB\b\n" +
+	"\x06config\x1a\xfd\b\n" +
 	"\x0eStorageSetting\x12[\n" +
 	"\fstorage_type\x18\x01 \x01(\x0e28.memos.api.v1.InstanceSetting.StorageSetting.StorageTypeR\vstorageType\x12+\n" +
 	"\x11filepath_template\x18\x02 \x01(\tR\x10filepathTemplate\x12/\n" +
 	"\x14upload_size_limit_mb\x18\x03 \x01(\x03R\x11uploadSizeLimitMb\x12R\n" +
 	"\ts3_config\x18\x04 \x01(\v25.memos.api.v1.InstanceSetting.StorageSetting.S3ConfigR\bs3Config\x12A\n" +
 	"\bstorages\x18\x05 \x03(\v2%.memos.api.v1.InstanceSetting.StorageR\bstorages\x12,\n" +
-	"\x12default_storage_id\x18\x06 \x01(\tR\x10defaultStorageId\x1a\x8a\x02\n" +
+	"\x12default_storage_id\x18\x06 \x01(\tR\x10defaultStorageId\x12@\n" +
+	"\x05quota\x18\a \x01(\v2*.memos.api.v1.InstanceSetting.StorageQuotaR\x05quota\x12,\n" +
+	"\x12allowed_mime_types\x18\b \x03(\tR\x10allowedMimeTypes\x12,\n" +
+	"\x12blocked_mime_types\x18\t \x03(\tR\x10blockedMimeTypes\x121\n" +
+	"\x15variant_cache_size_mb\x18\n" +
+	" \x01(\x03R\x12variantCacheSizeMb\x12:\n" +
+	"\x1avariant_cache_max_age_days\x18\v \x01(\x05R\x16variantCacheMaxAgeDays\x12R\n" +
+	"\fcontent_scan\x18\f \x01(\v2/.memos.api.v1.InstanceSetting.ContentScanConfigR\vcontentScan\x1a\xbb\x02\n" +
 	"\bS3Config\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12/\n" +
 	"\x11access_key_secret\x18\x02 \x01(\tB\x03\xe0A\x04R\x0faccessKeySecret\x12\x1a\n" +
@@ -2256,12 +3452,40 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x16\n" +
 	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12$\n" +
 	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\x127\n" +
-	"\x18insecure_skip_tls_verify\x18\a \x01(\bR\x15insecureSkipTlsVerify\"L\n" +
+	"\x18insecure_skip_tls_verify\x18\a \x01(\bR\x15insecureSkipTlsVerify\x12/\n" +
+	"\x13presigned_transfers\x18\b \x01(\bR\x12presignedTransfers\"L\n" +
 	"\vStorageType\x12\x1c\n" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\x1a\xbd\x01\n" +
+	"\x02S3\x10\x03\x1a\xf2\x03\n" +
+	"\x11ContentScanConfig\x12Q\n" +
+	"\ascanner\x18\x01 \x01(\x0e27.memos.api.v1.InstanceSetting.ContentScanConfig.ScannerR\ascanner\x12#\n" +
+	"\rclamd_address\x18\x02 \x01(\tR\fclamdAddress\x12#\n" +
+	"\rhttp_endpoint\x18\x03 \x01(\tR\fhttpEndpoint\x12\"\n" +
+	"\n" +
+	"http_token\x18\x04 \x01(\tB\x03\xe0A\x04R\thttpToken\x12_\n" +
+	"\x0finfected_action\x18\x05 \x01(\x0e26.memos.api.v1.InstanceSetting.ContentScanConfig.ActionR\x0einfectedAction\x12\x1b\n" +
+	"\tfail_open\x18\x06 \x01(\bR\bfailOpen\x12'\n" +
+	"\x0ftimeout_seconds\x18\a \x01(\x05R\x0etimeoutSeconds\"7\n" +
+	"\aScanner\x12\x17\n" +
+	"\x13SCANNER_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05CLAMD\x10\x01\x12\b\n" +
+	"\x04HTTP\x10\x02\"<\n" +
+	"\x06Action\x12\x16\n" +
+	"\x12ACTION_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06REJECT\x10\x01\x12\x0e\n" +
+	"\n" +
+	"QUARANTINE\x10\x02\x1a\x8f\x02\n" +
+	"\fStorageQuota\x12\"\n" +
+	"\ruser_quota_mb\x18\x01 \x01(\x03R\vuserQuotaMb\x12$\n" +
+	"\x0eadmin_quota_mb\x18\x02 \x01(\x03R\fadminQuotaMb\x12^\n" +
+	"\x0euser_overrides\x18\x03 \x03(\v27.memos.api.v1.InstanceSetting.StorageQuota.UserOverrideR\ruserOverrides\x1aU\n" +
+	"\fUserOverride\x12*\n" +
+	"\x04user\x18\x01 \x01(\tB\x16\xfaA\x13\n" +
+	"\x11memos.api.v1/UserR\x04user\x12\x19\n" +
+	"\bquota_mb\x18\x02 \x01(\x03R\aquotaMb\x1a\xbd\x01\n" +
 	"\x12MemoRelatedSetting\x120\n" +
 	"\x14content_length_limit\x18\x03 \x01(\x05R\x12contentLengthLimit\x127\n" +
 	"\x18enable_double_click_edit\x18\x04 \x01(\bR\x15enableDoubleClickEdit\x12\x1c\n" +
@@ -2288,25 +3512,39 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\breply_to\x18\b \x01(\tR\areplyTo\x12\x17\n" +
 	"\ause_tls\x18\t \x01(\bR\x06useTls\x12\x17\n" +
 	"\ause_ssl\x18\n" +
-	" \x01(\bR\x06useSsl\x1a\xb2\x01\n" +
+	" \x01(\bR\x06useSsl\x1a\x8c\x02\n" +
 	"\tAISetting\x12L\n" +
 	"\tproviders\x18\x01 \x03(\v2..memos.api.v1.InstanceSetting.AIProviderConfigR\tproviders\x12W\n" +
-	"\rtranscription\x18\x02 \x01(\v21.memos.api.v1.InstanceSetting.TranscriptionConfigR\rtranscription\x1a\x80\x02\n" +
+	"\rtranscription\x18\x02 \x01(\v21.memos.api.v1.InstanceSetting.TranscriptionConfigR\rtranscription\x12X\n" +
+	"\x0eimage_analysis\x18\x03 \x01(\v21.memos.api.v1.InstanceSetting.ImageAnalysisConfigR\rimageAnalysis\x1a\xe1\x02\n" +
 	"\x10AIProviderConfig\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12@\n" +
 	"\x04type\x18\x03 \x01(\x0e2,.memos.api.v1.InstanceSetting.AIProviderTypeR\x04type\x12\x1a\n" +
 	"\bendpoint\x18\x04 \x01(\tR\bendpoint\x12\x1c\n" +
-	"\aapi_key\x18\x05 \x01(\tB\x03\xe0A\x04R\x06apiKey\x12#\n" +
+	"\aapi_key\x18\x05 \x01(\tB\x03\xe0A\x04R\x06apiKey\x12'\n" +
+	"\x0ftimeout_seconds\x18\x06 \x01(\x05R\x0etimeoutSeconds\x126\n" +
+	"\x17max_concurrent_requests\x18\a \x01(\x05R\x15maxConcurrentRequests\x12#\n" +
 	"\vapi_key_set\x18\b \x01(\bB\x03\xe0A\x03R\tapiKeySet\x12%\n" +
 	"\fapi_key_hint\x18\t \x01(\tB\x03\xe0A\x03R\n" +
-	"apiKeyHint\x1a\x80\x01\n" +
+	"apiKeyHint\x1a\xc0\x01\n" +
 	"\x13TranscriptionConfig\x12\x1f\n" +
 	"\vprovider_id\x18\x01 \x01(\tR\n" +
 	"providerId\x12\x14\n" +
 	"\x05model\x18\x02 \x01(\tR\x05model\x12\x1a\n" +
 	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x16\n" +
-	"\x06prompt\x18\x04 \x01(\tR\x06prompt\x1aR\n" +
+	"\x06prompt\x18\x04 \x01(\tR\x06prompt\x12>\n" +
+	"\x1bauto_transcribe_attachments\x18\x05 \x01(\bR\x19autoTranscribeAttachments\x1a\xfc\x01\n" +
+	"\x13ImageAnalysisConfig\x12P\n" +
+	"\x06engine\x18\x01 \x01(\x0e28.memos.api.v1.InstanceSetting.ImageAnalysisConfig.EngineR\x06engine\x12\x1f\n" +
+	"\vprovider_id\x18\x02 \x01(\tR\n" +
+	"providerId\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\"@\n" +
+	"\x06Engine\x12\x16\n" +
+	"\x12ENGINE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vAI_PROVIDER\x10\x01\x12\r\n" +
+	"\tTESSERACT\x10\x02\x1aR\n" +
 	"\rAccessSetting\x12A\n" +
 	"\vaccess_mode\x18\x01 \x01(\x0e2 .memos.api.v1.InstanceAccessModeR\n" +
 	"accessMode\"v\n" +
@@ -2319,18 +3557,24 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"\fNOTIFICATION\x10\x05\x12\x06\n" +
 	"\x02AI\x10\x06\x12\n" +
 	"\n" +
-	"\x06ACCESS\x10\a\"L\n" +
+	"\x06ACCESS\x10\a\"b\n" +
 	"\vStorageType\x12\x1c\n" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\"J\n" +
+	"\x02S3\x10\x03\x12\n" +
+	"\n" +
+	"\x06WEBDAV\x10\x04\x12\b\n" +
+	"\x04SFTP\x10\x05\"m\n" +
 	"\x0eAIProviderType\x12 \n" +
 	"\x1cAI_PROVIDER_TYPE_UNSPECIFIED\x10\x00\x12\n" +
 	"\n" +
 	"\x06OPENAI\x10\x01\x12\n" +
 	"\n" +
-	"\x06GEMINI\x10\x02:a\xeaA^\n" +
+	"\x06GEMINI\x10\x02\x12\x15\n" +
+	"\x11OPENAI_COMPATIBLE\x10\x03\x12\n" +
+	"\n" +
+	"\x06OLLAMA\x10\x04:a\xeaA^\n" +
 	"\x1cmemos.api.v1/InstanceSetting\x12\x1binstance/settings/{setting}*\x10instanceSettings2\x0finstanceSettingB\a\n" +
 	"\x05value\"U\n" +
 	"\x19GetInstanceSettingRequest\x128\n" +
@@ -2347,27 +3591,45 @@ const file_api_v1_instance_service_proto_rawDesc = "" +
 	"updateMask\"\xaa\x01\n" +
 	"\x1fTestInstanceEmailSettingRequest\x12Y\n" +
 	"\x05email\x18\x01 \x01(\v2>.memos.api.v1.InstanceSetting.NotificationSetting.EmailSettingB\x03\xe0A\x01R\x05email\x12,\n" +
-	"\x0frecipient_email\x18\x02 \x01(\tB\x03\xe0A\x01R\x0erecipientEmail\"\x19\n" +
-	"\x17GetInstanceStatsRequest\"\x91\x02\n" +
+	"\x0frecipient_email\x18\x02 \x01(\tB\x03\xe0A\x01R\x0erecipientEmail\"h\n" +
+	"\x15TestAIProviderRequest\x12O\n" +
+	"\bprovider\x18\x01 \x01(\v2..memos.api.v1.InstanceSetting.AIProviderConfigB\x03\xe0A\x02R\bprovider\"\x98\x01\n" +
+	"\x16TestAIProviderResponse\x12B\n" +
+	"\x06models\x18\x01 \x03(\v2*.memos.api.v1.TestAIProviderResponse.ModelR\x06models\x1a:\n" +
+	"\x05Model\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\"\x19\n" +
+	"\x17GetInstanceStatsRequest\"\xa8\x03\n" +
 	"\rInstanceStats\x12E\n" +
 	"\bdatabase\x18\x01 \x01(\v2).memos.api.v1.InstanceStats.DatabaseStatsR\bdatabase\x12.\n" +
 	"\x13local_storage_bytes\x18\x02 \x01(\x03R\x11localStorageBytes\x12A\n" +
-	"\x0egenerated_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rgeneratedTime\x1aF\n" +
+	"\x0egenerated_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rgeneratedTime\x12M\n" +
+	"\vattachments\x18\x05 \x01(\v2+.memos.api.v1.InstanceStats.AttachmentStatsR\vattachments\x1aF\n" +
 	"\rDatabaseStats\x12\x16\n" +
 	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x1d\n" +
 	"\n" +
-	"size_bytes\x18\x02 \x01(\x03R\tsizeBytes*}\n" +
+	"size_bytes\x18\x02 \x01(\x03R\tsizeBytes\x1aF\n" +
+	"\x0fAttachmentStats\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x02 \x01(\x03R\tsizeBytes\"?\n" +
+	"\x1bListStorageConsumersRequest\x12 \n" +
+	"\tpage_size\x18\x01 \x01(\x05B\x03\xe0A\x01R\bpageSize\"X\n" +
+	"\x1cListStorageConsumersResponse\x128\n" +
+	"\tconsumers\x18\x01 \x03(\v2\x1a.memos.api.v1.StorageUsageR\tconsumers*}\n" +
 	"\x12InstanceAccessMode\x12$\n" +
 	" INSTANCE_ACCESS_MODE_UNSPECIFIED\x10\x00\x12 \n" +
 	"\x1cINSTANCE_ACCESS_MODE_PRIVATE\x10\x01\x12\x1f\n" +
-	"\x1bINSTANCE_ACCESS_MODE_PUBLIC\x10\x022\x9f\a\n" +
+	"\x1bINSTANCE_ACCESS_MODE_PUBLIC\x10\x022\xd4\t\n" +
 	"\x0fInstanceService\x12~\n" +
 	"\x12GetInstanceProfile\x12'.memos.api.v1.GetInstanceProfileRequest\x1a\x1d.memos.api.v1.InstanceProfile\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/instance/profile\x12\x8f\x01\n" +
 	"\x12GetInstanceSetting\x12'.memos.api.v1.GetInstanceSettingRequest\x1a\x1d.memos.api.v1.InstanceSetting\"1\xdaA\x04name\x82\xd3\xe4\x93\x02$\x12\"/api/v1/{name=instance/settings/*}\x12\xa8\x01\n" +
 	"\x18BatchGetInstanceSettings\x12-.memos.api.v1.BatchGetInstanceSettingsRequest\x1a..memos.api.v1.BatchGetInstanceSettingsResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/instance/settings:batchGet\x12\xb5\x01\n" +
 	"\x15UpdateInstanceSetting\x12*.memos.api.v1.UpdateInstanceSettingRequest\x1a\x1d.memos.api.v1.InstanceSetting\"Q\xdaA\x13setting,update_mask\x82\xd3\xe4\x93\x025:\asetting2*/api/v1/{setting.name=instance/settings/*}\x12\x9e\x01\n" +
-	"\x18TestInstanceEmailSetting\x12-.memos.api.v1.TestInstanceEmailSettingRequest\x1a\x16.google.protobuf.Empty\";\x82\xd3\xe4\x93\x025:\x01*\"0/api/v1/instance/settings/notification:testEmail\x12v\n" +
-	"\x10GetInstanceStats\x12%.memos.api.v1.GetInstanceStatsRequest\x1a\x1b.memos.api.v1.InstanceStats\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/instance/statsB\xac\x01\n" +
+	"\x18TestInstanceEmailSetting\x12-.memos.api.v1.TestInstanceEmailSettingRequest\x1a\x16.google.protobuf.Empty\";\x82\xd3\xe4\x93\x025:\x01*\"0/api/v1/instance/settings/notification:testEmail\x12\x91\x01\n" +
+	"\x0eTestAIProvider\x12#.memos.api.v1.TestAIProviderRequest\x1a$.memos.api.v1.TestAIProviderResponse\"4\x82\xd3\xe4\x93\x02.:\x01*\")/api/v1/instance/settings/ai:testProvider\x12v\n" +
+	"\x10GetInstanceStats\x12%.memos.api.v1.GetInstanceStatsRequest\x1a\x1b.memos.api.v1.InstanceStats\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/instance/stats\x12\x9e\x01\n" +
+	"\x14ListStorageConsumers\x12).memos.api.v1.ListStorageConsumersRequest\x1a*.memos.api.v1.ListStorageConsumersResponse\"/\x82\xd3\xe4\x93\x02)\x12'/api/v1/instance/stats/storageConsumersB\xac\x01\n" +
 	"\x10com.memos.api.v1B\x14InstanceServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
 var (
@@ -2382,94 +3644,129 @@ func file_api_v1_instance_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_instance_service_proto_rawDescData
 }
 
-var file_api_v1_instance_service_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_api_v1_instance_service_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_api_v1_instance_service_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_api_v1_instance_service_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_api_v1_instance_service_proto_goTypes = []any{
 	(InstanceAccessMode)(0),                              // 0: memos.api.v1.InstanceAccessMode
 	(InstanceSetting_Key)(0),                             // 1: memos.api.v1.InstanceSetting.Key
 	(InstanceSetting_StorageType)(0),                     // 2: memos.api.v1.InstanceSetting.StorageType
 	(InstanceSetting_AIProviderType)(0),                  // 3: memos.api.v1.InstanceSetting.AIProviderType
 	(InstanceSetting_StorageSetting_StorageType)(0),      // 4: memos.api.v1.InstanceSetting.StorageSetting.StorageType
-	(*InstanceProfile)(nil),                              // 5: memos.api.v1.InstanceProfile
-	(*GetInstanceProfileRequest)(nil),                    // 6: memos.api.v1.GetInstanceProfileRequest
-	(*InstanceSetting)(nil),                              // 7: memos.api.v1.InstanceSetting
-	(*GetInstanceSettingRequest)(nil),                    // 8: memos.api.v1.GetInstanceSettingRequest
-	(*BatchGetInstanceSettingsRequest)(nil),              // 9: memos.api.v1.BatchGetInstanceSettingsRequest
-	(*BatchGetInstanceSettingsResponse)(nil),             // 10: memos.api.v1.BatchGetInstanceSettingsResponse
-	(*UpdateInstanceSettingRequest)(nil),                 // 11: memos.api.v1.UpdateInstanceSettingRequest
-	(*TestInstanceEmailSettingRequest)(nil),              // 12: memos.api.v1.TestInstanceEmailSettingRequest
-	(*GetInstanceStatsRequest)(nil),                      // 13: memos.api.v1.GetInstanceStatsRequest
-	(*InstanceStats)(nil),                                // 14: memos.api.v1.InstanceStats
-	(*InstanceSetting_GeneralSetting)(nil),               // 15: memos.api.v1.InstanceSetting.GeneralSetting
-	(*InstanceSetting_Storage)(nil),                      // 16: memos.api.v1.InstanceSetting.Storage
-	(*InstanceSetting_StorageSetting)(nil),               // 17: memos.api.v1.InstanceSetting.StorageSetting
-	(*InstanceSetting_MemoRelatedSetting)(nil),           // 18: memos.api.v1.InstanceSetting.MemoRelatedSetting
-	(*InstanceSetting_TagMetadata)(nil),                  // 19: memos.api.v1.InstanceSetting.TagMetadata
-	(*InstanceSetting_TagsSetting)(nil),                  // 20: memos.api.v1.InstanceSetting.TagsSetting
-	(*InstanceSetting_NotificationSetting)(nil),          // 21: memos.api.v1.InstanceSetting.NotificationSetting
-	(*InstanceSetting_AISetting)(nil),                    // 22: memos.api.v1.InstanceSetting.AISetting
-	(*InstanceSetting_AIProviderConfig)(nil),             // 23: memos.api.v1.InstanceSetting.AIProviderConfig
-	(*InstanceSetting_TranscriptionConfig)(nil),          // 24: memos.api.v1.InstanceSetting.TranscriptionConfig
-	(*InstanceSetting_AccessSetting)(nil),                // 25: memos.api.v1.InstanceSetting.AccessSetting
-	(*InstanceSetting_GeneralSetting_CustomProfile)(nil), // 26: memos.api.v1.InstanceSetting.GeneralSetting.CustomProfile
-	(*InstanceSetting_Storage_S3Config)(nil),             // 27: memos.api.v1.InstanceSetting.Storage.S3Config
-	(*InstanceSetting_StorageSetting_S3Config)(nil),      // 28: memos.api.v1.InstanceSetting.StorageSetting.S3Config
-	nil, // 29: memos.api.v1.InstanceSetting.TagsSetting.TagsEntry
-	(*InstanceSetting_NotificationSetting_EmailSetting)(nil), // 30: memos.api.v1.InstanceSetting.NotificationSetting.EmailSetting
-	(*InstanceStats_DatabaseStats)(nil),                      // 31: memos.api.v1.InstanceStats.DatabaseStats
-	(*User)(nil),                                             // 32: memos.api.v1.User
-	(*fieldmaskpb.FieldMask)(nil),                            // 33: google.protobuf.FieldMask
-	(*timestamppb.Timestamp)(nil),                            // 34: google.protobuf.Timestamp
-	(*color.Color)(nil),                                      // 35: google.type.Color
-	(*emptypb.Empty)(nil),                                    // 36: google.protobuf.Empty
+	(InstanceSetting_ContentScanConfig_Scanner)(0),       // 5: memos.api.v1.InstanceSetting.ContentScanConfig.Scanner
+	(InstanceSetting_ContentScanConfig_Action)(0),        // 6: memos.api.v1.InstanceSetting.ContentScanConfig.Action
+	(InstanceSetting_ImageAnalysisConfig_Engine)(0),      // 7: memos.api.v1.InstanceSetting.ImageAnalysisConfig.Engine
+	(*InstanceProfile)(nil),                              // 8: memos.api.v1.InstanceProfile
+	(*GetInstanceProfileRequest)(nil),                    // 9: memos.api.v1.GetInstanceProfileRequest
+	(*InstanceSetting)(nil),                              // 10: memos.api.v1.InstanceSetting
+	(*GetInstanceSettingRequest)(nil),                    // 11: memos.api.v1.GetInstanceSettingRequest
+	(*BatchGetInstanceSettingsRequest)(nil),              // 12: memos.api.v1.BatchGetInstanceSettingsRequest
+	(*BatchGetInstanceSettingsResponse)(nil),             // 13: memos.api.v1.BatchGetInstanceSettingsResponse
+	(*UpdateInstanceSettingRequest)(nil),                 // 14: memos.api.v1.UpdateInstanceSettingRequest
+	(*TestInstanceEmailSettingRequest)(nil),              // 15: memos.api.v1.TestInstanceEmailSettingRequest
+	(*TestAIProviderRequest)(nil),                        // 16: memos.api.v1.TestAIProviderRequest
+	(*TestAIProviderResponse)(nil),                       // 17: memos.api.v1.TestAIProviderResponse
+	(*GetInstanceStatsRequest)(nil),                      // 18: memos.api.v1.GetInstanceStatsRequest
+	(*InstanceStats)(nil),                                // 19: memos.api.v1.InstanceStats
+	(*ListStorageConsumersRequest)(nil),                  // 20: memos.api.v1.ListStorageConsumersRequest
+	(*ListStorageConsumersResponse)(nil),                 // 21: memos.api.v1.ListStorageConsumersResponse
+	(*InstanceSetting_GeneralSetting)(nil),               // 22: memos.api.v1.InstanceSetting.GeneralSetting
+	(*InstanceSetting_Storage)(nil),                      // 23: memos.api.v1.InstanceSetting.Storage
+	(*InstanceSetting_StorageSetting)(nil),               // 24: memos.api.v1.InstanceSetting.StorageSetting
+	(*InstanceSetting_ContentScanConfig)(nil),            // 25: memos.api.v1.InstanceSetting.ContentScanConfig
+	(*InstanceSetting_StorageQuota)(nil),                 // 26: memos.api.v1.InstanceSetting.StorageQuota
+	(*InstanceSetting_MemoRelatedSetting)(nil),           // 27: memos.api.v1.InstanceSetting.MemoRelatedSetting
+	(*InstanceSetting_TagMetadata)(nil),                  // 28: memos.api.v1.InstanceSetting.TagMetadata
+	(*InstanceSetting_TagsSetting)(nil),                  // 29: memos.api.v1.InstanceSetting.TagsSetting
+	(*InstanceSetting_NotificationSetting)(nil),          // 30: memos.api.v1.InstanceSetting.NotificationSetting
+	(*InstanceSetting_AISetting)(nil),                    // 31: memos.api.v1.InstanceSetting.AISetting
+	(*InstanceSetting_AIProviderConfig)(nil),             // 32: memos.api.v1.InstanceSetting.AIProviderConfig
+	(*InstanceSetting_TranscriptionConfig)(nil),          // 33: memos.api.v1.InstanceSetting.TranscriptionConfig
+	(*InstanceSetting_ImageAnalysisConfig)(nil),          // 34: memos.api.v1.InstanceSetting.ImageAnalysisConfig
+	(*InstanceSetting_AccessSetting)(nil),                // 35: memos.api.v1.InstanceSetting.AccessSetting
+	(*InstanceSetting_GeneralSetting_CustomProfile)(nil), // 36: memos.api.v1.InstanceSetting.GeneralSetting.CustomProfile
+	(*InstanceSetting_Storage_S3Config)(nil),             // 37: memos.api.v1.InstanceSetting.Storage.S3Config
+	(*InstanceSetting_Storage_LocalConfig)(nil),          // 38: memos.api.v1.InstanceSetting.Storage.LocalConfig
+	(*InstanceSetting_Storage_WebDAVConfig)(nil),         // 39: memos.api.v1.InstanceSetting.Storage.WebDAVConfig
+	(*InstanceSetting_Storage_SFTPConfig)(nil),           // 40: memos.api.v1.InstanceSetting.Storage.SFTPConfig
+	(*InstanceSetting_StorageSetting_S3Config)(nil),      // 41: memos.api.v1.InstanceSetting.StorageSetting.S3Config
+	(*InstanceSetting_StorageQuota_UserOverride)(nil),    // 42: memos.api.v1.InstanceSetting.StorageQuota.UserOverride
+	nil, // 43: memos.api.v1.InstanceSetting.TagsSetting.TagsEntry
+	(*InstanceSetting_NotificationSetting_EmailSetting)(nil), // 44: memos.api.v1.InstanceSetting.NotificationSetting.EmailSetting
+	(*TestAIProviderResponse_Model)(nil),                     // 45: memos.api.v1.TestAIProviderResponse.Model
+	(*InstanceStats_DatabaseStats)(nil),                      // 46: memos.api.v1.InstanceStats.DatabaseStats
+	(*InstanceStats_AttachmentStats)(nil),                    // 47: memos.api.v1.InstanceStats.AttachmentStats
+	(*User)(nil),                                             // 48: memos.api.v1.User
+	(*fieldmaskpb.FieldMask)(nil),                            // 49: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil),                            // 50: google.protobuf.Timestamp
+	(*StorageUsage)(nil),                                     // 51: memos.api.v1.StorageUsage
+	(*color.Color)(nil),                                      // 52: google.type.Color
+	(*emptypb.Empty)(nil),                                    // 53: google.protobuf.Empty
 }
 var file_api_v1_instance_service_proto_depIdxs = []int32{
-	32, // 0: memos.api.v1.InstanceProfile.admin:type_name -> memos.api.v1.User
+	48, // 0: memos.api.v1.InstanceProfile.admin:type_name -> memos.api.v1.User
 	0,  // 1: memos.api.v1.InstanceProfile.access_mode:type_name -> memos.api.v1.InstanceAccessMode
-	15, // 2: memos.api.v1.InstanceSetting.general_setting:type_name -> memos.api.v1.InstanceSetting.GeneralSetting
-	17, // 3: memos.api.v1.InstanceSetting.storage_setting:type_name -> memos.api.v1.InstanceSetting.StorageSetting
-	18, // 4: memos.api.v1.InstanceSetting.memo_related_setting:type_name -> memos.api.v1.InstanceSetting.MemoRelatedSetting
-	20, // 5: memos.api.v1.InstanceSetting.tags_setting:type_name -> memos.api.v1.InstanceSetting.TagsSetting
-	21, // 6: memos.api.v1.InstanceSetting.notification_setting:type_name -> memos.api.v1.InstanceSetting.NotificationSetting
-	22, // 7: memos.api.v1.InstanceSetting.ai_setting:type_name -> memos.api.v1.InstanceSetting.AISetting
-	25, // 8: memos.api.v1.InstanceSetting.access_setting:type_name -> memos.api.v1.InstanceSetting.AccessSetting
-	7,  // 9: memos.api.v1.BatchGetInstanceSettingsResponse.settings:type_name -> memos.api.v1.InstanceSetting
-	7,  // 10: memos.api.v1.UpdateInstanceSettingRequest.setting:type_name -> memos.api.v1.InstanceSetting
-	33, // 11: memos.api.v1.UpdateInstanceSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
-	30, // 12: memos.api.v1.TestInstanceEmailSettingRequest.email:type_name -> memos.api.v1.InstanceSetting.NotificationSetting.EmailSetting
-	31, // 13: memos.api.v1.InstanceStats.database:type_name -> memos.api.v1.InstanceStats.DatabaseStats
-	34, // 14: memos.api.v1.InstanceStats.generated_time:type_name -> google.protobuf.Timestamp
-	26, // 15: memos.api.v1.InstanceSetting.GeneralSetting.custom_profile:type_name -> memos.api.v1.InstanceSetting.GeneralSetting.CustomProfile
-	2,  // 16: memos.api.v1.InstanceSetting.Storage.type:type_name -> memos.api.v1.InstanceSetting.StorageType
-	27, // 17: memos.api.v1.InstanceSetting.Storage.s3_config:type_name -> memos.api.v1.InstanceSetting.Storage.S3Config
-	4,  // 18: memos.api.v1.InstanceSetting.StorageSetting.storage_type:type_name -> memos.api.v1.InstanceSetting.StorageSetting.StorageType
-	28, // 19: memos.api.v1.InstanceSetting.StorageSetting.s3_config:type_name -> memos.api.v1.InstanceSetting.StorageSetting.S3Config
-	16, // 20: memos.api.v1.InstanceSetting.StorageSetting.storages:type_name -> memos.api.v1.InstanceSetting.Storage
-	35, // 21: memos.api.v1.InstanceSetting.TagMetadata.background_color:type_name -> google.type.Color
-	29, // 22: memos.api.v1.InstanceSetting.TagsSetting.tags:type_name -> memos.api.v1.InstanceSetting.TagsSetting.TagsEntry
-	30, // 23: memos.api.v1.InstanceSetting.NotificationSetting.email:type_name -> memos.api.v1.InstanceSetting.NotificationSetting.EmailSetting
-	23, // 24: memos.api.v1.InstanceSetting.AISetting.providers:type_name -> memos.api.v1.InstanceSetting.AIProviderConfig
-	24, // 25: memos.api.v1.InstanceSetting.AISetting.transcription:type_name -> memos.api.v1.InstanceSetting.TranscriptionConfig
-	3,  // 26: memos.api.v1.InstanceSetting.AIProviderConfig.type:type_name -> memos.api.v1.InstanceSetting.AIProviderType
-	0,  // 27: memos.api.v1.InstanceSetting.AccessSetting.access_mode:type_name -> memos.api.v1.InstanceAccessMode
-	19, // 28: memos.api.v1.InstanceSetting.TagsSetting.TagsEntry.value:type_name -> memos.api.v1.InstanceSetting.TagMetadata
-	6,  // 29: memos.api.v1.InstanceService.GetInstanceProfile:input_type -> memos.api.v1.GetInstanceProfileRequest
-	8,  // 30: memos.api.v1.InstanceService.GetInstanceSetting:input_type -> memos.api.v1.GetInstanceSettingRequest
-	9,  // 31: memos.api.v1.InstanceService.BatchGetInstanceSettings:input_type -> memos.api.v1.BatchGetInstanceSettingsRequest
-	11, // 32: memos.api.v1.InstanceService.UpdateInstanceSetting:input_type -> memos.api.v1.UpdateInstanceSettingRequest
-	12, // 33: memos.api.v1.InstanceService.TestInstanceEmailSetting:input_type -> memos.api.v1.TestInstanceEmailSettingRequest
-	13, // 34: memos.api.v1.InstanceService.GetInstanceStats:input_type -> memos.api.v1.GetInstanceStatsRequest
-	5,  // 35: memos.api.v1.InstanceService.GetInstanceProfile:output_type -> memos.api.v1.InstanceProfile
-	7,  // 36: memos.api.v1.InstanceService.GetInstanceSetting:output_type -> memos.api.v1.InstanceSetting
-	10, // 37: memos.api.v1.InstanceService.BatchGetInstanceSettings:output_type -> memos.api.v1.BatchGetInstanceSettingsResponse
-	7,  // 38: memos.api.v1.InstanceService.UpdateInstanceSetting:output_type -> memos.api.v1.InstanceSetting
-	36, // 39: memos.api.v1.InstanceService.TestInstanceEmailSetting:output_type -> google.protobuf.Empty
-	14, // 40: memos.api.v1.InstanceService.GetInstanceStats:output_type -> memos.api.v1.InstanceStats
-	35, // [35:41] is the sub-list for method output_type
-	29, // [29:35] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	22, // 2: memos.api.v1.InstanceSetting.general_setting:type_name -> memos.api.v1.InstanceSetting.GeneralSetting
+	24, // 3: memos.api.v1.InstanceSetting.storage_setting:type_name -> memos.api.v1.InstanceSetting.StorageSetting
+	27, // 4: memos.api.v1.InstanceSetting.memo_related_setting:type_name -> memos.api.v1.InstanceSetting.MemoRelatedSetting
+	29, // 5: memos.api.v1.InstanceSetting.tags_setting:type_name -> memos.api.v1.InstanceSetting.TagsSetting
+	30, // 6: memos.api.v1.InstanceSetting.notification_setting:type_name -> memos.api.v1.InstanceSetting.NotificationSetting
+	31, // 7: memos.api.v1.InstanceSetting.ai_setting:type_name -> memos.api.v1.InstanceSetting.AISetting
+	35, // 8: memos.api.v1.InstanceSetting.access_setting:type_name -> memos.api.v1.InstanceSetting.AccessSetting
+	10, // 9: memos.api.v1.BatchGetInstanceSettingsResponse.settings:type_name -> memos.api.v1.InstanceSetting
+	10, // 10: memos.api.v1.UpdateInstanceSettingRequest.setting:type_name -> memos.api.v1.InstanceSetting
+	49, // 11: memos.api.v1.UpdateInstanceSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
+	44, // 12: memos.api.v1.TestInstanceEmailSettingRequest.email:type_name -> memos.api.v1.InstanceSetting.NotificationSetting.EmailSetting
+	32, // 13: memos.api.v1.TestAIProviderRequest.provider:type_name -> memos.api.v1.InstanceSetting.AIProviderConfig
+	45, // 14: memos.api.v1.TestAIProviderResponse.models:type_name -> memos.api.v1.TestAIProviderResponse.Model
+	46, // 15: memos.api.v1.InstanceStats.database:type_name -> memos.api.v1.InstanceStats.DatabaseStats
+	50, // 16: memos.api.v1.InstanceStats.generated_time:type_name -> google.protobuf.Timestamp
+	47, // 17: memos.api.v1.InstanceStats.attachments:type_name -> memos.api.v1.InstanceStats.AttachmentStats
+	51, // 18: memos.api.v1.ListStorageConsumersResponse.consumers:type_name -> memos.api.v1.StorageUsage
+	36, // 19: memos.api.v1.InstanceSetting.GeneralSetting.custom_profile:type_name -> memos.api.v1.InstanceSetting.GeneralSetting.CustomProfile
+	2,  // 20: memos.api.v1.InstanceSetting.Storage.type:type_name -> memos.api.v1.InstanceSetting.StorageType
+	37, // 21: memos.api.v1.InstanceSetting.Storage.s3_config:type_name -> memos.api.v1.InstanceSetting.Storage.S3Config
+	38, // 22: memos.api.v1.InstanceSetting.Storage.local_config:type_name -> memos.api.v1.InstanceSetting.Storage.LocalConfig
+	39, // 23: memos.api.v1.InstanceSetting.Storage.webdav_config:type_name -> memos.api.v1.InstanceSetting.Storage.WebDAVConfig
+	40, // 24: memos.api.v1.InstanceSetting.Storage.sftp_config:type_name -> memos.api.v1.InstanceSetting.Storage.SFTPConfig
+	4,  // 25: memos.api.v1.InstanceSetting.StorageSetting.storage_type:type_name -> memos.api.v1.InstanceSetting.StorageSetting.StorageType
+	41, // 26: memos.api.v1.InstanceSetting.StorageSetting.s3_config:type_name -> memos.api.v1.InstanceSetting.StorageSetting.S3Config
+	23, // 27: memos.api.v1.InstanceSetting.StorageSetting.storages:type_name -> memos.api.v1.InstanceSetting.Storage
+	26, // 28: memos.api.v1.InstanceSetting.StorageSetting.quota:type_name -> memos.api.v1.InstanceSetting.StorageQuota
+	25, // 29: memos.api.v1.InstanceSetting.StorageSetting.content_scan:type_name -> memos.api.v1.InstanceSetting.ContentScanConfig
+	5,  // 30: memos.api.v1.InstanceSetting.ContentScanConfig.scanner:type_name -> memos.api.v1.InstanceSetting.ContentScanConfig.Scanner
+	6,  // 31: memos.api.v1.InstanceSetting.ContentScanConfig.infected_action:type_name -> memos.api.v1.InstanceSetting.ContentScanConfig.Action
+	42, // 32: memos.api.v1.InstanceSetting.StorageQuota.user_overrides:type_name -> memos.api.v1.InstanceSetting.StorageQuota.UserOverride
+	52, // 33: memos.api.v1.InstanceSetting.TagMetadata.background_color:type_name -> google.type.Color
+	43, // 34: memos.api.v1.InstanceSetting.TagsSetting.tags:type_name -> memos.api.v1.InstanceSetting.TagsSetting.TagsEntry
+	44, // 35: memos.api.v1.InstanceSetting.NotificationSetting.email:type_name -> memos.api.v1.InstanceSetting.NotificationSetting.EmailSetting
+	32, // 36: memos.api.v1.InstanceSetting.AISetting.providers:type_name -> memos.api.v1.InstanceSetting.AIProviderConfig
+	33, // 37: memos.api.v1.InstanceSetting.AISetting.transcription:type_name -> memos.api.v1.InstanceSetting.TranscriptionConfig
+	34, // 38: memos.api.v1.InstanceSetting.AISetting.image_analysis:type_name -> memos.api.v1.InstanceSetting.ImageAnalysisConfig
+	3,  // 39: memos.api.v1.InstanceSetting.AIProviderConfig.type:type_name -> memos.api.v1.InstanceSetting.AIProviderType
+	7,  // 40: memos.api.v1.InstanceSetting.ImageAnalysisConfig.engine:type_name -> memos.api.v1.InstanceSetting.ImageAnalysisConfig.Engine
+	0,  // 41: memos.api.v1.InstanceSetting.AccessSetting.access_mode:type_name -> memos.api.v1.InstanceAccessMode
+	28, // 42: memos.api.v1.InstanceSetting.TagsSetting.TagsEntry.value:type_name -> memos.api.v1.InstanceSetting.TagMetadata
+	9,  // 43: memos.api.v1.InstanceService.GetInstanceProfile:input_type -> memos.api.v1.GetInstanceProfileRequest
+	11, // 44: memos.api.v1.InstanceService.GetInstanceSetting:input_type -> memos.api.v1.GetInstanceSettingRequest
+	12, // 45: memos.api.v1.InstanceService.BatchGetInstanceSettings:input_type -> memos.api.v1.BatchGetInstanceSettingsRequest
+	14, // 46: memos.api.v1.InstanceService.UpdateInstanceSetting:input_type -> memos.api.v1.UpdateInstanceSettingRequest
+	15, // 47: memos.api.v1.InstanceService.TestInstanceEmailSetting:input_type -> memos.api.v1.TestInstanceEmailSettingRequest
+	16, // 48: memos.api.v1.InstanceService.TestAIProvider:input_type -> memos.api.v1.TestAIProviderRequest
+	18, // 49: memos.api.v1.InstanceService.GetInstanceStats:input_type -> memos.api.v1.GetInstanceStatsRequest
+	20, // 50: memos.api.v1.InstanceService.ListStorageConsumers:input_type -> memos.api.v1.ListStorageConsumersRequest
+	8,  // 51: memos.api.v1.InstanceService.GetInstanceProfile:output_type -> memos.api.v1.InstanceProfile
+	10, // 52: memos.api.v1.InstanceService.GetInstanceSetting:output_type -> memos.api.v1.InstanceSetting
+	13, // 53: memos.api.v1.InstanceService.BatchGetInstanceSettings:output_type -> memos.api.v1.BatchGetInstanceSettingsResponse
+	10, // 54: memos.api.v1.InstanceService.UpdateInstanceSetting:output_type -> memos.api.v1.InstanceSetting
+	53, // 55: memos.api.v1.InstanceService.TestInstanceEmailSetting:output_type -> google.protobuf.Empty
+	17, // 56: memos.api.v1.InstanceService.TestAIProvider:output_type -> memos.api.v1.TestAIProviderResponse
+	19, // 57: memos.api.v1.InstanceService.GetInstanceStats:output_type -> memos.api.v1.InstanceStats
+	21, // 58: memos.api.v1.InstanceService.ListStorageConsumers:output_type -> memos.api.v1.ListStorageConsumersResponse
+	51, // [51:59] is the sub-list for method output_type
+	43, // [43:51] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_instance_service_proto_init() }
@@ -2487,16 +3784,19 @@ func file_api_v1_instance_service_proto_init() {
 		(*InstanceSetting_AiSetting)(nil),
 		(*InstanceSetting_AccessSetting_)(nil),
 	}
-	file_api_v1_instance_service_proto_msgTypes[11].OneofWrappers = []any{
+	file_api_v1_instance_service_proto_msgTypes[15].OneofWrappers = []any{
 		(*InstanceSetting_Storage_S3Config_)(nil),
+		(*InstanceSetting_Storage_LocalConfig_)(nil),
+		(*InstanceSetting_Storage_WebdavConfig)(nil),
+		(*InstanceSetting_Storage_SftpConfig)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_instance_service_proto_rawDesc), len(file_api_v1_instance_service_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   27,
+			NumEnums:      8,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
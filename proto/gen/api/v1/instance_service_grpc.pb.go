@@ -25,7 +25,9 @@ const (
 	InstanceService_BatchGetInstanceSettings_FullMethodName = "/memos.api.v1.InstanceService/BatchGetInstanceSettings"
 	InstanceService_UpdateInstanceSetting_FullMethodName    = "/memos.api.v1.InstanceService/UpdateInstanceSetting"
 	InstanceService_TestInstanceEmailSetting_FullMethodName = "/memos.api.v1.InstanceService/TestInstanceEmailSetting"
+	InstanceService_TestAIProvider_FullMethodName           = "/memos.api.v1.InstanceService/TestAIProvider"
 	InstanceService_GetInstanceStats_FullMethodName         = "/memos.api.v1.InstanceService/GetInstanceStats"
+	InstanceService_ListStorageConsumers_FullMethodName     = "/memos.api.v1.InstanceService/ListStorageConsumers"
 )
 
 // InstanceServiceClient is the client API for InstanceService service.
@@ -42,8 +44,12 @@ type InstanceServiceClient interface {
 	UpdateInstanceSetting(ctx context.Context, in *UpdateInstanceSettingRequest, opts ...grpc.CallOption) (*InstanceSetting, error)
 	// Tests notification email delivery with the provided or stored SMTP settings.
 	TestInstanceEmailSetting(ctx context.Context, in *TestInstanceEmailSettingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Tests an AI provider connection by listing the models it serves. Admin only.
+	TestAIProvider(ctx context.Context, in *TestAIProviderRequest, opts ...grpc.CallOption) (*TestAIProviderResponse, error)
 	// GetInstanceStats returns resource usage statistics for the instance. Admin only.
 	GetInstanceStats(ctx context.Context, in *GetInstanceStatsRequest, opts ...grpc.CallOption) (*InstanceStats, error)
+	// ListStorageConsumers returns the users storing the most attachment data. Admin only.
+	ListStorageConsumers(ctx context.Context, in *ListStorageConsumersRequest, opts ...grpc.CallOption) (*ListStorageConsumersResponse, error)
 }
 
 type instanceServiceClient struct {
@@ -104,6 +110,16 @@ func (c *instanceServiceClient) TestInstanceEmailSetting(ctx context.Context, in
 	return out, nil
 }
 
+func (c *instanceServiceClient) TestAIProvider(ctx context.Context, in *TestAIProviderRequest, opts ...grpc.CallOption) (*TestAIProviderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestAIProviderResponse)
+	err := c.cc.Invoke(ctx, InstanceService_TestAIProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *instanceServiceClient) GetInstanceStats(ctx context.Context, in *GetInstanceStatsRequest, opts ...grpc.CallOption) (*InstanceStats, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(InstanceStats)
@@ -114,6 +130,16 @@ func (c *instanceServiceClient) GetInstanceStats(ctx context.Context, in *GetIns
 	return out, nil
 }
 
+func (c *instanceServiceClient) ListStorageConsumers(ctx context.Context, in *ListStorageConsumersRequest, opts ...grpc.CallOption) (*ListStorageConsumersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStorageConsumersResponse)
+	err := c.cc.Invoke(ctx, InstanceService_ListStorageConsumers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InstanceServiceServer is the server API for InstanceService service.
 // All implementations must embed UnimplementedInstanceServiceServer
 // for forward compatibility.
@@ -128,8 +154,12 @@ type InstanceServiceServer interface {
 	UpdateInstanceSetting(context.Context, *UpdateInstanceSettingRequest) (*InstanceSetting, error)
 	// Tests notification email delivery with the provided or stored SMTP settings.
 	TestInstanceEmailSetting(context.Context, *TestInstanceEmailSettingRequest) (*emptypb.Empty, error)
+	// Tests an AI provider connection by listing the models it serves. Admin only.
+	TestAIProvider(context.Context, *TestAIProviderRequest) (*TestAIProviderResponse, error)
 	// GetInstanceStats returns resource usage statistics for the instance. Admin only.
 	GetInstanceStats(context.Context, *GetInstanceStatsRequest) (*InstanceStats, error)
+	// ListStorageConsumers returns the users storing the most attachment data. Admin only.
+	ListStorageConsumers(context.Context, *ListStorageConsumersRequest) (*ListStorageConsumersResponse, error)
 	mustEmbedUnimplementedInstanceServiceServer()
 }
 
@@ -155,9 +185,15 @@ func (UnimplementedInstanceServiceServer) UpdateInstanceSetting(context.Context,
 func (UnimplementedInstanceServiceServer) TestInstanceEmailSetting(context.Context, *TestInstanceEmailSettingRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method TestInstanceEmailSetting not implemented")
 }
+func (UnimplementedInstanceServiceServer) TestAIProvider(context.Context, *TestAIProviderRequest) (*TestAIProviderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TestAIProvider not implemented")
+}
 func (UnimplementedInstanceServiceServer) GetInstanceStats(context.Context, *GetInstanceStatsRequest) (*InstanceStats, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetInstanceStats not implemented")
 }
+func (UnimplementedInstanceServiceServer) ListStorageConsumers(context.Context, *ListStorageConsumersRequest) (*ListStorageConsumersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListStorageConsumers not implemented")
+}
 func (UnimplementedInstanceServiceServer) mustEmbedUnimplementedInstanceServiceServer() {}
 func (UnimplementedInstanceServiceServer) testEmbeddedByValue()                         {}
 
@@ -269,6 +305,24 @@ func _InstanceService_TestInstanceEmailSetting_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InstanceService_TestAIProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestAIProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstanceServiceServer).TestAIProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstanceService_TestAIProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstanceServiceServer).TestAIProvider(ctx, req.(*TestAIProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InstanceService_GetInstanceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetInstanceStatsRequest)
 	if err := dec(in); err != nil {
@@ -287,6 +341,24 @@ func _InstanceService_GetInstanceStats_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InstanceService_ListStorageConsumers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStorageConsumersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstanceServiceServer).ListStorageConsumers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstanceService_ListStorageConsumers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstanceServiceServer).ListStorageConsumers(ctx, req.(*ListStorageConsumersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InstanceService_ServiceDesc is the grpc.ServiceDesc for InstanceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -314,10 +386,18 @@ var InstanceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TestInstanceEmailSetting",
 			Handler:    _InstanceService_TestInstanceEmailSetting_Handler,
 		},
+		{
+			MethodName: "TestAIProvider",
+			Handler:    _InstanceService_TestAIProvider_Handler,
+		},
 		{
 			MethodName: "GetInstanceStats",
 			Handler:    _InstanceService_GetInstanceStats_Handler,
 		},
+		{
+			MethodName: "ListStorageConsumers",
+			Handler:    _InstanceService_ListStorageConsumers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/instance_service.proto",
@@ -29,6 +29,7 @@ const (
 	MemoService_ListMemoAttachments_FullMethodName  = "/memos.api.v1.MemoService/ListMemoAttachments"
 	MemoService_SetMemoRelations_FullMethodName     = "/memos.api.v1.MemoService/SetMemoRelations"
 	MemoService_ListMemoRelations_FullMethodName    = "/memos.api.v1.MemoService/ListMemoRelations"
+	MemoService_ListMemoBacklinks_FullMethodName    = "/memos.api.v1.MemoService/ListMemoBacklinks"
 	MemoService_CreateMemoComment_FullMethodName    = "/memos.api.v1.MemoService/CreateMemoComment"
 	MemoService_ListMemoComments_FullMethodName     = "/memos.api.v1.MemoService/ListMemoComments"
 	MemoService_ListMemoReactions_FullMethodName    = "/memos.api.v1.MemoService/ListMemoReactions"
@@ -40,6 +41,14 @@ const (
 	MemoService_GetSharedMemo_FullMethodName        = "/memos.api.v1.MemoService/GetSharedMemo"
 	MemoService_GetLinkMetadata_FullMethodName      = "/memos.api.v1.MemoService/GetLinkMetadata"
 	MemoService_BatchGetLinkMetadata_FullMethodName = "/memos.api.v1.MemoService/BatchGetLinkMetadata"
+	MemoService_RenameTag_FullMethodName            = "/memos.api.v1.MemoService/RenameTag"
+	MemoService_MergeTags_FullMethodName            = "/memos.api.v1.MemoService/MergeTags"
+	MemoService_DeleteTag_FullMethodName            = "/memos.api.v1.MemoService/DeleteTag"
+	MemoService_ListTasks_FullMethodName            = "/memos.api.v1.MemoService/ListTasks"
+	MemoService_SetTaskState_FullMethodName         = "/memos.api.v1.MemoService/SetTaskState"
+	MemoService_GetDailyMemo_FullMethodName         = "/memos.api.v1.MemoService/GetDailyMemo"
+	MemoService_AppendDailyMemo_FullMethodName      = "/memos.api.v1.MemoService/AppendDailyMemo"
+	MemoService_ListDailyMemos_FullMethodName       = "/memos.api.v1.MemoService/ListDailyMemos"
 )
 
 // MemoServiceClient is the client API for MemoService service.
@@ -70,6 +79,10 @@ type MemoServiceClient interface {
 	SetMemoRelations(ctx context.Context, in *SetMemoRelationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// ListMemoRelations lists relations for a memo.
 	ListMemoRelations(ctx context.Context, in *ListMemoRelationsRequest, opts ...grpc.CallOption) (*ListMemoRelationsResponse, error)
+	// ListMemoBacklinks lists the memos that link to a memo, either with a
+	// [[memo]] link in their content or an explicit reference relation.
+	// Comments are not included.
+	ListMemoBacklinks(ctx context.Context, in *ListMemoBacklinksRequest, opts ...grpc.CallOption) (*ListMemoBacklinksResponse, error)
 	// CreateMemoComment creates a comment for a memo.
 	CreateMemoComment(ctx context.Context, in *CreateMemoCommentRequest, opts ...grpc.CallOption) (*Memo, error)
 	// ListMemoComments lists comments for a memo.
@@ -93,6 +106,35 @@ type MemoServiceClient interface {
 	GetLinkMetadata(ctx context.Context, in *GetLinkMetadataRequest, opts ...grpc.CallOption) (*LinkMetadata, error)
 	// BatchGetLinkMetadata gets metadata for links.
 	BatchGetLinkMetadata(ctx context.Context, in *BatchGetLinkMetadataRequest, opts ...grpc.CallOption) (*BatchGetLinkMetadataResponse, error)
+	// RenameTag renames a tag and its descendants in the caller's memos, e.g.
+	// proj/alpha to projects/alpha also turns proj/alpha/docs into
+	// projects/alpha/docs. Matching tag metadata moves with it.
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error)
+	// MergeTags renames several tags and their descendants to one target tag in
+	// the caller's memos.
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error)
+	// DeleteTag removes a tag and its descendants from the caller's memos and
+	// drops their tag metadata. The rest of the content is kept.
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
+	// ListTasks lists the task list items of the memos visible to the caller,
+	// newest memo first. Completed tasks are left out unless show_completed is
+	// set.
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	// SetTaskState checks or unchecks a single task. Only the checkbox of the
+	// task changes in the memo content. Requires the memo creator or an admin.
+	SetTaskState(ctx context.Context, in *SetTaskStateRequest, opts ...grpc.CallOption) (*Task, error)
+	// GetDailyMemo returns the caller's daily memo for a day, creating it when
+	// the day has none yet. Every call for the same user and day resolves to the
+	// same memo.
+	GetDailyMemo(ctx context.Context, in *GetDailyMemoRequest, opts ...grpc.CallOption) (*Memo, error)
+	// AppendDailyMemo appends content to the caller's daily memo for a day,
+	// creating the memo when the day has none yet, so entries can be logged to
+	// the day from anywhere. It fails with ABORTED when the memo is edited
+	// concurrently.
+	AppendDailyMemo(ctx context.Context, in *AppendDailyMemoRequest, opts ...grpc.CallOption) (*Memo, error)
+	// ListDailyMemos lists the days of a calendar month that have a daily memo
+	// of the caller.
+	ListDailyMemos(ctx context.Context, in *ListDailyMemosRequest, opts ...grpc.CallOption) (*ListDailyMemosResponse, error)
 }
 
 type memoServiceClient struct {
@@ -193,6 +235,16 @@ func (c *memoServiceClient) ListMemoRelations(ctx context.Context, in *ListMemoR
 	return out, nil
 }
 
+func (c *memoServiceClient) ListMemoBacklinks(ctx context.Context, in *ListMemoBacklinksRequest, opts ...grpc.CallOption) (*ListMemoBacklinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMemoBacklinksResponse)
+	err := c.cc.Invoke(ctx, MemoService_ListMemoBacklinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) CreateMemoComment(ctx context.Context, in *CreateMemoCommentRequest, opts ...grpc.CallOption) (*Memo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Memo)
@@ -303,6 +355,86 @@ func (c *memoServiceClient) BatchGetLinkMetadata(ctx context.Context, in *BatchG
 	return out, nil
 }
 
+func (c *memoServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameTagResponse)
+	err := c.cc.Invoke(ctx, MemoService_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeTagsResponse)
+	err := c.cc.Invoke(ctx, MemoService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTagResponse)
+	err := c.cc.Invoke(ctx, MemoService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, MemoService_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) SetTaskState(ctx context.Context, in *SetTaskStateRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, MemoService_SetTaskState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) GetDailyMemo(ctx context.Context, in *GetDailyMemoRequest, opts ...grpc.CallOption) (*Memo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Memo)
+	err := c.cc.Invoke(ctx, MemoService_GetDailyMemo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) AppendDailyMemo(ctx context.Context, in *AppendDailyMemoRequest, opts ...grpc.CallOption) (*Memo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Memo)
+	err := c.cc.Invoke(ctx, MemoService_AppendDailyMemo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) ListDailyMemos(ctx context.Context, in *ListDailyMemosRequest, opts ...grpc.CallOption) (*ListDailyMemosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDailyMemosResponse)
+	err := c.cc.Invoke(ctx, MemoService_ListDailyMemos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MemoServiceServer is the server API for MemoService service.
 // All implementations must embed UnimplementedMemoServiceServer
 // for forward compatibility.
@@ -331,6 +463,10 @@ type MemoServiceServer interface {
 	SetMemoRelations(context.Context, *SetMemoRelationsRequest) (*emptypb.Empty, error)
 	// ListMemoRelations lists relations for a memo.
 	ListMemoRelations(context.Context, *ListMemoRelationsRequest) (*ListMemoRelationsResponse, error)
+	// ListMemoBacklinks lists the memos that link to a memo, either with a
+	// [[memo]] link in their content or an explicit reference relation.
+	// Comments are not included.
+	ListMemoBacklinks(context.Context, *ListMemoBacklinksRequest) (*ListMemoBacklinksResponse, error)
 	// CreateMemoComment creates a comment for a memo.
 	CreateMemoComment(context.Context, *CreateMemoCommentRequest) (*Memo, error)
 	// ListMemoComments lists comments for a memo.
@@ -354,6 +490,35 @@ type MemoServiceServer interface {
 	GetLinkMetadata(context.Context, *GetLinkMetadataRequest) (*LinkMetadata, error)
 	// BatchGetLinkMetadata gets metadata for links.
 	BatchGetLinkMetadata(context.Context, *BatchGetLinkMetadataRequest) (*BatchGetLinkMetadataResponse, error)
+	// RenameTag renames a tag and its descendants in the caller's memos, e.g.
+	// proj/alpha to projects/alpha also turns proj/alpha/docs into
+	// projects/alpha/docs. Matching tag metadata moves with it.
+	RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error)
+	// MergeTags renames several tags and their descendants to one target tag in
+	// the caller's memos.
+	MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error)
+	// DeleteTag removes a tag and its descendants from the caller's memos and
+	// drops their tag metadata. The rest of the content is kept.
+	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
+	// ListTasks lists the task list items of the memos visible to the caller,
+	// newest memo first. Completed tasks are left out unless show_completed is
+	// set.
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	// SetTaskState checks or unchecks a single task. Only the checkbox of the
+	// task changes in the memo content. Requires the memo creator or an admin.
+	SetTaskState(context.Context, *SetTaskStateRequest) (*Task, error)
+	// GetDailyMemo returns the caller's daily memo for a day, creating it when
+	// the day has none yet. Every call for the same user and day resolves to the
+	// same memo.
+	GetDailyMemo(context.Context, *GetDailyMemoRequest) (*Memo, error)
+	// AppendDailyMemo appends content to the caller's daily memo for a day,
+	// creating the memo when the day has none yet, so entries can be logged to
+	// the day from anywhere. It fails with ABORTED when the memo is edited
+	// concurrently.
+	AppendDailyMemo(context.Context, *AppendDailyMemoRequest) (*Memo, error)
+	// ListDailyMemos lists the days of a calendar month that have a daily memo
+	// of the caller.
+	ListDailyMemos(context.Context, *ListDailyMemosRequest) (*ListDailyMemosResponse, error)
 	mustEmbedUnimplementedMemoServiceServer()
 }
 
@@ -391,6 +556,9 @@ func (UnimplementedMemoServiceServer) SetMemoRelations(context.Context, *SetMemo
 func (UnimplementedMemoServiceServer) ListMemoRelations(context.Context, *ListMemoRelationsRequest) (*ListMemoRelationsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListMemoRelations not implemented")
 }
+func (UnimplementedMemoServiceServer) ListMemoBacklinks(context.Context, *ListMemoBacklinksRequest) (*ListMemoBacklinksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMemoBacklinks not implemented")
+}
 func (UnimplementedMemoServiceServer) CreateMemoComment(context.Context, *CreateMemoCommentRequest) (*Memo, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateMemoComment not implemented")
 }
@@ -424,6 +592,30 @@ func (UnimplementedMemoServiceServer) GetLinkMetadata(context.Context, *GetLinkM
 func (UnimplementedMemoServiceServer) BatchGetLinkMetadata(context.Context, *BatchGetLinkMetadataRequest) (*BatchGetLinkMetadataResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method BatchGetLinkMetadata not implemented")
 }
+func (UnimplementedMemoServiceServer) RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedMemoServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedMemoServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedMemoServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedMemoServiceServer) SetTaskState(context.Context, *SetTaskStateRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTaskState not implemented")
+}
+func (UnimplementedMemoServiceServer) GetDailyMemo(context.Context, *GetDailyMemoRequest) (*Memo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDailyMemo not implemented")
+}
+func (UnimplementedMemoServiceServer) AppendDailyMemo(context.Context, *AppendDailyMemoRequest) (*Memo, error) {
+	return nil, status.Error(codes.Unimplemented, "method AppendDailyMemo not implemented")
+}
+func (UnimplementedMemoServiceServer) ListDailyMemos(context.Context, *ListDailyMemosRequest) (*ListDailyMemosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDailyMemos not implemented")
+}
 func (UnimplementedMemoServiceServer) mustEmbedUnimplementedMemoServiceServer() {}
 func (UnimplementedMemoServiceServer) testEmbeddedByValue()                     {}
 
@@ -607,6 +799,24 @@ func _MemoService_ListMemoRelations_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_ListMemoBacklinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMemoBacklinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ListMemoBacklinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ListMemoBacklinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ListMemoBacklinks(ctx, req.(*ListMemoBacklinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_CreateMemoComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateMemoCommentRequest)
 	if err := dec(in); err != nil {
@@ -805,6 +1015,150 @@ func _MemoService_BatchGetLinkMetadata_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_SetTaskState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTaskStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).SetTaskState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_SetTaskState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).SetTaskState(ctx, req.(*SetTaskStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_GetDailyMemo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyMemoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).GetDailyMemo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_GetDailyMemo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).GetDailyMemo(ctx, req.(*GetDailyMemoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_AppendDailyMemo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendDailyMemoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).AppendDailyMemo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_AppendDailyMemo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).AppendDailyMemo(ctx, req.(*AppendDailyMemoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_ListDailyMemos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDailyMemosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ListDailyMemos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ListDailyMemos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ListDailyMemos(ctx, req.(*ListDailyMemosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MemoService_ServiceDesc is the grpc.ServiceDesc for MemoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -848,6 +1202,10 @@ var MemoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListMemoRelations",
 			Handler:    _MemoService_ListMemoRelations_Handler,
 		},
+		{
+			MethodName: "ListMemoBacklinks",
+			Handler:    _MemoService_ListMemoBacklinks_Handler,
+		},
 		{
 			MethodName: "CreateMemoComment",
 			Handler:    _MemoService_CreateMemoComment_Handler,
@@ -892,6 +1250,38 @@ var MemoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchGetLinkMetadata",
 			Handler:    _MemoService_BatchGetLinkMetadata_Handler,
 		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _MemoService_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _MemoService_MergeTags_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _MemoService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _MemoService_ListTasks_Handler,
+		},
+		{
+			MethodName: "SetTaskState",
+			Handler:    _MemoService_SetTaskState_Handler,
+		},
+		{
+			MethodName: "GetDailyMemo",
+			Handler:    _MemoService_GetDailyMemo_Handler,
+		},
+		{
+			MethodName: "AppendDailyMemo",
+			Handler:    _MemoService_AppendDailyMemo_Handler,
+		},
+		{
+			MethodName: "ListDailyMemos",
+			Handler:    _MemoService_ListDailyMemos_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/memo_service.proto",
@@ -0,0 +1,529 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: api/v1/memo_template_service.proto
+
+package apiv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MemoTemplate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resource name of the memo template.
+	// Format: users/{user}/templates/{template} or instance/templates/{template}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The title of the memo template.
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// The Markdown content of memos created from the template. Placeholders are
+	// replaced when a memo is created:
+	//   {{date}}, {{time}}, {{datetime}} and {{weekday}}: the creation time in the
+	//     request time zone, as 2006-01-02, 15:04, 2006-01-02 15:04 and Monday.
+	//   {{user.username}} and {{user.nickname}}: the creating user.
+	//   {{cursor}}: the content of the CreateMemo request memo.
+	//   {{name}}: the template_variables entry "name" of the CreateMemo request.
+	// Unknown placeholders are kept as written.
+	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// Tags added to memos created from the template, without the leading "#".
+	Tags []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The visibility of memos created from the template when the CreateMemo
+	// request leaves it unspecified.
+	Visibility    Visibility `protobuf:"varint,5,opt,name=visibility,proto3,enum=memos.api.v1.Visibility" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoTemplate) Reset() {
+	*x = MemoTemplate{}
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoTemplate) ProtoMessage() {}
+
+func (x *MemoTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoTemplate.ProtoReflect.Descriptor instead.
+func (*MemoTemplate) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_template_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MemoTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MemoTemplate) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MemoTemplate) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *MemoTemplate) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *MemoTemplate) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+type ListMemoTemplatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The parent resource where memo templates are listed.
+	// Format: users/{user} or instance
+	Parent        string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMemoTemplatesRequest) Reset() {
+	*x = ListMemoTemplatesRequest{}
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMemoTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMemoTemplatesRequest) ProtoMessage() {}
+
+func (x *ListMemoTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMemoTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListMemoTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_template_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListMemoTemplatesRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+type ListMemoTemplatesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The list of memo templates.
+	MemoTemplates []*MemoTemplate `protobuf:"bytes,1,rep,name=memo_templates,json=memoTemplates,proto3" json:"memo_templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMemoTemplatesResponse) Reset() {
+	*x = ListMemoTemplatesResponse{}
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMemoTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMemoTemplatesResponse) ProtoMessage() {}
+
+func (x *ListMemoTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMemoTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListMemoTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_template_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListMemoTemplatesResponse) GetMemoTemplates() []*MemoTemplate {
+	if x != nil {
+		return x.MemoTemplates
+	}
+	return nil
+}
+
+type GetMemoTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the memo template to retrieve.
+	// Format: users/{user}/templates/{template} or instance/templates/{template}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemoTemplateRequest) Reset() {
+	*x = GetMemoTemplateRequest{}
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemoTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemoTemplateRequest) ProtoMessage() {}
+
+func (x *GetMemoTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemoTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetMemoTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_template_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetMemoTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateMemoTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The parent resource where this memo template will be created.
+	// Format: users/{user} or instance
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Required. The memo template to create.
+	MemoTemplate *MemoTemplate `protobuf:"bytes,2,opt,name=memo_template,json=memoTemplate,proto3" json:"memo_template,omitempty"`
+	// Optional. If set, validate the request, but do not actually create the memo template.
+	ValidateOnly  bool `protobuf:"varint,3,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMemoTemplateRequest) Reset() {
+	*x = CreateMemoTemplateRequest{}
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMemoTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMemoTemplateRequest) ProtoMessage() {}
+
+func (x *CreateMemoTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMemoTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateMemoTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_template_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateMemoTemplateRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *CreateMemoTemplateRequest) GetMemoTemplate() *MemoTemplate {
+	if x != nil {
+		return x.MemoTemplate
+	}
+	return nil
+}
+
+func (x *CreateMemoTemplateRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type UpdateMemoTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The memo template resource which replaces the resource on the server.
+	MemoTemplate *MemoTemplate `protobuf:"bytes,1,opt,name=memo_template,json=memoTemplate,proto3" json:"memo_template,omitempty"`
+	// Optional. The list of fields to update.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMemoTemplateRequest) Reset() {
+	*x = UpdateMemoTemplateRequest{}
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMemoTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMemoTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateMemoTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMemoTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMemoTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_template_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateMemoTemplateRequest) GetMemoTemplate() *MemoTemplate {
+	if x != nil {
+		return x.MemoTemplate
+	}
+	return nil
+}
+
+func (x *UpdateMemoTemplateRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteMemoTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the memo template to delete.
+	// Format: users/{user}/templates/{template} or instance/templates/{template}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMemoTemplateRequest) Reset() {
+	*x = DeleteMemoTemplateRequest{}
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMemoTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMemoTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteMemoTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_memo_template_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMemoTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMemoTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_memo_template_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteMemoTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_api_v1_memo_template_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_memo_template_service_proto_rawDesc = "" +
+	"\n" +
+	"\"api/v1/memo_template_service.proto\x12\fmemos.api.v1\x1a\x19api/v1/memo_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\"\xb0\x02\n" +
+	"\fMemoTemplate\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tB\x03\xe0A\x02R\x05title\x12\x1d\n" +
+	"\acontent\x18\x03 \x01(\tB\x03\xe0A\x02R\acontent\x12\x17\n" +
+	"\x04tags\x18\x04 \x03(\tB\x03\xe0A\x01R\x04tags\x12=\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\x0e2\x18.memos.api.v1.VisibilityB\x03\xe0A\x01R\n" +
+	"visibility:u\xeaAr\n" +
+	"\x19memos.api.v1/MemoTemplate\x12!users/{user}/templates/{template}\x12\x1dinstance/templates/{template}*\ttemplates2\btemplate\"U\n" +
+	"\x18ListMemoTemplatesRequest\x129\n" +
+	"\x06parent\x18\x01 \x01(\tB!\xe0A\x02\xfaA\x1b\x12\x19memos.api.v1/MemoTemplateR\x06parent\"^\n" +
+	"\x19ListMemoTemplatesResponse\x12A\n" +
+	"\x0ememo_templates\x18\x01 \x03(\v2\x1a.memos.api.v1.MemoTemplateR\rmemoTemplates\"O\n" +
+	"\x16GetMemoTemplateRequest\x125\n" +
+	"\x04name\x18\x01 \x01(\tB!\xe0A\x02\xfaA\x1b\n" +
+	"\x19memos.api.v1/MemoTemplateR\x04name\"\xc6\x01\n" +
+	"\x19CreateMemoTemplateRequest\x129\n" +
+	"\x06parent\x18\x01 \x01(\tB!\xe0A\x02\xfaA\x1b\x12\x19memos.api.v1/MemoTemplateR\x06parent\x12D\n" +
+	"\rmemo_template\x18\x02 \x01(\v2\x1a.memos.api.v1.MemoTemplateB\x03\xe0A\x02R\fmemoTemplate\x12(\n" +
+	"\rvalidate_only\x18\x03 \x01(\bB\x03\xe0A\x01R\fvalidateOnly\"\xa3\x01\n" +
+	"\x19UpdateMemoTemplateRequest\x12D\n" +
+	"\rmemo_template\x18\x01 \x01(\v2\x1a.memos.api.v1.MemoTemplateB\x03\xe0A\x02R\fmemoTemplate\x12@\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x01R\n" +
+	"updateMask\"R\n" +
+	"\x19DeleteMemoTemplateRequest\x125\n" +
+	"\x04name\x18\x01 \x01(\tB!\xe0A\x02\xfaA\x1b\n" +
+	"\x19memos.api.v1/MemoTemplateR\x04name2\xb4\x06\n" +
+	"\x13MemoTemplateService\x12\x99\x01\n" +
+	"\x11ListMemoTemplates\x12&.memos.api.v1.ListMemoTemplatesRequest\x1a'.memos.api.v1.ListMemoTemplatesResponse\"3\xdaA\x06parent\x82\xd3\xe4\x93\x02$\x12\"/api/v1/{parent=users/*}/templates\x12\x86\x01\n" +
+	"\x0fGetMemoTemplate\x12$.memos.api.v1.GetMemoTemplateRequest\x1a\x1a.memos.api.v1.MemoTemplate\"1\xdaA\x04name\x82\xd3\xe4\x93\x02$\x12\"/api/v1/{name=users/*/templates/*}\x12\xab\x01\n" +
+	"\x12CreateMemoTemplate\x12'.memos.api.v1.CreateMemoTemplateRequest\x1a\x1a.memos.api.v1.MemoTemplate\"P\xdaA\x14parent,memo_template\x82\xd3\xe4\x93\x023:\rmemo_template\"\"/api/v1/{parent=users/*}/templates\x12\xbe\x01\n" +
+	"\x12UpdateMemoTemplate\x12'.memos.api.v1.UpdateMemoTemplateRequest\x1a\x1a.memos.api.v1.MemoTemplate\"c\xdaA\x19memo_template,update_mask\x82\xd3\xe4\x93\x02A:\rmemo_template20/api/v1/{memo_template.name=users/*/templates/*}\x12\x88\x01\n" +
+	"\x12DeleteMemoTemplate\x12'.memos.api.v1.DeleteMemoTemplateRequest\x1a\x16.google.protobuf.Empty\"1\xdaA\x04name\x82\xd3\xe4\x93\x02$*\"/api/v1/{name=users/*/templates/*}B\xb0\x01\n" +
+	"\x10com.memos.api.v1B\x18MemoTemplateServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
+
+var (
+	file_api_v1_memo_template_service_proto_rawDescOnce sync.Once
+	file_api_v1_memo_template_service_proto_rawDescData []byte
+)
+
+func file_api_v1_memo_template_service_proto_rawDescGZIP() []byte {
+	file_api_v1_memo_template_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_memo_template_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_memo_template_service_proto_rawDesc), len(file_api_v1_memo_template_service_proto_rawDesc)))
+	})
+	return file_api_v1_memo_template_service_proto_rawDescData
+}
+
+var file_api_v1_memo_template_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_v1_memo_template_service_proto_goTypes = []any{
+	(*MemoTemplate)(nil),              // 0: memos.api.v1.MemoTemplate
+	(*ListMemoTemplatesRequest)(nil),  // 1: memos.api.v1.ListMemoTemplatesRequest
+	(*ListMemoTemplatesResponse)(nil), // 2: memos.api.v1.ListMemoTemplatesResponse
+	(*GetMemoTemplateRequest)(nil),    // 3: memos.api.v1.GetMemoTemplateRequest
+	(*CreateMemoTemplateRequest)(nil), // 4: memos.api.v1.CreateMemoTemplateRequest
+	(*UpdateMemoTemplateRequest)(nil), // 5: memos.api.v1.UpdateMemoTemplateRequest
+	(*DeleteMemoTemplateRequest)(nil), // 6: memos.api.v1.DeleteMemoTemplateRequest
+	(Visibility)(0),                   // 7: memos.api.v1.Visibility
+	(*fieldmaskpb.FieldMask)(nil),     // 8: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),             // 9: google.protobuf.Empty
+}
+var file_api_v1_memo_template_service_proto_depIdxs = []int32{
+	7,  // 0: memos.api.v1.MemoTemplate.visibility:type_name -> memos.api.v1.Visibility
+	0,  // 1: memos.api.v1.ListMemoTemplatesResponse.memo_templates:type_name -> memos.api.v1.MemoTemplate
+	0,  // 2: memos.api.v1.CreateMemoTemplateRequest.memo_template:type_name -> memos.api.v1.MemoTemplate
+	0,  // 3: memos.api.v1.UpdateMemoTemplateRequest.memo_template:type_name -> memos.api.v1.MemoTemplate
+	8,  // 4: memos.api.v1.UpdateMemoTemplateRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,  // 5: memos.api.v1.MemoTemplateService.ListMemoTemplates:input_type -> memos.api.v1.ListMemoTemplatesRequest
+	3,  // 6: memos.api.v1.MemoTemplateService.GetMemoTemplate:input_type -> memos.api.v1.GetMemoTemplateRequest
+	4,  // 7: memos.api.v1.MemoTemplateService.CreateMemoTemplate:input_type -> memos.api.v1.CreateMemoTemplateRequest
+	5,  // 8: memos.api.v1.MemoTemplateService.UpdateMemoTemplate:input_type -> memos.api.v1.UpdateMemoTemplateRequest
+	6,  // 9: memos.api.v1.MemoTemplateService.DeleteMemoTemplate:input_type -> memos.api.v1.DeleteMemoTemplateRequest
+	2,  // 10: memos.api.v1.MemoTemplateService.ListMemoTemplates:output_type -> memos.api.v1.ListMemoTemplatesResponse
+	0,  // 11: memos.api.v1.MemoTemplateService.GetMemoTemplate:output_type -> memos.api.v1.MemoTemplate
+	0,  // 12: memos.api.v1.MemoTemplateService.CreateMemoTemplate:output_type -> memos.api.v1.MemoTemplate
+	0,  // 13: memos.api.v1.MemoTemplateService.UpdateMemoTemplate:output_type -> memos.api.v1.MemoTemplate
+	9,  // 14: memos.api.v1.MemoTemplateService.DeleteMemoTemplate:output_type -> google.protobuf.Empty
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_memo_template_service_proto_init() }
+func file_api_v1_memo_template_service_proto_init() {
+	if File_api_v1_memo_template_service_proto != nil {
+		return
+	}
+	file_api_v1_memo_service_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_memo_template_service_proto_rawDesc), len(file_api_v1_memo_template_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_memo_template_service_proto_goTypes,
+		DependencyIndexes: file_api_v1_memo_template_service_proto_depIdxs,
+		MessageInfos:      file_api_v1_memo_template_service_proto_msgTypes,
+	}.Build()
+	File_api_v1_memo_template_service_proto = out.File
+	file_api_v1_memo_template_service_proto_goTypes = nil
+	file_api_v1_memo_template_service_proto_depIdxs = nil
+}
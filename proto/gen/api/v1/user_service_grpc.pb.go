@@ -43,6 +43,8 @@ const (
 	UserService_UpdateUserWebhook_FullMethodName           = "/memos.api.v1.UserService/UpdateUserWebhook"
 	UserService_DeleteUserWebhook_FullMethodName           = "/memos.api.v1.UserService/DeleteUserWebhook"
 	UserService_GetUserWebhookSigningSecret_FullMethodName = "/memos.api.v1.UserService/GetUserWebhookSigningSecret"
+	UserService_ListUserWebhookDeliveries_FullMethodName   = "/memos.api.v1.UserService/ListUserWebhookDeliveries"
+	UserService_RedeliverWebhook_FullMethodName            = "/memos.api.v1.UserService/RedeliverWebhook"
 	UserService_ListUserNotifications_FullMethodName       = "/memos.api.v1.UserService/ListUserNotifications"
 	UserService_UpdateUserNotification_FullMethodName      = "/memos.api.v1.UserService/UpdateUserNotification"
 	UserService_DeleteUserNotification_FullMethodName      = "/memos.api.v1.UserService/DeleteUserNotification"
@@ -103,6 +105,10 @@ type UserServiceClient interface {
 	// The secret is returned only through this explicit, owner-gated call; it is
 	// never included in List/Create/Update responses.
 	GetUserWebhookSigningSecret(ctx context.Context, in *GetUserWebhookSigningSecretRequest, opts ...grpc.CallOption) (*GetUserWebhookSigningSecretResponse, error)
+	// ListUserWebhookDeliveries lists the delivery history of a webhook, newest first.
+	ListUserWebhookDeliveries(ctx context.Context, in *ListUserWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListUserWebhookDeliveriesResponse, error)
+	// RedeliverWebhook queues a new delivery of a previously recorded event.
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
 	// ListUserNotifications lists notifications for a user.
 	ListUserNotifications(ctx context.Context, in *ListUserNotificationsRequest, opts ...grpc.CallOption) (*ListUserNotificationsResponse, error)
 	// UpdateUserNotification updates a notification.
@@ -349,6 +355,26 @@ func (c *userServiceClient) GetUserWebhookSigningSecret(ctx context.Context, in
 	return out, nil
 }
 
+func (c *userServiceClient) ListUserWebhookDeliveries(ctx context.Context, in *ListUserWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListUserWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUserWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, UserService_RedeliverWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ListUserNotifications(ctx context.Context, in *ListUserNotificationsRequest, opts ...grpc.CallOption) (*ListUserNotificationsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListUserNotificationsResponse)
@@ -434,6 +460,10 @@ type UserServiceServer interface {
 	// The secret is returned only through this explicit, owner-gated call; it is
 	// never included in List/Create/Update responses.
 	GetUserWebhookSigningSecret(context.Context, *GetUserWebhookSigningSecretRequest) (*GetUserWebhookSigningSecretResponse, error)
+	// ListUserWebhookDeliveries lists the delivery history of a webhook, newest first.
+	ListUserWebhookDeliveries(context.Context, *ListUserWebhookDeliveriesRequest) (*ListUserWebhookDeliveriesResponse, error)
+	// RedeliverWebhook queues a new delivery of a previously recorded event.
+	RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDelivery, error)
 	// ListUserNotifications lists notifications for a user.
 	ListUserNotifications(context.Context, *ListUserNotificationsRequest) (*ListUserNotificationsResponse, error)
 	// UpdateUserNotification updates a notification.
@@ -519,6 +549,12 @@ func (UnimplementedUserServiceServer) DeleteUserWebhook(context.Context, *Delete
 func (UnimplementedUserServiceServer) GetUserWebhookSigningSecret(context.Context, *GetUserWebhookSigningSecretRequest) (*GetUserWebhookSigningSecretResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserWebhookSigningSecret not implemented")
 }
+func (UnimplementedUserServiceServer) ListUserWebhookDeliveries(context.Context, *ListUserWebhookDeliveriesRequest) (*ListUserWebhookDeliveriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUserWebhookDeliveries not implemented")
+}
+func (UnimplementedUserServiceServer) RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDelivery, error) {
+	return nil, status.Error(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
 func (UnimplementedUserServiceServer) ListUserNotifications(context.Context, *ListUserNotificationsRequest) (*ListUserNotificationsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListUserNotifications not implemented")
 }
@@ -963,6 +999,42 @@ func _UserService_GetUserWebhookSigningSecret_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListUserWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUserWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserWebhookDeliveries(ctx, req.(*ListUserWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RedeliverWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RedeliverWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ListUserNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListUserNotificationsRequest)
 	if err := dec(in); err != nil {
@@ -1116,6 +1188,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserWebhookSigningSecret",
 			Handler:    _UserService_GetUserWebhookSigningSecret_Handler,
 		},
+		{
+			MethodName: "ListUserWebhookDeliveries",
+			Handler:    _UserService_ListUserWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhook",
+			Handler:    _UserService_RedeliverWebhook_Handler,
+		},
 		{
 			MethodName: "ListUserNotifications",
 			Handler:    _UserService_ListUserNotifications_Handler,
@@ -31,6 +31,9 @@ const (
 	AttachmentStorageType_S3 AttachmentStorageType = 2
 	// Attachment is stored in an external storage. The reference is a URL.
 	AttachmentStorageType_EXTERNAL AttachmentStorageType = 3
+	// Attachment is stored as an object in a configured WebDAV, SFTP or
+	// local-path storage. The payload references the storage and key.
+	AttachmentStorageType_OBJECT AttachmentStorageType = 4
 )
 
 // Enum value maps for AttachmentStorageType.
@@ -40,12 +43,14 @@ var (
 		1: "LOCAL",
 		2: "S3",
 		3: "EXTERNAL",
+		4: "OBJECT",
 	}
 	AttachmentStorageType_value = map[string]int32{
 		"ATTACHMENT_STORAGE_TYPE_UNSPECIFIED": 0,
 		"LOCAL":                               1,
 		"S3":                                  2,
 		"EXTERNAL":                            3,
+		"OBJECT":                              4,
 	}
 )
 
@@ -177,6 +182,61 @@ func (MotionMediaRole) EnumDescriptor() ([]byte, []int) {
 	return file_store_attachment_proto_rawDescGZIP(), []int{2}
 }
 
+type ContentScan_Status int32
+
+const (
+	ContentScan_STATUS_UNSPECIFIED ContentScan_Status = 0
+	// CLEAN content had no detections.
+	ContentScan_CLEAN ContentScan_Status = 1
+	// INFECTED content is quarantined and never served.
+	ContentScan_INFECTED ContentScan_Status = 2
+	// FAILED scans were accepted because the scanner fails open.
+	ContentScan_FAILED ContentScan_Status = 3
+)
+
+// Enum value maps for ContentScan_Status.
+var (
+	ContentScan_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "CLEAN",
+		2: "INFECTED",
+		3: "FAILED",
+	}
+	ContentScan_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"CLEAN":              1,
+		"INFECTED":           2,
+		"FAILED":             3,
+	}
+)
+
+func (x ContentScan_Status) Enum() *ContentScan_Status {
+	p := new(ContentScan_Status)
+	*p = x
+	return p
+}
+
+func (x ContentScan_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContentScan_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_attachment_proto_enumTypes[3].Descriptor()
+}
+
+func (ContentScan_Status) Type() protoreflect.EnumType {
+	return &file_store_attachment_proto_enumTypes[3]
+}
+
+func (x ContentScan_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContentScan_Status.Descriptor instead.
+func (ContentScan_Status) EnumDescriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{10, 0}
+}
+
 type MotionMedia struct {
 	state                   protoimpl.MessageState `protogen:"open.v1"`
 	Family                  MotionMediaFamily      `protobuf:"varint,1,opt,name=family,proto3,enum=memos.store.MotionMediaFamily" json:"family,omitempty"`
@@ -623,32 +683,36 @@ func (x *VideoMetadata) GetDurationSeconds() float64 {
 	return 0
 }
 
-type AttachmentPayload struct {
+type AudioTranscript struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Payload:
-	//
-	//	*AttachmentPayload_S3Object_
-	Payload       isAttachmentPayload_Payload `protobuf_oneof:"payload"`
-	MotionMedia   *MotionMedia                `protobuf:"bytes,10,opt,name=motion_media,json=motionMedia,proto3" json:"motion_media,omitempty"`
-	MediaMetadata *MediaMetadata              `protobuf:"bytes,11,opt,name=media_metadata,json=mediaMetadata,proto3" json:"media_metadata,omitempty"`
+	// text is the full transcript.
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// language is the language reported by the provider, if any.
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	// segments are timestamped portions of the transcript, when the provider returns them.
+	Segments []*AudioTranscript_Segment `protobuf:"bytes,3,rep,name=segments,proto3" json:"segments,omitempty"`
+	// model is the provider model that produced the transcript.
+	Model string `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	// create_ts is the unix timestamp when the transcript was generated.
+	CreateTs      int64 `protobuf:"varint,5,opt,name=create_ts,json=createTs,proto3" json:"create_ts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttachmentPayload) Reset() {
-	*x = AttachmentPayload{}
+func (x *AudioTranscript) Reset() {
+	*x = AudioTranscript{}
 	mi := &file_store_attachment_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttachmentPayload) String() string {
+func (x *AudioTranscript) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttachmentPayload) ProtoMessage() {}
+func (*AudioTranscript) ProtoMessage() {}
 
-func (x *AttachmentPayload) ProtoReflect() protoreflect.Message {
+func (x *AudioTranscript) ProtoReflect() protoreflect.Message {
 	mi := &file_store_attachment_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -660,78 +724,75 @@ func (x *AttachmentPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttachmentPayload.ProtoReflect.Descriptor instead.
-func (*AttachmentPayload) Descriptor() ([]byte, []int) {
+// Deprecated: Use AudioTranscript.ProtoReflect.Descriptor instead.
+func (*AudioTranscript) Descriptor() ([]byte, []int) {
 	return file_store_attachment_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *AttachmentPayload) GetPayload() isAttachmentPayload_Payload {
+func (x *AudioTranscript) GetText() string {
 	if x != nil {
-		return x.Payload
+		return x.Text
 	}
-	return nil
+	return ""
 }
 
-func (x *AttachmentPayload) GetS3Object() *AttachmentPayload_S3Object {
+func (x *AudioTranscript) GetLanguage() string {
 	if x != nil {
-		if x, ok := x.Payload.(*AttachmentPayload_S3Object_); ok {
-			return x.S3Object
-		}
+		return x.Language
 	}
-	return nil
+	return ""
 }
 
-func (x *AttachmentPayload) GetMotionMedia() *MotionMedia {
+func (x *AudioTranscript) GetSegments() []*AudioTranscript_Segment {
 	if x != nil {
-		return x.MotionMedia
+		return x.Segments
 	}
 	return nil
 }
 
-func (x *AttachmentPayload) GetMediaMetadata() *MediaMetadata {
+func (x *AudioTranscript) GetModel() string {
 	if x != nil {
-		return x.MediaMetadata
+		return x.Model
 	}
-	return nil
-}
-
-type isAttachmentPayload_Payload interface {
-	isAttachmentPayload_Payload()
+	return ""
 }
 
-type AttachmentPayload_S3Object_ struct {
-	S3Object *AttachmentPayload_S3Object `protobuf:"bytes,1,opt,name=s3_object,json=s3Object,proto3,oneof"`
+func (x *AudioTranscript) GetCreateTs() int64 {
+	if x != nil {
+		return x.CreateTs
+	}
+	return 0
 }
 
-func (*AttachmentPayload_S3Object_) isAttachmentPayload_Payload() {}
-
-type AttachmentPayload_S3Object struct {
+type ImageAnalysis struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Legacy attachments embedded their complete S3 configuration.
-	// New attachments resolve storage through storage_id.
-	S3Config *StorageS3Config `protobuf:"bytes,1,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
-	// key is the S3 object key.
-	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	// storage_id identifies the configured storage containing this object.
-	StorageId     string `protobuf:"bytes,4,opt,name=storage_id,json=storageId,proto3" json:"storage_id,omitempty"`
+	// text is the text recognized in the image (OCR).
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// caption is a short description of the image, suitable as alt text.
+	Caption string `protobuf:"bytes,2,opt,name=caption,proto3" json:"caption,omitempty"`
+	// engine identifies what produced the analysis: the provider model id, or
+	// "tesseract" for the local OCR engine.
+	Engine string `protobuf:"bytes,3,opt,name=engine,proto3" json:"engine,omitempty"`
+	// create_ts is the unix timestamp when the analysis was generated.
+	CreateTs      int64 `protobuf:"varint,4,opt,name=create_ts,json=createTs,proto3" json:"create_ts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttachmentPayload_S3Object) Reset() {
-	*x = AttachmentPayload_S3Object{}
+func (x *ImageAnalysis) Reset() {
+	*x = ImageAnalysis{}
 	mi := &file_store_attachment_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttachmentPayload_S3Object) String() string {
+func (x *ImageAnalysis) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttachmentPayload_S3Object) ProtoMessage() {}
+func (*ImageAnalysis) ProtoMessage() {}
 
-func (x *AttachmentPayload_S3Object) ProtoReflect() protoreflect.Message {
+func (x *ImageAnalysis) ProtoReflect() protoreflect.Message {
 	mi := &file_store_attachment_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -743,183 +804,1146 @@ func (x *AttachmentPayload_S3Object) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttachmentPayload_S3Object.ProtoReflect.Descriptor instead.
-func (*AttachmentPayload_S3Object) Descriptor() ([]byte, []int) {
-	return file_store_attachment_proto_rawDescGZIP(), []int{6, 0}
+// Deprecated: Use ImageAnalysis.ProtoReflect.Descriptor instead.
+func (*ImageAnalysis) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *AttachmentPayload_S3Object) GetS3Config() *StorageS3Config {
+func (x *ImageAnalysis) GetText() string {
 	if x != nil {
-		return x.S3Config
+		return x.Text
 	}
-	return nil
+	return ""
 }
 
-func (x *AttachmentPayload_S3Object) GetKey() string {
+func (x *ImageAnalysis) GetCaption() string {
 	if x != nil {
-		return x.Key
+		return x.Caption
 	}
 	return ""
 }
 
-func (x *AttachmentPayload_S3Object) GetStorageId() string {
+func (x *ImageAnalysis) GetEngine() string {
 	if x != nil {
-		return x.StorageId
+		return x.Engine
 	}
 	return ""
 }
 
-var File_store_attachment_proto protoreflect.FileDescriptor
+func (x *ImageAnalysis) GetCreateTs() int64 {
+	if x != nil {
+		return x.CreateTs
+	}
+	return 0
+}
 
-const file_store_attachment_proto_rawDesc = "" +
-	"\n" +
-	"\x16store/attachment.proto\x12\vmemos.store\x1a\x1cstore/instance_setting.proto\"\xfc\x01\n" +
-	"\vMotionMedia\x126\n" +
-	"\x06family\x18\x01 \x01(\x0e2\x1e.memos.store.MotionMediaFamilyR\x06family\x120\n" +
-	"\x04role\x18\x02 \x01(\x0e2\x1c.memos.store.MotionMediaRoleR\x04role\x12\x19\n" +
-	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12:\n" +
-	"\x19presentation_timestamp_us\x18\x04 \x01(\x03R\x17presentationTimestampUs\x12,\n" +
-	"\x12has_embedded_video\x18\x05 \x01(\bR\x10hasEmbeddedVideo\"\xcf\x01\n" +
-	"\rMediaMetadata\x12\x19\n" +
-	"\x05width\x18\x01 \x01(\x05H\x01R\x05width\x88\x01\x01\x12\x1b\n" +
-	"\x06height\x18\x02 \x01(\x05H\x02R\x06height\x88\x01\x01\x122\n" +
-	"\x05photo\x18\x03 \x01(\v2\x1a.memos.store.PhotoMetadataH\x00R\x05photo\x122\n" +
-	"\x05video\x18\x04 \x01(\v2\x1a.memos.store.VideoMetadataH\x00R\x05videoB\t\n" +
-	"\adetailsB\b\n" +
-	"\x06_widthB\t\n" +
-	"\a_height\"\xa5\x04\n" +
-	"\rPhotoMetadata\x12@\n" +
-	"\fcapture_time\x18\x01 \x01(\v2\x1d.memos.store.MediaCaptureTimeR\vcaptureTime\x126\n" +
-	"\blocation\x18\x02 \x01(\v2\x1a.memos.store.MediaLocationR\blocation\x12;\n" +
-	"\x17source_exif_orientation\x18\x03 \x01(\x05H\x00R\x15sourceExifOrientation\x88\x01\x01\x12\x1f\n" +
-	"\vcamera_make\x18\x04 \x01(\tR\n" +
-	"cameraMake\x12!\n" +
-	"\fcamera_model\x18\x05 \x01(\tR\vcameraModel\x12\x1d\n" +
-	"\n" +
-	"lens_model\x18\x06 \x01(\tR\tlensModel\x12\x1e\n" +
-	"\bf_number\x18\a \x01(\x01H\x01R\afNumber\x88\x01\x01\x127\n" +
-	"\x15exposure_time_seconds\x18\b \x01(\x01H\x02R\x13exposureTimeSeconds\x88\x01\x01\x12\x15\n" +
-	"\x03iso\x18\t \x01(\x05H\x03R\x03iso\x88\x01\x01\x12+\n" +
-	"\x0ffocal_length_mm\x18\n" +
-	" \x01(\x01H\x04R\rfocalLengthMm\x88\x01\x01B\x1a\n" +
-	"\x18_source_exif_orientationB\v\n" +
-	"\t_f_numberB\x18\n" +
-	"\x16_exposure_time_secondsB\x06\n" +
-	"\x04_isoB\x12\n" +
-	"\x10_focal_length_mm\"m\n" +
-	"\x10MediaCaptureTime\x12&\n" +
-	"\x0flocal_date_time\x18\x01 \x01(\tR\rlocalDateTime\x12\"\n" +
-	"\n" +
-	"utc_offset\x18\x02 \x01(\tH\x00R\tutcOffset\x88\x01\x01B\r\n" +
-	"\v_utc_offset\"\xb0\x01\n" +
-	"\rMediaLocation\x12\x1f\n" +
-	"\blatitude\x18\x01 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
-	"\tlongitude\x18\x02 \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12,\n" +
-	"\x0faltitude_meters\x18\x03 \x01(\x01H\x02R\x0ealtitudeMeters\x88\x01\x01B\v\n" +
-	"\t_latitudeB\f\n" +
-	"\n" +
-	"_longitudeB\x12\n" +
-	"\x10_altitude_meters\"T\n" +
-	"\rVideoMetadata\x12.\n" +
-	"\x10duration_seconds\x18\x01 \x01(\x01H\x00R\x0fdurationSeconds\x88\x01\x01B\x13\n" +
-	"\x11_duration_seconds\"\xfa\x02\n" +
-	"\x11AttachmentPayload\x12F\n" +
-	"\ts3_object\x18\x01 \x01(\v2'.memos.store.AttachmentPayload.S3ObjectH\x00R\bs3Object\x12;\n" +
-	"\fmotion_media\x18\n" +
-	" \x01(\v2\x18.memos.store.MotionMediaR\vmotionMedia\x12A\n" +
-	"\x0emedia_metadata\x18\v \x01(\v2\x1a.memos.store.MediaMetadataR\rmediaMetadata\x1a\x91\x01\n" +
-	"\bS3Object\x129\n" +
-	"\ts3_config\x18\x01 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1d\n" +
-	"\n" +
-	"storage_id\x18\x04 \x01(\tR\tstorageIdJ\x04\b\x03\x10\x04R\x13last_presigned_timeB\t\n" +
-	"\apayload*a\n" +
-	"\x15AttachmentStorageType\x12'\n" +
-	"#ATTACHMENT_STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
-	"\x05LOCAL\x10\x01\x12\x06\n" +
-	"\x02S3\x10\x02\x12\f\n" +
-	"\bEXTERNAL\x10\x03*h\n" +
-	"\x11MotionMediaFamily\x12#\n" +
-	"\x1fMOTION_MEDIA_FAMILY_UNSPECIFIED\x10\x00\x12\x14\n" +
-	"\x10APPLE_LIVE_PHOTO\x10\x01\x12\x18\n" +
-	"\x14ANDROID_MOTION_PHOTO\x10\x02*Y\n" +
-	"\x0fMotionMediaRole\x12!\n" +
-	"\x1dMOTION_MEDIA_ROLE_UNSPECIFIED\x10\x00\x12\t\n" +
-	"\x05STILL\x10\x01\x12\t\n" +
-	"\x05VIDEO\x10\x02\x12\r\n" +
-	"\tCONTAINER\x10\x03B\x9a\x01\n" +
-	"\x0fcom.memos.storeB\x0fAttachmentProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
+type MediaPreview struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// has_poster reports whether a poster frame of the video is cached as the
+	// "poster.jpeg" attachment variant.
+	HasPoster bool `protobuf:"varint,1,opt,name=has_poster,json=hasPoster,proto3" json:"has_poster,omitempty"`
+	// waveform_peaks are the audio peak levels, from 0 to 1, of equally long
+	// consecutive slices of the recording.
+	WaveformPeaks []float32 `protobuf:"fixed32,2,rep,packed,name=waveform_peaks,json=waveformPeaks,proto3" json:"waveform_peaks,omitempty"`
+	// create_ts is the unix timestamp when the preview was generated.
+	CreateTs      int64 `protobuf:"varint,3,opt,name=create_ts,json=createTs,proto3" json:"create_ts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-var (
-	file_store_attachment_proto_rawDescOnce sync.Once
-	file_store_attachment_proto_rawDescData []byte
-)
+func (x *MediaPreview) Reset() {
+	*x = MediaPreview{}
+	mi := &file_store_attachment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func file_store_attachment_proto_rawDescGZIP() []byte {
-	file_store_attachment_proto_rawDescOnce.Do(func() {
-		file_store_attachment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_store_attachment_proto_rawDesc), len(file_store_attachment_proto_rawDesc)))
-	})
-	return file_store_attachment_proto_rawDescData
+func (x *MediaPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_store_attachment_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_store_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_store_attachment_proto_goTypes = []any{
-	(AttachmentStorageType)(0),         // 0: memos.store.AttachmentStorageType
-	(MotionMediaFamily)(0),             // 1: memos.store.MotionMediaFamily
-	(MotionMediaRole)(0),               // 2: memos.store.MotionMediaRole
-	(*MotionMedia)(nil),                // 3: memos.store.MotionMedia
-	(*MediaMetadata)(nil),              // 4: memos.store.MediaMetadata
-	(*PhotoMetadata)(nil),              // 5: memos.store.PhotoMetadata
-	(*MediaCaptureTime)(nil),           // 6: memos.store.MediaCaptureTime
-	(*MediaLocation)(nil),              // 7: memos.store.MediaLocation
-	(*VideoMetadata)(nil),              // 8: memos.store.VideoMetadata
-	(*AttachmentPayload)(nil),          // 9: memos.store.AttachmentPayload
-	(*AttachmentPayload_S3Object)(nil), // 10: memos.store.AttachmentPayload.S3Object
-	(*StorageS3Config)(nil),            // 11: memos.store.StorageS3Config
+func (*MediaPreview) ProtoMessage() {}
+
+func (x *MediaPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_store_attachment_proto_depIdxs = []int32{
-	1,  // 0: memos.store.MotionMedia.family:type_name -> memos.store.MotionMediaFamily
-	2,  // 1: memos.store.MotionMedia.role:type_name -> memos.store.MotionMediaRole
-	5,  // 2: memos.store.MediaMetadata.photo:type_name -> memos.store.PhotoMetadata
-	8,  // 3: memos.store.MediaMetadata.video:type_name -> memos.store.VideoMetadata
-	6,  // 4: memos.store.PhotoMetadata.capture_time:type_name -> memos.store.MediaCaptureTime
-	7,  // 5: memos.store.PhotoMetadata.location:type_name -> memos.store.MediaLocation
-	10, // 6: memos.store.AttachmentPayload.s3_object:type_name -> memos.store.AttachmentPayload.S3Object
-	3,  // 7: memos.store.AttachmentPayload.motion_media:type_name -> memos.store.MotionMedia
-	4,  // 8: memos.store.AttachmentPayload.media_metadata:type_name -> memos.store.MediaMetadata
-	11, // 9: memos.store.AttachmentPayload.S3Object.s3_config:type_name -> memos.store.StorageS3Config
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+
+// Deprecated: Use MediaPreview.ProtoReflect.Descriptor instead.
+func (*MediaPreview) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{8}
 }
 
-func init() { file_store_attachment_proto_init() }
-func file_store_attachment_proto_init() {
-	if File_store_attachment_proto != nil {
-		return
+func (x *MediaPreview) GetHasPoster() bool {
+	if x != nil {
+		return x.HasPoster
 	}
-	file_store_instance_setting_proto_init()
-	file_store_attachment_proto_msgTypes[1].OneofWrappers = []any{
-		(*MediaMetadata_Photo)(nil),
-		(*MediaMetadata_Video)(nil),
+	return false
+}
+
+func (x *MediaPreview) GetWaveformPeaks() []float32 {
+	if x != nil {
+		return x.WaveformPeaks
 	}
-	file_store_attachment_proto_msgTypes[2].OneofWrappers = []any{}
-	file_store_attachment_proto_msgTypes[3].OneofWrappers = []any{}
-	file_store_attachment_proto_msgTypes[4].OneofWrappers = []any{}
-	file_store_attachment_proto_msgTypes[5].OneofWrappers = []any{}
-	file_store_attachment_proto_msgTypes[6].OneofWrappers = []any{
-		(*AttachmentPayload_S3Object_)(nil),
+	return nil
+}
+
+func (x *MediaPreview) GetCreateTs() int64 {
+	if x != nil {
+		return x.CreateTs
+	}
+	return 0
+}
+
+type DocumentExtraction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// text is the plain text of the document, truncated to a bounded length.
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// page_count is the number of pages of PDF and DOCX documents, when known.
+	PageCount int32 `protobuf:"varint,2,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	// truncated reports whether text was cut short.
+	Truncated bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// create_ts is the unix timestamp when the text was extracted.
+	CreateTs      int64 `protobuf:"varint,4,opt,name=create_ts,json=createTs,proto3" json:"create_ts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentExtraction) Reset() {
+	*x = DocumentExtraction{}
+	mi := &file_store_attachment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentExtraction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentExtraction) ProtoMessage() {}
+
+func (x *DocumentExtraction) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentExtraction.ProtoReflect.Descriptor instead.
+func (*DocumentExtraction) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DocumentExtraction) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DocumentExtraction) GetPageCount() int32 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+func (x *DocumentExtraction) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *DocumentExtraction) GetCreateTs() int64 {
+	if x != nil {
+		return x.CreateTs
+	}
+	return 0
+}
+
+type ContentScan struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status ContentScan_Status     `protobuf:"varint,1,opt,name=status,proto3,enum=memos.store.ContentScan_Status" json:"status,omitempty"`
+	// signature names the detected malware.
+	Signature string `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// scanner names the scanner that checked the content.
+	Scanner string `protobuf:"bytes,3,opt,name=scanner,proto3" json:"scanner,omitempty"`
+	// scan_ts is the unix timestamp of the scan.
+	ScanTs        int64 `protobuf:"varint,4,opt,name=scan_ts,json=scanTs,proto3" json:"scan_ts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContentScan) Reset() {
+	*x = ContentScan{}
+	mi := &file_store_attachment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentScan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentScan) ProtoMessage() {}
+
+func (x *ContentScan) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentScan.ProtoReflect.Descriptor instead.
+func (*ContentScan) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ContentScan) GetStatus() ContentScan_Status {
+	if x != nil {
+		return x.Status
+	}
+	return ContentScan_STATUS_UNSPECIFIED
+}
+
+func (x *ContentScan) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *ContentScan) GetScanner() string {
+	if x != nil {
+		return x.Scanner
+	}
+	return ""
+}
+
+func (x *ContentScan) GetScanTs() int64 {
+	if x != nil {
+		return x.ScanTs
+	}
+	return 0
+}
+
+type AttachmentPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*AttachmentPayload_S3Object_
+	//	*AttachmentPayload_StorageObject_
+	Payload       isAttachmentPayload_Payload `protobuf_oneof:"payload"`
+	MotionMedia   *MotionMedia                `protobuf:"bytes,10,opt,name=motion_media,json=motionMedia,proto3" json:"motion_media,omitempty"`
+	MediaMetadata *MediaMetadata              `protobuf:"bytes,11,opt,name=media_metadata,json=mediaMetadata,proto3" json:"media_metadata,omitempty"`
+	// transcript is the speech-to-text result generated for audio attachments.
+	Transcript *AudioTranscript `protobuf:"bytes,12,opt,name=transcript,proto3" json:"transcript,omitempty"`
+	// image_analysis is the OCR text and caption generated for image attachments.
+	ImageAnalysis *ImageAnalysis `protobuf:"bytes,13,opt,name=image_analysis,json=imageAnalysis,proto3" json:"image_analysis,omitempty"`
+	// media_preview is the poster frame or waveform generated for video and
+	// audio attachments.
+	MediaPreview *MediaPreview `protobuf:"bytes,14,opt,name=media_preview,json=mediaPreview,proto3" json:"media_preview,omitempty"`
+	// content_scan is the malware scan verdict of the uploaded content.
+	ContentScan *ContentScan `protobuf:"bytes,15,opt,name=content_scan,json=contentScan,proto3" json:"content_scan,omitempty"`
+	// document is the text extracted from PDF, Office and plain text attachments.
+	Document      *DocumentExtraction `protobuf:"bytes,16,opt,name=document,proto3" json:"document,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentPayload) Reset() {
+	*x = AttachmentPayload{}
+	mi := &file_store_attachment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentPayload) ProtoMessage() {}
+
+func (x *AttachmentPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentPayload.ProtoReflect.Descriptor instead.
+func (*AttachmentPayload) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AttachmentPayload) GetPayload() isAttachmentPayload_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetS3Object() *AttachmentPayload_S3Object {
+	if x != nil {
+		if x, ok := x.Payload.(*AttachmentPayload_S3Object_); ok {
+			return x.S3Object
+		}
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetStorageObject() *AttachmentPayload_StorageObject {
+	if x != nil {
+		if x, ok := x.Payload.(*AttachmentPayload_StorageObject_); ok {
+			return x.StorageObject
+		}
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetMotionMedia() *MotionMedia {
+	if x != nil {
+		return x.MotionMedia
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetMediaMetadata() *MediaMetadata {
+	if x != nil {
+		return x.MediaMetadata
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetTranscript() *AudioTranscript {
+	if x != nil {
+		return x.Transcript
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetImageAnalysis() *ImageAnalysis {
+	if x != nil {
+		return x.ImageAnalysis
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetMediaPreview() *MediaPreview {
+	if x != nil {
+		return x.MediaPreview
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetContentScan() *ContentScan {
+	if x != nil {
+		return x.ContentScan
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetDocument() *DocumentExtraction {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+type isAttachmentPayload_Payload interface {
+	isAttachmentPayload_Payload()
+}
+
+type AttachmentPayload_S3Object_ struct {
+	S3Object *AttachmentPayload_S3Object `protobuf:"bytes,1,opt,name=s3_object,json=s3Object,proto3,oneof"`
+}
+
+type AttachmentPayload_StorageObject_ struct {
+	StorageObject *AttachmentPayload_StorageObject `protobuf:"bytes,2,opt,name=storage_object,json=storageObject,proto3,oneof"`
+}
+
+func (*AttachmentPayload_S3Object_) isAttachmentPayload_Payload() {}
+
+func (*AttachmentPayload_StorageObject_) isAttachmentPayload_Payload() {}
+
+// UploadSessionPayload tracks where the content of a resumable upload is
+// accumulated until it is finalized into an attachment.
+type UploadSessionPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// attachment_uid is the uid reserved for the finalized attachment.
+	AttachmentUid string `protobuf:"bytes,1,opt,name=attachment_uid,json=attachmentUid,proto3" json:"attachment_uid,omitempty"`
+	// memo_id links the finalized attachment to a memo; zero for none.
+	MemoId int32 `protobuf:"varint,2,opt,name=memo_id,json=memoId,proto3" json:"memo_id,omitempty"`
+	// chunk_size is the size every chunk except the last must have.
+	ChunkSize int64 `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*UploadSessionPayload_StagingPath
+	//	*UploadSessionPayload_MultipartUpload_
+	//	*UploadSessionPayload_DirectUpload_
+	Target        isUploadSessionPayload_Target `protobuf_oneof:"target"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadSessionPayload) Reset() {
+	*x = UploadSessionPayload{}
+	mi := &file_store_attachment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSessionPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSessionPayload) ProtoMessage() {}
+
+func (x *UploadSessionPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSessionPayload.ProtoReflect.Descriptor instead.
+func (*UploadSessionPayload) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UploadSessionPayload) GetAttachmentUid() string {
+	if x != nil {
+		return x.AttachmentUid
+	}
+	return ""
+}
+
+func (x *UploadSessionPayload) GetMemoId() int32 {
+	if x != nil {
+		return x.MemoId
+	}
+	return 0
+}
+
+func (x *UploadSessionPayload) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *UploadSessionPayload) GetTarget() isUploadSessionPayload_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *UploadSessionPayload) GetStagingPath() string {
+	if x != nil {
+		if x, ok := x.Target.(*UploadSessionPayload_StagingPath); ok {
+			return x.StagingPath
+		}
+	}
+	return ""
+}
+
+func (x *UploadSessionPayload) GetMultipartUpload() *UploadSessionPayload_MultipartUpload {
+	if x != nil {
+		if x, ok := x.Target.(*UploadSessionPayload_MultipartUpload_); ok {
+			return x.MultipartUpload
+		}
+	}
+	return nil
+}
+
+func (x *UploadSessionPayload) GetDirectUpload() *UploadSessionPayload_DirectUpload {
+	if x != nil {
+		if x, ok := x.Target.(*UploadSessionPayload_DirectUpload_); ok {
+			return x.DirectUpload
+		}
+	}
+	return nil
+}
+
+type isUploadSessionPayload_Target interface {
+	isUploadSessionPayload_Target()
+}
+
+type UploadSessionPayload_StagingPath struct {
+	// staging_path is the file, relative to the data directory, that chunks
+	// are appended to.
+	StagingPath string `protobuf:"bytes,4,opt,name=staging_path,json=stagingPath,proto3,oneof"`
+}
+
+type UploadSessionPayload_MultipartUpload_ struct {
+	// multipart_upload streams chunks straight to an S3 multipart upload.
+	MultipartUpload *UploadSessionPayload_MultipartUpload `protobuf:"bytes,5,opt,name=multipart_upload,json=multipartUpload,proto3,oneof"`
+}
+
+type UploadSessionPayload_DirectUpload_ struct {
+	// direct_upload receives the whole content from the client through a
+	// presigned PUT URL.
+	DirectUpload *UploadSessionPayload_DirectUpload `protobuf:"bytes,6,opt,name=direct_upload,json=directUpload,proto3,oneof"`
+}
+
+func (*UploadSessionPayload_StagingPath) isUploadSessionPayload_Target() {}
+
+func (*UploadSessionPayload_MultipartUpload_) isUploadSessionPayload_Target() {}
+
+func (*UploadSessionPayload_DirectUpload_) isUploadSessionPayload_Target() {}
+
+type AudioTranscript_Segment struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Text         string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	StartSeconds float64                `protobuf:"fixed64,2,opt,name=start_seconds,json=startSeconds,proto3" json:"start_seconds,omitempty"`
+	EndSeconds   float64                `protobuf:"fixed64,3,opt,name=end_seconds,json=endSeconds,proto3" json:"end_seconds,omitempty"`
+	// speaker is empty unless the model returns speaker labels.
+	Speaker       string `protobuf:"bytes,4,opt,name=speaker,proto3" json:"speaker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AudioTranscript_Segment) Reset() {
+	*x = AudioTranscript_Segment{}
+	mi := &file_store_attachment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AudioTranscript_Segment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioTranscript_Segment) ProtoMessage() {}
+
+func (x *AudioTranscript_Segment) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioTranscript_Segment.ProtoReflect.Descriptor instead.
+func (*AudioTranscript_Segment) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *AudioTranscript_Segment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *AudioTranscript_Segment) GetStartSeconds() float64 {
+	if x != nil {
+		return x.StartSeconds
+	}
+	return 0
+}
+
+func (x *AudioTranscript_Segment) GetEndSeconds() float64 {
+	if x != nil {
+		return x.EndSeconds
+	}
+	return 0
+}
+
+func (x *AudioTranscript_Segment) GetSpeaker() string {
+	if x != nil {
+		return x.Speaker
+	}
+	return ""
+}
+
+type AttachmentPayload_S3Object struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Legacy attachments embedded their complete S3 configuration.
+	// New attachments resolve storage through storage_id.
+	S3Config *StorageS3Config `protobuf:"bytes,1,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
+	// key is the S3 object key.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// storage_id identifies the configured storage containing this object.
+	StorageId     string `protobuf:"bytes,4,opt,name=storage_id,json=storageId,proto3" json:"storage_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentPayload_S3Object) Reset() {
+	*x = AttachmentPayload_S3Object{}
+	mi := &file_store_attachment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentPayload_S3Object) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentPayload_S3Object) ProtoMessage() {}
+
+func (x *AttachmentPayload_S3Object) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentPayload_S3Object.ProtoReflect.Descriptor instead.
+func (*AttachmentPayload_S3Object) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *AttachmentPayload_S3Object) GetS3Config() *StorageS3Config {
+	if x != nil {
+		return x.S3Config
+	}
+	return nil
+}
+
+func (x *AttachmentPayload_S3Object) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AttachmentPayload_S3Object) GetStorageId() string {
+	if x != nil {
+		return x.StorageId
+	}
+	return ""
+}
+
+type AttachmentPayload_StorageObject struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// storage_id identifies the configured storage containing this object.
+	StorageId string `protobuf:"bytes,1,opt,name=storage_id,json=storageId,proto3" json:"storage_id,omitempty"`
+	// key is the slash-separated object path within the storage.
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentPayload_StorageObject) Reset() {
+	*x = AttachmentPayload_StorageObject{}
+	mi := &file_store_attachment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentPayload_StorageObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentPayload_StorageObject) ProtoMessage() {}
+
+func (x *AttachmentPayload_StorageObject) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentPayload_StorageObject.ProtoReflect.Descriptor instead.
+func (*AttachmentPayload_StorageObject) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{11, 1}
+}
+
+func (x *AttachmentPayload_StorageObject) GetStorageId() string {
+	if x != nil {
+		return x.StorageId
+	}
+	return ""
+}
+
+func (x *AttachmentPayload_StorageObject) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type UploadSessionPayload_MultipartUpload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// storage_id identifies the configured storage receiving the upload.
+	StorageId string `protobuf:"bytes,1,opt,name=storage_id,json=storageId,proto3" json:"storage_id,omitempty"`
+	// key is the S3 staging key the client uploads to. Completion copies the
+	// object to a fresh key that is never presigned.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// upload_id is the S3 multipart upload id.
+	UploadId string `protobuf:"bytes,3,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	// parts are the uploaded parts in order.
+	Parts         []*UploadSessionPayload_MultipartUpload_Part `protobuf:"bytes,4,rep,name=parts,proto3" json:"parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadSessionPayload_MultipartUpload) Reset() {
+	*x = UploadSessionPayload_MultipartUpload{}
+	mi := &file_store_attachment_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSessionPayload_MultipartUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSessionPayload_MultipartUpload) ProtoMessage() {}
+
+func (x *UploadSessionPayload_MultipartUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSessionPayload_MultipartUpload.ProtoReflect.Descriptor instead.
+func (*UploadSessionPayload_MultipartUpload) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{12, 0}
+}
+
+func (x *UploadSessionPayload_MultipartUpload) GetStorageId() string {
+	if x != nil {
+		return x.StorageId
+	}
+	return ""
+}
+
+func (x *UploadSessionPayload_MultipartUpload) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UploadSessionPayload_MultipartUpload) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadSessionPayload_MultipartUpload) GetParts() []*UploadSessionPayload_MultipartUpload_Part {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+type UploadSessionPayload_DirectUpload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// storage_id identifies the configured storage receiving the upload.
+	StorageId string `protobuf:"bytes,1,opt,name=storage_id,json=storageId,proto3" json:"storage_id,omitempty"`
+	// key is the S3 staging key the client uploads to. Completion copies the
+	// object to a fresh key that is never presigned.
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadSessionPayload_DirectUpload) Reset() {
+	*x = UploadSessionPayload_DirectUpload{}
+	mi := &file_store_attachment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSessionPayload_DirectUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSessionPayload_DirectUpload) ProtoMessage() {}
+
+func (x *UploadSessionPayload_DirectUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSessionPayload_DirectUpload.ProtoReflect.Descriptor instead.
+func (*UploadSessionPayload_DirectUpload) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{12, 1}
+}
+
+func (x *UploadSessionPayload_DirectUpload) GetStorageId() string {
+	if x != nil {
+		return x.StorageId
+	}
+	return ""
+}
+
+func (x *UploadSessionPayload_DirectUpload) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type UploadSessionPayload_MultipartUpload_Part struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadSessionPayload_MultipartUpload_Part) Reset() {
+	*x = UploadSessionPayload_MultipartUpload_Part{}
+	mi := &file_store_attachment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSessionPayload_MultipartUpload_Part) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSessionPayload_MultipartUpload_Part) ProtoMessage() {}
+
+func (x *UploadSessionPayload_MultipartUpload_Part) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSessionPayload_MultipartUpload_Part.ProtoReflect.Descriptor instead.
+func (*UploadSessionPayload_MultipartUpload_Part) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{12, 0, 0}
+}
+
+func (x *UploadSessionPayload_MultipartUpload_Part) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadSessionPayload_MultipartUpload_Part) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+var File_store_attachment_proto protoreflect.FileDescriptor
+
+const file_store_attachment_proto_rawDesc = "" +
+	"\n" +
+	"\x16store/attachment.proto\x12\vmemos.store\x1a\x1cstore/instance_setting.proto\"\xfc\x01\n" +
+	"\vMotionMedia\x126\n" +
+	"\x06family\x18\x01 \x01(\x0e2\x1e.memos.store.MotionMediaFamilyR\x06family\x120\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x1c.memos.store.MotionMediaRoleR\x04role\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12:\n" +
+	"\x19presentation_timestamp_us\x18\x04 \x01(\x03R\x17presentationTimestampUs\x12,\n" +
+	"\x12has_embedded_video\x18\x05 \x01(\bR\x10hasEmbeddedVideo\"\xcf\x01\n" +
+	"\rMediaMetadata\x12\x19\n" +
+	"\x05width\x18\x01 \x01(\x05H\x01R\x05width\x88\x01\x01\x12\x1b\n" +
+	"\x06height\x18\x02 \x01(\x05H\x02R\x06height\x88\x01\x01\x122\n" +
+	"\x05photo\x18\x03 \x01(\v2\x1a.memos.store.PhotoMetadataH\x00R\x05photo\x122\n" +
+	"\x05video\x18\x04 \x01(\v2\x1a.memos.store.VideoMetadataH\x00R\x05videoB\t\n" +
+	"\adetailsB\b\n" +
+	"\x06_widthB\t\n" +
+	"\a_height\"\xa5\x04\n" +
+	"\rPhotoMetadata\x12@\n" +
+	"\fcapture_time\x18\x01 \x01(\v2\x1d.memos.store.MediaCaptureTimeR\vcaptureTime\x126\n" +
+	"\blocation\x18\x02 \x01(\v2\x1a.memos.store.MediaLocationR\blocation\x12;\n" +
+	"\x17source_exif_orientation\x18\x03 \x01(\x05H\x00R\x15sourceExifOrientation\x88\x01\x01\x12\x1f\n" +
+	"\vcamera_make\x18\x04 \x01(\tR\n" +
+	"cameraMake\x12!\n" +
+	"\fcamera_model\x18\x05 \x01(\tR\vcameraModel\x12\x1d\n" +
+	"\n" +
+	"lens_model\x18\x06 \x01(\tR\tlensModel\x12\x1e\n" +
+	"\bf_number\x18\a \x01(\x01H\x01R\afNumber\x88\x01\x01\x127\n" +
+	"\x15exposure_time_seconds\x18\b \x01(\x01H\x02R\x13exposureTimeSeconds\x88\x01\x01\x12\x15\n" +
+	"\x03iso\x18\t \x01(\x05H\x03R\x03iso\x88\x01\x01\x12+\n" +
+	"\x0ffocal_length_mm\x18\n" +
+	" \x01(\x01H\x04R\rfocalLengthMm\x88\x01\x01B\x1a\n" +
+	"\x18_source_exif_orientationB\v\n" +
+	"\t_f_numberB\x18\n" +
+	"\x16_exposure_time_secondsB\x06\n" +
+	"\x04_isoB\x12\n" +
+	"\x10_focal_length_mm\"m\n" +
+	"\x10MediaCaptureTime\x12&\n" +
+	"\x0flocal_date_time\x18\x01 \x01(\tR\rlocalDateTime\x12\"\n" +
+	"\n" +
+	"utc_offset\x18\x02 \x01(\tH\x00R\tutcOffset\x88\x01\x01B\r\n" +
+	"\v_utc_offset\"\xb0\x01\n" +
+	"\rMediaLocation\x12\x1f\n" +
+	"\blatitude\x18\x01 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x02 \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12,\n" +
+	"\x0faltitude_meters\x18\x03 \x01(\x01H\x02R\x0ealtitudeMeters\x88\x01\x01B\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\x12\n" +
+	"\x10_altitude_meters\"T\n" +
+	"\rVideoMetadata\x12.\n" +
+	"\x10duration_seconds\x18\x01 \x01(\x01H\x00R\x0fdurationSeconds\x88\x01\x01B\x13\n" +
+	"\x11_duration_seconds\"\xb5\x02\n" +
+	"\x0fAudioTranscript\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12@\n" +
+	"\bsegments\x18\x03 \x03(\v2$.memos.store.AudioTranscript.SegmentR\bsegments\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x1b\n" +
+	"\tcreate_ts\x18\x05 \x01(\x03R\bcreateTs\x1a}\n" +
+	"\aSegment\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12#\n" +
+	"\rstart_seconds\x18\x02 \x01(\x01R\fstartSeconds\x12\x1f\n" +
+	"\vend_seconds\x18\x03 \x01(\x01R\n" +
+	"endSeconds\x12\x18\n" +
+	"\aspeaker\x18\x04 \x01(\tR\aspeaker\"r\n" +
+	"\rImageAnalysis\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x18\n" +
+	"\acaption\x18\x02 \x01(\tR\acaption\x12\x16\n" +
+	"\x06engine\x18\x03 \x01(\tR\x06engine\x12\x1b\n" +
+	"\tcreate_ts\x18\x04 \x01(\x03R\bcreateTs\"q\n" +
+	"\fMediaPreview\x12\x1d\n" +
+	"\n" +
+	"has_poster\x18\x01 \x01(\bR\thasPoster\x12%\n" +
+	"\x0ewaveform_peaks\x18\x02 \x03(\x02R\rwaveformPeaks\x12\x1b\n" +
+	"\tcreate_ts\x18\x03 \x01(\x03R\bcreateTs\"\x82\x01\n" +
+	"\x12DocumentExtraction\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\x02 \x01(\x05R\tpageCount\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x12\x1b\n" +
+	"\tcreate_ts\x18\x04 \x01(\x03R\bcreateTs\"\xde\x01\n" +
+	"\vContentScan\x127\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1f.memos.store.ContentScan.StatusR\x06status\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignature\x12\x18\n" +
+	"\ascanner\x18\x03 \x01(\tR\ascanner\x12\x17\n" +
+	"\ascan_ts\x18\x04 \x01(\x03R\x06scanTs\"E\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05CLEAN\x10\x01\x12\f\n" +
+	"\bINFECTED\x10\x02\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x03\"\xce\x06\n" +
+	"\x11AttachmentPayload\x12F\n" +
+	"\ts3_object\x18\x01 \x01(\v2'.memos.store.AttachmentPayload.S3ObjectH\x00R\bs3Object\x12U\n" +
+	"\x0estorage_object\x18\x02 \x01(\v2,.memos.store.AttachmentPayload.StorageObjectH\x00R\rstorageObject\x12;\n" +
+	"\fmotion_media\x18\n" +
+	" \x01(\v2\x18.memos.store.MotionMediaR\vmotionMedia\x12A\n" +
+	"\x0emedia_metadata\x18\v \x01(\v2\x1a.memos.store.MediaMetadataR\rmediaMetadata\x12<\n" +
+	"\n" +
+	"transcript\x18\f \x01(\v2\x1c.memos.store.AudioTranscriptR\n" +
+	"transcript\x12A\n" +
+	"\x0eimage_analysis\x18\r \x01(\v2\x1a.memos.store.ImageAnalysisR\rimageAnalysis\x12>\n" +
+	"\rmedia_preview\x18\x0e \x01(\v2\x19.memos.store.MediaPreviewR\fmediaPreview\x12;\n" +
+	"\fcontent_scan\x18\x0f \x01(\v2\x18.memos.store.ContentScanR\vcontentScan\x12;\n" +
+	"\bdocument\x18\x10 \x01(\v2\x1f.memos.store.DocumentExtractionR\bdocument\x1a\x91\x01\n" +
+	"\bS3Object\x129\n" +
+	"\ts3_config\x18\x01 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1d\n" +
+	"\n" +
+	"storage_id\x18\x04 \x01(\tR\tstorageIdJ\x04\b\x03\x10\x04R\x13last_presigned_time\x1a@\n" +
+	"\rStorageObject\x12\x1d\n" +
+	"\n" +
+	"storage_id\x18\x01 \x01(\tR\tstorageId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03keyB\t\n" +
+	"\apayload\"\x89\x05\n" +
+	"\x14UploadSessionPayload\x12%\n" +
+	"\x0eattachment_uid\x18\x01 \x01(\tR\rattachmentUid\x12\x17\n" +
+	"\amemo_id\x18\x02 \x01(\x05R\x06memoId\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x03 \x01(\x03R\tchunkSize\x12#\n" +
+	"\fstaging_path\x18\x04 \x01(\tH\x00R\vstagingPath\x12^\n" +
+	"\x10multipart_upload\x18\x05 \x01(\v21.memos.store.UploadSessionPayload.MultipartUploadH\x00R\x0fmultipartUpload\x12U\n" +
+	"\rdirect_upload\x18\x06 \x01(\v2..memos.store.UploadSessionPayload.DirectUploadH\x00R\fdirectUpload\x1a\xea\x01\n" +
+	"\x0fMultipartUpload\x12\x1d\n" +
+	"\n" +
+	"storage_id\x18\x01 \x01(\tR\tstorageId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1b\n" +
+	"\tupload_id\x18\x03 \x01(\tR\buploadId\x12L\n" +
+	"\x05parts\x18\x04 \x03(\v26.memos.store.UploadSessionPayload.MultipartUpload.PartR\x05parts\x1a;\n" +
+	"\x04Part\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x1a?\n" +
+	"\fDirectUpload\x12\x1d\n" +
+	"\n" +
+	"storage_id\x18\x01 \x01(\tR\tstorageId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03keyB\b\n" +
+	"\x06target*m\n" +
+	"\x15AttachmentStorageType\x12'\n" +
+	"#ATTACHMENT_STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05LOCAL\x10\x01\x12\x06\n" +
+	"\x02S3\x10\x02\x12\f\n" +
+	"\bEXTERNAL\x10\x03\x12\n" +
+	"\n" +
+	"\x06OBJECT\x10\x04*h\n" +
+	"\x11MotionMediaFamily\x12#\n" +
+	"\x1fMOTION_MEDIA_FAMILY_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10APPLE_LIVE_PHOTO\x10\x01\x12\x18\n" +
+	"\x14ANDROID_MOTION_PHOTO\x10\x02*Y\n" +
+	"\x0fMotionMediaRole\x12!\n" +
+	"\x1dMOTION_MEDIA_ROLE_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05STILL\x10\x01\x12\t\n" +
+	"\x05VIDEO\x10\x02\x12\r\n" +
+	"\tCONTAINER\x10\x03B\x9a\x01\n" +
+	"\x0fcom.memos.storeB\x0fAttachmentProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
+
+var (
+	file_store_attachment_proto_rawDescOnce sync.Once
+	file_store_attachment_proto_rawDescData []byte
+)
+
+func file_store_attachment_proto_rawDescGZIP() []byte {
+	file_store_attachment_proto_rawDescOnce.Do(func() {
+		file_store_attachment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_store_attachment_proto_rawDesc), len(file_store_attachment_proto_rawDesc)))
+	})
+	return file_store_attachment_proto_rawDescData
+}
+
+var file_store_attachment_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_store_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_store_attachment_proto_goTypes = []any{
+	(AttachmentStorageType)(0),                        // 0: memos.store.AttachmentStorageType
+	(MotionMediaFamily)(0),                            // 1: memos.store.MotionMediaFamily
+	(MotionMediaRole)(0),                              // 2: memos.store.MotionMediaRole
+	(ContentScan_Status)(0),                           // 3: memos.store.ContentScan.Status
+	(*MotionMedia)(nil),                               // 4: memos.store.MotionMedia
+	(*MediaMetadata)(nil),                             // 5: memos.store.MediaMetadata
+	(*PhotoMetadata)(nil),                             // 6: memos.store.PhotoMetadata
+	(*MediaCaptureTime)(nil),                          // 7: memos.store.MediaCaptureTime
+	(*MediaLocation)(nil),                             // 8: memos.store.MediaLocation
+	(*VideoMetadata)(nil),                             // 9: memos.store.VideoMetadata
+	(*AudioTranscript)(nil),                           // 10: memos.store.AudioTranscript
+	(*ImageAnalysis)(nil),                             // 11: memos.store.ImageAnalysis
+	(*MediaPreview)(nil),                              // 12: memos.store.MediaPreview
+	(*DocumentExtraction)(nil),                        // 13: memos.store.DocumentExtraction
+	(*ContentScan)(nil),                               // 14: memos.store.ContentScan
+	(*AttachmentPayload)(nil),                         // 15: memos.store.AttachmentPayload
+	(*UploadSessionPayload)(nil),                      // 16: memos.store.UploadSessionPayload
+	(*AudioTranscript_Segment)(nil),                   // 17: memos.store.AudioTranscript.Segment
+	(*AttachmentPayload_S3Object)(nil),                // 18: memos.store.AttachmentPayload.S3Object
+	(*AttachmentPayload_StorageObject)(nil),           // 19: memos.store.AttachmentPayload.StorageObject
+	(*UploadSessionPayload_MultipartUpload)(nil),      // 20: memos.store.UploadSessionPayload.MultipartUpload
+	(*UploadSessionPayload_DirectUpload)(nil),         // 21: memos.store.UploadSessionPayload.DirectUpload
+	(*UploadSessionPayload_MultipartUpload_Part)(nil), // 22: memos.store.UploadSessionPayload.MultipartUpload.Part
+	(*StorageS3Config)(nil),                           // 23: memos.store.StorageS3Config
+}
+var file_store_attachment_proto_depIdxs = []int32{
+	1,  // 0: memos.store.MotionMedia.family:type_name -> memos.store.MotionMediaFamily
+	2,  // 1: memos.store.MotionMedia.role:type_name -> memos.store.MotionMediaRole
+	6,  // 2: memos.store.MediaMetadata.photo:type_name -> memos.store.PhotoMetadata
+	9,  // 3: memos.store.MediaMetadata.video:type_name -> memos.store.VideoMetadata
+	7,  // 4: memos.store.PhotoMetadata.capture_time:type_name -> memos.store.MediaCaptureTime
+	8,  // 5: memos.store.PhotoMetadata.location:type_name -> memos.store.MediaLocation
+	17, // 6: memos.store.AudioTranscript.segments:type_name -> memos.store.AudioTranscript.Segment
+	3,  // 7: memos.store.ContentScan.status:type_name -> memos.store.ContentScan.Status
+	18, // 8: memos.store.AttachmentPayload.s3_object:type_name -> memos.store.AttachmentPayload.S3Object
+	19, // 9: memos.store.AttachmentPayload.storage_object:type_name -> memos.store.AttachmentPayload.StorageObject
+	4,  // 10: memos.store.AttachmentPayload.motion_media:type_name -> memos.store.MotionMedia
+	5,  // 11: memos.store.AttachmentPayload.media_metadata:type_name -> memos.store.MediaMetadata
+	10, // 12: memos.store.AttachmentPayload.transcript:type_name -> memos.store.AudioTranscript
+	11, // 13: memos.store.AttachmentPayload.image_analysis:type_name -> memos.store.ImageAnalysis
+	12, // 14: memos.store.AttachmentPayload.media_preview:type_name -> memos.store.MediaPreview
+	14, // 15: memos.store.AttachmentPayload.content_scan:type_name -> memos.store.ContentScan
+	13, // 16: memos.store.AttachmentPayload.document:type_name -> memos.store.DocumentExtraction
+	20, // 17: memos.store.UploadSessionPayload.multipart_upload:type_name -> memos.store.UploadSessionPayload.MultipartUpload
+	21, // 18: memos.store.UploadSessionPayload.direct_upload:type_name -> memos.store.UploadSessionPayload.DirectUpload
+	23, // 19: memos.store.AttachmentPayload.S3Object.s3_config:type_name -> memos.store.StorageS3Config
+	22, // 20: memos.store.UploadSessionPayload.MultipartUpload.parts:type_name -> memos.store.UploadSessionPayload.MultipartUpload.Part
+	21, // [21:21] is the sub-list for method output_type
+	21, // [21:21] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_store_attachment_proto_init() }
+func file_store_attachment_proto_init() {
+	if File_store_attachment_proto != nil {
+		return
+	}
+	file_store_instance_setting_proto_init()
+	file_store_attachment_proto_msgTypes[1].OneofWrappers = []any{
+		(*MediaMetadata_Photo)(nil),
+		(*MediaMetadata_Video)(nil),
+	}
+	file_store_attachment_proto_msgTypes[2].OneofWrappers = []any{}
+	file_store_attachment_proto_msgTypes[3].OneofWrappers = []any{}
+	file_store_attachment_proto_msgTypes[4].OneofWrappers = []any{}
+	file_store_attachment_proto_msgTypes[5].OneofWrappers = []any{}
+	file_store_attachment_proto_msgTypes[11].OneofWrappers = []any{
+		(*AttachmentPayload_S3Object_)(nil),
+		(*AttachmentPayload_StorageObject_)(nil),
+	}
+	file_store_attachment_proto_msgTypes[12].OneofWrappers = []any{
+		(*UploadSessionPayload_StagingPath)(nil),
+		(*UploadSessionPayload_MultipartUpload_)(nil),
+		(*UploadSessionPayload_DirectUpload_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_attachment_proto_rawDesc), len(file_store_attachment_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   8,
+			NumEnums:      4,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.11
+// 	protoc-gen-go v1.36.12
 // 	protoc        (unknown)
 // source: store/inbox.proto
 
@@ -29,6 +29,10 @@ const (
 	InboxMessage_MEMO_COMMENT InboxMessage_Type = 1
 	// Memo mention notification.
 	InboxMessage_MEMO_MENTION InboxMessage_Type = 2
+	// A webhook was disabled after repeated delivery failures.
+	InboxMessage_WEBHOOK_DISABLED InboxMessage_Type = 3
+	// An upload was found infected by the content scanner.
+	InboxMessage_ATTACHMENT_INFECTED InboxMessage_Type = 4
 )
 
 // Enum value maps for InboxMessage_Type.
@@ -37,11 +41,15 @@ var (
 		0: "TYPE_UNSPECIFIED",
 		1: "MEMO_COMMENT",
 		2: "MEMO_MENTION",
+		3: "WEBHOOK_DISABLED",
+		4: "ATTACHMENT_INFECTED",
 	}
 	InboxMessage_Type_value = map[string]int32{
-		"TYPE_UNSPECIFIED": 0,
-		"MEMO_COMMENT":     1,
-		"MEMO_MENTION":     2,
+		"TYPE_UNSPECIFIED":    0,
+		"MEMO_COMMENT":        1,
+		"MEMO_MENTION":        2,
+		"WEBHOOK_DISABLED":    3,
+		"ATTACHMENT_INFECTED": 4,
 	}
 )
 
@@ -80,6 +88,8 @@ type InboxMessage struct {
 	//
 	//	*InboxMessage_MemoComment
 	//	*InboxMessage_MemoMention
+	//	*InboxMessage_WebhookDisabled
+	//	*InboxMessage_AttachmentInfected
 	Payload       isInboxMessage_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -147,6 +157,24 @@ func (x *InboxMessage) GetMemoMention() *InboxMessage_MemoMentionPayload {
 	return nil
 }
 
+func (x *InboxMessage) GetWebhookDisabled() *InboxMessage_WebhookDisabledPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*InboxMessage_WebhookDisabled); ok {
+			return x.WebhookDisabled
+		}
+	}
+	return nil
+}
+
+func (x *InboxMessage) GetAttachmentInfected() *InboxMessage_AttachmentInfectedPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*InboxMessage_AttachmentInfected); ok {
+			return x.AttachmentInfected
+		}
+	}
+	return nil
+}
+
 type isInboxMessage_Payload interface {
 	isInboxMessage_Payload()
 }
@@ -159,10 +187,22 @@ type InboxMessage_MemoMention struct {
 	MemoMention *InboxMessage_MemoMentionPayload `protobuf:"bytes,3,opt,name=memo_mention,json=memoMention,proto3,oneof"`
 }
 
+type InboxMessage_WebhookDisabled struct {
+	WebhookDisabled *InboxMessage_WebhookDisabledPayload `protobuf:"bytes,4,opt,name=webhook_disabled,json=webhookDisabled,proto3,oneof"`
+}
+
+type InboxMessage_AttachmentInfected struct {
+	AttachmentInfected *InboxMessage_AttachmentInfectedPayload `protobuf:"bytes,5,opt,name=attachment_infected,json=attachmentInfected,proto3,oneof"`
+}
+
 func (*InboxMessage_MemoComment) isInboxMessage_Payload() {}
 
 func (*InboxMessage_MemoMention) isInboxMessage_Payload() {}
 
+func (*InboxMessage_WebhookDisabled) isInboxMessage_Payload() {}
+
+func (*InboxMessage_AttachmentInfected) isInboxMessage_Payload() {}
+
 type InboxMessage_MemoCommentPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MemoId        int32                  `protobuf:"varint,1,opt,name=memo_id,json=memoId,proto3" json:"memo_id,omitempty"`
@@ -267,25 +307,169 @@ func (x *InboxMessage_MemoMentionPayload) GetRelatedMemoId() int32 {
 	return 0
 }
 
+type InboxMessage_WebhookDisabledPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	LastError     string                 `protobuf:"bytes,2,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	WebhookTitle  string                 `protobuf:"bytes,3,opt,name=webhook_title,json=webhookTitle,proto3" json:"webhook_title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InboxMessage_WebhookDisabledPayload) Reset() {
+	*x = InboxMessage_WebhookDisabledPayload{}
+	mi := &file_store_inbox_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboxMessage_WebhookDisabledPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboxMessage_WebhookDisabledPayload) ProtoMessage() {}
+
+func (x *InboxMessage_WebhookDisabledPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_inbox_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboxMessage_WebhookDisabledPayload.ProtoReflect.Descriptor instead.
+func (*InboxMessage_WebhookDisabledPayload) Descriptor() ([]byte, []int) {
+	return file_store_inbox_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *InboxMessage_WebhookDisabledPayload) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *InboxMessage_WebhookDisabledPayload) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *InboxMessage_WebhookDisabledPayload) GetWebhookTitle() string {
+	if x != nil {
+		return x.WebhookTitle
+	}
+	return ""
+}
+
+type InboxMessage_AttachmentInfectedPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// attachment_uid is empty when the upload was rejected.
+	AttachmentUid string `protobuf:"bytes,1,opt,name=attachment_uid,json=attachmentUid,proto3" json:"attachment_uid,omitempty"`
+	Filename      string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Signature     string `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Quarantined   bool   `protobuf:"varint,4,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InboxMessage_AttachmentInfectedPayload) Reset() {
+	*x = InboxMessage_AttachmentInfectedPayload{}
+	mi := &file_store_inbox_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboxMessage_AttachmentInfectedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboxMessage_AttachmentInfectedPayload) ProtoMessage() {}
+
+func (x *InboxMessage_AttachmentInfectedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_inbox_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboxMessage_AttachmentInfectedPayload.ProtoReflect.Descriptor instead.
+func (*InboxMessage_AttachmentInfectedPayload) Descriptor() ([]byte, []int) {
+	return file_store_inbox_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *InboxMessage_AttachmentInfectedPayload) GetAttachmentUid() string {
+	if x != nil {
+		return x.AttachmentUid
+	}
+	return ""
+}
+
+func (x *InboxMessage_AttachmentInfectedPayload) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *InboxMessage_AttachmentInfectedPayload) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *InboxMessage_AttachmentInfectedPayload) GetQuarantined() bool {
+	if x != nil {
+		return x.Quarantined
+	}
+	return false
+}
+
 var File_store_inbox_proto protoreflect.FileDescriptor
 
 const file_store_inbox_proto_rawDesc = "" +
 	"\n" +
-	"\x11store/inbox.proto\x12\vmemos.store\"\xe3\x03\n" +
+	"\x11store/inbox.proto\x12\vmemos.store\"\xf7\a\n" +
 	"\fInboxMessage\x122\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x1e.memos.store.InboxMessage.TypeR\x04type\x12Q\n" +
 	"\fmemo_comment\x18\x02 \x01(\v2,.memos.store.InboxMessage.MemoCommentPayloadH\x00R\vmemoComment\x12Q\n" +
-	"\fmemo_mention\x18\x03 \x01(\v2,.memos.store.InboxMessage.MemoMentionPayloadH\x00R\vmemoMention\x1aU\n" +
+	"\fmemo_mention\x18\x03 \x01(\v2,.memos.store.InboxMessage.MemoMentionPayloadH\x00R\vmemoMention\x12]\n" +
+	"\x10webhook_disabled\x18\x04 \x01(\v20.memos.store.InboxMessage.WebhookDisabledPayloadH\x00R\x0fwebhookDisabled\x12f\n" +
+	"\x13attachment_infected\x18\x05 \x01(\v23.memos.store.InboxMessage.AttachmentInfectedPayloadH\x00R\x12attachmentInfected\x1aU\n" +
 	"\x12MemoCommentPayload\x12\x17\n" +
 	"\amemo_id\x18\x01 \x01(\x05R\x06memoId\x12&\n" +
 	"\x0frelated_memo_id\x18\x02 \x01(\x05R\rrelatedMemoId\x1aU\n" +
 	"\x12MemoMentionPayload\x12\x17\n" +
 	"\amemo_id\x18\x01 \x01(\x05R\x06memoId\x12&\n" +
-	"\x0frelated_memo_id\x18\x02 \x01(\x05R\rrelatedMemoId\"@\n" +
+	"\x0frelated_memo_id\x18\x02 \x01(\x05R\rrelatedMemoId\x1a{\n" +
+	"\x16WebhookDisabledPayload\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x02 \x01(\tR\tlastError\x12#\n" +
+	"\rwebhook_title\x18\x03 \x01(\tR\fwebhookTitle\x1a\x9e\x01\n" +
+	"\x19AttachmentInfectedPayload\x12%\n" +
+	"\x0eattachment_uid\x18\x01 \x01(\tR\rattachmentUid\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\tR\tsignature\x12 \n" +
+	"\vquarantined\x18\x04 \x01(\bR\vquarantined\"o\n" +
 	"\x04Type\x12\x14\n" +
 	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
 	"\fMEMO_COMMENT\x10\x01\x12\x10\n" +
-	"\fMEMO_MENTION\x10\x02B\t\n" +
+	"\fMEMO_MENTION\x10\x02\x12\x14\n" +
+	"\x10WEBHOOK_DISABLED\x10\x03\x12\x17\n" +
+	"\x13ATTACHMENT_INFECTED\x10\x04B\t\n" +
 	"\apayloadB\x95\x01\n" +
 	"\x0fcom.memos.storeB\n" +
 	"InboxProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
@@ -303,22 +487,26 @@ func file_store_inbox_proto_rawDescGZIP() []byte {
 }
 
 var file_store_inbox_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_inbox_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_store_inbox_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_store_inbox_proto_goTypes = []any{
-	(InboxMessage_Type)(0),                  // 0: memos.store.InboxMessage.Type
-	(*InboxMessage)(nil),                    // 1: memos.store.InboxMessage
-	(*InboxMessage_MemoCommentPayload)(nil), // 2: memos.store.InboxMessage.MemoCommentPayload
-	(*InboxMessage_MemoMentionPayload)(nil), // 3: memos.store.InboxMessage.MemoMentionPayload
+	(InboxMessage_Type)(0),                         // 0: memos.store.InboxMessage.Type
+	(*InboxMessage)(nil),                           // 1: memos.store.InboxMessage
+	(*InboxMessage_MemoCommentPayload)(nil),        // 2: memos.store.InboxMessage.MemoCommentPayload
+	(*InboxMessage_MemoMentionPayload)(nil),        // 3: memos.store.InboxMessage.MemoMentionPayload
+	(*InboxMessage_WebhookDisabledPayload)(nil),    // 4: memos.store.InboxMessage.WebhookDisabledPayload
+	(*InboxMessage_AttachmentInfectedPayload)(nil), // 5: memos.store.InboxMessage.AttachmentInfectedPayload
 }
 var file_store_inbox_proto_depIdxs = []int32{
 	0, // 0: memos.store.InboxMessage.type:type_name -> memos.store.InboxMessage.Type
 	2, // 1: memos.store.InboxMessage.memo_comment:type_name -> memos.store.InboxMessage.MemoCommentPayload
 	3, // 2: memos.store.InboxMessage.memo_mention:type_name -> memos.store.InboxMessage.MemoMentionPayload
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 3: memos.store.InboxMessage.webhook_disabled:type_name -> memos.store.InboxMessage.WebhookDisabledPayload
+	5, // 4: memos.store.InboxMessage.attachment_infected:type_name -> memos.store.InboxMessage.AttachmentInfectedPayload
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_store_inbox_proto_init() }
@@ -329,6 +517,8 @@ func file_store_inbox_proto_init() {
 	file_store_inbox_proto_msgTypes[0].OneofWrappers = []any{
 		(*InboxMessage_MemoComment)(nil),
 		(*InboxMessage_MemoMention)(nil),
+		(*InboxMessage_WebhookDisabled)(nil),
+		(*InboxMessage_AttachmentInfected)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -336,7 +526,7 @@ func file_store_inbox_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_inbox_proto_rawDesc), len(file_store_inbox_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
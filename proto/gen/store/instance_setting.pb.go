@@ -42,6 +42,8 @@ const (
 	InstanceSettingKey_AI InstanceSettingKey = 7
 	// ACCESS is the key for instance access policy settings.
 	InstanceSettingKey_ACCESS InstanceSettingKey = 8
+	// MEMO_TEMPLATES is the key for instance-wide memo templates.
+	InstanceSettingKey_MEMO_TEMPLATES InstanceSettingKey = 9
 )
 
 // Enum value maps for InstanceSettingKey.
@@ -56,6 +58,7 @@ var (
 		6: "NOTIFICATION",
 		7: "AI",
 		8: "ACCESS",
+		9: "MEMO_TEMPLATES",
 	}
 	InstanceSettingKey_value = map[string]int32{
 		"INSTANCE_SETTING_KEY_UNSPECIFIED": 0,
@@ -67,6 +70,7 @@ var (
 		"NOTIFICATION":                     6,
 		"AI":                               7,
 		"ACCESS":                           8,
+		"MEMO_TEMPLATES":                   9,
 	}
 )
 
@@ -107,6 +111,10 @@ const (
 	StorageType_STORAGE_TYPE_LOCAL StorageType = 2
 	// S3 stores attachment content in an S3-compatible object store.
 	StorageType_STORAGE_TYPE_S3 StorageType = 3
+	// WEBDAV stores attachment content on a WebDAV server.
+	StorageType_STORAGE_TYPE_WEBDAV StorageType = 4
+	// SFTP stores attachment content on an SFTP server.
+	StorageType_STORAGE_TYPE_SFTP StorageType = 5
 )
 
 // Enum value maps for StorageType.
@@ -116,12 +124,16 @@ var (
 		1: "STORAGE_TYPE_DATABASE",
 		2: "STORAGE_TYPE_LOCAL",
 		3: "STORAGE_TYPE_S3",
+		4: "STORAGE_TYPE_WEBDAV",
+		5: "STORAGE_TYPE_SFTP",
 	}
 	StorageType_value = map[string]int32{
 		"STORAGE_TYPE_UNSPECIFIED": 0,
 		"STORAGE_TYPE_DATABASE":    1,
 		"STORAGE_TYPE_LOCAL":       2,
 		"STORAGE_TYPE_S3":          3,
+		"STORAGE_TYPE_WEBDAV":      4,
+		"STORAGE_TYPE_SFTP":        5,
 	}
 )
 
@@ -158,6 +170,10 @@ const (
 	AIProviderType_AI_PROVIDER_TYPE_UNSPECIFIED AIProviderType = 0
 	AIProviderType_OPENAI                       AIProviderType = 1
 	AIProviderType_GEMINI                       AIProviderType = 2
+	// OPENAI_COMPATIBLE is any server implementing the OpenAI REST API at endpoint.
+	AIProviderType_OPENAI_COMPATIBLE AIProviderType = 3
+	// OLLAMA is an Ollama server reached through its native API at endpoint.
+	AIProviderType_OLLAMA AIProviderType = 4
 )
 
 // Enum value maps for AIProviderType.
@@ -166,11 +182,15 @@ var (
 		0: "AI_PROVIDER_TYPE_UNSPECIFIED",
 		1: "OPENAI",
 		2: "GEMINI",
+		3: "OPENAI_COMPATIBLE",
+		4: "OLLAMA",
 	}
 	AIProviderType_value = map[string]int32{
 		"AI_PROVIDER_TYPE_UNSPECIFIED": 0,
 		"OPENAI":                       1,
 		"GEMINI":                       2,
+		"OPENAI_COMPATIBLE":            3,
+		"OLLAMA":                       4,
 	}
 )
 
@@ -306,6 +326,163 @@ func (InstanceStorageSetting_StorageType) EnumDescriptor() ([]byte, []int) {
 	return file_store_instance_setting_proto_rawDescGZIP(), []int{5, 0}
 }
 
+type ContentScanConfig_Scanner int32
+
+const (
+	// SCANNER_UNSPECIFIED disables scanning.
+	ContentScanConfig_SCANNER_UNSPECIFIED ContentScanConfig_Scanner = 0
+	// CLAMD streams content to a ClamAV daemon.
+	ContentScanConfig_CLAMD ContentScanConfig_Scanner = 1
+	// HTTP posts content to a scanning endpoint answering with a JSON verdict.
+	ContentScanConfig_HTTP ContentScanConfig_Scanner = 2
+)
+
+// Enum value maps for ContentScanConfig_Scanner.
+var (
+	ContentScanConfig_Scanner_name = map[int32]string{
+		0: "SCANNER_UNSPECIFIED",
+		1: "CLAMD",
+		2: "HTTP",
+	}
+	ContentScanConfig_Scanner_value = map[string]int32{
+		"SCANNER_UNSPECIFIED": 0,
+		"CLAMD":               1,
+		"HTTP":                2,
+	}
+)
+
+func (x ContentScanConfig_Scanner) Enum() *ContentScanConfig_Scanner {
+	p := new(ContentScanConfig_Scanner)
+	*p = x
+	return p
+}
+
+func (x ContentScanConfig_Scanner) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContentScanConfig_Scanner) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_instance_setting_proto_enumTypes[5].Descriptor()
+}
+
+func (ContentScanConfig_Scanner) Type() protoreflect.EnumType {
+	return &file_store_instance_setting_proto_enumTypes[5]
+}
+
+func (x ContentScanConfig_Scanner) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContentScanConfig_Scanner.Descriptor instead.
+func (ContentScanConfig_Scanner) EnumDescriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{6, 0}
+}
+
+type ContentScanConfig_Action int32
+
+const (
+	// ACTION_UNSPECIFIED rejects infected uploads.
+	ContentScanConfig_ACTION_UNSPECIFIED ContentScanConfig_Action = 0
+	// REJECT refuses infected uploads.
+	ContentScanConfig_REJECT ContentScanConfig_Action = 1
+	// QUARANTINE stores infected uploads but refuses to serve them.
+	ContentScanConfig_QUARANTINE ContentScanConfig_Action = 2
+)
+
+// Enum value maps for ContentScanConfig_Action.
+var (
+	ContentScanConfig_Action_name = map[int32]string{
+		0: "ACTION_UNSPECIFIED",
+		1: "REJECT",
+		2: "QUARANTINE",
+	}
+	ContentScanConfig_Action_value = map[string]int32{
+		"ACTION_UNSPECIFIED": 0,
+		"REJECT":             1,
+		"QUARANTINE":         2,
+	}
+)
+
+func (x ContentScanConfig_Action) Enum() *ContentScanConfig_Action {
+	p := new(ContentScanConfig_Action)
+	*p = x
+	return p
+}
+
+func (x ContentScanConfig_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContentScanConfig_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_instance_setting_proto_enumTypes[6].Descriptor()
+}
+
+func (ContentScanConfig_Action) Type() protoreflect.EnumType {
+	return &file_store_instance_setting_proto_enumTypes[6]
+}
+
+func (x ContentScanConfig_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContentScanConfig_Action.Descriptor instead.
+func (ContentScanConfig_Action) EnumDescriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{6, 1}
+}
+
+type ImageAnalysisConfig_Engine int32
+
+const (
+	// ENGINE_UNSPECIFIED disables image analysis.
+	ImageAnalysisConfig_ENGINE_UNSPECIFIED ImageAnalysisConfig_Engine = 0
+	// AI_PROVIDER sends images to the vision model of provider_id.
+	ImageAnalysisConfig_AI_PROVIDER ImageAnalysisConfig_Engine = 1
+	// TESSERACT runs the tesseract binary found on the server PATH. It
+	// recognizes text only and produces no caption.
+	ImageAnalysisConfig_TESSERACT ImageAnalysisConfig_Engine = 2
+)
+
+// Enum value maps for ImageAnalysisConfig_Engine.
+var (
+	ImageAnalysisConfig_Engine_name = map[int32]string{
+		0: "ENGINE_UNSPECIFIED",
+		1: "AI_PROVIDER",
+		2: "TESSERACT",
+	}
+	ImageAnalysisConfig_Engine_value = map[string]int32{
+		"ENGINE_UNSPECIFIED": 0,
+		"AI_PROVIDER":        1,
+		"TESSERACT":          2,
+	}
+)
+
+func (x ImageAnalysisConfig_Engine) Enum() *ImageAnalysisConfig_Engine {
+	p := new(ImageAnalysisConfig_Engine)
+	*p = x
+	return p
+}
+
+func (x ImageAnalysisConfig_Engine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImageAnalysisConfig_Engine) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_instance_setting_proto_enumTypes[7].Descriptor()
+}
+
+func (ImageAnalysisConfig_Engine) Type() protoreflect.EnumType {
+	return &file_store_instance_setting_proto_enumTypes[7]
+}
+
+func (x ImageAnalysisConfig_Engine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImageAnalysisConfig_Engine.Descriptor instead.
+func (ImageAnalysisConfig_Engine) EnumDescriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{20, 0}
+}
+
 type InstanceSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Key   InstanceSettingKey     `protobuf:"varint,1,opt,name=key,proto3,enum=memos.store.InstanceSettingKey" json:"key,omitempty"`
@@ -319,6 +496,7 @@ type InstanceSetting struct {
 	//	*InstanceSetting_NotificationSetting
 	//	*InstanceSetting_AiSetting
 	//	*InstanceSetting_AccessSetting
+	//	*InstanceSetting_MemoTemplatesSetting
 	Value         isInstanceSetting_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -440,6 +618,15 @@ func (x *InstanceSetting) GetAccessSetting() *InstanceAccessSetting {
 	return nil
 }
 
+func (x *InstanceSetting) GetMemoTemplatesSetting() *InstanceMemoTemplatesSetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_MemoTemplatesSetting); ok {
+			return x.MemoTemplatesSetting
+		}
+	}
+	return nil
+}
+
 type isInstanceSetting_Value interface {
 	isInstanceSetting_Value()
 }
@@ -476,6 +663,10 @@ type InstanceSetting_AccessSetting struct {
 	AccessSetting *InstanceAccessSetting `protobuf:"bytes,9,opt,name=access_setting,json=accessSetting,proto3,oneof"`
 }
 
+type InstanceSetting_MemoTemplatesSetting struct {
+	MemoTemplatesSetting *InstanceMemoTemplatesSetting `protobuf:"bytes,10,opt,name=memo_templates_setting,json=memoTemplatesSetting,proto3,oneof"`
+}
+
 func (*InstanceSetting_BasicSetting) isInstanceSetting_Value() {}
 
 func (*InstanceSetting_GeneralSetting) isInstanceSetting_Value() {}
@@ -492,6 +683,8 @@ func (*InstanceSetting_AiSetting) isInstanceSetting_Value() {}
 
 func (*InstanceSetting_AccessSetting) isInstanceSetting_Value() {}
 
+func (*InstanceSetting_MemoTemplatesSetting) isInstanceSetting_Value() {}
+
 type InstanceBasicSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The secret key for instance. Mainly used for session management.
@@ -727,6 +920,9 @@ type Storage struct {
 	// Types that are valid to be assigned to Config:
 	//
 	//	*Storage_S3Config
+	//	*Storage_LocalConfig
+	//	*Storage_WebdavConfig
+	//	*Storage_SftpConfig
 	Config        isStorage_Config `protobuf_oneof:"config"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -799,6 +995,33 @@ func (x *Storage) GetS3Config() *StorageS3Config {
 	return nil
 }
 
+func (x *Storage) GetLocalConfig() *StorageLocalConfig {
+	if x != nil {
+		if x, ok := x.Config.(*Storage_LocalConfig); ok {
+			return x.LocalConfig
+		}
+	}
+	return nil
+}
+
+func (x *Storage) GetWebdavConfig() *StorageWebDAVConfig {
+	if x != nil {
+		if x, ok := x.Config.(*Storage_WebdavConfig); ok {
+			return x.WebdavConfig
+		}
+	}
+	return nil
+}
+
+func (x *Storage) GetSftpConfig() *StorageSFTPConfig {
+	if x != nil {
+		if x, ok := x.Config.(*Storage_SftpConfig); ok {
+			return x.SftpConfig
+		}
+	}
+	return nil
+}
+
 type isStorage_Config interface {
 	isStorage_Config()
 }
@@ -807,8 +1030,26 @@ type Storage_S3Config struct {
 	S3Config *StorageS3Config `protobuf:"bytes,10,opt,name=s3_config,json=s3Config,proto3,oneof"`
 }
 
+type Storage_LocalConfig struct {
+	LocalConfig *StorageLocalConfig `protobuf:"bytes,11,opt,name=local_config,json=localConfig,proto3,oneof"`
+}
+
+type Storage_WebdavConfig struct {
+	WebdavConfig *StorageWebDAVConfig `protobuf:"bytes,12,opt,name=webdav_config,json=webdavConfig,proto3,oneof"`
+}
+
+type Storage_SftpConfig struct {
+	SftpConfig *StorageSFTPConfig `protobuf:"bytes,13,opt,name=sftp_config,json=sftpConfig,proto3,oneof"`
+}
+
 func (*Storage_S3Config) isStorage_Config() {}
 
+func (*Storage_LocalConfig) isStorage_Config() {}
+
+func (*Storage_WebdavConfig) isStorage_Config() {}
+
+func (*Storage_SftpConfig) isStorage_Config() {}
+
 type InstanceStorageSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Legacy compatibility field. New code uses default_storage_id.
@@ -825,8 +1066,24 @@ type InstanceStorageSetting struct {
 	Storages []*Storage `protobuf:"bytes,5,rep,name=storages,proto3" json:"storages,omitempty"`
 	// default_storage_id identifies the storage used for new attachments.
 	DefaultStorageId string `protobuf:"bytes,6,opt,name=default_storage_id,json=defaultStorageId,proto3" json:"default_storage_id,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// quota limits the total size of the attachments each user may store.
+	Quota *StorageQuota `protobuf:"bytes,7,opt,name=quota,proto3" json:"quota,omitempty"`
+	// allowed_mime_types restricts new attachments to these MIME types when not
+	// empty. Entries are exact types or wildcards such as "image/*".
+	AllowedMimeTypes []string `protobuf:"bytes,8,rep,name=allowed_mime_types,json=allowedMimeTypes,proto3" json:"allowed_mime_types,omitempty"`
+	// blocked_mime_types rejects new attachments of these MIME types and takes
+	// precedence over allowed_mime_types.
+	BlockedMimeTypes []string `protobuf:"bytes,9,rep,name=blocked_mime_types,json=blockedMimeTypes,proto3" json:"blocked_mime_types,omitempty"`
+	// variant_cache_size_mb caps the total size of cached image variants.
+	// Zero uses the default of 1024.
+	VariantCacheSizeMb int64 `protobuf:"varint,10,opt,name=variant_cache_size_mb,json=variantCacheSizeMb,proto3" json:"variant_cache_size_mb,omitempty"`
+	// variant_cache_max_age_days evicts image variants not served for that many days.
+	// Zero uses the default of 30.
+	VariantCacheMaxAgeDays int32 `protobuf:"varint,11,opt,name=variant_cache_max_age_days,json=variantCacheMaxAgeDays,proto3" json:"variant_cache_max_age_days,omitempty"`
+	// content_scan checks new attachments for malware.
+	ContentScan   *ContentScanConfig `protobuf:"bytes,12,opt,name=content_scan,json=contentScan,proto3" json:"content_scan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InstanceStorageSetting) Reset() {
@@ -863,76 +1120,510 @@ func (x *InstanceStorageSetting) GetStorageType() InstanceStorageSetting_Storage
 	if x != nil {
 		return x.StorageType
 	}
-	return InstanceStorageSetting_STORAGE_TYPE_UNSPECIFIED
+	return InstanceStorageSetting_STORAGE_TYPE_UNSPECIFIED
+}
+
+func (x *InstanceStorageSetting) GetFilepathTemplate() string {
+	if x != nil {
+		return x.FilepathTemplate
+	}
+	return ""
+}
+
+func (x *InstanceStorageSetting) GetUploadSizeLimitMb() int64 {
+	if x != nil {
+		return x.UploadSizeLimitMb
+	}
+	return 0
+}
+
+func (x *InstanceStorageSetting) GetS3Config() *StorageS3Config {
+	if x != nil {
+		return x.S3Config
+	}
+	return nil
+}
+
+func (x *InstanceStorageSetting) GetStorages() []*Storage {
+	if x != nil {
+		return x.Storages
+	}
+	return nil
+}
+
+func (x *InstanceStorageSetting) GetDefaultStorageId() string {
+	if x != nil {
+		return x.DefaultStorageId
+	}
+	return ""
+}
+
+func (x *InstanceStorageSetting) GetQuota() *StorageQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *InstanceStorageSetting) GetAllowedMimeTypes() []string {
+	if x != nil {
+		return x.AllowedMimeTypes
+	}
+	return nil
+}
+
+func (x *InstanceStorageSetting) GetBlockedMimeTypes() []string {
+	if x != nil {
+		return x.BlockedMimeTypes
+	}
+	return nil
+}
+
+func (x *InstanceStorageSetting) GetVariantCacheSizeMb() int64 {
+	if x != nil {
+		return x.VariantCacheSizeMb
+	}
+	return 0
+}
+
+func (x *InstanceStorageSetting) GetVariantCacheMaxAgeDays() int32 {
+	if x != nil {
+		return x.VariantCacheMaxAgeDays
+	}
+	return 0
+}
+
+func (x *InstanceStorageSetting) GetContentScan() *ContentScanConfig {
+	if x != nil {
+		return x.ContentScan
+	}
+	return nil
+}
+
+// ContentScanConfig configures malware scanning of uploaded attachments.
+type ContentScanConfig struct {
+	state   protoimpl.MessageState    `protogen:"open.v1"`
+	Scanner ContentScanConfig_Scanner `protobuf:"varint,1,opt,name=scanner,proto3,enum=memos.store.ContentScanConfig_Scanner" json:"scanner,omitempty"`
+	// clamd_address is "host:port", or "unix:" followed by a socket path.
+	ClamdAddress string `protobuf:"bytes,2,opt,name=clamd_address,json=clamdAddress,proto3" json:"clamd_address,omitempty"`
+	// http_endpoint receives the content in a POST request.
+	HttpEndpoint string `protobuf:"bytes,3,opt,name=http_endpoint,json=httpEndpoint,proto3" json:"http_endpoint,omitempty"`
+	// http_token is sent to http_endpoint as a bearer token.
+	HttpToken      string                   `protobuf:"bytes,4,opt,name=http_token,json=httpToken,proto3" json:"http_token,omitempty"`
+	InfectedAction ContentScanConfig_Action `protobuf:"varint,5,opt,name=infected_action,json=infectedAction,proto3,enum=memos.store.ContentScanConfig_Action" json:"infected_action,omitempty"`
+	// fail_open accepts uploads when the scanner cannot be reached, recording
+	// the scan as failed. Otherwise such uploads are refused.
+	FailOpen bool `protobuf:"varint,6,opt,name=fail_open,json=failOpen,proto3" json:"fail_open,omitempty"`
+	// timeout_seconds bounds one scan. Zero uses the default of 30.
+	TimeoutSeconds int32 `protobuf:"varint,7,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ContentScanConfig) Reset() {
+	*x = ContentScanConfig{}
+	mi := &file_store_instance_setting_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentScanConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentScanConfig) ProtoMessage() {}
+
+func (x *ContentScanConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentScanConfig.ProtoReflect.Descriptor instead.
+func (*ContentScanConfig) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ContentScanConfig) GetScanner() ContentScanConfig_Scanner {
+	if x != nil {
+		return x.Scanner
+	}
+	return ContentScanConfig_SCANNER_UNSPECIFIED
+}
+
+func (x *ContentScanConfig) GetClamdAddress() string {
+	if x != nil {
+		return x.ClamdAddress
+	}
+	return ""
+}
+
+func (x *ContentScanConfig) GetHttpEndpoint() string {
+	if x != nil {
+		return x.HttpEndpoint
+	}
+	return ""
+}
+
+func (x *ContentScanConfig) GetHttpToken() string {
+	if x != nil {
+		return x.HttpToken
+	}
+	return ""
+}
+
+func (x *ContentScanConfig) GetInfectedAction() ContentScanConfig_Action {
+	if x != nil {
+		return x.InfectedAction
+	}
+	return ContentScanConfig_ACTION_UNSPECIFIED
+}
+
+func (x *ContentScanConfig) GetFailOpen() bool {
+	if x != nil {
+		return x.FailOpen
+	}
+	return false
+}
+
+func (x *ContentScanConfig) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+// StorageQuota limits the total attachment size per user, in megabytes.
+// Zero means unlimited.
+type StorageQuota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// user_quota_mb applies to users with the USER role.
+	UserQuotaMb int64 `protobuf:"varint,1,opt,name=user_quota_mb,json=userQuotaMb,proto3" json:"user_quota_mb,omitempty"`
+	// admin_quota_mb applies to users with the ADMIN role.
+	AdminQuotaMb int64 `protobuf:"varint,2,opt,name=admin_quota_mb,json=adminQuotaMb,proto3" json:"admin_quota_mb,omitempty"`
+	// user_overrides replace the role quota for individual users.
+	UserOverrides []*StorageQuota_UserOverride `protobuf:"bytes,3,rep,name=user_overrides,json=userOverrides,proto3" json:"user_overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageQuota) Reset() {
+	*x = StorageQuota{}
+	mi := &file_store_instance_setting_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageQuota) ProtoMessage() {}
+
+func (x *StorageQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageQuota.ProtoReflect.Descriptor instead.
+func (*StorageQuota) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StorageQuota) GetUserQuotaMb() int64 {
+	if x != nil {
+		return x.UserQuotaMb
+	}
+	return 0
+}
+
+func (x *StorageQuota) GetAdminQuotaMb() int64 {
+	if x != nil {
+		return x.AdminQuotaMb
+	}
+	return 0
+}
+
+func (x *StorageQuota) GetUserOverrides() []*StorageQuota_UserOverride {
+	if x != nil {
+		return x.UserOverrides
+	}
+	return nil
+}
+
+// Reference: https://developers.cloudflare.com/r2/examples/aws/aws-sdk-go/
+type StorageS3Config struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccessKeyId     string                 `protobuf:"bytes,1,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
+	AccessKeySecret string                 `protobuf:"bytes,2,opt,name=access_key_secret,json=accessKeySecret,proto3" json:"access_key_secret,omitempty"`
+	Endpoint        string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Region          string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	Bucket          string                 `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	UsePathStyle    bool                   `protobuf:"varint,6,opt,name=use_path_style,json=usePathStyle,proto3" json:"use_path_style,omitempty"`
+	// insecure_skip_tls_verify disables TLS certificate verification when connecting
+	// to the S3 endpoint. Only enable this for trusted endpoints that use a self-signed
+	// certificate; it removes protection against man-in-the-middle attacks.
+	InsecureSkipTlsVerify bool `protobuf:"varint,7,opt,name=insecure_skip_tls_verify,json=insecureSkipTlsVerify,proto3" json:"insecure_skip_tls_verify,omitempty"`
+	// presigned_transfers lets clients upload directly to the bucket with presigned
+	// PUT URLs and redirects downloads to short-lived presigned GET URLs.
+	PresignedTransfers bool `protobuf:"varint,8,opt,name=presigned_transfers,json=presignedTransfers,proto3" json:"presigned_transfers,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StorageS3Config) Reset() {
+	*x = StorageS3Config{}
+	mi := &file_store_instance_setting_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageS3Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageS3Config) ProtoMessage() {}
+
+func (x *StorageS3Config) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageS3Config.ProtoReflect.Descriptor instead.
+func (*StorageS3Config) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StorageS3Config) GetAccessKeyId() string {
+	if x != nil {
+		return x.AccessKeyId
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetAccessKeySecret() string {
+	if x != nil {
+		return x.AccessKeySecret
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetUsePathStyle() bool {
+	if x != nil {
+		return x.UsePathStyle
+	}
+	return false
+}
+
+func (x *StorageS3Config) GetInsecureSkipTlsVerify() bool {
+	if x != nil {
+		return x.InsecureSkipTlsVerify
+	}
+	return false
+}
+
+func (x *StorageS3Config) GetPresignedTransfers() bool {
+	if x != nil {
+		return x.PresignedTransfers
+	}
+	return false
+}
+
+type StorageLocalConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// path is the directory attachments are written to, such as a mounted NAS
+	// share. Empty means the data directory.
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageLocalConfig) Reset() {
+	*x = StorageLocalConfig{}
+	mi := &file_store_instance_setting_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageLocalConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageLocalConfig) ProtoMessage() {}
+
+func (x *StorageLocalConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageLocalConfig.ProtoReflect.Descriptor instead.
+func (*StorageLocalConfig) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StorageLocalConfig) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type StorageWebDAVConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// endpoint is the URL of the collection attachments are written to.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// insecure_skip_tls_verify disables TLS certificate verification. Only enable
+	// this for trusted servers that use a self-signed certificate.
+	InsecureSkipTlsVerify bool `protobuf:"varint,4,opt,name=insecure_skip_tls_verify,json=insecureSkipTlsVerify,proto3" json:"insecure_skip_tls_verify,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *StorageWebDAVConfig) Reset() {
+	*x = StorageWebDAVConfig{}
+	mi := &file_store_instance_setting_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageWebDAVConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageWebDAVConfig) ProtoMessage() {}
+
+func (x *StorageWebDAVConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *InstanceStorageSetting) GetFilepathTemplate() string {
-	if x != nil {
-		return x.FilepathTemplate
-	}
-	return ""
+// Deprecated: Use StorageWebDAVConfig.ProtoReflect.Descriptor instead.
+func (*StorageWebDAVConfig) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *InstanceStorageSetting) GetUploadSizeLimitMb() int64 {
+func (x *StorageWebDAVConfig) GetEndpoint() string {
 	if x != nil {
-		return x.UploadSizeLimitMb
+		return x.Endpoint
 	}
-	return 0
+	return ""
 }
 
-func (x *InstanceStorageSetting) GetS3Config() *StorageS3Config {
+func (x *StorageWebDAVConfig) GetUsername() string {
 	if x != nil {
-		return x.S3Config
+		return x.Username
 	}
-	return nil
+	return ""
 }
 
-func (x *InstanceStorageSetting) GetStorages() []*Storage {
+func (x *StorageWebDAVConfig) GetPassword() string {
 	if x != nil {
-		return x.Storages
+		return x.Password
 	}
-	return nil
+	return ""
 }
 
-func (x *InstanceStorageSetting) GetDefaultStorageId() string {
+func (x *StorageWebDAVConfig) GetInsecureSkipTlsVerify() bool {
 	if x != nil {
-		return x.DefaultStorageId
+		return x.InsecureSkipTlsVerify
 	}
-	return ""
+	return false
 }
 
-// Reference: https://developers.cloudflare.com/r2/examples/aws/aws-sdk-go/
-type StorageS3Config struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	AccessKeyId     string                 `protobuf:"bytes,1,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
-	AccessKeySecret string                 `protobuf:"bytes,2,opt,name=access_key_secret,json=accessKeySecret,proto3" json:"access_key_secret,omitempty"`
-	Endpoint        string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
-	Region          string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
-	Bucket          string                 `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	UsePathStyle    bool                   `protobuf:"varint,6,opt,name=use_path_style,json=usePathStyle,proto3" json:"use_path_style,omitempty"`
-	// insecure_skip_tls_verify disables TLS certificate verification when connecting
-	// to the S3 endpoint. Only enable this for trusted endpoints that use a self-signed
-	// certificate; it removes protection against man-in-the-middle attacks.
-	InsecureSkipTlsVerify bool `protobuf:"varint,7,opt,name=insecure_skip_tls_verify,json=insecureSkipTlsVerify,proto3" json:"insecure_skip_tls_verify,omitempty"`
+type StorageSFTPConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Host  string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// port defaults to 22.
+	Port     int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// password and private_key are alternative credentials; private_key is a
+	// PEM-encoded key and takes precedence when both are set.
+	Password   string `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	PrivateKey string `protobuf:"bytes,5,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	// host_key is the server public key in authorized_keys format, used to
+	// verify the server.
+	HostKey string `protobuf:"bytes,6,opt,name=host_key,json=hostKey,proto3" json:"host_key,omitempty"`
+	// path is the directory attachments are written to.
+	Path string `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	// insecure_ignore_host_key skips server verification when no host_key is
+	// configured. It removes protection against man-in-the-middle attacks.
+	InsecureIgnoreHostKey bool `protobuf:"varint,8,opt,name=insecure_ignore_host_key,json=insecureIgnoreHostKey,proto3" json:"insecure_ignore_host_key,omitempty"`
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
 
-func (x *StorageS3Config) Reset() {
-	*x = StorageS3Config{}
-	mi := &file_store_instance_setting_proto_msgTypes[6]
+func (x *StorageSFTPConfig) Reset() {
+	*x = StorageSFTPConfig{}
+	mi := &file_store_instance_setting_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StorageS3Config) String() string {
+func (x *StorageSFTPConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StorageS3Config) ProtoMessage() {}
+func (*StorageSFTPConfig) ProtoMessage() {}
 
-func (x *StorageS3Config) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[6]
+func (x *StorageSFTPConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -943,56 +1634,63 @@ func (x *StorageS3Config) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StorageS3Config.ProtoReflect.Descriptor instead.
-func (*StorageS3Config) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use StorageSFTPConfig.ProtoReflect.Descriptor instead.
+func (*StorageSFTPConfig) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *StorageS3Config) GetAccessKeyId() string {
+func (x *StorageSFTPConfig) GetHost() string {
 	if x != nil {
-		return x.AccessKeyId
+		return x.Host
 	}
 	return ""
 }
 
-func (x *StorageS3Config) GetAccessKeySecret() string {
+func (x *StorageSFTPConfig) GetPort() int32 {
 	if x != nil {
-		return x.AccessKeySecret
+		return x.Port
+	}
+	return 0
+}
+
+func (x *StorageSFTPConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
 	return ""
 }
 
-func (x *StorageS3Config) GetEndpoint() string {
+func (x *StorageSFTPConfig) GetPassword() string {
 	if x != nil {
-		return x.Endpoint
+		return x.Password
 	}
 	return ""
 }
 
-func (x *StorageS3Config) GetRegion() string {
+func (x *StorageSFTPConfig) GetPrivateKey() string {
 	if x != nil {
-		return x.Region
+		return x.PrivateKey
 	}
 	return ""
 }
 
-func (x *StorageS3Config) GetBucket() string {
+func (x *StorageSFTPConfig) GetHostKey() string {
 	if x != nil {
-		return x.Bucket
+		return x.HostKey
 	}
 	return ""
 }
 
-func (x *StorageS3Config) GetUsePathStyle() bool {
+func (x *StorageSFTPConfig) GetPath() string {
 	if x != nil {
-		return x.UsePathStyle
+		return x.Path
 	}
-	return false
+	return ""
 }
 
-func (x *StorageS3Config) GetInsecureSkipTlsVerify() bool {
+func (x *StorageSFTPConfig) GetInsecureIgnoreHostKey() bool {
 	if x != nil {
-		return x.InsecureSkipTlsVerify
+		return x.InsecureIgnoreHostKey
 	}
 	return false
 }
@@ -1011,7 +1709,7 @@ type InstanceMemoRelatedSetting struct {
 
 func (x *InstanceMemoRelatedSetting) Reset() {
 	*x = InstanceMemoRelatedSetting{}
-	mi := &file_store_instance_setting_proto_msgTypes[7]
+	mi := &file_store_instance_setting_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1023,7 +1721,7 @@ func (x *InstanceMemoRelatedSetting) String() string {
 func (*InstanceMemoRelatedSetting) ProtoMessage() {}
 
 func (x *InstanceMemoRelatedSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[7]
+	mi := &file_store_instance_setting_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1036,7 +1734,7 @@ func (x *InstanceMemoRelatedSetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceMemoRelatedSetting.ProtoReflect.Descriptor instead.
 func (*InstanceMemoRelatedSetting) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{7}
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *InstanceMemoRelatedSetting) GetContentLengthLimit() int32 {
@@ -1073,7 +1771,7 @@ type InstanceTagMetadata struct {
 
 func (x *InstanceTagMetadata) Reset() {
 	*x = InstanceTagMetadata{}
-	mi := &file_store_instance_setting_proto_msgTypes[8]
+	mi := &file_store_instance_setting_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1085,7 +1783,7 @@ func (x *InstanceTagMetadata) String() string {
 func (*InstanceTagMetadata) ProtoMessage() {}
 
 func (x *InstanceTagMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[8]
+	mi := &file_store_instance_setting_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1098,7 +1796,7 @@ func (x *InstanceTagMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceTagMetadata.ProtoReflect.Descriptor instead.
 func (*InstanceTagMetadata) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{8}
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *InstanceTagMetadata) GetBackgroundColor() *color.Color {
@@ -1130,7 +1828,7 @@ type InstanceTagsSetting struct {
 
 func (x *InstanceTagsSetting) Reset() {
 	*x = InstanceTagsSetting{}
-	mi := &file_store_instance_setting_proto_msgTypes[9]
+	mi := &file_store_instance_setting_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1142,7 +1840,7 @@ func (x *InstanceTagsSetting) String() string {
 func (*InstanceTagsSetting) ProtoMessage() {}
 
 func (x *InstanceTagsSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[9]
+	mi := &file_store_instance_setting_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1155,7 +1853,7 @@ func (x *InstanceTagsSetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceTagsSetting.ProtoReflect.Descriptor instead.
 func (*InstanceTagsSetting) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{9}
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *InstanceTagsSetting) GetTags() map[string]*InstanceTagMetadata {
@@ -1174,7 +1872,7 @@ type InstanceNotificationSetting struct {
 
 func (x *InstanceNotificationSetting) Reset() {
 	*x = InstanceNotificationSetting{}
-	mi := &file_store_instance_setting_proto_msgTypes[10]
+	mi := &file_store_instance_setting_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1186,7 +1884,7 @@ func (x *InstanceNotificationSetting) String() string {
 func (*InstanceNotificationSetting) ProtoMessage() {}
 
 func (x *InstanceNotificationSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[10]
+	mi := &file_store_instance_setting_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1199,7 +1897,7 @@ func (x *InstanceNotificationSetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceNotificationSetting.ProtoReflect.Descriptor instead.
 func (*InstanceNotificationSetting) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{10}
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *InstanceNotificationSetting) GetEmail() *InstanceNotificationSetting_EmailSetting {
@@ -1216,13 +1914,16 @@ type InstanceAISetting struct {
 	// transcription is the speech-to-text feature configuration.
 	// When unset or transcription.provider_id is empty, transcription is disabled.
 	Transcription *TranscriptionConfig `protobuf:"bytes,2,opt,name=transcription,proto3" json:"transcription,omitempty"`
+	// image_analysis is the OCR and captioning configuration for image attachments.
+	// When unset or image_analysis.engine is ENGINE_UNSPECIFIED, analysis is disabled.
+	ImageAnalysis *ImageAnalysisConfig `protobuf:"bytes,3,opt,name=image_analysis,json=imageAnalysis,proto3" json:"image_analysis,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InstanceAISetting) Reset() {
 	*x = InstanceAISetting{}
-	mi := &file_store_instance_setting_proto_msgTypes[11]
+	mi := &file_store_instance_setting_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1234,7 +1935,7 @@ func (x *InstanceAISetting) String() string {
 func (*InstanceAISetting) ProtoMessage() {}
 
 func (x *InstanceAISetting) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[11]
+	mi := &file_store_instance_setting_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1247,7 +1948,7 @@ func (x *InstanceAISetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceAISetting.ProtoReflect.Descriptor instead.
 func (*InstanceAISetting) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{11}
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *InstanceAISetting) GetProviders() []*AIProviderConfig {
@@ -1264,21 +1965,35 @@ func (x *InstanceAISetting) GetTranscription() *TranscriptionConfig {
 	return nil
 }
 
+func (x *InstanceAISetting) GetImageAnalysis() *ImageAnalysisConfig {
+	if x != nil {
+		return x.ImageAnalysis
+	}
+	return nil
+}
+
 type AIProviderConfig struct {
 	state    protoimpl.MessageState `protogen:"open.v1"`
 	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Title    string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
 	Type     AIProviderType         `protobuf:"varint,3,opt,name=type,proto3,enum=memos.store.AIProviderType" json:"type,omitempty"`
 	Endpoint string                 `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
-	// api_key is write-only at the API layer and is required by the server to call providers.
-	ApiKey        string `protobuf:"bytes,5,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// api_key is write-only at the API layer. It is required for OPENAI and GEMINI
+	// and optional for self-hosted OPENAI_COMPATIBLE and OLLAMA servers.
+	ApiKey string `protobuf:"bytes,5,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// timeout_seconds bounds a single request to the provider.
+	// Zero uses the built-in default of the called capability.
+	TimeoutSeconds int32 `protobuf:"varint,6,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// max_concurrent_requests caps in-flight requests to the provider.
+	// Zero means unlimited.
+	MaxConcurrentRequests int32 `protobuf:"varint,7,opt,name=max_concurrent_requests,json=maxConcurrentRequests,proto3" json:"max_concurrent_requests,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *AIProviderConfig) Reset() {
 	*x = AIProviderConfig{}
-	mi := &file_store_instance_setting_proto_msgTypes[12]
+	mi := &file_store_instance_setting_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1290,7 +2005,7 @@ func (x *AIProviderConfig) String() string {
 func (*AIProviderConfig) ProtoMessage() {}
 
 func (x *AIProviderConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[12]
+	mi := &file_store_instance_setting_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1303,7 +2018,7 @@ func (x *AIProviderConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AIProviderConfig.ProtoReflect.Descriptor instead.
 func (*AIProviderConfig) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{12}
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AIProviderConfig) GetId() string {
@@ -1341,6 +2056,20 @@ func (x *AIProviderConfig) GetApiKey() string {
 	return ""
 }
 
+func (x *AIProviderConfig) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *AIProviderConfig) GetMaxConcurrentRequests() int32 {
+	if x != nil {
+		return x.MaxConcurrentRequests
+	}
+	return 0
+}
+
 // TranscriptionConfig configures the speech-to-text feature.
 type TranscriptionConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1353,7 +2082,6 @@ type TranscriptionConfig struct {
 	//   - whisper-1 (legacy, lower cost)
 	//   - gpt-4o-transcribe, gpt-4o-mini-transcribe (higher quality)
 	//   - gpt-4o-transcribe-diarize (includes speaker labels)
-	//
 	// GEMINI examples:
 	//   - gemini-2.5-flash (default, multimodal call)
 	//   - gemini-2.5-pro
@@ -1365,14 +2093,17 @@ type TranscriptionConfig struct {
 	// Used as the OpenAI Whisper "prompt" parameter (a soft hint that the model
 	// may ignore) and folded into the Gemini generation prompt as a "Context and
 	// spelling hints" block (which the LLM will treat more literally).
-	Prompt        string `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Prompt string `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	// auto_transcribe_attachments transcribes uploaded audio attachments in the
+	// background and stores the transcript on the attachment.
+	AutoTranscribeAttachments bool `protobuf:"varint,5,opt,name=auto_transcribe_attachments,json=autoTranscribeAttachments,proto3" json:"auto_transcribe_attachments,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
 }
 
 func (x *TranscriptionConfig) Reset() {
 	*x = TranscriptionConfig{}
-	mi := &file_store_instance_setting_proto_msgTypes[13]
+	mi := &file_store_instance_setting_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1384,7 +2115,7 @@ func (x *TranscriptionConfig) String() string {
 func (*TranscriptionConfig) ProtoMessage() {}
 
 func (x *TranscriptionConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[13]
+	mi := &file_store_instance_setting_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1397,7 +2128,7 @@ func (x *TranscriptionConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TranscriptionConfig.ProtoReflect.Descriptor instead.
 func (*TranscriptionConfig) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{13}
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *TranscriptionConfig) GetProviderId() string {
@@ -1428,6 +2159,13 @@ func (x *TranscriptionConfig) GetPrompt() string {
 	return ""
 }
 
+func (x *TranscriptionConfig) GetAutoTranscribeAttachments() bool {
+	if x != nil {
+		return x.AutoTranscribeAttachments
+	}
+	return false
+}
+
 type InstanceAccessSetting struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccessMode    InstanceAccessMode     `protobuf:"varint,1,opt,name=access_mode,json=accessMode,proto3,enum=memos.store.InstanceAccessMode" json:"access_mode,omitempty"`
@@ -1437,7 +2175,7 @@ type InstanceAccessSetting struct {
 
 func (x *InstanceAccessSetting) Reset() {
 	*x = InstanceAccessSetting{}
-	mi := &file_store_instance_setting_proto_msgTypes[14]
+	mi := &file_store_instance_setting_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1449,7 +2187,7 @@ func (x *InstanceAccessSetting) String() string {
 func (*InstanceAccessSetting) ProtoMessage() {}
 
 func (x *InstanceAccessSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[14]
+	mi := &file_store_instance_setting_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1462,7 +2200,7 @@ func (x *InstanceAccessSetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceAccessSetting.ProtoReflect.Descriptor instead.
 func (*InstanceAccessSetting) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{14}
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *InstanceAccessSetting) GetAccessMode() InstanceAccessMode {
@@ -1472,6 +2210,180 @@ func (x *InstanceAccessSetting) GetAccessMode() InstanceAccessMode {
 	return InstanceAccessMode_INSTANCE_ACCESS_MODE_UNSPECIFIED
 }
 
+// ImageAnalysisConfig configures OCR and captioning of uploaded images.
+type ImageAnalysisConfig struct {
+	state  protoimpl.MessageState     `protogen:"open.v1"`
+	Engine ImageAnalysisConfig_Engine `protobuf:"varint,1,opt,name=engine,proto3,enum=memos.store.ImageAnalysisConfig_Engine" json:"engine,omitempty"`
+	// provider_id references an entry in InstanceAISetting.providers[].id.
+	// Required when engine is AI_PROVIDER.
+	ProviderId string `protobuf:"bytes,2,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	// model is the vision model identifier.
+	// Empty string falls back to the provider default
+	// (gpt-4o-mini for OPENAI, gemini-2.5-flash for GEMINI).
+	Model string `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	// language is an optional language hint. AI providers take an ISO 639-1
+	// code; TESSERACT takes its own language codes, e.g. "eng+deu".
+	Language      string `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageAnalysisConfig) Reset() {
+	*x = ImageAnalysisConfig{}
+	mi := &file_store_instance_setting_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageAnalysisConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageAnalysisConfig) ProtoMessage() {}
+
+func (x *ImageAnalysisConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageAnalysisConfig.ProtoReflect.Descriptor instead.
+func (*ImageAnalysisConfig) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImageAnalysisConfig) GetEngine() ImageAnalysisConfig_Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return ImageAnalysisConfig_ENGINE_UNSPECIFIED
+}
+
+func (x *ImageAnalysisConfig) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *ImageAnalysisConfig) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ImageAnalysisConfig) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type InstanceMemoTemplatesSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Templates shared with every user, managed by admins.
+	Templates     []*MemoTemplate `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceMemoTemplatesSetting) Reset() {
+	*x = InstanceMemoTemplatesSetting{}
+	mi := &file_store_instance_setting_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceMemoTemplatesSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceMemoTemplatesSetting) ProtoMessage() {}
+
+func (x *InstanceMemoTemplatesSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceMemoTemplatesSetting.ProtoReflect.Descriptor instead.
+func (*InstanceMemoTemplatesSetting) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *InstanceMemoTemplatesSetting) GetTemplates() []*MemoTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type StorageQuota_UserOverride struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// quota_mb of zero exempts the user from any quota.
+	QuotaMb       int64 `protobuf:"varint,2,opt,name=quota_mb,json=quotaMb,proto3" json:"quota_mb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageQuota_UserOverride) Reset() {
+	*x = StorageQuota_UserOverride{}
+	mi := &file_store_instance_setting_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageQuota_UserOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageQuota_UserOverride) ProtoMessage() {}
+
+func (x *StorageQuota_UserOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageQuota_UserOverride.ProtoReflect.Descriptor instead.
+func (*StorageQuota_UserOverride) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *StorageQuota_UserOverride) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *StorageQuota_UserOverride) GetQuotaMb() int64 {
+	if x != nil {
+		return x.QuotaMb
+	}
+	return 0
+}
+
 type InstanceNotificationSetting_EmailSetting struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
@@ -1490,7 +2402,7 @@ type InstanceNotificationSetting_EmailSetting struct {
 
 func (x *InstanceNotificationSetting_EmailSetting) Reset() {
 	*x = InstanceNotificationSetting_EmailSetting{}
-	mi := &file_store_instance_setting_proto_msgTypes[16]
+	mi := &file_store_instance_setting_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1502,7 +2414,7 @@ func (x *InstanceNotificationSetting_EmailSetting) String() string {
 func (*InstanceNotificationSetting_EmailSetting) ProtoMessage() {}
 
 func (x *InstanceNotificationSetting_EmailSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_store_instance_setting_proto_msgTypes[16]
+	mi := &file_store_instance_setting_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1515,7 +2427,7 @@ func (x *InstanceNotificationSetting_EmailSetting) ProtoReflect() protoreflect.M
 
 // Deprecated: Use InstanceNotificationSetting_EmailSetting.ProtoReflect.Descriptor instead.
 func (*InstanceNotificationSetting_EmailSetting) Descriptor() ([]byte, []int) {
-	return file_store_instance_setting_proto_rawDescGZIP(), []int{10, 0}
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{15, 0}
 }
 
 func (x *InstanceNotificationSetting_EmailSetting) GetEnabled() bool {
@@ -1592,7 +2504,7 @@ var File_store_instance_setting_proto protoreflect.FileDescriptor
 
 const file_store_instance_setting_proto_rawDesc = "" +
 	"\n" +
-	"\x1cstore/instance_setting.proto\x12\vmemos.store\x1a\x17google/type/color.proto\"\xc8\x05\n" +
+	"\x1cstore/instance_setting.proto\x12\vmemos.store\x1a\x17google/type/color.proto\x1a\x19store/memo_template.proto\"\xab\x06\n" +
 	"\x0fInstanceSetting\x121\n" +
 	"\x03key\x18\x01 \x01(\x0e2\x1f.memos.store.InstanceSettingKeyR\x03key\x12H\n" +
 	"\rbasic_setting\x18\x02 \x01(\v2!.memos.store.InstanceBasicSettingH\x00R\fbasicSetting\x12N\n" +
@@ -1603,7 +2515,9 @@ const file_store_instance_setting_proto_rawDesc = "" +
 	"\x14notification_setting\x18\a \x01(\v2(.memos.store.InstanceNotificationSettingH\x00R\x13notificationSetting\x12?\n" +
 	"\n" +
 	"ai_setting\x18\b \x01(\v2\x1e.memos.store.InstanceAISettingH\x00R\taiSetting\x12K\n" +
-	"\x0eaccess_setting\x18\t \x01(\v2\".memos.store.InstanceAccessSettingH\x00R\raccessSettingB\a\n" +
+	"\x0eaccess_setting\x18\t \x01(\v2\".memos.store.InstanceAccessSettingH\x00R\raccessSetting\x12a\n" +
+	"\x16memo_templates_setting\x18\n" +
+	" \x01(\v2).memos.store.InstanceMemoTemplatesSettingH\x00R\x14memoTemplatesSettingB\a\n" +
 	"\x05value\"\\\n" +
 	"\x14InstanceBasicSetting\x12\x1d\n" +
 	"\n" +
@@ -1621,26 +2535,63 @@ const file_store_instance_setting_proto_rawDesc = "" +
 	"\x15InstanceCustomProfile\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
-	"\blogo_url\x18\x03 \x01(\tR\alogoUrl\"\xa2\x01\n" +
+	"\blogo_url\x18\x03 \x01(\tR\alogoUrl\"\xf4\x02\n" +
 	"\aStorage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12,\n" +
 	"\x04type\x18\x03 \x01(\x0e2\x18.memos.store.StorageTypeR\x04type\x12;\n" +
 	"\ts3_config\x18\n" +
-	" \x01(\v2\x1c.memos.store.StorageS3ConfigH\x00R\bs3ConfigB\b\n" +
-	"\x06config\"\xb3\x03\n" +
+	" \x01(\v2\x1c.memos.store.StorageS3ConfigH\x00R\bs3Config\x12D\n" +
+	"\flocal_config\x18\v \x01(\v2\x1f.memos.store.StorageLocalConfigH\x00R\vlocalConfig\x12G\n" +
+	"\rwebdav_config\x18\f \x01(\v2 .memos.store.StorageWebDAVConfigH\x00R\fwebdavConfig\x12A\n" +
+	"\vsftp_config\x18\r \x01(\v2\x1e.memos.store.StorageSFTPConfigH\x00R\n" +
+	"sftpConfigB\b\n" +
+	"\x06config\"\xf2\x05\n" +
 	"\x16InstanceStorageSetting\x12R\n" +
 	"\fstorage_type\x18\x01 \x01(\x0e2/.memos.store.InstanceStorageSetting.StorageTypeR\vstorageType\x12+\n" +
 	"\x11filepath_template\x18\x02 \x01(\tR\x10filepathTemplate\x12/\n" +
 	"\x14upload_size_limit_mb\x18\x03 \x01(\x03R\x11uploadSizeLimitMb\x129\n" +
 	"\ts3_config\x18\x04 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x120\n" +
 	"\bstorages\x18\x05 \x03(\v2\x14.memos.store.StorageR\bstorages\x12,\n" +
-	"\x12default_storage_id\x18\x06 \x01(\tR\x10defaultStorageId\"L\n" +
+	"\x12default_storage_id\x18\x06 \x01(\tR\x10defaultStorageId\x12/\n" +
+	"\x05quota\x18\a \x01(\v2\x19.memos.store.StorageQuotaR\x05quota\x12,\n" +
+	"\x12allowed_mime_types\x18\b \x03(\tR\x10allowedMimeTypes\x12,\n" +
+	"\x12blocked_mime_types\x18\t \x03(\tR\x10blockedMimeTypes\x121\n" +
+	"\x15variant_cache_size_mb\x18\n" +
+	" \x01(\x03R\x12variantCacheSizeMb\x12:\n" +
+	"\x1avariant_cache_max_age_days\x18\v \x01(\x05R\x16variantCacheMaxAgeDays\x12A\n" +
+	"\fcontent_scan\x18\f \x01(\v2\x1e.memos.store.ContentScanConfigR\vcontentScan\"L\n" +
 	"\vStorageType\x12\x1c\n" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\"\x8c\x02\n" +
+	"\x02S3\x10\x03\"\xcb\x03\n" +
+	"\x11ContentScanConfig\x12@\n" +
+	"\ascanner\x18\x01 \x01(\x0e2&.memos.store.ContentScanConfig.ScannerR\ascanner\x12#\n" +
+	"\rclamd_address\x18\x02 \x01(\tR\fclamdAddress\x12#\n" +
+	"\rhttp_endpoint\x18\x03 \x01(\tR\fhttpEndpoint\x12\x1d\n" +
+	"\n" +
+	"http_token\x18\x04 \x01(\tR\thttpToken\x12N\n" +
+	"\x0finfected_action\x18\x05 \x01(\x0e2%.memos.store.ContentScanConfig.ActionR\x0einfectedAction\x12\x1b\n" +
+	"\tfail_open\x18\x06 \x01(\bR\bfailOpen\x12'\n" +
+	"\x0ftimeout_seconds\x18\a \x01(\x05R\x0etimeoutSeconds\"7\n" +
+	"\aScanner\x12\x17\n" +
+	"\x13SCANNER_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05CLAMD\x10\x01\x12\b\n" +
+	"\x04HTTP\x10\x02\"<\n" +
+	"\x06Action\x12\x16\n" +
+	"\x12ACTION_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06REJECT\x10\x01\x12\x0e\n" +
+	"\n" +
+	"QUARANTINE\x10\x02\"\xeb\x01\n" +
+	"\fStorageQuota\x12\"\n" +
+	"\ruser_quota_mb\x18\x01 \x01(\x03R\vuserQuotaMb\x12$\n" +
+	"\x0eadmin_quota_mb\x18\x02 \x01(\x03R\fadminQuotaMb\x12M\n" +
+	"\x0euser_overrides\x18\x03 \x03(\v2&.memos.store.StorageQuota.UserOverrideR\ruserOverrides\x1aB\n" +
+	"\fUserOverride\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bquota_mb\x18\x02 \x01(\x03R\aquotaMb\"\xbd\x02\n" +
 	"\x0fStorageS3Config\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
 	"\x11access_key_secret\x18\x02 \x01(\tR\x0faccessKeySecret\x12\x1a\n" +
@@ -1648,7 +2599,25 @@ const file_store_instance_setting_proto_rawDesc = "" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x16\n" +
 	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12$\n" +
 	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\x127\n" +
-	"\x18insecure_skip_tls_verify\x18\a \x01(\bR\x15insecureSkipTlsVerify\"\xc5\x01\n" +
+	"\x18insecure_skip_tls_verify\x18\a \x01(\bR\x15insecureSkipTlsVerify\x12/\n" +
+	"\x13presigned_transfers\x18\b \x01(\bR\x12presignedTransfers\"(\n" +
+	"\x12StorageLocalConfig\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"\xa2\x01\n" +
+	"\x13StorageWebDAVConfig\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x127\n" +
+	"\x18insecure_skip_tls_verify\x18\x04 \x01(\bR\x15insecureSkipTlsVerify\"\xfc\x01\n" +
+	"\x11StorageSFTPConfig\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12\x1f\n" +
+	"\vprivate_key\x18\x05 \x01(\tR\n" +
+	"privateKey\x12\x19\n" +
+	"\bhost_key\x18\x06 \x01(\tR\ahostKey\x12\x12\n" +
+	"\x04path\x18\a \x01(\tR\x04path\x127\n" +
+	"\x18insecure_ignore_host_key\x18\b \x01(\bR\x15insecureIgnoreHostKey\"\xc5\x01\n" +
 	"\x1aInstanceMemoRelatedSetting\x120\n" +
 	"\x14content_length_limit\x18\x03 \x01(\x05R\x12contentLengthLimit\x127\n" +
 	"\x18enable_double_click_edit\x18\x04 \x01(\bR\x15enableDoubleClickEdit\x12\x1c\n" +
@@ -1675,25 +2644,41 @@ const file_store_instance_setting_proto_rawDesc = "" +
 	"\breply_to\x18\b \x01(\tR\areplyTo\x12\x17\n" +
 	"\ause_tls\x18\t \x01(\bR\x06useTls\x12\x17\n" +
 	"\ause_ssl\x18\n" +
-	" \x01(\bR\x06useSsl\"\x98\x01\n" +
+	" \x01(\bR\x06useSsl\"\xe1\x01\n" +
 	"\x11InstanceAISetting\x12;\n" +
 	"\tproviders\x18\x01 \x03(\v2\x1d.memos.store.AIProviderConfigR\tproviders\x12F\n" +
-	"\rtranscription\x18\x02 \x01(\v2 .memos.store.TranscriptionConfigR\rtranscription\"\x9e\x01\n" +
+	"\rtranscription\x18\x02 \x01(\v2 .memos.store.TranscriptionConfigR\rtranscription\x12G\n" +
+	"\x0eimage_analysis\x18\x03 \x01(\v2 .memos.store.ImageAnalysisConfigR\rimageAnalysis\"\xff\x01\n" +
 	"\x10AIProviderConfig\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12/\n" +
 	"\x04type\x18\x03 \x01(\x0e2\x1b.memos.store.AIProviderTypeR\x04type\x12\x1a\n" +
 	"\bendpoint\x18\x04 \x01(\tR\bendpoint\x12\x17\n" +
-	"\aapi_key\x18\x05 \x01(\tR\x06apiKey\"\x80\x01\n" +
+	"\aapi_key\x18\x05 \x01(\tR\x06apiKey\x12'\n" +
+	"\x0ftimeout_seconds\x18\x06 \x01(\x05R\x0etimeoutSeconds\x126\n" +
+	"\x17max_concurrent_requests\x18\a \x01(\x05R\x15maxConcurrentRequests\"\xc0\x01\n" +
 	"\x13TranscriptionConfig\x12\x1f\n" +
 	"\vprovider_id\x18\x01 \x01(\tR\n" +
 	"providerId\x12\x14\n" +
 	"\x05model\x18\x02 \x01(\tR\x05model\x12\x1a\n" +
 	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x16\n" +
-	"\x06prompt\x18\x04 \x01(\tR\x06prompt\"Y\n" +
+	"\x06prompt\x18\x04 \x01(\tR\x06prompt\x12>\n" +
+	"\x1bauto_transcribe_attachments\x18\x05 \x01(\bR\x19autoTranscribeAttachments\"Y\n" +
 	"\x15InstanceAccessSetting\x12@\n" +
 	"\vaccess_mode\x18\x01 \x01(\x0e2\x1f.memos.store.InstanceAccessModeR\n" +
-	"accessMode*\xa1\x01\n" +
+	"accessMode\"\xeb\x01\n" +
+	"\x13ImageAnalysisConfig\x12?\n" +
+	"\x06engine\x18\x01 \x01(\x0e2'.memos.store.ImageAnalysisConfig.EngineR\x06engine\x12\x1f\n" +
+	"\vprovider_id\x18\x02 \x01(\tR\n" +
+	"providerId\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\"@\n" +
+	"\x06Engine\x12\x16\n" +
+	"\x12ENGINE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vAI_PROVIDER\x10\x01\x12\r\n" +
+	"\tTESSERACT\x10\x02\"W\n" +
+	"\x1cInstanceMemoTemplatesSetting\x127\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x19.memos.store.MemoTemplateR\ttemplates*\xb5\x01\n" +
 	"\x12InstanceSettingKey\x12$\n" +
 	" INSTANCE_SETTING_KEY_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05BASIC\x10\x01\x12\v\n" +
@@ -1704,18 +2689,24 @@ const file_store_instance_setting_proto_rawDesc = "" +
 	"\fNOTIFICATION\x10\x06\x12\x06\n" +
 	"\x02AI\x10\a\x12\n" +
 	"\n" +
-	"\x06ACCESS\x10\b*s\n" +
+	"\x06ACCESS\x10\b\x12\x12\n" +
+	"\x0eMEMO_TEMPLATES\x10\t*\xa3\x01\n" +
 	"\vStorageType\x12\x1c\n" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15STORAGE_TYPE_DATABASE\x10\x01\x12\x16\n" +
 	"\x12STORAGE_TYPE_LOCAL\x10\x02\x12\x13\n" +
-	"\x0fSTORAGE_TYPE_S3\x10\x03*J\n" +
+	"\x0fSTORAGE_TYPE_S3\x10\x03\x12\x17\n" +
+	"\x13STORAGE_TYPE_WEBDAV\x10\x04\x12\x15\n" +
+	"\x11STORAGE_TYPE_SFTP\x10\x05*m\n" +
 	"\x0eAIProviderType\x12 \n" +
 	"\x1cAI_PROVIDER_TYPE_UNSPECIFIED\x10\x00\x12\n" +
 	"\n" +
 	"\x06OPENAI\x10\x01\x12\n" +
 	"\n" +
-	"\x06GEMINI\x10\x02*}\n" +
+	"\x06GEMINI\x10\x02\x12\x15\n" +
+	"\x11OPENAI_COMPATIBLE\x10\x03\x12\n" +
+	"\n" +
+	"\x06OLLAMA\x10\x04*}\n" +
 	"\x12InstanceAccessMode\x12$\n" +
 	" INSTANCE_ACCESS_MODE_UNSPECIFIED\x10\x00\x12 \n" +
 	"\x1cINSTANCE_ACCESS_MODE_PRIVATE\x10\x01\x12\x1f\n" +
@@ -1734,62 +2725,86 @@ func file_store_instance_setting_proto_rawDescGZIP() []byte {
 	return file_store_instance_setting_proto_rawDescData
 }
 
-var file_store_instance_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_store_instance_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_store_instance_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_store_instance_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_store_instance_setting_proto_goTypes = []any{
 	(InstanceSettingKey)(0),                          // 0: memos.store.InstanceSettingKey
 	(StorageType)(0),                                 // 1: memos.store.StorageType
 	(AIProviderType)(0),                              // 2: memos.store.AIProviderType
 	(InstanceAccessMode)(0),                          // 3: memos.store.InstanceAccessMode
 	(InstanceStorageSetting_StorageType)(0),          // 4: memos.store.InstanceStorageSetting.StorageType
-	(*InstanceSetting)(nil),                          // 5: memos.store.InstanceSetting
-	(*InstanceBasicSetting)(nil),                     // 6: memos.store.InstanceBasicSetting
-	(*InstanceGeneralSetting)(nil),                   // 7: memos.store.InstanceGeneralSetting
-	(*InstanceCustomProfile)(nil),                    // 8: memos.store.InstanceCustomProfile
-	(*Storage)(nil),                                  // 9: memos.store.Storage
-	(*InstanceStorageSetting)(nil),                   // 10: memos.store.InstanceStorageSetting
-	(*StorageS3Config)(nil),                          // 11: memos.store.StorageS3Config
-	(*InstanceMemoRelatedSetting)(nil),               // 12: memos.store.InstanceMemoRelatedSetting
-	(*InstanceTagMetadata)(nil),                      // 13: memos.store.InstanceTagMetadata
-	(*InstanceTagsSetting)(nil),                      // 14: memos.store.InstanceTagsSetting
-	(*InstanceNotificationSetting)(nil),              // 15: memos.store.InstanceNotificationSetting
-	(*InstanceAISetting)(nil),                        // 16: memos.store.InstanceAISetting
-	(*AIProviderConfig)(nil),                         // 17: memos.store.AIProviderConfig
-	(*TranscriptionConfig)(nil),                      // 18: memos.store.TranscriptionConfig
-	(*InstanceAccessSetting)(nil),                    // 19: memos.store.InstanceAccessSetting
-	nil,                                              // 20: memos.store.InstanceTagsSetting.TagsEntry
-	(*InstanceNotificationSetting_EmailSetting)(nil), // 21: memos.store.InstanceNotificationSetting.EmailSetting
-	(*color.Color)(nil),                              // 22: google.type.Color
+	(ContentScanConfig_Scanner)(0),                   // 5: memos.store.ContentScanConfig.Scanner
+	(ContentScanConfig_Action)(0),                    // 6: memos.store.ContentScanConfig.Action
+	(ImageAnalysisConfig_Engine)(0),                  // 7: memos.store.ImageAnalysisConfig.Engine
+	(*InstanceSetting)(nil),                          // 8: memos.store.InstanceSetting
+	(*InstanceBasicSetting)(nil),                     // 9: memos.store.InstanceBasicSetting
+	(*InstanceGeneralSetting)(nil),                   // 10: memos.store.InstanceGeneralSetting
+	(*InstanceCustomProfile)(nil),                    // 11: memos.store.InstanceCustomProfile
+	(*Storage)(nil),                                  // 12: memos.store.Storage
+	(*InstanceStorageSetting)(nil),                   // 13: memos.store.InstanceStorageSetting
+	(*ContentScanConfig)(nil),                        // 14: memos.store.ContentScanConfig
+	(*StorageQuota)(nil),                             // 15: memos.store.StorageQuota
+	(*StorageS3Config)(nil),                          // 16: memos.store.StorageS3Config
+	(*StorageLocalConfig)(nil),                       // 17: memos.store.StorageLocalConfig
+	(*StorageWebDAVConfig)(nil),                      // 18: memos.store.StorageWebDAVConfig
+	(*StorageSFTPConfig)(nil),                        // 19: memos.store.StorageSFTPConfig
+	(*InstanceMemoRelatedSetting)(nil),               // 20: memos.store.InstanceMemoRelatedSetting
+	(*InstanceTagMetadata)(nil),                      // 21: memos.store.InstanceTagMetadata
+	(*InstanceTagsSetting)(nil),                      // 22: memos.store.InstanceTagsSetting
+	(*InstanceNotificationSetting)(nil),              // 23: memos.store.InstanceNotificationSetting
+	(*InstanceAISetting)(nil),                        // 24: memos.store.InstanceAISetting
+	(*AIProviderConfig)(nil),                         // 25: memos.store.AIProviderConfig
+	(*TranscriptionConfig)(nil),                      // 26: memos.store.TranscriptionConfig
+	(*InstanceAccessSetting)(nil),                    // 27: memos.store.InstanceAccessSetting
+	(*ImageAnalysisConfig)(nil),                      // 28: memos.store.ImageAnalysisConfig
+	(*InstanceMemoTemplatesSetting)(nil),             // 29: memos.store.InstanceMemoTemplatesSetting
+	(*StorageQuota_UserOverride)(nil),                // 30: memos.store.StorageQuota.UserOverride
+	nil,                                              // 31: memos.store.InstanceTagsSetting.TagsEntry
+	(*InstanceNotificationSetting_EmailSetting)(nil), // 32: memos.store.InstanceNotificationSetting.EmailSetting
+	(*color.Color)(nil),                              // 33: google.type.Color
+	(*MemoTemplate)(nil),                             // 34: memos.store.MemoTemplate
 }
 var file_store_instance_setting_proto_depIdxs = []int32{
 	0,  // 0: memos.store.InstanceSetting.key:type_name -> memos.store.InstanceSettingKey
-	6,  // 1: memos.store.InstanceSetting.basic_setting:type_name -> memos.store.InstanceBasicSetting
-	7,  // 2: memos.store.InstanceSetting.general_setting:type_name -> memos.store.InstanceGeneralSetting
-	10, // 3: memos.store.InstanceSetting.storage_setting:type_name -> memos.store.InstanceStorageSetting
-	12, // 4: memos.store.InstanceSetting.memo_related_setting:type_name -> memos.store.InstanceMemoRelatedSetting
-	14, // 5: memos.store.InstanceSetting.tags_setting:type_name -> memos.store.InstanceTagsSetting
-	15, // 6: memos.store.InstanceSetting.notification_setting:type_name -> memos.store.InstanceNotificationSetting
-	16, // 7: memos.store.InstanceSetting.ai_setting:type_name -> memos.store.InstanceAISetting
-	19, // 8: memos.store.InstanceSetting.access_setting:type_name -> memos.store.InstanceAccessSetting
-	8,  // 9: memos.store.InstanceGeneralSetting.custom_profile:type_name -> memos.store.InstanceCustomProfile
-	1,  // 10: memos.store.Storage.type:type_name -> memos.store.StorageType
-	11, // 11: memos.store.Storage.s3_config:type_name -> memos.store.StorageS3Config
-	4,  // 12: memos.store.InstanceStorageSetting.storage_type:type_name -> memos.store.InstanceStorageSetting.StorageType
-	11, // 13: memos.store.InstanceStorageSetting.s3_config:type_name -> memos.store.StorageS3Config
-	9,  // 14: memos.store.InstanceStorageSetting.storages:type_name -> memos.store.Storage
-	22, // 15: memos.store.InstanceTagMetadata.background_color:type_name -> google.type.Color
-	20, // 16: memos.store.InstanceTagsSetting.tags:type_name -> memos.store.InstanceTagsSetting.TagsEntry
-	21, // 17: memos.store.InstanceNotificationSetting.email:type_name -> memos.store.InstanceNotificationSetting.EmailSetting
-	17, // 18: memos.store.InstanceAISetting.providers:type_name -> memos.store.AIProviderConfig
-	18, // 19: memos.store.InstanceAISetting.transcription:type_name -> memos.store.TranscriptionConfig
-	2,  // 20: memos.store.AIProviderConfig.type:type_name -> memos.store.AIProviderType
-	3,  // 21: memos.store.InstanceAccessSetting.access_mode:type_name -> memos.store.InstanceAccessMode
-	13, // 22: memos.store.InstanceTagsSetting.TagsEntry.value:type_name -> memos.store.InstanceTagMetadata
-	23, // [23:23] is the sub-list for method output_type
-	23, // [23:23] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	9,  // 1: memos.store.InstanceSetting.basic_setting:type_name -> memos.store.InstanceBasicSetting
+	10, // 2: memos.store.InstanceSetting.general_setting:type_name -> memos.store.InstanceGeneralSetting
+	13, // 3: memos.store.InstanceSetting.storage_setting:type_name -> memos.store.InstanceStorageSetting
+	20, // 4: memos.store.InstanceSetting.memo_related_setting:type_name -> memos.store.InstanceMemoRelatedSetting
+	22, // 5: memos.store.InstanceSetting.tags_setting:type_name -> memos.store.InstanceTagsSetting
+	23, // 6: memos.store.InstanceSetting.notification_setting:type_name -> memos.store.InstanceNotificationSetting
+	24, // 7: memos.store.InstanceSetting.ai_setting:type_name -> memos.store.InstanceAISetting
+	27, // 8: memos.store.InstanceSetting.access_setting:type_name -> memos.store.InstanceAccessSetting
+	29, // 9: memos.store.InstanceSetting.memo_templates_setting:type_name -> memos.store.InstanceMemoTemplatesSetting
+	11, // 10: memos.store.InstanceGeneralSetting.custom_profile:type_name -> memos.store.InstanceCustomProfile
+	1,  // 11: memos.store.Storage.type:type_name -> memos.store.StorageType
+	16, // 12: memos.store.Storage.s3_config:type_name -> memos.store.StorageS3Config
+	17, // 13: memos.store.Storage.local_config:type_name -> memos.store.StorageLocalConfig
+	18, // 14: memos.store.Storage.webdav_config:type_name -> memos.store.StorageWebDAVConfig
+	19, // 15: memos.store.Storage.sftp_config:type_name -> memos.store.StorageSFTPConfig
+	4,  // 16: memos.store.InstanceStorageSetting.storage_type:type_name -> memos.store.InstanceStorageSetting.StorageType
+	16, // 17: memos.store.InstanceStorageSetting.s3_config:type_name -> memos.store.StorageS3Config
+	12, // 18: memos.store.InstanceStorageSetting.storages:type_name -> memos.store.Storage
+	15, // 19: memos.store.InstanceStorageSetting.quota:type_name -> memos.store.StorageQuota
+	14, // 20: memos.store.InstanceStorageSetting.content_scan:type_name -> memos.store.ContentScanConfig
+	5,  // 21: memos.store.ContentScanConfig.scanner:type_name -> memos.store.ContentScanConfig.Scanner
+	6,  // 22: memos.store.ContentScanConfig.infected_action:type_name -> memos.store.ContentScanConfig.Action
+	30, // 23: memos.store.StorageQuota.user_overrides:type_name -> memos.store.StorageQuota.UserOverride
+	33, // 24: memos.store.InstanceTagMetadata.background_color:type_name -> google.type.Color
+	31, // 25: memos.store.InstanceTagsSetting.tags:type_name -> memos.store.InstanceTagsSetting.TagsEntry
+	32, // 26: memos.store.InstanceNotificationSetting.email:type_name -> memos.store.InstanceNotificationSetting.EmailSetting
+	25, // 27: memos.store.InstanceAISetting.providers:type_name -> memos.store.AIProviderConfig
+	26, // 28: memos.store.InstanceAISetting.transcription:type_name -> memos.store.TranscriptionConfig
+	28, // 29: memos.store.InstanceAISetting.image_analysis:type_name -> memos.store.ImageAnalysisConfig
+	2,  // 30: memos.store.AIProviderConfig.type:type_name -> memos.store.AIProviderType
+	3,  // 31: memos.store.InstanceAccessSetting.access_mode:type_name -> memos.store.InstanceAccessMode
+	7,  // 32: memos.store.ImageAnalysisConfig.engine:type_name -> memos.store.ImageAnalysisConfig.Engine
+	34, // 33: memos.store.InstanceMemoTemplatesSetting.templates:type_name -> memos.store.MemoTemplate
+	21, // 34: memos.store.InstanceTagsSetting.TagsEntry.value:type_name -> memos.store.InstanceTagMetadata
+	35, // [35:35] is the sub-list for method output_type
+	35, // [35:35] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_store_instance_setting_proto_init() }
@@ -1797,6 +2812,7 @@ func file_store_instance_setting_proto_init() {
 	if File_store_instance_setting_proto != nil {
 		return
 	}
+	file_store_memo_template_proto_init()
 	file_store_instance_setting_proto_msgTypes[0].OneofWrappers = []any{
 		(*InstanceSetting_BasicSetting)(nil),
 		(*InstanceSetting_GeneralSetting)(nil),
@@ -1806,17 +2822,21 @@ func file_store_instance_setting_proto_init() {
 		(*InstanceSetting_NotificationSetting)(nil),
 		(*InstanceSetting_AiSetting)(nil),
 		(*InstanceSetting_AccessSetting)(nil),
+		(*InstanceSetting_MemoTemplatesSetting)(nil),
 	}
 	file_store_instance_setting_proto_msgTypes[4].OneofWrappers = []any{
 		(*Storage_S3Config)(nil),
+		(*Storage_LocalConfig)(nil),
+		(*Storage_WebdavConfig)(nil),
+		(*Storage_SftpConfig)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_instance_setting_proto_rawDesc), len(file_store_instance_setting_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   17,
+			NumEnums:      8,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
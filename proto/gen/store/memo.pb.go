@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.11
+// 	protoc-gen-go v1.36.12
 // 	protoc        (unknown)
 // source: store/memo.proto
 
@@ -22,10 +22,18 @@ const (
 )
 
 type MemoPayload struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Property      *MemoPayload_Property  `protobuf:"bytes,1,opt,name=property,proto3" json:"property,omitempty"`
-	Location      *MemoPayload_Location  `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
-	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Property *MemoPayload_Property  `protobuf:"bytes,1,opt,name=property,proto3" json:"property,omitempty"`
+	Location *MemoPayload_Location  `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Tags     []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The typed properties from the YAML front matter of the memo content, keyed
+	// by property name.
+	Properties map[string]*MemoPayload_PropertyValue `protobuf:"bytes,4,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The task list items in the memo content, in document order.
+	Tasks []*MemoPayload_Task `protobuf:"bytes,5,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// The usernames mentioned in the memo content, as written and without the
+	// @. The @here group mention is not included.
+	Mentions      []string `protobuf:"bytes,6,rep,name=mentions,proto3" json:"mentions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,6 +89,27 @@ func (x *MemoPayload) GetTags() []string {
 	return nil
 }
 
+func (x *MemoPayload) GetProperties() map[string]*MemoPayload_PropertyValue {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *MemoPayload) GetTasks() []*MemoPayload_Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *MemoPayload) GetMentions() []string {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
 // The calculated properties from the memo content.
 type MemoPayload_Property struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -96,7 +125,7 @@ type MemoPayload_Property struct {
 
 func (x *MemoPayload_Property) Reset() {
 	*x = MemoPayload_Property{}
-	mi := &file_store_memo_proto_msgTypes[1]
+	mi := &file_store_memo_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -108,7 +137,7 @@ func (x *MemoPayload_Property) String() string {
 func (*MemoPayload_Property) ProtoMessage() {}
 
 func (x *MemoPayload_Property) ProtoReflect() protoreflect.Message {
-	mi := &file_store_memo_proto_msgTypes[1]
+	mi := &file_store_memo_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -121,7 +150,7 @@ func (x *MemoPayload_Property) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoPayload_Property.ProtoReflect.Descriptor instead.
 func (*MemoPayload_Property) Descriptor() ([]byte, []int) {
-	return file_store_memo_proto_rawDescGZIP(), []int{0, 0}
+	return file_store_memo_proto_rawDescGZIP(), []int{0, 1}
 }
 
 func (x *MemoPayload_Property) GetHasLink() bool {
@@ -159,6 +188,266 @@ func (x *MemoPayload_Property) GetTitle() string {
 	return ""
 }
 
+// A front matter property value.
+type MemoPayload_PropertyValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*MemoPayload_PropertyValue_StringValue
+	//	*MemoPayload_PropertyValue_NumberValue
+	//	*MemoPayload_PropertyValue_DateTs
+	//	*MemoPayload_PropertyValue_BoolValue
+	//	*MemoPayload_PropertyValue_ListValue
+	Kind          isMemoPayload_PropertyValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoPayload_PropertyValue) Reset() {
+	*x = MemoPayload_PropertyValue{}
+	mi := &file_store_memo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoPayload_PropertyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoPayload_PropertyValue) ProtoMessage() {}
+
+func (x *MemoPayload_PropertyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_store_memo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoPayload_PropertyValue.ProtoReflect.Descriptor instead.
+func (*MemoPayload_PropertyValue) Descriptor() ([]byte, []int) {
+	return file_store_memo_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *MemoPayload_PropertyValue) GetKind() isMemoPayload_PropertyValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *MemoPayload_PropertyValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*MemoPayload_PropertyValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *MemoPayload_PropertyValue) GetNumberValue() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*MemoPayload_PropertyValue_NumberValue); ok {
+			return x.NumberValue
+		}
+	}
+	return 0
+}
+
+func (x *MemoPayload_PropertyValue) GetDateTs() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*MemoPayload_PropertyValue_DateTs); ok {
+			return x.DateTs
+		}
+	}
+	return 0
+}
+
+func (x *MemoPayload_PropertyValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*MemoPayload_PropertyValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *MemoPayload_PropertyValue) GetListValue() *MemoPayload_StringList {
+	if x != nil {
+		if x, ok := x.Kind.(*MemoPayload_PropertyValue_ListValue); ok {
+			return x.ListValue
+		}
+	}
+	return nil
+}
+
+type isMemoPayload_PropertyValue_Kind interface {
+	isMemoPayload_PropertyValue_Kind()
+}
+
+type MemoPayload_PropertyValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type MemoPayload_PropertyValue_NumberValue struct {
+	NumberValue float64 `protobuf:"fixed64,2,opt,name=number_value,json=numberValue,proto3,oneof"`
+}
+
+type MemoPayload_PropertyValue_DateTs struct {
+	// A date or date-time in seconds since the Unix epoch.
+	DateTs int64 `protobuf:"varint,3,opt,name=date_ts,json=dateTs,proto3,oneof"`
+}
+
+type MemoPayload_PropertyValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,4,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type MemoPayload_PropertyValue_ListValue struct {
+	ListValue *MemoPayload_StringList `protobuf:"bytes,5,opt,name=list_value,json=listValue,proto3,oneof"`
+}
+
+func (*MemoPayload_PropertyValue_StringValue) isMemoPayload_PropertyValue_Kind() {}
+
+func (*MemoPayload_PropertyValue_NumberValue) isMemoPayload_PropertyValue_Kind() {}
+
+func (*MemoPayload_PropertyValue_DateTs) isMemoPayload_PropertyValue_Kind() {}
+
+func (*MemoPayload_PropertyValue_BoolValue) isMemoPayload_PropertyValue_Kind() {}
+
+func (*MemoPayload_PropertyValue_ListValue) isMemoPayload_PropertyValue_Kind() {}
+
+type MemoPayload_StringList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoPayload_StringList) Reset() {
+	*x = MemoPayload_StringList{}
+	mi := &file_store_memo_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoPayload_StringList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoPayload_StringList) ProtoMessage() {}
+
+func (x *MemoPayload_StringList) ProtoReflect() protoreflect.Message {
+	mi := &file_store_memo_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoPayload_StringList.ProtoReflect.Descriptor instead.
+func (*MemoPayload_StringList) Descriptor() ([]byte, []int) {
+	return file_store_memo_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *MemoPayload_StringList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// A task list item.
+type MemoPayload_Task struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID derived from the task text. It is unique within the memo and
+	// survives checking or unchecking the task.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The task text without its checkbox and markers.
+	Text    string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Checked bool   `protobuf:"varint,3,opt,name=checked,proto3" json:"checked,omitempty"`
+	// The due date from a due:YYYY-MM-DD or @YYYY-MM-DD marker, in seconds
+	// since the Unix epoch, or 0 when unset.
+	DueTs int64 `protobuf:"varint,4,opt,name=due_ts,json=dueTs,proto3" json:"due_ts,omitempty"`
+	// The priority from a !1, !2 or !3 marker, where 1 is the highest, or 0
+	// when unset.
+	Priority      int32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoPayload_Task) Reset() {
+	*x = MemoPayload_Task{}
+	mi := &file_store_memo_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoPayload_Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoPayload_Task) ProtoMessage() {}
+
+func (x *MemoPayload_Task) ProtoReflect() protoreflect.Message {
+	mi := &file_store_memo_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoPayload_Task.ProtoReflect.Descriptor instead.
+func (*MemoPayload_Task) Descriptor() ([]byte, []int) {
+	return file_store_memo_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *MemoPayload_Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MemoPayload_Task) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *MemoPayload_Task) GetChecked() bool {
+	if x != nil {
+		return x.Checked
+	}
+	return false
+}
+
+func (x *MemoPayload_Task) GetDueTs() int64 {
+	if x != nil {
+		return x.DueTs
+	}
+	return 0
+}
+
+func (x *MemoPayload_Task) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
 type MemoPayload_Location struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Placeholder   string                 `protobuf:"bytes,1,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
@@ -170,7 +459,7 @@ type MemoPayload_Location struct {
 
 func (x *MemoPayload_Location) Reset() {
 	*x = MemoPayload_Location{}
-	mi := &file_store_memo_proto_msgTypes[2]
+	mi := &file_store_memo_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -182,7 +471,7 @@ func (x *MemoPayload_Location) String() string {
 func (*MemoPayload_Location) ProtoMessage() {}
 
 func (x *MemoPayload_Location) ProtoReflect() protoreflect.Message {
-	mi := &file_store_memo_proto_msgTypes[2]
+	mi := &file_store_memo_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,7 +484,7 @@ func (x *MemoPayload_Location) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoPayload_Location.ProtoReflect.Descriptor instead.
 func (*MemoPayload_Location) Descriptor() ([]byte, []int) {
-	return file_store_memo_proto_rawDescGZIP(), []int{0, 1}
+	return file_store_memo_proto_rawDescGZIP(), []int{0, 5}
 }
 
 func (x *MemoPayload_Location) GetPlaceholder() string {
@@ -223,17 +512,43 @@ var File_store_memo_proto protoreflect.FileDescriptor
 
 const file_store_memo_proto_rawDesc = "" +
 	"\n" +
-	"\x10store/memo.proto\x12\vmemos.store\"\xb6\x03\n" +
+	"\x10store/memo.proto\x12\vmemos.store\"\xbd\b\n" +
 	"\vMemoPayload\x12=\n" +
 	"\bproperty\x18\x01 \x01(\v2!.memos.store.MemoPayload.PropertyR\bproperty\x12=\n" +
 	"\blocation\x18\x02 \x01(\v2!.memos.store.MemoPayload.LocationR\blocation\x12\x12\n" +
-	"\x04tags\x18\x03 \x03(\tR\x04tags\x1a\xac\x01\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12H\n" +
+	"\n" +
+	"properties\x18\x04 \x03(\v2(.memos.store.MemoPayload.PropertiesEntryR\n" +
+	"properties\x123\n" +
+	"\x05tasks\x18\x05 \x03(\v2\x1d.memos.store.MemoPayload.TaskR\x05tasks\x12\x1a\n" +
+	"\bmentions\x18\x06 \x03(\tR\bmentions\x1ae\n" +
+	"\x0fPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12<\n" +
+	"\x05value\x18\x02 \x01(\v2&.memos.store.MemoPayload.PropertyValueR\x05value:\x028\x01\x1a\xac\x01\n" +
 	"\bProperty\x12\x19\n" +
 	"\bhas_link\x18\x01 \x01(\bR\ahasLink\x12\"\n" +
 	"\rhas_task_list\x18\x02 \x01(\bR\vhasTaskList\x12\x19\n" +
 	"\bhas_code\x18\x03 \x01(\bR\ahasCode\x120\n" +
 	"\x14has_incomplete_tasks\x18\x04 \x01(\bR\x12hasIncompleteTasks\x12\x14\n" +
-	"\x05title\x18\x05 \x01(\tR\x05title\x1af\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x1a\xe3\x01\n" +
+	"\rPropertyValue\x12#\n" +
+	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x12#\n" +
+	"\fnumber_value\x18\x02 \x01(\x01H\x00R\vnumberValue\x12\x19\n" +
+	"\adate_ts\x18\x03 \x01(\x03H\x00R\x06dateTs\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x04 \x01(\bH\x00R\tboolValue\x12D\n" +
+	"\n" +
+	"list_value\x18\x05 \x01(\v2#.memos.store.MemoPayload.StringListH\x00R\tlistValueB\x06\n" +
+	"\x04kind\x1a$\n" +
+	"\n" +
+	"StringList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\x1aw\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x18\n" +
+	"\achecked\x18\x03 \x01(\bR\achecked\x12\x15\n" +
+	"\x06due_ts\x18\x04 \x01(\x03R\x05dueTs\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x1af\n" +
 	"\bLocation\x12 \n" +
 	"\vplaceholder\x18\x01 \x01(\tR\vplaceholder\x12\x1a\n" +
 	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
@@ -252,20 +567,28 @@ func file_store_memo_proto_rawDescGZIP() []byte {
 	return file_store_memo_proto_rawDescData
 }
 
-var file_store_memo_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_store_memo_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_store_memo_proto_goTypes = []any{
-	(*MemoPayload)(nil),          // 0: memos.store.MemoPayload
-	(*MemoPayload_Property)(nil), // 1: memos.store.MemoPayload.Property
-	(*MemoPayload_Location)(nil), // 2: memos.store.MemoPayload.Location
+	(*MemoPayload)(nil),               // 0: memos.store.MemoPayload
+	nil,                               // 1: memos.store.MemoPayload.PropertiesEntry
+	(*MemoPayload_Property)(nil),      // 2: memos.store.MemoPayload.Property
+	(*MemoPayload_PropertyValue)(nil), // 3: memos.store.MemoPayload.PropertyValue
+	(*MemoPayload_StringList)(nil),    // 4: memos.store.MemoPayload.StringList
+	(*MemoPayload_Task)(nil),          // 5: memos.store.MemoPayload.Task
+	(*MemoPayload_Location)(nil),      // 6: memos.store.MemoPayload.Location
 }
 var file_store_memo_proto_depIdxs = []int32{
-	1, // 0: memos.store.MemoPayload.property:type_name -> memos.store.MemoPayload.Property
-	2, // 1: memos.store.MemoPayload.location:type_name -> memos.store.MemoPayload.Location
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 0: memos.store.MemoPayload.property:type_name -> memos.store.MemoPayload.Property
+	6, // 1: memos.store.MemoPayload.location:type_name -> memos.store.MemoPayload.Location
+	1, // 2: memos.store.MemoPayload.properties:type_name -> memos.store.MemoPayload.PropertiesEntry
+	5, // 3: memos.store.MemoPayload.tasks:type_name -> memos.store.MemoPayload.Task
+	3, // 4: memos.store.MemoPayload.PropertiesEntry.value:type_name -> memos.store.MemoPayload.PropertyValue
+	4, // 5: memos.store.MemoPayload.PropertyValue.list_value:type_name -> memos.store.MemoPayload.StringList
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_store_memo_proto_init() }
@@ -273,13 +596,20 @@ func file_store_memo_proto_init() {
 	if File_store_memo_proto != nil {
 		return
 	}
+	file_store_memo_proto_msgTypes[3].OneofWrappers = []any{
+		(*MemoPayload_PropertyValue_StringValue)(nil),
+		(*MemoPayload_PropertyValue_NumberValue)(nil),
+		(*MemoPayload_PropertyValue_DateTs)(nil),
+		(*MemoPayload_PropertyValue_BoolValue)(nil),
+		(*MemoPayload_PropertyValue_ListValue)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_memo_proto_rawDesc), len(file_store_memo_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
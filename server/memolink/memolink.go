@@ -0,0 +1,252 @@
+// Package memolink resolves [[memo]] links and ![[memo]] embeds to memos and
+// expands embeds into rendered memo content.
+package memolink
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/base"
+	"github.com/usememos/memos/internal/markdown"
+	"github.com/usememos/memos/server/access"
+	"github.com/usememos/memos/store"
+)
+
+// maxEmbedDepth bounds how many embeds deep an expansion may nest.
+const maxEmbedDepth = 4
+
+// maxEmbedsPerExpansion bounds how many embeds one expansion expands in
+// total, so memos that embed each other many times cannot fan out.
+const maxEmbedsPerExpansion = 64
+
+// embedMarker prefixes every ![[memo]] embed; content without it renders as is.
+const embedMarker = "![["
+
+// Resolver resolves memo link targets and expands embeds.
+type Resolver struct {
+	store    *store.Store
+	markdown markdown.Service
+}
+
+// NewResolver creates a resolver backed by the store and markdown service.
+func NewResolver(store *store.Store, markdownService markdown.Service) *Resolver {
+	return &Resolver{
+		store:    store,
+		markdown: markdownService,
+	}
+}
+
+// ResolveTarget resolves a link or embed target written by the user creatorID.
+// A target resolves to the memo with that UID when the creator may read it,
+// or else to the creator's newest memo whose title matches the target
+// case-insensitively. It returns nil when nothing matches.
+func (r *Resolver) ResolveTarget(ctx context.Context, creatorID int32, target string) (*store.Memo, error) {
+	return r.resolveTarget(&readChecker{store: r.store, ctx: ctx}, creatorID, target)
+}
+
+func (r *Resolver) resolveTarget(checker *readChecker, creatorID int32, target string) (*store.Memo, error) {
+	ctx := checker.ctx
+	if base.UIDMatcher.MatchString(target) {
+		memo, err := r.store.GetMemo(ctx, &store.FindMemo{UID: &target})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get linked memo")
+		}
+		if memo != nil {
+			// Links resolve with the access of their author, so a link never
+			// reveals a memo its author could not open.
+			creator, err := checker.user(creatorID)
+			if err != nil {
+				return nil, err
+			}
+			readable, err := checker.canRead(memo, creator)
+			if err != nil {
+				return nil, err
+			}
+			if readable {
+				return memo, nil
+			}
+		}
+	}
+
+	// Titles come from the first heading, so the content must contain the
+	// title text; the exact comparison happens on the stored payload.
+	normal := store.Normal
+	candidates, err := r.store.ListMemos(ctx, &store.FindMemo{
+		CreatorID: &creatorID,
+		RowStatus: &normal,
+		Filters:   []string{fmt.Sprintf("content.contains(%s)", strconv.Quote(target))},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find linked memo")
+	}
+	for _, candidate := range candidates {
+		if title := candidate.Payload.GetProperty().GetTitle(); title != "" && strings.EqualFold(title, target) {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// RenderHTML renders the content of memo to HTML with the embeds viewer may
+// read expanded in place. A nil viewer is anonymous.
+func (r *Resolver) RenderHTML(ctx context.Context, memo *store.Memo, viewer *store.User) (string, error) {
+	if !strings.Contains(memo.Content, embedMarker) {
+		return r.markdown.RenderHTML(ctx, []byte(memo.Content))
+	}
+	e := newExpansion(ctx, r, viewer)
+	return e.renderHTML(memo, []string{memo.UID})
+}
+
+// GenerateSnippet creates a plain text summary of memo in which the embeds
+// viewer may read contribute their own text. A nil viewer is anonymous.
+func (r *Resolver) GenerateSnippet(ctx context.Context, memo *store.Memo, viewer *store.User, maxLength int) (string, error) {
+	if !strings.Contains(memo.Content, embedMarker) {
+		return r.markdown.GenerateSnippet([]byte(memo.Content), maxLength)
+	}
+	e := newExpansion(ctx, r, viewer)
+	return e.generateSnippet(memo, []string{memo.UID}, maxLength)
+}
+
+// expansion holds the state of expanding the embeds of one memo.
+type expansion struct {
+	resolver *Resolver
+	checker  *readChecker
+	viewer   *store.User
+	// embeds counts the embeds expanded so far.
+	embeds int
+	// targets memoizes resolved targets; nil records a target that did not
+	// resolve.
+	targets map[embedTarget]*store.Memo
+}
+
+// embedTarget is an embed target as written by the user creatorID.
+type embedTarget struct {
+	creatorID int32
+	target    string
+}
+
+func newExpansion(ctx context.Context, resolver *Resolver, viewer *store.User) *expansion {
+	return &expansion{
+		resolver: resolver,
+		checker:  &readChecker{store: resolver.store, ctx: ctx},
+		viewer:   viewer,
+		targets:  map[embedTarget]*store.Memo{},
+	}
+}
+
+func (e *expansion) renderHTML(memo *store.Memo, path []string) (string, error) {
+	return e.resolver.markdown.RenderHTMLWithEmbeds(e.checker.ctx, []byte(memo.Content), func(target string) (string, bool, error) {
+		embedded, err := e.resolveEmbed(memo, target, path)
+		if err != nil || embedded == nil {
+			return "", false, err
+		}
+		rendered, err := e.renderHTML(embedded, append(slices.Clone(path), embedded.UID))
+		if err != nil {
+			return "", false, err
+		}
+		return rendered, true, nil
+	})
+}
+
+func (e *expansion) generateSnippet(memo *store.Memo, path []string, maxLength int) (string, error) {
+	return e.resolver.markdown.GenerateSnippetWithEmbeds([]byte(memo.Content), maxLength, func(target string) (string, bool, error) {
+		embedded, err := e.resolveEmbed(memo, target, path)
+		if err != nil || embedded == nil {
+			return "", false, err
+		}
+		snippet, err := e.generateSnippet(embedded, append(slices.Clone(path), embedded.UID), maxLength)
+		if err != nil {
+			return "", false, err
+		}
+		return snippet, true, nil
+	})
+}
+
+// resolveEmbed returns the memo that an embed in memo points to, or nil when
+// the embed is too deep, exceeds the expansion's budget, would form a cycle
+// along path, or is not readable by the viewer.
+func (e *expansion) resolveEmbed(memo *store.Memo, target string, path []string) (*store.Memo, error) {
+	if len(path) > maxEmbedDepth || e.embeds >= maxEmbedsPerExpansion {
+		return nil, nil
+	}
+	embedded, err := e.resolveTarget(memo.CreatorID, target)
+	if err != nil || embedded == nil {
+		return nil, err
+	}
+	if slices.Contains(path, embedded.UID) {
+		return nil, nil
+	}
+	readable, err := e.checker.canRead(embedded, e.viewer)
+	if err != nil || !readable {
+		return nil, err
+	}
+	e.embeds++
+	return embedded, nil
+}
+
+func (e *expansion) resolveTarget(creatorID int32, target string) (*store.Memo, error) {
+	key := embedTarget{creatorID: creatorID, target: target}
+	if memo, ok := e.targets[key]; ok {
+		return memo, nil
+	}
+	memo, err := e.resolver.resolveTarget(e.checker, creatorID, target)
+	if err != nil {
+		return nil, err
+	}
+	e.targets[key] = memo
+	return memo, nil
+}
+
+// readChecker checks read access to memos, loading the instance access
+// policy and the users involved once.
+type readChecker struct {
+	store *store.Store
+	ctx   context.Context
+	// allowAnonymous is loaded on first use.
+	allowAnonymous *bool
+	users          map[int32]*store.User
+}
+
+// user returns the user with the ID, or nil when it no longer exists.
+func (c *readChecker) user(id int32) (*store.User, error) {
+	if user, ok := c.users[id]; ok {
+		return user, nil
+	}
+	user, err := c.store.GetUser(c.ctx, &store.FindUser{ID: &id})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if c.users == nil {
+		c.users = map[int32]*store.User{}
+	}
+	c.users[id] = user
+	return user, nil
+}
+
+// canRead reports whether viewer may read memo. A nil viewer is anonymous.
+func (c *readChecker) canRead(memo *store.Memo, viewer *store.User) (bool, error) {
+	var parent *store.Memo
+	if memo.ParentUID != nil {
+		var err error
+		parent, err = c.store.GetMemo(c.ctx, &store.FindMemo{UID: memo.ParentUID})
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get parent memo")
+		}
+		if parent == nil {
+			return false, nil
+		}
+	}
+	if c.allowAnonymous == nil {
+		allowAnonymous, err := c.store.AllowsAnonymousAccess(c.ctx)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get instance access policy")
+		}
+		c.allowAnonymous = &allowAnonymous
+	}
+	return access.CheckMemoRead(memo, parent, viewer, *c.allowAnonymous, nil).Allowed(), nil
+}
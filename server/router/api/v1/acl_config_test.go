@@ -52,23 +52,47 @@ func TestProtectedMethodsRequireAuth(t *testing.T) {
 		// Instance Service - admin operations
 		"/memos.api.v1.InstanceService/UpdateInstanceSetting",
 		"/memos.api.v1.InstanceService/TestInstanceEmailSetting",
+		"/memos.api.v1.InstanceService/TestAIProvider",
+		"/memos.api.v1.InstanceService/ListStorageConsumers",
 		// User Service - modification operations
 		"/memos.api.v1.UserService/ListUsers",
 		"/memos.api.v1.UserService/UpdateUser",
 		"/memos.api.v1.UserService/DeleteUser",
+		"/memos.api.v1.UserService/ListUserWebhookDeliveries",
+		"/memos.api.v1.UserService/RedeliverWebhook",
 		// Memo Service - write operations
 		"/memos.api.v1.MemoService/CreateMemo",
 		"/memos.api.v1.MemoService/UpdateMemo",
 		"/memos.api.v1.MemoService/DeleteMemo",
+		"/memos.api.v1.MemoService/RenameTag",
+		"/memos.api.v1.MemoService/MergeTags",
+		"/memos.api.v1.MemoService/DeleteTag",
+		"/memos.api.v1.MemoService/SetTaskState",
+		"/memos.api.v1.MemoService/GetDailyMemo",
+		"/memos.api.v1.MemoService/AppendDailyMemo",
+		"/memos.api.v1.MemoService/ListDailyMemos",
+		// Memo Service - relation views
+		"/memos.api.v1.MemoService/ListMemoBacklinks",
 		// Attachment Service - write operations
 		"/memos.api.v1.AttachmentService/CreateAttachment",
 		"/memos.api.v1.AttachmentService/DeleteAttachment",
+		"/memos.api.v1.AttachmentService/MigrateAttachments",
+		"/memos.api.v1.AttachmentService/CreateUploadSession",
+		"/memos.api.v1.AttachmentService/GetUploadSession",
+		"/memos.api.v1.AttachmentService/CompleteUploadSession",
+		"/memos.api.v1.AttachmentService/DeleteUploadSession",
 		// Memo View Service
 		"/memos.api.v1.MemoViewService/CreateMemoView",
 		"/memos.api.v1.MemoViewService/GetMemoView",
 		"/memos.api.v1.MemoViewService/ListMemoViews",
 		"/memos.api.v1.MemoViewService/UpdateMemoView",
 		"/memos.api.v1.MemoViewService/DeleteMemoView",
+		// Memo Template Service
+		"/memos.api.v1.MemoTemplateService/CreateMemoTemplate",
+		"/memos.api.v1.MemoTemplateService/GetMemoTemplate",
+		"/memos.api.v1.MemoTemplateService/ListMemoTemplates",
+		"/memos.api.v1.MemoTemplateService/UpdateMemoTemplate",
+		"/memos.api.v1.MemoTemplateService/DeleteMemoTemplate",
 	}
 
 	for _, method := range protectedMethods {
@@ -5,7 +5,9 @@ import (
 	"context"
 	"mime"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -14,6 +16,10 @@ import (
 	"github.com/usememos/memos/internal/ai"
 	"github.com/usememos/memos/internal/ai/audiollm"
 	audiollmgemini "github.com/usememos/memos/internal/ai/audiollm/gemini"
+	"github.com/usememos/memos/internal/ai/catalog"
+	cataloggemini "github.com/usememos/memos/internal/ai/catalog/gemini"
+	catalogollama "github.com/usememos/memos/internal/ai/catalog/ollama"
+	catalogopenai "github.com/usememos/memos/internal/ai/catalog/openai"
 	"github.com/usememos/memos/internal/ai/stt"
 	sttopenai "github.com/usememos/memos/internal/ai/stt/openai"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
@@ -83,6 +89,30 @@ func (s *APIV1Service) Transcribe(ctx context.Context, request *v1pb.TranscribeR
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get AI setting: %v", err)
 	}
+	result, err := s.transcribeAudio(ctx, aiSetting, content, filename, contentType, false)
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.TranscribeResponse{Text: result.Text}, nil
+}
+
+// audioTranscription is the provider-independent result of transcribeAudio.
+type audioTranscription struct {
+	*stt.Response
+	Model string
+}
+
+// transcribeAudio transcribes audio with the persisted transcription setting.
+// Configuration problems are returned as gRPC status errors; provider failures
+// are wrapped in codes.Internal.
+func (s *APIV1Service) transcribeAudio(
+	ctx context.Context,
+	aiSetting *storepb.InstanceAISetting,
+	content []byte,
+	filename string,
+	contentType string,
+	timestamps bool,
+) (*audioTranscription, error) {
 	persisted := aiSetting.GetTranscription()
 
 	providerID := persisted.GetProviderId()
@@ -104,20 +134,28 @@ func (s *APIV1Service) Transcribe(ctx context.Context, request *v1pb.TranscribeR
 		model = defaultModel
 	}
 
-	var text string
-	switch provider.Type {
-	case ai.ProviderOpenAI:
-		text, err = s.transcribeViaSTT(ctx, provider, persisted, model, content, filename, contentType)
-	case ai.ProviderGemini:
-		text, err = s.transcribeViaAudioLLM(ctx, provider, persisted, model, content, contentType)
-	default:
+	if !ai.SupportsTranscription(provider.Type) {
 		return nil, status.Errorf(codes.FailedPrecondition,
 			"provider type %q is not supported for transcription", provider.Type)
 	}
+
+	release, err := s.aiProviderLimiter.Acquire(ctx, provider)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	defer release()
+
+	var resp *stt.Response
+	switch provider.Type {
+	case ai.ProviderOpenAI, ai.ProviderOpenAICompatible:
+		resp, err = s.transcribeViaSTT(ctx, provider, persisted, model, content, filename, contentType, timestamps)
+	case ai.ProviderGemini:
+		resp, err = s.transcribeViaAudioLLM(ctx, provider, persisted, model, content, contentType)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to transcribe audio: %v", err)
 	}
-	return &v1pb.TranscribeResponse{Text: text}, nil
+	return &audioTranscription{Response: resp, Model: model}, nil
 }
 
 func (*APIV1Service) transcribeViaSTT(
@@ -128,12 +166,13 @@ func (*APIV1Service) transcribeViaSTT(
 	content []byte,
 	filename string,
 	contentType string,
-) (string, error) {
-	transcriber, err := sttopenai.New(provider, stt.ApplyOptions(nil))
+	timestamps bool,
+) (*stt.Response, error) {
+	transcriber, err := sttopenai.New(provider, stt.ApplyOptions([]stt.TranscriberOption{stt.WithTimeout(provider.Timeout)}))
 	if err != nil {
-		return "", errors.Wrap(err, "failed to create STT transcriber")
+		return nil, errors.Wrap(err, "failed to create STT transcriber")
 	}
-	resp, err := transcriber.Transcribe(ctx, stt.Request{
+	return transcriber.Transcribe(ctx, stt.Request{
 		Audio:       bytes.NewReader(content),
 		Size:        int64(len(content)),
 		Filename:    filename,
@@ -141,11 +180,8 @@ func (*APIV1Service) transcribeViaSTT(
 		Model:       model,
 		Prompt:      persisted.GetPrompt(),
 		Language:    persisted.GetLanguage(),
+		Timestamps:  timestamps,
 	})
-	if err != nil {
-		return "", err
-	}
-	return resp.Text, nil
 }
 
 func (*APIV1Service) transcribeViaAudioLLM(
@@ -155,10 +191,10 @@ func (*APIV1Service) transcribeViaAudioLLM(
 	model string,
 	content []byte,
 	contentType string,
-) (string, error) {
-	m, err := audiollmgemini.New(provider, audiollm.ApplyOptions(nil))
+) (*stt.Response, error) {
+	m, err := audiollmgemini.New(provider, audiollm.ApplyOptions([]audiollm.ModelOption{audiollm.WithTimeout(provider.Timeout)}))
 	if err != nil {
-		return "", errors.Wrap(err, "failed to create audio LLM")
+		return nil, errors.Wrap(err, "failed to create audio LLM")
 	}
 	resp, err := m.GenerateFromAudio(ctx, audiollm.Request{
 		Audio:        bytes.NewReader(content),
@@ -168,15 +204,51 @@ func (*APIV1Service) transcribeViaAudioLLM(
 		Instructions: buildTranscriptionInstructions(persisted.GetPrompt(), persisted.GetLanguage()),
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if resp.FinishReason != audiollm.FinishStop {
-		return "", errors.Errorf("transcription incomplete (finish reason: %s)", resp.FinishReason)
+		return nil, errors.Errorf("transcription incomplete (finish reason: %s)", resp.FinishReason)
 	}
 	if strings.TrimSpace(resp.Text) == "" {
-		return "", errors.New("transcription response did not include text")
+		return nil, errors.New("transcription response did not include text")
 	}
-	return resp.Text, nil
+	return &stt.Response{Text: resp.Text}, nil
+}
+
+// listAIProviderModels lists the models served by a provider, sorted by ID.
+func (s *APIV1Service) listAIProviderModels(ctx context.Context, provider ai.ProviderConfig) ([]catalog.Model, error) {
+	options := catalog.ApplyOptions([]catalog.ListerOption{catalog.WithTimeout(provider.Timeout)})
+
+	var lister catalog.Lister
+	var err error
+	switch provider.Type {
+	case ai.ProviderOpenAI, ai.ProviderOpenAICompatible:
+		lister, err = catalogopenai.New(provider, options)
+	case ai.ProviderGemini:
+		lister, err = cataloggemini.New(provider, options)
+	case ai.ProviderOllama:
+		lister, err = catalogollama.New(provider, options)
+	default:
+		return nil, errors.Wrapf(ai.ErrCapabilityUnsupported, "provider type %q", provider.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create model lister")
+	}
+
+	release, err := s.aiProviderLimiter.Acquire(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	models, err := lister.ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(models, func(a, b catalog.Model) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return models, nil
 }
 
 func buildTranscriptionInstructions(prompt, language string) string {
@@ -211,11 +283,13 @@ func (*APIV1Service) resolveAIProvider(setting *storepb.InstanceAISetting, provi
 
 func convertAIProviderConfigFromStore(provider *storepb.AIProviderConfig) ai.ProviderConfig {
 	return ai.ProviderConfig{
-		ID:       provider.GetId(),
-		Title:    provider.GetTitle(),
-		Type:     convertAIProviderTypeFromStore(provider.GetType()),
-		Endpoint: provider.GetEndpoint(),
-		APIKey:   provider.GetApiKey(),
+		ID:                    provider.GetId(),
+		Title:                 provider.GetTitle(),
+		Type:                  convertAIProviderTypeFromStore(provider.GetType()),
+		Endpoint:              provider.GetEndpoint(),
+		APIKey:                provider.GetApiKey(),
+		Timeout:               time.Duration(provider.GetTimeoutSeconds()) * time.Second,
+		MaxConcurrentRequests: int(provider.GetMaxConcurrentRequests()),
 	}
 }
 
@@ -225,6 +299,10 @@ func convertAIProviderTypeFromStore(providerType storepb.AIProviderType) ai.Prov
 		return ai.ProviderOpenAI
 	case storepb.AIProviderType_GEMINI:
 		return ai.ProviderGemini
+	case storepb.AIProviderType_OPENAI_COMPATIBLE:
+		return ai.ProviderOpenAICompatible
+	case storepb.AIProviderType_OLLAMA:
+		return ai.ProviderOllama
 	default:
 		return ""
 	}
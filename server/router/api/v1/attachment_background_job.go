@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/semaphore"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// attachmentJob analyzes the content of an attachment and returns how to apply
+// the result to its payload.
+type attachmentJob func(ctx context.Context) (func(*storepb.AttachmentPayload) error, error)
+
+// runAttachmentBackgroundJob runs job for a newly created attachment without
+// blocking the upload. The job waits for a free slot on sem, and timeout bounds
+// the wait and the job together. Its result is merged into the latest payload
+// atomically, so jobs running side by side keep each other's results, and an
+// attachment deleted in the meantime is left alone. A failure only logs.
+func (s *APIV1Service) runAttachmentBackgroundJob(ctx context.Context, name string, attachment *store.Attachment, timeout time.Duration, sem *semaphore.Weighted, job attachmentJob) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+
+		if err := s.runAttachmentJob(ctx, attachment, sem, job); err != nil {
+			slog.Warn("failed to run attachment background job",
+				slog.String("job", name),
+				slog.String("attachment", attachment.UID),
+				slog.Any("err", err))
+		}
+	}()
+}
+
+func (s *APIV1Service) runAttachmentJob(ctx context.Context, attachment *store.Attachment, sem *semaphore.Weighted, job attachmentJob) error {
+	if sem != nil {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return errors.Wrap(err, "failed to acquire a job slot")
+		}
+		defer sem.Release(1)
+	}
+
+	merge, err := job(ctx)
+	if err != nil {
+		return err
+	}
+	found, err := s.Store.UpdateAttachmentPayload(ctx, attachment.ID, merge)
+	if err != nil {
+		return errors.Wrap(err, "failed to save the job result")
+	}
+	if !found {
+		// Drop variants the job recorded for an attachment deleted meanwhile.
+		if err := s.Store.DeleteAttachmentVariants(ctx, attachment); err != nil {
+			return errors.Wrap(err, "failed to delete variants of deleted attachment")
+		}
+	}
+	return nil
+}
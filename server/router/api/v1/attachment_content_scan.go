@@ -0,0 +1,242 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/contentscan"
+	"github.com/usememos/memos/internal/storage"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// defaultContentScanTimeout bounds one scan when the setting leaves it unset.
+const defaultContentScanTimeout = 30 * time.Second
+
+// newContentScanner returns the scanner configured for uploads, or nil when
+// scanning is disabled.
+func newContentScanner(config *storepb.ContentScanConfig) contentscan.Scanner {
+	switch config.GetScanner() {
+	case storepb.ContentScanConfig_CLAMD:
+		return contentscan.NewClamd(config.ClamdAddress)
+	case storepb.ContentScanConfig_HTTP:
+		return contentscan.NewHTTP(config.HttpEndpoint, config.HttpToken)
+	default:
+		return nil
+	}
+}
+
+// scanAttachmentContent checks the content of a new attachment for malware
+// when upload scanning is enabled, and records the verdict on create. Infected
+// content is refused unless the instance quarantines it; quarantined
+// attachments are stored but never served. Content that cannot be scanned is
+// refused unless the scanner fails open.
+func (s *APIV1Service) scanAttachmentContent(ctx context.Context, instanceStorageSetting *storepb.InstanceStorageSetting, create *store.Attachment, open func() (io.ReadCloser, error)) error {
+	config := instanceStorageSetting.GetContentScan()
+	scanner := newContentScanner(config)
+	if scanner == nil {
+		return nil
+	}
+
+	timeout := defaultContentScanTimeout
+	if config.TimeoutSeconds > 0 {
+		timeout = time.Duration(config.TimeoutSeconds) * time.Second
+	}
+	scanCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	scan := &storepb.ContentScan{
+		Scanner: scanner.Name(),
+		ScanTs:  time.Now().Unix(),
+	}
+	result, err := scanAttachmentContentWith(scanCtx, scanner, open)
+	switch {
+	case err != nil:
+		if !config.FailOpen {
+			slog.Error("failed to scan attachment content", slog.String("filename", create.Filename), slog.Any("err", err))
+			return status.Errorf(codes.Unavailable, "failed to scan attachment content")
+		}
+		slog.Warn("failed to scan attachment content; accepting it unscanned", slog.String("filename", create.Filename), slog.Any("err", err))
+		scan.Status = storepb.ContentScan_FAILED
+	case result.Infected:
+		scan.Status = storepb.ContentScan_INFECTED
+		scan.Signature = result.Signature
+		slog.Warn("infected attachment uploaded",
+			slog.String("filename", create.Filename),
+			slog.Int("creator", int(create.CreatorID)),
+			slog.String("signature", result.Signature))
+		if config.InfectedAction != storepb.ContentScanConfig_QUARANTINE {
+			// Rejected uploads are never stored, so admins learn of them here.
+			s.notifyAdminsOfInfectedAttachment(ctx, create, scan)
+			return status.Errorf(codes.InvalidArgument, "attachment content was rejected by the malware scanner: %s", result.Signature)
+		}
+	default:
+		scan.Status = storepb.ContentScan_CLEAN
+	}
+	create.Payload = ensureAttachmentPayload(create.Payload)
+	create.Payload.ContentScan = scan
+	return nil
+}
+
+func scanAttachmentContentWith(ctx context.Context, scanner contentscan.Scanner, open func() (io.ReadCloser, error)) (*contentscan.Result, error) {
+	content, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return scanner.Scan(ctx, content)
+}
+
+// openBlob returns an opener for content held in memory.
+func openBlob(blob []byte) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(blob)), nil
+	}
+}
+
+// openFile returns an opener for content in a local file.
+func openFile(path string) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}
+}
+
+// openObject returns an opener for an object in attachment storage.
+func openObject(ctx context.Context, driver storage.Driver, key string) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		object, err := driver.GetObjectStream(ctx, key, "")
+		if err != nil {
+			return nil, err
+		}
+		return object.Body, nil
+	}
+}
+
+// contentDigest computes the SHA-256 digest of content while it is streamed
+// for a scan, so finalizing an upload reads a stored object only once.
+type contentDigest struct {
+	open func() (io.ReadCloser, error)
+
+	mu     sync.Mutex
+	digest string
+}
+
+func newContentDigest(open func() (io.ReadCloser, error)) *contentDigest {
+	return &contentDigest{open: open}
+}
+
+// Open is an opener whose content yields the digest once read to the end.
+func (d *contentDigest) Open() (io.ReadCloser, error) {
+	content, err := d.open()
+	if err != nil {
+		return nil, err
+	}
+	return &digestingReader{ReadCloser: content, hash: sha256.New(), digest: d}, nil
+}
+
+// Sum returns the hex-encoded SHA-256 digest of the content, reading it
+// again unless a scan already read it to the end.
+func (d *contentDigest) Sum() (string, error) {
+	d.mu.Lock()
+	digest := d.digest
+	d.mu.Unlock()
+	if digest != "" {
+		return digest, nil
+	}
+
+	content, err := d.Open()
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+	if _, err := io.Copy(io.Discard, content); err != nil {
+		return "", err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.digest, nil
+}
+
+type digestingReader struct {
+	io.ReadCloser
+	hash   hash.Hash
+	digest *contentDigest
+}
+
+func (r *digestingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF {
+		r.digest.mu.Lock()
+		r.digest.digest = hex.EncodeToString(r.hash.Sum(nil))
+		r.digest.mu.Unlock()
+	}
+	return n, err
+}
+
+// isAttachmentQuarantined reports whether the scanner found the content of an
+// attachment infected.
+func isAttachmentQuarantined(attachment *store.Attachment) bool {
+	return attachment.Payload.GetContentScan().GetStatus() == storepb.ContentScan_INFECTED
+}
+
+// notifyAdminsOfInfectedAttachment tells every admin about an infected upload.
+// The attachment has no UID in the notification when the upload was rejected.
+func (s *APIV1Service) notifyAdminsOfInfectedAttachment(ctx context.Context, attachment *store.Attachment, scan *storepb.ContentScan) {
+	adminRole := store.RoleAdmin
+	admins, err := s.Store.ListUsers(ctx, &store.FindUser{Role: &adminRole})
+	if err != nil {
+		slog.Warn("failed to list admins to notify of infected attachment", slog.Any("err", err))
+		return
+	}
+	payload := &storepb.InboxMessage_AttachmentInfectedPayload{
+		Filename:  attachment.Filename,
+		Signature: scan.Signature,
+	}
+	if attachment.ID != 0 {
+		payload.AttachmentUid = attachment.UID
+		payload.Quarantined = true
+	}
+	for _, admin := range admins {
+		if _, err := s.Store.CreateInbox(ctx, &store.Inbox{
+			SenderID:   attachment.CreatorID,
+			ReceiverID: admin.ID,
+			Status:     store.UNREAD,
+			Message: &storepb.InboxMessage{
+				Type: storepb.InboxMessage_ATTACHMENT_INFECTED,
+				Payload: &storepb.InboxMessage_AttachmentInfected{
+					AttachmentInfected: payload,
+				},
+			},
+		}); err != nil {
+			slog.Warn("failed to notify admin of infected attachment", slog.Int("admin", int(admin.ID)), slog.Any("err", err))
+		}
+	}
+}
+
+func convertContentScanFromStore(scan *storepb.ContentScan) *v1pb.ContentScan {
+	if scan == nil {
+		return nil
+	}
+	apiScan := &v1pb.ContentScan{
+		Status:    v1pb.ContentScan_Status(scan.Status),
+		Signature: scan.Signature,
+	}
+	if scan.ScanTs != 0 {
+		apiScan.ScanTime = timestamppb.New(time.Unix(scan.ScanTs, 0))
+	}
+	return apiScan
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// attachmentDocumentExtractionTimeout bounds one background extraction,
+	// including the time spent waiting for a free extraction slot.
+	attachmentDocumentExtractionTimeout = 5 * time.Minute
+	// maxDocumentExtractionSizeBytes skips documents too large to extract.
+	maxDocumentExtractionSizeBytes = 100 * MebiByte
+)
+
+// shouldExtractAttachmentDocument reports whether an attachment qualifies for
+// background text extraction.
+func (s *APIV1Service) shouldExtractAttachmentDocument(attachment *store.Attachment) bool {
+	if s.DocumentExtractor == nil || !s.DocumentExtractor.Supports(attachment.Type) {
+		return false
+	}
+	if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+		return false
+	}
+	return attachment.Size > 0 && attachment.Size <= maxDocumentExtractionSizeBytes
+}
+
+// scheduleAttachmentDocumentExtraction extracts the text and page count of a
+// newly created document attachment in the background so it can be searched.
+// Like transcription, a failure only logs.
+func (s *APIV1Service) scheduleAttachmentDocumentExtraction(ctx context.Context, attachment *store.Attachment) {
+	if !s.shouldExtractAttachmentDocument(attachment) {
+		return
+	}
+
+	s.runAttachmentBackgroundJob(ctx, "document extraction", attachment, attachmentDocumentExtractionTimeout, s.documentExtractionSemaphore, func(ctx context.Context) (func(*storepb.AttachmentPayload) error, error) {
+		return s.extractAttachmentDocument(ctx, attachment)
+	})
+}
+
+func (s *APIV1Service) extractAttachmentDocument(ctx context.Context, attachment *store.Attachment) (func(*storepb.AttachmentPayload) error, error) {
+	inputPath, cleanup, err := s.getAttachmentFilePath(ctx, attachment)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	document, err := s.DocumentExtractor.Extract(ctx, attachment.Type, inputPath)
+	if err != nil {
+		return nil, err
+	}
+	extraction := &storepb.DocumentExtraction{
+		Text:      document.Text,
+		PageCount: int32(document.PageCount),
+		Truncated: document.Truncated,
+		CreateTs:  time.Now().Unix(),
+	}
+	return func(payload *storepb.AttachmentPayload) error {
+		payload.Document = extraction
+		return nil
+	}, nil
+}
+
+func convertDocumentExtractionFromStore(document *storepb.DocumentExtraction) *v1pb.DocumentExtraction {
+	if document == nil {
+		return nil
+	}
+	apiDocument := &v1pb.DocumentExtraction{
+		Text:      document.Text,
+		PageCount: document.PageCount,
+		Truncated: document.Truncated,
+	}
+	if document.CreateTs != 0 {
+		apiDocument.CreateTime = timestamppb.New(time.Unix(document.CreateTs, 0))
+	}
+	return apiDocument
+}
@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/ai/vision"
+	visiongemini "github.com/usememos/memos/internal/ai/vision/gemini"
+	visionopenai "github.com/usememos/memos/internal/ai/vision/openai"
+	"github.com/usememos/memos/internal/ai/vision/tesseract"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// attachmentImageAnalysisTimeout bounds one background image analysis,
+	// including the time spent waiting for a free analysis slot.
+	attachmentImageAnalysisTimeout = 5 * time.Minute
+	// maxImageAnalysisSizeBytes skips images that vision APIs reject anyway.
+	maxImageAnalysisSizeBytes = 20 * MebiByte
+)
+
+var supportedImageAnalysisContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/webp": true,
+	"image/gif":  true,
+	"image/heic": true,
+	"image/heif": true,
+}
+
+// shouldAnalyzeImageAttachment reports whether an uploaded attachment qualifies
+// for background OCR and captioning.
+func shouldAnalyzeImageAttachment(mimeType string, size int) bool {
+	if size == 0 || size > maxImageAnalysisSizeBytes {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mimeType))
+	if err != nil {
+		return false
+	}
+	return supportedImageAnalysisContentTypes[strings.ToLower(mediaType)]
+}
+
+// scheduleAttachmentImageAnalysis recognizes the text in a newly created image
+// attachment and captions it in the background when the instance configures an
+// image analysis engine. Like transcription, a failure only logs.
+func (s *APIV1Service) scheduleAttachmentImageAnalysis(ctx context.Context, attachment *store.Attachment, content []byte) {
+	if !shouldAnalyzeImageAttachment(attachment.Type, len(content)) {
+		return
+	}
+
+	aiSetting, err := s.Store.GetInstanceAISetting(ctx)
+	if err != nil {
+		slog.Warn("failed to get AI setting for image analysis", slog.Any("err", err))
+		return
+	}
+	if aiSetting.GetImageAnalysis().GetEngine() == storepb.ImageAnalysisConfig_ENGINE_UNSPECIFIED {
+		return
+	}
+
+	s.runAttachmentBackgroundJob(ctx, "image analysis", attachment, attachmentImageAnalysisTimeout, s.imageAnalysisSemaphore, func(ctx context.Context) (func(*storepb.AttachmentPayload) error, error) {
+		return s.analyzeAttachmentImage(ctx, aiSetting, attachment, content)
+	})
+}
+
+func (s *APIV1Service) analyzeAttachmentImage(ctx context.Context, aiSetting *storepb.InstanceAISetting, attachment *store.Attachment, content []byte) (func(*storepb.AttachmentPayload) error, error) {
+	config := aiSetting.GetImageAnalysis()
+	var analyzer vision.Analyzer
+	var model, engine string
+	switch config.GetEngine() {
+	case storepb.ImageAnalysisConfig_TESSERACT:
+		local, err := tesseract.New("")
+		if err != nil {
+			return nil, err
+		}
+		analyzer, engine = local, tesseract.DefaultCommand
+	case storepb.ImageAnalysisConfig_AI_PROVIDER:
+		provider, err := s.resolveAIProvider(aiSetting, config.GetProviderId())
+		if err != nil {
+			return nil, err
+		}
+		model = config.GetModel()
+		if model == "" {
+			if model, err = ai.DefaultVisionModel(provider.Type); err != nil {
+				return nil, err
+			}
+		}
+		options := vision.ApplyOptions([]vision.AnalyzerOption{vision.WithTimeout(provider.Timeout)})
+		switch provider.Type {
+		case ai.ProviderOpenAI, ai.ProviderOpenAICompatible, ai.ProviderOllama:
+			analyzer, err = visionopenai.New(provider, options)
+		case ai.ProviderGemini:
+			analyzer, err = visiongemini.New(provider, options)
+		default:
+			err = errors.Errorf("provider type %q is not supported for image analysis", provider.Type)
+		}
+		if err != nil {
+			return nil, err
+		}
+		engine = model
+
+		release, err := s.aiProviderLimiter.Acquire(ctx, provider)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	default:
+		return nil, errors.Errorf("image analysis engine %q is not supported", config.GetEngine())
+	}
+
+	result, err := analyzer.AnalyzeImage(ctx, vision.Request{
+		Image:       bytes.NewReader(content),
+		Size:        int64(len(content)),
+		ContentType: attachment.Type,
+		Model:       model,
+		Language:    config.GetLanguage(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &storepb.ImageAnalysis{
+		Text:     strings.TrimSpace(result.Text),
+		Caption:  strings.TrimSpace(result.Caption),
+		Engine:   engine,
+		CreateTs: time.Now().Unix(),
+	}
+	return func(payload *storepb.AttachmentPayload) error {
+		payload.ImageAnalysis = analysis
+		return nil
+	}, nil
+}
+
+func convertImageAnalysisFromStore(analysis *storepb.ImageAnalysis) *v1pb.ImageAnalysis {
+	if analysis == nil {
+		return nil
+	}
+
+	apiAnalysis := &v1pb.ImageAnalysis{
+		Text:    analysis.Text,
+		Caption: analysis.Caption,
+	}
+	if analysis.CreateTs != 0 {
+		apiAnalysis.CreateTime = timestamppb.New(time.Unix(analysis.CreateTs, 0))
+	}
+	return apiAnalysis
+}
@@ -0,0 +1,182 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/storage"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// attachmentMediaPreviewTimeout bounds one background preview, including
+	// the time spent waiting for a free preview slot.
+	attachmentMediaPreviewTimeout = 10 * time.Minute
+	// maxMediaPreviewSizeBytes skips recordings too large to copy for ffmpeg.
+	maxMediaPreviewSizeBytes = 2048 * MebiByte
+	// maxPosterOffset is how far into a video the poster frame is taken at most.
+	maxPosterOffset = time.Second
+)
+
+// shouldPreviewAttachment reports whether an attachment qualifies for a
+// background poster frame or waveform.
+func shouldPreviewAttachment(attachment *store.Attachment) bool {
+	if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+		return false
+	}
+	if attachment.Size == 0 || attachment.Size > maxMediaPreviewSizeBytes {
+		return false
+	}
+	return strings.HasPrefix(attachment.Type, "video/") || strings.HasPrefix(attachment.Type, "audio/")
+}
+
+// scheduleAttachmentMediaPreview extracts a poster frame from a newly created
+// video attachment, or the waveform of an audio attachment, in the background
+// when ffmpeg is available. Like transcription, a failure only logs.
+func (s *APIV1Service) scheduleAttachmentMediaPreview(ctx context.Context, attachment *store.Attachment) {
+	if s.MediaPreviewGenerator == nil || !s.MediaPreviewGenerator.Available() || !shouldPreviewAttachment(attachment) {
+		return
+	}
+
+	s.runAttachmentBackgroundJob(ctx, "media preview", attachment, attachmentMediaPreviewTimeout, s.mediaPreviewSemaphore, func(ctx context.Context) (func(*storepb.AttachmentPayload) error, error) {
+		return s.generateAttachmentMediaPreview(ctx, attachment)
+	})
+}
+
+func (s *APIV1Service) generateAttachmentMediaPreview(ctx context.Context, attachment *store.Attachment) (func(*storepb.AttachmentPayload) error, error) {
+	inputPath, cleanup, err := s.getAttachmentFilePath(ctx, attachment)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	preview := &storepb.MediaPreview{}
+	if strings.HasPrefix(attachment.Type, "video/") {
+		poster, err := s.MediaPreviewGenerator.Poster(ctx, inputPath, getPosterOffset(attachment))
+		if err != nil {
+			return nil, err
+		}
+		driver, err := s.Store.ResolveAttachmentVariantDriver(ctx, attachment)
+		if err != nil {
+			return nil, err
+		}
+		reference, err := driver.UploadObject(ctx, store.AttachmentVariantKey(attachment, store.AttachmentPosterVariant), "image/jpeg", bytes.NewReader(poster))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to save poster")
+		}
+		if _, err := s.Store.UpsertAttachmentVariant(ctx, &store.AttachmentVariant{
+			AttachmentID: attachment.ID,
+			Name:         store.AttachmentPosterVariant,
+			Type:         "image/jpeg",
+			Size:         int64(len(poster)),
+			Reference:    reference,
+		}); err != nil {
+			return nil, errors.Wrap(err, "failed to record poster")
+		}
+		preview.HasPoster = true
+	} else {
+		peaks, err := s.MediaPreviewGenerator.Waveform(ctx, inputPath)
+		if err != nil {
+			return nil, err
+		}
+		preview.WaveformPeaks = peaks
+	}
+	preview.CreateTs = time.Now().Unix()
+
+	return func(payload *storepb.AttachmentPayload) error {
+		payload.MediaPreview = preview
+		return nil
+	}, nil
+}
+
+// getPosterOffset returns where in a video its poster frame is taken: the
+// still image of a Live Photo, or shortly after the start to skip fade-ins.
+func getPosterOffset(attachment *store.Attachment) time.Duration {
+	if motion := getAttachmentMotionMedia(attachment); motion != nil && motion.PresentationTimestampUs > 0 {
+		return time.Duration(motion.PresentationTimestampUs) * time.Microsecond
+	}
+	duration := attachment.Payload.GetMediaMetadata().GetVideo().GetDurationSeconds()
+	if duration <= 0 {
+		return 0
+	}
+	return min(time.Duration(duration*float64(time.Second))/2, maxPosterOffset)
+}
+
+// getAttachmentFilePath returns a local file holding the content of an
+// attachment for command line tools, copying it to a temporary file unless
+// its storage keeps it on the local file system. The cleanup function
+// removes the copy.
+func (s *APIV1Service) getAttachmentFilePath(ctx context.Context, attachment *store.Attachment) (string, func(), error) {
+	noop := func() {}
+	var source io.Reader
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_LOCAL, storepb.AttachmentStorageType_S3, storepb.AttachmentStorageType_OBJECT:
+		driver, key, err := s.Store.ResolveAttachmentDriver(ctx, attachment)
+		if err != nil {
+			return "", noop, errors.Wrap(err, "failed to resolve attachment driver")
+		}
+		if fileDriver, ok := driver.(storage.FileDriver); ok {
+			path, err := fileDriver.FilePath(key)
+			if err != nil {
+				return "", noop, errors.Wrap(err, "failed to resolve attachment file")
+			}
+			return path, noop, nil
+		}
+		stream, err := driver.GetObjectStream(ctx, key, "")
+		if err != nil {
+			return "", noop, errors.Wrap(err, "failed to read attachment content")
+		}
+		defer stream.Body.Close()
+		source = stream.Body
+	default:
+		source = bytes.NewReader(attachment.Blob)
+	}
+
+	file, err := os.CreateTemp("", "memos-attachment-*")
+	if err != nil {
+		return "", noop, errors.Wrap(err, "failed to create temporary file")
+	}
+	cleanup := func() {
+		if err := os.Remove(file.Name()); err != nil && !os.IsNotExist(err) {
+			slog.Warn("failed to remove temporary attachment copy", slog.String("path", file.Name()), slog.Any("err", err))
+		}
+	}
+	_, err = io.Copy(file, source)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", noop, errors.Wrap(err, "failed to copy attachment content")
+	}
+	return file.Name(), cleanup, nil
+}
+
+func convertMediaPreviewFromStore(attachment *store.Attachment) *v1pb.MediaPreview {
+	preview := attachment.Payload.GetMediaPreview()
+	if preview == nil {
+		return nil
+	}
+
+	apiPreview := &v1pb.MediaPreview{
+		WaveformPeaks: preview.WaveformPeaks,
+	}
+	if preview.HasPoster {
+		apiPreview.PosterUrl = fmt.Sprintf("/file/%s%s?poster=true", AttachmentNamePrefix, attachment.UID)
+	}
+	if preview.CreateTs != 0 {
+		apiPreview.CreateTime = timestamppb.New(time.Unix(preview.CreateTs, 0))
+	}
+	return apiPreview
+}
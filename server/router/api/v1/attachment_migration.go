@@ -0,0 +1,333 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/internal/storage"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// MigrateAttachments moves a batch of attachments between configured storages.
+func (s *APIV1Service) MigrateAttachments(ctx context.Context, request *v1pb.MigrateAttachmentsRequest) (*v1pb.MigrateAttachmentsResponse, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if request.Filter != "" {
+		if err := s.validateAttachmentFilter(ctx, request.Filter); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+		}
+	}
+	return MigrateAttachmentBatch(ctx, s.Profile, s.Store, request)
+}
+
+// MigrateAttachmentBatch moves the attachments of one migration page from the
+// source storage to the target storage. Each attachment is copied first, then
+// repointed in a single update, and only then removed from the source, so an
+// interrupted migration never loses content. Attachments already moved no
+// longer match the source, which makes restarting a migration safe.
+func MigrateAttachmentBatch(ctx context.Context, profile *profile.Profile, stores *store.Store, request *v1pb.MigrateAttachmentsRequest) (*v1pb.MigrateAttachmentsResponse, error) {
+	instanceStorageSetting, err := stores.GetInstanceStorageSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get instance storage setting: %v", err)
+	}
+	if request.SourceStorageId == "" || request.TargetStorageId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "source and target storage are required")
+	}
+	if request.SourceStorageId == request.TargetStorageId {
+		return nil, status.Errorf(codes.InvalidArgument, "source and target storage must differ")
+	}
+	if store.FindStorageOrBuiltin(instanceStorageSetting, request.SourceStorageId) == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "source storage %q is not configured", request.SourceStorageId)
+	}
+	targetStorage := store.FindStorageOrBuiltin(instanceStorageSetting, request.TargetStorageId)
+	if targetStorage == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "target storage %q is not configured", request.TargetStorageId)
+	}
+
+	pageToken := &v1pb.AttachmentMigrationPageToken{}
+	if request.PageToken != "" {
+		if err := unmarshalAttachmentMigrationPageToken(request.PageToken, pageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+	} else {
+		totalCount, err := countAttachmentsOnStorage(ctx, stores, instanceStorageSetting, request.SourceStorageId, request.Filter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to count attachments: %v", err)
+		}
+		pageToken.TotalCount = totalCount
+	}
+
+	limit := normalizePageSize(request.PageSize)
+	find := &store.FindAttachment{
+		IDAfter: &pageToken.LastAttachmentId,
+		Limit:   &limit,
+	}
+	if request.Filter != "" {
+		find.Filters = append(find.Filters, request.Filter)
+	}
+	attachments, err := stores.ListAttachments(ctx, find)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list attachments: %v", err)
+	}
+
+	response := &v1pb.MigrateAttachmentsResponse{}
+	for _, attachment := range attachments {
+		pageToken.LastAttachmentId = attachment.ID
+		if store.AttachmentStorageID(instanceStorageSetting, attachment) != request.SourceStorageId {
+			continue
+		}
+		pageToken.ProcessedCount++
+		if err := migrateAttachment(ctx, profile, stores, instanceStorageSetting, attachment, targetStorage); err != nil {
+			slog.Warn("failed to migrate attachment",
+				slog.String("attachment", attachment.UID),
+				slog.String("target", targetStorage.Id),
+				slog.Any("err", err))
+			response.Failures = append(response.Failures, &v1pb.MigrateAttachmentsResponse_Failure{
+				Attachment: fmt.Sprintf("%s%s", AttachmentNamePrefix, attachment.UID),
+				Error:      err.Error(),
+			})
+			continue
+		}
+		response.MigratedCount++
+	}
+
+	response.TotalCount = pageToken.TotalCount
+	response.ProcessedCount = pageToken.ProcessedCount
+	if len(attachments) == limit {
+		nextPageToken, err := marshalAttachmentMigrationPageToken(pageToken)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token: %v", err)
+		}
+		response.NextPageToken = nextPageToken
+	}
+	return response, nil
+}
+
+// countAttachmentsOnStorage counts the attachments matching the filter whose
+// content is held by the given storage.
+func countAttachmentsOnStorage(ctx context.Context, stores *store.Store, instanceStorageSetting *storepb.InstanceStorageSetting, storageID, filter string) (int32, error) {
+	var count int32
+	cursor := int32(0)
+	limit := MaxPageSize
+	for {
+		find := &store.FindAttachment{IDAfter: &cursor, Limit: &limit}
+		if filter != "" {
+			find.Filters = append(find.Filters, filter)
+		}
+		attachments, err := stores.ListAttachments(ctx, find)
+		if err != nil {
+			return 0, err
+		}
+		for _, attachment := range attachments {
+			if store.AttachmentStorageID(instanceStorageSetting, attachment) == storageID {
+				count++
+			}
+			cursor = attachment.ID
+		}
+		if len(attachments) < limit {
+			return count, nil
+		}
+	}
+}
+
+// migrateAttachment copies an attachment's content to the target storage,
+// repoints the attachment and then removes the source copy.
+func migrateAttachment(
+	ctx context.Context,
+	profile *profile.Profile,
+	stores *store.Store,
+	instanceStorageSetting *storepb.InstanceStorageSetting,
+	attachment *store.Attachment,
+	targetStorage *storepb.Storage,
+) error {
+	var sourceDriver storage.Driver
+	var sourceKey string
+	if attachment.StorageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		var err error
+		if sourceDriver, sourceKey, err = stores.ResolveAttachmentDriver(ctx, attachment); err != nil {
+			return err
+		}
+	}
+
+	stagedPath, err := stageAttachmentContent(ctx, stores, attachment, sourceDriver, sourceKey)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.Remove(stagedPath); err != nil && !os.IsNotExist(err) {
+			slog.Warn("Failed to remove staged migration file", slog.String("path", stagedPath), slog.Any("err", err))
+		}
+	}()
+
+	// The copy starts from the attachment without its storage location, which
+	// the save fills in for the target storage.
+	migrated := &store.Attachment{
+		UID:      attachment.UID,
+		Filename: attachment.Filename,
+		Type:     attachment.Type,
+		Size:     attachment.Size,
+		Payload:  proto.CloneOf(attachment.Payload),
+	}
+	if migrated.Payload == nil {
+		migrated.Payload = &storepb.AttachmentPayload{}
+	}
+	migrated.Payload.Payload = nil
+	if err := saveAttachmentFileToStorage(ctx, profile, stores, instanceStorageSetting, targetStorage, migrated, stagedPath); err != nil {
+		return errors.Wrap(err, "failed to save to target storage")
+	}
+
+	// Re-read the attachment so payload changes made while copying are kept,
+	// and give up when another writer moved or deleted it in the meantime.
+	current, err := stores.GetAttachment(ctx, &store.FindAttachment{ID: &attachment.ID})
+	if err == nil && (current == nil || current.StorageType != attachment.StorageType || current.Reference != attachment.Reference ||
+		store.AttachmentStorageID(instanceStorageSetting, current) != store.AttachmentStorageID(instanceStorageSetting, attachment)) {
+		err = errors.New("attachment changed during migration")
+	}
+	released := false
+	if err == nil {
+		payload := proto.CloneOf(current.Payload)
+		if payload == nil {
+			payload = &storepb.AttachmentPayload{}
+		}
+		payload.Payload = migrated.Payload.Payload
+		// Moving the row and checking whether the source is still referenced
+		// happen under the content lock, so the source object is only deleted
+		// once its last reference is gone.
+		released, err = stores.MoveAttachmentContent(ctx, attachment, migrated, &store.UpdateAttachment{
+			ID:      attachment.ID,
+			Payload: payload,
+			Storage: &store.AttachmentStorage{
+				Type:      migrated.StorageType,
+				Reference: migrated.Reference,
+				Blob:      migrated.Blob,
+				SHA256:    migrated.SHA256,
+			},
+		})
+	}
+	if err != nil {
+		discardMigratedContent(ctx, stores, migrated)
+		return errors.Wrap(err, "failed to update attachment")
+	}
+
+	if sourceDriver != nil && released {
+		if err := sourceDriver.DeleteObject(ctx, sourceKey); err != nil {
+			slog.Warn("Failed to delete migrated attachment source", slog.String("attachment", attachment.UID), slog.Any("err", err))
+		}
+	}
+	// Cached image variants are dropped and generated again on the target when
+	// requested; posters move along since they are only generated on upload.
+	migrated.ID = attachment.ID
+	if err := stores.RelocateAttachmentVariants(ctx, attachment, migrated); err != nil {
+		slog.Warn("Failed to relocate migrated attachment variants", slog.String("attachment", attachment.UID), slog.Any("err", err))
+	}
+	return nil
+}
+
+// stageAttachmentContent copies an attachment's content into a staging file,
+// verifying it against the recorded size.
+func stageAttachmentContent(ctx context.Context, stores *store.Store, attachment *store.Attachment, sourceDriver storage.Driver, sourceKey string) (string, error) {
+	var content io.ReadCloser
+	if sourceDriver == nil {
+		withBlob, err := stores.GetAttachment(ctx, &store.FindAttachment{ID: &attachment.ID, GetBlob: true})
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read attachment blob")
+		}
+		if withBlob == nil {
+			return "", errors.New("attachment not found")
+		}
+		content = io.NopCloser(bytes.NewReader(withBlob.Blob))
+	} else {
+		object, err := sourceDriver.GetObjectStream(ctx, sourceKey, "")
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read source content")
+		}
+		content = object.Body
+	}
+	defer content.Close()
+
+	stagingDir := stores.UploadSessionStagingPath(store.UploadSessionStagingFolder)
+	if err := os.MkdirAll(stagingDir, os.ModePerm); err != nil {
+		return "", errors.Wrap(err, "failed to create staging directory")
+	}
+	staged, err := os.CreateTemp(stagingDir, "migration-*.part")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create staging file")
+	}
+	stagedPath := filepath.Clean(staged.Name())
+	written, err := io.Copy(staged, content)
+	if closeErr := staged.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && attachment.Size > 0 && written != attachment.Size {
+		err = errors.Errorf("source content has %d bytes, expected %d", written, attachment.Size)
+	}
+	if err != nil {
+		os.Remove(stagedPath)
+		return "", errors.Wrap(err, "failed to stage source content")
+	}
+	return stagedPath, nil
+}
+
+// discardMigratedContent removes a copy that could not be committed, unless
+// the copy reused content other attachments already reference.
+func discardMigratedContent(ctx context.Context, stores *store.Store, migrated *store.Attachment) {
+	if migrated.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		return
+	}
+	shared, err := stores.AttachmentContentShared(ctx, migrated)
+	if err != nil {
+		slog.Warn("Failed to discard migrated attachment copy", slog.String("attachment", migrated.UID), slog.Any("err", err))
+		return
+	}
+	if shared {
+		return
+	}
+	driver, key, err := stores.ResolveAttachmentDriver(ctx, migrated)
+	if err == nil {
+		err = driver.DeleteObject(ctx, key)
+	}
+	if err != nil {
+		slog.Warn("Failed to discard migrated attachment copy", slog.String("attachment", migrated.UID), slog.Any("err", err))
+	}
+}
+
+func marshalAttachmentMigrationPageToken(pageToken *v1pb.AttachmentMigrationPageToken) (string, error) {
+	b, err := proto.Marshal(pageToken)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal page token")
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func unmarshalAttachmentMigrationPageToken(s string, pageToken *v1pb.AttachmentMigrationPageToken) error {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode page token")
+	}
+	if err := proto.Unmarshal(b, pageToken); err != nil {
+		return errors.Wrap(err, "failed to unmarshal page token")
+	}
+	return nil
+}
@@ -2,21 +2,26 @@ package v1
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"mime"
 	"net/http"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/usememos/memos/internal/filter"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -83,6 +88,10 @@ func (s *APIV1Service) CreateAttachment(ctx context.Context, request *v1pb.Creat
 		return nil, status.Errorf(codes.InvalidArgument, "invalid MIME type format")
 	}
 	request.Attachment.Type = normalizedType
+	reuseContent, err := validateAttachmentDigest(request.Attachment)
+	if err != nil {
+		return nil, err
+	}
 
 	attachmentUID, err := ValidateAndGenerateUID(request.AttachmentId)
 	if err != nil {
@@ -118,39 +127,155 @@ func (s *APIV1Service) CreateAttachment(ctx context.Context, request *v1pb.Creat
 		return nil, status.Errorf(codes.Internal, "failed to get instance storage setting: %v", err)
 	}
 	size := binary.Size(request.Attachment.Content)
-	uploadSizeLimit := int(instanceStorageSetting.UploadSizeLimitMb) * MebiByte
-	if uploadSizeLimit == 0 {
-		uploadSizeLimit = MaxUploadBufferSizeBytes
-	}
-	if size > uploadSizeLimit {
+	if int64(size) > getUploadSizeLimit(instanceStorageSetting) {
 		return nil, status.Errorf(codes.InvalidArgument, "file size exceeds the limit")
 	}
 	create.Size = int64(size)
 	create.Blob = request.Attachment.Content
+	if !reuseContent {
+		release, err := s.reserveAttachmentUpload(ctx, instanceStorageSetting, user, create.Type, create.Size)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
 
+	var memoUID string
 	if request.Attachment.Memo != nil {
-		memoUID, err := ExtractMemoUIDFromName(*request.Attachment.Memo)
+		memo, err := s.findAttachmentCreateMemo(ctx, user, *request.Attachment.Memo)
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+			return nil, err
 		}
-		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+		memoUID = memo.UID
+		create.MemoID = &memo.ID
+	}
+
+	if reuseContent {
+		release, err := s.reuseUserAttachmentContent(ctx, instanceStorageSetting, user, create, request.Attachment.Sha256)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to find memo: %v", err)
-		}
-		if memo == nil {
-			return nil, status.Errorf(codes.NotFound, "memo not found: %s", *request.Attachment.Memo)
-		}
-		if !canModifyMemo(user, memo) {
-			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
-		}
-		if memo.CreatorID != user.ID {
-			return nil, status.Errorf(codes.PermissionDenied, "attachments linked at creation must be owned by the memo creator")
+			return nil, err
 		}
-		create.MemoID = &memo.ID
+		defer release()
+		return s.finishAttachmentCreate(ctx, user, memoUID, create, nil)
+	}
+
+	if err := s.scanAttachmentContent(ctx, instanceStorageSetting, create, openBlob(create.Blob)); err != nil {
+		return nil, err
+	}
+	if err := s.processAttachmentBlob(ctx, create); err != nil {
+		return nil, err
+	}
+
+	// Keep the content for background analysis; saving moves it out of create.Blob.
+	content := create.Blob
+	if err := SaveAttachmentBlob(ctx, s.Profile, s.Store, create); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save attachment blob: %v", err)
+	}
+	return s.finishAttachmentCreate(ctx, user, memoUID, create, content)
+}
+
+// validateAttachmentDigest checks the SHA-256 digest of a new attachment and
+// reports whether the attachment should reuse stored content instead of the
+// empty content it carries.
+func validateAttachmentDigest(attachment *v1pb.Attachment) (bool, error) {
+	if attachment.Sha256 == "" {
+		return false, nil
+	}
+	digest := strings.ToLower(attachment.Sha256)
+	if decoded, err := hex.DecodeString(digest); err != nil || len(decoded) != sha256.Size {
+		return false, status.Errorf(codes.InvalidArgument, "sha256 must be a hex-encoded SHA-256 digest")
+	}
+	attachment.Sha256 = digest
+	contentDigest := sha256.Sum256(attachment.Content)
+	if digest == hex.EncodeToString(contentDigest[:]) {
+		return false, nil
+	}
+	if len(attachment.Content) > 0 {
+		return false, status.Errorf(codes.InvalidArgument, "sha256 does not match the content")
+	}
+	return true, nil
+}
+
+// reuseUserAttachmentContent gives a new attachment the content of one of the
+// user's attachments with the given digest. Only the user's own attachments
+// are considered, so knowing a digest never grants access to another user's
+// content. The new attachment still counts towards the user's storage quota,
+// whose reservation is released by the returned function.
+func (s *APIV1Service) reuseUserAttachmentContent(ctx context.Context, instanceStorageSetting *storepb.InstanceStorageSetting, user *store.User, create *store.Attachment, digest string) (func(), error) {
+	limit := 1
+	sources, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
+		CreatorID: &user.ID,
+		SHA256:    &digest,
+		GetBlob:   true,
+		Limit:     &limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find attachment content: %v", err)
 	}
+	if len(sources) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no stored content with sha256 %s; upload the content instead", digest)
+	}
+	source := sources[0]
+	create.Size = source.Size
+	release, err := s.reserveAttachmentUpload(ctx, instanceStorageSetting, user, create.Type, create.Size)
+	if err != nil {
+		return nil, err
+	}
+	// The content was scanned when it was first uploaded.
+	if scan := source.Payload.GetContentScan(); scan != nil {
+		create.Payload = ensureAttachmentPayload(create.Payload)
+		create.Payload.ContentScan = proto.CloneOf(scan)
+	}
+	if source.StorageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		shareAttachmentContent(create, source)
+		return release, nil
+	}
+	// Database blobs are not shared; copy them into the default storage.
+	create.Blob = source.Blob
+	if err := SaveAttachmentBlob(ctx, s.Profile, s.Store, create); err != nil {
+		release()
+		return nil, status.Errorf(codes.Internal, "failed to save attachment blob: %v", err)
+	}
+	return release, nil
+}
+
+// getUploadSizeLimit returns the maximum attachment size in bytes.
+func getUploadSizeLimit(instanceStorageSetting *storepb.InstanceStorageSetting) int64 {
+	uploadSizeLimit := int64(instanceStorageSetting.UploadSizeLimitMb) * MebiByte
+	if uploadSizeLimit == 0 {
+		uploadSizeLimit = MaxUploadBufferSizeBytes
+	}
+	return uploadSizeLimit
+}
 
+// findAttachmentCreateMemo resolves the memo a new attachment is linked to and
+// checks that the user may attach to it.
+func (s *APIV1Service) findAttachmentCreateMemo(ctx context.Context, user *store.User, memoName string) (*store.Memo, error) {
+	memoUID, err := ExtractMemoUIDFromName(memoName)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find memo: %v", err)
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found: %s", memoName)
+	}
+	if !canModifyMemo(user, memo) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if memo.CreatorID != user.ID {
+		return nil, status.Errorf(codes.PermissionDenied, "attachments linked at creation must be owned by the memo creator")
+	}
+	return memo, nil
+}
+
+// processAttachmentBlob detects motion photos and strips EXIF metadata from
+// the content of a new attachment before it is saved.
+func (s *APIV1Service) processAttachmentBlob(ctx context.Context, create *store.Attachment) error {
 	if create.Payload == nil || create.Payload.MotionMedia == nil {
-		if detectedMotion := detectAndroidMotionMedia(create.Blob, create.Type, attachmentUID); detectedMotion != nil {
+		if detectedMotion := detectAndroidMotionMedia(create.Blob, create.Type, create.UID); detectedMotion != nil {
 			create.Payload = ensureAttachmentPayload(create.Payload)
 			create.Payload.MotionMedia = detectedMotion
 		}
@@ -161,7 +286,7 @@ func (s *APIV1Service) CreateAttachment(ctx context.Context, request *v1pb.Creat
 	if shouldStripExif(create.Type) && !isAndroidMotionContainer(create.Payload.GetMotionMedia()) {
 		release, err := s.acquireImageProcessingSlot(ctx)
 		if err != nil {
-			return nil, status.Errorf(codes.ResourceExhausted, "too many image processing requests")
+			return status.Errorf(codes.ResourceExhausted, "too many image processing requests")
 		}
 		strippedBlob, stripErr := stripImageExif(create.Blob, create.Type)
 		release()
@@ -176,17 +301,37 @@ func (s *APIV1Service) CreateAttachment(ctx context.Context, request *v1pb.Creat
 			create.Size = int64(len(strippedBlob))
 		}
 	}
+	return nil
+}
 
-	if err := SaveAttachmentBlob(ctx, s.Profile, s.Store, create); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to save attachment blob: %v", err)
-	}
-
+// finishAttachmentCreate records an attachment whose content has been saved,
+// schedules its background analysis and notifies webhooks. Quarantined
+// attachments are not analyzed; admins are notified of them instead.
+func (s *APIV1Service) finishAttachmentCreate(ctx context.Context, user *store.User, memoUID string, create *store.Attachment, content []byte) (*v1pb.Attachment, error) {
 	attachment, err := s.Store.CreateAttachment(ctx, create)
 	if err != nil {
+		if errors.Is(err, store.ErrAttachmentContentReleased) {
+			return nil, status.Errorf(codes.Aborted, "attachment content was deleted concurrently; retry the upload")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create attachment: %v", err)
 	}
+	if memoUID != "" {
+		attachment.MemoUID = &memoUID
+	}
+	if isAttachmentQuarantined(attachment) {
+		s.notifyAdminsOfInfectedAttachment(ctx, attachment, attachment.Payload.GetContentScan())
+	} else {
+		s.scheduleAttachmentTranscription(ctx, attachment, content)
+		s.scheduleAttachmentImageAnalysis(ctx, attachment, content)
+		s.scheduleAttachmentMediaPreview(ctx, attachment)
+		s.scheduleAttachmentDocumentExtraction(ctx, attachment)
+	}
 
-	return convertAttachmentFromStore(attachment), nil
+	attachmentMessage := convertAttachmentFromStore(attachment)
+	if err := s.DispatchAttachmentCreatedWebhook(ctx, user.ID, memoUID, attachmentMessage, BuildUserName(user.Username)); err != nil {
+		slog.Warn("Failed to dispatch attachment created webhook", slog.Any("err", err))
+	}
+	return attachmentMessage, nil
 }
 
 func (s *APIV1Service) ListAttachments(ctx context.Context, request *v1pb.ListAttachmentsRequest) (*v1pb.ListAttachmentsResponse, error) {
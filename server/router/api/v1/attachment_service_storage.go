@@ -3,8 +3,11 @@ package v1
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime"
 	"os"
 	"path/filepath"
@@ -13,9 +16,11 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/internal/storage"
 	"github.com/usememos/memos/internal/util"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -31,6 +36,12 @@ func convertAttachmentFromStore(attachment *store.Attachment) *v1pb.Attachment {
 		Size:          attachment.Size,
 		MotionMedia:   convertMotionMediaFromStore(getAttachmentMotionMedia(attachment)),
 		MediaMetadata: convertMediaMetadataFromStore(attachment.Payload.GetMediaMetadata()),
+		Transcript:    convertAudioTranscriptFromStore(attachment.Payload.GetTranscript()),
+		ImageAnalysis: convertImageAnalysisFromStore(attachment.Payload.GetImageAnalysis()),
+		Sha256:        attachment.SHA256,
+		MediaPreview:  convertMediaPreviewFromStore(attachment),
+		ContentScan:   convertContentScanFromStore(attachment.Payload.GetContentScan()),
+		Document:      convertDocumentExtractionFromStore(attachment.Payload.GetDocument()),
 	}
 	if attachment.MemoUID != nil && *attachment.MemoUID != "" {
 		memoName := fmt.Sprintf("%s%s", MemoNamePrefix, *attachment.MemoUID)
@@ -58,115 +69,332 @@ func SaveAttachmentBlob(ctx context.Context, profile *profile.Profile, stores *s
 		return errors.New("default storage is not configured")
 	}
 
-	if defaultStorage.Type == storepb.StorageType_STORAGE_TYPE_LOCAL {
-		filepathTemplate := "assets/{timestamp}_{uuid}_{filename}"
-		if instanceStorageSetting.FilepathTemplate != "" {
-			filepathTemplate = instanceStorageSetting.FilepathTemplate
+	digest := sha256.Sum256(create.Blob)
+	create.SHA256 = hex.EncodeToString(digest[:])
+	if defaultStorage.Type == storepb.StorageType_STORAGE_TYPE_DATABASE {
+		return nil
+	}
+	reused, err := reuseStoredAttachmentContent(ctx, stores, instanceStorageSetting, defaultStorage, create)
+	if err != nil {
+		return err
+	}
+	if reused {
+		return nil
+	}
+	driver, err := stores.StorageDriver(ctx, defaultStorage)
+	if err != nil {
+		return errors.Wrap(err, "failed to create storage driver")
+	}
+	if isBuiltinLocalStorage(defaultStorage) {
+		_, internalPath, err := prepareLocalAttachmentPath(profile, instanceStorageSetting, create)
+		if err != nil {
+			return err
 		}
-
-		internalPath := filepathTemplate
-		if !strings.Contains(internalPath, "{filename}") {
-			internalPath = filepath.Join(internalPath, "{filename}")
+		if _, err := driver.UploadObject(ctx, internalPath, create.Type, bytes.NewReader(create.Blob)); err != nil {
+			return errors.Wrap(err, "Failed to write file")
 		}
-		internalPath = replaceFilenameWithPathTemplate(internalPath, create.Filename)
-		internalPath = filepath.ToSlash(internalPath)
+		create.Reference = internalPath
+		create.Blob = nil
+		create.StorageType = storepb.AttachmentStorageType_LOCAL
+		return nil
+	}
 
-		// Ensure the directory exists.
-		osPath := filepath.FromSlash(internalPath)
-		if !filepath.IsAbs(osPath) {
-			osPath = filepath.Join(profile.Data, osPath)
-		}
-		osPath = ensureUniqueLocalAttachmentPath(osPath, create.UID)
-		internalPath = filepath.ToSlash(osPath)
-		if !filepath.IsAbs(filepath.FromSlash(internalPath)) {
-			internalPath, err = filepath.Rel(profile.Data, osPath)
-			if err != nil {
-				return errors.Wrap(err, "Failed to get relative path")
-			}
-			internalPath = filepath.ToSlash(internalPath)
-		}
-		dir := filepath.Dir(osPath)
-		if err = os.MkdirAll(dir, os.ModePerm); err != nil {
-			return errors.Wrap(err, "Failed to create directory")
+	key, err := driver.UploadObject(ctx, getAttachmentObjectKey(instanceStorageSetting, create.Filename), create.Type, bytes.NewReader(create.Blob))
+	if err != nil {
+		return errors.Wrap(err, "failed to upload via storage driver")
+	}
+	setAttachmentStorageObject(create, defaultStorage, key)
+	return nil
+}
+
+// SaveAttachmentFile saves the content of a staged file based on the storage
+// config. The staged file is consumed: it is moved into local storage, or
+// removed once its content is uploaded or loaded into the database.
+func SaveAttachmentFile(ctx context.Context, profile *profile.Profile, stores *store.Store, create *store.Attachment, stagedPath string) error {
+	instanceStorageSetting, err := stores.GetInstanceStorageSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Failed to find instance storage setting")
+	}
+
+	defaultStorage := store.GetDefaultStorage(instanceStorageSetting)
+	if defaultStorage == nil {
+		return errors.New("default storage is not configured")
+	}
+	return saveAttachmentFileToStorage(ctx, profile, stores, instanceStorageSetting, defaultStorage, create, stagedPath)
+}
+
+// saveAttachmentFileToStorage saves a staged file to the given storage,
+// consuming the staged file like SaveAttachmentFile.
+func saveAttachmentFileToStorage(
+	ctx context.Context,
+	profile *profile.Profile,
+	stores *store.Store,
+	instanceStorageSetting *storepb.InstanceStorageSetting,
+	targetStorage *storepb.Storage,
+	create *store.Attachment,
+	stagedPath string,
+) error {
+	digest, err := hashFile(stagedPath)
+	if err != nil {
+		return err
+	}
+	create.SHA256 = digest
+	reused, err := reuseStoredAttachmentContent(ctx, stores, instanceStorageSetting, targetStorage, create)
+	if err != nil {
+		return err
+	}
+	if reused {
+		if err := os.Remove(stagedPath); err != nil && !os.IsNotExist(err) {
+			slog.Warn("Failed to remove staged upload", slog.String("path", stagedPath), slog.Any("err", err))
 		}
+		return nil
+	}
 
-		// Write the blob to the file.
-		if err := os.WriteFile(osPath, create.Blob, 0644); err != nil {
-			return errors.Wrap(err, "Failed to write file")
+	switch {
+	case isBuiltinLocalStorage(targetStorage):
+		osPath, internalPath, err := prepareLocalAttachmentPath(profile, instanceStorageSetting, create)
+		if err != nil {
+			return err
+		}
+		if err := moveFile(stagedPath, osPath); err != nil {
+			return errors.Wrap(err, "Failed to move file")
 		}
 		create.Reference = internalPath
 		create.Blob = nil
 		create.StorageType = storepb.AttachmentStorageType_LOCAL
-	} else if defaultStorage.Type == storepb.StorageType_STORAGE_TYPE_S3 {
-		driver, err := stores.StorageDriver(ctx, defaultStorage)
+		return nil
+	case targetStorage.Type == storepb.StorageType_STORAGE_TYPE_DATABASE:
+		blob, err := os.ReadFile(stagedPath)
+		if err != nil {
+			return errors.Wrap(err, "failed to read staged file")
+		}
+		create.Blob = blob
+	default:
+		driver, err := stores.StorageDriver(ctx, targetStorage)
 		if err != nil {
 			return errors.Wrap(err, "failed to create storage driver")
 		}
-
-		filepathTemplate := instanceStorageSetting.FilepathTemplate
-		if !strings.Contains(filepathTemplate, "{filename}") {
-			filepathTemplate = filepath.Join(filepathTemplate, "{filename}")
+		file, err := os.Open(stagedPath)
+		if err != nil {
+			return errors.Wrap(err, "failed to open staged file")
 		}
-		filepathTemplate = replaceFilenameWithPathTemplate(filepathTemplate, create.Filename)
-		key, err := driver.UploadObject(ctx, filepathTemplate, create.Type, bytes.NewReader(create.Blob))
+		key, err := driver.UploadObject(ctx, getAttachmentObjectKey(instanceStorageSetting, create.Filename), create.Type, file)
+		file.Close()
 		if err != nil {
 			return errors.Wrap(err, "failed to upload via storage driver")
 		}
+		setAttachmentStorageObject(create, targetStorage, key)
+	}
+	if err := os.Remove(stagedPath); err != nil && !os.IsNotExist(err) {
+		slog.Warn("Failed to remove staged upload", slog.String("path", stagedPath), slog.Any("err", err))
+	}
+	return nil
+}
 
-		// S3 attachments carry no reference; they are served via the authenticated file route.
-		create.Blob = nil
-		create.StorageType = storepb.AttachmentStorageType_S3
-		payload := ensureAttachmentPayload(create.Payload)
-		payload.Payload = &storepb.AttachmentPayload_S3Object_{
-			S3Object: &storepb.AttachmentPayload_S3Object{
-				Key:       key,
-				StorageId: defaultStorage.Id,
-			},
+// reuseStoredAttachmentContent points create at an object on targetStorage
+// that already holds the same content, so identical uploads are stored once.
+// It reports whether such an object was found; database storage keeps a blob
+// per attachment and is never shared.
+func reuseStoredAttachmentContent(
+	ctx context.Context,
+	stores *store.Store,
+	instanceStorageSetting *storepb.InstanceStorageSetting,
+	targetStorage *storepb.Storage,
+	create *store.Attachment,
+) (bool, error) {
+	if create.SHA256 == "" || targetStorage.Type == storepb.StorageType_STORAGE_TYPE_DATABASE {
+		return false, nil
+	}
+	candidates, err := stores.ListAttachments(ctx, &store.FindAttachment{SHA256: &create.SHA256, SkipDefaultLimit: true})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to find attachments with the same content")
+	}
+	for _, candidate := range candidates {
+		if candidate.Size != create.Size || !isAttachmentStoredOn(instanceStorageSetting, targetStorage, candidate) {
+			continue
 		}
-		create.Payload = payload
+		shareAttachmentContent(create, candidate)
+		return true, nil
 	}
+	return false, nil
+}
 
-	return nil
+// isAttachmentStoredOn reports whether the content of attachment is an object
+// on the given storage.
+func isAttachmentStoredOn(instanceStorageSetting *storepb.InstanceStorageSetting, targetStorage *storepb.Storage, attachment *store.Attachment) bool {
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_LOCAL:
+		return isBuiltinLocalStorage(targetStorage)
+	case storepb.AttachmentStorageType_S3, storepb.AttachmentStorageType_OBJECT:
+		return !isBuiltinLocalStorage(targetStorage) && store.AttachmentStorageID(instanceStorageSetting, attachment) == targetStorage.Id
+	default:
+		return false
+	}
 }
 
-// GetAttachmentBlob reads an attachment from its configured storage.
-func (s *APIV1Service) GetAttachmentBlob(ctx context.Context, attachment *store.Attachment) ([]byte, error) {
-	// For local storage, read the file from the local disk.
-	if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
-		attachmentPath := filepath.FromSlash(attachment.Reference)
-		if !filepath.IsAbs(attachmentPath) {
-			attachmentPath = filepath.Join(s.Profile.Data, attachmentPath)
-		}
+// shareAttachmentContent makes create reference the stored content of source.
+func shareAttachmentContent(create *store.Attachment, source *store.Attachment) {
+	create.SharedContent = true
+	create.StorageType = source.StorageType
+	create.Reference = source.Reference
+	create.Blob = nil
+	create.SHA256 = source.SHA256
+	create.Payload = ensureAttachmentPayload(create.Payload)
+	create.Payload.Payload = nil
+	if source.Payload != nil {
+		create.Payload.Payload = proto.Clone(source.Payload).(*storepb.AttachmentPayload).Payload
+	}
+}
 
-		file, err := os.Open(attachmentPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return nil, errors.Wrap(err, "file not found")
-			}
-			return nil, errors.Wrap(err, "failed to open the file")
-		}
-		defer file.Close()
-		blob, err := io.ReadAll(file)
+// hashFile returns the hex-encoded SHA-256 digest of a file.
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open staged file")
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", errors.Wrap(err, "failed to hash staged file")
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// prepareLocalAttachmentPath resolves the file path of a new local attachment
+// from the path template and creates its directory. It returns the OS path
+// and the reference stored on the attachment.
+func prepareLocalAttachmentPath(profile *profile.Profile, instanceStorageSetting *storepb.InstanceStorageSetting, create *store.Attachment) (string, string, error) {
+	filepathTemplate := "assets/{timestamp}_{uuid}_{filename}"
+	if instanceStorageSetting.FilepathTemplate != "" {
+		filepathTemplate = instanceStorageSetting.FilepathTemplate
+	}
+
+	internalPath := filepathTemplate
+	if !strings.Contains(internalPath, "{filename}") {
+		internalPath = filepath.Join(internalPath, "{filename}")
+	}
+	internalPath = replaceFilenameWithPathTemplate(internalPath, create.Filename)
+	internalPath = filepath.ToSlash(internalPath)
+
+	// Ensure the directory exists.
+	osPath := filepath.FromSlash(internalPath)
+	if !filepath.IsAbs(osPath) {
+		osPath = filepath.Join(profile.Data, osPath)
+	}
+	osPath = ensureUniqueLocalAttachmentPath(osPath, create.UID)
+	internalPath = filepath.ToSlash(osPath)
+	if !filepath.IsAbs(filepath.FromSlash(internalPath)) {
+		relativePath, err := filepath.Rel(profile.Data, osPath)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to read the file")
+			return "", "", errors.Wrap(err, "Failed to get relative path")
 		}
-		return blob, nil
+		internalPath = filepath.ToSlash(relativePath)
+	}
+	dir := filepath.Dir(osPath)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", "", errors.Wrap(err, "Failed to create directory")
+	}
+	return osPath, internalPath, nil
+}
+
+// isBuiltinLocalStorage reports whether a storage is the server data
+// directory, whose attachments reference their file path directly.
+func isBuiltinLocalStorage(configuredStorage *storepb.Storage) bool {
+	return configuredStorage.GetType() == storepb.StorageType_STORAGE_TYPE_LOCAL && configuredStorage.GetLocalConfig().GetPath() == ""
+}
+
+// getAttachmentObjectKey returns the object key of a new attachment stored
+// through a storage driver.
+func getAttachmentObjectKey(instanceStorageSetting *storepb.InstanceStorageSetting, filename string) string {
+	filepathTemplate := instanceStorageSetting.FilepathTemplate
+	if !strings.Contains(filepathTemplate, "{filename}") {
+		filepathTemplate = filepath.Join(filepathTemplate, "{filename}")
+	}
+	return replaceFilenameWithPathTemplate(filepathTemplate, filename)
+}
+
+// setAttachmentStorageObject points an attachment at an object uploaded to a
+// configured storage.
+func setAttachmentStorageObject(create *store.Attachment, configuredStorage *storepb.Storage, key string) {
+	if configuredStorage.Type == storepb.StorageType_STORAGE_TYPE_S3 {
+		setS3AttachmentObject(create, key, configuredStorage.Id)
+		return
+	}
+	// Like S3, storage objects are only served via the authenticated file route.
+	create.Blob = nil
+	create.StorageType = storepb.AttachmentStorageType_OBJECT
+	payload := ensureAttachmentPayload(create.Payload)
+	payload.Payload = &storepb.AttachmentPayload_StorageObject_{
+		StorageObject: &storepb.AttachmentPayload_StorageObject{
+			StorageId: configuredStorage.Id,
+			Key:       key,
+		},
+	}
+	create.Payload = payload
+}
+
+// setS3AttachmentObject points an attachment at its uploaded S3 object.
+func setS3AttachmentObject(create *store.Attachment, key, storageID string) {
+	// S3 attachments carry no reference; they are served via the authenticated file route.
+	create.Blob = nil
+	create.StorageType = storepb.AttachmentStorageType_S3
+	payload := ensureAttachmentPayload(create.Payload)
+	payload.Payload = &storepb.AttachmentPayload_S3Object_{
+		S3Object: &storepb.AttachmentPayload_S3Object{
+			Key:       key,
+			StorageId: storageID,
+		},
+	}
+	create.Payload = payload
+}
+
+// moveFile renames src to dst, copying the content when they are on
+// different file systems.
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
 	}
-	// For S3 storage, download the file from S3.
-	if attachment.StorageType == storepb.AttachmentStorageType_S3 {
-		driver, s3Object, err := s.Store.ResolveAttachmentS3Driver(ctx, attachment)
+	source, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	destination, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(destination, source); err != nil {
+		destination.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := destination.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return os.Remove(src)
+}
+
+// GetAttachmentBlob reads an attachment from its configured storage.
+func (s *APIV1Service) GetAttachmentBlob(ctx context.Context, attachment *store.Attachment) ([]byte, error) {
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_LOCAL, storepb.AttachmentStorageType_S3, storepb.AttachmentStorageType_OBJECT:
+		driver, key, err := s.Store.ResolveAttachmentDriver(ctx, attachment)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to resolve S3 attachment driver")
+			return nil, errors.Wrap(err, "failed to resolve attachment driver")
 		}
-
-		blob, err := driver.GetObject(ctx, s3Object.Key)
+		blob, err := driver.GetObject(ctx, key)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to get object from S3")
+			if errors.Is(err, storage.ErrObjectNotFound) || errors.Is(err, os.ErrNotExist) {
+				return nil, errors.Wrap(err, "file not found")
+			}
+			return nil, errors.Wrap(err, "failed to read attachment content")
 		}
 		return blob, nil
+	default:
+		// For database storage, return the blob from the database.
+		return attachment.Blob, nil
 	}
-	// For database storage, return the blob from the database.
-	return attachment.Blob, nil
 }
 
 var fileKeyPattern = regexp.MustCompile(`\{[a-z]{1,9}\}`)
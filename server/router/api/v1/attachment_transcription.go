@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// attachmentTranscriptionTimeout bounds one background transcription, including
+// the time spent waiting for a free transcription slot.
+const attachmentTranscriptionTimeout = 10 * time.Minute
+
+// shouldTranscribeAttachment reports whether an uploaded attachment qualifies
+// for background transcription. Video containers accepted by the Transcribe
+// RPC are skipped; only audio uploads are transcribed automatically.
+func shouldTranscribeAttachment(mimeType string, size int) bool {
+	if size == 0 || size > maxTranscriptionAudioSizeBytes {
+		return false
+	}
+	return strings.HasPrefix(mimeType, "audio/") && isSupportedTranscriptionContentType(mimeType)
+}
+
+// scheduleAttachmentTranscription transcribes a newly created audio attachment
+// in the background when the instance enables automatic transcription. The
+// upload never waits for the provider, and a failed transcription only logs.
+func (s *APIV1Service) scheduleAttachmentTranscription(ctx context.Context, attachment *store.Attachment, content []byte) {
+	if !shouldTranscribeAttachment(attachment.Type, len(content)) {
+		return
+	}
+
+	aiSetting, err := s.Store.GetInstanceAISetting(ctx)
+	if err != nil {
+		slog.Warn("failed to get AI setting for attachment transcription", slog.Any("err", err))
+		return
+	}
+	transcription := aiSetting.GetTranscription()
+	if !transcription.GetAutoTranscribeAttachments() || transcription.GetProviderId() == "" {
+		return
+	}
+
+	s.runAttachmentBackgroundJob(ctx, "transcription", attachment, attachmentTranscriptionTimeout, s.transcriptionSemaphore, func(ctx context.Context) (func(*storepb.AttachmentPayload) error, error) {
+		return s.transcribeAttachment(ctx, aiSetting, attachment, content)
+	})
+}
+
+func (s *APIV1Service) transcribeAttachment(ctx context.Context, aiSetting *storepb.InstanceAISetting, attachment *store.Attachment, content []byte) (func(*storepb.AttachmentPayload) error, error) {
+	result, err := s.transcribeAudio(ctx, aiSetting, content, attachment.Filename, attachment.Type, true)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript := &storepb.AudioTranscript{
+		Text:     strings.TrimSpace(result.Text),
+		Language: result.Language,
+		Model:    result.Model,
+		CreateTs: time.Now().Unix(),
+	}
+	for _, segment := range result.Segments {
+		transcript.Segments = append(transcript.Segments, &storepb.AudioTranscript_Segment{
+			Text:         segment.Text,
+			StartSeconds: segment.Start,
+			EndSeconds:   segment.End,
+			Speaker:      segment.Speaker,
+		})
+	}
+	return func(payload *storepb.AttachmentPayload) error {
+		payload.Transcript = transcript
+		return nil
+	}, nil
+}
+
+func convertAudioTranscriptFromStore(transcript *storepb.AudioTranscript) *v1pb.AudioTranscript {
+	if transcript == nil {
+		return nil
+	}
+
+	apiTranscript := &v1pb.AudioTranscript{
+		Text:     transcript.Text,
+		Language: transcript.Language,
+		Segments: make([]*v1pb.AudioTranscript_Segment, 0, len(transcript.Segments)),
+	}
+	if transcript.CreateTs != 0 {
+		apiTranscript.CreateTime = timestamppb.New(time.Unix(transcript.CreateTs, 0))
+	}
+	for _, segment := range transcript.Segments {
+		apiTranscript.Segments = append(apiTranscript.Segments, &v1pb.AudioTranscript_Segment{
+			Text:         segment.Text,
+			StartSeconds: segment.StartSeconds,
+			EndSeconds:   segment.EndSeconds,
+			Speaker:      segment.Speaker,
+		})
+	}
+	return apiTranscript
+}
@@ -0,0 +1,690 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v5"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/storage"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/auth"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// uploadSessionChunkSize is the size of every chunk except the last. It is
+	// above the 5 MiB minimum part size of S3 multipart uploads.
+	uploadSessionChunkSize = 8 << 20
+	// uploadSessionTTL is how long an upload session is kept after its last chunk.
+	uploadSessionTTL = 24 * time.Hour
+	// presignedUploadTTL is how long a presigned direct upload URL stays valid.
+	// Fetching the session again returns a fresh URL.
+	presignedUploadTTL = 15 * time.Minute
+	// uploadSniffLength is the number of leading bytes inspected to verify the
+	// MIME type of directly uploaded content.
+	uploadSniffLength = 512
+
+	uploadOffsetHeader = "Upload-Offset"
+	uploadLengthHeader = "Upload-Length"
+)
+
+func (s *APIV1Service) CreateUploadSession(ctx context.Context, request *v1pb.CreateUploadSessionRequest) (*v1pb.UploadSession, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	uploadSession := request.UploadSession
+	if uploadSession == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "upload session is required")
+	}
+	if uploadSession.Filename == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "filename is required")
+	}
+	if !validateFilename(uploadSession.Filename) {
+		return nil, status.Errorf(codes.InvalidArgument, "filename contains invalid characters or format")
+	}
+	mimeType := uploadSession.Type
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(uploadSession.Filename))
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	normalizedType, ok := normalizeMimeType(mimeType)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid MIME type format")
+	}
+	if uploadSession.Size <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "size must be positive")
+	}
+
+	instanceStorageSetting, err := s.Store.GetInstanceStorageSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get instance storage setting: %v", err)
+	}
+	if uploadSession.Size > getUploadSizeLimit(instanceStorageSetting) {
+		return nil, status.Errorf(codes.InvalidArgument, "file size exceeds the limit")
+	}
+	if err := s.checkAttachmentUploadPolicy(ctx, instanceStorageSetting, user, normalizedType, uploadSession.Size); err != nil {
+		return nil, err
+	}
+
+	attachmentUID, err := ValidateAndGenerateUID(request.AttachmentId)
+	if err != nil {
+		return nil, err
+	}
+	payload := &storepb.UploadSessionPayload{
+		AttachmentUid: attachmentUID,
+		ChunkSize:     uploadSessionChunkSize,
+	}
+	if uploadSession.Memo != nil {
+		memo, err := s.findAttachmentCreateMemo(ctx, user, *uploadSession.Memo)
+		if err != nil {
+			return nil, err
+		}
+		payload.MemoId = memo.ID
+	}
+
+	sessionUID := shortuuid.New()
+	// Images are staged so their EXIF metadata can be stripped on completion;
+	// everything else bound for S3 is uploaded by the client through a
+	// presigned URL when requested and allowed, or streamed straight into a
+	// multipart upload.
+	defaultStorage := store.GetDefaultStorage(instanceStorageSetting)
+	if defaultStorage != nil && defaultStorage.Type == storepb.StorageType_STORAGE_TYPE_S3 && !shouldStripExif(normalizedType) {
+		driver, err := s.Store.StorageDriver(ctx, defaultStorage)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create storage driver: %v", err)
+		}
+		key := getAttachmentObjectKey(instanceStorageSetting, uploadSession.Filename)
+		if presignDriver, ok := driver.(storage.PresignDriver); ok && request.DirectUpload && presignDriver.PresignEnabled() {
+			payload.ChunkSize = 0
+			// The client uploads to a staging key, so the presigned URL can
+			// never replace the object of the completed attachment.
+			payload.Target = &storepb.UploadSessionPayload_DirectUpload_{
+				DirectUpload: &storepb.UploadSessionPayload_DirectUpload{
+					StorageId: defaultStorage.Id,
+					Key:       path.Join(store.UploadSessionStagingFolder, sessionUID),
+				},
+			}
+		} else if multipartDriver, ok := driver.(storage.MultipartDriver); ok {
+			uploadID, err := multipartDriver.CreateMultipartUpload(ctx, key, normalizedType)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to start multipart upload: %v", err)
+			}
+			payload.Target = &storepb.UploadSessionPayload_MultipartUpload_{
+				MultipartUpload: &storepb.UploadSessionPayload_MultipartUpload{
+					StorageId: defaultStorage.Id,
+					Key:       key,
+					UploadId:  uploadID,
+				},
+			}
+		}
+	}
+	if payload.Target == nil {
+		stagingPath := path.Join(store.UploadSessionStagingFolder, sessionUID+".part")
+		osPath := s.Store.UploadSessionStagingPath(stagingPath)
+		if err := os.MkdirAll(filepath.Dir(osPath), os.ModePerm); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create staging directory: %v", err)
+		}
+		if err := os.WriteFile(osPath, nil, 0600); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create staging file: %v", err)
+		}
+		payload.Target = &storepb.UploadSessionPayload_StagingPath{StagingPath: stagingPath}
+	}
+
+	session, err := s.Store.CreateUploadSession(ctx, &store.UploadSession{
+		UID:       sessionUID,
+		CreatorID: user.ID,
+		Filename:  uploadSession.Filename,
+		Type:      normalizedType,
+		Size:      uploadSession.Size,
+		Payload:   payload,
+		ExpiresTs: time.Now().Add(uploadSessionTTL).Unix(),
+	})
+	if err != nil {
+		if cleanupErr := s.Store.DeleteUploadSessionStorage(ctx, &store.UploadSession{Payload: payload}); cleanupErr != nil {
+			slog.Warn("Failed to discard upload session storage", slog.Any("err", cleanupErr))
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create upload session: %v", err)
+	}
+	return s.convertUploadSessionFromStore(ctx, session)
+}
+
+func (s *APIV1Service) GetUploadSession(ctx context.Context, request *v1pb.GetUploadSessionRequest) (*v1pb.UploadSession, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	sessionUID, err := ExtractUploadSessionUIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid upload session name: %v", err)
+	}
+	session, err := s.getUserUploadSession(ctx, user, sessionUID)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertUploadSessionFromStore(ctx, session)
+}
+
+func (s *APIV1Service) CompleteUploadSession(ctx context.Context, request *v1pb.CompleteUploadSessionRequest) (*v1pb.Attachment, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	sessionUID, err := ExtractUploadSessionUIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid upload session name: %v", err)
+	}
+
+	unlock := s.Store.LockUploadSession(sessionUID)
+	defer unlock()
+	session, err := s.getUserUploadSession(ctx, user, sessionUID)
+	if err != nil {
+		return nil, err
+	}
+	directUpload := session.Payload.GetDirectUpload()
+	if directUpload == nil && session.ReceivedSize != session.Size {
+		return nil, status.Errorf(codes.FailedPrecondition, "upload is incomplete: received %d of %d bytes", session.ReceivedSize, session.Size)
+	}
+	// Other uploads may have used up the quota while this one was in flight.
+	instanceStorageSetting, err := s.Store.GetInstanceStorageSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get instance storage setting: %v", err)
+	}
+	release, err := s.reserveAttachmentUpload(ctx, instanceStorageSetting, user, session.Type, session.Size)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	create := &store.Attachment{
+		UID:       session.Payload.AttachmentUid,
+		CreatorID: user.ID,
+		Filename:  session.Filename,
+		Type:      session.Type,
+		Size:      session.Size,
+	}
+	var memoUID string
+	if memoID := session.Payload.MemoId; memoID != 0 {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to find memo: %v", err)
+		}
+		if memo == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "the memo of the upload session no longer exists")
+		}
+		memoUID = memo.UID
+		create.MemoID = &memo.ID
+	}
+
+	var content []byte
+	if multipartUpload := session.Payload.GetMultipartUpload(); multipartUpload != nil {
+		content, err = s.completeMultipartUploadSession(ctx, instanceStorageSetting, multipartUpload, create)
+	} else if directUpload != nil {
+		content, err = s.completeDirectUploadSession(ctx, instanceStorageSetting, directUpload, create)
+	} else {
+		content, err = s.completeStagedUploadSession(ctx, instanceStorageSetting, session, create)
+	}
+	if err != nil {
+		// Content the malware scanner rejected can never complete, so the
+		// session is discarded along with it.
+		if status.Code(err) == codes.InvalidArgument {
+			s.discardRejectedUploadSession(ctx, session)
+		}
+		return nil, err
+	}
+
+	// The content now lives in attachment storage, so the session is done
+	// even if recording the attachment fails below.
+	if err := s.Store.DeleteUploadSession(ctx, &store.DeleteUploadSession{ID: session.ID}); err != nil {
+		slog.Warn("Failed to delete completed upload session", slog.String("session", session.UID), slog.Any("err", err))
+	}
+	s.Store.ForgetUploadSessionLock(sessionUID)
+	return s.finishAttachmentCreate(ctx, user, memoUID, create, content)
+}
+
+func (s *APIV1Service) DeleteUploadSession(ctx context.Context, request *v1pb.DeleteUploadSessionRequest) (*emptypb.Empty, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	sessionUID, err := ExtractUploadSessionUIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid upload session name: %v", err)
+	}
+
+	unlock := s.Store.LockUploadSession(sessionUID)
+	defer unlock()
+	session, err := s.getUserUploadSession(ctx, user, sessionUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteUploadSessionStorage(ctx, session); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to discard uploaded content: %v", err)
+	}
+	if err := s.Store.DeleteUploadSession(ctx, &store.DeleteUploadSession{ID: session.ID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete upload session: %v", err)
+	}
+	s.Store.ForgetUploadSessionLock(sessionUID)
+	return &emptypb.Empty{}, nil
+}
+
+// WriteUploadSessionChunk appends a chunk starting at offset to an upload
+// session. Every chunk except the last must be exactly the session chunk size,
+// and offset must match the number of bytes received so far.
+func (s *APIV1Service) WriteUploadSessionChunk(ctx context.Context, sessionUID string, offset int64, chunk io.Reader) (*store.UploadSession, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	unlock := s.Store.LockUploadSession(sessionUID)
+	defer unlock()
+	session, err := s.getUserUploadSession(ctx, user, sessionUID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Payload.GetDirectUpload() != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "upload session only accepts a direct upload")
+	}
+	if offset != session.ReceivedSize {
+		return nil, status.Errorf(codes.Aborted, "upload offset mismatch: expected %d", session.ReceivedSize)
+	}
+	remaining := session.Size - session.ReceivedSize
+	if remaining == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "upload is already complete")
+	}
+
+	chunkSize := min(session.Payload.ChunkSize, remaining)
+	data, err := io.ReadAll(io.LimitReader(chunk, chunkSize+1))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to read chunk: %v", err)
+	}
+	if int64(len(data)) != chunkSize {
+		return nil, status.Errorf(codes.InvalidArgument, "chunk must be %d bytes", chunkSize)
+	}
+
+	update := &store.UpdateUploadSession{ID: session.ID}
+	if multipartUpload := session.Payload.GetMultipartUpload(); multipartUpload != nil {
+		driver, err := s.Store.ResolveUploadSessionMultipartDriver(ctx, multipartUpload)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to resolve storage driver: %v", err)
+		}
+		partNumber := int32(offset/session.Payload.ChunkSize) + 1
+		etag, err := driver.UploadPart(ctx, multipartUpload.Key, multipartUpload.UploadId, partNumber, bytes.NewReader(data))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to upload part: %v", err)
+		}
+		multipartUpload.Parts = append(multipartUpload.Parts, &storepb.UploadSessionPayload_MultipartUpload_Part{
+			PartNumber: partNumber,
+			Etag:       etag,
+		})
+		update.Payload = session.Payload
+	} else {
+		if err := writeStagedChunk(s.Store.UploadSessionStagingPath(session.Payload.GetStagingPath()), offset, data); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to write chunk: %v", err)
+		}
+	}
+
+	receivedSize := offset + chunkSize
+	expiresTs := time.Now().Add(uploadSessionTTL).Unix()
+	update.ReceivedSize = &receivedSize
+	update.ExpiresTs = &expiresTs
+	if err := s.Store.UpdateUploadSession(ctx, update); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update upload session: %v", err)
+	}
+	session.ReceivedSize = receivedSize
+	session.ExpiresTs = expiresTs
+	return session, nil
+}
+
+// writeStagedChunk writes a chunk at offset, dropping anything written past
+// the offset by an attempt that was never recorded.
+func writeStagedChunk(stagedPath string, offset int64, data []byte) error {
+	file, err := os.OpenFile(stagedPath, os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.WriteAt(data, offset); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// completeMultipartUploadSession assembles the uploaded parts into the
+// attachment object. The content is only downloaded again when it is scanned
+// or a background analysis needs it; rejected content is deleted.
+func (s *APIV1Service) completeMultipartUploadSession(ctx context.Context, instanceStorageSetting *storepb.InstanceStorageSetting, multipartUpload *storepb.UploadSessionPayload_MultipartUpload, create *store.Attachment) ([]byte, error) {
+	driver, err := s.Store.ResolveUploadSessionMultipartDriver(ctx, multipartUpload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to resolve storage driver: %v", err)
+	}
+	parts := make([]storage.CompletedPart, 0, len(multipartUpload.Parts))
+	for _, part := range multipartUpload.Parts {
+		parts = append(parts, storage.CompletedPart{PartNumber: part.PartNumber, ETag: part.Etag})
+	}
+	if err := driver.CompleteMultipartUpload(ctx, multipartUpload.Key, multipartUpload.UploadId, parts); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to complete multipart upload: %v", err)
+	}
+	digest := newContentDigest(openObject(ctx, driver, multipartUpload.Key))
+	if err := s.scanAttachmentContent(ctx, instanceStorageSetting, create, digest.Open); err != nil {
+		discardUploadedObject(ctx, driver, multipartUpload.Key)
+		return nil, err
+	}
+	sum, err := digest.Sum()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash uploaded object: %v", err)
+	}
+	create.SHA256 = sum
+	setS3AttachmentObject(create, multipartUpload.Key, multipartUpload.StorageId)
+	return readUploadedObjectForAnalysis(ctx, driver, multipartUpload.Key, create), nil
+}
+
+// completeDirectUploadSession accepts an object the client uploaded through a
+// presigned URL. The object is first copied out of the staging key the URL
+// writes to, so the verified content can no longer be replaced; copies with
+// the wrong size or content that does not match the declared MIME type are
+// deleted so the client can upload again, and so is content the malware
+// scanner rejects.
+func (s *APIV1Service) completeDirectUploadSession(ctx context.Context, instanceStorageSetting *storepb.InstanceStorageSetting, directUpload *storepb.UploadSessionPayload_DirectUpload, create *store.Attachment) ([]byte, error) {
+	driver, err := s.Store.ResolveUploadSessionPresignDriver(ctx, directUpload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to resolve storage driver: %v", err)
+	}
+	key := getAttachmentObjectKey(instanceStorageSetting, create.Filename)
+	if err := driver.CopyObject(ctx, directUpload.Key, key); err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "content has not been uploaded")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to copy uploaded object: %v", err)
+	}
+	discardUploadedObject(ctx, driver, directUpload.Key)
+
+	size, err := driver.StatObject(ctx, key)
+	if err != nil {
+		discardUploadedObject(ctx, driver, key)
+		return nil, status.Errorf(codes.Internal, "failed to stat uploaded object: %v", err)
+	}
+	if size != create.Size {
+		discardUploadedObject(ctx, driver, key)
+		return nil, status.Errorf(codes.FailedPrecondition, "uploaded object is %d bytes, expected %d", size, create.Size)
+	}
+
+	object, err := driver.GetObjectStream(ctx, key, fmt.Sprintf("bytes=0-%d", uploadSniffLength-1))
+	if err != nil {
+		discardUploadedObject(ctx, driver, key)
+		return nil, status.Errorf(codes.Internal, "failed to read uploaded object: %v", err)
+	}
+	head, err := io.ReadAll(object.Body)
+	object.Body.Close()
+	if err != nil {
+		discardUploadedObject(ctx, driver, key)
+		return nil, status.Errorf(codes.Internal, "failed to read uploaded object: %v", err)
+	}
+	sniffedType, _ := normalizeMimeType(http.DetectContentType(head))
+	if !isSniffedTypeCompatible(create.Type, sniffedType) {
+		discardUploadedObject(ctx, driver, key)
+		return nil, status.Errorf(codes.FailedPrecondition, "uploaded content looks like %s, not %s", sniffedType, create.Type)
+	}
+	digest := newContentDigest(openObject(ctx, driver, key))
+	if err := s.scanAttachmentContent(ctx, instanceStorageSetting, create, digest.Open); err != nil {
+		discardUploadedObject(ctx, driver, key)
+		return nil, err
+	}
+	sum, err := digest.Sum()
+	if err != nil {
+		discardUploadedObject(ctx, driver, key)
+		return nil, status.Errorf(codes.Internal, "failed to hash uploaded object: %v", err)
+	}
+	create.SHA256 = sum
+
+	setS3AttachmentObject(create, key, directUpload.StorageId)
+	return readUploadedObjectForAnalysis(ctx, driver, key, create), nil
+}
+
+func (s *APIV1Service) discardRejectedUploadSession(ctx context.Context, session *store.UploadSession) {
+	if session.Payload.GetStagingPath() != "" {
+		if err := s.Store.DeleteUploadSessionStorage(ctx, session); err != nil {
+			slog.Warn("Failed to discard rejected upload", slog.String("session", session.UID), slog.Any("err", err))
+		}
+	}
+	if err := s.Store.DeleteUploadSession(ctx, &store.DeleteUploadSession{ID: session.ID}); err != nil {
+		slog.Warn("Failed to delete rejected upload session", slog.String("session", session.UID), slog.Any("err", err))
+	}
+	s.Store.ForgetUploadSessionLock(session.UID)
+}
+
+func discardUploadedObject(ctx context.Context, driver storage.Driver, key string) {
+	if err := driver.DeleteObject(ctx, key); err != nil {
+		slog.Warn("Failed to delete rejected upload", slog.String("key", key), slog.Any("err", err))
+	}
+}
+
+// isSniffedTypeCompatible reports whether content sniffed as sniffedType may
+// be stored as declaredType. Sniffing only recognizes a few signatures, so
+// generic results are accepted and related types only need to share a family;
+// HTML is never accepted under another type.
+func isSniffedTypeCompatible(declaredType, sniffedType string) bool {
+	switch {
+	case sniffedType == declaredType:
+		return true
+	case sniffedType == "text/html":
+		return false
+	case sniffedType == "application/octet-stream", sniffedType == "text/plain":
+		return true
+	case sniffedType == "text/xml":
+		return declaredType == "application/xml" || strings.HasSuffix(declaredType, "+xml")
+	}
+	return mimeTypeFamily(sniffedType) == mimeTypeFamily(declaredType)
+}
+
+// mimeTypeFamily returns the top-level type, treating audio and video as one
+// family because container formats such as MP4, WebM and Ogg hold either.
+func mimeTypeFamily(mimeType string) string {
+	family, _, _ := strings.Cut(mimeType, "/")
+	if family == "audio" || family == "video" || mimeType == "application/ogg" {
+		return "media"
+	}
+	return family
+}
+
+// readUploadedObjectForAnalysis downloads a finalized object again only when a
+// background analysis needs its content.
+func readUploadedObjectForAnalysis(ctx context.Context, driver storage.Driver, key string, create *store.Attachment) []byte {
+	if !shouldTranscribeAttachment(create.Type, int(create.Size)) && !shouldAnalyzeImageAttachment(create.Type, int(create.Size)) {
+		return nil
+	}
+	content, err := driver.GetObject(ctx, key)
+	if err != nil {
+		slog.Warn("Failed to read uploaded object for analysis", slog.String("key", key), slog.Any("err", err))
+		return nil
+	}
+	return content
+}
+
+// completeStagedUploadSession saves a staged upload into attachment storage.
+// Content that needs processing or analysis goes through the same path as
+// CreateAttachment; anything else is moved without loading it into memory.
+func (s *APIV1Service) completeStagedUploadSession(ctx context.Context, instanceStorageSetting *storepb.InstanceStorageSetting, session *store.UploadSession, create *store.Attachment) ([]byte, error) {
+	stagedPath := s.Store.UploadSessionStagingPath(session.Payload.GetStagingPath())
+	if err := s.scanAttachmentContent(ctx, instanceStorageSetting, create, openFile(stagedPath)); err != nil {
+		return nil, err
+	}
+	if !shouldStripExif(create.Type) && !shouldTranscribeAttachment(create.Type, int(create.Size)) && !shouldAnalyzeImageAttachment(create.Type, int(create.Size)) {
+		if err := SaveAttachmentFile(ctx, s.Profile, s.Store, create, stagedPath); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to save attachment file: %v", err)
+		}
+		return nil, nil
+	}
+
+	blob, err := os.ReadFile(stagedPath)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read staged upload: %v", err)
+	}
+	create.Blob = blob
+	if err := s.processAttachmentBlob(ctx, create); err != nil {
+		return nil, err
+	}
+	content := create.Blob
+	if err := SaveAttachmentBlob(ctx, s.Profile, s.Store, create); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save attachment blob: %v", err)
+	}
+	if err := os.Remove(stagedPath); err != nil && !os.IsNotExist(err) {
+		slog.Warn("Failed to remove staged upload", slog.String("path", stagedPath), slog.Any("err", err))
+	}
+	return content, nil
+}
+
+// getUserUploadSession returns an unexpired upload session owned by the user.
+func (s *APIV1Service) getUserUploadSession(ctx context.Context, user *store.User, sessionUID string) (*store.UploadSession, error) {
+	session, err := s.Store.GetUploadSession(ctx, &store.FindUploadSession{UID: &sessionUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get upload session: %v", err)
+	}
+	if session == nil || session.CreatorID != user.ID || session.ExpiresTs < time.Now().Unix() {
+		return nil, status.Errorf(codes.NotFound, "upload session not found")
+	}
+	return session, nil
+}
+
+func (s *APIV1Service) convertUploadSessionFromStore(ctx context.Context, session *store.UploadSession) (*v1pb.UploadSession, error) {
+	uploadSession := &v1pb.UploadSession{
+		Name:       fmt.Sprintf("%s%s", UploadSessionNamePrefix, session.UID),
+		Filename:   session.Filename,
+		Type:       session.Type,
+		Size:       session.Size,
+		Offset:     session.ReceivedSize,
+		ChunkSize:  session.Payload.GetChunkSize(),
+		UploadUrl:  "/api/v1/uploads/" + session.UID,
+		CreateTime: timestamppb.New(time.Unix(session.CreatedTs, 0)),
+		ExpireTime: timestamppb.New(time.Unix(session.ExpiresTs, 0)),
+	}
+	if directUpload := session.Payload.GetDirectUpload(); directUpload != nil {
+		driver, err := s.Store.ResolveUploadSessionPresignDriver(ctx, directUpload)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to resolve storage driver: %v", err)
+		}
+		directUploadURL, err := driver.PresignPutObject(ctx, directUpload.Key, session.Type, presignedUploadTTL)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to presign direct upload: %v", err)
+		}
+		uploadSession.UploadUrl = ""
+		uploadSession.DirectUploadUrl = directUploadURL
+	}
+	if memoID := session.Payload.GetMemoId(); memoID != 0 {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to find memo: %v", err)
+		}
+		if memo != nil {
+			memoName := fmt.Sprintf("%s%s", MemoNamePrefix, memo.UID)
+			uploadSession.Memo = &memoName
+		}
+	}
+	return uploadSession, nil
+}
+
+type uploadRouteRegistrar interface {
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+}
+
+// RegisterUploadRoutes registers the chunk endpoint of resumable uploads.
+// Chunks are raw request bodies, so they bypass the gRPC gateway.
+// Authentication is done via Bearer token in the Authorization header.
+func (s *APIV1Service) RegisterUploadRoutes(router uploadRouteRegistrar) {
+	authenticator := auth.NewAuthenticator(s.Store, s.Secret)
+	router.PUT("/api/v1/uploads/:id", func(c *echo.Context) error {
+		return s.handleUploadChunk(c, authenticator)
+	})
+	router.HEAD("/api/v1/uploads/:id", func(c *echo.Context) error {
+		return s.handleUploadOffset(c, authenticator)
+	})
+}
+
+// handleUploadChunk writes the request body at the Upload-Offset header and
+// answers with the new offset.
+func (s *APIV1Service) handleUploadChunk(c *echo.Context, authenticator *auth.Authenticator) error {
+	ctx := auth.ApplyToContext(c.Request().Context(), authenticator.Authenticate(c.Request().Context(), c.Request().Header.Get("Authorization")))
+	offset, err := strconv.ParseInt(c.Request().Header.Get(uploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid Upload-Offset header"})
+	}
+	session, err := s.WriteUploadSessionChunk(ctx, c.Param("id"), offset, c.Request().Body)
+	if err != nil {
+		return writeUploadError(c, err)
+	}
+	c.Response().Header().Set(uploadOffsetHeader, strconv.FormatInt(session.ReceivedSize, 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+// handleUploadOffset reports how many bytes of an upload were received so a
+// client can resume after an interruption.
+func (s *APIV1Service) handleUploadOffset(c *echo.Context, authenticator *auth.Authenticator) error {
+	ctx := auth.ApplyToContext(c.Request().Context(), authenticator.Authenticate(c.Request().Context(), c.Request().Header.Get("Authorization")))
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return writeUploadError(c, status.Errorf(codes.Internal, "failed to get current user: %v", err))
+	}
+	if user == nil {
+		return writeUploadError(c, status.Errorf(codes.Unauthenticated, "user not authenticated"))
+	}
+	session, err := s.getUserUploadSession(ctx, user, c.Param("id"))
+	if err != nil {
+		return writeUploadError(c, err)
+	}
+	c.Response().Header().Set(uploadOffsetHeader, strconv.FormatInt(session.ReceivedSize, 10))
+	c.Response().Header().Set(uploadLengthHeader, strconv.FormatInt(session.Size, 10))
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.NoContent(http.StatusOK)
+}
+
+func writeUploadError(c *echo.Context, err error) error {
+	st, _ := status.FromError(err)
+	if st.Code() == codes.Internal {
+		slog.Error("failed to handle upload chunk", slog.Any("err", err))
+	}
+	return c.JSON(runtime.HTTPStatusFromCode(st.Code()), map[string]string{"error": st.Message()})
+}
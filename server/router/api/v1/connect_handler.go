@@ -42,6 +42,7 @@ func (s *ConnectServiceHandler) RegisterConnectHandlers(mux *http.ServeMux, opts
 		wrap(apiv1connect.NewAttachmentServiceHandler(s, opts...)),
 		wrap(apiv1connect.NewAIServiceHandler(s, opts...)),
 		wrap(apiv1connect.NewMemoViewServiceHandler(s, opts...)),
+		wrap(apiv1connect.NewMemoTemplateServiceHandler(s, opts...)),
 		wrap(apiv1connect.NewIdentityProviderServiceHandler(s, opts...)),
 	}
 
@@ -55,6 +55,14 @@ func (s *ConnectServiceHandler) TestInstanceEmailSetting(ctx context.Context, re
 	return connect.NewResponse(resp), nil
 }
 
+func (s *ConnectServiceHandler) TestAIProvider(ctx context.Context, req *connect.Request[v1pb.TestAIProviderRequest]) (*connect.Response[v1pb.TestAIProviderResponse], error) {
+	resp, err := s.APIV1Service.TestAIProvider(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
 func (s *ConnectServiceHandler) GetInstanceStats(ctx context.Context, req *connect.Request[v1pb.GetInstanceStatsRequest]) (*connect.Response[v1pb.InstanceStats], error) {
 	resp, err := s.APIV1Service.GetInstanceStats(ctx, req.Msg)
 	if err != nil {
@@ -63,6 +71,14 @@ func (s *ConnectServiceHandler) GetInstanceStats(ctx context.Context, req *conne
 	return connect.NewResponse(resp), nil
 }
 
+func (s *ConnectServiceHandler) ListStorageConsumers(ctx context.Context, req *connect.Request[v1pb.ListStorageConsumersRequest]) (*connect.Response[v1pb.ListStorageConsumersResponse], error) {
+	resp, err := s.APIV1Service.ListStorageConsumers(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
 // AuthService
 //
 // Auth service methods need special handling for response headers (cookies).
@@ -277,6 +293,22 @@ func (s *ConnectServiceHandler) GetUserWebhookSigningSecret(ctx context.Context,
 	return connect.NewResponse(resp), nil
 }
 
+func (s *ConnectServiceHandler) ListUserWebhookDeliveries(ctx context.Context, req *connect.Request[v1pb.ListUserWebhookDeliveriesRequest]) (*connect.Response[v1pb.ListUserWebhookDeliveriesResponse], error) {
+	resp, err := s.APIV1Service.ListUserWebhookDeliveries(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) RedeliverWebhook(ctx context.Context, req *connect.Request[v1pb.RedeliverWebhookRequest]) (*connect.Response[v1pb.WebhookDelivery], error) {
+	resp, err := s.APIV1Service.RedeliverWebhook(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
 func (s *ConnectServiceHandler) ListUserNotifications(ctx context.Context, req *connect.Request[v1pb.ListUserNotificationsRequest]) (*connect.Response[v1pb.ListUserNotificationsResponse], error) {
 	resp, err := s.APIV1Service.ListUserNotifications(ctx, req.Msg)
 	if err != nil {
@@ -375,6 +407,14 @@ func (s *ConnectServiceHandler) ListMemoRelations(ctx context.Context, req *conn
 	return connect.NewResponse(resp), nil
 }
 
+func (s *ConnectServiceHandler) ListMemoBacklinks(ctx context.Context, req *connect.Request[v1pb.ListMemoBacklinksRequest]) (*connect.Response[v1pb.ListMemoBacklinksResponse], error) {
+	resp, err := s.APIV1Service.ListMemoBacklinks(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
 func (s *ConnectServiceHandler) CreateMemoComment(ctx context.Context, req *connect.Request[v1pb.CreateMemoCommentRequest]) (*connect.Response[v1pb.Memo], error) {
 	resp, err := s.APIV1Service.CreateMemoComment(ctx, req.Msg)
 	if err != nil {
@@ -463,6 +503,70 @@ func (s *ConnectServiceHandler) BatchGetLinkMetadata(ctx context.Context, req *c
 	return connect.NewResponse(resp), nil
 }
 
+func (s *ConnectServiceHandler) RenameTag(ctx context.Context, req *connect.Request[v1pb.RenameTagRequest]) (*connect.Response[v1pb.RenameTagResponse], error) {
+	resp, err := s.APIV1Service.RenameTag(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) MergeTags(ctx context.Context, req *connect.Request[v1pb.MergeTagsRequest]) (*connect.Response[v1pb.MergeTagsResponse], error) {
+	resp, err := s.APIV1Service.MergeTags(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) DeleteTag(ctx context.Context, req *connect.Request[v1pb.DeleteTagRequest]) (*connect.Response[v1pb.DeleteTagResponse], error) {
+	resp, err := s.APIV1Service.DeleteTag(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListTasks(ctx context.Context, req *connect.Request[v1pb.ListTasksRequest]) (*connect.Response[v1pb.ListTasksResponse], error) {
+	resp, err := s.APIV1Service.ListTasks(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) SetTaskState(ctx context.Context, req *connect.Request[v1pb.SetTaskStateRequest]) (*connect.Response[v1pb.Task], error) {
+	resp, err := s.APIV1Service.SetTaskState(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) GetDailyMemo(ctx context.Context, req *connect.Request[v1pb.GetDailyMemoRequest]) (*connect.Response[v1pb.Memo], error) {
+	resp, err := s.APIV1Service.GetDailyMemo(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) AppendDailyMemo(ctx context.Context, req *connect.Request[v1pb.AppendDailyMemoRequest]) (*connect.Response[v1pb.Memo], error) {
+	resp, err := s.APIV1Service.AppendDailyMemo(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListDailyMemos(ctx context.Context, req *connect.Request[v1pb.ListDailyMemosRequest]) (*connect.Response[v1pb.ListDailyMemosResponse], error) {
+	resp, err := s.APIV1Service.ListDailyMemos(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
 // AttachmentService
 
 func (s *ConnectServiceHandler) CreateAttachment(ctx context.Context, req *connect.Request[v1pb.CreateAttachmentRequest]) (*connect.Response[v1pb.Attachment], error) {
@@ -513,6 +617,46 @@ func (s *ConnectServiceHandler) BatchDeleteAttachments(ctx context.Context, req
 	return connect.NewResponse(resp), nil
 }
 
+func (s *ConnectServiceHandler) MigrateAttachments(ctx context.Context, req *connect.Request[v1pb.MigrateAttachmentsRequest]) (*connect.Response[v1pb.MigrateAttachmentsResponse], error) {
+	resp, err := s.APIV1Service.MigrateAttachments(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) CreateUploadSession(ctx context.Context, req *connect.Request[v1pb.CreateUploadSessionRequest]) (*connect.Response[v1pb.UploadSession], error) {
+	resp, err := s.APIV1Service.CreateUploadSession(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) GetUploadSession(ctx context.Context, req *connect.Request[v1pb.GetUploadSessionRequest]) (*connect.Response[v1pb.UploadSession], error) {
+	resp, err := s.APIV1Service.GetUploadSession(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) CompleteUploadSession(ctx context.Context, req *connect.Request[v1pb.CompleteUploadSessionRequest]) (*connect.Response[v1pb.Attachment], error) {
+	resp, err := s.APIV1Service.CompleteUploadSession(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) DeleteUploadSession(ctx context.Context, req *connect.Request[v1pb.DeleteUploadSessionRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := s.APIV1Service.DeleteUploadSession(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
 // AIService
 
 func (s *ConnectServiceHandler) Transcribe(ctx context.Context, req *connect.Request[v1pb.TranscribeRequest]) (*connect.Response[v1pb.TranscribeResponse], error) {
@@ -570,6 +714,53 @@ func (s *ConnectServiceHandler) DeleteMemoView(ctx context.Context, req *connect
 	return connect.NewResponse(resp), nil
 }
 
+// MemoTemplateService
+
+// ListMemoTemplates lists the memo templates of a user followed by the instance-wide templates.
+func (s *ConnectServiceHandler) ListMemoTemplates(ctx context.Context, req *connect.Request[v1pb.ListMemoTemplatesRequest]) (*connect.Response[v1pb.ListMemoTemplatesResponse], error) {
+	resp, err := s.APIV1Service.ListMemoTemplates(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetMemoTemplate returns a memo template by resource name.
+func (s *ConnectServiceHandler) GetMemoTemplate(ctx context.Context, req *connect.Request[v1pb.GetMemoTemplateRequest]) (*connect.Response[v1pb.MemoTemplate], error) {
+	resp, err := s.APIV1Service.GetMemoTemplate(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CreateMemoTemplate creates a memo template for a user or the instance.
+func (s *ConnectServiceHandler) CreateMemoTemplate(ctx context.Context, req *connect.Request[v1pb.CreateMemoTemplateRequest]) (*connect.Response[v1pb.MemoTemplate], error) {
+	resp, err := s.APIV1Service.CreateMemoTemplate(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// UpdateMemoTemplate updates the selected fields of a memo template.
+func (s *ConnectServiceHandler) UpdateMemoTemplate(ctx context.Context, req *connect.Request[v1pb.UpdateMemoTemplateRequest]) (*connect.Response[v1pb.MemoTemplate], error) {
+	resp, err := s.APIV1Service.UpdateMemoTemplate(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteMemoTemplate deletes a memo template by resource name.
+func (s *ConnectServiceHandler) DeleteMemoTemplate(ctx context.Context, req *connect.Request[v1pb.DeleteMemoTemplateRequest]) (*connect.Response[emptypb.Empty], error) {
+	resp, err := s.APIV1Service.DeleteMemoTemplate(ctx, req.Msg)
+	if err != nil {
+		return nil, convertGRPCError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
 // IdentityProviderService
 
 func (s *ConnectServiceHandler) ListIdentityProviders(ctx context.Context, req *connect.Request[v1pb.ListIdentityProvidersRequest]) (*connect.Response[v1pb.ListIdentityProvidersResponse], error) {
@@ -21,6 +21,8 @@ const (
 	maxTranscriptionConfigLanguageLength = 32
 	maxTranscriptionConfigPromptLength   = 4096
 	maxBatchGetInstanceSettings          = 100
+	maxAIProviderTimeoutSeconds          = 3600
+	maxAIProviderConcurrentRequests      = 64
 )
 
 type instanceSettingCaller struct {
@@ -173,6 +175,9 @@ func (s *APIV1Service) getInstanceSettingByName(ctx context.Context, name string
 	}
 
 	result := convertInstanceSettingFromStore(instanceSetting)
+	if err := s.fillStorageQuotaOverrides(ctx, instanceSetting.GetStorageSetting(), result.GetStorageSetting()); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get storage quota overrides: %v", err)
+	}
 	if instanceSetting.Key == storepb.InstanceSettingKey_AI && !isAdminCaller {
 		// Non-admin callers only need transcription.provider_id to gate the
 		// editor's Transcribe button. Model / language / prompt are
@@ -183,6 +188,10 @@ func (s *APIV1Service) getInstanceSettingByName(ctx context.Context, name string
 			ai.Transcription.Language = ""
 			ai.Transcription.Prompt = ""
 		}
+		// Image analysis is entirely server-side; nothing in it is needed by clients.
+		if ai := result.GetAiSetting(); ai != nil {
+			ai.ImageAnalysis = nil
+		}
 	}
 	return result, nil
 }
@@ -243,6 +252,15 @@ func (s *APIV1Service) UpdateInstanceSetting(ctx context.Context, request *v1pb.
 		if err := store.PrepareInstanceStorageSettingUpdate(updateSetting.GetStorageSetting(), existing); err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid storage setting: %v", err)
 		}
+		if err := s.resolveStorageQuotaOverrides(ctx, request.Setting.GetStorageSetting(), updateSetting.GetStorageSetting()); err != nil {
+			return nil, err
+		}
+		// Keep the scanner token unless the endpoint it authenticates to changes.
+		if scan := updateSetting.GetStorageSetting().GetContentScan(); scan != nil && scan.HttpToken == "" {
+			if previous := existing.GetContentScan(); previous.GetHttpToken() != "" && previous.GetHttpEndpoint() == scan.HttpEndpoint {
+				scan.HttpToken = previous.HttpToken
+			}
+		}
 	case storepb.InstanceSettingKey_AI:
 		if err := s.prepareInstanceAISettingForUpdate(ctx, updateSetting.GetAiSetting()); err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid AI setting: %v", err)
@@ -264,7 +282,11 @@ func (s *APIV1Service) UpdateInstanceSetting(ctx context.Context, request *v1pb.
 		return nil, status.Errorf(codes.Internal, "failed to upsert instance setting: %v", err)
 	}
 
-	return convertInstanceSettingFromStore(instanceSetting), nil
+	result := convertInstanceSettingFromStore(instanceSetting)
+	if err := s.fillStorageQuotaOverrides(ctx, instanceSetting.GetStorageSetting(), result.GetStorageSetting()); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get storage quota overrides: %v", err)
+	}
+	return result, nil
 }
 
 func (s *APIV1Service) TestInstanceEmailSetting(ctx context.Context, request *v1pb.TestInstanceEmailSettingRequest) (*emptypb.Empty, error) {
@@ -343,6 +365,91 @@ func sameSMTPConnectionIdentity(setting, existing *storepb.InstanceNotificationS
 		setting.UseSsl == existing.UseSsl
 }
 
+func (s *APIV1Service) TestAIProvider(ctx context.Context, request *v1pb.TestAIProviderRequest) (*v1pb.TestAIProviderResponse, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if user.Role != store.RoleAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if request.Provider == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "provider is required")
+	}
+
+	provider, err := s.resolveTestAIProvider(ctx, request.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := s.listAIProviderModels(ctx, convertAIProviderConfigFromStore(provider))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list AI provider models: %v", err)
+	}
+
+	response := &v1pb.TestAIProviderResponse{
+		Models: make([]*v1pb.TestAIProviderResponse_Model, 0, len(models)),
+	}
+	for _, model := range models {
+		response.Models = append(response.Models, &v1pb.TestAIProviderResponse_Model{
+			Id:          model.ID,
+			DisplayName: model.DisplayName,
+		})
+	}
+	return response, nil
+}
+
+// resolveTestAIProvider validates the provider under test and fills in the
+// stored API key when the request omits it. Like the SMTP password, a stored
+// key is only reused while the provider keeps its type and endpoint, so it is
+// never sent to a server it was not configured for.
+func (s *APIV1Service) resolveTestAIProvider(ctx context.Context, requestProvider *v1pb.InstanceSetting_AIProviderConfig) (*storepb.AIProviderConfig, error) {
+	provider := convertInstanceAISettingToStore(&v1pb.InstanceSetting_AISetting{
+		Providers: []*v1pb.InstanceSetting_AIProviderConfig{requestProvider},
+	}).GetProviders()[0]
+	provider.Id = strings.TrimSpace(provider.Id)
+	if err := normalizeAIProviderConfig(provider); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid AI provider: %v", err)
+	}
+
+	requiresAPIKey := convertAIProviderTypeFromStore(provider.Type).RequiresAPIKey()
+	if provider.ApiKey != "" || provider.Id == "" {
+		if provider.ApiKey == "" && requiresAPIKey {
+			return nil, status.Errorf(codes.InvalidArgument, "api key is required")
+		}
+		return provider, nil
+	}
+
+	existing, err := s.Store.GetInstanceAISetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get AI setting: %v", err)
+	}
+	for _, existingProvider := range existing.GetProviders() {
+		if existingProvider == nil || existingProvider.Id != provider.Id || existingProvider.ApiKey == "" {
+			continue
+		}
+		if sameAIProviderConnection(provider, existingProvider) {
+			provider.ApiKey = existingProvider.ApiKey
+			return provider, nil
+		}
+		if requiresAPIKey {
+			return nil, status.Errorf(codes.InvalidArgument, "api key is required when changing the provider type or endpoint")
+		}
+	}
+	if provider.ApiKey == "" && requiresAPIKey {
+		return nil, status.Errorf(codes.InvalidArgument, "api key is required")
+	}
+	return provider, nil
+}
+
+func sameAIProviderConnection(provider, existing *storepb.AIProviderConfig) bool {
+	return provider.Type == existing.Type &&
+		strings.TrimRight(provider.Endpoint, "/") == strings.TrimRight(existing.Endpoint, "/")
+}
+
 func (s *APIV1Service) GetInstanceAdmin(ctx context.Context) (*v1pb.User, error) {
 	adminUserType := store.RoleAdmin
 	user, err := s.Store.GetUser(ctx, &store.FindUser{
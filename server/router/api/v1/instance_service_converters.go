@@ -2,6 +2,7 @@ package v1
 
 import (
 	"fmt"
+	"strings"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -163,14 +164,26 @@ func convertInstanceStorageSettingFromStore(settingpb *storepb.InstanceStorageSe
 		return nil
 	}
 	setting := &v1pb.InstanceSetting_StorageSetting{
-		StorageType:       v1pb.InstanceSetting_StorageSetting_StorageType(settingpb.StorageType),
-		FilepathTemplate:  settingpb.FilepathTemplate,
-		UploadSizeLimitMb: settingpb.UploadSizeLimitMb,
-		DefaultStorageId:  settingpb.DefaultStorageId,
+		StorageType:            v1pb.InstanceSetting_StorageSetting_StorageType(settingpb.StorageType),
+		FilepathTemplate:       settingpb.FilepathTemplate,
+		UploadSizeLimitMb:      settingpb.UploadSizeLimitMb,
+		DefaultStorageId:       settingpb.DefaultStorageId,
+		VariantCacheSizeMb:     settingpb.VariantCacheSizeMb,
+		VariantCacheMaxAgeDays: settingpb.VariantCacheMaxAgeDays,
 	}
 	for _, storagepb := range settingpb.Storages {
 		setting.Storages = append(setting.Storages, convertStorageFromStore(storagepb))
 	}
+	setting.AllowedMimeTypes = settingpb.AllowedMimeTypes
+	setting.BlockedMimeTypes = settingpb.BlockedMimeTypes
+	// User overrides are keyed by user ID in the store; the service fills in
+	// their user names.
+	if quota := settingpb.Quota; quota != nil {
+		setting.Quota = &v1pb.InstanceSetting_StorageQuota{
+			UserQuotaMb:  quota.UserQuotaMb,
+			AdminQuotaMb: quota.AdminQuotaMb,
+		}
+	}
 	if settingpb.S3Config != nil {
 		setting.S3Config = &v1pb.InstanceSetting_StorageSetting_S3Config{
 			AccessKeyId: settingpb.S3Config.AccessKeyId,
@@ -180,6 +193,18 @@ func convertInstanceStorageSettingFromStore(settingpb *storepb.InstanceStorageSe
 			Bucket:                settingpb.S3Config.Bucket,
 			UsePathStyle:          settingpb.S3Config.UsePathStyle,
 			InsecureSkipTlsVerify: settingpb.S3Config.InsecureSkipTlsVerify,
+			PresignedTransfers:    settingpb.S3Config.PresignedTransfers,
+		}
+	}
+	if scan := settingpb.ContentScan; scan != nil {
+		setting.ContentScan = &v1pb.InstanceSetting_ContentScanConfig{
+			Scanner:      v1pb.InstanceSetting_ContentScanConfig_Scanner(scan.Scanner),
+			ClamdAddress: scan.ClamdAddress,
+			HttpEndpoint: scan.HttpEndpoint,
+			// HttpToken is write-only: never returned in responses.
+			InfectedAction: v1pb.InstanceSetting_ContentScanConfig_Action(scan.InfectedAction),
+			FailOpen:       scan.FailOpen,
+			TimeoutSeconds: scan.TimeoutSeconds,
 		}
 	}
 	return setting
@@ -190,14 +215,24 @@ func convertInstanceStorageSettingToStore(setting *v1pb.InstanceSetting_StorageS
 		return nil
 	}
 	settingpb := &storepb.InstanceStorageSetting{
-		StorageType:       storepb.InstanceStorageSetting_StorageType(setting.StorageType),
-		FilepathTemplate:  setting.FilepathTemplate,
-		UploadSizeLimitMb: setting.UploadSizeLimitMb,
-		DefaultStorageId:  setting.DefaultStorageId,
+		StorageType:            storepb.InstanceStorageSetting_StorageType(setting.StorageType),
+		FilepathTemplate:       setting.FilepathTemplate,
+		UploadSizeLimitMb:      setting.UploadSizeLimitMb,
+		DefaultStorageId:       setting.DefaultStorageId,
+		VariantCacheSizeMb:     setting.VariantCacheSizeMb,
+		VariantCacheMaxAgeDays: setting.VariantCacheMaxAgeDays,
 	}
 	for _, storage := range setting.Storages {
 		settingpb.Storages = append(settingpb.Storages, convertStorageToStore(storage))
 	}
+	settingpb.AllowedMimeTypes = normalizeMimeTypePatterns(setting.AllowedMimeTypes)
+	settingpb.BlockedMimeTypes = normalizeMimeTypePatterns(setting.BlockedMimeTypes)
+	if quota := setting.Quota; quota != nil {
+		settingpb.Quota = &storepb.StorageQuota{
+			UserQuotaMb:  quota.UserQuotaMb,
+			AdminQuotaMb: quota.AdminQuotaMb,
+		}
+	}
 	if setting.S3Config != nil {
 		settingpb.S3Config = &storepb.StorageS3Config{
 			AccessKeyId:           setting.S3Config.AccessKeyId,
@@ -207,6 +242,18 @@ func convertInstanceStorageSettingToStore(setting *v1pb.InstanceSetting_StorageS
 			Bucket:                setting.S3Config.Bucket,
 			UsePathStyle:          setting.S3Config.UsePathStyle,
 			InsecureSkipTlsVerify: setting.S3Config.InsecureSkipTlsVerify,
+			PresignedTransfers:    setting.S3Config.PresignedTransfers,
+		}
+	}
+	if scan := setting.ContentScan; scan != nil {
+		settingpb.ContentScan = &storepb.ContentScanConfig{
+			Scanner:        storepb.ContentScanConfig_Scanner(scan.Scanner),
+			ClamdAddress:   strings.TrimSpace(scan.ClamdAddress),
+			HttpEndpoint:   strings.TrimSpace(scan.HttpEndpoint),
+			HttpToken:      scan.HttpToken,
+			InfectedAction: storepb.ContentScanConfig_Action(scan.InfectedAction),
+			FailOpen:       scan.FailOpen,
+			TimeoutSeconds: scan.TimeoutSeconds,
 		}
 	}
 	return settingpb
@@ -231,6 +278,37 @@ func convertStorageFromStore(storagepb *storepb.Storage) *v1pb.InstanceSetting_S
 				Bucket:                s3Config.Bucket,
 				UsePathStyle:          s3Config.UsePathStyle,
 				InsecureSkipTlsVerify: s3Config.InsecureSkipTlsVerify,
+				PresignedTransfers:    s3Config.PresignedTransfers,
+			},
+		}
+	}
+	if localConfig := storagepb.GetLocalConfig(); localConfig != nil {
+		storage.Config = &v1pb.InstanceSetting_Storage_LocalConfig_{
+			LocalConfig: &v1pb.InstanceSetting_Storage_LocalConfig{
+				Path: localConfig.Path,
+			},
+		}
+	}
+	if webdavConfig := storagepb.GetWebdavConfig(); webdavConfig != nil {
+		storage.Config = &v1pb.InstanceSetting_Storage_WebdavConfig{
+			WebdavConfig: &v1pb.InstanceSetting_Storage_WebDAVConfig{
+				Endpoint: webdavConfig.Endpoint,
+				Username: webdavConfig.Username,
+				// Password is write-only: never returned in responses.
+				InsecureSkipTlsVerify: webdavConfig.InsecureSkipTlsVerify,
+			},
+		}
+	}
+	if sftpConfig := storagepb.GetSftpConfig(); sftpConfig != nil {
+		storage.Config = &v1pb.InstanceSetting_Storage_SftpConfig{
+			SftpConfig: &v1pb.InstanceSetting_Storage_SFTPConfig{
+				Host:     sftpConfig.Host,
+				Port:     sftpConfig.Port,
+				Username: sftpConfig.Username,
+				// Password and PrivateKey are write-only: never returned in responses.
+				HostKey:               sftpConfig.HostKey,
+				Path:                  sftpConfig.Path,
+				InsecureIgnoreHostKey: sftpConfig.InsecureIgnoreHostKey,
 			},
 		}
 	}
@@ -256,6 +334,38 @@ func convertStorageToStore(storage *v1pb.InstanceSetting_Storage) *storepb.Stora
 				Bucket:                s3Config.Bucket,
 				UsePathStyle:          s3Config.UsePathStyle,
 				InsecureSkipTlsVerify: s3Config.InsecureSkipTlsVerify,
+				PresignedTransfers:    s3Config.PresignedTransfers,
+			},
+		}
+	}
+	if localConfig := storage.GetLocalConfig(); localConfig != nil {
+		storagepb.Config = &storepb.Storage_LocalConfig{
+			LocalConfig: &storepb.StorageLocalConfig{
+				Path: localConfig.Path,
+			},
+		}
+	}
+	if webdavConfig := storage.GetWebdavConfig(); webdavConfig != nil {
+		storagepb.Config = &storepb.Storage_WebdavConfig{
+			WebdavConfig: &storepb.StorageWebDAVConfig{
+				Endpoint:              webdavConfig.Endpoint,
+				Username:              webdavConfig.Username,
+				Password:              webdavConfig.Password,
+				InsecureSkipTlsVerify: webdavConfig.InsecureSkipTlsVerify,
+			},
+		}
+	}
+	if sftpConfig := storage.GetSftpConfig(); sftpConfig != nil {
+		storagepb.Config = &storepb.Storage_SftpConfig{
+			SftpConfig: &storepb.StorageSFTPConfig{
+				Host:                  sftpConfig.Host,
+				Port:                  sftpConfig.Port,
+				Username:              sftpConfig.Username,
+				Password:              sftpConfig.Password,
+				PrivateKey:            sftpConfig.PrivateKey,
+				HostKey:               sftpConfig.HostKey,
+				Path:                  sftpConfig.Path,
+				InsecureIgnoreHostKey: sftpConfig.InsecureIgnoreHostKey,
 			},
 		}
 	}
@@ -370,6 +480,7 @@ func convertInstanceAISettingFromStore(setting *storepb.InstanceAISetting) *v1pb
 	aiSetting := &v1pb.InstanceSetting_AISetting{
 		Providers:     make([]*v1pb.InstanceSetting_AIProviderConfig, 0, len(setting.Providers)),
 		Transcription: convertTranscriptionConfigFromStore(setting.GetTranscription()),
+		ImageAnalysis: convertImageAnalysisConfigFromStore(setting.GetImageAnalysis()),
 	}
 	for _, provider := range setting.Providers {
 		if provider == nil {
@@ -377,12 +488,14 @@ func convertInstanceAISettingFromStore(setting *storepb.InstanceAISetting) *v1pb
 		}
 		apiKey := provider.GetApiKey()
 		aiSetting.Providers = append(aiSetting.Providers, &v1pb.InstanceSetting_AIProviderConfig{
-			Id:         provider.GetId(),
-			Title:      provider.GetTitle(),
-			Type:       v1pb.InstanceSetting_AIProviderType(provider.GetType()),
-			Endpoint:   provider.GetEndpoint(),
-			ApiKeySet:  apiKey != "",
-			ApiKeyHint: maskAPIKey(apiKey),
+			Id:                    provider.GetId(),
+			Title:                 provider.GetTitle(),
+			Type:                  v1pb.InstanceSetting_AIProviderType(provider.GetType()),
+			Endpoint:              provider.GetEndpoint(),
+			ApiKeySet:             apiKey != "",
+			ApiKeyHint:            maskAPIKey(apiKey),
+			TimeoutSeconds:        provider.GetTimeoutSeconds(),
+			MaxConcurrentRequests: provider.GetMaxConcurrentRequests(),
 		})
 	}
 	return aiSetting
@@ -396,17 +509,20 @@ func convertInstanceAISettingToStore(setting *v1pb.InstanceSetting_AISetting) *s
 	aiSetting := &storepb.InstanceAISetting{
 		Providers:     make([]*storepb.AIProviderConfig, 0, len(setting.Providers)),
 		Transcription: convertTranscriptionConfigToStore(setting.GetTranscription()),
+		ImageAnalysis: convertImageAnalysisConfigToStore(setting.GetImageAnalysis()),
 	}
 	for _, provider := range setting.Providers {
 		if provider == nil {
 			continue
 		}
 		aiSetting.Providers = append(aiSetting.Providers, &storepb.AIProviderConfig{
-			Id:       provider.GetId(),
-			Title:    provider.GetTitle(),
-			Type:     storepb.AIProviderType(provider.GetType()),
-			Endpoint: provider.GetEndpoint(),
-			ApiKey:   provider.GetApiKey(),
+			Id:                    provider.GetId(),
+			Title:                 provider.GetTitle(),
+			Type:                  storepb.AIProviderType(provider.GetType()),
+			Endpoint:              provider.GetEndpoint(),
+			ApiKey:                provider.GetApiKey(),
+			TimeoutSeconds:        provider.GetTimeoutSeconds(),
+			MaxConcurrentRequests: provider.GetMaxConcurrentRequests(),
 		})
 	}
 	return aiSetting
@@ -417,21 +533,47 @@ func convertTranscriptionConfigFromStore(setting *storepb.TranscriptionConfig) *
 		return nil
 	}
 	return &v1pb.InstanceSetting_TranscriptionConfig{
+		ProviderId:                setting.GetProviderId(),
+		Model:                     setting.GetModel(),
+		Language:                  setting.GetLanguage(),
+		Prompt:                    setting.GetPrompt(),
+		AutoTranscribeAttachments: setting.GetAutoTranscribeAttachments(),
+	}
+}
+
+func convertTranscriptionConfigToStore(setting *v1pb.InstanceSetting_TranscriptionConfig) *storepb.TranscriptionConfig {
+	if setting == nil {
+		return nil
+	}
+	return &storepb.TranscriptionConfig{
+		ProviderId:                setting.GetProviderId(),
+		Model:                     setting.GetModel(),
+		Language:                  setting.GetLanguage(),
+		Prompt:                    setting.GetPrompt(),
+		AutoTranscribeAttachments: setting.GetAutoTranscribeAttachments(),
+	}
+}
+
+func convertImageAnalysisConfigFromStore(setting *storepb.ImageAnalysisConfig) *v1pb.InstanceSetting_ImageAnalysisConfig {
+	if setting == nil {
+		return nil
+	}
+	return &v1pb.InstanceSetting_ImageAnalysisConfig{
+		Engine:     v1pb.InstanceSetting_ImageAnalysisConfig_Engine(setting.GetEngine()),
 		ProviderId: setting.GetProviderId(),
 		Model:      setting.GetModel(),
 		Language:   setting.GetLanguage(),
-		Prompt:     setting.GetPrompt(),
 	}
 }
 
-func convertTranscriptionConfigToStore(setting *v1pb.InstanceSetting_TranscriptionConfig) *storepb.TranscriptionConfig {
+func convertImageAnalysisConfigToStore(setting *v1pb.InstanceSetting_ImageAnalysisConfig) *storepb.ImageAnalysisConfig {
 	if setting == nil {
 		return nil
 	}
-	return &storepb.TranscriptionConfig{
+	return &storepb.ImageAnalysisConfig{
+		Engine:     storepb.ImageAnalysisConfig_Engine(setting.GetEngine()),
 		ProviderId: setting.GetProviderId(),
 		Model:      setting.GetModel(),
 		Language:   setting.GetLanguage(),
-		Prompt:     setting.GetPrompt(),
 	}
 }
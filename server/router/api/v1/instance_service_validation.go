@@ -3,13 +3,18 @@ package v1
 import (
 	"context"
 	"math"
+	"net"
+	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/pkg/errors"
 	colorpb "google.golang.org/genproto/googleapis/type/color"
 
+	"github.com/usememos/memos/internal/ai"
+	catalogollama "github.com/usememos/memos/internal/ai/catalog/ollama"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
@@ -24,6 +29,10 @@ func validateInstanceSetting(setting *v1pb.InstanceSetting) error {
 		return validateInstanceTagsSetting(setting.GetTagsSetting())
 	case storepb.InstanceSettingKey_ACCESS.String():
 		return validateInstanceAccessSetting(setting.GetAccessSetting())
+	case storepb.InstanceSettingKey_STORAGE.String():
+		return validateInstanceStorageSetting(setting.GetStorageSetting())
+	case storepb.InstanceSettingKey_MEMO_TEMPLATES.String():
+		return errors.New("memo templates are managed by MemoTemplateService")
 	default:
 		return nil
 	}
@@ -41,6 +50,67 @@ func validateInstanceAccessSetting(setting *v1pb.InstanceSetting_AccessSetting)
 	}
 }
 
+func validateInstanceStorageSetting(setting *v1pb.InstanceSetting_StorageSetting) error {
+	if setting == nil {
+		return errors.New("storage setting is required")
+	}
+	for _, pattern := range append(slices.Clone(setting.AllowedMimeTypes), setting.BlockedMimeTypes...) {
+		family, subtype, ok := strings.Cut(strings.TrimSpace(pattern), "/")
+		if !ok || family == "" || family == "*" || subtype == "" || strings.ContainsAny(subtype, "/; ") {
+			return errors.Errorf("invalid MIME type pattern %q", pattern)
+		}
+	}
+	if setting.VariantCacheSizeMb < 0 || setting.VariantCacheMaxAgeDays < 0 {
+		return errors.New("variant cache limits must not be negative")
+	}
+	quota := setting.GetQuota()
+	if quota.GetUserQuotaMb() < 0 || quota.GetAdminQuotaMb() < 0 {
+		return errors.New("storage quotas must not be negative")
+	}
+	for _, override := range quota.GetUserOverrides() {
+		if override.User == "" {
+			return errors.New("user is required for a quota override")
+		}
+		if override.QuotaMb < 0 {
+			return errors.Errorf("quota of %s must not be negative", override.User)
+		}
+	}
+	return validateContentScanConfig(setting.GetContentScan())
+}
+
+func validateContentScanConfig(config *v1pb.InstanceSetting_ContentScanConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.TimeoutSeconds < 0 {
+		return errors.New("content scan timeout must not be negative")
+	}
+	switch config.Scanner {
+	case v1pb.InstanceSetting_ContentScanConfig_SCANNER_UNSPECIFIED:
+		return nil
+	case v1pb.InstanceSetting_ContentScanConfig_CLAMD:
+		address := strings.TrimSpace(config.ClamdAddress)
+		if path, ok := strings.CutPrefix(address, "unix:"); ok {
+			if path == "" {
+				return errors.New("clamd socket path is required")
+			}
+			return nil
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return errors.Errorf("invalid clamd address %q", config.ClamdAddress)
+		}
+		return nil
+	case v1pb.InstanceSetting_ContentScanConfig_HTTP:
+		endpoint, err := url.Parse(strings.TrimSpace(config.HttpEndpoint))
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return errors.Errorf("invalid content scan endpoint %q", config.HttpEndpoint)
+		}
+		return nil
+	default:
+		return errors.Errorf("unsupported content scanner %v", config.Scanner)
+	}
+}
+
 func (s *APIV1Service) prepareInstanceAISettingForUpdate(ctx context.Context, setting *storepb.InstanceAISetting) error {
 	if setting == nil {
 		return errors.New("AI setting is required")
@@ -78,16 +148,8 @@ func (s *APIV1Service) prepareInstanceAISettingForUpdate(ctx context.Context, se
 		if provider.Title == "" {
 			return errors.New("provider title is required")
 		}
-		if provider.Type != storepb.AIProviderType_OPENAI && provider.Type != storepb.AIProviderType_GEMINI {
-			return errors.Errorf("provider %q has unsupported type", provider.Id)
-		}
-
-		provider.Endpoint = strings.TrimSpace(provider.Endpoint)
-		if provider.Type == storepb.AIProviderType_OPENAI && provider.Endpoint == "" {
-			provider.Endpoint = "https://api.openai.com/v1"
-		}
-		if provider.Type == storepb.AIProviderType_GEMINI && provider.Endpoint == "" {
-			provider.Endpoint = "https://generativelanguage.googleapis.com/v1beta"
+		if err := normalizeAIProviderConfig(provider); err != nil {
+			return err
 		}
 
 		if provider.ApiKey == "" {
@@ -95,7 +157,7 @@ func (s *APIV1Service) prepareInstanceAISettingForUpdate(ctx context.Context, se
 				provider.ApiKey = existingProvider.ApiKey
 			}
 		}
-		if provider.ApiKey == "" {
+		if provider.ApiKey == "" && convertAIProviderTypeFromStore(provider.Type).RequiresAPIKey() {
 			return errors.Errorf("provider %q API key is required", provider.Id)
 		}
 	}
@@ -103,6 +165,47 @@ func (s *APIV1Service) prepareInstanceAISettingForUpdate(ctx context.Context, se
 	if err := preparePersistedTranscriptionConfig(setting, existing); err != nil {
 		return err
 	}
+	if err := preparePersistedImageAnalysisConfig(setting, existing); err != nil {
+		return err
+	}
+	return nil
+}
+
+// normalizeAIProviderConfig validates the connection settings of a provider and
+// fills in the default endpoint of its type. The API key is checked by callers,
+// which may fall back to a stored key.
+func normalizeAIProviderConfig(provider *storepb.AIProviderConfig) error {
+	provider.Endpoint = strings.TrimSpace(provider.Endpoint)
+	switch provider.Type {
+	case storepb.AIProviderType_OPENAI:
+		if provider.Endpoint == "" {
+			provider.Endpoint = "https://api.openai.com/v1"
+		}
+	case storepb.AIProviderType_GEMINI:
+		if provider.Endpoint == "" {
+			provider.Endpoint = "https://generativelanguage.googleapis.com/v1beta"
+		}
+	case storepb.AIProviderType_OPENAI_COMPATIBLE:
+		if provider.Endpoint == "" {
+			return errors.Errorf("provider %q endpoint is required", provider.Id)
+		}
+	case storepb.AIProviderType_OLLAMA:
+		if provider.Endpoint == "" {
+			provider.Endpoint = catalogollama.DefaultEndpoint
+		}
+	default:
+		return errors.Errorf("provider %q has unsupported type", provider.Id)
+	}
+	if parsed, err := url.ParseRequestURI(provider.Endpoint); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return errors.Errorf("provider %q endpoint must be an http or https URL", provider.Id)
+	}
+
+	if provider.TimeoutSeconds < 0 || provider.TimeoutSeconds > maxAIProviderTimeoutSeconds {
+		return errors.Errorf("provider %q timeout must be between 0 and %d seconds", provider.Id, maxAIProviderTimeoutSeconds)
+	}
+	if provider.MaxConcurrentRequests < 0 || provider.MaxConcurrentRequests > maxAIProviderConcurrentRequests {
+		return errors.Errorf("provider %q max concurrent requests must be between 0 and %d", provider.Id, maxAIProviderConcurrentRequests)
+	}
 	return nil
 }
 
@@ -125,16 +228,25 @@ func preparePersistedTranscriptionConfig(setting *storepb.InstanceAISetting, exi
 	cfg.Prompt = strings.TrimSpace(cfg.Prompt)
 
 	if cfg.ProviderId != "" {
-		referenced := false
+		var referenced *storepb.AIProviderConfig
 		for _, provider := range setting.Providers {
 			if provider != nil && provider.Id == cfg.ProviderId {
-				referenced = true
+				referenced = provider
 				break
 			}
 		}
-		if !referenced {
+		if referenced == nil {
 			return errors.Errorf("transcription provider_id %q does not reference any configured provider", cfg.ProviderId)
 		}
+		providerType := convertAIProviderTypeFromStore(referenced.Type)
+		if !ai.SupportsTranscription(providerType) {
+			return errors.Errorf("transcription provider %q does not support transcription", cfg.ProviderId)
+		}
+		if cfg.Model == "" {
+			if _, err := ai.DefaultTranscriptionModel(providerType); err != nil {
+				return errors.Errorf("transcription model is required for provider %q", cfg.ProviderId)
+			}
+		}
 	}
 
 	if len(cfg.Model) > maxTranscriptionConfigModelLength {
@@ -149,6 +261,52 @@ func preparePersistedTranscriptionConfig(setting *storepb.InstanceAISetting, exi
 	return nil
 }
 
+func preparePersistedImageAnalysisConfig(setting *storepb.InstanceAISetting, existing *storepb.InstanceAISetting) error {
+	// Same "absence == keep" semantics as the transcription config.
+	if setting.ImageAnalysis == nil && existing != nil {
+		setting.ImageAnalysis = existing.GetImageAnalysis()
+	}
+	if setting.ImageAnalysis == nil {
+		return nil
+	}
+
+	cfg := setting.ImageAnalysis
+	cfg.ProviderId = strings.TrimSpace(cfg.ProviderId)
+	cfg.Model = strings.TrimSpace(cfg.Model)
+	cfg.Language = strings.TrimSpace(cfg.Language)
+	if len(cfg.Model) > maxTranscriptionConfigModelLength {
+		return errors.Errorf("image analysis model is too long; maximum length is %d characters", maxTranscriptionConfigModelLength)
+	}
+	if len(cfg.Language) > maxTranscriptionConfigLanguageLength {
+		return errors.Errorf("image analysis language is too long; maximum length is %d characters", maxTranscriptionConfigLanguageLength)
+	}
+
+	switch cfg.Engine {
+	case storepb.ImageAnalysisConfig_ENGINE_UNSPECIFIED, storepb.ImageAnalysisConfig_TESSERACT:
+		return nil
+	case storepb.ImageAnalysisConfig_AI_PROVIDER:
+	default:
+		return errors.New("image analysis engine is not supported")
+	}
+
+	var referenced *storepb.AIProviderConfig
+	for _, provider := range setting.Providers {
+		if provider != nil && provider.Id == cfg.ProviderId {
+			referenced = provider
+			break
+		}
+	}
+	if referenced == nil {
+		return errors.Errorf("image analysis provider_id %q does not reference any configured provider", cfg.ProviderId)
+	}
+	if cfg.Model == "" {
+		if _, err := ai.DefaultVisionModel(convertAIProviderTypeFromStore(referenced.Type)); err != nil {
+			return errors.Errorf("image analysis model is required for provider %q", cfg.ProviderId)
+		}
+	}
+	return nil
+}
+
 func maskAPIKey(apiKey string) string {
 	if apiKey == "" {
 		return ""
@@ -79,6 +79,10 @@ func (s *APIV1Service) computeInstanceStats(ctx context.Context) (*v1pb.Instance
 		},
 		LocalStorageBytes: -1,
 		GeneratedTime:     timestamppb.Now(),
+		Attachments: &v1pb.InstanceStats_AttachmentStats{
+			Count:     -1,
+			SizeBytes: -1,
+		},
 	}
 
 	type result struct {
@@ -117,13 +121,30 @@ func (s *APIV1Service) computeInstanceStats(ctx context.Context) (*v1pb.Instance
 		return nil
 	})
 
+	g.Go(func() error {
+		usages, err := s.Store.ListAttachmentUsage(gctx, &store.FindAttachmentUsage{})
+		if err != nil {
+			record("attachments", err)
+			return nil
+		}
+		var count int32
+		var size int64
+		for _, usage := range usages {
+			count += usage.AttachmentCount
+			size += usage.TotalSize
+		}
+		stats.Attachments.Count = count
+		stats.Attachments.SizeBytes = size
+		return nil
+	})
+
 	_ = g.Wait()
 
 	for _, r := range results {
 		slog.Warn("instance stats subtask failed", slog.String("subtask", r.name), slog.String("err", r.err.Error()))
 	}
 
-	const totalSubtasks = 2
+	const totalSubtasks = 3
 	if len(results) == totalSubtasks {
 		return nil, errors.New("all instance stats subtasks failed")
 	}
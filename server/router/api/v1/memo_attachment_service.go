@@ -183,9 +183,8 @@ func (s *APIV1Service) applyMemoMutation(
 		return status.Errorf(codes.Internal, "failed to apply memo mutation: %v", err)
 	}
 
-	// Rows are detached in the transaction above. Delete storage one at a time so
-	// local cleanup remains storage-first; on failure the unlinked row remains and
-	// the owner can safely retry deletion.
+	// Rows are detached in the transaction above. Delete them one at a time; a
+	// failure leaves the remaining unlinked rows so the owner can retry deletion.
 	for _, attachment := range prepared.removed {
 		if err := s.Store.DeleteAttachment(ctx, &store.DeleteAttachment{ID: attachment.ID}); err != nil {
 			return status.Errorf(codes.Internal, "failed to delete attachment: %v", err)
@@ -0,0 +1,302 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// dailyMemoMonthLayout is the layout of the ListDailyMemos month.
+const dailyMemoMonthLayout = "2006-01"
+
+// GetDailyMemo returns the caller's daily memo for the date, creating it on
+// first use.
+func (s *APIV1Service) GetDailyMemo(ctx context.Context, request *v1pb.GetDailyMemoRequest) (*v1pb.Memo, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	unlock := s.lockDailyMemos(user.ID)
+	defer unlock()
+
+	memo, created, err := s.getOrCreateDailyMemo(ctx, user, request.Date, request.Template, "")
+	if err != nil {
+		return nil, err
+	}
+	if created != nil {
+		return created, nil
+	}
+	return s.GetMemo(ctx, &v1pb.GetMemoRequest{Name: buildMemoName(memo.UID)})
+}
+
+// AppendDailyMemo appends the request content to the caller's daily memo for
+// the date, creating the memo on first use.
+func (s *APIV1Service) AppendDailyMemo(ctx context.Context, request *v1pb.AppendDailyMemoRequest) (*v1pb.Memo, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	appendContent := strings.TrimSpace(request.Content)
+	if appendContent == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "content is required")
+	}
+
+	unlock := s.lockDailyMemos(user.ID)
+	defer unlock()
+
+	memo, created, err := s.getOrCreateDailyMemo(ctx, user, request.Date, request.Template, appendContent)
+	if err != nil {
+		return nil, err
+	}
+	if created != nil {
+		return created, nil
+	}
+	content := strings.TrimRight(memo.Content, " \t\r\n")
+	if content != "" {
+		content += "\n\n"
+	}
+	// The lock only covers this server, so the update aborts instead of
+	// overwriting an edit made since the memo was read.
+	return s.updateMemo(ctx, &v1pb.UpdateMemoRequest{
+		Memo:       &v1pb.Memo{Name: buildMemoName(memo.UID), Content: content + appendContent},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+	}, &memo.Content)
+}
+
+// ListDailyMemos lists the caller's daily memos within the calendar grid of a month.
+func (s *APIV1Service) ListDailyMemos(ctx context.Context, request *v1pb.ListDailyMemosRequest) (*v1pb.ListDailyMemosResponse, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	location, err := s.getUserLocation(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user time zone: %v", err)
+	}
+	month := time.Now().In(location)
+	if request.Month != "" {
+		month, err = time.Parse(dailyMemoMonthLayout, request.Month)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid month: %v", err)
+		}
+	}
+	instanceGeneralSetting, err := s.Store.GetInstanceGeneralSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get instance general setting: %v", err)
+	}
+	startDate, endDate := dailyMemoCalendarRange(month.Year(), month.Month(), int(instanceGeneralSetting.GetWeekStartDayOffset()))
+
+	dailyMemos, err := s.Store.ListDailyMemos(ctx, &store.FindDailyMemo{
+		CreatorID: &user.ID,
+		StartDate: &startDate,
+		EndDate:   &endDate,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list daily memos: %v", err)
+	}
+	response := &v1pb.ListDailyMemosResponse{
+		StartDate:  startDate,
+		EndDate:    endDate,
+		DailyMemos: []*v1pb.DailyMemo{},
+	}
+	if len(dailyMemos) == 0 {
+		return response, nil
+	}
+
+	memoIDs := make([]int32, 0, len(dailyMemos))
+	for _, dailyMemo := range dailyMemos {
+		memoIDs = append(memoIDs, dailyMemo.MemoID)
+	}
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{IDList: memoIDs, ExcludeContent: true})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+	memoUIDs := make(map[int32]string, len(memos))
+	for _, memo := range memos {
+		memoUIDs[memo.ID] = memo.UID
+	}
+	for _, dailyMemo := range dailyMemos {
+		uid, ok := memoUIDs[dailyMemo.MemoID]
+		if !ok {
+			continue
+		}
+		response.DailyMemos = append(response.DailyMemos, &v1pb.DailyMemo{
+			Date: dailyMemo.Date,
+			Memo: buildMemoName(uid),
+		})
+	}
+	return response, nil
+}
+
+// getOrCreateDailyMemo returns the user's daily memo for the date, which
+// defaults to today in the user's time zone. When the day has none yet, it
+// creates one from template with content and returns it as created instead.
+// Callers hold the lock of the user's daily memos.
+func (s *APIV1Service) getOrCreateDailyMemo(ctx context.Context, user *store.User, dateString, template, content string) (*store.Memo, *v1pb.Memo, error) {
+	location, err := s.getUserLocation(ctx, user.ID)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get user time zone: %v", err)
+	}
+	now := time.Now().In(location)
+	date := now
+	if dateString != "" {
+		date, err = time.ParseInLocation(store.DailyMemoDateLayout, dateString, location)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "invalid date: %v", err)
+		}
+	}
+	dateKey := date.Format(store.DailyMemoDateLayout)
+
+	memo, err := s.findDailyMemo(ctx, user.ID, dateKey)
+	if err != nil || memo != nil {
+		return memo, nil, err
+	}
+	created, claimed, err := s.createDailyMemo(ctx, user, date, now, template, content)
+	if err != nil {
+		return nil, nil, err
+	}
+	if claimed {
+		return nil, created, nil
+	}
+	// Another server claimed the day first; use its daily memo.
+	memo, err = s.findDailyMemo(ctx, user.ID, dateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if memo == nil {
+		return nil, nil, status.Errorf(codes.Aborted, "daily memo changed concurrently")
+	}
+	return memo, nil, nil
+}
+
+// createDailyMemo creates a daily memo and claims the day for it. When another
+// server claimed the day first, the new memo is deleted again and claimed is false.
+func (s *APIV1Service) createDailyMemo(ctx context.Context, user *store.User, date, now time.Time, template, appendContent string) (*v1pb.Memo, bool, error) {
+	dateKey := date.Format(store.DailyMemoDateLayout)
+	content := appendContent
+	if template == "" {
+		content = strings.TrimSpace("# " + dateKey + "\n\n" + appendContent)
+	}
+	createRequest := &v1pb.CreateMemoRequest{
+		Memo:     &v1pb.Memo{Content: content},
+		Template: template,
+	}
+	// Date the memo, and its template placeholders, on its day at the current
+	// time of day.
+	if dateKey != now.Format(store.DailyMemoDateLayout) {
+		createTime := time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), now.Second(), 0, date.Location())
+		createRequest.Memo.CreateTime = timestamppb.New(createTime)
+	}
+	created, err := s.CreateMemo(ctx, createRequest)
+	if err != nil {
+		return nil, false, err
+	}
+	memoUID, err := ExtractMemoUIDFromName(created.Name)
+	if err != nil {
+		return nil, false, status.Errorf(codes.Internal, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID, ExcludeContent: true})
+	if err != nil {
+		return nil, false, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, false, status.Errorf(codes.NotFound, "memo not found")
+	}
+
+	claimed, err := s.Store.CreateDailyMemo(ctx, &store.DailyMemo{
+		CreatorID: user.ID,
+		Date:      dateKey,
+		MemoID:    memo.ID,
+	})
+	if err != nil {
+		return nil, false, status.Errorf(codes.Internal, "failed to create daily memo: %v", err)
+	}
+	if !claimed {
+		if _, err := s.DeleteMemo(ctx, &v1pb.DeleteMemoRequest{Name: created.Name}); err != nil {
+			return nil, false, err
+		}
+		return nil, false, nil
+	}
+	return created, true, nil
+}
+
+// findDailyMemo returns the memo indexed as the user's daily memo for the
+// date, or nil when there is none. Index entries of deleted memos are dropped.
+func (s *APIV1Service) findDailyMemo(ctx context.Context, userID int32, dateKey string) (*store.Memo, error) {
+	dailyMemo, err := s.Store.GetDailyMemo(ctx, userID, dateKey)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get daily memo: %v", err)
+	}
+	if dailyMemo == nil {
+		return nil, nil
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: &dailyMemo.MemoID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo != nil && memo.CreatorID == userID {
+		return memo, nil
+	}
+	if err := s.Store.DeleteDailyMemo(ctx, &store.DeleteDailyMemo{CreatorID: userID, Date: dateKey}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete daily memo: %v", err)
+	}
+	return nil, nil
+}
+
+// lockDailyMemos serializes daily memo creation and appends of a user on this
+// server.
+func (s *APIV1Service) lockDailyMemos(userID int32) func() {
+	value, _ := s.dailyMemoMutexes.LoadOrStore(userID, &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+// getUserLocation returns the time zone of the user's general setting, or UTC
+// when the user has none.
+func (s *APIV1Service) getUserLocation(ctx context.Context, userID int32) (*time.Location, error) {
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSetting_GENERAL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	timeZone := userSetting.GetGeneral().GetTimeZone()
+	if timeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(timeZone)
+}
+
+// dailyMemoCalendarRange returns the first and last day of the calendar grid
+// of the month: whole weeks starting on the week start day offset, matching
+// the web calendar.
+func dailyMemoCalendarRange(year int, month time.Month, weekStartDayOffset int) (string, string) {
+	offset := ((weekStartDayOffset % 7) + 7) % 7
+	monthStart := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, -1)
+	start := monthStart.AddDate(0, 0, -((int(monthStart.Weekday()) - offset + 7) % 7))
+	end := monthEnd.AddDate(0, 0, (offset+6-int(monthEnd.Weekday())+7)%7)
+	return start.Format(store.DailyMemoDateLayout), end.Format(store.DailyMemoDateLayout)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/internal/base"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
@@ -23,7 +24,10 @@ func isMentionNotificationSuppressed(ctx context.Context) bool {
 	return ok && v
 }
 
-func (s *APIV1Service) resolveMentionTargets(ctx context.Context, content string) (map[int32]*store.User, error) {
+// resolveMentionTargets returns the active users that content mentions.
+// Unknown usernames are ignored. @here mentions everyone taking part in the
+// thread of memo, see listThreadParticipants.
+func (s *APIV1Service) resolveMentionTargets(ctx context.Context, content string, memo *store.Memo, relatedMemo *store.Memo) (map[int32]*store.User, error) {
 	targets := make(map[int32]*store.User)
 	if content == "" {
 		return targets, nil
@@ -33,23 +37,109 @@ func (s *APIV1Service) resolveMentionTargets(ctx context.Context, content string
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to extract mentions")
 	}
-	if len(data.Mentions) == 0 {
-		return targets, nil
+	usernames := make([]string, 0, len(data.Mentions))
+	mentionsHere := false
+	for _, username := range data.Mentions {
+		if base.IsHereMention(username) {
+			mentionsHere = true
+			continue
+		}
+		usernames = append(usernames, username)
 	}
 
 	normal := store.Normal
-	users, err := s.Store.ListUsers(ctx, &store.FindUser{
-		UsernameList: data.Mentions,
-		RowStatus:    &normal,
+	if len(usernames) > 0 {
+		users, err := s.Store.ListUsers(ctx, &store.FindUser{
+			UsernameList: usernames,
+			RowStatus:    &normal,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve mention users")
+		}
+		for _, user := range users {
+			targets[user.ID] = user
+		}
+	}
+
+	if mentionsHere {
+		participantIDs, err := s.listThreadParticipants(ctx, memo, relatedMemo)
+		if err != nil {
+			return nil, err
+		}
+		if len(participantIDs) > 0 {
+			users, err := s.Store.ListUsers(ctx, &store.FindUser{
+				IDList:    participantIDs,
+				RowStatus: &normal,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to resolve thread participants")
+			}
+			for _, user := range users {
+				targets[user.ID] = user
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+// listThreadParticipants returns the IDs of the users taking part in the
+// thread of memo: the creator of the memo, or of the memo it comments on, and
+// the creators of the comments on that memo.
+func (s *APIV1Service) listThreadParticipants(ctx context.Context, memo *store.Memo, relatedMemo *store.Memo) ([]int32, error) {
+	root := memo
+	if relatedMemo != nil {
+		root = relatedMemo
+	}
+
+	commentType := store.MemoRelationComment
+	relations, err := s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
+		RelatedMemoID: &root.ID,
+		Type:          &commentType,
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to resolve mention users")
+		return nil, errors.Wrap(err, "failed to list thread comments")
 	}
 
-	for _, user := range users {
-		targets[user.ID] = user
+	participantIDs := []int32{root.CreatorID}
+	if len(relations) == 0 {
+		return participantIDs, nil
+	}
+	commentIDs := make([]int32, 0, len(relations))
+	for _, relation := range relations {
+		commentIDs = append(commentIDs, relation.MemoID)
 	}
+	normal := store.Normal
+	comments, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		IDList:         commentIDs,
+		RowStatus:      &normal,
+		ExcludeContent: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list thread comments")
+	}
+	seen := map[int32]bool{root.CreatorID: true}
+	for _, comment := range comments {
+		if !seen[comment.CreatorID] {
+			seen[comment.CreatorID] = true
+			participantIDs = append(participantIDs, comment.CreatorID)
+		}
+	}
+	return participantIDs, nil
+}
 
+// resolveMentionRecipients returns the users that a mention in memo notifies:
+// the mention targets that did not write it and can read it.
+func (s *APIV1Service) resolveMentionRecipients(ctx context.Context, memo *store.Memo, relatedMemo *store.Memo) (map[int32]*store.User, error) {
+	targets, err := s.resolveMentionTargets(ctx, memo.Content, memo, relatedMemo)
+	if err != nil {
+		return nil, err
+	}
+	for userID, target := range targets {
+		if shouldSkipMentionInbox(target, memo, relatedMemo) {
+			delete(targets, userID)
+		}
+	}
 	return targets, nil
 }
 
@@ -88,29 +178,33 @@ func shouldSkipMentionInbox(target *store.User, memo *store.Memo, relatedMemo *s
 	return !canUserAccessMentionContext(target, memo, relatedMemo)
 }
 
-func (s *APIV1Service) dispatchMemoMentionNotifications(ctx context.Context, memo *store.Memo, relatedMemo *store.Memo, previousContent string) error {
+// dispatchMemoMentionNotifications notifies the users that memo mentions.
+// previous is the memo before an update, or nil for a new memo; users whom
+// the previous version already notified are not notified again, while users
+// who could not read it, such as mentions in a memo that was private, are.
+func (s *APIV1Service) dispatchMemoMentionNotifications(ctx context.Context, memo *store.Memo, relatedMemo *store.Memo, previous *store.Memo) error {
 	if memo == nil {
 		return nil
 	}
 
-	currentTargets, err := s.resolveMentionTargets(ctx, memo.Content)
+	recipients, err := s.resolveMentionRecipients(ctx, memo, relatedMemo)
 	if err != nil {
 		return err
 	}
-	if len(currentTargets) == 0 {
+	if len(recipients) == 0 {
 		return nil
 	}
 
-	previousTargets, err := s.resolveMentionTargets(ctx, previousContent)
-	if err != nil {
-		return err
+	previousRecipients := map[int32]*store.User{}
+	if previous != nil {
+		previousRecipients, err = s.resolveMentionRecipients(ctx, previous, relatedMemo)
+		if err != nil {
+			return err
+		}
 	}
 
-	for userID, target := range currentTargets {
-		if _, exists := previousTargets[userID]; exists {
-			continue
-		}
-		if shouldSkipMentionInbox(target, memo, relatedMemo) {
+	for userID, target := range recipients {
+		if _, exists := previousRecipients[userID]; exists {
 			continue
 		}
 
@@ -139,8 +233,8 @@ func (s *APIV1Service) dispatchMemoMentionNotifications(ctx context.Context, mem
 	return nil
 }
 
-func (s *APIV1Service) dispatchMemoMentionNotificationsBestEffort(ctx context.Context, memo *store.Memo, relatedMemo *store.Memo, previousContent string) {
-	if err := s.dispatchMemoMentionNotifications(ctx, memo, relatedMemo, previousContent); err != nil {
+func (s *APIV1Service) dispatchMemoMentionNotificationsBestEffort(ctx context.Context, memo *store.Memo, relatedMemo *store.Memo, previous *store.Memo) {
+	if err := s.dispatchMemoMentionNotifications(ctx, memo, relatedMemo, previous); err != nil {
 		slog.Warn("Failed to dispatch memo mention notifications", slog.Any("err", err), slog.Int64("memo_id", int64(memo.ID)))
 	}
 }
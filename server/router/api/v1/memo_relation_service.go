@@ -40,6 +40,10 @@ func (s *APIV1Service) SetMemoRelations(ctx context.Context, request *v1pb.SetMe
 	if err != nil {
 		return nil, err
 	}
+	relations, err = s.syncEmbedRelations(ctx, memo, relations)
+	if err != nil {
+		return nil, err
+	}
 	updatedTs := time.Now().Unix()
 	if err := s.applyMemoMutation(ctx, memo, nil, &store.UpdateMemo{ID: memo.ID, UpdatedTs: &updatedTs}, nil, &relations); err != nil {
 		return nil, err
@@ -69,6 +73,10 @@ func (s *APIV1Service) prepareMemoRelations(ctx context.Context, memo *store.Mem
 		if relation.Type == v1pb.MemoRelation_COMMENT {
 			continue
 		}
+		// Ignore embed relations as they follow the ![[memo]] embeds in the content.
+		if relation.Type == v1pb.MemoRelation_EMBED {
+			continue
+		}
 		relatedMemoUID, err := ExtractMemoUIDFromName(relation.RelatedMemo.Name)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid related memo name: %v", err)
@@ -185,6 +193,8 @@ func convertMemoRelationTypeFromStore(relationType store.MemoRelationType) v1pb.
 		return v1pb.MemoRelation_REFERENCE
 	case store.MemoRelationComment:
 		return v1pb.MemoRelation_COMMENT
+	case store.MemoRelationEmbed:
+		return v1pb.MemoRelation_EMBED
 	default:
 		return v1pb.MemoRelation_TYPE_UNSPECIFIED
 	}
@@ -194,6 +204,8 @@ func convertMemoRelationTypeToStore(relationType v1pb.MemoRelation_Type) store.M
 	switch relationType {
 	case v1pb.MemoRelation_COMMENT:
 		return store.MemoRelationComment
+	case v1pb.MemoRelation_EMBED:
+		return store.MemoRelationEmbed
 	default:
 		return store.MemoRelationReference
 	}
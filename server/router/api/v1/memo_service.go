@@ -116,6 +116,18 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 		updatedTs := request.Memo.UpdateTime.AsTime().Unix()
 		create.UpdatedTs = updatedTs
 	}
+	if request.Template != "" {
+		createdAt := time.Now()
+		if create.CreatedTs != 0 {
+			createdAt = time.Unix(create.CreatedTs, 0)
+		}
+		content, visibility, err := s.instantiateMemoTemplate(ctx, user, request, createdAt)
+		if err != nil {
+			return nil, err
+		}
+		create.Content = content
+		create.Visibility = convertVisibilityToStore(visibility)
+	}
 
 	contentLengthLimit, err := s.getContentLengthLimit(ctx)
 	if err != nil {
@@ -143,6 +155,14 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 	if err != nil {
 		return nil, err
 	}
+	preparedRelations, err = s.syncWikiLinkRelations(ctx, create, "", preparedRelations)
+	if err != nil {
+		return nil, err
+	}
+	preparedRelations, err = s.syncEmbedRelations(ctx, create, preparedRelations)
+	if err != nil {
+		return nil, err
+	}
 
 	memo, err := s.Store.CreateMemo(ctx, create)
 	if err != nil {
@@ -198,7 +218,7 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 	}
 
 	if !isMentionNotificationSuppressed(ctx) {
-		s.dispatchMemoMentionNotificationsBestEffort(ctx, memo, nil, "")
+		s.dispatchMemoMentionNotificationsBestEffort(ctx, memo, nil, nil)
 	}
 
 	return memoMessage, nil
@@ -432,6 +452,13 @@ func (s *APIV1Service) GetMemo(ctx context.Context, request *v1pb.GetMemoRequest
 
 // UpdateMemo updates an existing memo.
 func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoRequest) (*v1pb.Memo, error) {
+	return s.updateMemo(ctx, request, nil)
+}
+
+// updateMemo applies an UpdateMemo request. When expectedContent is set, the
+// update is computed from that content and fails with Aborted if the memo
+// content changed since, up to the transaction that writes the memo.
+func (s *APIV1Service) updateMemo(ctx context.Context, request *v1pb.UpdateMemoRequest, expectedContent *string) (*v1pb.Memo, error) {
 	if request.Memo == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "memo is required")
 	}
@@ -450,6 +477,9 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 	if memo == nil {
 		return nil, status.Errorf(codes.NotFound, "memo not found")
 	}
+	if expectedContent != nil && memo.Content != *expectedContent {
+		return nil, status.Errorf(codes.Aborted, "memo content changed concurrently")
+	}
 
 	user, err := s.fetchCurrentUser(ctx)
 	if err != nil {
@@ -466,7 +496,10 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 	update := &store.UpdateMemo{
 		ID: memo.ID,
 	}
+	previousMemo := *memo
 	previousContent := memo.Content
+	wasPinned := memo.Pinned
+	wasArchived := memo.RowStatus == store.Archived
 	contentUpdated := false
 	attachmentsUpdated := false
 	relationsUpdated := false
@@ -551,6 +584,27 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 			return nil, err
 		}
 	}
+	if contentUpdated {
+		// Keep reference relations in step with the [[memo]] links in the content.
+		if !relationsUpdated {
+			preparedRelations, err = s.listMemoReferenceRelations(ctx, memo)
+			if err != nil {
+				return nil, err
+			}
+		}
+		preparedRelations, err = s.syncWikiLinkRelations(ctx, &nextMemo, previousContent, preparedRelations)
+		if err != nil {
+			return nil, err
+		}
+		relationsUpdated = true
+	}
+	if relationsUpdated {
+		// Replacing relations replaces embed relations too, so rebuild them.
+		preparedRelations, err = s.syncEmbedRelations(ctx, &nextMemo, preparedRelations)
+		if err != nil {
+			return nil, err
+		}
+	}
 	var requiredAttachmentIDs []int32
 	if contentUpdated || attachmentsUpdated {
 		var finalAttachments []*store.Attachment
@@ -573,7 +627,12 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 		if relationsUpdated {
 			relations = &preparedRelations
 		}
+		// The mutation rechecks the content read above, which is the expected
+		// content when one is given.
 		if err := s.applyMemoMutation(ctx, memo, preparedAttachments, update, requiredAttachmentIDs, relations); err != nil {
+			if expectedContent != nil && status.Code(err) == codes.FailedPrecondition {
+				return nil, status.Errorf(codes.Aborted, "memo content changed concurrently")
+			}
 			return nil, err
 		}
 	} else if err = s.Store.UpdateMemo(ctx, update); err != nil {
@@ -590,10 +649,22 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build updated memo state")
 	}
-	if contentUpdated {
-		s.dispatchMemoMentionNotificationsBestEffort(ctx, memo, parentMemo, previousContent)
+	// Newly added mentions notify their users, and so do mentions that become
+	// readable when the visibility widens.
+	if contentUpdated || memo.Visibility != previousMemo.Visibility {
+		s.dispatchMemoMentionNotificationsBestEffort(ctx, memo, parentMemo, &previousMemo)
 	}
 	s.dispatchMemoUpdatedSideEffects(ctx, memo, parentMemo, memoMessage)
+	if !wasPinned && memo.Pinned {
+		if err := s.DispatchMemoPinnedWebhook(ctx, memoMessage); err != nil {
+			slog.Warn("Failed to dispatch memo pinned webhook", slog.Any("err", err))
+		}
+	}
+	if !wasArchived && memo.RowStatus == store.Archived {
+		if err := s.DispatchMemoArchivedWebhook(ctx, memoMessage); err != nil {
+			slog.Warn("Failed to dispatch memo archived webhook", slog.Any("err", err))
+		}
+	}
 
 	return memoMessage, nil
 }
@@ -122,7 +122,7 @@ func (s *APIV1Service) CreateMemoComment(ctx context.Context, request *v1pb.Crea
 		slog.Warn("Failed to dispatch memo comment created webhook", slog.Any("err", err))
 	}
 
-	s.dispatchMemoMentionNotificationsBestEffort(ctx, memo, relatedMemo, "")
+	s.dispatchMemoMentionNotificationsBestEffort(ctx, memo, relatedMemo, nil)
 
 	// Broadcast live refresh event for the parent memo so subscribers see the new comment.
 	s.SSEHub.Broadcast(&SSEEvent{
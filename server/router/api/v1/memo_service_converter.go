@@ -47,6 +47,7 @@ func (s *APIV1Service) convertMemoFromStoreWithCreators(ctx context.Context, mem
 	if memo.Payload != nil {
 		memoMessage.Tags = memo.Payload.Tags
 		memoMessage.Property = convertMemoPropertyFromStore(memo.Payload.Property)
+		memoMessage.Properties = convertMemoPropertiesFromStore(memo.Payload.Properties)
 		memoMessage.Location = convertLocationFromStore(memo.Payload.Location)
 	}
 
@@ -73,7 +74,7 @@ func (s *APIV1Service) convertMemoFromStoreWithCreators(ctx context.Context, mem
 		memoMessage.Attachments = append(memoMessage.Attachments, attachmentResponse)
 	}
 
-	snippet, err := s.getMemoContentSnippet(memo.Content)
+	snippet, err := s.getMemoSnippetWithEmbeds(ctx, memo)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get memo content snippet")
 	}
@@ -324,6 +325,32 @@ func convertMemoPropertyFromStore(property *storepb.MemoPayload_Property) *v1pb.
 	}
 }
 
+func convertMemoPropertiesFromStore(properties map[string]*storepb.MemoPayload_PropertyValue) map[string]*v1pb.Memo_PropertyValue {
+	if len(properties) == 0 {
+		return nil
+	}
+	converted := make(map[string]*v1pb.Memo_PropertyValue, len(properties))
+	for key, property := range properties {
+		value := &v1pb.Memo_PropertyValue{}
+		switch kind := property.GetKind().(type) {
+		case *storepb.MemoPayload_PropertyValue_StringValue:
+			value.Kind = &v1pb.Memo_PropertyValue_StringValue{StringValue: kind.StringValue}
+		case *storepb.MemoPayload_PropertyValue_NumberValue:
+			value.Kind = &v1pb.Memo_PropertyValue_NumberValue{NumberValue: kind.NumberValue}
+		case *storepb.MemoPayload_PropertyValue_DateTs:
+			value.Kind = &v1pb.Memo_PropertyValue_DateValue{DateValue: timestamppb.New(time.Unix(kind.DateTs, 0))}
+		case *storepb.MemoPayload_PropertyValue_BoolValue:
+			value.Kind = &v1pb.Memo_PropertyValue_BoolValue{BoolValue: kind.BoolValue}
+		case *storepb.MemoPayload_PropertyValue_ListValue:
+			value.Kind = &v1pb.Memo_PropertyValue_ListValue{ListValue: &v1pb.Memo_StringList{Values: kind.ListValue.GetValues()}}
+		default:
+			continue
+		}
+		converted[key] = value
+	}
+	return converted
+}
+
 func convertLocationFromStore(location *storepb.MemoPayload_Location) *v1pb.Location {
 	if location == nil {
 		return nil
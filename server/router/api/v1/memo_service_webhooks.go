@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -9,40 +10,75 @@ import (
 
 	"github.com/usememos/memos/internal/webhook"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
 )
 
 // DispatchMemoCreatedWebhook dispatches a webhook when a memo is created.
 func (s *APIV1Service) DispatchMemoCreatedWebhook(ctx context.Context, memo *v1pb.Memo) error {
-	return s.dispatchMemoRelatedWebhook(ctx, memo, "memos.memo.created")
+	return s.dispatchMemoRelatedWebhook(ctx, memo, webhook.ActivityMemoCreated)
 }
 
 // DispatchMemoUpdatedWebhook dispatches webhook when memo is updated.
 func (s *APIV1Service) DispatchMemoUpdatedWebhook(ctx context.Context, memo *v1pb.Memo) error {
-	return s.dispatchMemoRelatedWebhook(ctx, memo, "memos.memo.updated")
+	return s.dispatchMemoRelatedWebhook(ctx, memo, webhook.ActivityMemoUpdated)
 }
 
 // DispatchMemoDeletedWebhook dispatches webhook when memo is deleted.
 func (s *APIV1Service) DispatchMemoDeletedWebhook(ctx context.Context, memo *v1pb.Memo) error {
-	return s.dispatchMemoRelatedWebhook(ctx, memo, "memos.memo.deleted")
+	return s.dispatchMemoRelatedWebhook(ctx, memo, webhook.ActivityMemoDeleted)
+}
+
+// DispatchMemoPinnedWebhook dispatches webhook when memo is pinned.
+func (s *APIV1Service) DispatchMemoPinnedWebhook(ctx context.Context, memo *v1pb.Memo) error {
+	return s.dispatchMemoRelatedWebhook(ctx, memo, webhook.ActivityMemoPinned)
+}
+
+// DispatchMemoArchivedWebhook dispatches webhook when memo is archived.
+func (s *APIV1Service) DispatchMemoArchivedWebhook(ctx context.Context, memo *v1pb.Memo) error {
+	return s.dispatchMemoRelatedWebhook(ctx, memo, webhook.ActivityMemoArchived)
 }
 
 // DispatchMemoCommentCreatedWebhook dispatches webhook to the related memo owner when a comment is created.
 func (s *APIV1Service) DispatchMemoCommentCreatedWebhook(ctx context.Context, commentMemo *v1pb.Memo, relatedMemoCreatorID int32) error {
-	webhooks, err := s.Store.GetUserWebhooks(ctx, relatedMemoCreatorID)
+	payload, err := convertMemoToWebhookPayload(commentMemo)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "failed to convert memo to webhook payload")
 	}
-	for _, hook := range webhooks {
-		payload, err := convertMemoToWebhookPayload(commentMemo)
-		if err != nil {
-			return errors.Wrap(err, "failed to convert memo to webhook payload")
-		}
-		payload.ActivityType = "memos.memo.comment.created"
-		payload.URL = hook.Url
-		payload.SigningSecret = hook.SigningSecret
-		webhook.PostAsync(payload)
+	payload.ActivityType = webhook.ActivityMemoCommentCreated
+	memoUID, err := ExtractMemoUIDFromName(commentMemo.Name)
+	if err != nil {
+		return errors.Wrap(err, "invalid memo name")
 	}
-	return nil
+	return s.dispatchWebhookEvent(ctx, relatedMemoCreatorID, memoUID, payload)
+}
+
+// DispatchReactionWebhook dispatches webhook to the memo owner when a reaction is upserted or deleted.
+func (s *APIV1Service) DispatchReactionWebhook(ctx context.Context, memo *store.Memo, reaction *v1pb.Reaction, activityType string) error {
+	return s.dispatchWebhookEvent(ctx, memo.CreatorID, memo.UID, &webhook.WebhookRequestPayload{
+		ActivityType: activityType,
+		Creator:      reaction.Creator,
+		Reaction:     reaction,
+	})
+}
+
+// DispatchMemoShareCreatedWebhook dispatches webhook to the memo owner when a share link is created.
+func (s *APIV1Service) DispatchMemoShareCreatedWebhook(ctx context.Context, memo *store.Memo, share *v1pb.MemoShare, creator string) error {
+	return s.dispatchWebhookEvent(ctx, memo.CreatorID, memo.UID, &webhook.WebhookRequestPayload{
+		ActivityType: webhook.ActivityShareCreated,
+		Creator:      creator,
+		MemoShare:    share,
+	})
+}
+
+// DispatchAttachmentCreatedWebhook dispatches webhook to the uploader when an attachment is created.
+// The webhook filter is evaluated against the memo the attachment belongs to, if any.
+func (s *APIV1Service) DispatchAttachmentCreatedWebhook(ctx context.Context, creatorID int32, memoUID string, attachment *v1pb.Attachment, creator string) error {
+	return s.dispatchWebhookEvent(ctx, creatorID, memoUID, &webhook.WebhookRequestPayload{
+		ActivityType: webhook.ActivityAttachmentCreated,
+		Creator:      creator,
+		Attachment:   attachment,
+	})
 }
 
 func (s *APIV1Service) dispatchMemoRelatedWebhook(ctx context.Context, memo *v1pb.Memo, activityType string) error {
@@ -53,22 +89,74 @@ func (s *APIV1Service) dispatchMemoRelatedWebhook(ctx context.Context, memo *v1p
 	if creator == nil {
 		return status.Errorf(codes.NotFound, "memo creator not found")
 	}
-	creatorID := creator.ID
-	webhooks, err := s.Store.GetUserWebhooks(ctx, creatorID)
+	memoUID, err := ExtractMemoUIDFromName(memo.Name)
+	if err != nil {
+		return errors.Wrap(err, "invalid memo name")
+	}
+	payload, err := convertMemoToWebhookPayload(memo)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert memo to webhook payload")
+	}
+	payload.ActivityType = activityType
+	return s.dispatchWebhookEvent(ctx, creator.ID, memoUID, payload)
+}
+
+// dispatchWebhookEvent queues the payload for every webhook of ownerID that
+// subscribes to its activity type and whose filter matches the memo with memoUID.
+func (s *APIV1Service) dispatchWebhookEvent(ctx context.Context, ownerID int32, memoUID string, payload *webhook.WebhookRequestPayload) error {
+	webhooks, err := s.Store.GetUserWebhooks(ctx, ownerID)
 	if err != nil {
 		return err
 	}
 	for _, hook := range webhooks {
-		payload, err := convertMemoToWebhookPayload(memo)
-		if err != nil {
-			return errors.Wrap(err, "failed to convert memo to webhook payload")
+		if hook.Disabled || !webhook.Subscribes(hook.EventTypes, payload.ActivityType) {
+			continue
 		}
-		payload.ActivityType = activityType
-		payload.URL = hook.Url
-		payload.SigningSecret = hook.SigningSecret
+		if hook.Filter != "" {
+			matched, err := s.memoMatchesWebhookFilter(ctx, memoUID, hook.Filter)
+			if err != nil {
+				slog.Warn("failed to evaluate webhook filter", slog.String("webhook", hook.Id), slog.Any("err", err))
+				continue
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		hookPayload := *payload
+		hookPayload.URL = hook.Url
+		if err := s.enqueueWebhookDelivery(ctx, ownerID, hook, &hookPayload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memoMatchesWebhookFilter evaluates a webhook filter against the memo with
+// memoUID. Events without a memo never match a filter.
+func (s *APIV1Service) memoMatchesWebhookFilter(ctx context.Context, memoUID string, filter string) (bool, error) {
+	if memoUID == "" {
+		return false, nil
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+		UID:            &memoUID,
+		ExcludeContent: true,
+		Filters:        []string{filter},
+	})
+	if err != nil {
+		return false, err
+	}
+	return memo != nil, nil
+}
 
-		// Use asynchronous webhook dispatch
-		webhook.PostAsync(payload)
+// enqueueWebhookDelivery queues the payload for delivery in the background.
+// The runner signs the request with the webhook's current secret.
+func (s *APIV1Service) enqueueWebhookDelivery(ctx context.Context, creatorID int32, hook *storepb.WebhooksUserSetting_Webhook, payload *webhook.WebhookRequestPayload) error {
+	if s.WebhookDeliveryRunner == nil {
+		return nil
+	}
+	if _, err := s.WebhookDeliveryRunner.Enqueue(ctx, creatorID, hook, payload); err != nil {
+		return errors.Wrap(err, "failed to enqueue webhook delivery")
 	}
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	stderrors "errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -70,7 +71,11 @@ func (s *APIV1Service) CreateMemoShare(ctx context.Context, request *v1pb.Create
 		return nil, status.Errorf(codes.Internal, "failed to create memo share")
 	}
 
-	return convertMemoShareFromStore(ms, memo.UID), nil
+	share := convertMemoShareFromStore(ms, memo.UID)
+	if err := s.DispatchMemoShareCreatedWebhook(ctx, memo, share, BuildUserName(user.Username)); err != nil {
+		slog.Warn("Failed to dispatch memo share created webhook", slog.Any("err", err))
+	}
+	return share, nil
 }
 
 // ListMemoShares lists all share links for a memo.
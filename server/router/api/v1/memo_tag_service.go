@@ -0,0 +1,238 @@
+package v1
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/memopayload"
+	"github.com/usememos/memos/store"
+)
+
+// tagRewrite returns the replacement of a tag, or false for tags it leaves
+// alone. An empty replacement deletes the tag.
+type tagRewrite func(tag string) (string, bool)
+
+func (s *APIV1Service) RenameTag(ctx context.Context, request *v1pb.RenameTagRequest) (*v1pb.RenameTagResponse, error) {
+	if err := s.validateTagName(request.Tag); err != nil {
+		return nil, err
+	}
+	if err := s.validateTagName(request.NewTag); err != nil {
+		return nil, err
+	}
+	if request.Tag == request.NewTag {
+		return nil, status.Errorf(codes.InvalidArgument, "new tag must differ from tag")
+	}
+
+	memos, err := s.rewriteTags(ctx, []string{request.Tag}, request.AllUsers, func(tag string) (string, bool) {
+		return moveTag(tag, request.Tag, request.NewTag)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.RenameTagResponse{Memos: memos}, nil
+}
+
+func (s *APIV1Service) MergeTags(ctx context.Context, request *v1pb.MergeTagsRequest) (*v1pb.MergeTagsResponse, error) {
+	if err := s.validateTagName(request.TargetTag); err != nil {
+		return nil, err
+	}
+	var sources []string
+	for _, tag := range request.Tags {
+		if err := s.validateTagName(tag); err != nil {
+			return nil, err
+		}
+		if tag != request.TargetTag && !slices.Contains(sources, tag) {
+			sources = append(sources, tag)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one tag other than the target tag is required")
+	}
+	// The most specific source wins, so merging both proj and proj/alpha
+	// moves proj/alpha/docs by its closest ancestor.
+	slices.SortFunc(sources, func(a, b string) int { return len(b) - len(a) })
+
+	memos, err := s.rewriteTags(ctx, sources, request.AllUsers, func(tag string) (string, bool) {
+		for _, source := range sources {
+			if moved, ok := moveTag(tag, source, request.TargetTag); ok {
+				return moved, true
+			}
+		}
+		return "", false
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.MergeTagsResponse{Memos: memos}, nil
+}
+
+func (s *APIV1Service) DeleteTag(ctx context.Context, request *v1pb.DeleteTagRequest) (*v1pb.DeleteTagResponse, error) {
+	if err := s.validateTagName(request.Tag); err != nil {
+		return nil, err
+	}
+
+	memos, err := s.rewriteTags(ctx, []string{request.Tag}, request.AllUsers, func(tag string) (string, bool) {
+		if _, ok := moveTag(tag, request.Tag, ""); ok {
+			return "", true
+		}
+		return "", false
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.DeleteTagResponse{Memos: memos}, nil
+}
+
+// validateTagName checks that tag is written without the leading # and reads
+// back as exactly that tag, so a rewrite never produces a different tag.
+func (s *APIV1Service) validateTagName(tag string) error {
+	if tag == "" || strings.HasPrefix(tag, "#") {
+		return status.Errorf(codes.InvalidArgument, "invalid tag %q: a tag name without the leading # is required", tag)
+	}
+	tags, err := s.MarkdownService.ExtractTags([]byte("#" + tag))
+	if err != nil || len(tags) == 0 || tags[len(tags)-1] != tag {
+		return status.Errorf(codes.InvalidArgument, "invalid tag %q", tag)
+	}
+	return nil
+}
+
+// moveTag moves tag from under one tag to another: from itself becomes to,
+// and its descendants keep their path below it.
+func moveTag(tag, from, to string) (string, bool) {
+	if tag == from {
+		return to, true
+	}
+	if strings.HasPrefix(tag, from+"/") {
+		return to + strings.TrimPrefix(tag, from), true
+	}
+	return "", false
+}
+
+// rewriteTags applies rewrite to the tags of the caller's memos, or every
+// user's memos with allUsers, and to the matching tag metadata keys. All
+// changes are stored in one transaction; the updated memo names are returned.
+func (s *APIV1Service) rewriteTags(ctx context.Context, sources []string, allUsers bool, rewrite tagRewrite) ([]string, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if allUsers && !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins can rewrite tags of all users")
+	}
+
+	// Memo tag sets include every ancestor, so membership of a source also
+	// finds memos that only use its descendants.
+	conditions := make([]string, 0, len(sources))
+	for _, source := range sources {
+		conditions = append(conditions, strconv.Quote(source)+" in tags")
+	}
+	find := &store.FindMemo{Filters: []string{strings.Join(conditions, " || ")}}
+	if !allUsers {
+		find.CreatorID = &user.ID
+	}
+	memos, err := s.Store.ListMemos(ctx, find)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+
+	mutation := &store.TagMutation{}
+	for _, memo := range memos {
+		content, err := s.MarkdownService.RewriteTags([]byte(memo.Content), rewrite)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to rewrite tags of memo %s: %v", memo.UID, err)
+		}
+		if content == memo.Content {
+			continue
+		}
+		next := *memo
+		next.Content = content
+		next.Payload = proto.CloneOf(memo.Payload)
+		if err := memopayload.RebuildMemoPayload(ctx, &next, s.MarkdownService); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
+		}
+		mutation.Memos = append(mutation.Memos, &store.MemoTagRewrite{
+			MemoID:          memo.ID,
+			ExpectedContent: memo.Content,
+			Content:         next.Content,
+			Payload:         next.Payload,
+		})
+	}
+
+	findSetting := &store.FindUserSetting{Key: storepb.UserSetting_TAGS}
+	if !allUsers {
+		findSetting.UserID = &user.ID
+	}
+	settings, err := s.Store.ListUserSettings(ctx, findSetting)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tag settings: %v", err)
+	}
+	for _, setting := range settings {
+		if tags, changed := rewriteTagMetadata(setting.GetTags().GetTags(), rewrite); changed {
+			mutation.UserSettings = append(mutation.UserSettings, &storepb.UserSetting{
+				UserId: setting.UserId,
+				Key:    storepb.UserSetting_TAGS,
+				Value:  &storepb.UserSetting_Tags{Tags: &storepb.TagsUserSetting{Tags: tags}},
+			})
+		}
+	}
+
+	if len(mutation.Memos) == 0 && len(mutation.UserSettings) == 0 {
+		return []string{}, nil
+	}
+	if err := s.Store.ApplyTagMutation(ctx, mutation); err != nil {
+		if stderrors.Is(err, store.ErrMemoMutationConflict) {
+			return nil, status.Errorf(codes.FailedPrecondition, "memo state changed: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to rewrite tags: %v", err)
+	}
+
+	names := make([]string, 0, len(mutation.Memos))
+	for _, rewrite := range mutation.Memos {
+		memo, parentMemo, memoMessage, err := s.buildUpdatedMemoState(ctx, rewrite.MemoID)
+		if err != nil {
+			slog.Warn("Failed to build updated memo state after tag rewrite", slog.Int("memo", int(rewrite.MemoID)), slog.Any("err", err))
+			continue
+		}
+		s.dispatchMemoUpdatedSideEffects(ctx, memo, parentMemo, memoMessage)
+		names = append(names, memoMessage.Name)
+	}
+	return names, nil
+}
+
+// rewriteTagMetadata moves the metadata of rewritten tags to their new keys
+// and drops the metadata of deleted ones. Keys are matched literally, so a
+// pattern such as proj/.* follows a rename of proj. Metadata already stored
+// under a target key wins over moved metadata.
+func rewriteTagMetadata(tags map[string]*storepb.UserTagMetadata, rewrite tagRewrite) (map[string]*storepb.UserTagMetadata, bool) {
+	result := make(map[string]*storepb.UserTagMetadata, len(tags))
+	var moved []string
+	for key, metadata := range tags {
+		if _, ok := rewrite(key); ok {
+			moved = append(moved, key)
+			continue
+		}
+		result[key] = metadata
+	}
+	// Sorted keys make the metadata kept for merged tags deterministic.
+	slices.Sort(moved)
+	for _, key := range moved {
+		replacement, _ := rewrite(key)
+		if _, exists := result[replacement]; replacement != "" && !exists {
+			result[replacement] = tags[key]
+		}
+	}
+	return result, len(moved) > 0
+}
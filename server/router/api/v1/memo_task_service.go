@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/markdown"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// ListTasks lists the tasks of the memos visible to the caller.
+func (s *APIV1Service) ListTasks(ctx context.Context, request *v1pb.ListTasksRequest) (*v1pb.ListTasksResponse, error) {
+	filter := "has_incomplete_tasks"
+	if request.ShowCompleted {
+		filter = "has_task_list"
+	}
+	if request.Filter != "" {
+		filter = fmt.Sprintf("(%s) && %s", request.Filter, filter)
+	}
+
+	// ListMemos applies the caller's visibility rules and pages over memos.
+	response, err := s.ListMemos(ctx, &v1pb.ListMemosRequest{
+		PageSize:  request.PageSize,
+		PageToken: request.PageToken,
+		Filter:    filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Memos) == 0 {
+		return &v1pb.ListTasksResponse{Tasks: []*v1pb.Task{}, NextPageToken: response.NextPageToken}, nil
+	}
+
+	uids := make([]string, 0, len(response.Memos))
+	for _, memo := range response.Memos {
+		uid, err := ExtractMemoUIDFromName(memo.Name)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid memo name: %v", err)
+		}
+		uids = append(uids, uid)
+	}
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{UIDList: uids, ExcludeContent: true})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+	memosByUID := make(map[string]*store.Memo, len(memos))
+	for _, memo := range memos {
+		memosByUID[memo.UID] = memo
+	}
+
+	tasks := []*v1pb.Task{}
+	for _, uid := range uids {
+		memo, ok := memosByUID[uid]
+		if !ok {
+			continue
+		}
+		for _, task := range memo.Payload.GetTasks() {
+			if task.Checked && !request.ShowCompleted {
+				continue
+			}
+			tasks = append(tasks, convertTaskFromStore(memo.UID, task))
+		}
+	}
+	return &v1pb.ListTasksResponse{
+		Tasks:         tasks,
+		NextPageToken: response.NextPageToken,
+	}, nil
+}
+
+// SetTaskState checks or unchecks a task by rewriting its checkbox in the
+// memo content. It fails with Aborted when the memo is edited concurrently.
+func (s *APIV1Service) SetTaskState(ctx context.Context, request *v1pb.SetTaskStateRequest) (*v1pb.Task, error) {
+	memoUID, taskID, err := ExtractMemoTaskIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid task name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if !canModifyMemo(user, memo) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	content, err := s.MarkdownService.SetTaskState([]byte(memo.Content), taskID, request.Checked)
+	if err != nil {
+		if errors.Is(err, markdown.ErrTaskNotFound) {
+			return nil, status.Errorf(codes.NotFound, "task not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to set task state: %v", err)
+	}
+	if content != memo.Content {
+		// The update rebuilds the payload and notifies webhooks and listeners.
+		// The task offset was found in the content read above, so a concurrent
+		// edit aborts the update instead of being overwritten.
+		if _, err := s.updateMemo(ctx, &v1pb.UpdateMemoRequest{
+			Memo:       &v1pb.Memo{Name: buildMemoName(memo.UID), Content: content},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+		}, &memo.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := s.MarkdownService.ExtractAll([]byte(content))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to extract tasks: %v", err)
+	}
+	for _, task := range data.Tasks {
+		if task.Id == taskID {
+			return convertTaskFromStore(memo.UID, task), nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "task not found")
+}
+
+func convertTaskFromStore(memoUID string, task *storepb.MemoPayload_Task) *v1pb.Task {
+	result := &v1pb.Task{
+		Name:     fmt.Sprintf("%s%s/%s%s", MemoNamePrefix, memoUID, TaskNamePrefix, task.Id),
+		Text:     task.Text,
+		Checked:  task.Checked,
+		Priority: v1pb.Task_Priority(task.Priority),
+	}
+	if task.DueTs != 0 {
+		result.DueTime = timestamppb.New(time.Unix(task.DueTs, 0))
+	}
+	return result
+}
@@ -0,0 +1,400 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/usememos/memos/internal/util"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// memoTemplateCursorVariable is the placeholder filled with the request memo content.
+const memoTemplateCursorVariable = "cursor"
+
+// memoTemplateVariablePattern matches a {{variable}} placeholder.
+var memoTemplateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// resolveMemoTemplateParent resolves the owner of a memo template collection.
+// It returns a nil user for the instance-wide collection.
+func (s *APIV1Service) resolveMemoTemplateParent(ctx context.Context, parent string) (*store.User, error) {
+	if parent == InstanceMemoTemplateParent {
+		return nil, nil
+	}
+	user, err := ResolveUserByName(ctx, s.Store, parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo template parent: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	return user, nil
+}
+
+// Helper function to extract the owner and memo template ID from a memo template resource name.
+// Format: users/{user}/templates/{template} or instance/templates/{template}.
+// The owner is nil for instance-wide templates.
+func (s *APIV1Service) extractMemoTemplateOwnerAndIDFromName(ctx context.Context, name string) (*store.User, string, error) {
+	parts := strings.Split(name, "/")
+	var user *store.User
+	switch {
+	case len(parts) == 3 && parts[0] == InstanceMemoTemplateParent && parts[1] == "templates":
+	case len(parts) == 4 && parts[0] == "users" && parts[2] == "templates":
+		var err error
+		user, err = ResolveUserByName(ctx, s.Store, BuildUserName(parts[1]))
+		if err != nil {
+			return nil, "", err
+		}
+		if user == nil {
+			return nil, "", errors.Errorf("user not found: %s", parts[1])
+		}
+	default:
+		return nil, "", errors.Errorf("invalid memo template name format: %s", name)
+	}
+
+	memoTemplateID := parts[len(parts)-1]
+	if memoTemplateID == "" {
+		return nil, "", errors.Errorf("empty memo template ID in name: %s", name)
+	}
+	return user, memoTemplateID, nil
+}
+
+// Helper function to construct a memo template resource name.
+func constructMemoTemplateName(user *store.User, memoTemplateID string) string {
+	if user == nil {
+		return fmt.Sprintf("%s/templates/%s", InstanceMemoTemplateParent, memoTemplateID)
+	}
+	return fmt.Sprintf("%s/templates/%s", BuildUserName(user.Username), memoTemplateID)
+}
+
+func convertMemoTemplateFromStore(user *store.User, memoTemplate *storepb.MemoTemplate) *v1pb.MemoTemplate {
+	return &v1pb.MemoTemplate{
+		Name:       constructMemoTemplateName(user, memoTemplate.GetId()),
+		Title:      memoTemplate.GetTitle(),
+		Content:    memoTemplate.GetContent(),
+		Tags:       memoTemplate.GetTags(),
+		Visibility: convertVisibilityFromStore(store.Visibility(memoTemplate.GetVisibility())),
+	}
+}
+
+func convertMemoTemplateVisibilityToStore(visibility v1pb.Visibility) string {
+	if visibility == v1pb.Visibility_VISIBILITY_UNSPECIFIED {
+		return ""
+	}
+	return convertVisibilityToStore(visibility).String()
+}
+
+// memoTemplateOwnerID returns the store owner key of a memo template collection.
+func memoTemplateOwnerID(user *store.User) *int32 {
+	if user == nil {
+		return nil
+	}
+	return &user.ID
+}
+
+// authorizeMemoTemplateAccess asserts that the caller may access the memo templates
+// of the owner. Users read and write their own templates; instance-wide templates
+// are readable by every user and writable by admins.
+func (s *APIV1Service) authorizeMemoTemplateAccess(ctx context.Context, owner *store.User, write bool) error {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if owner == nil {
+		if write && !isSuperUser(currentUser) {
+			return status.Errorf(codes.PermissionDenied, "permission denied")
+		}
+		return nil
+	}
+	if currentUser.ID != owner.ID {
+		return status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	return nil
+}
+
+// ListMemoTemplates lists the memo templates of a user followed by the instance-wide
+// templates, or only the instance-wide templates.
+func (s *APIV1Service) ListMemoTemplates(ctx context.Context, request *v1pb.ListMemoTemplatesRequest) (*v1pb.ListMemoTemplatesResponse, error) {
+	user, err := s.resolveMemoTemplateParent(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeMemoTemplateAccess(ctx, user, false); err != nil {
+		return nil, err
+	}
+
+	memoTemplates := []*v1pb.MemoTemplate{}
+	if user != nil {
+		userMemoTemplates, err := s.Store.ListMemoTemplates(ctx, &user.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get memo templates: %v", err)
+		}
+		for _, memoTemplate := range userMemoTemplates {
+			memoTemplates = append(memoTemplates, convertMemoTemplateFromStore(user, memoTemplate))
+		}
+	}
+	instanceMemoTemplates, err := s.Store.ListMemoTemplates(ctx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get instance memo templates: %v", err)
+	}
+	for _, memoTemplate := range instanceMemoTemplates {
+		memoTemplates = append(memoTemplates, convertMemoTemplateFromStore(nil, memoTemplate))
+	}
+
+	return &v1pb.ListMemoTemplatesResponse{
+		MemoTemplates: memoTemplates,
+	}, nil
+}
+
+// GetMemoTemplate returns a memo template by resource name.
+func (s *APIV1Service) GetMemoTemplate(ctx context.Context, request *v1pb.GetMemoTemplateRequest) (*v1pb.MemoTemplate, error) {
+	user, memoTemplate, err := s.getMemoTemplate(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return convertMemoTemplateFromStore(user, memoTemplate), nil
+}
+
+// getMemoTemplate resolves a memo template the caller can read.
+func (s *APIV1Service) getMemoTemplate(ctx context.Context, name string) (*store.User, *storepb.MemoTemplate, error) {
+	user, memoTemplateID, err := s.extractMemoTemplateOwnerAndIDFromName(ctx, name)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid memo template name: %v", err)
+	}
+	if err := s.authorizeMemoTemplateAccess(ctx, user, false); err != nil {
+		return nil, nil, err
+	}
+
+	memoTemplate, err := s.Store.GetMemoTemplate(ctx, memoTemplateOwnerID(user), memoTemplateID)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get memo template: %v", err)
+	}
+	if memoTemplate == nil {
+		return nil, nil, status.Errorf(codes.NotFound, "memo template not found")
+	}
+	return user, memoTemplate, nil
+}
+
+// CreateMemoTemplate creates a memo template for a user or the instance.
+func (s *APIV1Service) CreateMemoTemplate(ctx context.Context, request *v1pb.CreateMemoTemplateRequest) (*v1pb.MemoTemplate, error) {
+	user, err := s.resolveMemoTemplateParent(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeMemoTemplateAccess(ctx, user, true); err != nil {
+		return nil, err
+	}
+
+	newMemoTemplate := &storepb.MemoTemplate{
+		Id:         util.GenUUID(),
+		Title:      request.GetMemoTemplate().GetTitle(),
+		Content:    request.GetMemoTemplate().GetContent(),
+		Visibility: convertMemoTemplateVisibilityToStore(request.GetMemoTemplate().GetVisibility()),
+	}
+	if newMemoTemplate.Title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "title is required")
+	}
+	if newMemoTemplate.Content == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "content is required")
+	}
+	newMemoTemplate.Tags, err = s.normalizeMemoTemplateTags(request.GetMemoTemplate().GetTags())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tags: %v", err)
+	}
+	if request.ValidateOnly {
+		return convertMemoTemplateFromStore(user, newMemoTemplate), nil
+	}
+
+	if err := s.Store.AddMemoTemplate(ctx, memoTemplateOwnerID(user), newMemoTemplate); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create memo template: %v", err)
+	}
+
+	return convertMemoTemplateFromStore(user, newMemoTemplate), nil
+}
+
+// UpdateMemoTemplate updates the selected fields of a memo template.
+func (s *APIV1Service) UpdateMemoTemplate(ctx context.Context, request *v1pb.UpdateMemoTemplateRequest) (*v1pb.MemoTemplate, error) {
+	user, memoTemplateID, err := s.extractMemoTemplateOwnerAndIDFromName(ctx, request.GetMemoTemplate().GetName())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo template name: %v", err)
+	}
+	if err := s.authorizeMemoTemplateAccess(ctx, user, true); err != nil {
+		return nil, err
+	}
+	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "update mask is required")
+	}
+
+	update := &store.UpdateMemoTemplate{}
+	for _, field := range request.UpdateMask.Paths {
+		switch field {
+		case "title":
+			if request.GetMemoTemplate().GetTitle() == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "title is required")
+			}
+			value := request.GetMemoTemplate().GetTitle()
+			update.Title = &value
+		case "content":
+			if request.GetMemoTemplate().GetContent() == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "content is required")
+			}
+			value := request.GetMemoTemplate().GetContent()
+			update.Content = &value
+		case "tags":
+			tags, err := s.normalizeMemoTemplateTags(request.GetMemoTemplate().GetTags())
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid tags: %v", err)
+			}
+			update.Tags = tags
+		case "visibility":
+			value := convertMemoTemplateVisibilityToStore(request.GetMemoTemplate().GetVisibility())
+			update.Visibility = &value
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported update mask path: %s", field)
+		}
+	}
+
+	updatedMemoTemplate, err := s.Store.UpdateMemoTemplate(ctx, memoTemplateOwnerID(user), memoTemplateID, update)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update memo template: %v", err)
+	}
+	if updatedMemoTemplate == nil {
+		return nil, status.Errorf(codes.NotFound, "memo template not found")
+	}
+
+	return convertMemoTemplateFromStore(user, updatedMemoTemplate), nil
+}
+
+// DeleteMemoTemplate deletes a memo template by resource name.
+func (s *APIV1Service) DeleteMemoTemplate(ctx context.Context, request *v1pb.DeleteMemoTemplateRequest) (*emptypb.Empty, error) {
+	user, memoTemplateID, err := s.extractMemoTemplateOwnerAndIDFromName(ctx, request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo template name: %v", err)
+	}
+	if err := s.authorizeMemoTemplateAccess(ctx, user, true); err != nil {
+		return nil, err
+	}
+
+	found, err := s.Store.RemoveMemoTemplate(ctx, memoTemplateOwnerID(user), memoTemplateID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete memo template: %v", err)
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "memo template not found")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// normalizeMemoTemplateTags strips the leading "#" of each tag, drops duplicates and
+// rejects values that do not read back as a single tag.
+func (s *APIV1Service) normalizeMemoTemplateTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		extracted, err := s.MarkdownService.ExtractTags([]byte("#" + tag))
+		if err != nil {
+			return nil, err
+		}
+		if tag == "" || !slices.Contains(extracted, tag) {
+			return nil, errors.Errorf("%q is not a tag", tag)
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+// instantiateMemoTemplate builds the content and visibility of a memo created from the
+// template named in the request. The request memo content fills the {{cursor}}
+// placeholder, or follows the template when it has none.
+func (s *APIV1Service) instantiateMemoTemplate(ctx context.Context, user *store.User, request *v1pb.CreateMemoRequest, createdAt time.Time) (string, v1pb.Visibility, error) {
+	_, memoTemplate, err := s.getMemoTemplate(ctx, request.Template)
+	if err != nil {
+		return "", v1pb.Visibility_VISIBILITY_UNSPECIFIED, err
+	}
+	var location *time.Location
+	if request.TimeZone != "" {
+		location, err = time.LoadLocation(request.TimeZone)
+		if err != nil {
+			return "", v1pb.Visibility_VISIBILITY_UNSPECIFIED, status.Errorf(codes.InvalidArgument, "invalid time zone: %v", err)
+		}
+	} else {
+		location, err = s.getUserLocation(ctx, user.ID)
+		if err != nil {
+			return "", v1pb.Visibility_VISIBILITY_UNSPECIFIED, status.Errorf(codes.Internal, "failed to get user time zone: %v", err)
+		}
+	}
+	createdAt = createdAt.In(location)
+	nickname := user.Nickname
+	if nickname == "" {
+		nickname = user.Username
+	}
+	variables := map[string]string{
+		"date":          createdAt.Format(time.DateOnly),
+		"time":          createdAt.Format("15:04"),
+		"datetime":      createdAt.Format("2006-01-02 15:04"),
+		"weekday":       createdAt.Weekday().String(),
+		"user.username": user.Username,
+		"user.nickname": nickname,
+	}
+
+	body := request.GetMemo().GetContent()
+	cursorFilled := false
+	content := memoTemplateVariablePattern.ReplaceAllStringFunc(memoTemplate.GetContent(), func(placeholder string) string {
+		name := memoTemplateVariablePattern.FindStringSubmatch(placeholder)[1]
+		if name == memoTemplateCursorVariable {
+			if cursorFilled {
+				return ""
+			}
+			cursorFilled = true
+			return body
+		}
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		if value, ok := request.TemplateVariables[name]; ok {
+			return value
+		}
+		return placeholder
+	})
+	content = strings.TrimSpace(content)
+	if !cursorFilled && strings.TrimSpace(body) != "" {
+		content += "\n\n" + strings.TrimSpace(body)
+	}
+
+	// Append the template tags the content does not carry yet.
+	existingTags, err := s.MarkdownService.ExtractTags([]byte(content))
+	if err != nil {
+		return "", v1pb.Visibility_VISIBILITY_UNSPECIFIED, status.Errorf(codes.Internal, "failed to extract tags: %v", err)
+	}
+	missingTags := []string{}
+	for _, tag := range memoTemplate.GetTags() {
+		if !slices.Contains(existingTags, tag) {
+			missingTags = append(missingTags, "#"+tag)
+		}
+	}
+	if len(missingTags) > 0 {
+		content += "\n\n" + strings.Join(missingTags, " ")
+	}
+
+	visibility := request.GetMemo().GetVisibility()
+	if visibility == v1pb.Visibility_VISIBILITY_UNSPECIFIED && memoTemplate.GetVisibility() != "" {
+		visibility = convertVisibilityFromStore(store.Visibility(memoTemplate.GetVisibility()))
+	}
+	return content, visibility, nil
+}
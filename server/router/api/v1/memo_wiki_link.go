@@ -0,0 +1,210 @@
+package v1
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/memolink"
+	"github.com/usememos/memos/store"
+)
+
+// ListMemoBacklinks lists the memos that reference the given memo.
+func (s *APIV1Service) ListMemoBacklinks(ctx context.Context, request *v1pb.ListMemoBacklinksRequest) (*v1pb.ListMemoBacklinksResponse, error) {
+	memoUID, err := ExtractMemoUIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo")
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	if err := s.checkMemoReadAccess(ctx, memo); err != nil {
+		return nil, err
+	}
+
+	// ListMemos applies the caller's visibility rules to the referencing memos.
+	response, err := s.ListMemos(ctx, &v1pb.ListMemosRequest{
+		PageSize:  request.PageSize,
+		PageToken: request.PageToken,
+		Filter:    fmt.Sprintf("%s in references", strconv.Quote(buildMemoName(memo.UID))),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.ListMemoBacklinksResponse{
+		Memos:         response.Memos,
+		NextPageToken: response.NextPageToken,
+	}, nil
+}
+
+// syncWikiLinkRelations updates the reference relations of memo to follow the
+// [[memo]] links in its content. Links present in previousContent but gone
+// from memo.Content drop their relation; new links add one. Relations that
+// were never backed by a link are kept.
+func (s *APIV1Service) syncWikiLinkRelations(ctx context.Context, memo *store.Memo, previousContent string, relations []*store.MemoRelation) ([]*store.MemoRelation, error) {
+	previousTargets, err := s.resolveWikiLinkTargets(ctx, memo, previousContent)
+	if err != nil {
+		return nil, err
+	}
+	currentTargets, err := s.resolveWikiLinkTargets(ctx, memo, memo.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	synced := make([]*store.MemoRelation, 0, len(relations)+len(currentTargets))
+	seen := make(map[int32]struct{}, len(relations)+len(currentTargets))
+	for _, relation := range relations {
+		if relation.Type == store.MemoRelationReference {
+			_, wasLinked := previousTargets[relation.RelatedMemoID]
+			_, isLinked := currentTargets[relation.RelatedMemoID]
+			if wasLinked && !isLinked {
+				continue
+			}
+			seen[relation.RelatedMemoID] = struct{}{}
+		}
+		synced = append(synced, relation)
+	}
+	for _, target := range orderedWikiLinkTargets(currentTargets) {
+		if _, ok := seen[target.ID]; ok {
+			continue
+		}
+		seen[target.ID] = struct{}{}
+		synced = append(synced, &store.MemoRelation{
+			MemoID:        memo.ID,
+			RelatedMemoID: target.ID,
+			Type:          store.MemoRelationReference,
+		})
+	}
+	return synced, nil
+}
+
+// listMemoReferenceRelations returns the stored reference relations of memo.
+func (s *APIV1Service) listMemoReferenceRelations(ctx context.Context, memo *store.Memo) ([]*store.MemoRelation, error) {
+	referenceType := store.MemoRelationReference
+	relations, err := s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
+		MemoID: &memo.ID,
+		Type:   &referenceType,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memo relations")
+	}
+	return relations, nil
+}
+
+// resolveWikiLinkTargets resolves the [[memo]] links in content to memos the
+// creator of memo can read. A link resolves to the memo with that UID, or else
+// to the creator's newest memo whose title matches the link case-insensitively.
+// Links that resolve to nothing, or to memo itself, are ignored.
+func (s *APIV1Service) resolveWikiLinkTargets(ctx context.Context, memo *store.Memo, content string) (map[int32]*store.Memo, error) {
+	targets := make(map[int32]*store.Memo)
+	if content == "" {
+		return targets, nil
+	}
+
+	data, err := s.MarkdownService.ExtractAll([]byte(content))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract wiki links")
+	}
+	for _, link := range data.WikiLinks {
+		target, err := s.resolveWikiLinkTarget(ctx, memo, link)
+		if err != nil {
+			return nil, err
+		}
+		if target == nil || target.UID == memo.UID {
+			continue
+		}
+		targets[target.ID] = target
+	}
+	return targets, nil
+}
+
+func (s *APIV1Service) resolveWikiLinkTarget(ctx context.Context, memo *store.Memo, link string) (*store.Memo, error) {
+	target, err := s.memoLinkResolver().ResolveTarget(ctx, memo.CreatorID, link)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to resolve linked memo: %v", err)
+	}
+	return target, nil
+}
+
+// syncEmbedRelations replaces the embed relations in relations with one per
+// ![[memo]] embed in the content of memo. Embeds resolve like [[memo]] links.
+func (s *APIV1Service) syncEmbedRelations(ctx context.Context, memo *store.Memo, relations []*store.MemoRelation) ([]*store.MemoRelation, error) {
+	synced := make([]*store.MemoRelation, 0, len(relations))
+	for _, relation := range relations {
+		if relation.Type != store.MemoRelationEmbed {
+			synced = append(synced, relation)
+		}
+	}
+	if memo.Content == "" {
+		return synced, nil
+	}
+
+	data, err := s.MarkdownService.ExtractAll([]byte(memo.Content))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract embeds")
+	}
+	targets := make(map[int32]*store.Memo, len(data.Embeds))
+	for _, embed := range data.Embeds {
+		target, err := s.resolveWikiLinkTarget(ctx, memo, embed)
+		if err != nil {
+			return nil, err
+		}
+		if target == nil || target.UID == memo.UID {
+			continue
+		}
+		targets[target.ID] = target
+	}
+	for _, target := range orderedWikiLinkTargets(targets) {
+		synced = append(synced, &store.MemoRelation{
+			MemoID:        memo.ID,
+			RelatedMemoID: target.ID,
+			Type:          store.MemoRelationEmbed,
+		})
+	}
+	return synced, nil
+}
+
+// getMemoSnippetWithEmbeds generates the snippet of memo with the ![[memo]]
+// embeds the current user may read expanded.
+func (s *APIV1Service) getMemoSnippetWithEmbeds(ctx context.Context, memo *store.Memo) (string, error) {
+	if !strings.Contains(memo.Content, "![[") {
+		return s.getMemoContentSnippet(memo.Content)
+	}
+	viewer, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get current user")
+	}
+	snippet, err := s.memoLinkResolver().GenerateSnippet(ctx, memo, viewer, 64)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate snippet")
+	}
+	return snippet, nil
+}
+
+// memoLinkResolver returns a resolver for memo links and embeds.
+func (s *APIV1Service) memoLinkResolver() *memolink.Resolver {
+	return memolink.NewResolver(s.Store, s.MarkdownService)
+}
+
+// orderedWikiLinkTargets returns targets ordered by memo ID so that the
+// relations written for a memo do not depend on map iteration order.
+func orderedWikiLinkTargets(targets map[int32]*store.Memo) []*store.Memo {
+	ordered := make([]*store.Memo, 0, len(targets))
+	for _, target := range targets {
+		ordered = append(ordered, target)
+	}
+	slices.SortFunc(ordered, func(a, b *store.Memo) int { return cmp.Compare(a.ID, b.ID) })
+	return ordered
+}
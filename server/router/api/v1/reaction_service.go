@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/usememos/memos/internal/webhook"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	"github.com/usememos/memos/store"
 )
@@ -110,6 +111,10 @@ func (s *APIV1Service) UpsertMemoReaction(ctx context.Context, request *v1pb.Ups
 	}
 	s.SSEHub.Broadcast(buildMemoReactionSSEEvent(SSEEventReactionUpserted, memoName, memo, parentMemo))
 
+	if err := s.DispatchReactionWebhook(ctx, memo, reactionMessage, webhook.ActivityReactionUpserted); err != nil {
+		slog.Warn("Failed to dispatch reaction upserted webhook", slog.Any("err", err))
+	}
+
 	return reactionMessage, nil
 }
 
@@ -147,6 +152,12 @@ func (s *APIV1Service) DeleteMemoReaction(ctx context.Context, request *v1pb.Del
 		return nil, status.Errorf(codes.Internal, "failed to get memo")
 	}
 
+	// Convert before deleting so the webhook can carry the removed reaction.
+	var reactionMessage *v1pb.Reaction
+	if memo != nil {
+		reactionMessage, _ = s.convertReactionFromStore(ctx, reaction, buildMemoName(memo.UID))
+	}
+
 	if err := s.Store.DeleteReaction(ctx, &store.DeleteReaction{ID: &reactionID}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete reaction")
 	}
@@ -160,6 +171,12 @@ func (s *APIV1Service) DeleteMemoReaction(ctx context.Context, request *v1pb.Del
 			parentMemo, _ = s.Store.GetMemo(ctx, &store.FindMemo{UID: memo.ParentUID})
 		}
 		s.SSEHub.Broadcast(buildMemoReactionSSEEvent(SSEEventReactionDeleted, buildMemoName(memo.UID), memo, parentMemo))
+
+		if reactionMessage != nil {
+			if err := s.DispatchReactionWebhook(ctx, memo, reactionMessage, webhook.ActivityReactionDeleted); err != nil {
+				slog.Warn("Failed to dispatch reaction deleted webhook", slog.Any("err", err))
+			}
+		}
 	}
 
 	return &emptypb.Empty{}, nil
@@ -18,11 +18,15 @@ const (
 	UserNamePrefix             = "users/"
 	MemoNamePrefix             = "memos/"
 	MemoShareNamePrefix        = "shares/"
+	TaskNamePrefix             = "tasks/"
 	AttachmentNamePrefix       = "attachments/"
 	ReactionNamePrefix         = "reactions/"
 	InboxNamePrefix            = "inboxes/"
 	IdentityProviderNamePrefix = "identity-providers/"
 	WebhookNamePrefix          = "webhooks/"
+	UploadSessionNamePrefix    = "uploadSessions/"
+	// InstanceMemoTemplateParent is the parent of instance-wide memo templates.
+	InstanceMemoTemplateParent = "instance"
 )
 
 // GetNameParentTokens returns the tokens from a resource name.
@@ -98,6 +102,15 @@ func ExtractAttachmentUIDFromName(name string) (string, error) {
 	return id, nil
 }
 
+// ExtractUploadSessionUIDFromName returns the upload session UID from a resource name.
+func ExtractUploadSessionUIDFromName(name string) (string, error) {
+	tokens, err := GetNameParentTokens(name, UploadSessionNamePrefix)
+	if err != nil {
+		return "", err
+	}
+	return tokens[0], nil
+}
+
 // ExtractMemoReactionIDFromName returns the memo UID and reaction ID from a resource name.
 // e.g., "memos/abc/reactions/123" -> ("abc", 123).
 func ExtractMemoReactionIDFromName(name string) (string, int32, error) {
@@ -113,6 +126,16 @@ func ExtractMemoReactionIDFromName(name string) (string, int32, error) {
 	return memoUID, reactionID, nil
 }
 
+// ExtractMemoTaskIDFromName returns the memo UID and task ID from a resource name.
+// e.g., "memos/abc/tasks/1a2b3c4d5e6f" -> ("abc", "1a2b3c4d5e6f").
+func ExtractMemoTaskIDFromName(name string) (string, string, error) {
+	tokens, err := GetNameParentTokens(name, MemoNamePrefix, TaskNamePrefix)
+	if err != nil {
+		return "", "", err
+	}
+	return tokens[0], tokens[1], nil
+}
+
 // ExtractInboxIDFromName returns the inbox ID from a resource name.
 func ExtractInboxIDFromName(name string) (int32, error) {
 	tokens, err := GetNameParentTokens(name, InboxNamePrefix)
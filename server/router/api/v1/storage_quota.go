@@ -0,0 +1,250 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	defaultStorageConsumersPageSize = 10
+	maxStorageConsumersPageSize     = 100
+)
+
+func (s *APIV1Service) ListStorageConsumers(ctx context.Context, request *v1pb.ListStorageConsumersRequest) (*v1pb.ListStorageConsumersResponse, error) {
+	user, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if user.Role != store.RoleAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	limit := int(request.PageSize)
+	if limit <= 0 {
+		limit = defaultStorageConsumersPageSize
+	}
+	if limit > maxStorageConsumersPageSize {
+		limit = maxStorageConsumersPageSize
+	}
+	usages, err := s.Store.ListAttachmentUsage(ctx, &store.FindAttachmentUsage{Limit: &limit})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list storage usage: %v", err)
+	}
+	instanceStorageSetting, err := s.Store.GetInstanceStorageSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get instance storage setting: %v", err)
+	}
+
+	response := &v1pb.ListStorageConsumersResponse{}
+	for _, usage := range usages {
+		consumer, err := s.Store.GetUser(ctx, &store.FindUser{ID: &usage.CreatorID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+		}
+		if consumer == nil {
+			continue
+		}
+		response.Consumers = append(response.Consumers, convertStorageUsage(consumer, usage, instanceStorageSetting))
+	}
+	return response, nil
+}
+
+// getUserStorageUsage returns the attachment storage used by a user.
+func (s *APIV1Service) getUserStorageUsage(ctx context.Context, user *store.User) (*v1pb.StorageUsage, error) {
+	usage, err := s.Store.GetUserAttachmentUsage(ctx, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attachment usage")
+	}
+	instanceStorageSetting, err := s.Store.GetInstanceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance storage setting")
+	}
+	return convertStorageUsage(user, usage, instanceStorageSetting), nil
+}
+
+func convertStorageUsage(user *store.User, usage *store.AttachmentUsage, instanceStorageSetting *storepb.InstanceStorageSetting) *v1pb.StorageUsage {
+	return &v1pb.StorageUsage{
+		User:            BuildUserName(user.Username),
+		AttachmentCount: usage.AttachmentCount,
+		SizeBytes:       usage.TotalSize,
+		QuotaBytes:      getStorageQuotaBytes(instanceStorageSetting, user),
+	}
+}
+
+// getStorageQuotaBytes returns the attachment quota of a user in bytes, or
+// zero when the user may store any amount.
+func getStorageQuotaBytes(instanceStorageSetting *storepb.InstanceStorageSetting, user *store.User) int64 {
+	quota := instanceStorageSetting.GetQuota()
+	if quota == nil {
+		return 0
+	}
+	for _, override := range quota.UserOverrides {
+		if override.UserId == user.ID {
+			return override.QuotaMb * MebiByte
+		}
+	}
+	if user.Role == store.RoleAdmin {
+		return quota.AdminQuotaMb * MebiByte
+	}
+	return quota.UserQuotaMb * MebiByte
+}
+
+// checkAttachmentUploadPolicy rejects a new attachment whose MIME type the
+// storage setting does not accept, or whose size would take the user over
+// their storage quota. It reserves nothing; uploads stored right away use
+// reserveAttachmentUpload instead.
+func (s *APIV1Service) checkAttachmentUploadPolicy(ctx context.Context, instanceStorageSetting *storepb.InstanceStorageSetting, user *store.User, mimeType string, size int64) error {
+	release, err := s.reserveAttachmentUpload(ctx, instanceStorageSetting, user, mimeType, size)
+	if err != nil {
+		return err
+	}
+	release()
+	return nil
+}
+
+// attachmentQuotaReservation holds the bytes of a user's uploads that passed
+// the quota check but whose attachments are not created yet.
+type attachmentQuotaReservation struct {
+	mu       sync.Mutex
+	reserved int64
+}
+
+// reserveAttachmentUpload applies checkAttachmentUploadPolicy and reserves
+// size bytes of the user's quota until the returned function is called, which
+// must happen once the attachment is created or the upload failed. Concurrent
+// uploads of a user therefore never exceed the quota together.
+func (s *APIV1Service) reserveAttachmentUpload(ctx context.Context, instanceStorageSetting *storepb.InstanceStorageSetting, user *store.User, mimeType string, size int64) (func(), error) {
+	if !isMimeTypeAccepted(instanceStorageSetting, mimeType) {
+		return nil, status.Errorf(codes.InvalidArgument, "attachments of type %s are not allowed", mimeType)
+	}
+	quota := getStorageQuotaBytes(instanceStorageSetting, user)
+	if quota <= 0 {
+		return func() {}, nil
+	}
+
+	value, _ := s.attachmentQuotaReservations.LoadOrStore(user.ID, &attachmentQuotaReservation{})
+	reservation := value.(*attachmentQuotaReservation)
+	reservation.mu.Lock()
+	defer reservation.mu.Unlock()
+	usage, err := s.Store.GetUserAttachmentUsage(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get attachment usage: %v", err)
+	}
+	if used := usage.TotalSize + reservation.reserved; used+size > quota {
+		return nil, status.Errorf(codes.ResourceExhausted, "storage quota exceeded: %s of %s used, the attachment needs %s",
+			formatMebibytes(used), formatMebibytes(quota), formatMebibytes(size))
+	}
+	reservation.reserved += size
+	return func() {
+		reservation.mu.Lock()
+		defer reservation.mu.Unlock()
+		reservation.reserved -= size
+	}, nil
+}
+
+func formatMebibytes(size int64) string {
+	return fmt.Sprintf("%.1f MiB", float64(size)/MebiByte)
+}
+
+// isMimeTypeAccepted applies the allowed and blocked MIME type lists; blocked
+// types win, and an empty allow list accepts every type.
+func isMimeTypeAccepted(instanceStorageSetting *storepb.InstanceStorageSetting, mimeType string) bool {
+	matches := func(pattern string) bool {
+		return matchesMimeTypePattern(pattern, mimeType)
+	}
+	if slices.ContainsFunc(instanceStorageSetting.GetBlockedMimeTypes(), matches) {
+		return false
+	}
+	allowed := instanceStorageSetting.GetAllowedMimeTypes()
+	return len(allowed) == 0 || slices.ContainsFunc(allowed, matches)
+}
+
+// matchesMimeTypePattern matches an exact MIME type or a "type/*" wildcard.
+func matchesMimeTypePattern(pattern, mimeType string) bool {
+	if family, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(mimeType, family+"/")
+	}
+	return pattern == mimeType
+}
+
+func normalizeMimeTypePatterns(patterns []string) []string {
+	var normalized []string
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern != "" && !slices.Contains(normalized, pattern) {
+			normalized = append(normalized, pattern)
+		}
+	}
+	return normalized
+}
+
+// resolveStorageQuotaOverrides stores the per-user quota overrides of an
+// updated storage setting by user ID, so they survive username changes.
+func (s *APIV1Service) resolveStorageQuotaOverrides(ctx context.Context, setting *v1pb.InstanceSetting_StorageSetting, settingpb *storepb.InstanceStorageSetting) error {
+	overrides := setting.GetQuota().GetUserOverrides()
+	if len(overrides) == 0 {
+		return nil
+	}
+	if settingpb.Quota == nil {
+		settingpb.Quota = &storepb.StorageQuota{}
+	}
+	seen := make(map[int32]bool, len(overrides))
+	for _, override := range overrides {
+		user, err := ResolveUserByName(ctx, s.Store, override.User)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid user name %q: %v", override.User, err)
+		}
+		if user == nil {
+			return status.Errorf(codes.InvalidArgument, "user %s not found", override.User)
+		}
+		if seen[user.ID] {
+			return status.Errorf(codes.InvalidArgument, "duplicate quota override for %s", override.User)
+		}
+		seen[user.ID] = true
+		settingpb.Quota.UserOverrides = append(settingpb.Quota.UserOverrides, &storepb.StorageQuota_UserOverride{
+			UserId:  user.ID,
+			QuotaMb: override.QuotaMb,
+		})
+	}
+	return nil
+}
+
+// fillStorageQuotaOverrides adds the per-user quota overrides of a stored
+// storage setting to its API form, skipping users that no longer exist.
+func (s *APIV1Service) fillStorageQuotaOverrides(ctx context.Context, settingpb *storepb.InstanceStorageSetting, setting *v1pb.InstanceSetting_StorageSetting) error {
+	overrides := settingpb.GetQuota().GetUserOverrides()
+	if len(overrides) == 0 || setting == nil {
+		return nil
+	}
+	if setting.Quota == nil {
+		setting.Quota = &v1pb.InstanceSetting_StorageQuota{}
+	}
+	for _, override := range overrides {
+		user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &override.UserId})
+		if err != nil {
+			return errors.Wrap(err, "failed to get user")
+		}
+		if user == nil {
+			continue
+		}
+		setting.Quota.UserOverrides = append(setting.Quota.UserOverrides, &v1pb.InstanceSetting_StorageQuota_UserOverride{
+			User:    BuildUserName(user.Username),
+			QuotaMb: override.QuotaMb,
+		})
+	}
+	return nil
+}
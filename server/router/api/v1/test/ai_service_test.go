@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -244,6 +246,100 @@ func TestTranscribe(t *testing.T) {
 		require.Equal(t, "built-in model", resp.Text)
 	})
 
+	t.Run("transcribes audio with OpenAI-compatible provider without API key", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "dana")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		localServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v1/audio/transcriptions", r.URL.Path)
+			require.Empty(t, r.Header.Get("Authorization"))
+			require.NoError(t, r.ParseMultipartForm(10<<20))
+			require.Equal(t, "Systran/faster-whisper-small", r.FormValue("model"))
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{
+				"text": "air-gapped transcript",
+			}))
+		}))
+		defer localServer.Close()
+
+		_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+			Key: storepb.InstanceSettingKey_AI,
+			Value: &storepb.InstanceSetting_AiSetting{
+				AiSetting: &storepb.InstanceAISetting{
+					Providers: []*storepb.AIProviderConfig{
+						{
+							Id:                    "local",
+							Title:                 "Local Whisper",
+							Type:                  storepb.AIProviderType_OPENAI_COMPATIBLE,
+							Endpoint:              localServer.URL + "/v1",
+							TimeoutSeconds:        5,
+							MaxConcurrentRequests: 1,
+						},
+					},
+					Transcription: &storepb.TranscriptionConfig{
+						ProviderId: "local",
+						Model:      "Systran/faster-whisper-small",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		resp, err := ts.Service.Transcribe(userCtx, &v1pb.TranscribeRequest{
+			Audio: &v1pb.TranscriptionAudio{
+				Source:      &v1pb.TranscriptionAudio_Content{Content: []byte("RIFF")},
+				Filename:    "voice.wav",
+				ContentType: "audio/wav",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "air-gapped transcript", resp.Text)
+	})
+
+	t.Run("returns FailedPrecondition for Ollama providers", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "erin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+			Key: storepb.InstanceSettingKey_AI,
+			Value: &storepb.InstanceSetting_AiSetting{
+				AiSetting: &storepb.InstanceAISetting{
+					Providers: []*storepb.AIProviderConfig{
+						{
+							Id:       "ollama",
+							Title:    "Ollama",
+							Type:     storepb.AIProviderType_OLLAMA,
+							Endpoint: "http://localhost:11434",
+						},
+					},
+					Transcription: &storepb.TranscriptionConfig{
+						ProviderId: "ollama",
+						Model:      "llama3.2",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = ts.Service.Transcribe(userCtx, &v1pb.TranscribeRequest{
+			Audio: &v1pb.TranscriptionAudio{
+				Source:      &v1pb.TranscriptionAudio_Content{Content: []byte("RIFF")},
+				Filename:    "voice.wav",
+				ContentType: "audio/wav",
+			},
+		})
+		require.Error(t, err)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
 	t.Run("rejects non-audio content before provider call", func(t *testing.T) {
 		ts := NewTestService(t)
 		defer ts.Cleanup()
@@ -0,0 +1,252 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// newFakeScanner serves the HTTP scanner protocol, reporting content that
+// contains "EICAR" as infected. It records the authorization header of the
+// last request.
+func newFakeScanner(t *testing.T) (*httptest.Server, *string) {
+	t.Helper()
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		content, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(content), "EICAR") {
+			w.Write([]byte(`{"infected": true, "signature": "Eicar-Test-Signature"}`))
+			return
+		}
+		w.Write([]byte(`{"infected": false}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &authorization
+}
+
+func setContentScanConfig(ctx context.Context, t *testing.T, ts *TestService, config *v1pb.InstanceSetting_ContentScanConfig) *v1pb.InstanceSetting_ContentScanConfig {
+	t.Helper()
+	updated, err := ts.Service.UpdateInstanceSetting(ctx, &v1pb.UpdateInstanceSettingRequest{
+		Setting: &v1pb.InstanceSetting{
+			Name: "instance/settings/STORAGE",
+			Value: &v1pb.InstanceSetting_StorageSetting_{
+				StorageSetting: &v1pb.InstanceSetting_StorageSetting{ContentScan: config},
+			},
+		},
+	})
+	require.NoError(t, err)
+	return updated.GetStorageSetting().GetContentScan()
+}
+
+func listInfectedNotifications(ctx context.Context, t *testing.T, ts *TestService, parent string) []*v1pb.UserNotification_AttachmentInfectedPayload {
+	t.Helper()
+	resp, err := ts.Service.ListUserNotifications(ctx, &v1pb.ListUserNotificationsRequest{Parent: parent})
+	require.NoError(t, err)
+	var payloads []*v1pb.UserNotification_AttachmentInfectedPayload
+	for _, notification := range resp.Notifications {
+		if notification.Type == v1pb.UserNotification_ATTACHMENT_INFECTED {
+			payloads = append(payloads, notification.GetAttachmentInfected())
+		}
+	}
+	return payloads
+}
+
+func TestContentScanSettingKeepsToken(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	admin, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	adminCtx := ts.CreateUserContext(ctx, admin.ID)
+
+	config := setContentScanConfig(adminCtx, t, ts, &v1pb.InstanceSetting_ContentScanConfig{
+		Scanner:      v1pb.InstanceSetting_ContentScanConfig_HTTP,
+		HttpEndpoint: "https://scanner.example.com/scan",
+		HttpToken:    "secret",
+	})
+	require.Empty(t, config.HttpToken)
+	require.Equal(t, "https://scanner.example.com/scan", config.HttpEndpoint)
+
+	// An empty token keeps the stored one while the endpoint is unchanged.
+	setContentScanConfig(adminCtx, t, ts, &v1pb.InstanceSetting_ContentScanConfig{
+		Scanner:        v1pb.InstanceSetting_ContentScanConfig_HTTP,
+		HttpEndpoint:   "https://scanner.example.com/scan",
+		InfectedAction: v1pb.InstanceSetting_ContentScanConfig_QUARANTINE,
+	})
+	setting, err := ts.Store.GetInstanceStorageSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "secret", setting.GetContentScan().GetHttpToken())
+	require.Equal(t, storepb.ContentScanConfig_QUARANTINE, setting.GetContentScan().GetInfectedAction())
+
+	// A new endpoint does not inherit the token of the old one.
+	setContentScanConfig(adminCtx, t, ts, &v1pb.InstanceSetting_ContentScanConfig{
+		Scanner:      v1pb.InstanceSetting_ContentScanConfig_HTTP,
+		HttpEndpoint: "https://other.example.com/scan",
+	})
+	setting, err = ts.Store.GetInstanceStorageSetting(ctx)
+	require.NoError(t, err)
+	require.Empty(t, setting.GetContentScan().GetHttpToken())
+
+	for _, invalid := range []*v1pb.InstanceSetting_ContentScanConfig{
+		{Scanner: v1pb.InstanceSetting_ContentScanConfig_HTTP, HttpEndpoint: "ftp://scanner.example.com"},
+		{Scanner: v1pb.InstanceSetting_ContentScanConfig_CLAMD, ClamdAddress: "localhost"},
+		{Scanner: v1pb.InstanceSetting_ContentScanConfig_CLAMD, ClamdAddress: "unix:"},
+		{Scanner: v1pb.InstanceSetting_ContentScanConfig_CLAMD, ClamdAddress: "localhost:3310", TimeoutSeconds: -1},
+	} {
+		_, err := ts.Service.UpdateInstanceSetting(adminCtx, &v1pb.UpdateInstanceSettingRequest{
+			Setting: &v1pb.InstanceSetting{
+				Name: "instance/settings/STORAGE",
+				Value: &v1pb.InstanceSetting_StorageSetting_{
+					StorageSetting: &v1pb.InstanceSetting_StorageSetting{ContentScan: invalid},
+				},
+			},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), invalid.String())
+	}
+}
+
+func TestCreateAttachmentContentScan(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	admin, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	adminCtx := ts.CreateUserContext(ctx, admin.ID)
+	user, err := ts.CreateRegularUser(ctx, "uploader")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	scanner, authorization := newFakeScanner(t)
+
+	create := func(filename, content string) (*v1pb.Attachment, error) {
+		return ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: filename, Type: "text/plain", Content: []byte(content)},
+		})
+	}
+
+	t.Run("rejects infected uploads", func(t *testing.T) {
+		setContentScanConfig(adminCtx, t, ts, &v1pb.InstanceSetting_ContentScanConfig{
+			Scanner:      v1pb.InstanceSetting_ContentScanConfig_HTTP,
+			HttpEndpoint: scanner.URL,
+			HttpToken:    "scan-token",
+		})
+
+		clean, err := create("clean.txt", "hello")
+		require.NoError(t, err)
+		require.Equal(t, v1pb.ContentScan_CLEAN, clean.GetContentScan().GetStatus())
+		require.NotNil(t, clean.GetContentScan().GetScanTime())
+		require.Equal(t, "Bearer scan-token", *authorization)
+
+		_, err = create("eicar.txt", "EICAR test file")
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Contains(t, err.Error(), "Eicar-Test-Signature")
+
+		attachments, err := ts.Store.ListAttachments(ctx, &store.FindAttachment{CreatorID: &user.ID})
+		require.NoError(t, err)
+		require.Len(t, attachments, 1)
+
+		notifications := listInfectedNotifications(adminCtx, t, ts, "users/admin")
+		require.Len(t, notifications, 1)
+		require.Equal(t, "eicar.txt", notifications[0].Filename)
+		require.Equal(t, "Eicar-Test-Signature", notifications[0].Signature)
+		require.False(t, notifications[0].Quarantined)
+		require.Empty(t, notifications[0].Attachment)
+	})
+
+	t.Run("quarantines infected uploads", func(t *testing.T) {
+		setContentScanConfig(adminCtx, t, ts, &v1pb.InstanceSetting_ContentScanConfig{
+			Scanner:        v1pb.InstanceSetting_ContentScanConfig_HTTP,
+			HttpEndpoint:   scanner.URL,
+			InfectedAction: v1pb.InstanceSetting_ContentScanConfig_QUARANTINE,
+		})
+
+		quarantined, err := create("quarantined.txt", "EICAR quarantined")
+		require.NoError(t, err)
+		require.Equal(t, v1pb.ContentScan_INFECTED, quarantined.GetContentScan().GetStatus())
+		require.Equal(t, "Eicar-Test-Signature", quarantined.GetContentScan().GetSignature())
+
+		notifications := listInfectedNotifications(adminCtx, t, ts, "users/admin")
+		require.Len(t, notifications, 2)
+		var found bool
+		for _, notification := range notifications {
+			if notification.Quarantined {
+				found = true
+				require.Equal(t, quarantined.Name, notification.Attachment)
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("refuses uploads when the scanner fails", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		listener.Close()
+
+		setContentScanConfig(adminCtx, t, ts, &v1pb.InstanceSetting_ContentScanConfig{
+			Scanner:      v1pb.InstanceSetting_ContentScanConfig_CLAMD,
+			ClamdAddress: address,
+		})
+		_, err = create("unscanned.txt", "hello")
+		require.Equal(t, codes.Unavailable, status.Code(err))
+
+		setContentScanConfig(adminCtx, t, ts, &v1pb.InstanceSetting_ContentScanConfig{
+			Scanner:      v1pb.InstanceSetting_ContentScanConfig_CLAMD,
+			ClamdAddress: address,
+			FailOpen:     true,
+		})
+		attachment, err := create("unscanned.txt", "hello")
+		require.NoError(t, err)
+		require.Equal(t, v1pb.ContentScan_FAILED, attachment.GetContentScan().GetStatus())
+	})
+}
+
+func TestUploadSessionContentScanRejection(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	admin, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	adminCtx := ts.CreateUserContext(ctx, admin.ID)
+	user, err := ts.CreateRegularUser(ctx, "uploader")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	client := newUploadClient(t, ts, user)
+	scanner, _ := newFakeScanner(t)
+	setContentScanConfig(adminCtx, t, ts, &v1pb.InstanceSetting_ContentScanConfig{
+		Scanner:      v1pb.InstanceSetting_ContentScanConfig_HTTP,
+		HttpEndpoint: scanner.URL,
+	})
+
+	content := []byte("EICAR staged upload")
+	session, err := ts.Service.CreateUploadSession(userCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "staged.bin", Size: int64(len(content))},
+	})
+	require.NoError(t, err)
+	client.uploadInChunks(session, content)
+
+	_, err = ts.Service.CompleteUploadSession(userCtx, &v1pb.CompleteUploadSessionRequest{Name: session.Name})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The rejected content cannot be completed again.
+	_, err = ts.Service.GetUploadSession(userCtx, &v1pb.GetUploadSessionRequest{Name: session.Name})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	attachments, err := ts.Store.ListAttachments(ctx, &store.FindAttachment{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Empty(t, attachments)
+}
@@ -0,0 +1,155 @@
+package test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/internal/testutil/fakes3"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestAttachmentContentDeduplication(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "dedup-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	other, err := ts.CreateRegularUser(ctx, "dedup-other")
+	require.NoError(t, err)
+	otherCtx := ts.CreateUserContext(ctx, other.ID)
+
+	content := []byte("the same screenshot, uploaded again")
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+	getStored := func(t *testing.T, attachment *v1pb.Attachment) *store.Attachment {
+		uid, err := apiv1.ExtractAttachmentUIDFromName(attachment.Name)
+		require.NoError(t, err)
+		stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &uid})
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		return stored
+	}
+	localPath := func(stored *store.Attachment) string {
+		path := filepath.FromSlash(stored.Reference)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(ts.Profile.Data, path)
+		}
+		return path
+	}
+
+	t.Run("local storage keeps shared content until the last reference", func(t *testing.T) {
+		first, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "first.txt", Type: "text/plain", Content: content},
+		})
+		require.NoError(t, err)
+		require.Equal(t, digest, first.Sha256)
+		second, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "second.txt", Type: "text/plain", Content: content},
+		})
+		require.NoError(t, err)
+		require.Equal(t, digest, second.Sha256)
+
+		firstStored, secondStored := getStored(t, first), getStored(t, second)
+		require.Equal(t, storepb.AttachmentStorageType_LOCAL, secondStored.StorageType)
+		require.Equal(t, firstStored.Reference, secondStored.Reference)
+
+		// Creating with only the digest reuses the stored content.
+		third, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "third.txt", Type: "text/plain", Sha256: digest},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(len(content)), third.Size)
+		thirdStored := getStored(t, third)
+		require.Equal(t, firstStored.Reference, thirdStored.Reference)
+		blob, err := ts.Service.GetAttachmentBlob(ctx, thirdStored)
+		require.NoError(t, err)
+		require.Equal(t, content, blob)
+
+		_, err = ts.Service.DeleteAttachment(userCtx, &v1pb.DeleteAttachmentRequest{Name: first.Name})
+		require.NoError(t, err)
+		require.FileExists(t, localPath(firstStored))
+
+		_, err = ts.Service.BatchDeleteAttachments(userCtx, &v1pb.BatchDeleteAttachmentsRequest{Names: []string{second.Name, third.Name}})
+		require.NoError(t, err)
+		require.NoFileExists(t, localPath(firstStored))
+	})
+
+	t.Run("digest-only create is limited to the caller's content", func(t *testing.T) {
+		_, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "owned.txt", Type: "text/plain", Content: content},
+		})
+		require.NoError(t, err)
+
+		_, err = ts.Service.CreateAttachment(otherCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "guessed.txt", Type: "text/plain", Sha256: digest},
+		})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("rejects invalid digests", func(t *testing.T) {
+		_, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "bad.txt", Type: "text/plain", Sha256: "not-a-digest"},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "mismatch.txt", Type: "text/plain", Content: []byte("other content"), Sha256: digest},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("S3 objects are shared per storage", func(t *testing.T) {
+		fake := fakes3.New(t, "dedup")
+		s3Storage := fakeStorage("s3-dedup", "Dedup S3", fake.Config("dedup"))
+		upsertS3StorageSetting(ctx, t, ts, s3Storage.Id, s3Storage)
+
+		s3Content := []byte("a report stored in S3")
+		first, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "report.txt", Type: "text/plain", Content: s3Content},
+		})
+		require.NoError(t, err)
+		second, err := ts.Service.CreateAttachment(otherCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "copy.txt", Type: "text/plain", Content: s3Content},
+		})
+		require.NoError(t, err)
+
+		firstStored, secondStored := getStored(t, first), getStored(t, second)
+		key := firstStored.Payload.GetS3Object().GetKey()
+		require.NotEmpty(t, key)
+		require.Equal(t, key, secondStored.Payload.GetS3Object().GetKey())
+
+		_, err = ts.Service.DeleteAttachment(userCtx, &v1pb.DeleteAttachmentRequest{Name: first.Name})
+		require.NoError(t, err)
+		object, err := fake.GetObject("dedup", key)
+		require.NoError(t, err)
+		require.Equal(t, s3Content, object)
+
+		_, err = ts.Service.DeleteAttachment(otherCtx, &v1pb.DeleteAttachmentRequest{Name: second.Name})
+		require.NoError(t, err)
+		_, err = fake.GetObject("dedup", key)
+		require.Error(t, err)
+	})
+
+	t.Run("database blobs record the digest", func(t *testing.T) {
+		databaseStorage := &storepb.Storage{Id: "database", Name: "Database", Type: storepb.StorageType_STORAGE_TYPE_DATABASE}
+		upsertS3StorageSetting(ctx, t, ts, databaseStorage.Id, databaseStorage)
+
+		created, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "blob.txt", Type: "text/plain", Content: content},
+		})
+		require.NoError(t, err)
+		require.Equal(t, digest, created.Sha256)
+		require.Equal(t, storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED, getStored(t, created).StorageType)
+	})
+}
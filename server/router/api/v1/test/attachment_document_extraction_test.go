@@ -0,0 +1,115 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/docextract"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestAttachmentDocumentExtraction(t *testing.T) {
+	ctx := context.Background()
+
+	hasDocument := func(attachment *v1pb.Attachment) bool { return attachment.Document != nil }
+
+	t.Run("extracts and searches plain text documents", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+		ts.Service.DocumentExtractor = docextract.New("")
+
+		user, err := ts.CreateRegularUser(ctx, "alice")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "expenses.csv",
+				Type:     "text/csv",
+				Content:  []byte("item,amount\nespresso machine,240\n"),
+			},
+		})
+		require.NoError(t, err)
+
+		extracted := ts.WaitForAttachment(userCtx, t, attachment.Name, hasDocument)
+		require.Equal(t, "item,amount\nespresso machine,240", extracted.Document.Text)
+		require.Zero(t, extracted.Document.PageCount)
+		require.False(t, extracted.Document.Truncated)
+		require.NotNil(t, extracted.Document.CreateTime)
+
+		attachments, err := ts.Service.ListAttachments(userCtx, &v1pb.ListAttachmentsRequest{Filter: `document_text.contains("Espresso")`})
+		require.NoError(t, err)
+		require.Len(t, attachments.Attachments, 1)
+
+		memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+			Memo: &v1pb.Memo{
+				Content:     "Monthly expenses",
+				Visibility:  v1pb.Visibility_PRIVATE,
+				Attachments: []*v1pb.Attachment{{Name: attachment.Name}},
+			},
+		})
+		require.NoError(t, err)
+		memos, err := ts.Service.ListMemos(userCtx, &v1pb.ListMemosRequest{Filter: `content.contains("espresso")`})
+		require.NoError(t, err)
+		require.Len(t, memos.Memos, 1)
+		require.Equal(t, memo.Name, memos.Memos[0].Name)
+	})
+
+	t.Run("extracts PDF text and page count with pdftotext", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("fake pdftotext requires a POSIX shell")
+		}
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		command := filepath.Join(t.TempDir(), "pdftotext")
+		require.NoError(t, os.WriteFile(command, []byte("#!/bin/sh\nprintf 'Lease agreement\\fSignatures\\f'\n"), 0o755))
+		ts.Service.DocumentExtractor = docextract.New(command)
+
+		user, err := ts.CreateRegularUser(ctx, "bob")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "lease.pdf",
+				Type:     "application/pdf",
+				Content:  []byte("%PDF-1.7 fake"),
+			},
+		})
+		require.NoError(t, err)
+
+		extracted := ts.WaitForAttachment(userCtx, t, attachment.Name, hasDocument)
+		require.Equal(t, "Lease agreement\n\nSignatures", extracted.Document.Text)
+		require.Equal(t, int32(2), extracted.Document.PageCount)
+	})
+
+	t.Run("skips unsupported attachments", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+		ts.Service.DocumentExtractor = docextract.New("")
+
+		user, err := ts.CreateRegularUser(ctx, "carol")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "archive.zip",
+				Type:     "application/zip",
+				Content:  []byte("PK fake"),
+			},
+		})
+		require.NoError(t, err)
+		require.Never(t, func() bool {
+			fetched, err := ts.Service.GetAttachment(userCtx, &v1pb.GetAttachmentRequest{Name: attachment.Name})
+			return err != nil || fetched.Document != nil
+		}, 200*time.Millisecond, 20*time.Millisecond)
+	})
+}
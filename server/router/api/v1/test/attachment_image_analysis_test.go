@@ -0,0 +1,148 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestAttachmentImageAnalysis(t *testing.T) {
+	ctx := context.Background()
+
+	newVisionServer := func(t *testing.T, requests *atomic.Int32) *httptest.Server {
+		t.Helper()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			require.Equal(t, "/chat/completions", r.URL.Path)
+
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+				"id":     "chatcmpl-1",
+				"object": "chat.completion",
+				"model":  "gpt-4o-mini",
+				"choices": []map[string]any{{
+					"index":         0,
+					"finish_reason": "stop",
+					"message": map[string]any{
+						"role":    "assistant",
+						"content": `{"text":"Invoice 2026-17\nTotal 42.00","caption":"A printed invoice on a desk."}`,
+					},
+				}},
+			}))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	configureImageAnalysis := func(t *testing.T, ts *TestService, endpoint string, engine storepb.ImageAnalysisConfig_Engine) {
+		t.Helper()
+		_, err := ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+			Key: storepb.InstanceSettingKey_AI,
+			Value: &storepb.InstanceSetting_AiSetting{
+				AiSetting: &storepb.InstanceAISetting{
+					Providers: []*storepb.AIProviderConfig{
+						{
+							Id:       "openai-main",
+							Title:    "OpenAI",
+							Type:     storepb.AIProviderType_OPENAI,
+							Endpoint: endpoint,
+							ApiKey:   "sk-test",
+						},
+					},
+					ImageAnalysis: &storepb.ImageAnalysisConfig{
+						Engine:     engine,
+						ProviderId: "openai-main",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("analyzes image attachments in the background", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "alice")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		var requests atomic.Int32
+		server := newVisionServer(t, &requests)
+		configureImageAnalysis(t, ts, server.URL, storepb.ImageAnalysisConfig_AI_PROVIDER)
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "invoice.png",
+				Type:     "image/png",
+				Content:  []byte("fake png content"),
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, attachment.ImageAnalysis, "the upload must not wait for the provider")
+
+		analyzed := ts.WaitForAttachment(userCtx, t, attachment.Name, func(attachment *v1pb.Attachment) bool {
+			return attachment.ImageAnalysis != nil
+		})
+
+		require.Equal(t, "Invoice 2026-17\nTotal 42.00", analyzed.ImageAnalysis.Text)
+		require.Equal(t, "A printed invoice on a desk.", analyzed.ImageAnalysis.Caption)
+		require.NotNil(t, analyzed.ImageAnalysis.CreateTime)
+
+		for _, filter := range []string{`image_text.contains("invoice")`, `image_caption.contains("desk")`} {
+			attachments, err := ts.Service.ListAttachments(userCtx, &v1pb.ListAttachmentsRequest{Filter: filter})
+			require.NoError(t, err, filter)
+			require.Len(t, attachments.Attachments, 1, filter)
+		}
+	})
+
+	t.Run("skips attachments when image analysis is disabled", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "bob")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		var requests atomic.Int32
+		server := newVisionServer(t, &requests)
+		configureImageAnalysis(t, ts, server.URL, storepb.ImageAnalysisConfig_ENGINE_UNSPECIFIED)
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "invoice.png",
+				Type:     "image/png",
+				Content:  []byte("fake png content"),
+			},
+		})
+		require.NoError(t, err)
+
+		require.Never(t, func() bool { return requests.Load() > 0 }, 200*time.Millisecond, 20*time.Millisecond)
+		fetched, err := ts.Service.GetAttachment(userCtx, &v1pb.GetAttachmentRequest{Name: attachment.Name})
+		require.NoError(t, err)
+		require.Nil(t, fetched.ImageAnalysis)
+	})
+
+	t.Run("redacts the image analysis setting for regular users", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "carol")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+		configureImageAnalysis(t, ts, "https://api.openai.com/v1", storepb.ImageAnalysisConfig_AI_PROVIDER)
+
+		setting, err := ts.Service.GetInstanceSetting(userCtx, &v1pb.GetInstanceSettingRequest{Name: "instance/settings/AI"})
+		require.NoError(t, err)
+		require.Nil(t, setting.GetAiSetting().GetImageAnalysis())
+	})
+}
@@ -0,0 +1,120 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/usememos/memos/internal/mediapreview"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestAttachmentMediaPreview(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg requires a POSIX shell")
+	}
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "alice")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	// The fake ffmpeg records its arguments and writes a frame for video
+	// posters or four samples for audio waveforms.
+	dir := t.TempDir()
+	frame := filepath.Join(dir, "frame.png")
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 64, 36))))
+	require.NoError(t, os.WriteFile(frame, buf.Bytes(), 0o600))
+	args := filepath.Join(dir, "args")
+	ffmpeg := filepath.Join(dir, "ffmpeg")
+	require.NoError(t, os.WriteFile(ffmpeg, []byte(`#!/bin/sh
+eval out=\${$#}
+echo "$@" >> "`+args+`"
+case "$out" in
+*.png) cp "`+frame+`" "$out" ;;
+*) printf '\000\100\000\300\000\000\377\177' > "$out" ;;
+esac
+`), 0o755))
+	ts.Service.MediaPreviewGenerator = mediapreview.New(ffmpeg)
+
+	hasPreview := func(attachment *v1pb.Attachment) bool { return attachment.MediaPreview != nil }
+
+	t.Run("extracts video posters", func(t *testing.T) {
+		created, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "clip.mp4",
+				Type:     "video/mp4",
+				Content:  []byte("fake video"),
+				MediaMetadata: &v1pb.MediaMetadata{
+					Details: &v1pb.MediaMetadata_Video{Video: &v1pb.VideoMetadata{DurationSeconds: proto.Float64(12.5)}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, created.MediaPreview, "the upload must not wait for ffmpeg")
+
+		attachment := ts.WaitForAttachment(userCtx, t, created.Name, hasPreview)
+		uid := strings.TrimPrefix(attachment.Name, "attachments/")
+		require.Equal(t, "/file/attachments/"+uid+"?poster=true", attachment.MediaPreview.PosterUrl)
+		require.Empty(t, attachment.MediaPreview.WaveformPeaks)
+		require.NotNil(t, attachment.MediaPreview.CreateTime)
+
+		recorded, err := os.ReadFile(args)
+		require.NoError(t, err)
+		require.Contains(t, string(recorded), "-ss 1.000 -i ")
+
+		stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &uid})
+		require.NoError(t, err)
+		name := store.AttachmentPosterVariant
+		poster, err := ts.Store.GetAttachmentVariant(ctx, &store.FindAttachmentVariant{AttachmentID: &stored.ID, Name: &name})
+		require.NoError(t, err)
+		require.NotNil(t, poster)
+		driver, err := ts.Store.ResolveAttachmentVariantDriver(ctx, stored)
+		require.NoError(t, err)
+		blob, err := driver.GetObject(ctx, poster.Reference)
+		require.NoError(t, err)
+		img, err := jpeg.Decode(bytes.NewReader(blob))
+		require.NoError(t, err)
+		require.Equal(t, image.Rect(0, 0, 64, 36), img.Bounds())
+	})
+
+	t.Run("measures audio waveforms", func(t *testing.T) {
+		created, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "voice.m4a",
+				Type:     "audio/mp4",
+				Content:  []byte("fake audio"),
+			},
+		})
+		require.NoError(t, err)
+
+		attachment := ts.WaitForAttachment(userCtx, t, created.Name, hasPreview)
+		require.Empty(t, attachment.MediaPreview.PosterUrl)
+		require.Equal(t, []float32{0.5, 0.5, 0, 32767.0 / 32768}, attachment.MediaPreview.WaveformPeaks)
+	})
+
+	t.Run("skips other attachments", func(t *testing.T) {
+		created, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "notes.txt", Type: "text/plain", Content: []byte("notes")},
+		})
+		require.NoError(t, err)
+		// Attachments that do not qualify are skipped before any work is scheduled.
+		attachment, err := ts.Service.GetAttachment(userCtx, &v1pb.GetAttachmentRequest{Name: created.Name})
+		require.NoError(t, err)
+		require.Nil(t, attachment.MediaPreview)
+	})
+}
@@ -0,0 +1,189 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/internal/testutil/fakes3"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestMigrateAttachments(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	admin, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	adminCtx := ts.CreateUserContext(ctx, admin.ID)
+	user, err := ts.CreateRegularUser(ctx, "migration-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	fake := fakes3.New(t, "migrated")
+	databaseStorage := &storepb.Storage{Id: "database", Name: "Database", Type: storepb.StorageType_STORAGE_TYPE_DATABASE}
+	s3Storage := fakeStorage("s3-migrated", "Migrated S3", fake.Config("migrated"))
+	upsertS3StorageSetting(ctx, t, ts, databaseStorage.Id, databaseStorage, s3Storage)
+
+	contents := map[string][]byte{
+		"a.txt": []byte("first database attachment"),
+		"b.txt": []byte("second database attachment"),
+		"c.txt": []byte("third database attachment"),
+	}
+	uids := map[string]string{}
+	for _, filename := range []string{"a.txt", "b.txt", "c.txt"} {
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: filename, Type: "text/plain", Content: contents[filename]},
+		})
+		require.NoError(t, err)
+		uids[filename], err = apiv1.ExtractAttachmentUIDFromName(attachment.Name)
+		require.NoError(t, err)
+	}
+	getStored := func(filename string) *store.Attachment {
+		uid := uids[filename]
+		attachment, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &uid, GetBlob: true})
+		require.NoError(t, err)
+		require.NotNil(t, attachment)
+		return attachment
+	}
+	require.Equal(t, storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED, getStored("a.txt").StorageType)
+
+	t.Run("requires admin", func(t *testing.T) {
+		_, err := ts.Service.MigrateAttachments(userCtx, &v1pb.MigrateAttachmentsRequest{
+			SourceStorageId: databaseStorage.Id,
+			TargetStorageId: s3Storage.Id,
+		})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("rejects unknown and identical storages", func(t *testing.T) {
+		_, err := ts.Service.MigrateAttachments(adminCtx, &v1pb.MigrateAttachmentsRequest{
+			SourceStorageId: databaseStorage.Id,
+			TargetStorageId: "missing",
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = ts.Service.MigrateAttachments(adminCtx, &v1pb.MigrateAttachmentsRequest{
+			SourceStorageId: s3Storage.Id,
+			TargetStorageId: s3Storage.Id,
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("moves database blobs to S3 across pages", func(t *testing.T) {
+		first, err := ts.Service.MigrateAttachments(adminCtx, &v1pb.MigrateAttachmentsRequest{
+			SourceStorageId: databaseStorage.Id,
+			TargetStorageId: s3Storage.Id,
+			PageSize:        2,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(3), first.TotalCount)
+		require.Equal(t, int32(2), first.ProcessedCount)
+		require.Equal(t, int32(2), first.MigratedCount)
+		require.Empty(t, first.Failures)
+		require.NotEmpty(t, first.NextPageToken)
+
+		second, err := ts.Service.MigrateAttachments(adminCtx, &v1pb.MigrateAttachmentsRequest{
+			SourceStorageId: databaseStorage.Id,
+			TargetStorageId: s3Storage.Id,
+			PageSize:        2,
+			PageToken:       first.NextPageToken,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(3), second.TotalCount)
+		require.Equal(t, int32(3), second.ProcessedCount)
+		require.Equal(t, int32(1), second.MigratedCount)
+		require.Empty(t, second.NextPageToken)
+
+		for filename, content := range contents {
+			stored := getStored(filename)
+			require.Equal(t, storepb.AttachmentStorageType_S3, stored.StorageType)
+			require.Empty(t, stored.Blob)
+			require.Equal(t, s3Storage.Id, stored.Payload.GetS3Object().GetStorageId())
+			object, err := fake.GetObject("migrated", stored.Payload.GetS3Object().GetKey())
+			require.NoError(t, err)
+			require.Equal(t, content, object)
+			downloaded, err := ts.Service.GetAttachmentBlob(ctx, stored)
+			require.NoError(t, err)
+			require.Equal(t, content, downloaded)
+		}
+
+		// Restarting a finished migration finds nothing left on the source.
+		again, err := ts.Service.MigrateAttachments(adminCtx, &v1pb.MigrateAttachmentsRequest{
+			SourceStorageId: databaseStorage.Id,
+			TargetStorageId: s3Storage.Id,
+		})
+		require.NoError(t, err)
+		require.Zero(t, again.TotalCount)
+		require.Zero(t, again.MigratedCount)
+	})
+
+	t.Run("moves filtered S3 objects to local storage", func(t *testing.T) {
+		s3Key := getStored("b.txt").Payload.GetS3Object().GetKey()
+		// Posters move with the attachment; other variants are dropped.
+		s3Driver, err := ts.Store.ResolveAttachmentVariantDriver(ctx, getStored("b.txt"))
+		require.NoError(t, err)
+		for _, name := range []string{store.AttachmentPosterVariant, "small.jpeg"} {
+			reference, err := s3Driver.UploadObject(ctx, store.AttachmentVariantKey(getStored("b.txt"), name), "image/jpeg", bytes.NewReader([]byte(name)))
+			require.NoError(t, err)
+			_, err = ts.Store.UpsertAttachmentVariant(ctx, &store.AttachmentVariant{
+				AttachmentID: getStored("b.txt").ID, Name: name, Type: "image/jpeg", Size: int64(len(name)), Reference: reference,
+			})
+			require.NoError(t, err)
+		}
+
+		response, err := ts.Service.MigrateAttachments(adminCtx, &v1pb.MigrateAttachmentsRequest{
+			SourceStorageId: s3Storage.Id,
+			TargetStorageId: "local",
+			Filter:          `filename == "b.txt"`,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(1), response.TotalCount)
+		require.Equal(t, int32(1), response.MigratedCount)
+
+		stored := getStored("b.txt")
+		require.Equal(t, storepb.AttachmentStorageType_LOCAL, stored.StorageType)
+		require.Nil(t, stored.Payload.GetS3Object())
+		localPath := filepath.FromSlash(stored.Reference)
+		if !filepath.IsAbs(localPath) {
+			localPath = filepath.Join(ts.Profile.Data, localPath)
+		}
+		content, err := os.ReadFile(localPath)
+		require.NoError(t, err)
+		require.Equal(t, contents["b.txt"], content)
+		_, err = fake.GetObject("migrated", s3Key)
+		require.Error(t, err, "a migrated attachment must be removed from the source storage")
+
+		variants, err := ts.Store.ListAttachmentVariants(ctx, &store.FindAttachmentVariant{AttachmentID: &stored.ID})
+		require.NoError(t, err)
+		require.Len(t, variants, 1)
+		require.Equal(t, store.AttachmentPosterVariant, variants[0].Name)
+		localDriver, err := ts.Store.ResolveAttachmentVariantDriver(ctx, stored)
+		require.NoError(t, err)
+		poster, err := localDriver.GetObject(ctx, variants[0].Reference)
+		require.NoError(t, err)
+		require.Equal(t, []byte(store.AttachmentPosterVariant), poster)
+		_, err = s3Driver.GetObject(ctx, variants[0].Reference)
+		require.Error(t, err, "variants must be removed from the source storage")
+
+		require.Equal(t, storepb.AttachmentStorageType_S3, getStored("a.txt").StorageType)
+	})
+
+	t.Run("rejects invalid filters", func(t *testing.T) {
+		_, err := ts.Service.MigrateAttachments(adminCtx, &v1pb.MigrateAttachmentsRequest{
+			SourceStorageId: s3Storage.Id,
+			TargetStorageId: "local",
+			Filter:          "unknown_field == 1",
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
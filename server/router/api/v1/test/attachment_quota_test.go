@@ -0,0 +1,202 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestAttachmentStorageQuota(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	admin, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	adminCtx := ts.CreateUserContext(ctx, admin.ID)
+	user, err := ts.CreateRegularUser(ctx, "quota-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	heavy, err := ts.CreateRegularUser(ctx, "quota-heavy")
+	require.NoError(t, err)
+	heavyCtx := ts.CreateUserContext(ctx, heavy.ID)
+
+	updated, err := ts.Service.UpdateInstanceSetting(adminCtx, &v1pb.UpdateInstanceSettingRequest{
+		Setting: &v1pb.InstanceSetting{
+			Name: "instance/settings/STORAGE",
+			Value: &v1pb.InstanceSetting_StorageSetting_{
+				StorageSetting: &v1pb.InstanceSetting_StorageSetting{
+					UploadSizeLimitMb: 30,
+					AllowedMimeTypes:  []string{"text/plain", " Image/* "},
+					BlockedMimeTypes:  []string{"image/svg+xml"},
+					Quota: &v1pb.InstanceSetting_StorageQuota{
+						UserQuotaMb: 1,
+						UserOverrides: []*v1pb.InstanceSetting_StorageQuota_UserOverride{
+							{User: "users/quota-heavy", QuotaMb: 3},
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	storageSetting := updated.GetStorageSetting()
+	require.Equal(t, []string{"text/plain", "image/*"}, storageSetting.AllowedMimeTypes)
+	require.Equal(t, int64(1), storageSetting.GetQuota().GetUserQuotaMb())
+	require.Len(t, storageSetting.GetQuota().GetUserOverrides(), 1)
+	require.Equal(t, "users/quota-heavy", storageSetting.GetQuota().GetUserOverrides()[0].User)
+
+	fetched, err := ts.Service.GetInstanceSetting(adminCtx, &v1pb.GetInstanceSettingRequest{Name: "instance/settings/STORAGE"})
+	require.NoError(t, err)
+	require.Equal(t, "users/quota-heavy", fetched.GetStorageSetting().GetQuota().GetUserOverrides()[0].User)
+
+	create := func(ctx context.Context, filename, mimeType string, size int) error {
+		_, err := ts.Service.CreateAttachment(ctx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: filename, Type: mimeType, Content: bytes.Repeat([]byte{'a' + byte(size%26)}, size)},
+		})
+		return err
+	}
+	const mebibyte = 1024 * 1024
+
+	t.Run("rejects MIME types outside the policy", func(t *testing.T) {
+		require.Equal(t, codes.InvalidArgument, status.Code(create(userCtx, "doc.pdf", "application/pdf", 10)))
+		require.Equal(t, codes.InvalidArgument, status.Code(create(userCtx, "logo.svg", "image/svg+xml", 10)))
+		require.NoError(t, create(userCtx, "note.txt", "text/plain", 10))
+	})
+
+	t.Run("rejects uploads over the quota", func(t *testing.T) {
+		require.NoError(t, create(userCtx, "big.txt", "text/plain", mebibyte/2))
+		err := create(userCtx, "bigger.txt", "text/plain", mebibyte/2+1)
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+		require.Contains(t, err.Error(), "storage quota exceeded")
+
+		_, err = ts.Service.CreateUploadSession(userCtx, &v1pb.CreateUploadSessionRequest{
+			UploadSession: &v1pb.UploadSession{Filename: "chunked.txt", Type: "text/plain", Size: mebibyte},
+		})
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("applies per-user overrides", func(t *testing.T) {
+		require.NoError(t, create(heavyCtx, "one.txt", "text/plain", 2*mebibyte))
+		require.Equal(t, codes.ResourceExhausted, status.Code(create(heavyCtx, "two.txt", "text/plain", 2*mebibyte)))
+	})
+
+	t.Run("reports usage in user stats to the user and admins", func(t *testing.T) {
+		stats, err := ts.Service.GetUserStats(userCtx, &v1pb.GetUserStatsRequest{Name: "users/quota-user"})
+		require.NoError(t, err)
+		usage := stats.GetStorageUsage()
+		require.NotNil(t, usage)
+		require.Equal(t, int32(2), usage.AttachmentCount)
+		require.Equal(t, int64(10+mebibyte/2), usage.SizeBytes)
+		require.Equal(t, int64(mebibyte), usage.QuotaBytes)
+
+		stats, err = ts.Service.GetUserStats(adminCtx, &v1pb.GetUserStatsRequest{Name: "users/quota-heavy"})
+		require.NoError(t, err)
+		require.Equal(t, int64(3*mebibyte), stats.GetStorageUsage().GetQuotaBytes())
+
+		stats, err = ts.Service.GetUserStats(heavyCtx, &v1pb.GetUserStatsRequest{Name: "users/quota-user"})
+		require.NoError(t, err)
+		require.Nil(t, stats.StorageUsage)
+	})
+
+	t.Run("reports attachment totals in instance stats", func(t *testing.T) {
+		stats, err := ts.Service.GetInstanceStats(adminCtx, &v1pb.GetInstanceStatsRequest{})
+		require.NoError(t, err)
+		require.Equal(t, int32(3), stats.GetAttachments().GetCount())
+		require.Equal(t, int64(10+mebibyte/2+2*mebibyte), stats.GetAttachments().GetSizeBytes())
+	})
+
+	t.Run("lists top storage consumers", func(t *testing.T) {
+		_, err := ts.Service.ListStorageConsumers(userCtx, &v1pb.ListStorageConsumersRequest{})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		response, err := ts.Service.ListStorageConsumers(adminCtx, &v1pb.ListStorageConsumersRequest{PageSize: 1})
+		require.NoError(t, err)
+		require.Len(t, response.Consumers, 1)
+		require.Equal(t, "users/quota-heavy", response.Consumers[0].User)
+		require.Equal(t, int64(2*mebibyte), response.Consumers[0].SizeBytes)
+
+		response, err = ts.Service.ListStorageConsumers(adminCtx, &v1pb.ListStorageConsumersRequest{})
+		require.NoError(t, err)
+		require.Len(t, response.Consumers, 2)
+		require.Equal(t, "users/quota-user", response.Consumers[1].User)
+	})
+
+	t.Run("rejects invalid policies", func(t *testing.T) {
+		for _, storageSetting := range []*v1pb.InstanceSetting_StorageSetting{
+			{AllowedMimeTypes: []string{"text"}},
+			{Quota: &v1pb.InstanceSetting_StorageQuota{UserQuotaMb: -1}},
+			{Quota: &v1pb.InstanceSetting_StorageQuota{UserOverrides: []*v1pb.InstanceSetting_StorageQuota_UserOverride{{User: "users/missing", QuotaMb: 1}}}},
+		} {
+			_, err := ts.Service.UpdateInstanceSetting(adminCtx, &v1pb.UpdateInstanceSettingRequest{
+				Setting: &v1pb.InstanceSetting{
+					Name:  "instance/settings/STORAGE",
+					Value: &v1pb.InstanceSetting_StorageSetting_{StorageSetting: storageSetting},
+				},
+			})
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func TestAttachmentStorageQuotaConcurrentUploads(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	admin, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	adminCtx := ts.CreateUserContext(ctx, admin.ID)
+	user, err := ts.CreateRegularUser(ctx, "quota-racer")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	_, err = ts.Service.UpdateInstanceSetting(adminCtx, &v1pb.UpdateInstanceSettingRequest{
+		Setting: &v1pb.InstanceSetting{
+			Name: "instance/settings/STORAGE",
+			Value: &v1pb.InstanceSetting_StorageSetting_{
+				StorageSetting: &v1pb.InstanceSetting_StorageSetting{
+					Quota: &v1pb.InstanceSetting_StorageQuota{UserQuotaMb: 1},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Each upload fits on its own, but only three fit together.
+	const uploads = 8
+	const size = 300 * 1024
+	var wg sync.WaitGroup
+	errs := make([]error, uploads)
+	for i := range uploads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+				Attachment: &v1pb.Attachment{Filename: fmt.Sprintf("race-%d.txt", i), Type: "text/plain", Content: bytes.Repeat([]byte{'a' + byte(i)}, size)},
+			})
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		require.Equal(t, codes.ResourceExhausted, status.Code(err), err)
+	}
+	require.Equal(t, 3, created)
+	stats, err := ts.Service.GetUserStats(userCtx, &v1pb.GetUserStatsRequest{Name: "users/quota-racer"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3*size), stats.GetStorageUsage().GetSizeBytes())
+}
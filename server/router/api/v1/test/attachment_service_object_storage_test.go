@@ -0,0 +1,115 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/testutil/fakesftp"
+	"github.com/usememos/memos/internal/testutil/fakewebdav"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestObjectStorageAttachmentLifecycle(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup returns the storage and a function reading an object back from it.
+		setup func(t *testing.T) (*storepb.Storage, func(key string) ([]byte, error))
+	}{
+		{
+			name: "WebDAV",
+			setup: func(t *testing.T) (*storepb.Storage, func(key string) ([]byte, error)) {
+				server := fakewebdav.New(t)
+				return &storepb.Storage{
+					Id:     "nas-webdav",
+					Name:   "NAS WebDAV",
+					Type:   storepb.StorageType_STORAGE_TYPE_WEBDAV,
+					Config: &storepb.Storage_WebdavConfig{WebdavConfig: server.Config("memos")},
+				}, func(key string) ([]byte, error) {
+					return server.GetObject("memos/" + key)
+				}
+			},
+		},
+		{
+			name: "SFTP",
+			setup: func(t *testing.T) (*storepb.Storage, func(key string) ([]byte, error)) {
+				server := fakesftp.New(t)
+				return &storepb.Storage{
+					Id:     "nas-sftp",
+					Name:   "NAS SFTP",
+					Type:   storepb.StorageType_STORAGE_TYPE_SFTP,
+					Config: &storepb.Storage_SftpConfig{SftpConfig: server.Config("/memos")},
+				}, func(key string) ([]byte, error) {
+					return server.GetObject("/memos/" + key)
+				}
+			},
+		},
+		{
+			name: "local path",
+			setup: func(t *testing.T) (*storepb.Storage, func(key string) ([]byte, error)) {
+				dir := t.TempDir()
+				return &storepb.Storage{
+					Id:     "nas-mount",
+					Name:   "NAS mount",
+					Type:   storepb.StorageType_STORAGE_TYPE_LOCAL,
+					Config: &storepb.Storage_LocalConfig{LocalConfig: &storepb.StorageLocalConfig{Path: dir}},
+				}, func(key string) ([]byte, error) {
+					return os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			ts := NewTestService(t)
+			defer ts.Cleanup()
+
+			user, err := ts.CreateRegularUser(ctx, "object-storage-user")
+			require.NoError(t, err)
+			userCtx := ts.CreateUserContext(ctx, user.ID)
+
+			configuredStorage, readObject := test.setup(t)
+			upsertS3StorageSetting(ctx, t, ts, configuredStorage.Id, configuredStorage)
+
+			content := []byte("attachment kept on the NAS")
+			attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+				Attachment: &v1pb.Attachment{
+					Filename: "nas.txt",
+					Type:     "text/plain",
+					Content:  content,
+				},
+			})
+			require.NoError(t, err)
+
+			uid, err := apiv1.ExtractAttachmentUIDFromName(attachment.Name)
+			require.NoError(t, err)
+			stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &uid})
+			require.NoError(t, err)
+			require.Equal(t, storepb.AttachmentStorageType_OBJECT, stored.StorageType)
+			require.Empty(t, stored.Blob)
+			require.Empty(t, stored.Reference)
+			require.Equal(t, configuredStorage.Id, stored.Payload.GetStorageObject().GetStorageId())
+
+			key := stored.Payload.GetStorageObject().GetKey()
+			storedContent, err := readObject(key)
+			require.NoError(t, err)
+			require.Equal(t, content, storedContent)
+			downloaded, err := ts.Service.GetAttachmentBlob(ctx, stored)
+			require.NoError(t, err)
+			require.Equal(t, content, downloaded)
+
+			_, err = ts.Service.DeleteAttachment(userCtx, &v1pb.DeleteAttachmentRequest{Name: attachment.Name})
+			require.NoError(t, err)
+			_, err = readObject(key)
+			require.Error(t, err, "deleting an attachment must delete its storage object")
+		})
+	}
+}
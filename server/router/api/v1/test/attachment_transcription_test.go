@@ -0,0 +1,215 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/mediapreview"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestAttachmentTranscription(t *testing.T) {
+	ctx := context.Background()
+
+	newTranscriptionServer := func(t *testing.T, requests *atomic.Int32) *httptest.Server {
+		t.Helper()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			require.NoError(t, r.ParseMultipartForm(10<<20))
+			require.Equal(t, "whisper-1", r.FormValue("model"))
+			require.Equal(t, "verbose_json", r.FormValue("response_format"))
+
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+				"text":     "Discussed the quarterly roadmap",
+				"language": "english",
+				"segments": []map[string]any{
+					{"id": 0, "start": 0.0, "end": 1.5, "text": " Discussed the"},
+					{"id": 1, "start": 1.5, "end": 3.0, "text": " quarterly roadmap"},
+				},
+			}))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	configureTranscription := func(t *testing.T, ts *TestService, endpoint string, auto bool) {
+		t.Helper()
+		_, err := ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+			Key: storepb.InstanceSettingKey_AI,
+			Value: &storepb.InstanceSetting_AiSetting{
+				AiSetting: &storepb.InstanceAISetting{
+					Providers: []*storepb.AIProviderConfig{
+						{
+							Id:       "openai-main",
+							Title:    "OpenAI",
+							Type:     storepb.AIProviderType_OPENAI,
+							Endpoint: endpoint,
+							ApiKey:   "sk-test",
+						},
+					},
+					Transcription: &storepb.TranscriptionConfig{
+						ProviderId:                "openai-main",
+						Model:                     "whisper-1",
+						AutoTranscribeAttachments: auto,
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("transcribes audio attachments in the background", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "alice")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		var requests atomic.Int32
+		server := newTranscriptionServer(t, &requests)
+		configureTranscription(t, ts, server.URL, true)
+
+		memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+			Memo: &v1pb.Memo{Content: "Voice note", Visibility: v1pb.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "recording.webm",
+				Type:     "audio/webm;codecs=opus",
+				Content:  []byte("fake webm content"),
+				Memo:     &memo.Name,
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, attachment.Transcript, "the upload must not wait for the provider")
+
+		transcribed := ts.WaitForAttachment(userCtx, t, attachment.Name, func(attachment *v1pb.Attachment) bool {
+			return attachment.Transcript != nil
+		})
+
+		require.Equal(t, "Discussed the quarterly roadmap", transcribed.Transcript.Text)
+		require.Equal(t, "english", transcribed.Transcript.Language)
+		require.NotNil(t, transcribed.Transcript.CreateTime)
+		require.Len(t, transcribed.Transcript.Segments, 2)
+		require.Equal(t, "quarterly roadmap", transcribed.Transcript.Segments[1].Text)
+		require.InDelta(t, 1.5, transcribed.Transcript.Segments[1].StartSeconds, 1e-9)
+		require.InDelta(t, 3.0, transcribed.Transcript.Segments[1].EndSeconds, 1e-9)
+
+		// The memo is found by its transcript through both search paths.
+		for _, filter := range []string{`transcript.contains("roadmap")`, `content.contains("roadmap")`} {
+			resp, err := ts.Service.ListMemos(userCtx, &v1pb.ListMemosRequest{Filter: filter})
+			require.NoError(t, err, filter)
+			require.Len(t, resp.Memos, 1, filter)
+			require.Equal(t, memo.Name, resp.Memos[0].Name, filter)
+		}
+
+		attachments, err := ts.Service.ListAttachments(userCtx, &v1pb.ListAttachmentsRequest{Filter: `transcript.contains("quarterly")`})
+		require.NoError(t, err)
+		require.Len(t, attachments.Attachments, 1)
+	})
+
+	t.Run("skips attachments when automatic transcription is disabled", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "bob")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		var requests atomic.Int32
+		server := newTranscriptionServer(t, &requests)
+		configureTranscription(t, ts, server.URL, false)
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "recording.webm",
+				Type:     "audio/webm",
+				Content:  []byte("fake webm content"),
+			},
+		})
+		require.NoError(t, err)
+
+		require.Never(t, func() bool { return requests.Load() > 0 }, 200*time.Millisecond, 20*time.Millisecond)
+		fetched, err := ts.Service.GetAttachment(userCtx, &v1pb.GetAttachmentRequest{Name: attachment.Name})
+		require.NoError(t, err)
+		require.Nil(t, fetched.Transcript)
+	})
+
+	t.Run("skips non-audio attachments", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "carol")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		var requests atomic.Int32
+		server := newTranscriptionServer(t, &requests)
+		configureTranscription(t, ts, server.URL, true)
+
+		_, err = ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "notes.txt",
+				Type:     "text/plain",
+				Content:  []byte("plain text"),
+			},
+		})
+		require.NoError(t, err)
+
+		require.Never(t, func() bool { return requests.Load() > 0 }, 200*time.Millisecond, 20*time.Millisecond)
+	})
+
+	t.Run("keeps the waveform generated alongside the transcript", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("fake ffmpeg requires a POSIX shell")
+		}
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "dave")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		var requests atomic.Int32
+		server := newTranscriptionServer(t, &requests)
+		configureTranscription(t, ts, server.URL, true)
+		ffmpeg := filepath.Join(t.TempDir(), "ffmpeg")
+		require.NoError(t, os.WriteFile(ffmpeg, []byte(`#!/bin/sh
+eval out=\${$#}
+printf '\000\100\000\300' > "$out"
+`), 0o755))
+		ts.Service.MediaPreviewGenerator = mediapreview.New(ffmpeg)
+
+		// Both background jobs update the payload of the same attachment, and
+		// neither may drop the result of the other.
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: "recording.webm",
+				Type:     "audio/webm",
+				Content:  []byte("fake webm content"),
+			},
+		})
+		require.NoError(t, err)
+
+		processed := ts.WaitForAttachment(userCtx, t, attachment.Name, func(attachment *v1pb.Attachment) bool {
+			return attachment.Transcript != nil && attachment.MediaPreview != nil
+		})
+		require.Equal(t, "Discussed the quarterly roadmap", processed.Transcript.Text)
+		require.Equal(t, []float32{0.5, 0.5}, processed.MediaPreview.WaveformPeaks)
+	})
+}
@@ -0,0 +1,405 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/internal/testutil/fakes3"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/auth"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/server/runner/uploadsession"
+	"github.com/usememos/memos/store"
+)
+
+type uploadClient struct {
+	t     *testing.T
+	echo  *echo.Echo
+	token string
+}
+
+func newUploadClient(t *testing.T, ts *TestService, user *store.User) *uploadClient {
+	t.Helper()
+	token, _, err := auth.GenerateAccessTokenV2(user.ID, user.Username, string(user.Role), string(user.RowStatus), []byte(ts.Secret))
+	require.NoError(t, err)
+	e := echo.New()
+	ts.Service.RegisterUploadRoutes(e)
+	return &uploadClient{t: t, echo: e, token: token}
+}
+
+func (c *uploadClient) put(uploadURL string, offset int64, chunk []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, uploadURL, bytes.NewReader(chunk))
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	rec := httptest.NewRecorder()
+	c.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (c *uploadClient) head(uploadURL string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodHead, uploadURL, nil)
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	rec := httptest.NewRecorder()
+	c.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+// uploadInChunks sends content in chunk-sized pieces and checks the offsets.
+func (c *uploadClient) uploadInChunks(session *v1pb.UploadSession, content []byte) {
+	c.t.Helper()
+	for offset := int64(0); offset < int64(len(content)); offset += session.ChunkSize {
+		end := min(offset+session.ChunkSize, int64(len(content)))
+		rec := c.put(session.UploadUrl, offset, content[offset:end])
+		require.Equal(c.t, http.StatusNoContent, rec.Code, rec.Body.String())
+		require.Equal(c.t, strconv.FormatInt(end, 10), rec.Header().Get("Upload-Offset"))
+	}
+}
+
+func TestUploadSessionStagedToLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "uploader")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	client := newUploadClient(t, ts, user)
+
+	memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "upload target", Visibility: v1pb.Visibility_PRIVATE}})
+	require.NoError(t, err)
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), (9<<20)/16)
+	session, err := ts.Service.CreateUploadSession(userCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{
+			Filename: "archive.bin",
+			Size:     int64(len(content)),
+			Memo:     &memo.Name,
+		},
+		AttachmentId: "resumed-archive",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "application/octet-stream", session.Type)
+	require.Zero(t, session.Offset)
+	require.Equal(t, memo.Name, session.GetMemo())
+	sessionUID, err := apiv1.ExtractUploadSessionUIDFromName(session.Name)
+	require.NoError(t, err)
+	require.Equal(t, "/api/v1/uploads/"+sessionUID, session.UploadUrl)
+
+	// The first chunk must be exactly the chunk size.
+	rec := client.put(session.UploadUrl, 0, content[:100])
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = client.put(session.UploadUrl, 0, content[:session.ChunkSize])
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	require.Equal(t, strconv.FormatInt(session.ChunkSize, 10), rec.Header().Get("Upload-Offset"))
+
+	// A retried chunk at a stale offset is rejected and reports the current offset.
+	rec = client.put(session.UploadUrl, 0, content[:session.ChunkSize])
+	require.Equal(t, http.StatusConflict, rec.Code)
+
+	_, err = ts.Service.CompleteUploadSession(userCtx, &v1pb.CompleteUploadSessionRequest{Name: session.Name})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// A client resuming after an interruption asks for the offset.
+	rec = client.head(session.UploadUrl)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, strconv.FormatInt(session.ChunkSize, 10), rec.Header().Get("Upload-Offset"))
+	require.Equal(t, strconv.Itoa(len(content)), rec.Header().Get("Upload-Length"))
+	resumed, err := ts.Service.GetUploadSession(userCtx, &v1pb.GetUploadSessionRequest{Name: session.Name})
+	require.NoError(t, err)
+	require.Equal(t, session.ChunkSize, resumed.Offset)
+
+	rec = client.put(session.UploadUrl, session.ChunkSize, content[session.ChunkSize:])
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	attachment, err := ts.Service.CompleteUploadSession(userCtx, &v1pb.CompleteUploadSessionRequest{Name: session.Name})
+	require.NoError(t, err)
+	require.Equal(t, "attachments/resumed-archive", attachment.Name)
+	require.Equal(t, int64(len(content)), attachment.Size)
+	require.Equal(t, memo.Name, attachment.GetMemo())
+
+	attachmentUID := "resumed-archive"
+	stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+	require.NoError(t, err)
+	require.Equal(t, storepb.AttachmentStorageType_LOCAL, stored.StorageType)
+	blob, err := ts.Service.GetAttachmentBlob(ctx, stored)
+	require.NoError(t, err)
+	require.Equal(t, content, blob)
+
+	// The staged file was moved into storage and the session is gone.
+	entries, err := os.ReadDir(filepath.Join(ts.Profile.Data, store.UploadSessionStagingFolder))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	_, err = ts.Service.GetUploadSession(userCtx, &v1pb.GetUploadSessionRequest{Name: session.Name})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestUploadSessionAccessAndCancel(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	owner, err := ts.CreateRegularUser(ctx, "upload-owner")
+	require.NoError(t, err)
+	other, err := ts.CreateRegularUser(ctx, "upload-other")
+	require.NoError(t, err)
+	ownerCtx := ts.CreateUserContext(ctx, owner.ID)
+	otherCtx := ts.CreateUserContext(ctx, other.ID)
+
+	session, err := ts.Service.CreateUploadSession(ownerCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "notes.txt", Size: 5},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "text/plain", session.Type)
+
+	_, err = ts.Service.GetUploadSession(otherCtx, &v1pb.GetUploadSessionRequest{Name: session.Name})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	rec := newUploadClient(t, ts, other).put(session.UploadUrl, 0, []byte("hello"))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, session.UploadUrl, bytes.NewReader([]byte("hello")))
+	req.Header.Set("Upload-Offset", "0")
+	client := newUploadClient(t, ts, owner)
+	client.echo.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	_, err = ts.Service.DeleteUploadSession(ownerCtx, &v1pb.DeleteUploadSessionRequest{Name: session.Name})
+	require.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Join(ts.Profile.Data, store.UploadSessionStagingFolder))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	rec = client.put(session.UploadUrl, 0, []byte("hello"))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	_, err = ts.Service.CreateUploadSession(ownerCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "huge.bin", Size: 1 << 40},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUploadSessionSweepWaitsForChunkWrite(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "slow-uploader")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	session, err := ts.Service.CreateUploadSession(userCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "notes.txt", Size: 5},
+	})
+	require.NoError(t, err)
+	sessionUID, err := apiv1.ExtractUploadSessionUIDFromName(session.Name)
+	require.NoError(t, err)
+	stored, err := ts.Store.GetUploadSession(ctx, &store.FindUploadSession{UID: &sessionUID})
+	require.NoError(t, err)
+
+	// The session expires while a chunk write holds its lock; the write then
+	// extends the session, so the sweep waiting on the lock must keep it.
+	unlock := ts.Store.LockUploadSession(sessionUID)
+	expiresTs := int64(1)
+	require.NoError(t, ts.Store.UpdateUploadSession(ctx, &store.UpdateUploadSession{ID: stored.ID, ExpiresTs: &expiresTs}))
+	swept := make(chan struct{})
+	go func() {
+		uploadsession.NewRunner(ts.Store).RunOnce(ctx)
+		close(swept)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	expiresTs = time.Now().Add(time.Hour).Unix()
+	require.NoError(t, ts.Store.UpdateUploadSession(ctx, &store.UpdateUploadSession{ID: stored.ID, ExpiresTs: &expiresTs}))
+	unlock()
+	<-swept
+
+	resumed, err := ts.Service.GetUploadSession(userCtx, &v1pb.GetUploadSessionRequest{Name: session.Name})
+	require.NoError(t, err)
+	require.Zero(t, resumed.Offset)
+	entries, err := os.ReadDir(filepath.Join(ts.Profile.Data, store.UploadSessionStagingFolder))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestUploadSessionS3Multipart(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	fake := fakes3.New(t, "uploads")
+	storage := fakeStorage("s3-uploads", "Uploads", fake.Config("uploads"))
+	upsertS3StorageSetting(ctx, t, ts, storage.Id, storage)
+
+	user, err := ts.CreateRegularUser(ctx, "multipart-uploader")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	client := newUploadClient(t, ts, user)
+
+	content := bytes.Repeat([]byte("multipart-"), (17<<20)/10)
+	session, err := ts.Service.CreateUploadSession(userCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "movie.mp4", Type: "video/mp4", Size: int64(len(content))},
+	})
+	require.NoError(t, err)
+	sessionUID, err := apiv1.ExtractUploadSessionUIDFromName(session.Name)
+	require.NoError(t, err)
+	stored, err := ts.Store.GetUploadSession(ctx, &store.FindUploadSession{UID: &sessionUID})
+	require.NoError(t, err)
+	require.NotEmpty(t, stored.Payload.GetMultipartUpload().GetUploadId())
+
+	client.uploadInChunks(session, content)
+	attachment, err := ts.Service.CompleteUploadSession(userCtx, &v1pb.CompleteUploadSessionRequest{Name: session.Name})
+	require.NoError(t, err)
+
+	attachmentUID, err := apiv1.ExtractAttachmentUIDFromName(attachment.Name)
+	require.NoError(t, err)
+	storedAttachment, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+	require.NoError(t, err)
+	require.Equal(t, storepb.AttachmentStorageType_S3, storedAttachment.StorageType)
+	require.Equal(t, storage.Id, storedAttachment.Payload.GetS3Object().GetStorageId())
+	object, err := fake.GetObject("uploads", storedAttachment.Payload.GetS3Object().GetKey())
+	require.NoError(t, err)
+	require.Equal(t, content, object)
+	contentDigest := sha256.Sum256(content)
+	require.Equal(t, hex.EncodeToString(contentDigest[:]), storedAttachment.SHA256)
+
+	// Sessions that expire before completion are discarded by the runner.
+	abandoned, err := ts.Service.CreateUploadSession(userCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "abandoned.mp4", Type: "video/mp4", Size: 10},
+	})
+	require.NoError(t, err)
+	abandonedUID, err := apiv1.ExtractUploadSessionUIDFromName(abandoned.Name)
+	require.NoError(t, err)
+	abandonedSession, err := ts.Store.GetUploadSession(ctx, &store.FindUploadSession{UID: &abandonedUID})
+	require.NoError(t, err)
+	expiresTs := int64(1)
+	require.NoError(t, ts.Store.UpdateUploadSession(ctx, &store.UpdateUploadSession{ID: abandonedSession.ID, ExpiresTs: &expiresTs}))
+	_, err = ts.Service.GetUploadSession(userCtx, &v1pb.GetUploadSessionRequest{Name: abandoned.Name})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	uploadsession.NewRunner(ts.Store).RunOnce(ctx)
+	abandonedSession, err = ts.Store.GetUploadSession(ctx, &store.FindUploadSession{UID: &abandonedUID})
+	require.NoError(t, err)
+	require.Nil(t, abandonedSession)
+}
+
+func TestUploadSessionS3DirectUpload(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	fake := fakes3.New(t, "direct")
+	config := fake.Config("direct")
+	config.PresignedTransfers = true
+	storage := fakeStorage("s3-direct", "Direct", config)
+	upsertS3StorageSetting(ctx, t, ts, storage.Id, storage)
+
+	user, err := ts.CreateRegularUser(ctx, "direct-uploader")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	client := newUploadClient(t, ts, user)
+
+	putDirect := func(session *v1pb.UploadSession, content []byte) {
+		t.Helper()
+		request, err := http.NewRequestWithContext(ctx, http.MethodPut, session.DirectUploadUrl, bytes.NewReader(content))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", session.Type)
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		require.Equal(t, http.StatusOK, response.StatusCode)
+	}
+
+	content := []byte("%PDF-1.7\nuploaded straight to the bucket")
+	session, err := ts.Service.CreateUploadSession(userCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "report.pdf", Type: "application/pdf", Size: int64(len(content))},
+		DirectUpload:  true,
+	})
+	require.NoError(t, err)
+	require.Empty(t, session.UploadUrl)
+	require.True(t, strings.HasPrefix(session.DirectUploadUrl, fake.URL), session.DirectUploadUrl)
+
+	// Direct sessions never accept chunks through the server.
+	sessionUID, err := apiv1.ExtractUploadSessionUIDFromName(session.Name)
+	require.NoError(t, err)
+	stored, err := ts.Store.GetUploadSession(ctx, &store.FindUploadSession{UID: &sessionUID})
+	require.NoError(t, err)
+	rec := client.put("/api/v1/uploads/"+sessionUID, 0, content)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	_, err = ts.Service.CompleteUploadSession(userCtx, &v1pb.CompleteUploadSessionRequest{Name: session.Name})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// A truncated object is rejected and removed so the client can retry.
+	putDirect(session, content[:10])
+	_, err = ts.Service.CompleteUploadSession(userCtx, &v1pb.CompleteUploadSessionRequest{Name: session.Name})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Contains(t, err.Error(), "expected")
+
+	putDirect(session, content)
+	attachment, err := ts.Service.CompleteUploadSession(userCtx, &v1pb.CompleteUploadSessionRequest{Name: session.Name})
+	require.NoError(t, err)
+	attachmentUID, err := apiv1.ExtractAttachmentUIDFromName(attachment.Name)
+	require.NoError(t, err)
+	storedAttachment, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+	require.NoError(t, err)
+	require.Equal(t, storepb.AttachmentStorageType_S3, storedAttachment.StorageType)
+	require.Equal(t, storage.Id, storedAttachment.Payload.GetS3Object().GetStorageId())
+	object, err := fake.GetObject("direct", storedAttachment.Payload.GetS3Object().GetKey())
+	require.NoError(t, err)
+	require.Equal(t, content, object)
+	contentDigest := sha256.Sum256(content)
+	require.Equal(t, hex.EncodeToString(contentDigest[:]), storedAttachment.SHA256)
+
+	// The presigned URL only ever reaches the staging key, which completion
+	// copied out of and removed, so reusing it cannot replace the attachment.
+	directUpload := stored.Payload.GetDirectUpload()
+	require.NotEqual(t, directUpload.GetKey(), storedAttachment.Payload.GetS3Object().GetKey())
+	_, err = fake.GetObject("direct", directUpload.GetKey())
+	require.Error(t, err)
+	putDirect(session, []byte("%PDF-1.7\nswapped after the checks passed"))
+	object, err = fake.GetObject("direct", storedAttachment.Payload.GetS3Object().GetKey())
+	require.NoError(t, err)
+	require.Equal(t, content, object)
+
+	// Content that sniffs as HTML is never accepted under another type.
+	html := []byte("<html><script>alert(1)</script></html>")
+	disguised, err := ts.Service.CreateUploadSession(userCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "notes.md", Type: "text/markdown", Size: int64(len(html))},
+		DirectUpload:  true,
+	})
+	require.NoError(t, err)
+	putDirect(disguised, html)
+	_, err = ts.Service.CompleteUploadSession(userCtx, &v1pb.CompleteUploadSessionRequest{Name: disguised.Name})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	disguisedUID, err := apiv1.ExtractUploadSessionUIDFromName(disguised.Name)
+	require.NoError(t, err)
+	disguisedSession, err := ts.Store.GetUploadSession(ctx, &store.FindUploadSession{UID: &disguisedUID})
+	require.NoError(t, err)
+	_, err = fake.GetObject("direct", disguisedSession.Payload.GetDirectUpload().GetKey())
+	require.Error(t, err)
+
+	// Without presigned transfers the request falls back to chunked uploads.
+	config.PresignedTransfers = false
+	upsertS3StorageSetting(ctx, t, ts, storage.Id, storage)
+	fallback, err := ts.Service.CreateUploadSession(userCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "fallback.pdf", Type: "application/pdf", Size: int64(len(content))},
+		DirectUpload:  true,
+	})
+	require.NoError(t, err)
+	require.Empty(t, fallback.DirectUploadUrl)
+	require.NotEmpty(t, fallback.UploadUrl)
+}
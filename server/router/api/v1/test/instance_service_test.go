@@ -2,6 +2,9 @@ package test
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -463,6 +466,163 @@ func TestTestInstanceEmailSettingRequiresPasswordWhenSMTPIdentityChanges(t *test
 	require.Contains(t, err.Error(), "smtp password is required")
 }
 
+func TestTestAIProvider(t *testing.T) {
+	ctx := context.Background()
+
+	newOllamaServer := func(t *testing.T, wantAuthorization string) *httptest.Server {
+		t.Helper()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/tags", r.URL.Path)
+			require.Equal(t, wantAuthorization, r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+				"models": []map[string]string{
+					{"name": "qwen2.5:7b", "model": "qwen2.5:7b"},
+					{"name": "llama3.2:latest", "model": "llama3.2:latest"},
+				},
+			}))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("TestAIProvider - requires admin", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		regularUser, err := ts.CreateRegularUser(ctx, "ai-test-user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, regularUser.ID)
+
+		req := &v1pb.TestAIProviderRequest{
+			Provider: &v1pb.InstanceSetting_AIProviderConfig{Type: v1pb.InstanceSetting_OLLAMA},
+		}
+
+		_, err = ts.Service.TestAIProvider(ctx, req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not authenticated")
+
+		_, err = ts.Service.TestAIProvider(userCtx, req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "permission denied")
+	})
+
+	t.Run("TestAIProvider - lists models of an unsaved provider", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		admin, err := ts.CreateHostUser(ctx, "ai-test-admin")
+		require.NoError(t, err)
+		adminCtx := ts.CreateUserContext(ctx, admin.ID)
+
+		server := newOllamaServer(t, "")
+		resp, err := ts.Service.TestAIProvider(adminCtx, &v1pb.TestAIProviderRequest{
+			Provider: &v1pb.InstanceSetting_AIProviderConfig{
+				Type:           v1pb.InstanceSetting_OLLAMA,
+				Endpoint:       server.URL,
+				TimeoutSeconds: 5,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Models, 2)
+		require.Equal(t, "llama3.2:latest", resp.Models[0].Id)
+		require.Equal(t, "qwen2.5:7b", resp.Models[1].Id)
+	})
+
+	t.Run("TestAIProvider - validates the provider", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		admin, err := ts.CreateHostUser(ctx, "ai-test-admin")
+		require.NoError(t, err)
+		adminCtx := ts.CreateUserContext(ctx, admin.ID)
+
+		_, err = ts.Service.TestAIProvider(adminCtx, &v1pb.TestAIProviderRequest{
+			Provider: &v1pb.InstanceSetting_AIProviderConfig{Type: v1pb.InstanceSetting_OPENAI_COMPATIBLE},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "endpoint is required")
+
+		_, err = ts.Service.TestAIProvider(adminCtx, &v1pb.TestAIProviderRequest{
+			Provider: &v1pb.InstanceSetting_AIProviderConfig{Type: v1pb.InstanceSetting_OPENAI},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "api key is required")
+	})
+
+	t.Run("TestAIProvider - reuses the stored key only for the same connection", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		admin, err := ts.CreateHostUser(ctx, "ai-test-admin")
+		require.NoError(t, err)
+		adminCtx := ts.CreateUserContext(ctx, admin.ID)
+
+		server := newOllamaServer(t, "Bearer stored-key")
+		_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+			Key: storepb.InstanceSettingKey_AI,
+			Value: &storepb.InstanceSetting_AiSetting{
+				AiSetting: &storepb.InstanceAISetting{
+					Providers: []*storepb.AIProviderConfig{
+						{
+							Id:       "ollama",
+							Title:    "Ollama",
+							Type:     storepb.AIProviderType_OLLAMA,
+							Endpoint: server.URL,
+							ApiKey:   "stored-key",
+						},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		resp, err := ts.Service.TestAIProvider(adminCtx, &v1pb.TestAIProviderRequest{
+			Provider: &v1pb.InstanceSetting_AIProviderConfig{
+				Id:       "ollama",
+				Type:     v1pb.InstanceSetting_OLLAMA,
+				Endpoint: server.URL + "/",
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Models, 2)
+
+		// The stored key must not be sent to a different server.
+		otherServer := newOllamaServer(t, "")
+		_, err = ts.Service.TestAIProvider(adminCtx, &v1pb.TestAIProviderRequest{
+			Provider: &v1pb.InstanceSetting_AIProviderConfig{
+				Id:       "ollama",
+				Type:     v1pb.InstanceSetting_OLLAMA,
+				Endpoint: otherServer.URL,
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("TestAIProvider - reports connection failures", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		admin, err := ts.CreateHostUser(ctx, "ai-test-admin")
+		require.NoError(t, err)
+		adminCtx := ts.CreateUserContext(ctx, admin.ID)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		_, err = ts.Service.TestAIProvider(adminCtx, &v1pb.TestAIProviderRequest{
+			Provider: &v1pb.InstanceSetting_AIProviderConfig{
+				Type:     v1pb.InstanceSetting_OLLAMA,
+				Endpoint: server.URL,
+			},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to list AI provider models")
+	})
+}
+
 func TestUpdateInstanceSetting(t *testing.T) {
 	ctx := context.Background()
 
@@ -845,6 +1005,84 @@ func TestUpdateInstanceSetting(t *testing.T) {
 		require.True(t, previousStorageFound)
 	})
 
+	t.Run("UpdateInstanceSetting - WebDAV and SFTP secrets are write-only and preserved on empty", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		adminCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+		storageSetting := func(webdavPassword, sftpPrivateKey string) *v1pb.InstanceSetting {
+			return &v1pb.InstanceSetting{
+				Name: "instance/settings/STORAGE",
+				Value: &v1pb.InstanceSetting_StorageSetting_{
+					StorageSetting: &v1pb.InstanceSetting_StorageSetting{
+						DefaultStorageId: "nas-webdav",
+						Storages: []*v1pb.InstanceSetting_Storage{
+							{
+								Id:   "nas-webdav",
+								Name: "NAS WebDAV",
+								Type: v1pb.InstanceSetting_WEBDAV,
+								Config: &v1pb.InstanceSetting_Storage_WebdavConfig{
+									WebdavConfig: &v1pb.InstanceSetting_Storage_WebDAVConfig{
+										Endpoint: "https://nas.example.com/dav/memos",
+										Username: "memos",
+										Password: webdavPassword,
+									},
+								},
+							},
+							{
+								Id:   "nas-sftp",
+								Name: "NAS SFTP",
+								Type: v1pb.InstanceSetting_SFTP,
+								Config: &v1pb.InstanceSetting_Storage_SftpConfig{
+									SftpConfig: &v1pb.InstanceSetting_Storage_SFTPConfig{
+										Host:       "nas.example.com",
+										Username:   "memos",
+										PrivateKey: sftpPrivateKey,
+										HostKey:    "ssh-ed25519 AAAA",
+										Path:       "/volume1/memos",
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		_, err = ts.Service.UpdateInstanceSetting(adminCtx, &v1pb.UpdateInstanceSettingRequest{
+			Setting: storageSetting("dav-secret", "ssh-private-key"),
+		})
+		require.NoError(t, err)
+
+		resp, err := ts.Service.GetInstanceSetting(adminCtx, &v1pb.GetInstanceSettingRequest{
+			Name: "instance/settings/STORAGE",
+		})
+		require.NoError(t, err)
+		for _, configuredStorage := range resp.GetStorageSetting().GetStorages() {
+			require.Empty(t, configuredStorage.GetWebdavConfig().GetPassword(), "WebDAV password must never be returned in responses")
+			require.Empty(t, configuredStorage.GetSftpConfig().GetPassword(), "SFTP password must never be returned in responses")
+			require.Empty(t, configuredStorage.GetSftpConfig().GetPrivateKey(), "SFTP private key must never be returned in responses")
+		}
+
+		_, err = ts.Service.UpdateInstanceSetting(adminCtx, &v1pb.UpdateInstanceSettingRequest{
+			Setting: storageSetting("", ""),
+		})
+		require.NoError(t, err)
+
+		stored, err := ts.Store.GetInstanceStorageSetting(ctx)
+		require.NoError(t, err)
+		storedByID := map[string]*storepb.Storage{}
+		for _, configuredStorage := range stored.GetStorages() {
+			storedByID[configuredStorage.GetId()] = configuredStorage
+		}
+		require.Equal(t, "dav-secret", storedByID["nas-webdav"].GetWebdavConfig().GetPassword())
+		require.Equal(t, "ssh-private-key", storedByID["nas-sftp"].GetSftpConfig().GetPrivateKey())
+		require.Equal(t, "/volume1/memos", storedByID["nas-sftp"].GetSftpConfig().GetPath())
+	})
+
 	t.Run("UpdateInstanceSetting - AI provider keys are write-only and preserved on empty", func(t *testing.T) {
 		ts := NewTestService(t)
 		defer ts.Cleanup()
@@ -1054,4 +1292,119 @@ func TestUpdateInstanceSetting(t *testing.T) {
 		require.Equal(t, "en", stored.GetTranscription().GetLanguage())
 		require.Equal(t, "names: Alice", stored.GetTranscription().GetPrompt())
 	})
+
+	t.Run("UpdateInstanceSetting - self-hosted AI providers", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		adminCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+		update := func(providers []*v1pb.InstanceSetting_AIProviderConfig, transcription *v1pb.InstanceSetting_TranscriptionConfig) error {
+			_, err := ts.Service.UpdateInstanceSetting(adminCtx, &v1pb.UpdateInstanceSettingRequest{
+				Setting: &v1pb.InstanceSetting{
+					Name: "instance/settings/AI",
+					Value: &v1pb.InstanceSetting_AiSetting{
+						AiSetting: &v1pb.InstanceSetting_AISetting{
+							Providers:     providers,
+							Transcription: transcription,
+						},
+					},
+				},
+			})
+			return err
+		}
+		compatible := &v1pb.InstanceSetting_AIProviderConfig{
+			Id:                    "local",
+			Title:                 "vLLM",
+			Type:                  v1pb.InstanceSetting_OPENAI_COMPATIBLE,
+			Endpoint:              "http://10.0.0.5:8000/v1",
+			TimeoutSeconds:        300,
+			MaxConcurrentRequests: 2,
+		}
+		ollama := &v1pb.InstanceSetting_AIProviderConfig{
+			Id:    "ollama",
+			Title: "Ollama",
+			Type:  v1pb.InstanceSetting_OLLAMA,
+		}
+
+		err = update([]*v1pb.InstanceSetting_AIProviderConfig{{Id: "x", Title: "x", Type: v1pb.InstanceSetting_OPENAI_COMPATIBLE}}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "endpoint is required")
+
+		err = update([]*v1pb.InstanceSetting_AIProviderConfig{{Id: "x", Title: "x", Type: v1pb.InstanceSetting_OLLAMA, TimeoutSeconds: 3601}}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "timeout")
+
+		err = update([]*v1pb.InstanceSetting_AIProviderConfig{compatible, ollama}, &v1pb.InstanceSetting_TranscriptionConfig{ProviderId: "ollama", Model: "llama3.2"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not support transcription")
+
+		err = update([]*v1pb.InstanceSetting_AIProviderConfig{compatible, ollama}, &v1pb.InstanceSetting_TranscriptionConfig{ProviderId: "local"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "transcription model is required")
+
+		err = update([]*v1pb.InstanceSetting_AIProviderConfig{compatible, ollama}, &v1pb.InstanceSetting_TranscriptionConfig{ProviderId: "local", Model: "whisper-large-v3"})
+		require.NoError(t, err)
+
+		resp, err := ts.Service.GetInstanceSetting(adminCtx, &v1pb.GetInstanceSettingRequest{Name: "instance/settings/AI"})
+		require.NoError(t, err)
+		providers := resp.GetAiSetting().GetProviders()
+		require.Len(t, providers, 2)
+		require.False(t, providers[0].GetApiKeySet())
+		require.Equal(t, int32(300), providers[0].GetTimeoutSeconds())
+		require.Equal(t, int32(2), providers[0].GetMaxConcurrentRequests())
+		require.Equal(t, "http://localhost:11434", providers[1].GetEndpoint())
+	})
+
+	t.Run("UpdateInstanceSetting - image analysis", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		adminCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+		ollama := &v1pb.InstanceSetting_AIProviderConfig{
+			Id:    "ollama",
+			Title: "Ollama",
+			Type:  v1pb.InstanceSetting_OLLAMA,
+		}
+		update := func(imageAnalysis *v1pb.InstanceSetting_ImageAnalysisConfig) error {
+			_, err := ts.Service.UpdateInstanceSetting(adminCtx, &v1pb.UpdateInstanceSettingRequest{
+				Setting: &v1pb.InstanceSetting{
+					Name: "instance/settings/AI",
+					Value: &v1pb.InstanceSetting_AiSetting{
+						AiSetting: &v1pb.InstanceSetting_AISetting{
+							Providers:     []*v1pb.InstanceSetting_AIProviderConfig{ollama},
+							ImageAnalysis: imageAnalysis,
+						},
+					},
+				},
+			})
+			return err
+		}
+
+		err = update(&v1pb.InstanceSetting_ImageAnalysisConfig{Engine: v1pb.InstanceSetting_ImageAnalysisConfig_AI_PROVIDER, ProviderId: "missing"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not reference any configured provider")
+
+		err = update(&v1pb.InstanceSetting_ImageAnalysisConfig{Engine: v1pb.InstanceSetting_ImageAnalysisConfig_AI_PROVIDER, ProviderId: "ollama"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "image analysis model is required")
+
+		err = update(&v1pb.InstanceSetting_ImageAnalysisConfig{Engine: v1pb.InstanceSetting_ImageAnalysisConfig_AI_PROVIDER, ProviderId: "ollama", Model: " llava "})
+		require.NoError(t, err)
+
+		// Omitting the config keeps the stored one.
+		require.NoError(t, update(nil))
+		resp, err := ts.Service.GetInstanceSetting(adminCtx, &v1pb.GetInstanceSettingRequest{Name: "instance/settings/AI"})
+		require.NoError(t, err)
+		require.Equal(t, v1pb.InstanceSetting_ImageAnalysisConfig_AI_PROVIDER, resp.GetAiSetting().GetImageAnalysis().GetEngine())
+		require.Equal(t, "llava", resp.GetAiSetting().GetImageAnalysis().GetModel())
+
+		err = update(&v1pb.InstanceSetting_ImageAnalysisConfig{Engine: v1pb.InstanceSetting_ImageAnalysisConfig_TESSERACT, Language: "eng+deu"})
+		require.NoError(t, err)
+	})
 }
@@ -0,0 +1,171 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestGetDailyMemo(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "journal")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	settingName := "users/journal/settings/GENERAL"
+
+	_, err = ts.Service.UpdateUserSetting(userCtx, &v1pb.UpdateUserSettingRequest{
+		Setting: &v1pb.UserSetting{
+			Name: settingName,
+			Value: &v1pb.UserSetting_GeneralSetting_{
+				GeneralSetting: &v1pb.UserSetting_GeneralSetting{TimeZone: "Mars/Olympus"},
+			},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"time_zone"}},
+	})
+	require.ErrorContains(t, err, "invalid time zone")
+	_, err = ts.Service.UpdateUserSetting(userCtx, &v1pb.UpdateUserSettingRequest{
+		Setting: &v1pb.UserSetting{
+			Name: settingName,
+			Value: &v1pb.UserSetting_GeneralSetting_{
+				GeneralSetting: &v1pb.UserSetting_GeneralSetting{TimeZone: "Pacific/Kiritimati"},
+			},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"time_zone"}},
+	})
+	require.NoError(t, err)
+
+	// Without a date, the daily memo is for today in the user's time zone.
+	location, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+	today := time.Now().In(location).Format(time.DateOnly)
+	memo, err := ts.Service.GetDailyMemo(userCtx, &v1pb.GetDailyMemoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "# "+today, memo.Content)
+	require.Equal(t, v1pb.Visibility_PRIVATE, memo.Visibility)
+
+	again, err := ts.Service.GetDailyMemo(userCtx, &v1pb.GetDailyMemoRequest{Date: today})
+	require.NoError(t, err)
+	require.Equal(t, memo.Name, again.Name)
+
+	appended, err := ts.Service.AppendDailyMemo(userCtx, &v1pb.AppendDailyMemoRequest{Content: "- bought milk\n"})
+	require.NoError(t, err)
+	require.Equal(t, memo.Name, appended.Name)
+	require.Equal(t, "# "+today+"\n\n- bought milk", appended.Content)
+	_, err = ts.Service.AppendDailyMemo(userCtx, &v1pb.AppendDailyMemoRequest{Content: " \n"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Getting the daily memo leaves its content alone.
+	again, err = ts.Service.GetDailyMemo(userCtx, &v1pb.GetDailyMemoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, appended.Content, again.Content)
+
+	_, err = ts.Service.GetDailyMemo(userCtx, &v1pb.GetDailyMemoRequest{Date: "2026-02-30"})
+	require.ErrorContains(t, err, "invalid date")
+
+	// Other users get their own daily memo for the same day.
+	other, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	otherMemo, err := ts.Service.GetDailyMemo(ts.CreateUserContext(ctx, other.ID), &v1pb.GetDailyMemoRequest{Date: today})
+	require.NoError(t, err)
+	require.NotEqual(t, memo.Name, otherMemo.Name)
+
+	// Deleting the daily memo frees the day for a new one.
+	_, err = ts.Service.DeleteMemo(userCtx, &v1pb.DeleteMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	recreated, err := ts.Service.GetDailyMemo(userCtx, &v1pb.GetDailyMemoRequest{})
+	require.NoError(t, err)
+	require.NotEqual(t, memo.Name, recreated.Name)
+}
+
+func TestGetDailyMemoFromTemplate(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "journal")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	template, err := ts.Service.CreateMemoTemplate(userCtx, &v1pb.CreateMemoTemplateRequest{
+		Parent: "users/journal",
+		MemoTemplate: &v1pb.MemoTemplate{
+			Title:      "Daily",
+			Content:    "## {{weekday}} {{date}}\n\n{{cursor}}",
+			Tags:       []string{"daily"},
+			Visibility: v1pb.Visibility_PROTECTED,
+		},
+	})
+	require.NoError(t, err)
+
+	// A past day is dated on that day, including its template placeholders.
+	memo, err := ts.Service.AppendDailyMemo(userCtx, &v1pb.AppendDailyMemoRequest{
+		Date:     "2026-03-02",
+		Template: template.Name,
+		Content:  "First entry.",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "## Monday 2026-03-02\n\nFirst entry.\n\n#daily", memo.Content)
+	require.Equal(t, v1pb.Visibility_PROTECTED, memo.Visibility)
+	require.Equal(t, "2026-03-02", memo.CreateTime.AsTime().Format(time.DateOnly))
+
+	// The template only applies on creation.
+	memo, err = ts.Service.AppendDailyMemo(userCtx, &v1pb.AppendDailyMemoRequest{
+		Date:     "2026-03-02",
+		Template: template.Name,
+		Content:  "Second entry.",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "## Monday 2026-03-02\n\nFirst entry.\n\n#daily\n\nSecond entry.", memo.Content)
+}
+
+func TestListDailyMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "journal")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	for _, date := range []string{"2026-02-23", "2026-03-02", "2026-03-31", "2026-04-05"} {
+		_, err := ts.Service.GetDailyMemo(userCtx, &v1pb.GetDailyMemoRequest{Date: date})
+		require.NoError(t, err)
+	}
+
+	// March 2026 starts on a Sunday, so a Sunday-first grid starts on March 1.
+	response, err := ts.Service.ListDailyMemos(userCtx, &v1pb.ListDailyMemosRequest{Month: "2026-03"})
+	require.NoError(t, err)
+	require.Equal(t, "2026-03-01", response.StartDate)
+	require.Equal(t, "2026-04-04", response.EndDate)
+	require.Len(t, response.DailyMemos, 2)
+	require.Equal(t, "2026-03-02", response.DailyMemos[0].Date)
+	require.Equal(t, "2026-03-31", response.DailyMemos[1].Date)
+	require.Regexp(t, "^memos/.+", response.DailyMemos[0].Memo)
+
+	_, err = ts.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_GENERAL,
+		Value: &storepb.InstanceSetting_GeneralSetting{
+			GeneralSetting: &storepb.InstanceGeneralSetting{WeekStartDayOffset: 1},
+		},
+	})
+	require.NoError(t, err)
+	response, err = ts.Service.ListDailyMemos(userCtx, &v1pb.ListDailyMemosRequest{Month: "2026-03"})
+	require.NoError(t, err)
+	require.Equal(t, "2026-02-23", response.StartDate)
+	require.Equal(t, "2026-04-05", response.EndDate)
+	require.Len(t, response.DailyMemos, 4)
+
+	_, err = ts.Service.ListDailyMemos(userCtx, &v1pb.ListDailyMemosRequest{Month: "March"})
+	require.ErrorContains(t, err, "invalid month")
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	apiv1 "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestMemoFrontMatterProperties(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{
+			Content:    "---\nstatus: todo\ndue: 2026-01-02\ntags: [home, errands]\n---\n# Groceries",
+			Visibility: apiv1.Visibility_PRIVATE,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Groceries", memo.Property.Title)
+	require.Equal(t, "todo", memo.Properties["status"].GetStringValue())
+	require.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), memo.Properties["due"].GetDateValue().AsTime())
+	require.Equal(t, []string{"home", "errands"}, memo.Properties["tags"].GetListValue().GetValues())
+
+	_, err = ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "No front matter", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	listed, err := ts.Service.ListMemos(userCtx, &apiv1.ListMemosRequest{Filter: `props.status == "todo" && props.due < now`})
+	require.NoError(t, err)
+	require.Len(t, listed.Memos, 1)
+	require.Equal(t, memo.Name, listed.Memos[0].Name)
+
+	updated, err := ts.Service.UpdateMemo(userCtx, &apiv1.UpdateMemoRequest{
+		Memo:       &apiv1.Memo{Name: memo.Name, Content: "---\nstatus: done\n---\n# Groceries"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, updated.Properties, 1)
+	require.Equal(t, "done", updated.Properties["status"].GetStringValue())
+
+	listed, err = ts.Service.ListMemos(userCtx, &apiv1.ListMemosRequest{Filter: `props.status == "todo"`})
+	require.NoError(t, err)
+	require.Empty(t, listed.Memos)
+}
@@ -0,0 +1,177 @@
+package test
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func createTaggedMemo(ctx context.Context, t *testing.T, ts *TestService, content string) *v1pb.Memo {
+	t.Helper()
+	memo, err := ts.Service.CreateMemo(ctx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: content, Visibility: v1pb.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	return memo
+}
+
+func getMemoContent(ctx context.Context, t *testing.T, ts *TestService, name string) (string, []string) {
+	t.Helper()
+	memo, err := ts.Service.GetMemo(ctx, &v1pb.GetMemoRequest{Name: name})
+	require.NoError(t, err)
+	return memo.Content, memo.Tags
+}
+
+func setTagMetadata(ctx context.Context, t *testing.T, ts *TestService, userID int32, tags map[string]*storepb.UserTagMetadata) {
+	t.Helper()
+	_, err := ts.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSetting_TAGS,
+		Value:  &storepb.UserSetting_Tags{Tags: &storepb.TagsUserSetting{Tags: tags}},
+	})
+	require.NoError(t, err)
+}
+
+func getTagMetadata(ctx context.Context, t *testing.T, ts *TestService, userID int32) map[string]*storepb.UserTagMetadata {
+	t.Helper()
+	setting, err := ts.Store.GetUserSetting(ctx, &store.FindUserSetting{UserID: &userID, Key: storepb.UserSetting_TAGS})
+	require.NoError(t, err)
+	return setting.GetTags().GetTags()
+}
+
+func TestRenameTag(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "alice")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	other, err := ts.CreateRegularUser(ctx, "bob")
+	require.NoError(t, err)
+	otherCtx := ts.CreateUserContext(ctx, other.ID)
+
+	alpha := createTaggedMemo(userCtx, t, ts, "Kickoff #proj/alpha")
+	docs := createTaggedMemo(userCtx, t, ts, "Specs #proj/alpha/docs and #proj/beta")
+	untouched := createTaggedMemo(userCtx, t, ts, "Unrelated #proj/alphabet")
+	otherMemo := createTaggedMemo(otherCtx, t, ts, "Bob's #proj/alpha")
+	setTagMetadata(ctx, t, ts, user.ID, map[string]*storepb.UserTagMetadata{
+		"proj/alpha":    {BlurContent: true},
+		"proj/alpha/.*": {BlurContent: true},
+		"proj/beta":     {},
+	})
+
+	resp, err := ts.Service.RenameTag(userCtx, &v1pb.RenameTagRequest{Tag: "proj/alpha", NewTag: "projects/alpha"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{alpha.Name, docs.Name}, resp.Memos)
+
+	content, tags := getMemoContent(userCtx, t, ts, alpha.Name)
+	require.Equal(t, "Kickoff #projects/alpha", content)
+	require.ElementsMatch(t, []string{"projects", "projects/alpha"}, tags)
+	content, tags = getMemoContent(userCtx, t, ts, docs.Name)
+	require.Equal(t, "Specs #projects/alpha/docs and #proj/beta", content)
+	require.ElementsMatch(t, []string{"projects", "projects/alpha", "projects/alpha/docs", "proj", "proj/beta"}, tags)
+	content, _ = getMemoContent(userCtx, t, ts, untouched.Name)
+	require.Equal(t, "Unrelated #proj/alphabet", content)
+	content, _ = getMemoContent(otherCtx, t, ts, otherMemo.Name)
+	require.Equal(t, "Bob's #proj/alpha", content)
+
+	metadata := getTagMetadata(ctx, t, ts, user.ID)
+	require.ElementsMatch(t, []string{"projects/alpha", "projects/alpha/.*", "proj/beta"}, slices.Collect(maps.Keys(metadata)))
+	require.True(t, metadata["projects/alpha"].BlurContent)
+
+	// Filters see the rebuilt tag sets.
+	memos, err := ts.Service.ListMemos(userCtx, &v1pb.ListMemosRequest{Filter: `"projects/alpha" in tags`})
+	require.NoError(t, err)
+	require.Len(t, memos.Memos, 2)
+
+	for _, request := range []*v1pb.RenameTagRequest{
+		{Tag: "#proj", NewTag: "work"},
+		{Tag: "proj", NewTag: "has space"},
+		{Tag: "proj", NewTag: "proj"},
+	} {
+		_, err := ts.Service.RenameTag(userCtx, request)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), request.String())
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "alice")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	first := createTaggedMemo(userCtx, t, ts, "#todo buy milk")
+	second := createTaggedMemo(userCtx, t, ts, "#to-do #tasks call mom")
+	setTagMetadata(ctx, t, ts, user.ID, map[string]*storepb.UserTagMetadata{
+		"tasks": {BlurContent: false},
+		"to-do": {BlurContent: true},
+	})
+
+	resp, err := ts.Service.MergeTags(userCtx, &v1pb.MergeTagsRequest{Tags: []string{"todo", "to-do", "tasks"}, TargetTag: "tasks"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{first.Name, second.Name}, resp.Memos)
+
+	content, tags := getMemoContent(userCtx, t, ts, first.Name)
+	require.Equal(t, "#tasks buy milk", content)
+	require.Equal(t, []string{"tasks"}, tags)
+	content, tags = getMemoContent(userCtx, t, ts, second.Name)
+	require.Equal(t, "#tasks #tasks call mom", content)
+	require.Equal(t, []string{"tasks"}, tags)
+
+	// Metadata already stored for the target tag wins.
+	metadata := getTagMetadata(ctx, t, ts, user.ID)
+	require.Equal(t, []string{"tasks"}, slices.Collect(maps.Keys(metadata)))
+	require.False(t, metadata["tasks"].BlurContent)
+
+	_, err = ts.Service.MergeTags(userCtx, &v1pb.MergeTagsRequest{Tags: []string{"tasks"}, TargetTag: "tasks"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDeleteTag(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	admin, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	adminCtx := ts.CreateUserContext(ctx, admin.ID)
+	user, err := ts.CreateRegularUser(ctx, "alice")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	own := createTaggedMemo(userCtx, t, ts, "Draft #wip/today done")
+	adminMemo := createTaggedMemo(adminCtx, t, ts, "#wip ship it")
+	setTagMetadata(ctx, t, ts, user.ID, map[string]*storepb.UserTagMetadata{"wip": {BlurContent: true}, "keep": {}})
+
+	_, err = ts.Service.DeleteTag(userCtx, &v1pb.DeleteTagRequest{Tag: "wip", AllUsers: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err := ts.Service.DeleteTag(adminCtx, &v1pb.DeleteTagRequest{Tag: "wip", AllUsers: true})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{own.Name, adminMemo.Name}, resp.Memos)
+
+	content, tags := getMemoContent(userCtx, t, ts, own.Name)
+	require.Equal(t, "Draft done", content)
+	require.Empty(t, tags)
+	content, _ = getMemoContent(adminCtx, t, ts, adminMemo.Name)
+	require.Equal(t, "ship it", content)
+	require.Equal(t, []string{"keep"}, slices.Collect(maps.Keys(getTagMetadata(ctx, t, ts, user.ID))))
+
+	// Nothing left to change.
+	resp, err = ts.Service.DeleteTag(userCtx, &v1pb.DeleteTagRequest{Tag: "wip"})
+	require.NoError(t, err)
+	require.Empty(t, resp.Memos)
+}
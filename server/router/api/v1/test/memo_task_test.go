@@ -0,0 +1,123 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apiv1 "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestMemoTasks(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	other, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	otherCtx := ts.CreateUserContext(ctx, other.ID)
+
+	content := "# Errands\n\n- [ ] Buy milk due:2026-01-02 !1\n- [x] Post letter\n\nDone for today."
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: content, Visibility: apiv1.Visibility_PROTECTED},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "- [ ] Private task", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "No tasks here", Visibility: apiv1.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+
+	open, err := ts.Service.ListTasks(otherCtx, &apiv1.ListTasksRequest{})
+	require.NoError(t, err)
+	require.Len(t, open.Tasks, 1)
+	task := open.Tasks[0]
+	require.True(t, strings.HasPrefix(task.Name, memo.Name+"/tasks/"))
+	require.Equal(t, "Buy milk", task.Text)
+	require.False(t, task.Checked)
+	require.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), task.DueTime.AsTime())
+	require.Equal(t, apiv1.Task_HIGH, task.Priority)
+
+	all, err := ts.Service.ListTasks(userCtx, &apiv1.ListTasksRequest{ShowCompleted: true, Filter: "visibility == \"PROTECTED\""})
+	require.NoError(t, err)
+	require.Len(t, all.Tasks, 2)
+	require.Equal(t, "Post letter", all.Tasks[1].Text)
+	require.True(t, all.Tasks[1].Checked)
+
+	_, err = ts.Service.SetTaskState(otherCtx, &apiv1.SetTaskStateRequest{Name: task.Name, Checked: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = ts.Service.SetTaskState(userCtx, &apiv1.SetTaskStateRequest{Name: memo.Name + "/tasks/missing", Checked: true})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	checked, err := ts.Service.SetTaskState(userCtx, &apiv1.SetTaskStateRequest{Name: task.Name, Checked: true})
+	require.NoError(t, err)
+	require.Equal(t, task.Name, checked.Name)
+	require.True(t, checked.Checked)
+
+	updated, err := ts.Service.GetMemo(userCtx, &apiv1.GetMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	require.Equal(t, strings.Replace(content, "- [ ] Buy milk", "- [x] Buy milk", 1), updated.Content)
+	require.False(t, updated.Property.HasIncompleteTasks)
+
+	open, err = ts.Service.ListTasks(otherCtx, &apiv1.ListTasksRequest{})
+	require.NoError(t, err)
+	require.Empty(t, open.Tasks)
+}
+
+func TestSetTaskStateConcurrentEdits(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	lines := make([]string, 0, 8)
+	for i := range 8 {
+		lines = append(lines, fmt.Sprintf("- [ ] Task %d", i))
+	}
+	memo, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: strings.Join(lines, "\n"), Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	tasks, err := ts.Service.ListTasks(userCtx, &apiv1.ListTasksRequest{})
+	require.NoError(t, err)
+	require.Len(t, tasks.Tasks, 8)
+
+	// Each request rewrites the whole content. A request racing another one
+	// aborts instead of overwriting the other's checkbox.
+	var wg sync.WaitGroup
+	errs := make([]error, len(tasks.Tasks))
+	for i, task := range tasks.Tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = ts.Service.SetTaskState(userCtx, &apiv1.SetTaskStateRequest{Name: task.Name, Checked: true})
+		}()
+	}
+	wg.Wait()
+
+	updated, err := ts.Service.GetMemo(userCtx, &apiv1.GetMemoRequest{Name: memo.Name})
+	require.NoError(t, err)
+	for i, task := range tasks.Tasks {
+		if errs[i] != nil {
+			require.Equal(t, codes.Aborted, status.Code(errs[i]), errs[i])
+			continue
+		}
+		require.Contains(t, updated.Content, "- [x] "+task.Text)
+	}
+}
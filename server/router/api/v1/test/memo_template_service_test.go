@@ -0,0 +1,202 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestMemoTemplateCRUD(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "writer")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	parent := fmt.Sprintf("users/%s", user.Username)
+
+	created, err := ts.Service.CreateMemoTemplate(userCtx, &v1pb.CreateMemoTemplateRequest{
+		Parent: parent,
+		MemoTemplate: &v1pb.MemoTemplate{
+			Title:      "Meeting",
+			Content:    "# Meeting {{date}}\n\n{{cursor}}",
+			Tags:       []string{"#meeting", "work/notes", "meeting"},
+			Visibility: v1pb.Visibility_PROTECTED,
+		},
+	})
+	require.NoError(t, err)
+	require.Regexp(t, "^users/writer/templates/.+", created.Name)
+	require.Equal(t, []string{"meeting", "work/notes"}, created.Tags)
+	require.Equal(t, v1pb.Visibility_PROTECTED, created.Visibility)
+
+	_, err = ts.Service.CreateMemoTemplate(userCtx, &v1pb.CreateMemoTemplateRequest{
+		Parent:       parent,
+		MemoTemplate: &v1pb.MemoTemplate{Title: "Bad", Content: "x", Tags: []string{"two words"}},
+	})
+	require.ErrorContains(t, err, "invalid tags")
+	_, err = ts.Service.CreateMemoTemplate(userCtx, &v1pb.CreateMemoTemplateRequest{
+		Parent:       parent,
+		MemoTemplate: &v1pb.MemoTemplate{Title: "Empty"},
+	})
+	require.ErrorContains(t, err, "content is required")
+
+	updated, err := ts.Service.UpdateMemoTemplate(userCtx, &v1pb.UpdateMemoTemplateRequest{
+		MemoTemplate: &v1pb.MemoTemplate{Name: created.Name, Title: "Standup", Tags: []string{}},
+		UpdateMask:   &fieldmaskpb.FieldMask{Paths: []string{"title", "tags"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Standup", updated.Title)
+	require.Empty(t, updated.Tags)
+	require.Equal(t, created.Content, updated.Content)
+
+	got, err := ts.Service.GetMemoTemplate(userCtx, &v1pb.GetMemoTemplateRequest{Name: created.Name})
+	require.NoError(t, err)
+	require.Equal(t, "Standup", got.Title)
+
+	other, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	otherCtx := ts.CreateUserContext(ctx, other.ID)
+	_, err = ts.Service.GetMemoTemplate(otherCtx, &v1pb.GetMemoTemplateRequest{Name: created.Name})
+	require.ErrorContains(t, err, "permission denied")
+
+	_, err = ts.Service.DeleteMemoTemplate(userCtx, &v1pb.DeleteMemoTemplateRequest{Name: created.Name})
+	require.NoError(t, err)
+	_, err = ts.Service.GetMemoTemplate(userCtx, &v1pb.GetMemoTemplateRequest{Name: created.Name})
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestInstanceMemoTemplates(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	admin, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	adminCtx := ts.CreateUserContext(ctx, admin.ID)
+	user, err := ts.CreateRegularUser(ctx, "member")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	_, err = ts.Service.CreateMemoTemplate(userCtx, &v1pb.CreateMemoTemplateRequest{
+		Parent:       "instance",
+		MemoTemplate: &v1pb.MemoTemplate{Title: "Shared", Content: "shared"},
+	})
+	require.ErrorContains(t, err, "permission denied")
+
+	shared, err := ts.Service.CreateMemoTemplate(adminCtx, &v1pb.CreateMemoTemplateRequest{
+		Parent:       "instance",
+		MemoTemplate: &v1pb.MemoTemplate{Title: "Shared", Content: "shared"},
+	})
+	require.NoError(t, err)
+	require.Regexp(t, "^instance/templates/.+", shared.Name)
+
+	own, err := ts.Service.CreateMemoTemplate(userCtx, &v1pb.CreateMemoTemplateRequest{
+		Parent:       "users/member",
+		MemoTemplate: &v1pb.MemoTemplate{Title: "Own", Content: "own"},
+	})
+	require.NoError(t, err)
+
+	// Users list their own templates followed by the instance-wide ones.
+	list, err := ts.Service.ListMemoTemplates(userCtx, &v1pb.ListMemoTemplatesRequest{Parent: "users/member"})
+	require.NoError(t, err)
+	require.Len(t, list.MemoTemplates, 2)
+	require.Equal(t, own.Name, list.MemoTemplates[0].Name)
+	require.Equal(t, shared.Name, list.MemoTemplates[1].Name)
+
+	list, err = ts.Service.ListMemoTemplates(userCtx, &v1pb.ListMemoTemplatesRequest{Parent: "instance"})
+	require.NoError(t, err)
+	require.Len(t, list.MemoTemplates, 1)
+
+	_, err = ts.Service.DeleteMemoTemplate(userCtx, &v1pb.DeleteMemoTemplateRequest{Name: shared.Name})
+	require.ErrorContains(t, err, "permission denied")
+
+	memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo:     &v1pb.Memo{},
+		Template: shared.Name,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "shared", memo.Content)
+	require.Equal(t, v1pb.Visibility_PRIVATE, memo.Visibility)
+
+	_, err = ts.Service.UpdateInstanceSetting(adminCtx, &v1pb.UpdateInstanceSettingRequest{
+		Setting: &v1pb.InstanceSetting{Name: "instance/settings/MEMO_TEMPLATES"},
+	})
+	require.ErrorContains(t, err, "MemoTemplateService")
+}
+
+func TestCreateMemoFromTemplate(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.Store.CreateUser(ctx, &store.User{
+		Username: "ada",
+		Nickname: "Ada",
+		Role:     store.RoleUser,
+		Email:    "ada@example.com",
+	})
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	template, err := ts.Service.CreateMemoTemplate(userCtx, &v1pb.CreateMemoTemplateRequest{
+		Parent: "users/ada",
+		MemoTemplate: &v1pb.MemoTemplate{
+			Title:      "Log",
+			Content:    "## {{weekday}} {{ date }} {{time}}\nby {{user.nickname}} ({{user.username}}) on {{project}} {{unknown}}\n\n{{cursor}}\n\n{{cursor}}#log",
+			Tags:       []string{"log", "daily/work"},
+			Visibility: v1pb.Visibility_PROTECTED,
+		},
+	})
+	require.NoError(t, err)
+
+	createTime := time.Date(2026, time.March, 1, 23, 30, 0, 0, time.UTC)
+	memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo:              &v1pb.Memo{Content: "Shipped it.", CreateTime: timestamppb.New(createTime)},
+		Template:          template.Name,
+		TemplateVariables: map[string]string{"project": "memos"},
+		TimeZone:          "Europe/Berlin",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "## Monday 2026-03-02 00:30\nby Ada (ada) on memos {{unknown}}\n\nShipped it.\n\n#log\n\n#daily/work", memo.Content)
+	require.Equal(t, v1pb.Visibility_PROTECTED, memo.Visibility)
+	require.Equal(t, []string{"log", "daily", "daily/work"}, memo.Tags)
+
+	// Without a cursor the request content follows the template, and an explicit
+	// visibility wins over the template default.
+	_, err = ts.Service.UpdateMemoTemplate(userCtx, &v1pb.UpdateMemoTemplateRequest{
+		MemoTemplate: &v1pb.MemoTemplate{Name: template.Name, Content: "Notes", Tags: []string{}},
+		UpdateMask:   &fieldmaskpb.FieldMask{Paths: []string{"content", "tags"}},
+	})
+	require.NoError(t, err)
+	memo, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo:     &v1pb.Memo{Content: "More", Visibility: v1pb.Visibility_PUBLIC},
+		Template: template.Name,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Notes\n\nMore", memo.Content)
+	require.Equal(t, v1pb.Visibility_PUBLIC, memo.Visibility)
+
+	_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo:     &v1pb.Memo{},
+		Template: template.Name,
+		TimeZone: "Mars/Olympus",
+	})
+	require.ErrorContains(t, err, "invalid time zone")
+
+	other, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	_, err = ts.Service.CreateMemo(ts.CreateUserContext(ctx, other.ID), &v1pb.CreateMemoRequest{
+		Memo:     &v1pb.Memo{},
+		Template: template.Name,
+	})
+	require.ErrorContains(t, err, "permission denied")
+}
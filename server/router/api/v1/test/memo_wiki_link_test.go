@@ -0,0 +1,317 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	apiv1 "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/memolink"
+	"github.com/usememos/memos/store"
+)
+
+func referencedMemoNames(memo *apiv1.Memo) []string {
+	names := []string{}
+	for _, relation := range memo.Relations {
+		if relation.Type == apiv1.MemoRelation_REFERENCE && relation.Memo.Name == memo.Name {
+			names = append(names, relation.RelatedMemo.Name)
+		}
+	}
+	return names
+}
+
+func TestWikiLinkRelations(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	other, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	otherCtx := ts.CreateUserContext(ctx, other.ID)
+
+	readingList, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		MemoId: "reading-list",
+		Memo:   &apiv1.Memo{Content: "Books to read", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	weeklyReview, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "# Weekly Review\n\nWhat went well", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	explicit, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Linked by hand", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.CreateMemo(otherCtx, &apiv1.CreateMemoRequest{
+		MemoId: "secret",
+		Memo:   &apiv1.Memo{Content: "# Secret", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	t.Run("CreateMemo adds relations for links by UID and title", func(t *testing.T) {
+		source, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			Memo: &apiv1.Memo{
+				Content:    "See [[reading-list]], [[weekly review]], [[secret]], [[Secret]] and [[missing]]",
+				Visibility: apiv1.Visibility_PRIVATE,
+			},
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{readingList.Name, weeklyReview.Name}, referencedMemoNames(source))
+	})
+
+	t.Run("UpdateMemo follows content and keeps explicit relations", func(t *testing.T) {
+		source, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			Memo: &apiv1.Memo{Content: "See [[reading-list]]", Visibility: apiv1.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		_, err = ts.Service.SetMemoRelations(userCtx, &apiv1.SetMemoRelationsRequest{
+			Name: source.Name,
+			Relations: []*apiv1.MemoRelation{
+				{RelatedMemo: &apiv1.MemoRelation_Memo{Name: readingList.Name}, Type: apiv1.MemoRelation_REFERENCE},
+				{RelatedMemo: &apiv1.MemoRelation_Memo{Name: explicit.Name}, Type: apiv1.MemoRelation_REFERENCE},
+			},
+		})
+		require.NoError(t, err)
+
+		updated, err := ts.Service.UpdateMemo(userCtx, &apiv1.UpdateMemoRequest{
+			Memo:       &apiv1.Memo{Name: source.Name, Content: "Now see [[Weekly Review]]"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{explicit.Name, weeklyReview.Name}, referencedMemoNames(updated))
+	})
+
+	t.Run("self links are ignored", func(t *testing.T) {
+		source, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			MemoId: "self-link",
+			Memo:   &apiv1.Memo{Content: "Me: [[self-link]]", Visibility: apiv1.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		require.Empty(t, referencedMemoNames(source))
+	})
+
+	t.Run("links resolve with the access of their author", func(t *testing.T) {
+		require.NoError(t, ts.SetInstanceAccessMode(ctx, storepb.InstanceAccessMode_INSTANCE_ACCESS_MODE_PRIVATE))
+		defer func() {
+			require.NoError(t, ts.SetInstanceAccessMode(ctx, storepb.InstanceAccessMode_INSTANCE_ACCESS_MODE_PUBLIC))
+		}()
+		protected, err := ts.Service.CreateMemo(otherCtx, &apiv1.CreateMemoRequest{
+			MemoId: "protected-note",
+			Memo:   &apiv1.Memo{Content: "Members only", Visibility: apiv1.Visibility_PROTECTED},
+		})
+		require.NoError(t, err)
+		archived, err := ts.Service.CreateMemo(otherCtx, &apiv1.CreateMemoRequest{
+			MemoId: "archived-note",
+			Memo:   &apiv1.Memo{Content: "Put away", Visibility: apiv1.Visibility_PROTECTED},
+		})
+		require.NoError(t, err)
+		_, err = ts.Service.UpdateMemo(otherCtx, &apiv1.UpdateMemoRequest{
+			Memo:       &apiv1.Memo{Name: archived.Name, State: apiv1.State_ARCHIVED},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"state"}},
+		})
+		require.NoError(t, err)
+
+		source, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			Memo: &apiv1.Memo{Content: "See [[protected-note]] and [[archived-note]]", Visibility: apiv1.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{protected.Name}, referencedMemoNames(source))
+
+		// A link whose author no longer exists resolves anonymously.
+		resolver := memolink.NewResolver(ts.Store, ts.Service.MarkdownService)
+		resolved, err := resolver.ResolveTarget(ctx, user.ID, "protected-note")
+		require.NoError(t, err)
+		require.NotNil(t, resolved)
+		resolved, err = resolver.ResolveTarget(ctx, 9999, "protected-note")
+		require.NoError(t, err)
+		require.Nil(t, resolved)
+	})
+}
+
+func TestListMemoBacklinks(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	other, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	otherCtx := ts.CreateUserContext(ctx, other.ID)
+
+	target, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		MemoId: "target",
+		Memo:   &apiv1.Memo{Content: "Target", Visibility: apiv1.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+	publicSource, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Public link to [[target]]", Visibility: apiv1.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+	privateSource, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Private link to [[target]]", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "No link", Visibility: apiv1.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+
+	memoNames := func(memos []*apiv1.Memo) []string {
+		names := []string{}
+		for _, memo := range memos {
+			names = append(names, memo.Name)
+		}
+		return names
+	}
+
+	response, err := ts.Service.ListMemoBacklinks(userCtx, &apiv1.ListMemoBacklinksRequest{Name: target.Name})
+	require.NoError(t, err)
+	require.Equal(t, []string{privateSource.Name, publicSource.Name}, memoNames(response.Memos))
+
+	response, err = ts.Service.ListMemoBacklinks(otherCtx, &apiv1.ListMemoBacklinksRequest{Name: target.Name})
+	require.NoError(t, err)
+	require.Equal(t, []string{publicSource.Name}, memoNames(response.Memos))
+
+	response, err = ts.Service.ListMemoBacklinks(userCtx, &apiv1.ListMemoBacklinksRequest{Name: target.Name, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, response.Memos, 1)
+	require.NotEmpty(t, response.NextPageToken)
+
+	// The filter field sees the same relations.
+	memos, err := ts.Service.ListMemos(userCtx, &apiv1.ListMemosRequest{Filter: `"memos/target" in references && visibility == "PUBLIC"`})
+	require.NoError(t, err)
+	require.Equal(t, []string{publicSource.Name}, memoNames(memos.Memos))
+}
+
+func embeddedMemoNames(memo *apiv1.Memo) []string {
+	names := []string{}
+	for _, relation := range memo.Relations {
+		if relation.Type == apiv1.MemoRelation_EMBED && relation.Memo.Name == memo.Name {
+			names = append(names, relation.RelatedMemo.Name)
+		}
+	}
+	return names
+}
+
+func TestMemoEmbeds(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	other, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	otherCtx := ts.CreateUserContext(ctx, other.ID)
+
+	checklist, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		MemoId: "checklist",
+		Memo:   &apiv1.Memo{Content: "Pack the charger", Visibility: apiv1.Visibility_PROTECTED},
+	})
+	require.NoError(t, err)
+	boilerplate, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "# Boilerplate\n\nKeep it short", Visibility: apiv1.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	t.Run("embeds are recorded as relations and expanded in snippets", func(t *testing.T) {
+		source, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			Memo: &apiv1.Memo{Content: "Trip ![[checklist]] ![[boilerplate]]", Visibility: apiv1.Visibility_PROTECTED},
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{checklist.Name, boilerplate.Name}, embeddedMemoNames(source))
+		require.Empty(t, referencedMemoNames(source))
+		require.Equal(t, "Trip Pack the charger Boilerplate Keep it short", source.Snippet)
+
+		// The private embed stays literal for other viewers.
+		seen, err := ts.Service.GetMemo(otherCtx, &apiv1.GetMemoRequest{Name: source.Name})
+		require.NoError(t, err)
+		require.Equal(t, "Trip Pack the charger ![[boilerplate]]", seen.Snippet)
+
+		// Embed relations follow the content and survive relation updates.
+		_, err = ts.Service.SetMemoRelations(userCtx, &apiv1.SetMemoRelationsRequest{Name: source.Name})
+		require.NoError(t, err)
+		updated, err := ts.Service.UpdateMemo(userCtx, &apiv1.UpdateMemoRequest{
+			Memo:       &apiv1.Memo{Name: source.Name, Content: "Trip ![[checklist]]"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{checklist.Name}, embeddedMemoNames(updated))
+
+		// The embedded memo lists where it is used.
+		relations, err := ts.Service.ListMemoRelations(userCtx, &apiv1.ListMemoRelationsRequest{Name: checklist.Name})
+		require.NoError(t, err)
+		usedBy := []string{}
+		for _, relation := range relations.Relations {
+			if relation.Type == apiv1.MemoRelation_EMBED && relation.RelatedMemo.Name == checklist.Name {
+				usedBy = append(usedBy, relation.Memo.Name)
+			}
+		}
+		require.Equal(t, []string{source.Name}, usedBy)
+	})
+
+	t.Run("embed cycles terminate", func(t *testing.T) {
+		_, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			MemoId: "cycle-a",
+			Memo:   &apiv1.Memo{Content: "A ![[cycle-b]]", Visibility: apiv1.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		_, err = ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			MemoId: "cycle-b",
+			Memo:   &apiv1.Memo{Content: "B ![[cycle-a]]", Visibility: apiv1.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+
+		memo, err := ts.Service.GetMemo(userCtx, &apiv1.GetMemoRequest{Name: "memos/cycle-a"})
+		require.NoError(t, err)
+		require.Equal(t, "A B ![[cycle-a]]", memo.Snippet)
+	})
+
+	t.Run("embed fan-out is bounded", func(t *testing.T) {
+		// Every level embeds the next one eight times, which would expand to
+		// 8^4 leaves without a per-expansion budget.
+		_, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+			MemoId: "fan-0",
+			Memo:   &apiv1.Memo{Content: "leaf", Visibility: apiv1.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		for level := 1; level <= 4; level++ {
+			created, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+				MemoId: fmt.Sprintf("fan-%d", level),
+				Memo: &apiv1.Memo{
+					Content:    strings.Repeat(fmt.Sprintf("![[fan-%d]] ", level-1), 8),
+					Visibility: apiv1.Visibility_PRIVATE,
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("memos/fan-%d", level), created.Name)
+		}
+		uid := "fan-4"
+		top, err := ts.Store.GetMemo(ctx, &store.FindMemo{UID: &uid})
+		require.NoError(t, err)
+
+		resolver := memolink.NewResolver(ts.Store, ts.Service.MarkdownService)
+		html, err := resolver.RenderHTML(ctx, top, user)
+		require.NoError(t, err)
+		leaves := strings.Count(html, "leaf")
+		require.Positive(t, leaves)
+		require.LessOrEqual(t, leaves, 64)
+
+		snippet, err := resolver.GenerateSnippet(ctx, top, user, 100000)
+		require.NoError(t, err)
+		leaves = strings.Count(snippet, "leaf")
+		require.Positive(t, leaves)
+		require.LessOrEqual(t, leaves, 64)
+	})
+}
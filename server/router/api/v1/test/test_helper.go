@@ -3,12 +3,17 @@ package test
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
 
 	"github.com/usememos/memos/internal/markdown"
 	"github.com/usememos/memos/internal/profile"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/server/auth"
 	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/server/runner/webhookdelivery"
 	"github.com/usememos/memos/store"
 	teststore "github.com/usememos/memos/store/test"
 )
@@ -37,7 +42,7 @@ func NewTestService(t *testing.T) *TestService {
 	}
 
 	// Align the profile data directory with the test store so attachment files and
-	// derived caches resolve against the same location as DeleteAttachmentStorage.
+	// derived caches resolve against the same location as attachment deletes.
 	testProfile := &profile.Profile{
 		Demo:        true,
 		Version:     "test-1.0.0",
@@ -53,6 +58,8 @@ func NewTestService(t *testing.T) *TestService {
 	markdownService := markdown.NewService(
 		markdown.WithTagExtension(),
 		markdown.WithMentionExtension(),
+		markdown.WithWikiLinkExtension(),
+		markdown.WithInstanceURL(testProfile.InstanceURL),
 	)
 	service := &apiv1.APIV1Service{
 		Secret:          secret,
@@ -61,6 +68,7 @@ func NewTestService(t *testing.T) *TestService {
 		MarkdownService: markdownService,
 		SSEHub:          apiv1.NewSSEHub(),
 	}
+	service.WebhookDeliveryRunner = webhookdelivery.NewRunner(testStore)
 
 	return &TestService{
 		Service: service,
@@ -109,3 +117,16 @@ func (*TestService) CreateUserContext(ctx context.Context, userID int32) context
 	// Use the context key from the auth package
 	return context.WithValue(ctx, auth.UserIDContextKey, userID)
 }
+
+// WaitForAttachment polls an attachment until ready reports that the results
+// of its background jobs are in, and returns it.
+func (ts *TestService) WaitForAttachment(ctx context.Context, t *testing.T, name string, ready func(*v1pb.Attachment) bool) *v1pb.Attachment {
+	t.Helper()
+	var attachment *v1pb.Attachment
+	require.Eventually(t, func() bool {
+		var err error
+		attachment, err = ts.Service.GetAttachment(ctx, &v1pb.GetAttachmentRequest{Name: name})
+		return err == nil && ready(attachment)
+	}, 5*time.Second, 20*time.Millisecond)
+	return attachment
+}
@@ -524,3 +524,124 @@ func TestUpdateMemoMentionOnlyNotifiesNewTargets(t *testing.T) {
 	require.Len(t, secondResp.Notifications, 1)
 	require.Equal(t, apiv1.UserNotification_MEMO_MENTION, secondResp.Notifications[0].Type)
 }
+
+func TestUpdateMemoVisibilityNotifiesNewlyReadableMentions(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	author, err := ts.CreateRegularUser(ctx, "mention-visibility-author")
+	require.NoError(t, err)
+	authorCtx := ts.CreateUserContext(ctx, author.ID)
+
+	target, err := ts.CreateRegularUser(ctx, "mention-visibility-target")
+	require.NoError(t, err)
+	targetCtx := ts.CreateUserContext(ctx, target.ID)
+
+	memo, err := ts.Service.CreateMemo(authorCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{
+			Content:    fmt.Sprintf("Draft for @%s and @nobody-here", target.Username),
+			Visibility: apiv1.Visibility_PRIVATE,
+		},
+	})
+	require.NoError(t, err)
+
+	listNotifications := func() []*apiv1.UserNotification {
+		resp, err := ts.Service.ListUserNotifications(targetCtx, &apiv1.ListUserNotificationsRequest{
+			Parent: fmt.Sprintf("users/%s", target.Username),
+		})
+		require.NoError(t, err)
+		return resp.Notifications
+	}
+	// The target cannot read the private memo, so nothing is sent.
+	require.Empty(t, listNotifications())
+
+	_, err = ts.Service.UpdateMemo(authorCtx, &apiv1.UpdateMemoRequest{
+		Memo:       &apiv1.Memo{Name: memo.Name, Visibility: apiv1.Visibility_PROTECTED},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"visibility"}},
+	})
+	require.NoError(t, err)
+	notifications := listNotifications()
+	require.Len(t, notifications, 1)
+	require.Equal(t, apiv1.UserNotification_MEMO_MENTION, notifications[0].Type)
+
+	_, err = ts.Service.UpdateMemo(authorCtx, &apiv1.UpdateMemoRequest{
+		Memo:       &apiv1.Memo{Name: memo.Name, Visibility: apiv1.Visibility_PUBLIC},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"visibility"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, listNotifications(), 1)
+
+	// The mentions filter lists the memo for the target but not for the
+	// unknown username.
+	resp, err := ts.Service.ListMemos(authorCtx, &apiv1.ListMemosRequest{
+		Filter: fmt.Sprintf(`"users/%s" in mentions`, target.Username),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Memos, 1)
+	require.Equal(t, memo.Name, resp.Memos[0].Name)
+	resp, err = ts.Service.ListMemos(authorCtx, &apiv1.ListMemosRequest{
+		Filter: `"users/here" in mentions`,
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Memos)
+}
+
+func TestCreateMemoCommentHereMentionNotifiesThreadParticipants(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	owner, err := ts.CreateRegularUser(ctx, "here-owner")
+	require.NoError(t, err)
+	ownerCtx := ts.CreateUserContext(ctx, owner.ID)
+	participant, err := ts.CreateRegularUser(ctx, "here-participant")
+	require.NoError(t, err)
+	participantCtx := ts.CreateUserContext(ctx, participant.ID)
+	caller, err := ts.CreateRegularUser(ctx, "here-caller")
+	require.NoError(t, err)
+	callerCtx := ts.CreateUserContext(ctx, caller.ID)
+	bystander, err := ts.CreateRegularUser(ctx, "here-bystander")
+	require.NoError(t, err)
+	bystanderCtx := ts.CreateUserContext(ctx, bystander.ID)
+
+	memo, err := ts.Service.CreateMemo(ownerCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{Content: "Plan", Visibility: apiv1.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.CreateMemoComment(participantCtx, &apiv1.CreateMemoCommentRequest{
+		Name:    memo.Name,
+		Comment: &apiv1.Memo{Content: "Count me in", Visibility: apiv1.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+	comment, err := ts.Service.CreateMemoComment(callerCtx, &apiv1.CreateMemoCommentRequest{
+		Name:    memo.Name,
+		Comment: &apiv1.Memo{Content: "Ready @here?", Visibility: apiv1.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+
+	listTypes := func(userCtx context.Context, user *store.User) []apiv1.UserNotification_Type {
+		resp, err := ts.Service.ListUserNotifications(userCtx, &apiv1.ListUserNotificationsRequest{
+			Parent: fmt.Sprintf("users/%s", user.Username),
+		})
+		require.NoError(t, err)
+		types := []apiv1.UserNotification_Type{}
+		for _, notification := range resp.Notifications {
+			types = append(types, notification.Type)
+		}
+		return types
+	}
+
+	// The owner already gets a comment notification for each comment.
+	require.Equal(t, []apiv1.UserNotification_Type{apiv1.UserNotification_MEMO_COMMENT, apiv1.UserNotification_MEMO_COMMENT}, listTypes(ownerCtx, owner))
+	require.Equal(t, []apiv1.UserNotification_Type{apiv1.UserNotification_MEMO_MENTION}, listTypes(participantCtx, participant))
+	require.Empty(t, listTypes(callerCtx, caller))
+	require.Empty(t, listTypes(bystanderCtx, bystander))
+
+	resp, err := ts.Service.ListUserNotifications(participantCtx, &apiv1.ListUserNotificationsRequest{
+		Parent: fmt.Sprintf("users/%s", participant.Username),
+	})
+	require.NoError(t, err)
+	require.Equal(t, comment.Name, resp.Notifications[0].GetMemoMention().Memo)
+	require.Equal(t, memo.Name, resp.Notifications[0].GetMemoMention().RelatedMemo)
+}
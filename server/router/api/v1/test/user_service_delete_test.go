@@ -488,3 +488,35 @@ func TestDeleteUserReturnsErrorWhenAttachmentStorageCleanupFails(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, deletedUser)
 }
+
+func TestDeleteUserDiscardsUploadSessions(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	ctx := context.Background()
+	user, err := ts.CreateRegularUser(ctx, "upload-leaver")
+	require.NoError(t, err)
+	authCtx := ts.CreateUserContext(ctx, user.ID)
+
+	_, err = ts.Service.CreateUploadSession(authCtx, &v1pb.CreateUploadSessionRequest{
+		UploadSession: &v1pb.UploadSession{Filename: "draft.txt", Size: 5},
+	})
+	require.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Join(ts.Profile.Data, store.UploadSessionStagingFolder))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	_, err = ts.Service.DeleteUser(authCtx, &v1pb.DeleteUserRequest{
+		Name: apiv1.BuildUserName(user.Username),
+	})
+	require.NoError(t, err)
+
+	sessions, err := ts.Store.ListUploadSessions(ctx, &store.FindUploadSession{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+	entries, err = os.ReadDir(filepath.Join(ts.Profile.Data, store.UploadSessionStagingFolder))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -0,0 +1,327 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/usememos/memos/internal/webhook"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/runner/webhookdelivery"
+	"github.com/usememos/memos/store"
+)
+
+// newWebhookReceiver starts a receiver that answers with the status stored in
+// statusCode and allows the webhook client to reach it on loopback.
+func newWebhookReceiver(t *testing.T, statusCode *atomic.Int32, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	require.NoError(t, webhook.ConfigurePrivateDestinationAllowlist([]string{"127.0.0.1"}))
+	t.Cleanup(func() {
+		require.NoError(t, webhook.ConfigurePrivateDestinationAllowlist(nil))
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		require.NotEmpty(t, r.Header.Get("webhook-id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(int(statusCode.Load()))
+		_, _ = w.Write([]byte(`{"code":0}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries failed deliveries and keeps history", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		var statusCode, requests atomic.Int32
+		statusCode.Store(http.StatusInternalServerError)
+		receiver := newWebhookReceiver(t, &statusCode, &requests)
+
+		user, err := ts.CreateRegularUser(ctx, "alice")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		hook, err := ts.Service.CreateUserWebhook(userCtx, &v1pb.CreateUserWebhookRequest{
+			Parent:  "users/alice",
+			Webhook: &v1pb.UserWebhook{DisplayName: "receiver", Url: receiver.URL},
+		})
+		require.NoError(t, err)
+
+		_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+			Memo: &v1pb.Memo{Content: "hello", Visibility: v1pb.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		ts.Service.WebhookDeliveryRunner.RunOnce(ctx)
+		require.Equal(t, int32(1), requests.Load())
+
+		resp, err := ts.Service.ListUserWebhookDeliveries(userCtx, &v1pb.ListUserWebhookDeliveriesRequest{Parent: hook.Name})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, 1)
+		delivery := resp.Deliveries[0]
+		require.Equal(t, "memos.memo.created", delivery.ActivityType)
+		require.Equal(t, v1pb.WebhookDelivery_PENDING, delivery.State)
+		require.Equal(t, int32(1), delivery.Attempts)
+		require.Equal(t, int32(http.StatusInternalServerError), delivery.ResponseStatusCode)
+		require.NotEmpty(t, delivery.Error)
+		require.NotNil(t, delivery.NextAttemptTime)
+
+		// The retry is backed off, so running again does not hit the receiver.
+		ts.Service.WebhookDeliveryRunner.RunOnce(ctx)
+		require.Equal(t, int32(1), requests.Load())
+
+		statusCode.Store(http.StatusOK)
+		redelivery, err := ts.Service.RedeliverWebhook(userCtx, &v1pb.RedeliverWebhookRequest{Name: delivery.Name})
+		require.NoError(t, err)
+		require.NotEqual(t, delivery.Name, redelivery.Name)
+		ts.Service.WebhookDeliveryRunner.RunOnce(ctx)
+		require.Equal(t, int32(2), requests.Load())
+
+		resp, err = ts.Service.ListUserWebhookDeliveries(userCtx, &v1pb.ListUserWebhookDeliveriesRequest{Parent: hook.Name, PageSize: 1})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, 1)
+		require.NotEmpty(t, resp.NextPageToken)
+		require.Equal(t, redelivery.Name, resp.Deliveries[0].Name)
+		require.Equal(t, v1pb.WebhookDelivery_SUCCEEDED, resp.Deliveries[0].State)
+		require.Equal(t, `{"code":0}`, resp.Deliveries[0].ResponseExcerpt)
+	})
+
+	t.Run("disables webhooks after repeated failures", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		var statusCode, requests atomic.Int32
+		statusCode.Store(http.StatusBadGateway)
+		receiver := newWebhookReceiver(t, &statusCode, &requests)
+
+		user, err := ts.CreateRegularUser(ctx, "bob")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		hook, err := ts.Service.CreateUserWebhook(userCtx, &v1pb.CreateUserWebhookRequest{
+			Parent:  "users/bob",
+			Webhook: &v1pb.UserWebhook{DisplayName: "flaky", Url: receiver.URL},
+		})
+		require.NoError(t, err)
+		webhooks, err := ts.Store.GetUserWebhooks(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+
+		// Queue deliveries that are on their last attempt.
+		for range webhookdelivery.DisableAfterFailures {
+			delivery, err := ts.Service.WebhookDeliveryRunner.Enqueue(ctx, user.ID, webhooks[0], &webhook.WebhookRequestPayload{
+				URL:          receiver.URL,
+				ActivityType: "memos.memo.created",
+				Creator:      "users/bob",
+			})
+			require.NoError(t, err)
+			attempts := int32(webhookdelivery.MaxAttempts - 1)
+			require.NoError(t, ts.Store.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDelivery{ID: delivery.ID, Attempts: &attempts}))
+		}
+		ts.Service.WebhookDeliveryRunner.RunOnce(ctx)
+		require.Equal(t, int32(webhookdelivery.DisableAfterFailures), requests.Load())
+
+		list, err := ts.Service.ListUserWebhooks(userCtx, &v1pb.ListUserWebhooksRequest{Parent: "users/bob"})
+		require.NoError(t, err)
+		require.True(t, list.Webhooks[0].Disabled)
+
+		notifications, err := ts.Service.ListUserNotifications(userCtx, &v1pb.ListUserNotificationsRequest{Parent: "users/bob"})
+		require.NoError(t, err)
+		require.Len(t, notifications.Notifications, 1)
+		notification := notifications.Notifications[0]
+		require.Equal(t, v1pb.UserNotification_WEBHOOK_DISABLED, notification.Type)
+		require.Equal(t, hook.Name, notification.GetWebhookDisabled().Webhook)
+		require.Equal(t, "flaky", notification.GetWebhookDisabled().WebhookDisplayName)
+		require.NotEmpty(t, notification.GetWebhookDisabled().LastError)
+
+		// Disabled webhooks neither receive new events nor accept redeliveries.
+		_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+			Memo: &v1pb.Memo{Content: "hello", Visibility: v1pb.Visibility_PRIVATE},
+		})
+		require.NoError(t, err)
+		resp, err := ts.Service.ListUserWebhookDeliveries(userCtx, &v1pb.ListUserWebhookDeliveriesRequest{Parent: hook.Name})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, webhookdelivery.DisableAfterFailures)
+		_, err = ts.Service.RedeliverWebhook(userCtx, &v1pb.RedeliverWebhookRequest{Name: resp.Deliveries[0].Name})
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		// Re-enabling resets the failure count.
+		updated, err := ts.Service.UpdateUserWebhook(userCtx, &v1pb.UpdateUserWebhookRequest{
+			Webhook:    &v1pb.UserWebhook{Name: hook.Name, Disabled: false},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"disabled"}},
+		})
+		require.NoError(t, err)
+		require.False(t, updated.Disabled)
+		webhooks, err = ts.Store.GetUserWebhooks(ctx, user.ID)
+		require.NoError(t, err)
+		require.Zero(t, webhooks[0].FailureCount)
+	})
+
+	t.Run("keeps edits made while recording failures", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		var statusCode, requests atomic.Int32
+		statusCode.Store(http.StatusBadGateway)
+		receiver := newWebhookReceiver(t, &statusCode, &requests)
+
+		user, err := ts.CreateRegularUser(ctx, "erin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		hook, err := ts.Service.CreateUserWebhook(userCtx, &v1pb.CreateUserWebhookRequest{
+			Parent:  "users/erin",
+			Webhook: &v1pb.UserWebhook{DisplayName: "hook", Url: receiver.URL},
+		})
+		require.NoError(t, err)
+		webhooks, err := ts.Store.GetUserWebhooks(ctx, user.ID)
+		require.NoError(t, err)
+
+		failures := webhookdelivery.DisableAfterFailures - 1
+		for range failures {
+			delivery, err := ts.Service.WebhookDeliveryRunner.Enqueue(ctx, user.ID, webhooks[0], &webhook.WebhookRequestPayload{
+				URL:          receiver.URL,
+				ActivityType: "memos.memo.created",
+				Creator:      "users/erin",
+			})
+			require.NoError(t, err)
+			attempts := int32(webhookdelivery.MaxAttempts - 1)
+			require.NoError(t, ts.Store.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDelivery{ID: delivery.ID, Attempts: &attempts}))
+		}
+
+		// Rename the webhook and rotate its secret while the failures are recorded.
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			ts.Service.WebhookDeliveryRunner.RunOnce(ctx)
+		}()
+		secret := "whsec_" + strings.Repeat("a", 32)
+		for i := range 20 {
+			_, err := ts.Service.UpdateUserWebhook(userCtx, &v1pb.UpdateUserWebhookRequest{
+				Webhook:    &v1pb.UserWebhook{Name: hook.Name, DisplayName: fmt.Sprintf("edit %d", i), SigningSecret: secret},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"display_name", "signing_secret"}},
+			})
+			require.NoError(t, err)
+		}
+		<-done
+		require.Equal(t, int32(failures), requests.Load())
+
+		webhooks, err = ts.Store.GetUserWebhooks(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		require.Equal(t, "edit 19", webhooks[0].Title)
+		require.Equal(t, secret, webhooks[0].SigningSecret)
+		require.Equal(t, int32(failures), webhooks[0].FailureCount)
+		require.False(t, webhooks[0].Disabled)
+	})
+
+	t.Run("restricts delivery history to the owner", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		var statusCode, requests atomic.Int32
+		statusCode.Store(http.StatusOK)
+		receiver := newWebhookReceiver(t, &statusCode, &requests)
+
+		owner, err := ts.CreateRegularUser(ctx, "carol")
+		require.NoError(t, err)
+		other, err := ts.CreateRegularUser(ctx, "dave")
+		require.NoError(t, err)
+
+		hook, err := ts.Service.CreateUserWebhook(ts.CreateUserContext(ctx, owner.ID), &v1pb.CreateUserWebhookRequest{
+			Parent:  "users/carol",
+			Webhook: &v1pb.UserWebhook{DisplayName: "hook", Url: receiver.URL},
+		})
+		require.NoError(t, err)
+
+		_, err = ts.Service.ListUserWebhookDeliveries(ts.CreateUserContext(ctx, other.ID), &v1pb.ListUserWebhookDeliveriesRequest{Parent: hook.Name})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = ts.Service.ListUserWebhookDeliveries(ts.CreateUserContext(ctx, owner.ID), &v1pb.ListUserWebhookDeliveriesRequest{Parent: "users/carol/webhooks/missing"})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestWebhookSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	var statusCode, requests atomic.Int32
+	statusCode.Store(http.StatusOK)
+	receiver := newWebhookReceiver(t, &statusCode, &requests)
+
+	user, err := ts.CreateRegularUser(ctx, "alice")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	_, err = ts.Service.CreateUserWebhook(userCtx, &v1pb.CreateUserWebhookRequest{
+		Parent:  "users/alice",
+		Webhook: &v1pb.UserWebhook{Url: receiver.URL, EventTypes: []string{"memos.memo.exploded"}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = ts.Service.CreateUserWebhook(userCtx, &v1pb.CreateUserWebhookRequest{
+		Parent:  "users/alice",
+		Webhook: &v1pb.UserWebhook{Url: receiver.URL, Filter: "unknown_field == 1"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	hook, err := ts.Service.CreateUserWebhook(userCtx, &v1pb.CreateUserWebhookRequest{
+		Parent: "users/alice",
+		Webhook: &v1pb.UserWebhook{
+			DisplayName: "deploys",
+			Url:         receiver.URL,
+			EventTypes:  []string{webhook.ActivityMemoPinned, webhook.ActivityReactionUpserted, webhook.ActivityMemoPinned},
+			Filter:      `tag in ["deploy"]`,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{webhook.ActivityMemoPinned, webhook.ActivityReactionUpserted}, hook.EventTypes)
+	require.Equal(t, `tag in ["deploy"]`, hook.Filter)
+
+	deployMemo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "shipping #deploy", Visibility: v1pb.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	otherMemo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "lunch #food", Visibility: v1pb.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	for _, memo := range []*v1pb.Memo{deployMemo, otherMemo} {
+		_, err = ts.Service.UpdateMemo(userCtx, &v1pb.UpdateMemoRequest{
+			Memo:       &v1pb.Memo{Name: memo.Name, Pinned: true},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"pinned"}},
+		})
+		require.NoError(t, err)
+		_, err = ts.Service.UpsertMemoReaction(userCtx, &v1pb.UpsertMemoReactionRequest{
+			Name:     memo.Name,
+			Reaction: &v1pb.Reaction{ReactionType: "🚀"},
+		})
+		require.NoError(t, err)
+	}
+
+	// Only the subscribed events of the memo matching the filter are queued.
+	resp, err := ts.Service.ListUserWebhookDeliveries(userCtx, &v1pb.ListUserWebhookDeliveriesRequest{Parent: hook.Name})
+	require.NoError(t, err)
+	activityTypes := []string{}
+	for _, delivery := range resp.Deliveries {
+		activityTypes = append(activityTypes, delivery.ActivityType)
+	}
+	require.ElementsMatch(t, []string{webhook.ActivityMemoPinned, webhook.ActivityReactionUpserted}, activityTypes)
+
+	ts.Service.WebhookDeliveryRunner.RunOnce(ctx)
+	require.Equal(t, int32(2), requests.Load())
+}
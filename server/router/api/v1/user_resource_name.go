@@ -30,6 +30,9 @@ func validateWritableUsername(username string) error {
 	if !base.IsValidUsername(username) {
 		return errors.Errorf("invalid username %q", username)
 	}
+	if base.IsHereMention(username) {
+		return errors.Errorf("username %q is reserved", username)
+	}
 	return nil
 }
 
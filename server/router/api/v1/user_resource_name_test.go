@@ -74,6 +74,11 @@ func TestValidateWritableUsername(t *testing.T) {
 			username:  "alice/smith",
 			wantError: true,
 		},
+		{
+			name:      "here mention",
+			username:  "Here",
+			wantError: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -16,7 +16,6 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
-	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -419,24 +418,16 @@ func (s *APIV1Service) DeleteUser(ctx context.Context, request *v1pb.DeleteUserR
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete user: %v", err)
 	}
-	attachments := deleteResult.Attachments
-	var attachmentCleanupErr error
-	failedAttachmentIDs := make([]int32, 0)
-	attachmentStorageSetting, attachmentStorageSettingErr := getDeleteUserAttachmentStorageSetting(ctx, s.Store, attachments)
-	for _, attachment := range attachments {
-		var err error
-		if attachmentStorageSettingErr != nil && store.AttachmentNeedsInstanceStorageSetting(attachment) {
-			err = attachmentStorageSettingErr
-		} else {
-			err = s.Store.DeleteAttachmentStorageWithInstanceSetting(ctx, attachment, attachmentStorageSetting)
-		}
-		if err != nil {
-			slog.Warn("failed to delete attachment storage after deleting user", "user_id", userID, "attachment_id", attachment.ID, "error", err)
-			failedAttachmentIDs = append(failedAttachmentIDs, attachment.ID)
-			if attachmentCleanupErr == nil {
-				attachmentCleanupErr = err
-			}
+	// The rows are gone with the user; the stored objects are released under
+	// the content lock so a concurrent upload reusing them keeps them alive.
+	attachmentCleanupErr := s.Store.ReleaseAttachmentContent(ctx, deleteResult.Attachments)
+	// Like the expiry sweep, a storage that cannot be cleaned up only logs;
+	// incomplete multipart uploads can also be expired by a bucket lifecycle rule.
+	for _, session := range deleteResult.UploadSessions {
+		if err := s.Store.DeleteUploadSessionStorage(ctx, session); err != nil {
+			slog.Warn("failed to discard upload session storage after deleting user", "user_id", userID, "session", session.UID, "error", err)
 		}
+		s.Store.ForgetUploadSessionLock(session.UID)
 	}
 	if isSelfDelete {
 		if err := s.clearAuthCookies(ctx); err != nil {
@@ -444,31 +435,23 @@ func (s *APIV1Service) DeleteUser(ctx context.Context, request *v1pb.DeleteUserR
 		}
 	}
 	if attachmentCleanupErr != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			"user was deleted but attachment storage cleanup failed for %d attachment(s), first attachment_id=%d: %v",
-			len(failedAttachmentIDs),
-			failedAttachmentIDs[0],
-			attachmentCleanupErr,
-		)
+		slog.Warn("failed to delete attachment storage after deleting user", "user_id", userID, "error", attachmentCleanupErr)
+		var cleanupErr *store.AttachmentCleanupError
+		if errors.As(attachmentCleanupErr, &cleanupErr) {
+			return nil, status.Errorf(
+				codes.Internal,
+				"user was deleted but attachment storage cleanup failed for %d attachment(s), first attachment_id=%d: %v",
+				len(cleanupErr.AttachmentIDs),
+				cleanupErr.AttachmentIDs[0],
+				cleanupErr.Err,
+			)
+		}
+		return nil, status.Errorf(codes.Internal, "user was deleted but attachment storage cleanup failed: %v", attachmentCleanupErr)
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
-func getDeleteUserAttachmentStorageSetting(ctx context.Context, stores *store.Store, attachments []*store.Attachment) (*storepb.InstanceStorageSetting, error) {
-	for _, attachment := range attachments {
-		if store.AttachmentNeedsInstanceStorageSetting(attachment) {
-			instanceStorageSetting, err := stores.GetInstanceStorageSetting(ctx)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to get instance storage setting")
-			}
-			return instanceStorageSetting, nil
-		}
-	}
-	return nil, nil
-}
-
 func getDefaultUserGeneralSetting() *v1pb.UserSetting_GeneralSetting {
 	return &v1pb.UserSetting_GeneralSetting{
 		Locale:         "en",
@@ -191,6 +191,7 @@ func convertUserSettingFromStore(storeSetting *storepb.UserSetting, user *store.
 					MemoVisibility:    general.MemoVisibility,
 					Theme:             general.Theme,
 					SaveMediaMetadata: general.SaveMediaMetadata,
+					TimeZone:          general.TimeZone,
 				},
 			}
 		} else {
@@ -245,6 +246,7 @@ func convertUserSettingToStore(apiSetting *v1pb.UserSetting, userID int32, key s
 					MemoVisibility:    general.MemoVisibility,
 					Theme:             general.Theme,
 					SaveMediaMetadata: general.SaveMediaMetadata,
+					TimeZone:          general.TimeZone,
 				},
 			}
 		} else {
@@ -291,6 +291,32 @@ func (s *APIV1Service) convertInboxToUserNotificationWithUsersAndMemos(inbox *st
 					MemoMention: payload,
 				}
 			}
+		case storepb.InboxMessage_WEBHOOK_DISABLED:
+			notification.Type = v1pb.UserNotification_WEBHOOK_DISABLED
+			if webhookDisabled := inbox.Message.GetWebhookDisabled(); webhookDisabled != nil {
+				notification.Payload = &v1pb.UserNotification_WebhookDisabled{
+					WebhookDisabled: &v1pb.UserNotification_WebhookDisabledPayload{
+						Webhook:            fmt.Sprintf("%s/webhooks/%s", BuildUserName(receiver.Username), webhookDisabled.WebhookId),
+						WebhookDisplayName: webhookDisabled.WebhookTitle,
+						LastError:          webhookDisabled.LastError,
+					},
+				}
+			}
+		case storepb.InboxMessage_ATTACHMENT_INFECTED:
+			notification.Type = v1pb.UserNotification_ATTACHMENT_INFECTED
+			if infected := inbox.Message.GetAttachmentInfected(); infected != nil {
+				payload := &v1pb.UserNotification_AttachmentInfectedPayload{
+					Filename:    infected.Filename,
+					Signature:   infected.Signature,
+					Quarantined: infected.Quarantined,
+				}
+				if infected.AttachmentUid != "" {
+					payload.Attachment = fmt.Sprintf("%s%s", AttachmentNamePrefix, infected.AttachmentUid)
+				}
+				notification.Payload = &v1pb.UserNotification_AttachmentInfected{
+					AttachmentInfected: payload,
+				}
+			}
 		default:
 			notification.Type = v1pb.UserNotification_TYPE_UNSPECIFIED
 		}
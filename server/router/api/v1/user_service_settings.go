@@ -3,6 +3,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -110,6 +111,11 @@ func (s *APIV1Service) UpdateUserSetting(ctx context.Context, request *v1pb.Upda
 				updatedGeneral.Locale = incomingGeneral.Locale
 			case "save_media_metadata":
 				updatedGeneral.SaveMediaMetadata = incomingGeneral.SaveMediaMetadata
+			case "time_zone":
+				if _, err := time.LoadLocation(incomingGeneral.TimeZone); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid time zone: %v", err)
+				}
+				updatedGeneral.TimeZone = incomingGeneral.TimeZone
 			default:
 				// Ignore unsupported fields.
 			}
@@ -287,6 +287,13 @@ func (s *APIV1Service) GetUserStats(ctx context.Context, request *v1pb.GetUserSt
 			UndoCount: undoCount,
 		},
 	}
+	// Storage usage is private to the user and admins.
+	if currentUser != nil && (currentUser.ID == userID || currentUser.Role == store.RoleAdmin) {
+		userStats.StorageUsage, err = s.getUserStorageUsage(ctx, user)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get storage usage: %v", err)
+		}
+	}
 
 	return userStats, nil
 }
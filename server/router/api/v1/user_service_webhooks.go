@@ -5,11 +5,16 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/usememos/memos/internal/webhook"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
@@ -87,12 +92,23 @@ func (s *APIV1Service) CreateUserWebhook(ctx context.Context, request *v1pb.Crea
 		return nil, err
 	}
 
+	eventTypes, err := webhook.NormalizeActivityTypes(request.Webhook.EventTypes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid event types: %v", err)
+	}
+	filter, err := s.validateWebhookFilter(ctx, request.Webhook.Filter)
+	if err != nil {
+		return nil, err
+	}
+
 	webhookID := generateUserWebhookID()
 	webhook := &storepb.WebhooksUserSetting_Webhook{
 		Id:            webhookID,
 		Title:         request.Webhook.DisplayName,
 		Url:           strings.TrimSpace(request.Webhook.Url),
 		SigningSecret: signingSecret,
+		EventTypes:    eventTypes,
+		Filter:        filter,
 	}
 
 	err = s.Store.AddUserWebhook(ctx, userID, webhook)
@@ -150,6 +166,10 @@ func (s *APIV1Service) UpdateUserWebhook(ctx context.Context, request *v1pb.Upda
 		Title:         targetWebhook.Title,
 		Url:           targetWebhook.Url,
 		SigningSecret: targetWebhook.SigningSecret,
+		Disabled:      targetWebhook.Disabled,
+		FailureCount:  targetWebhook.FailureCount,
+		EventTypes:    targetWebhook.EventTypes,
+		Filter:        targetWebhook.Filter,
 	}
 
 	if request.UpdateMask != nil {
@@ -171,6 +191,24 @@ func (s *APIV1Service) UpdateUserWebhook(ctx context.Context, request *v1pb.Upda
 					return nil, err
 				}
 				updatedWebhook.SigningSecret = secret
+			case "disabled":
+				updatedWebhook.Disabled = request.Webhook.Disabled
+				if !updatedWebhook.Disabled {
+					// Re-enabling gives the endpoint a fresh start.
+					updatedWebhook.FailureCount = 0
+				}
+			case "event_types":
+				eventTypes, err := webhook.NormalizeActivityTypes(request.Webhook.EventTypes)
+				if err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid event types: %v", err)
+				}
+				updatedWebhook.EventTypes = eventTypes
+			case "filter":
+				filter, err := s.validateWebhookFilter(ctx, request.Webhook.Filter)
+				if err != nil {
+					return nil, err
+				}
+				updatedWebhook.Filter = filter
 			default:
 				// Ignore unsupported fields
 			}
@@ -192,14 +230,59 @@ func (s *APIV1Service) UpdateUserWebhook(ctx context.Context, request *v1pb.Upda
 			}
 			updatedWebhook.SigningSecret = secret
 		}
+		eventTypes, err := webhook.NormalizeActivityTypes(request.Webhook.EventTypes)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid event types: %v", err)
+		}
+		updatedWebhook.EventTypes = eventTypes
+		filter, err := s.validateWebhookFilter(ctx, request.Webhook.Filter)
+		if err != nil {
+			return nil, err
+		}
+		updatedWebhook.Filter = filter
 	}
 
-	err = s.Store.UpdateUserWebhook(ctx, userID, updatedWebhook)
+	// Apply only the fields this request changed to the stored webhook, so
+	// failures the delivery runner recorded meanwhile are kept.
+	storedWebhook, err := s.Store.ModifyUserWebhook(ctx, userID, webhookID, func(hook *storepb.WebhooksUserSetting_Webhook) bool {
+		return applyUserWebhookChanges(hook, targetWebhook, updatedWebhook)
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update webhook: %v", err)
 	}
+	if storedWebhook == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook not found")
+	}
 
-	return convertUserWebhookFromUserSetting(updatedWebhook, user), nil
+	return convertUserWebhookFromUserSetting(storedWebhook, user), nil
+}
+
+// applyUserWebhookChanges copies the fields that differ between before and
+// after onto hook and reports whether hook changed.
+func applyUserWebhookChanges(hook, before, after *storepb.WebhooksUserSetting_Webhook) bool {
+	original := proto.Clone(hook)
+	if after.Title != before.Title {
+		hook.Title = after.Title
+	}
+	if after.Url != before.Url {
+		hook.Url = after.Url
+	}
+	if after.SigningSecret != before.SigningSecret {
+		hook.SigningSecret = after.SigningSecret
+	}
+	if after.Disabled != before.Disabled {
+		hook.Disabled = after.Disabled
+	}
+	if after.FailureCount != before.FailureCount {
+		hook.FailureCount = after.FailureCount
+	}
+	if !slices.Equal(after.EventTypes, before.EventTypes) {
+		hook.EventTypes = after.EventTypes
+	}
+	if after.Filter != before.Filter {
+		hook.Filter = after.Filter
+	}
+	return !proto.Equal(original, hook)
 }
 
 func (s *APIV1Service) DeleteUserWebhook(ctx context.Context, request *v1pb.DeleteUserWebhookRequest) (*emptypb.Empty, error) {
@@ -243,6 +326,12 @@ func (s *APIV1Service) DeleteUserWebhook(ctx context.Context, request *v1pb.Dele
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete webhook: %v", err)
 	}
+	if err := s.Store.DeleteWebhookDeliveries(ctx, &store.DeleteWebhookDelivery{
+		CreatorID: &userID,
+		WebhookID: &webhookID,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete webhook deliveries: %v", err)
+	}
 
 	return &emptypb.Empty{}, nil
 }
@@ -275,6 +364,94 @@ func (s *APIV1Service) GetUserWebhookSigningSecret(ctx context.Context, request
 	return nil, status.Errorf(codes.NotFound, "webhook not found")
 }
 
+// ListUserWebhookDeliveries returns the delivery history of a webhook, newest first.
+func (s *APIV1Service) ListUserWebhookDeliveries(ctx context.Context, request *v1pb.ListUserWebhookDeliveriesRequest) (*v1pb.ListUserWebhookDeliveriesResponse, error) {
+	user, webhookID, err := s.resolveUserAndWebhookIDFromName(ctx, request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid parent: %v", err)
+	}
+	if _, err := s.authorizeUserWebhookAccess(ctx, user.ID, webhookID); err != nil {
+		return nil, err
+	}
+
+	var limit, offset int
+	if request.PageToken != "" {
+		var pageToken v1pb.PageToken
+		if err := unmarshalPageToken(request.PageToken, &pageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+		limit = normalizePageSize(pageToken.Limit)
+		offset = max(int(pageToken.Offset), 0)
+	} else {
+		limit = normalizePageSize(request.PageSize)
+	}
+	limit = min(limit, MaxPageSize)
+	limitPlusOne := limit + 1
+	deliveries, err := s.Store.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		CreatorID: &user.ID,
+		WebhookID: &webhookID,
+		Limit:     &limitPlusOne,
+		Offset:    &offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook deliveries: %v", err)
+	}
+
+	nextPageToken := ""
+	if len(deliveries) == limitPlusOne {
+		deliveries = deliveries[:limit]
+		nextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token: %v", err)
+		}
+	}
+
+	response := &v1pb.ListUserWebhookDeliveriesResponse{
+		Deliveries:    make([]*v1pb.WebhookDelivery, 0, len(deliveries)),
+		NextPageToken: nextPageToken,
+	}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, convertWebhookDeliveryFromStore(delivery, user))
+	}
+	return response, nil
+}
+
+// RedeliverWebhook queues a previous delivery again under a new message id.
+func (s *APIV1Service) RedeliverWebhook(ctx context.Context, request *v1pb.RedeliverWebhookRequest) (*v1pb.WebhookDelivery, error) {
+	user, webhookID, deliveryUID, err := s.resolveUserWebhookAndDeliveryFromName(ctx, request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid delivery name: %v", err)
+	}
+	hook, err := s.authorizeUserWebhookAccess(ctx, user.ID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if hook.Disabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "webhook is disabled")
+	}
+
+	delivery, err := s.Store.GetWebhookDelivery(ctx, &store.FindWebhookDelivery{
+		UID:       &deliveryUID,
+		CreatorID: &user.ID,
+		WebhookID: &webhookID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get webhook delivery: %v", err)
+	}
+	if delivery == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook delivery not found")
+	}
+	if s.WebhookDeliveryRunner == nil {
+		return nil, status.Errorf(codes.Unavailable, "webhook delivery is not available")
+	}
+
+	redelivery, err := s.WebhookDeliveryRunner.Redeliver(ctx, delivery)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to redeliver webhook: %v", err)
+	}
+	return convertWebhookDeliveryFromStore(redelivery, user), nil
+}
+
 // Helper functions for webhook operations
 
 // generateUserWebhookID generates a unique ID for user webhooks.
@@ -291,7 +468,94 @@ func convertUserWebhookFromUserSetting(webhook *storepb.WebhooksUserSetting_Webh
 		Url:              webhook.Url,
 		DisplayName:      webhook.Title,
 		SigningSecretSet: webhook.SigningSecret != "",
+		Disabled:         webhook.Disabled,
+		EventTypes:       webhook.EventTypes,
+		Filter:           webhook.Filter,
 		// Note: create_time and update_time are not available in the user setting webhook structure
 		// This is a limitation of storing webhooks in user settings vs the dedicated webhook table
 	}
 }
+
+// validateWebhookFilter trims a webhook filter and checks that it compiles
+// against the memo filter schema. An empty filter is allowed.
+func (s *APIV1Service) validateWebhookFilter(ctx context.Context, filter string) (string, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return "", nil
+	}
+	if err := s.validateFilter(ctx, filter); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+	return filter, nil
+}
+
+// authorizeUserWebhookAccess checks that the current user owns the webhook or
+// is an admin, and returns the webhook.
+func (s *APIV1Service) authorizeUserWebhookAccess(ctx context.Context, userID int32, webhookID string) (*storepb.WebhooksUserSetting_Webhook, error) {
+	currentUser, err := s.fetchCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if currentUser.ID != userID && currentUser.Role != store.RoleAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	webhooks, err := s.Store.GetUserWebhooks(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user webhooks: %v", err)
+	}
+	for _, webhook := range webhooks {
+		if webhook.Id == webhookID {
+			return webhook, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "webhook not found")
+}
+
+// resolveUserWebhookAndDeliveryFromName parses users/{user}/webhooks/{webhook}/deliveries/{delivery}.
+func (s *APIV1Service) resolveUserWebhookAndDeliveryFromName(ctx context.Context, name string) (*store.User, string, string, error) {
+	webhookName, deliveryUID, ok := strings.Cut(name, "/deliveries/")
+	if !ok || deliveryUID == "" || strings.Contains(deliveryUID, "/") {
+		return nil, "", "", errors.Errorf("invalid webhook delivery name: %s", name)
+	}
+	user, webhookID, err := s.resolveUserAndWebhookIDFromName(ctx, webhookName)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return user, webhookID, deliveryUID, nil
+}
+
+func convertWebhookDeliveryFromStore(delivery *store.WebhookDelivery, user *store.User) *v1pb.WebhookDelivery {
+	webhookDelivery := &v1pb.WebhookDelivery{
+		Name:               fmt.Sprintf("%s/webhooks/%s/deliveries/%s", BuildUserName(user.Username), delivery.WebhookID, delivery.UID),
+		ActivityType:       delivery.ActivityType,
+		State:              convertWebhookDeliveryStateFromStore(delivery.Status),
+		Attempts:           delivery.Attempts,
+		ResponseStatusCode: delivery.ResponseStatusCode,
+		LatencyMs:          delivery.LatencyMs,
+		ResponseExcerpt:    delivery.ResponseExcerpt,
+		Error:              delivery.LastError,
+		CreateTime:         timestamppb.New(time.Unix(delivery.CreatedTs, 0)),
+		UpdateTime:         timestamppb.New(time.Unix(delivery.UpdatedTs, 0)),
+	}
+	if delivery.Status == store.WebhookDeliveryPending {
+		webhookDelivery.NextAttemptTime = timestamppb.New(time.Unix(delivery.NextAttemptTs, 0))
+	}
+	return webhookDelivery
+}
+
+func convertWebhookDeliveryStateFromStore(deliveryStatus store.WebhookDeliveryStatus) v1pb.WebhookDelivery_State {
+	switch deliveryStatus {
+	case store.WebhookDeliveryPending:
+		return v1pb.WebhookDelivery_PENDING
+	case store.WebhookDeliverySucceeded:
+		return v1pb.WebhookDelivery_SUCCEEDED
+	case store.WebhookDeliveryFailed:
+		return v1pb.WebhookDelivery_FAILED
+	default:
+		return v1pb.WebhookDelivery_STATE_UNSPECIFIED
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"path/filepath"
+	"sync"
 
 	"connectrpc.com/connect"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -11,12 +13,17 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/sync/semaphore"
 
+	"github.com/usememos/memos/internal/ai"
+	"github.com/usememos/memos/internal/diagram"
+	"github.com/usememos/memos/internal/docextract"
 	"github.com/usememos/memos/internal/httpgetter"
 	"github.com/usememos/memos/internal/markdown"
+	"github.com/usememos/memos/internal/mediapreview"
 	"github.com/usememos/memos/internal/profile"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	"github.com/usememos/memos/server/auth"
 	"github.com/usememos/memos/server/notification"
+	"github.com/usememos/memos/server/runner/webhookdelivery"
 	"github.com/usememos/memos/store"
 )
 
@@ -33,6 +40,7 @@ type APIV1Service struct {
 	v1pb.UnimplementedAttachmentServiceServer
 	v1pb.UnimplementedAIServiceServer
 	v1pb.UnimplementedMemoViewServiceServer
+	v1pb.UnimplementedMemoTemplateServiceServer
 	v1pb.UnimplementedIdentityProviderServiceServer
 
 	Secret                  string
@@ -41,10 +49,37 @@ type APIV1Service struct {
 	MarkdownService         markdown.Service
 	SSEHub                  *SSEHub
 	NotificationEmailSender notification.EmailSender
+	// WebhookDeliveryRunner queues and retries webhook deliveries.
+	WebhookDeliveryRunner *webhookdelivery.Runner
+	// MediaPreviewGenerator extracts video posters and audio waveforms; nil
+	// disables media previews.
+	MediaPreviewGenerator *mediapreview.Generator
+	// DocumentExtractor extracts the text of PDF, Office and plain text
+	// attachments; nil disables text extraction.
+	DocumentExtractor *docextract.Extractor
+	// DiagramService renders diagram code blocks in memo HTML to SVG.
+	DiagramService *diagram.Service
 
 	// thumbnailSemaphore limits concurrent thumbnail generation to prevent memory exhaustion
 	thumbnailSemaphore       *semaphore.Weighted
 	imageProcessingSemaphore *semaphore.Weighted
+	// transcriptionSemaphore limits concurrent background attachment transcriptions.
+	transcriptionSemaphore *semaphore.Weighted
+	// imageAnalysisSemaphore limits concurrent background OCR and captioning.
+	imageAnalysisSemaphore *semaphore.Weighted
+	// mediaPreviewSemaphore limits concurrent background poster and waveform extraction.
+	mediaPreviewSemaphore *semaphore.Weighted
+	// documentExtractionSemaphore limits concurrent background text extraction.
+	documentExtractionSemaphore *semaphore.Weighted
+	// aiProviderLimiter enforces the per-provider max_concurrent_requests setting.
+	aiProviderLimiter ai.ProviderLimiter
+
+	// attachmentQuotaReservations holds an *attachmentQuotaReservation per
+	// user ID, counting uploads that are not stored yet against the quota.
+	attachmentQuotaReservations sync.Map
+	// dailyMemoMutexes holds a *sync.Mutex per user ID, serializing the
+	// creation of and appends to the daily memos of a user.
+	dailyMemoMutexes sync.Map
 
 	// instanceStatsCache memoizes GetInstanceStats results for instanceStatsCacheTTL.
 	instanceStatsCache instanceStatsCache
@@ -54,24 +89,55 @@ type APIV1Service struct {
 
 // NewAPIV1Service creates an API v1 service with its shared dependencies.
 func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store) *APIV1Service {
+	diagramService := diagram.New(filepath.Join(profile.Data, diagram.CacheFolder), diagram.Config{
+		EnableCommands: profile.ExternalDiagramRenderers,
+	})
 	markdownService := markdown.NewService(
 		markdown.WithTagExtension(),
 		markdown.WithMentionExtension(),
+		markdown.WithWikiLinkExtension(),
+		markdown.WithInstanceURL(profile.InstanceURL),
+		markdown.WithDiagramResolver(newDiagramResolver(diagramService)),
 	)
 	service := &APIV1Service{
-		Secret:                   secret,
-		Profile:                  profile,
-		Store:                    store,
-		MarkdownService:          markdownService,
-		SSEHub:                   NewSSEHub(),
-		NotificationEmailSender:  nil,
-		thumbnailSemaphore:       semaphore.NewWeighted(3), // Limit to 3 concurrent thumbnail generations
-		imageProcessingSemaphore: semaphore.NewWeighted(2),
+		Secret:                      secret,
+		Profile:                     profile,
+		Store:                       store,
+		MarkdownService:             markdownService,
+		SSEHub:                      NewSSEHub(),
+		NotificationEmailSender:     nil,
+		WebhookDeliveryRunner:       webhookdelivery.NewRunner(store),
+		MediaPreviewGenerator:       mediapreview.New(""),
+		DocumentExtractor:           docextract.New(""),
+		DiagramService:              diagramService,
+		thumbnailSemaphore:          semaphore.NewWeighted(3), // Limit to 3 concurrent thumbnail generations
+		imageProcessingSemaphore:    semaphore.NewWeighted(2),
+		transcriptionSemaphore:      semaphore.NewWeighted(2),
+		imageAnalysisSemaphore:      semaphore.NewWeighted(2),
+		mediaPreviewSemaphore:       semaphore.NewWeighted(2),
+		documentExtractionSemaphore: semaphore.NewWeighted(2),
 	}
 	service.linkMetadataFetcher = httpgetter.NewHTMLMetaFetcher()
 	return service
 }
 
+// newDiagramResolver renders diagram code blocks for the markdown service and
+// points them at the file server. A diagram that fails to render is logged
+// and shown as code.
+func newDiagramResolver(diagramService *diagram.Service) markdown.DiagramResolver {
+	return func(ctx context.Context, language string, source []byte) (string, bool) {
+		if !diagramService.Supports(language) {
+			return "", false
+		}
+		key, err := diagramService.Render(ctx, language, source)
+		if err != nil {
+			slog.Warn("failed to render diagram", slog.String("language", language), slog.Any("err", err))
+			return "", false
+		}
+		return "/file/diagrams/" + key + ".svg", true
+	}
+}
+
 // newGatewayMarshaler mirrors grpc-gateway's default JSON marshaler with one
 // change: EmitDefaultValues replaces EmitUnpopulated. Both keep proto3 scalar
 // defaults ("" / 0 / false) and empty lists in the payload — the generated
@@ -158,12 +224,16 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 	if err := v1pb.RegisterMemoViewServiceHandlerServer(ctx, gwMux, s); err != nil {
 		return err
 	}
+	if err := v1pb.RegisterMemoTemplateServiceHandlerServer(ctx, gwMux, s); err != nil {
+		return err
+	}
 	if err := v1pb.RegisterIdentityProviderServiceHandlerServer(ctx, gwMux, s); err != nil {
 		return err
 	}
 	gwGroup := echoServer.Group("")
 	// Register SSE endpoint with same CORS as rest of /api/v1.
 	RegisterSSERoutes(gwGroup, s.SSEHub, s.Store, s.Secret)
+	s.RegisterUploadRoutes(gwGroup)
 	handler := echo.WrapHandler(http.MaxBytesHandler(gwMux, MaxAPIRequestBytes))
 
 	gwGroup.Any("/api/v1/*", handler)
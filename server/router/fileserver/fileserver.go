@@ -15,11 +15,12 @@ import (
 	"strings"
 	"time"
 
-	"github.com/disintegration/imaging"
 	"github.com/labstack/echo/v5"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/semaphore"
 
+	"github.com/usememos/memos/internal/diagram"
+	"github.com/usememos/memos/internal/imageconv"
 	"github.com/usememos/memos/internal/motionphoto"
 	"github.com/usememos/memos/internal/profile"
 	"github.com/usememos/memos/internal/storage"
@@ -31,27 +32,28 @@ import (
 
 // Constants for file serving configuration.
 const (
-	// thumbnailCacheFolder is the folder name where thumbnail images are stored.
-	thumbnailCacheFolder = ".thumbnail_cache"
-
 	// motionCacheFolder is the folder name where extracted motion clips are stored.
 	motionCacheFolder = ".motion_cache"
 
-	// thumbnailMaxSize is the maximum dimension (width or height) for thumbnails.
-	thumbnailMaxSize = 600
-
-	// thumbnailMetadataProbeSize is the maximum number of original image bytes inspected
-	// before thumbnail generation to detect metadata that the JPEG thumbnail pipeline cannot preserve.
-	thumbnailMetadataProbeSize = 1 << 20
+	// variantMetadataProbeSize is the maximum number of original image bytes inspected
+	// before variant generation to detect metadata that re-encoding cannot preserve.
+	variantMetadataProbeSize = 1 << 20
 
-	// maxConcurrentThumbnails limits concurrent thumbnail generation to prevent memory exhaustion.
-	maxConcurrentThumbnails = 3
+	// maxConcurrentVariants limits concurrent image variant generation to prevent memory exhaustion.
+	maxConcurrentVariants = 3
 
 	// cacheMaxAge is the max-age value for Cache-Control headers (1 hour).
 	cacheMaxAge = "public, max-age=3600"
 
 	publicAttachmentCacheControl  = "public, no-cache"
 	privateAttachmentCacheControl = "private, no-store"
+
+	// presignedDownloadTTL is how long a presigned download redirect stays valid.
+	presignedDownloadTTL = 5 * time.Minute
+
+	// diagramCacheControl caches rendered diagrams for a year: their URL is
+	// the hash of their source, so the content behind it never changes.
+	diagramCacheControl = "public, max-age=31536000, immutable"
 )
 
 // xssUnsafeTypes contains MIME types that could execute scripts if served directly.
@@ -66,14 +68,16 @@ var xssUnsafeTypes = map[string]bool{
 	"application/xhtml+xml":    true,
 }
 
-// thumbnailSupportedTypes contains image MIME types that support thumbnail generation.
-var thumbnailSupportedTypes = map[string]bool{
-	"image/png":  true,
-	"image/jpeg": true,
-	"image/jpg":  true,
-	"image/heic": true,
-	"image/heif": true,
-	"image/webp": true,
+// variantSupportedTypes contains MIME types that support variant generation.
+// Variants of PDFs show their first page.
+var variantSupportedTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/jpg":       true,
+	"image/heic":      true,
+	"image/heif":      true,
+	"image/webp":      true,
+	"application/pdf": true,
 }
 
 // avatarAllowedTypes contains MIME types allowed for user avatars.
@@ -87,8 +91,6 @@ var avatarAllowedTypes = map[string]bool{
 	"image/heif": true,
 }
 
-var errUseOriginalForThumbnail = errors.New("serve original image instead of metadata-stripping thumbnail")
-
 // dataURIRegex parses data URI format: data:image/png;base64,iVBORw0KGgo...
 var dataURIRegex = regexp.MustCompile(`^data:(?P<type>[^;]+);base64,(?P<base64>.+)`)
 
@@ -98,17 +100,23 @@ type FileServerService struct {
 	Store         *store.Store
 	authenticator *auth.Authenticator
 
-	// thumbnailSemaphore limits concurrent thumbnail generation.
-	thumbnailSemaphore *semaphore.Weighted
+	// ImageConverter encodes image variants and decodes HEIC photos.
+	ImageConverter *imageconv.Converter
+	// DiagramService holds the diagrams rendered in memo HTML; nil serves none.
+	DiagramService *diagram.Service
+
+	// variantSemaphore limits concurrent image variant generation.
+	variantSemaphore *semaphore.Weighted
 }
 
 // NewFileServerService creates a new file server service.
 func NewFileServerService(profile *profile.Profile, store *store.Store, secret string) *FileServerService {
 	return &FileServerService{
-		Profile:            profile,
-		Store:              store,
-		authenticator:      auth.NewAuthenticator(store, secret),
-		thumbnailSemaphore: semaphore.NewWeighted(maxConcurrentThumbnails),
+		Profile:          profile,
+		Store:            store,
+		authenticator:    auth.NewAuthenticator(store, secret),
+		ImageConverter:   imageconv.New(imageconv.Commands{}),
+		variantSemaphore: semaphore.NewWeighted(maxConcurrentVariants),
 	}
 }
 
@@ -118,6 +126,7 @@ func (s *FileServerService) RegisterRoutes(echoServer *echo.Echo) {
 	fileGroup.GET("/attachments/:uid", s.serveAttachmentFile)
 	fileGroup.GET("/attachments/:uid/:filename", s.serveAttachmentFile)
 	fileGroup.GET("/users/:identifier/avatar", s.serveUserAvatar)
+	fileGroup.GET("/diagrams/:filename", s.serveDiagram)
 }
 
 // =============================================================================
@@ -129,8 +138,12 @@ func (s *FileServerService) serveAttachmentFile(c *echo.Context) error {
 	ctx := c.Request().Context()
 	c.Response().Header().Set(echo.HeaderCacheControl, privateAttachmentCacheControl)
 	uid := c.Param("uid")
-	wantThumbnail := c.QueryParam("thumbnail") == "true"
+	variant, err := parseImageVariant(c)
+	if err != nil {
+		return err
+	}
 	wantMotion := c.QueryParam("motion") == "true"
+	wantPoster := c.QueryParam("poster") == "true"
 
 	attachment, err := s.Store.GetAttachment(ctx, &store.FindAttachment{
 		UID:     &uid,
@@ -150,10 +163,18 @@ func (s *FileServerService) serveAttachmentFile(c *echo.Context) error {
 	if readClass == access.MemoReadClassPublic {
 		c.Response().Header().Set(echo.HeaderCacheControl, publicAttachmentCacheControl)
 	}
+	// Content the malware scanner found infected is kept for admins to review
+	// but never served, not even to its creator.
+	if attachment.Payload.GetContentScan().GetStatus() == storepb.ContentScan_INFECTED {
+		return echo.NewHTTPError(http.StatusForbidden, "attachment is quarantined")
+	}
 
 	if wantMotion {
 		return s.serveMotionClip(c, attachment)
 	}
+	if wantPoster {
+		return s.servePoster(c, attachment)
+	}
 
 	contentType := sanitizeContentType(attachment.Type)
 
@@ -162,7 +183,7 @@ func (s *FileServerService) serveAttachmentFile(c *echo.Context) error {
 		return s.serveMediaStream(c, attachment, contentType)
 	}
 
-	return s.serveStaticFile(c, attachment, contentType, wantThumbnail)
+	return s.serveStaticFile(c, attachment, contentType, variant)
 }
 
 // serveUserAvatar serves user avatar images.
@@ -215,6 +236,40 @@ func (s *FileServerService) serveUserAvatar(c *echo.Context) error {
 	return c.Blob(http.StatusOK, imageType, imageData)
 }
 
+// serveDiagram serves a diagram rendered to SVG from a memo code block. The
+// file name is the hash of the diagram source, which is only known to those
+// who can read the memo, so no further access check is made.
+func (s *FileServerService) serveDiagram(c *echo.Context) error {
+	if s.DiagramService == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "diagram not found")
+	}
+	key, ok := strings.CutSuffix(c.Param("filename"), ".svg")
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "diagram not found")
+	}
+	file, err := s.DiagramService.Open(key)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return echo.NewHTTPError(http.StatusNotFound, "diagram not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to open diagram").Wrap(err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to read diagram").Wrap(err)
+	}
+
+	// The security headers keep scripts in the SVG from running when the
+	// diagram is opened directly.
+	setSecurityHeaders(c)
+	h := c.Response().Header()
+	h.Set(echo.HeaderContentType, "image/svg+xml")
+	h.Set(echo.HeaderCacheControl, diagramCacheControl)
+	http.ServeContent(c.Response(), c.Request(), info.Name(), info.ModTime(), file)
+	return nil
+}
+
 // =============================================================================
 // File Serving Methods
 // =============================================================================
@@ -225,12 +280,8 @@ func (s *FileServerService) serveMediaStream(c *echo.Context, attachment *store.
 	setMediaHeaders(c, contentType, attachment.Type)
 
 	switch attachment.StorageType {
-	case storepb.AttachmentStorageType_LOCAL:
-		http.ServeFile(c.Response(), c.Request(), s.resolveLocalPath(attachment.Reference))
-		return nil
-
-	case storepb.AttachmentStorageType_S3:
-		return s.streamS3Object(c, attachment, contentType)
+	case storepb.AttachmentStorageType_LOCAL, storepb.AttachmentStorageType_S3, storepb.AttachmentStorageType_OBJECT:
+		return s.serveStorageObject(c, attachment, contentType)
 
 	default:
 		// Database storage fallback.
@@ -241,17 +292,15 @@ func (s *FileServerService) serveMediaStream(c *echo.Context, attachment *store.
 }
 
 // serveStaticFile serves non-streaming files (images, documents, etc.).
-func (s *FileServerService) serveStaticFile(c *echo.Context, attachment *store.Attachment, contentType string, wantThumbnail bool) error {
-	// Generate thumbnail for supported image types.
-	if wantThumbnail && thumbnailSupportedTypes[attachment.Type] {
-		if thumbnailBlob, err := s.getOrGenerateThumbnail(c.Request().Context(), attachment); err != nil {
-			if !errors.Is(err, errUseOriginalForThumbnail) {
-				slog.Warn("failed to get thumbnail", "error", err)
-			}
-		} else {
-			setSecurityHeaders(c)
-			setMediaHeaders(c, "image/jpeg", attachment.Type)
-			return c.Blob(http.StatusOK, "image/jpeg", thumbnailBlob)
+func (s *FileServerService) serveStaticFile(c *echo.Context, attachment *store.Attachment, contentType string, variant *imageVariant) error {
+	// Serve a resized or converted variant for supported image types.
+	if variant != nil && variantSupportedTypes[attachment.Type] {
+		err := s.serveImageVariant(c, attachment, variant)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, errUseOriginalImage) {
+			slog.Warn("failed to get image variant", "error", err)
 		}
 	}
 
@@ -264,11 +313,8 @@ func (s *FileServerService) serveStaticFile(c *echo.Context, attachment *store.A
 	}
 
 	switch attachment.StorageType {
-	case storepb.AttachmentStorageType_LOCAL:
-		http.ServeFile(c.Response(), c.Request(), s.resolveLocalPath(attachment.Reference))
-		return nil
-	case storepb.AttachmentStorageType_S3:
-		return s.streamS3Object(c, attachment, contentType)
+	case storepb.AttachmentStorageType_LOCAL, storepb.AttachmentStorageType_S3, storepb.AttachmentStorageType_OBJECT:
+		return s.serveStorageObject(c, attachment, contentType)
 	default:
 		return c.Blob(http.StatusOK, contentType, attachment.Blob)
 	}
@@ -296,24 +342,17 @@ func (s *FileServerService) getAttachmentBlob(ctx context.Context, attachment *s
 // getAttachmentReader returns a reader for streaming attachment content.
 func (s *FileServerService) getAttachmentReader(ctx context.Context, attachment *store.Attachment) (io.ReadCloser, error) {
 	switch attachment.StorageType {
-	case storepb.AttachmentStorageType_LOCAL:
-		file, err := os.Open(s.resolveLocalPath(attachment.Reference))
-		if err != nil {
-			if os.IsNotExist(err) {
-				return nil, errors.Wrap(err, "file not found")
-			}
-			return nil, errors.Wrap(err, "failed to open file")
-		}
-		return file, nil
-
-	case storepb.AttachmentStorageType_S3:
-		driver, s3Object, err := s.Store.ResolveAttachmentS3Driver(ctx, attachment)
+	case storepb.AttachmentStorageType_LOCAL, storepb.AttachmentStorageType_S3, storepb.AttachmentStorageType_OBJECT:
+		driver, key, err := s.Store.ResolveAttachmentDriver(ctx, attachment)
 		if err != nil {
 			return nil, err
 		}
-		object, err := driver.GetObjectStream(ctx, s3Object.Key, "")
+		object, err := driver.GetObjectStream(ctx, key, "")
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to stream from S3")
+			if errors.Is(err, storage.ErrObjectNotFound) {
+				return nil, errors.Wrap(err, "file not found")
+			}
+			return nil, errors.Wrap(err, "failed to stream from storage")
 		}
 		return object.Body, nil
 
@@ -322,27 +361,42 @@ func (s *FileServerService) getAttachmentReader(ctx context.Context, attachment
 	}
 }
 
-// resolveLocalPath converts a storage reference to an absolute file path.
-func (s *FileServerService) resolveLocalPath(reference string) string {
-	filePath := filepath.FromSlash(reference)
-	if !filepath.IsAbs(filePath) {
-		filePath = filepath.Join(s.Profile.Data, filePath)
-	}
-	return filePath
-}
-
-// streamS3Object streams S3 content through the server, forwarding a supported
+// serveStorageObject serves attachment content held by a storage driver. Files
+// on the local file system are served directly with full conditional request
+// support. Other storages stream through the server, forwarding a supported
 // single Range so media players and document viewers can seek without a direct
-// S3 URL. Multipart ranges are ignored and served as a complete response
-// because S3 does not support multipart range responses.
-func (s *FileServerService) streamS3Object(c *echo.Context, attachment *store.Attachment, contentType string) error {
+// storage URL. Multipart ranges are ignored and served as a complete response
+// because S3 does not support multipart range responses. Storages with
+// presigned transfers enabled are redirected to a short-lived presigned URL
+// instead, which carries the response headers the server would have sent.
+func (s *FileServerService) serveStorageObject(c *echo.Context, attachment *store.Attachment, contentType string) error {
 	ctx := c.Request().Context()
-	driver, s3Object, err := s.Store.ResolveAttachmentS3Driver(ctx, attachment)
+	driver, key, err := s.Store.ResolveAttachmentDriver(ctx, attachment)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to resolve S3 attachment driver").Wrap(err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to resolve attachment driver").Wrap(err)
+	}
+
+	if fileDriver, ok := driver.(storage.FileDriver); ok {
+		filePath, err := fileDriver.FilePath(key)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to resolve attachment file").Wrap(err)
+		}
+		http.ServeFile(c.Response(), c.Request(), filePath)
+		return nil
+	}
+
+	if presignDriver, ok := driver.(storage.PresignDriver); ok && presignDriver.PresignEnabled() {
+		presignedURL, err := presignDriver.PresignGetObject(ctx, key, presignedDownloadTTL, storage.PresignGetOptions{
+			ContentType:        contentType,
+			ContentDisposition: c.Response().Header().Get(echo.HeaderContentDisposition),
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to presign S3 download").Wrap(err)
+		}
+		return c.Redirect(http.StatusFound, presignedURL)
 	}
 
-	object, err := driver.GetObjectStream(ctx, s3Object.Key, singleRangeHeader(c.Request().Header))
+	object, err := driver.GetObjectStream(ctx, key, singleRangeHeader(c.Request().Header))
 	if err != nil {
 		if errors.Is(err, storage.ErrRangeNotSatisfiable) {
 			h := c.Response().Header()
@@ -353,7 +407,10 @@ func (s *FileServerService) streamS3Object(c *echo.Context, attachment *store.At
 			}
 			return echo.NewHTTPError(http.StatusRequestedRangeNotSatisfiable, "requested range not satisfiable")
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to stream from S3").Wrap(err)
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "attachment content not found").Wrap(err)
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to stream from storage").Wrap(err)
 	}
 	defer object.Body.Close()
 
@@ -385,159 +442,6 @@ func singleRangeHeader(header http.Header) string {
 	return values[0]
 }
 
-// =============================================================================
-// Thumbnail Generation
-// =============================================================================
-
-// getOrGenerateThumbnail returns the thumbnail image of the attachment.
-// Uses semaphore to limit concurrent thumbnail generation and prevent memory exhaustion.
-func (s *FileServerService) getOrGenerateThumbnail(ctx context.Context, attachment *store.Attachment) ([]byte, error) {
-	thumbnailPath, err := s.getThumbnailPath(attachment)
-	if err != nil {
-		return nil, err
-	}
-
-	// Fast path: return cached thumbnail if exists.
-	if blob, err := os.ReadFile(thumbnailPath); err == nil {
-		return blob, nil
-	}
-
-	useOriginal, err := s.shouldUseOriginalForThumbnail(ctx, attachment)
-	if err != nil {
-		return nil, err
-	}
-	if useOriginal {
-		return nil, errUseOriginalForThumbnail
-	}
-
-	// Acquire semaphore to limit concurrent generation.
-	if err := s.thumbnailSemaphore.Acquire(ctx, 1); err != nil {
-		return nil, errors.Wrap(err, "failed to acquire semaphore")
-	}
-	defer s.thumbnailSemaphore.Release(1)
-
-	// Double-check after acquiring semaphore (another goroutine may have generated it).
-	if blob, err := os.ReadFile(thumbnailPath); err == nil {
-		return blob, nil
-	}
-
-	return s.generateThumbnail(ctx, attachment, thumbnailPath)
-}
-
-// getThumbnailPath returns the file path for a cached thumbnail.
-func (s *FileServerService) getThumbnailPath(attachment *store.Attachment) (string, error) {
-	cacheFolder := filepath.Join(s.Profile.Data, thumbnailCacheFolder)
-	if err := os.MkdirAll(cacheFolder, os.ModePerm); err != nil {
-		return "", errors.Wrap(err, "failed to create thumbnail cache folder")
-	}
-	filename := fmt.Sprintf("%s.v2.jpeg", attachment.UID)
-	return filepath.Join(cacheFolder, filename), nil
-}
-
-func (s *FileServerService) shouldUseOriginalForThumbnail(ctx context.Context, attachment *store.Attachment) (bool, error) {
-	if attachment.Type == "image/heic" || attachment.Type == "image/heif" {
-		return true, nil
-	}
-
-	if attachment.Type != "image/jpeg" && attachment.Type != "image/jpg" && attachment.Type != "image/png" && attachment.Type != "image/webp" {
-		return false, nil
-	}
-
-	reader, err := s.getAttachmentReader(ctx, attachment)
-	if err != nil {
-		return false, errors.Wrap(err, "failed to open image for metadata probe")
-	}
-	defer reader.Close()
-
-	probe, err := io.ReadAll(io.LimitReader(reader, thumbnailMetadataProbeSize))
-	if err != nil {
-		return false, errors.Wrap(err, "failed to read image metadata probe")
-	}
-
-	return hasThumbnailSensitiveMetadata(probe), nil
-}
-
-func hasThumbnailSensitiveMetadata(data []byte) bool {
-	for _, marker := range [][]byte{
-		[]byte("ICC_PROFILE"),
-		[]byte("iCCP"),
-		[]byte("ICCP"),
-		[]byte("cICP"),
-		[]byte("mDCv"),
-		[]byte("cLLi"),
-	} {
-		if bytes.Contains(data, marker) {
-			return true
-		}
-	}
-
-	lowerData := strings.ToLower(string(data))
-	for _, marker := range []string{
-		"hdrgm:",
-		"hdr gain map",
-		"hdrgainmap",
-		"gainmap",
-		"ultrahdr",
-		"adobe:hdrgainmap",
-		"aux:hdr",
-		"auxiliaryimagetype",
-		"display p3",
-		"display-p3",
-		"rec.2020",
-		"bt.2020",
-		"arib-std-b67",
-		"smpte st 2084",
-	} {
-		if strings.Contains(lowerData, marker) {
-			return true
-		}
-	}
-
-	return false
-}
-
-// generateThumbnail creates a new thumbnail and saves it to disk.
-func (s *FileServerService) generateThumbnail(ctx context.Context, attachment *store.Attachment, thumbnailPath string) ([]byte, error) {
-	reader, err := s.getAttachmentReader(ctx, attachment)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get attachment reader")
-	}
-	defer reader.Close()
-
-	img, err := imaging.Decode(reader, imaging.AutoOrientation(true))
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode image")
-	}
-
-	width, height := img.Bounds().Dx(), img.Bounds().Dy()
-	thumbnailWidth, thumbnailHeight := calculateThumbnailDimensions(width, height)
-
-	thumbnailImage := imaging.Resize(img, thumbnailWidth, thumbnailHeight, imaging.Lanczos)
-
-	var buf bytes.Buffer
-	if err := imaging.Encode(&buf, thumbnailImage, imaging.JPEG, imaging.JPEGQuality(90)); err != nil {
-		return nil, errors.Wrap(err, "failed to encode thumbnail")
-	}
-	if err := os.WriteFile(thumbnailPath, buf.Bytes(), 0644); err != nil {
-		return nil, errors.Wrap(err, "failed to save thumbnail")
-	}
-
-	return buf.Bytes(), nil
-}
-
-// calculateThumbnailDimensions calculates the target dimensions for a thumbnail.
-// The largest dimension is constrained to thumbnailMaxSize while maintaining aspect ratio.
-// Small images are not enlarged.
-func calculateThumbnailDimensions(width, height int) (int, int) {
-	if max(width, height) <= thumbnailMaxSize {
-		return width, height
-	}
-	if width >= height {
-		return thumbnailMaxSize, 0 // Landscape: constrain width.
-	}
-	return 0, thumbnailMaxSize // Portrait: constrain height.
-}
-
 func (s *FileServerService) serveMotionClip(c *echo.Context, attachment *store.Attachment) error {
 	motionMedia := attachment.Payload.GetMotionMedia()
 	if motionMedia == nil || motionMedia.Family != storepb.MotionMediaFamily_ANDROID_MOTION_PHOTO || !motionMedia.HasEmbeddedVideo {
@@ -10,6 +10,7 @@ import (
 	"image"
 	"image/color"
 	"image/png"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"github.com/usememos/memos/internal/profile"
 	"github.com/usememos/memos/internal/testutil"
 	"github.com/usememos/memos/internal/testutil/fakes3"
+	"github.com/usememos/memos/internal/testutil/fakewebdav"
 	testminio "github.com/usememos/memos/internal/testutil/minio"
 	"github.com/usememos/memos/internal/util"
 	apiv1 "github.com/usememos/memos/proto/gen/api/v1"
@@ -100,6 +102,144 @@ func TestServeAttachmentFile_S3(t *testing.T) {
 	require.Empty(t, multiRangeRecorder.Header().Get("Content-Range"))
 }
 
+func TestServeAttachmentFile_WebDAVSupportsRangeRequests(t *testing.T) {
+	ctx := context.Background()
+	server := fakewebdav.New(t)
+	svc, fs, stores, cleanup := newShareAttachmentTestServices(ctx, t)
+	defer cleanup()
+
+	configuredStorage := &storepb.Storage{
+		Id:     "webdav-files",
+		Name:   "File server WebDAV",
+		Type:   storepb.StorageType_STORAGE_TYPE_WEBDAV,
+		Config: &storepb.Storage_WebdavConfig{WebdavConfig: server.Config("files")},
+	}
+	_, err := stores.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_STORAGE,
+		Value: &storepb.InstanceSetting_StorageSetting{StorageSetting: &storepb.InstanceStorageSetting{
+			FilepathTemplate:  "files/{uuid}_{filename}",
+			UploadSizeLimitMb: 30,
+			Storages:          []*storepb.Storage{configuredStorage},
+			DefaultStorageId:  configuredStorage.Id,
+		}},
+	})
+	require.NoError(t, err)
+
+	creator, err := stores.CreateUser(ctx, &store.User{
+		Username: "webdav-file-owner",
+		Role:     store.RoleUser,
+		Email:    "webdav-file-owner@example.com",
+	})
+	require.NoError(t, err)
+	creatorCtx := context.WithValue(ctx, auth.UserIDContextKey, creator.ID)
+	content := []byte("content streamed from WebDAV")
+	attachment, err := svc.CreateAttachment(creatorCtx, &apiv1.CreateAttachmentRequest{Attachment: &apiv1.Attachment{
+		Filename: "document.txt",
+		Type:     "text/plain",
+		Content:  content,
+	}})
+	require.NoError(t, err)
+	_, err = svc.CreateMemo(creatorCtx, &apiv1.CreateMemoRequest{Memo: &apiv1.Memo{
+		Content:     "public WebDAV attachment",
+		Visibility:  apiv1.Visibility_PUBLIC,
+		Attachments: []*apiv1.Attachment{{Name: attachment.Name}},
+	}})
+	require.NoError(t, err)
+
+	e := echo.New()
+	fs.RegisterRoutes(e)
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/file/%s/%s", attachment.Name, attachment.Filename), nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, content, recorder.Body.Bytes())
+
+	rangeRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/file/%s/%s", attachment.Name, attachment.Filename), nil)
+	rangeRequest.Header.Set("Range", "bytes=8-15")
+	rangeRecorder := httptest.NewRecorder()
+	e.ServeHTTP(rangeRecorder, rangeRequest)
+	require.Equal(t, http.StatusPartialContent, rangeRecorder.Code)
+	require.Equal(t, content[8:16], rangeRecorder.Body.Bytes())
+	require.Equal(t, fmt.Sprintf("bytes 8-15/%d", len(content)), rangeRecorder.Header().Get("Content-Range"))
+}
+
+func TestServeAttachmentFile_S3PresignedRedirect(t *testing.T) {
+	ctx := context.Background()
+	fake := fakes3.New(t, "file-server-presigned")
+	svc, fs, stores, cleanup := newShareAttachmentTestServices(ctx, t)
+	defer cleanup()
+
+	s3Config := fake.Config("file-server-presigned")
+	s3Config.PresignedTransfers = true
+	configuredStorage := &storepb.Storage{
+		Id:     "s3-presigned",
+		Name:   "Presigned S3",
+		Type:   storepb.StorageType_STORAGE_TYPE_S3,
+		Config: &storepb.Storage_S3Config{S3Config: s3Config},
+	}
+	_, err := stores.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_STORAGE,
+		Value: &storepb.InstanceSetting_StorageSetting{StorageSetting: &storepb.InstanceStorageSetting{
+			FilepathTemplate:  "files/{uuid}_{filename}",
+			UploadSizeLimitMb: 30,
+			Storages:          []*storepb.Storage{configuredStorage},
+			DefaultStorageId:  configuredStorage.Id,
+		}},
+	})
+	require.NoError(t, err)
+
+	creator, err := stores.CreateUser(ctx, &store.User{
+		Username: "presigned-file-owner",
+		Role:     store.RoleUser,
+		Email:    "presigned-file-owner@example.com",
+	})
+	require.NoError(t, err)
+	creatorCtx := context.WithValue(ctx, auth.UserIDContextKey, creator.ID)
+	createMemoAttachment := func(filename string, content []byte, visibility apiv1.Visibility) *apiv1.Attachment {
+		attachment, err := svc.CreateAttachment(creatorCtx, &apiv1.CreateAttachmentRequest{Attachment: &apiv1.Attachment{
+			Filename: filename,
+			Type:     "text/plain",
+			Content:  content,
+		}})
+		require.NoError(t, err)
+		_, err = svc.CreateMemo(creatorCtx, &apiv1.CreateMemoRequest{Memo: &apiv1.Memo{
+			Content:     filename,
+			Visibility:  visibility,
+			Attachments: []*apiv1.Attachment{{Name: attachment.Name}},
+		}})
+		require.NoError(t, err)
+		return attachment
+	}
+
+	content := []byte("content served straight from the bucket")
+	public := createMemoAttachment("public.txt", content, apiv1.Visibility_PUBLIC)
+	private := createMemoAttachment("private.txt", []byte("private content"), apiv1.Visibility_PRIVATE)
+
+	e := echo.New()
+	fs.RegisterRoutes(e)
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/file/%s/%s", public.Name, public.Filename), nil))
+	require.Equal(t, http.StatusFound, recorder.Code)
+	location := recorder.Header().Get(echo.HeaderLocation)
+	require.True(t, strings.HasPrefix(location, fake.URL), location)
+	require.Contains(t, location, "X-Amz-Expires=300")
+	require.Contains(t, location, "response-content-disposition=")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	require.NoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	downloaded, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	require.Equal(t, content, downloaded)
+
+	// Authorization still runs before any URL is handed out.
+	recorder = httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/file/%s/%s", private.Name, private.Filename), nil))
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	require.Empty(t, recorder.Header().Get(echo.HeaderLocation))
+}
+
 func TestServeAttachmentFile_S3MinIO(t *testing.T) {
 	ctx := context.Background()
 	server := testminio.New(t, "file-server-attachments")
@@ -676,6 +816,50 @@ func testPNGWithChunk(t *testing.T, chunkType string, chunkData []byte) []byte {
 	return result
 }
 
+func TestServeDiagram(t *testing.T) {
+	ctx := context.Background()
+	testStore := teststore.NewTestingStore(ctx, t)
+	defer testStore.Close()
+	testProfile := &profile.Profile{
+		InstanceURL: "http://localhost:8080",
+		Driver:      "sqlite",
+		DSN:         ":memory:",
+		Data:        t.TempDir(),
+	}
+	apiService := apiv1service.NewAPIV1Service("test-secret", testProfile, testStore)
+	fs := NewFileServerService(testProfile, testStore, "test-secret")
+	fs.DiagramService = apiService.DiagramService
+	e := echo.New()
+	fs.RegisterRoutes(e)
+
+	html, err := apiService.MarkdownService.RenderHTML(context.Background(), []byte("```dot\ndigraph { start -> done }\n```"))
+	require.NoError(t, err)
+	prefix := `<img class="diagram" src="http://localhost:8080`
+	start := strings.Index(html, prefix)
+	require.GreaterOrEqual(t, start, 0, html)
+	path := html[start+len(prefix):]
+	path = path[:strings.IndexByte(path, '"')]
+	require.True(t, strings.HasPrefix(path, "/file/diagrams/"), path)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "image/svg+xml", rec.Header().Get("Content-Type"))
+	require.Equal(t, diagramCacheControl, rec.Header().Get("Cache-Control"))
+	require.Contains(t, rec.Header().Get("Content-Security-Policy"), "default-src 'none'")
+	require.Contains(t, rec.Body.String(), ">start</text>")
+
+	for _, missing := range []string{
+		"/file/diagrams/" + strings.Repeat("0", 64) + ".svg",
+		"/file/diagrams/" + strings.TrimSuffix(strings.TrimPrefix(path, "/file/diagrams/"), ".svg"),
+		"/file/diagrams/..%2Fsecret.svg",
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, missing, nil))
+		require.Equal(t, http.StatusNotFound, rec.Code, missing)
+	}
+}
+
 func newShareAttachmentTestServices(ctx context.Context, t *testing.T) (*apiv1service.APIV1Service, *FileServerService, *store.Store, func()) {
 	t.Helper()
 
@@ -926,3 +1110,28 @@ func newExpiredRefreshTokenCookie(ctx context.Context, t *testing.T, stores *sto
 	require.NoError(t, err)
 	return &http.Cookie{Name: auth.RefreshTokenCookieName, Value: refreshToken}
 }
+
+func TestServeAttachmentFile_Quarantined(t *testing.T) {
+	ctx := context.Background()
+	svc, fs, stores, cleanup := newShareAttachmentTestServices(ctx, t)
+	defer cleanup()
+
+	attachment := createPublicImageAttachment(ctx, t, svc, "eicar.txt", "text/plain", []byte("quarantined content"))
+	uid, err := apiv1service.ExtractAttachmentUIDFromName(attachment.Name)
+	require.NoError(t, err)
+	internalAttachment, err := stores.GetAttachment(ctx, &store.FindAttachment{UID: &uid})
+	require.NoError(t, err)
+	payload := internalAttachment.Payload
+	if payload == nil {
+		payload = &storepb.AttachmentPayload{}
+	}
+	payload.ContentScan = &storepb.ContentScan{Status: storepb.ContentScan_INFECTED, Signature: "Eicar-Test-Signature"}
+	require.NoError(t, stores.UpdateAttachment(ctx, &store.UpdateAttachment{ID: internalAttachment.ID, Payload: payload}))
+
+	e := echo.New()
+	fs.RegisterRoutes(e)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/file/%s/%s", attachment.Name, attachment.Filename), nil))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.NotContains(t, rec.Body.String(), "quarantined content")
+}
@@ -0,0 +1,404 @@
+package fileserver
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/labstack/echo/v5"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/imageconv"
+	"github.com/usememos/memos/internal/storage"
+	"github.com/usememos/memos/store"
+)
+
+// imageVariant is a named size attachment images are served at.
+type imageVariant struct {
+	name string
+	// maxSize bounds the width and height; zero keeps the original dimensions.
+	maxSize int
+}
+
+// imageVariants lists the variants from the smallest to the largest.
+var imageVariants = []imageVariant{
+	{name: "small", maxSize: 320},
+	{name: "medium", maxSize: 600},
+	{name: "large", maxSize: 1280},
+	{name: "full"},
+}
+
+const (
+	// thumbnailVariant is served for the legacy thumbnail=true parameter.
+	thumbnailVariant = "medium"
+
+	// variantAccessInterval is how stale the recorded access time of a cached
+	// variant may get before serving it records a new one.
+	variantAccessInterval = time.Hour
+)
+
+var errUseOriginalImage = errors.New("serve original image instead of a re-encoded variant")
+
+// parseImageVariant returns the variant requested with the variant, width or
+// thumbnail query parameters, or nil when the original is requested.
+func parseImageVariant(c *echo.Context) (*imageVariant, error) {
+	if name := c.QueryParam("variant"); name != "" {
+		variant := findImageVariant(name)
+		if variant == nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "unknown image variant")
+		}
+		return variant, nil
+	}
+	if value := c.QueryParam("width"); value != "" {
+		width, err := strconv.Atoi(value)
+		if err != nil || width <= 0 {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid image width")
+		}
+		// Widths are rounded up to a named variant so the cache stays small.
+		for _, variant := range imageVariants {
+			if variant.maxSize == 0 || variant.maxSize >= width {
+				return &variant, nil
+			}
+		}
+	}
+	if c.QueryParam("thumbnail") == "true" {
+		return findImageVariant(thumbnailVariant), nil
+	}
+	return nil, nil
+}
+
+// findImageVariant returns the variant with the name, or nil if there is none.
+func findImageVariant(name string) *imageVariant {
+	for _, variant := range imageVariants {
+		if variant.name == name {
+			return &variant
+		}
+	}
+	return nil
+}
+
+// negotiateImageFormat picks the most compact format that the client accepts
+// and the server can encode, falling back to JPEG.
+func (s *FileServerService) negotiateImageFormat(accept string) imageconv.Format {
+	for _, format := range []imageconv.Format{imageconv.AVIF, imageconv.WebP} {
+		if acceptsMediaType(accept, format.MIMEType()) && s.ImageConverter.CanEncode(format) {
+			return format
+		}
+	}
+	return imageconv.JPEG
+}
+
+// acceptsMediaType reports whether an Accept header explicitly lists a media
+// type with a non-zero quality. Wildcards are ignored because browsers send
+// "image/*" without supporting every image format.
+func acceptsMediaType(accept, mediaType string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		accepted, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(accepted), mediaType) {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(key, "q") {
+				if quality, err := strconv.ParseFloat(value, 64); err == nil && quality <= 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// serveImageVariant serves an image attachment resized to a variant in the
+// negotiated format. It returns errUseOriginalImage, or any other error, when
+// the caller should serve the original instead.
+func (s *FileServerService) serveImageVariant(c *echo.Context, attachment *store.Attachment, variant *imageVariant) error {
+	format := s.negotiateImageFormat(c.Request().Header.Get(echo.HeaderAccept))
+	blob, err := s.getOrGenerateImageVariant(c.Request().Context(), attachment, variant, format)
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	setSecurityHeaders(c)
+	setMediaHeaders(c, format.MIMEType(), attachment.Type)
+	return c.Blob(http.StatusOK, format.MIMEType(), blob)
+}
+
+// servePoster serves the poster frame generated for a video attachment.
+func (s *FileServerService) servePoster(c *echo.Context, attachment *store.Attachment) error {
+	name := store.AttachmentPosterVariant
+	poster, err := s.Store.GetAttachmentVariant(c.Request().Context(), &store.FindAttachmentVariant{AttachmentID: &attachment.ID, Name: &name})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to find poster").Wrap(err)
+	}
+	if poster == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "poster not found")
+	}
+	driver, err := s.Store.ResolveAttachmentVariantDriver(c.Request().Context(), attachment)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to resolve poster storage").Wrap(err)
+	}
+	blob, err := driver.GetObject(c.Request().Context(), poster.Reference)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "poster not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to read poster").Wrap(err)
+	}
+	setSecurityHeaders(c)
+	setMediaHeaders(c, poster.Type, poster.Type)
+	return c.Blob(http.StatusOK, poster.Type, blob)
+}
+
+// getOrGenerateImageVariant returns a variant of the attachment image from the
+// cache, generating and caching it when missing.
+// Uses semaphore to limit concurrent generation and prevent memory exhaustion.
+func (s *FileServerService) getOrGenerateImageVariant(ctx context.Context, attachment *store.Attachment, variant *imageVariant, format imageconv.Format) ([]byte, error) {
+	name := variant.name + "." + string(format)
+	if blob, ok := s.getCachedImageVariant(ctx, attachment, name); ok {
+		return blob, nil
+	}
+
+	useOriginal, err := s.shouldUseOriginalImage(ctx, attachment)
+	if err != nil {
+		return nil, err
+	}
+	if useOriginal {
+		return nil, errUseOriginalImage
+	}
+
+	if err := s.variantSemaphore.Acquire(ctx, 1); err != nil {
+		return nil, errors.Wrap(err, "failed to acquire semaphore")
+	}
+	defer s.variantSemaphore.Release(1)
+
+	// Double-check after acquiring semaphore (another goroutine may have generated it).
+	if blob, ok := s.getCachedImageVariant(ctx, attachment, name); ok {
+		return blob, nil
+	}
+
+	blob, err := s.generateImageVariant(ctx, attachment, variant, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cacheImageVariant(ctx, attachment, name, format, blob); err != nil {
+		slog.Warn("failed to cache image variant", "attachment", attachment.UID, "variant", name, "error", err)
+	}
+	return blob, nil
+}
+
+// getCachedImageVariant reads a cached variant from the storage of the
+// attachment and records that it was served.
+func (s *FileServerService) getCachedImageVariant(ctx context.Context, attachment *store.Attachment, name string) ([]byte, bool) {
+	variant, err := s.Store.GetAttachmentVariant(ctx, &store.FindAttachmentVariant{AttachmentID: &attachment.ID, Name: &name})
+	if err != nil {
+		slog.Warn("failed to find image variant", "attachment", attachment.UID, "variant", name, "error", err)
+		return nil, false
+	}
+	if variant == nil {
+		return nil, false
+	}
+	driver, err := s.Store.ResolveAttachmentVariantDriver(ctx, attachment)
+	if err != nil {
+		slog.Warn("failed to resolve image variant storage", "attachment", attachment.UID, "error", err)
+		return nil, false
+	}
+	blob, err := driver.GetObject(ctx, variant.Reference)
+	if err != nil {
+		if !errors.Is(err, storage.ErrObjectNotFound) {
+			slog.Warn("failed to read image variant", "attachment", attachment.UID, "variant", name, "error", err)
+			return nil, false
+		}
+		// The object is gone; forget it so it is generated again.
+		if err := s.Store.DeleteAttachmentVariant(ctx, &store.DeleteAttachmentVariant{ID: variant.ID}); err != nil {
+			slog.Warn("failed to delete missing image variant", "attachment", attachment.UID, "variant", name, "error", err)
+		}
+		return nil, false
+	}
+
+	if now := time.Now(); now.Sub(time.Unix(variant.AccessedTs, 0)) > variantAccessInterval {
+		accessedTs := now.Unix()
+		if err := s.Store.UpdateAttachmentVariant(ctx, &store.UpdateAttachmentVariant{ID: variant.ID, AccessedTs: &accessedTs}); err != nil {
+			slog.Warn("failed to record image variant access", "attachment", attachment.UID, "variant", name, "error", err)
+		}
+	}
+	return blob, true
+}
+
+// cacheImageVariant writes a generated variant next to the attachment in its
+// storage and records it for eviction.
+func (s *FileServerService) cacheImageVariant(ctx context.Context, attachment *store.Attachment, name string, format imageconv.Format, blob []byte) error {
+	driver, err := s.Store.ResolveAttachmentVariantDriver(ctx, attachment)
+	if err != nil {
+		return err
+	}
+	reference, err := driver.UploadObject(ctx, store.AttachmentVariantKey(attachment, name), format.MIMEType(), bytes.NewReader(blob))
+	if err != nil {
+		return errors.Wrap(err, "failed to upload image variant")
+	}
+	if _, err := s.Store.UpsertAttachmentVariant(ctx, &store.AttachmentVariant{
+		AttachmentID: attachment.ID,
+		Name:         name,
+		Type:         format.MIMEType(),
+		Size:         int64(len(blob)),
+		Reference:    reference,
+	}); err != nil {
+		return errors.Wrap(err, "failed to record image variant")
+	}
+	return nil
+}
+
+func isHEIFType(mimeType string) bool {
+	return mimeType == "image/heic" || mimeType == "image/heif"
+}
+
+// shouldUseOriginalImage reports whether an image must be served as is:
+// HEIC photos and PDFs when no decoder is installed, and images with metadata
+// that re-encoding would strip.
+func (s *FileServerService) shouldUseOriginalImage(ctx context.Context, attachment *store.Attachment) (bool, error) {
+	if isHEIFType(attachment.Type) {
+		return !s.ImageConverter.CanDecodeHEIF(), nil
+	}
+	if attachment.Type == "application/pdf" {
+		return !s.ImageConverter.CanDecodePDF(), nil
+	}
+
+	if attachment.Type != "image/jpeg" && attachment.Type != "image/jpg" && attachment.Type != "image/png" && attachment.Type != "image/webp" {
+		return false, nil
+	}
+
+	reader, err := s.getAttachmentReader(ctx, attachment)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to open image for metadata probe")
+	}
+	defer reader.Close()
+
+	probe, err := io.ReadAll(io.LimitReader(reader, variantMetadataProbeSize))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read image metadata probe")
+	}
+
+	return hasThumbnailSensitiveMetadata(probe), nil
+}
+
+func hasThumbnailSensitiveMetadata(data []byte) bool {
+	for _, marker := range [][]byte{
+		[]byte("ICC_PROFILE"),
+		[]byte("iCCP"),
+		[]byte("ICCP"),
+		[]byte("cICP"),
+		[]byte("mDCv"),
+		[]byte("cLLi"),
+	} {
+		if bytes.Contains(data, marker) {
+			return true
+		}
+	}
+
+	lowerData := strings.ToLower(string(data))
+	for _, marker := range []string{
+		"hdrgm:",
+		"hdr gain map",
+		"hdrgainmap",
+		"gainmap",
+		"ultrahdr",
+		"adobe:hdrgainmap",
+		"aux:hdr",
+		"auxiliaryimagetype",
+		"display p3",
+		"display-p3",
+		"rec.2020",
+		"bt.2020",
+		"arib-std-b67",
+		"smpte st 2084",
+	} {
+		if strings.Contains(lowerData, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generateImageVariant decodes the attachment image, resizes it to the
+// variant and encodes it in the format.
+func (s *FileServerService) generateImageVariant(ctx context.Context, attachment *store.Attachment, variant *imageVariant, format imageconv.Format) ([]byte, error) {
+	img, err := s.decodeAttachmentImage(ctx, attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	if variant.maxSize > 0 {
+		width, height := img.Bounds().Dx(), img.Bounds().Dy()
+		if max(width, height) > variant.maxSize {
+			variantWidth, variantHeight := calculateVariantDimensions(width, height, variant.maxSize)
+			img = imaging.Resize(img, variantWidth, variantHeight, imaging.Lanczos)
+		}
+	}
+
+	blob, err := s.ImageConverter.Encode(ctx, img, format)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode image variant")
+	}
+	return blob, nil
+}
+
+// decodeAttachmentImage decodes an attachment image upright, or the first
+// page of a PDF.
+func (s *FileServerService) decodeAttachmentImage(ctx context.Context, attachment *store.Attachment) (image.Image, error) {
+	if isHEIFType(attachment.Type) {
+		blob, err := s.getAttachmentBlob(ctx, attachment)
+		if err != nil {
+			return nil, err
+		}
+		img, err := s.ImageConverter.DecodeHEIF(ctx, blob)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode HEIF image")
+		}
+		return img, nil
+	}
+	if attachment.Type == "application/pdf" {
+		blob, err := s.getAttachmentBlob(ctx, attachment)
+		if err != nil {
+			return nil, err
+		}
+		img, err := s.ImageConverter.DecodePDF(ctx, blob)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to render PDF page")
+		}
+		return img, nil
+	}
+
+	reader, err := s.getAttachmentReader(ctx, attachment)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attachment reader")
+	}
+	defer reader.Close()
+
+	img, err := imaging.Decode(reader, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode image")
+	}
+	return img, nil
+}
+
+// calculateVariantDimensions calculates the target dimensions for a variant.
+// The largest dimension is constrained to maxSize while maintaining aspect ratio.
+// Small images are not enlarged.
+func calculateVariantDimensions(width, height, maxSize int) (int, int) {
+	if max(width, height) <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, 0 // Landscape: constrain width.
+	}
+	return 0, maxSize // Portrait: constrain height.
+}
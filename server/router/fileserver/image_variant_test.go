@@ -0,0 +1,283 @@
+package fileserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/imageconv"
+	"github.com/usememos/memos/internal/storage"
+	apiv1 "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/auth"
+	apiv1service "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestServeAttachmentFile_ImageVariants(t *testing.T) {
+	ctx := context.Background()
+	svc, fs, stores, cleanup := newShareAttachmentTestServices(ctx, t)
+	defer cleanup()
+	fs.ImageConverter = imageconv.New(imageconv.Commands{WebP: "missing-cwebp", AVIF: "missing-avifenc", HEIF: "missing-heif-convert"})
+
+	attachment := createPublicImageAttachment(ctx, t, svc, "photo.png", "image/png", testPNG(t, 2000, 1000))
+	e := echo.New()
+	fs.RegisterRoutes(e)
+
+	serve := func(query, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/file/%s/%s?%s", attachment.Name, attachment.Filename, query), nil)
+		if accept != "" {
+			req.Header.Set(echo.HeaderAccept, accept)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	decodedWidth := func(rec *httptest.ResponseRecorder) int {
+		img, err := jpeg.Decode(bytes.NewReader(rec.Body.Bytes()))
+		require.NoError(t, err)
+		return img.Bounds().Dx()
+	}
+
+	for _, tt := range []struct {
+		query string
+		width int
+	}{
+		{query: "variant=small", width: 320},
+		{query: "thumbnail=true", width: 600},
+		{query: "width=700", width: 1280},
+		{query: "width=5000", width: 2000},
+	} {
+		rec := serve(tt.query, "image/webp,image/*")
+		require.Equal(t, http.StatusOK, rec.Code, tt.query)
+		require.Equal(t, "image/jpeg", rec.Header().Get(echo.HeaderContentType), tt.query)
+		require.Equal(t, echo.HeaderAccept, rec.Header().Get(echo.HeaderVary), tt.query)
+		require.Equal(t, tt.width, decodedWidth(rec), tt.query)
+	}
+	require.Equal(t, http.StatusBadRequest, serve("variant=huge", "").Code)
+	require.Equal(t, http.StatusBadRequest, serve("width=wide", "").Code)
+
+	uid, err := apiv1service.ExtractAttachmentUIDFromName(attachment.Name)
+	require.NoError(t, err)
+	internalAttachment, err := stores.GetAttachment(ctx, &store.FindAttachment{UID: &uid})
+	require.NoError(t, err)
+	variants, err := stores.ListAttachmentVariants(ctx, &store.FindAttachmentVariant{AttachmentID: &internalAttachment.ID})
+	require.NoError(t, err)
+	require.Len(t, variants, 4)
+
+	// Cached variants are served from storage rather than regenerated.
+	name := "small.jpeg"
+	small, err := stores.GetAttachmentVariant(ctx, &store.FindAttachmentVariant{AttachmentID: &internalAttachment.ID, Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, "image/jpeg", small.Type)
+	require.Equal(t, store.AttachmentVariantKey(internalAttachment, name), small.Reference)
+	driver, err := stores.ResolveAttachmentVariantDriver(ctx, internalAttachment)
+	require.NoError(t, err)
+	_, err = driver.UploadObject(ctx, small.Reference, small.Type, strings.NewReader("cached"))
+	require.NoError(t, err)
+	require.Equal(t, "cached", serve("variant=small", "").Body.String())
+
+	// A variant missing from storage is generated again.
+	require.NoError(t, driver.DeleteObject(ctx, small.Reference))
+	require.Equal(t, 320, decodedWidth(serve("variant=small", "")))
+
+	require.NoError(t, stores.DeleteAttachment(ctx, &store.DeleteAttachment{ID: internalAttachment.ID}))
+	variants, err = stores.ListAttachmentVariants(ctx, &store.FindAttachmentVariant{AttachmentID: &internalAttachment.ID})
+	require.NoError(t, err)
+	require.Empty(t, variants)
+	_, err = driver.GetObject(ctx, small.Reference)
+	require.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestServeAttachmentFile_ImageVariantFormats(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake conversion tools require a POSIX shell")
+	}
+	ctx := context.Background()
+	svc, fs, _, cleanup := newShareAttachmentTestServices(ctx, t)
+	defer cleanup()
+
+	tools := t.TempDir()
+	writeTool := func(name, script string) string {
+		path := filepath.Join(tools, name)
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+		return path
+	}
+	decoded := filepath.Join(tools, "decoded.png")
+	require.NoError(t, os.WriteFile(decoded, testPNG(t, 900, 300), 0o600))
+	fs.ImageConverter = imageconv.New(imageconv.Commands{
+		WebP: writeTool("cwebp", `eval out=\${$#}; echo webp > "$out"`),
+		AVIF: "missing-avifenc",
+		HEIF: writeTool("heif-convert", `cp "`+decoded+`" "$2"`),
+	})
+
+	e := echo.New()
+	fs.RegisterRoutes(e)
+	serve := func(attachment *apiv1.Attachment, query, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/file/%s/%s?%s", attachment.Name, attachment.Filename, query), nil)
+		req.Header.Set(echo.HeaderAccept, accept)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	pngAttachment := createPublicImageAttachment(ctx, t, svc, "photo.png", "image/png", testPNG(t, 800, 800))
+	rec := serve(pngAttachment, "variant=medium", "image/avif,image/webp,*/*")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "image/webp", rec.Header().Get(echo.HeaderContentType))
+	require.Equal(t, "webp\n", rec.Body.String())
+	rec = serve(pngAttachment, "variant=medium", "image/webp;q=0,*/*")
+	require.Equal(t, "image/jpeg", rec.Header().Get(echo.HeaderContentType))
+
+	heic := createPublicImageAttachment(ctx, t, svc, "IMG_0001.HEIC", "image/heic", []byte("heic data"))
+	rec = serve(heic, "variant=full", "image/*")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "image/jpeg", rec.Header().Get(echo.HeaderContentType))
+	img, err := jpeg.Decode(bytes.NewReader(rec.Body.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 900, 300), img.Bounds())
+
+	// Without a decoder HEIC photos are served as uploaded.
+	fs.ImageConverter = imageconv.New(imageconv.Commands{WebP: "missing-cwebp", AVIF: "missing-avifenc", HEIF: "missing-heif-convert"})
+	heic = createPublicImageAttachment(ctx, t, svc, "IMG_0002.HEIC", "image/heic", []byte("other heic data"))
+	rec = serve(heic, "variant=small", "image/*")
+	require.Equal(t, "image/heic", rec.Header().Get(echo.HeaderContentType))
+	require.Equal(t, "other heic data", rec.Body.String())
+}
+
+func TestServeAttachmentFile_Poster(t *testing.T) {
+	ctx := context.Background()
+	svc, fs, stores, cleanup := newShareAttachmentTestServices(ctx, t)
+	defer cleanup()
+
+	attachment := createPublicImageAttachment(ctx, t, svc, "clip.mp4", "video/mp4", []byte("fake video"))
+	e := echo.New()
+	fs.RegisterRoutes(e)
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/file/%s/%s?poster=true", attachment.Name, attachment.Filename), nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	require.Equal(t, http.StatusNotFound, serve().Code)
+
+	uid, err := apiv1service.ExtractAttachmentUIDFromName(attachment.Name)
+	require.NoError(t, err)
+	internalAttachment, err := stores.GetAttachment(ctx, &store.FindAttachment{UID: &uid})
+	require.NoError(t, err)
+	driver, err := stores.ResolveAttachmentVariantDriver(ctx, internalAttachment)
+	require.NoError(t, err)
+	reference, err := driver.UploadObject(ctx, store.AttachmentVariantKey(internalAttachment, store.AttachmentPosterVariant), "image/jpeg", strings.NewReader("poster"))
+	require.NoError(t, err)
+	_, err = stores.UpsertAttachmentVariant(ctx, &store.AttachmentVariant{
+		AttachmentID: internalAttachment.ID,
+		Name:         store.AttachmentPosterVariant,
+		Type:         "image/jpeg",
+		Size:         6,
+		Reference:    reference,
+	})
+	require.NoError(t, err)
+
+	rec := serve()
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "image/jpeg", rec.Header().Get(echo.HeaderContentType))
+	require.Equal(t, "poster", rec.Body.String())
+}
+
+func TestServeAttachmentFile_PDFThumbnail(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake conversion tools require a POSIX shell")
+	}
+	ctx := context.Background()
+	svc, fs, _, cleanup := newShareAttachmentTestServices(ctx, t)
+	defer cleanup()
+
+	tools := t.TempDir()
+	rendered := filepath.Join(tools, "rendered.png")
+	require.NoError(t, os.WriteFile(rendered, testPNG(t, 1000, 1280), 0o600))
+	renderer := filepath.Join(tools, "pdftoppm")
+	require.NoError(t, os.WriteFile(renderer, []byte("#!/bin/sh\neval out=\\${$#}\ncp \""+rendered+"\" \"$out.png\"\n"), 0o755))
+
+	e := echo.New()
+	fs.RegisterRoutes(e)
+	serve := func(attachment *apiv1.Attachment, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/file/%s/%s?%s", attachment.Name, attachment.Filename, query), nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Without a renderer the PDF itself is served.
+	fs.ImageConverter = imageconv.New(imageconv.Commands{WebP: "missing-cwebp", AVIF: "missing-avifenc", PDF: "missing-pdftoppm"})
+	attachment := createPublicImageAttachment(ctx, t, svc, "report.pdf", "application/pdf", []byte("%PDF-1.7 report"))
+	rec := serve(attachment, "thumbnail=true")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+
+	fs.ImageConverter = imageconv.New(imageconv.Commands{WebP: "missing-cwebp", AVIF: "missing-avifenc", PDF: renderer})
+	rec = serve(attachment, "thumbnail=true")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "image/jpeg", rec.Header().Get(echo.HeaderContentType))
+	img, err := jpeg.Decode(bytes.NewReader(rec.Body.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 469, 600), img.Bounds())
+}
+
+func TestAcceptsMediaType(t *testing.T) {
+	require.True(t, acceptsMediaType("image/avif,image/webp,*/*;q=0.8", "image/webp"))
+	require.True(t, acceptsMediaType("Image/WebP;q=0.5", "image/webp"))
+	require.False(t, acceptsMediaType("image/*,*/*", "image/webp"))
+	require.False(t, acceptsMediaType("image/webp;q=0", "image/webp"))
+	require.False(t, acceptsMediaType("", "image/webp"))
+}
+
+// createPublicImageAttachment uploads an image attached to a public memo.
+func createPublicImageAttachment(ctx context.Context, t *testing.T, svc *apiv1service.APIV1Service, filename, mimeType string, content []byte) *apiv1.Attachment {
+	t.Helper()
+	username := "variant-owner"
+	creator, err := svc.Store.GetUser(ctx, &store.FindUser{Username: &username})
+	require.NoError(t, err)
+	if creator == nil {
+		creator, err = svc.Store.CreateUser(ctx, &store.User{Username: username, Role: store.RoleUser, Email: "variant-owner@example.com"})
+		require.NoError(t, err)
+	}
+	creatorCtx := context.WithValue(ctx, auth.UserIDContextKey, creator.ID)
+
+	attachment, err := svc.CreateAttachment(creatorCtx, &apiv1.CreateAttachmentRequest{
+		Attachment: &apiv1.Attachment{Filename: filename, Type: mimeType, Content: content},
+	})
+	require.NoError(t, err)
+	_, err = svc.CreateMemo(creatorCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{
+			Content:     "variant memo",
+			Visibility:  apiv1.Visibility_PUBLIC,
+			Attachments: []*apiv1.Attachment{{Name: attachment.Name}},
+		},
+	})
+	require.NoError(t, err)
+	return attachment
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := range width {
+		img.Set(x, x%height, color.NRGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
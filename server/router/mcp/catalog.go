@@ -24,11 +24,15 @@ var curatedOperationIDs = []string{
 	"MemoService_DeleteMemoReaction",
 	"MemoService_ListMemoRelations",
 	"MemoService_SetMemoRelations",
+	"MemoService_GetDailyMemo",
+	"MemoService_AppendDailyMemo",
+	"MemoService_ListDailyMemos",
 	"AttachmentService_ListAttachments",
 	"AttachmentService_CreateAttachment",
 	"AttachmentService_GetAttachment",
 	"AttachmentService_DeleteAttachment",
 	"MemoViewService_ListMemoViews",
+	"MemoTemplateService_ListMemoTemplates",
 	// The only allowed auth/identity operation: a read-only "whoami" so agents
 	// can resolve the current user (e.g. for MemoViewService_ListMemoViews).
 	"AuthService_GetCurrentUser",
@@ -292,8 +296,10 @@ func extractSchemaDefs(schema jsonSchema) map[string]any {
 // heuristic gets wrong. The "Set*" operations declaratively replace the full
 // set on a memo, so repeating an identical call converges to the same state —
 // idempotent — even though they are served over PATCH (which the heuristic
-// treats as non-idempotent).
+// treats as non-idempotent). GetDailyMemo is served over POST because it may
+// create the day's memo, but repeating it returns that same memo.
 var idempotentOperationIDs = map[string]bool{
+	"MemoService_GetDailyMemo":       true,
 	"MemoService_SetMemoAttachments": true,
 	"MemoService_SetMemoRelations":   true,
 }
@@ -9,7 +9,7 @@ import (
 )
 
 func TestCuratedOperationIDsStayMemoFocused(t *testing.T) {
-	require.Len(t, curatedOperationIDs, 20)
+	require.Len(t, curatedOperationIDs, 24)
 
 	for _, operationID := range curatedOperationIDs {
 		require.NotContains(t, operationID, "Admin")
@@ -294,6 +294,44 @@ func TestBuildToolFromOperationExposesListMemoViews(t *testing.T) {
 	require.Contains(t, properties, "user")
 }
 
+func TestBuildToolFromOperationExposesListMemoTemplates(t *testing.T) {
+	spec, err := loadOpenAPISpec("../../../proto/gen/openapi.yaml")
+	require.NoError(t, err)
+	registry, err := buildOperationRegistry(spec)
+	require.NoError(t, err)
+
+	tool, operation := buildToolFromOperation(registry["MemoTemplateService_ListMemoTemplates"])
+	require.Equal(t, "memo_template_list_memo_templates", tool.Name)
+	require.Equal(t, "GET", operation.Method)
+	require.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestBuildToolFromOperationExposesDailyMemos(t *testing.T) {
+	spec, err := loadOpenAPISpec("../../../proto/gen/openapi.yaml")
+	require.NoError(t, err)
+	registry, err := buildOperationRegistry(spec)
+	require.NoError(t, err)
+
+	tool, operation := buildToolFromOperation(registry["MemoService_GetDailyMemo"])
+	require.Equal(t, "memo_get_daily_memo", tool.Name)
+	require.Equal(t, "POST", operation.Method)
+	// Getting the daily memo may create it, but repeating the call returns the
+	// same memo.
+	require.False(t, tool.Annotations.ReadOnlyHint)
+	require.True(t, tool.Annotations.IdempotentHint)
+
+	tool, operation = buildToolFromOperation(registry["MemoService_AppendDailyMemo"])
+	require.Equal(t, "memo_append_daily_memo", tool.Name)
+	require.Equal(t, "POST", operation.Method)
+	require.False(t, tool.Annotations.ReadOnlyHint)
+	require.False(t, tool.Annotations.IdempotentHint)
+
+	tool, operation = buildToolFromOperation(registry["MemoService_ListDailyMemos"])
+	require.Equal(t, "memo_list_daily_memos", tool.Name)
+	require.Equal(t, "GET", operation.Method)
+	require.True(t, tool.Annotations.ReadOnlyHint)
+}
+
 func TestBuildToolFromOperationMarksSetOperationsIdempotent(t *testing.T) {
 	spec, err := loadOpenAPISpec("../../../proto/gen/openapi.yaml")
 	require.NoError(t, err)
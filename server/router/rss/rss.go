@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"html"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/usememos/memos/internal/markdown"
 	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/memolink"
 	"github.com/usememos/memos/store"
 )
 
@@ -257,7 +259,7 @@ func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*st
 		title := s.generateItemTitle(memo.Content)
 
 		// Render content as HTML
-		htmlContent, err := s.getRSSItemDescription(memo.Content)
+		htmlContent, err := s.getRSSItemDescription(ctx, memo)
 		if err != nil {
 			return "", lastModified, err
 		}
@@ -289,15 +291,14 @@ func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*st
 		// Note: gorilla/feeds doesn't support categories in RSS items
 		// Tags could be added to the description or content if needed
 
+		// Inline image attachments in the full content
+		item.Content += renderRSSImageAttachments(attachmentsByMemoID[memo.ID], baseURL)
+
 		// Add first attachment as enclosure
 		if attachments, ok := attachmentsByMemoID[memo.ID]; ok && len(attachments) > 0 {
 			attachment := attachments[0]
 			enclosure := feeds.Enclosure{}
-			if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
-				enclosure.Url = attachment.Reference
-			} else {
-				enclosure.Url = fmt.Sprintf("%s/file/attachments/%s", baseURL, attachment.UID)
-			}
+			enclosure.Url = getAttachmentURL(attachment, baseURL)
 			enclosure.Length = strconv.Itoa(int(attachment.Size))
 			enclosure.Type = attachment.Type
 			item.Enclosure = &enclosure
@@ -313,6 +314,34 @@ func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*st
 	return rss, lastModified, nil
 }
 
+// getAttachmentURL returns the public URL of an attachment.
+func getAttachmentURL(attachment *store.Attachment, baseURL string) string {
+	if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+		return attachment.Reference
+	}
+	return fmt.Sprintf("%s/file/attachments/%s", baseURL, attachment.UID)
+}
+
+// renderRSSImageAttachments renders the image attachments of a memo as <img>
+// tags so feed readers show them inline. The image analysis caption, when
+// present, becomes the alt text.
+func renderRSSImageAttachments(attachments []*store.Attachment, baseURL string) string {
+	var builder strings.Builder
+	for _, attachment := range attachments {
+		if !strings.HasPrefix(attachment.Type, "image/") {
+			continue
+		}
+		alt := attachment.Payload.GetImageAnalysis().GetCaption()
+		if alt == "" {
+			alt = attachment.Filename
+		}
+		fmt.Fprintf(&builder, `<p><img src="%s" alt="%s"></p>`,
+			html.EscapeString(getAttachmentURL(attachment, baseURL)),
+			html.EscapeString(alt))
+	}
+	return builder.String()
+}
+
 func (*RSSService) generateItemTitle(content string) string {
 	// Extract first line as title
 	lines := strings.Split(content, "\n")
@@ -349,8 +378,10 @@ func (*RSSService) generateItemTitle(content string) string {
 	return title
 }
 
-func (s *RSSService) getRSSItemDescription(content string) (string, error) {
-	html, err := s.MarkdownService.RenderHTML([]byte(content))
+// getRSSItemDescription renders memo to HTML with the public memos it embeds
+// expanded, since feed readers are anonymous.
+func (s *RSSService) getRSSItemDescription(ctx context.Context, memo *store.Memo) (string, error) {
+	html, err := memolink.NewResolver(s.Store, s.MarkdownService).RenderHTML(ctx, memo, nil)
 	if err != nil {
 		return "", err
 	}
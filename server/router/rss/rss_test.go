@@ -101,6 +101,85 @@ func TestPrivateInstanceDisablesRSS(t *testing.T) {
 	}
 }
 
+func TestRSSImageAttachmentsUseCaptionAsAltText(t *testing.T) {
+	ctx := context.Background()
+	stores := teststore.NewTestingStore(ctx, t)
+	defer stores.Close()
+	setInstanceAccessMode(ctx, t, stores, storepb.InstanceAccessMode_INSTANCE_ACCESS_MODE_PUBLIC)
+
+	user, err := stores.CreateUser(ctx, &store.User{
+		Username: "rss-image-owner",
+		Role:     store.RoleUser,
+		Email:    "rss-image-owner@example.com",
+	})
+	require.NoError(t, err)
+
+	memo, err := stores.CreateMemo(ctx, &store.Memo{
+		UID:        "rss-image-memo",
+		CreatorID:  user.ID,
+		Content:    "memo with images",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	for _, attachment := range []*store.Attachment{
+		{
+			UID:       "rss-captioned",
+			Filename:  "whiteboard.png",
+			Type:      "image/png",
+			Payload:   &storepb.AttachmentPayload{ImageAnalysis: &storepb.ImageAnalysis{Caption: `A whiteboard with "Q3 goals"`}},
+			CreatorID: user.ID,
+			MemoID:    &memo.ID,
+		},
+		{
+			UID:       "rss-uncaptioned",
+			Filename:  "photo.jpg",
+			Type:      "image/jpeg",
+			CreatorID: user.ID,
+			MemoID:    &memo.ID,
+		},
+	} {
+		_, err := stores.CreateAttachment(ctx, attachment)
+		require.NoError(t, err)
+	}
+
+	service := NewRSSService(stores, markdown.NewService())
+	rss := renderRSS(t, service, "/u/rss-image-owner/rss.xml", user.Username)
+	require.Contains(t, rss, `<img src="http://example.com/file/attachments/rss-captioned" alt="A whiteboard with &#34;Q3 goals&#34;">`)
+	require.Contains(t, rss, `<img src="http://example.com/file/attachments/rss-uncaptioned" alt="photo.jpg">`)
+}
+
+func TestRSSExpandsPublicEmbeds(t *testing.T) {
+	ctx := context.Background()
+	stores := teststore.NewTestingStore(ctx, t)
+	defer stores.Close()
+	setInstanceAccessMode(ctx, t, stores, storepb.InstanceAccessMode_INSTANCE_ACCESS_MODE_PUBLIC)
+
+	user, err := stores.CreateUser(ctx, &store.User{
+		Username: "rss-embed-owner",
+		Role:     store.RoleUser,
+		Email:    "rss-embed-owner@example.com",
+	})
+	require.NoError(t, err)
+
+	for _, memo := range []*store.Memo{
+		{UID: "rss-checklist", Content: "shared checklist body", Visibility: store.Public},
+		{UID: "rss-protected", Content: "protected snippet body", Visibility: store.Protected},
+		{UID: "rss-embedding", Content: "Before ![[rss-checklist]] ![[rss-protected]]", Visibility: store.Public},
+	} {
+		memo.CreatorID = user.ID
+		_, err := stores.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+	}
+
+	service := NewRSSService(stores, markdown.NewService(markdown.WithWikiLinkExtension()))
+	exploreRSS := renderRSS(t, service, "/explore/rss.xml", "")
+	require.Contains(t, exploreRSS, `memo-embed&#34; data-target=&#34;rss-checklist&#34;&gt;&lt;p&gt;shared checklist body`)
+	// Feed readers are anonymous, so the protected memo stays unexpanded.
+	require.NotContains(t, exploreRSS, "protected snippet body")
+	require.Contains(t, exploreRSS, "![[rss-protected]]")
+}
+
 func setInstanceAccessMode(ctx context.Context, t *testing.T, stores *store.Store, mode storepb.InstanceAccessMode) {
 	t.Helper()
 	_, err := stores.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
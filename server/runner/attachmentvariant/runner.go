@@ -0,0 +1,106 @@
+package attachmentvariant
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// evictInterval is how often the variant cache is evicted.
+	evictInterval = time.Hour
+	// defaultCacheSizeMb is the variant cache budget when none is configured.
+	defaultCacheSizeMb = 1024
+	// defaultCacheMaxAgeDays is how long unused variants are kept when no limit is configured.
+	defaultCacheMaxAgeDays = 30
+)
+
+// Runner evicts cached image variants that were not served recently, and the
+// least recently served variants once the cache outgrows its budget.
+type Runner struct {
+	Store *store.Store
+}
+
+func NewRunner(store *store.Store) *Runner {
+	return &Runner{
+		Store: store,
+	}
+}
+
+// Run evicts variants until the context is canceled.
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(evictInterval)
+	defer ticker.Stop()
+
+	for {
+		r.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce evicts the variants exceeding the configured age and size limits.
+func (r *Runner) RunOnce(ctx context.Context) {
+	storageSetting, err := r.Store.GetInstanceStorageSetting(ctx)
+	if err != nil {
+		slog.Error("failed to get instance storage setting", "err", err)
+		return
+	}
+	maxAgeDays := int64(storageSetting.GetVariantCacheMaxAgeDays())
+	if maxAgeDays == 0 {
+		maxAgeDays = defaultCacheMaxAgeDays
+	}
+	sizeMb := storageSetting.GetVariantCacheSizeMb()
+	if sizeMb == 0 {
+		sizeMb = defaultCacheSizeMb
+	}
+
+	// Variants are listed most recently served first, so everything past the
+	// budget or the age limit is the least recently served.
+	variants, err := r.Store.ListAttachmentVariants(ctx, &store.FindAttachmentVariant{})
+	if err != nil {
+		slog.Error("failed to list attachment variants", "err", err)
+		return
+	}
+	accessedAfter := time.Now().Add(-time.Duration(maxAgeDays) * 24 * time.Hour).Unix()
+	budget, used := sizeMb*1024*1024, int64(0)
+	for _, variant := range variants {
+		if ctx.Err() != nil {
+			return
+		}
+		// Posters are not generated on request, so evicting them would lose them.
+		if variant.Name == store.AttachmentPosterVariant {
+			continue
+		}
+		used += variant.Size
+		if variant.AccessedTs >= accessedAfter && used <= budget {
+			continue
+		}
+		if err := r.evict(ctx, variant); err != nil {
+			slog.Warn("failed to evict attachment variant", "err", err, "variant", variant.Reference)
+			continue
+		}
+		used -= variant.Size
+	}
+}
+
+func (r *Runner) evict(ctx context.Context, variant *store.AttachmentVariant) error {
+	attachment, err := r.Store.GetAttachment(ctx, &store.FindAttachment{ID: &variant.AttachmentID})
+	if err != nil {
+		return err
+	}
+	// Variants of deleted attachments are removed along with them, so only
+	// the record is left to clean up.
+	if attachment != nil {
+		if err := r.Store.DeleteAttachmentVariantStorage(ctx, attachment, variant); err != nil {
+			return err
+		}
+	}
+	return r.Store.DeleteAttachmentVariant(ctx, &store.DeleteAttachmentVariant{ID: variant.ID})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/internal/base"
 	"github.com/usememos/memos/internal/markdown"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
@@ -84,5 +85,13 @@ func RebuildMemoPayload(_ context.Context, memo *store.Memo, markdownService mar
 
 	memo.Payload.Tags = data.Tags
 	memo.Payload.Property = data.Property
+	memo.Payload.Properties = data.Properties
+	memo.Payload.Tasks = data.Tasks
+	memo.Payload.Mentions = []string{}
+	for _, username := range data.Mentions {
+		if !base.IsHereMention(username) {
+			memo.Payload.Mentions = append(memo.Payload.Mentions, username)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,97 @@
+package uploadsession
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// pruneInterval is how often expired upload sessions are discarded.
+	pruneInterval = time.Hour
+	// batchSize is the number of expired sessions discarded at once.
+	batchSize = 100
+)
+
+// Runner discards upload sessions that expired before they were completed,
+// removing staged files and aborting S3 multipart uploads.
+type Runner struct {
+	Store *store.Store
+}
+
+func NewRunner(store *store.Store) *Runner {
+	return &Runner{
+		Store: store,
+	}
+}
+
+// Run discards expired sessions until the context is canceled.
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		r.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce discards all currently expired sessions.
+func (r *Runner) RunOnce(ctx context.Context) {
+	now := time.Now().Unix()
+	for ctx.Err() == nil {
+		limit := batchSize
+		sessions, err := r.Store.ListUploadSessions(ctx, &store.FindUploadSession{
+			ExpiresBefore: &now,
+			Limit:         &limit,
+		})
+		if err != nil {
+			slog.Error("failed to list expired upload sessions", "err", err)
+			return
+		}
+
+		for _, session := range sessions {
+			if err := r.discard(ctx, session, now); err != nil {
+				slog.Error("failed to delete expired upload session", "err", err, "session", session.UID)
+				return
+			}
+		}
+
+		if len(sessions) < batchSize {
+			return
+		}
+	}
+}
+
+// discard removes an expired session under its lock, so it never races a
+// chunk write that extends the session.
+func (r *Runner) discard(ctx context.Context, session *store.UploadSession, now int64) error {
+	unlock := r.Store.LockUploadSession(session.UID)
+	defer unlock()
+
+	session, err := r.Store.GetUploadSession(ctx, &store.FindUploadSession{ID: &session.ID})
+	if err != nil {
+		return err
+	}
+	if session == nil || session.ExpiresTs >= now {
+		return nil
+	}
+	// A storage that cannot be cleaned up must not keep the session around
+	// forever; incomplete multipart uploads can also be expired by a bucket
+	// lifecycle rule.
+	if err := r.Store.DeleteUploadSessionStorage(ctx, session); err != nil {
+		slog.Warn("failed to discard expired upload session storage", "err", err, "session", session.UID)
+	}
+	if err := r.Store.DeleteUploadSession(ctx, &store.DeleteUploadSession{ID: session.ID}); err != nil {
+		return err
+	}
+	r.Store.ForgetUploadSessionLock(session.UID)
+	return nil
+}
@@ -0,0 +1,338 @@
+package webhookdelivery
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/webhook"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// pollInterval is how often the runner looks for due deliveries when it is not woken up.
+	pollInterval = 10 * time.Second
+	// batchSize is the number of due deliveries claimed at once.
+	batchSize = 20
+	// concurrency bounds the number of in-flight requests.
+	concurrency = 4
+	// leaseDuration keeps a claimed delivery from being picked up again while it is in flight.
+	leaseDuration = 2 * time.Minute
+
+	// baseRetryDelay is the delay before the first retry; it doubles with every attempt.
+	baseRetryDelay = 30 * time.Second
+	// maxRetryDelay caps the exponential backoff.
+	maxRetryDelay = time.Hour
+	// MaxAttempts is the number of attempts after which a delivery is marked as failed.
+	MaxAttempts = 8
+	// DisableAfterFailures is the number of consecutive failed deliveries after
+	// which a webhook is disabled and its owner notified.
+	DisableAfterFailures = 5
+
+	// retention is how long delivery history is kept.
+	retention = 30 * 24 * time.Hour
+	// pruneInterval is how often expired deliveries are removed.
+	pruneInterval = time.Hour
+)
+
+// Runner delivers queued webhook events, retrying failed attempts with
+// exponential backoff.
+type Runner struct {
+	Store *store.Store
+
+	wake chan struct{}
+}
+
+func NewRunner(store *store.Store) *Runner {
+	return &Runner{
+		Store: store,
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// Enqueue queues an event for a webhook and wakes the runner. Disabled
+// webhooks are skipped.
+func (r *Runner) Enqueue(ctx context.Context, creatorID int32, hook *storepb.WebhooksUserSetting_Webhook, payload *webhook.WebhookRequestPayload) (*store.WebhookDelivery, error) {
+	if hook.Disabled {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal webhook payload")
+	}
+	delivery, err := r.Store.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		UID:           webhook.NewMessageID(),
+		CreatorID:     creatorID,
+		WebhookID:     hook.Id,
+		ActivityType:  payload.ActivityType,
+		Payload:       string(body),
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create webhook delivery")
+	}
+	r.Wake()
+	return delivery, nil
+}
+
+// Redeliver queues a copy of a previous delivery under a new message id.
+func (r *Runner) Redeliver(ctx context.Context, delivery *store.WebhookDelivery) (*store.WebhookDelivery, error) {
+	redelivery, err := r.Store.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		UID:           webhook.NewMessageID(),
+		CreatorID:     delivery.CreatorID,
+		WebhookID:     delivery.WebhookID,
+		ActivityType:  delivery.ActivityType,
+		Payload:       delivery.Payload,
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create webhook delivery")
+	}
+	r.Wake()
+	return redelivery, nil
+}
+
+// Wake asks a running Run loop to process due deliveries now.
+func (r *Runner) Wake() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run processes deliveries until the context is canceled.
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	r.prune(ctx)
+	lastPrune := time.Now()
+	for {
+		r.RunOnce(ctx)
+		if time.Since(lastPrune) >= pruneInterval {
+			r.prune(ctx)
+			lastPrune = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// RunOnce attempts all deliveries that are currently due.
+func (r *Runner) RunOnce(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := time.Now().Unix()
+		pending := store.WebhookDeliveryPending
+		limit := batchSize
+		deliveries, err := r.Store.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+			Status:             &pending,
+			NextAttemptBefore:  &now,
+			OrderByNextAttempt: true,
+			Limit:              &limit,
+		})
+		if err != nil {
+			slog.Error("failed to list webhook deliveries", "err", err)
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		// Claim the batch so a slow attempt is not picked up twice.
+		leaseUntil := time.Now().Add(leaseDuration).Unix()
+		claimed := make([]*store.WebhookDelivery, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			if err := r.Store.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDelivery{
+				ID:            delivery.ID,
+				NextAttemptTs: &leaseUntil,
+			}); err != nil {
+				slog.Error("failed to claim webhook delivery", "err", err, "delivery", delivery.UID)
+				continue
+			}
+			claimed = append(claimed, delivery)
+		}
+
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, concurrency)
+		for _, delivery := range claimed {
+			slots <- struct{}{}
+			wg.Go(func() {
+				defer func() { <-slots }()
+				if err := r.attempt(ctx, delivery); err != nil {
+					slog.Error("failed to process webhook delivery", "err", err, "delivery", delivery.UID)
+				}
+			})
+		}
+		wg.Wait()
+
+		if len(deliveries) < batchSize {
+			return
+		}
+	}
+}
+
+// attempt posts a delivery once and records the outcome.
+func (r *Runner) attempt(ctx context.Context, delivery *store.WebhookDelivery) error {
+	hook, err := r.findWebhook(ctx, delivery.CreatorID, delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+	if hook == nil || hook.Disabled {
+		lastError := "webhook is disabled"
+		if hook == nil {
+			lastError = "webhook not found"
+		}
+		failed := store.WebhookDeliveryFailed
+		return r.Store.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDelivery{
+			ID:        delivery.ID,
+			Status:    &failed,
+			LastError: &lastError,
+		})
+	}
+
+	result, deliverErr := webhook.Deliver(ctx, hook.Url, hook.SigningSecret, delivery.UID, []byte(delivery.Payload))
+	if result == nil {
+		result = &webhook.DeliveryResult{}
+	}
+	attempts := delivery.Attempts + 1
+	statusCode := int32(result.StatusCode)
+	latencyMs := result.Latency.Milliseconds()
+	lastError := ""
+	if deliverErr != nil {
+		lastError = deliverErr.Error()
+	}
+	update := &store.UpdateWebhookDelivery{
+		ID:                 delivery.ID,
+		Attempts:           &attempts,
+		ResponseStatusCode: &statusCode,
+		LatencyMs:          &latencyMs,
+		ResponseExcerpt:    &result.ResponseExcerpt,
+		LastError:          &lastError,
+	}
+
+	var deliveryStatus store.WebhookDeliveryStatus
+	switch {
+	case deliverErr == nil:
+		deliveryStatus = store.WebhookDeliverySucceeded
+	case attempts >= MaxAttempts:
+		deliveryStatus = store.WebhookDeliveryFailed
+	default:
+		deliveryStatus = store.WebhookDeliveryPending
+		nextAttemptTs := time.Now().Add(RetryDelay(attempts)).Unix()
+		update.NextAttemptTs = &nextAttemptTs
+	}
+	update.Status = &deliveryStatus
+	if err := r.Store.UpdateWebhookDelivery(ctx, update); err != nil {
+		return errors.Wrap(err, "failed to update webhook delivery")
+	}
+
+	switch deliveryStatus {
+	case store.WebhookDeliverySucceeded:
+		return r.recordSuccess(ctx, delivery.CreatorID, delivery.WebhookID)
+	case store.WebhookDeliveryFailed:
+		return r.recordFailure(ctx, delivery.CreatorID, delivery.WebhookID, lastError)
+	default:
+		return nil
+	}
+}
+
+// RetryDelay returns the backoff before the attempt following the given number of attempts.
+func RetryDelay(attempts int32) time.Duration {
+	delay := baseRetryDelay
+	for i := int32(1); i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+func (r *Runner) findWebhook(ctx context.Context, userID int32, webhookID string) (*storepb.WebhooksUserSetting_Webhook, error) {
+	webhooks, err := r.Store.GetUserWebhooks(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user webhooks")
+	}
+	for _, hook := range webhooks {
+		if hook.Id == webhookID {
+			return hook, nil
+		}
+	}
+	return nil, nil
+}
+
+// recordSuccess resets the consecutive failure count of a webhook.
+func (r *Runner) recordSuccess(ctx context.Context, userID int32, webhookID string) error {
+	_, err := r.Store.ModifyUserWebhook(ctx, userID, webhookID, func(hook *storepb.WebhooksUserSetting_Webhook) bool {
+		if hook.FailureCount == 0 {
+			return false
+		}
+		hook.FailureCount = 0
+		return true
+	})
+	return err
+}
+
+// recordFailure counts a failed delivery and disables the webhook once it
+// failed DisableAfterFailures times in a row, notifying its owner.
+func (r *Runner) recordFailure(ctx context.Context, userID int32, webhookID string, lastError string) error {
+	disabled := false
+	hook, err := r.Store.ModifyUserWebhook(ctx, userID, webhookID, func(hook *storepb.WebhooksUserSetting_Webhook) bool {
+		disabled = false
+		if hook.Disabled {
+			return false
+		}
+		hook.FailureCount++
+		if hook.FailureCount >= DisableAfterFailures {
+			hook.Disabled = true
+			disabled = true
+		}
+		return true
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update webhook")
+	}
+	if !disabled {
+		return nil
+	}
+
+	slog.Warn("disabled webhook after repeated delivery failures", "user", userID, "webhook", webhookID)
+	if _, err := r.Store.CreateInbox(ctx, &store.Inbox{
+		SenderID:   userID,
+		ReceiverID: userID,
+		Status:     store.UNREAD,
+		Message: &storepb.InboxMessage{
+			Type: storepb.InboxMessage_WEBHOOK_DISABLED,
+			Payload: &storepb.InboxMessage_WebhookDisabled{
+				WebhookDisabled: &storepb.InboxMessage_WebhookDisabledPayload{
+					WebhookId:    hook.Id,
+					WebhookTitle: hook.Title,
+					LastError:    lastError,
+				},
+			},
+		},
+	}); err != nil {
+		return errors.Wrap(err, "failed to create webhook disabled notification")
+	}
+	return nil
+}
+
+// prune removes delivery history older than the retention period.
+func (r *Runner) prune(ctx context.Context) {
+	createdBefore := time.Now().Add(-retention).Unix()
+	if err := r.Store.DeleteWebhookDeliveries(ctx, &store.DeleteWebhookDelivery{
+		CreatedBefore: &createdBefore,
+	}); err != nil {
+		slog.Error("failed to prune webhook deliveries", "err", err)
+	}
+}
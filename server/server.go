@@ -21,6 +21,9 @@ import (
 	"github.com/usememos/memos/server/router/frontend"
 	"github.com/usememos/memos/server/router/mcp"
 	"github.com/usememos/memos/server/router/rss"
+	"github.com/usememos/memos/server/runner/attachmentvariant"
+	"github.com/usememos/memos/server/runner/uploadsession"
+	"github.com/usememos/memos/server/runner/webhookdelivery"
 	"github.com/usememos/memos/store"
 )
 
@@ -34,6 +37,12 @@ type Server struct {
 	echoServer *echo.Echo
 	httpServer *http.Server
 	sseHub     *apiv1.SSEHub
+
+	webhookDeliveryRunner *webhookdelivery.Runner
+	uploadSessionRunner   *uploadsession.Runner
+	variantRunner         *attachmentvariant.Runner
+	// runnerCancel stops the background runners started by Start.
+	runnerCancel context.CancelFunc
 }
 
 func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store) (*Server, error) {
@@ -70,10 +79,14 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 
 	apiV1Service := apiv1.NewAPIV1Service(s.Secret, profile, store)
 	s.sseHub = apiV1Service.SSEHub
+	s.webhookDeliveryRunner = apiV1Service.WebhookDeliveryRunner
+	s.uploadSessionRunner = uploadsession.NewRunner(store)
+	s.variantRunner = attachmentvariant.NewRunner(store)
 
 	// Register HTTP file server routes BEFORE gRPC-Gateway to ensure proper range request handling for Safari.
 	// This uses native HTTP serving (http.ServeContent) instead of gRPC for video/audio files.
 	fileServerService := fileserver.NewFileServerService(s.Profile, s.Store, s.Secret)
+	fileServerService.DiagramService = apiV1Service.DiagramService
 	fileServerService.RegisterRoutes(echoServer)
 
 	// Create and register RSS routes (needs markdown service from apiV1Service).
@@ -122,6 +135,12 @@ func (s *Server) Start() error {
 		}
 	}()
 
+	runnerCtx, runnerCancel := context.WithCancel(context.Background())
+	s.runnerCancel = runnerCancel
+	go s.webhookDeliveryRunner.Run(runnerCtx)
+	go s.uploadSessionRunner.Run(runnerCtx)
+	go s.variantRunner.Run(runnerCtx)
+
 	return nil
 }
 
@@ -134,6 +153,11 @@ func (s *Server) Shutdown(ctx context.Context) {
 	s.closeLongLivedConnections()
 	s.shutdownHTTPServer(ctx)
 
+	// Stop background runners before the database is closed.
+	if s.runnerCancel != nil {
+		s.runnerCancel()
+	}
+
 	// Close database connection.
 	if err := s.Store.Close(); err != nil {
 		slog.Error("failed to close database", slog.String("error", err.Error()))
@@ -2,9 +2,11 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/pkg/errors"
 
@@ -32,25 +34,37 @@ type Attachment struct {
 	StorageType storepb.AttachmentStorageType
 	Reference   string
 	Payload     *storepb.AttachmentPayload
+	// SHA256 is the hex-encoded digest of the stored content, empty when the
+	// content never passed through the server.
+	SHA256 string
 
 	// The related memo ID.
 	MemoID *int32
 
 	// Composed field
 	MemoUID *string
+
+	// SharedContent marks a new attachment that references the stored content
+	// of an existing one. It is not persisted; CreateAttachment uses it to
+	// check that the content is still referenced when the row is inserted.
+	SharedContent bool
 }
 
 type FindAttachment struct {
-	GetBlob          bool
-	ID               *int32
-	UID              *string
-	CreatorID        *int32
-	Filename         *string
-	FilenameSearch   *string
-	MemoID           *int32
-	MemoIDList       []int32
-	HasRelatedMemo   bool
-	Filters          []string
+	GetBlob        bool
+	ID             *int32
+	UID            *string
+	CreatorID      *int32
+	Filename       *string
+	FilenameSearch *string
+	SHA256         *string
+	MemoID         *int32
+	MemoIDList     []int32
+	HasRelatedMemo bool
+	Filters        []string
+	// IDAfter restricts results to attachments with a greater ID and orders
+	// them by ID ascending, for cursor-based scans over all attachments.
+	IDAfter          *int32
 	Limit            *int
 	Offset           *int
 	SkipDefaultLimit bool
@@ -63,6 +77,32 @@ type UpdateAttachment struct {
 	Filename  *string
 	MemoID    *int32
 	Payload   *storepb.AttachmentPayload
+	// Storage moves the attachment content; the storage type, reference and
+	// blob are replaced together in the same statement as the payload.
+	Storage *AttachmentStorage
+}
+
+// AttachmentStorage is where the content of an attachment is kept.
+type AttachmentStorage struct {
+	Type      storepb.AttachmentStorageType
+	Reference string
+	Blob      []byte
+	SHA256    string
+}
+
+// AttachmentUsage is the attachment storage used by one creator.
+type AttachmentUsage struct {
+	CreatorID       int32
+	AttachmentCount int32
+	// TotalSize is the sum of attachment sizes in bytes. Deduplicated content
+	// counts once for every attachment referencing it.
+	TotalSize int64
+}
+
+// FindAttachmentUsage selects usage rows, ordered by total size descending.
+type FindAttachmentUsage struct {
+	CreatorID *int32
+	Limit     *int
 }
 
 type DeleteAttachment struct {
@@ -80,7 +120,21 @@ type deleteAttachmentStorageFailpointKey struct{}
 // ErrDeleteAttachmentStorageFailpoint is returned by the test-only attachment storage failpoint.
 var ErrDeleteAttachmentStorageFailpoint = errors.New("delete attachment storage failpoint")
 
-// WithDeleteAttachmentStorageFailpoint forces DeleteAttachmentStorage to return a failpoint error.
+// ErrAttachmentContentReleased is returned when an attachment reuses stored
+// content whose last reference was deleted concurrently, so the object may
+// already be gone.
+var ErrAttachmentContentReleased = errors.New("attachment content was released")
+
+// AttachmentContentTx runs attachment statements inside LockAttachmentContent
+// or LockAttachment.
+type AttachmentContentTx interface {
+	CreateAttachment(ctx context.Context, create *Attachment) (*Attachment, error)
+	ListAttachments(ctx context.Context, find *FindAttachment) ([]*Attachment, error)
+	UpdateAttachment(ctx context.Context, update *UpdateAttachment) error
+	DeleteAttachments(ctx context.Context, deletes []*DeleteAttachment) error
+}
+
+// WithDeleteAttachmentStorageFailpoint forces attachment storage deletes to return a failpoint error.
 func WithDeleteAttachmentStorageFailpoint(ctx context.Context) context.Context {
 	return context.WithValue(ctx, deleteAttachmentStorageFailpointKey{}, true)
 }
@@ -89,7 +143,25 @@ func (s *Store) CreateAttachment(ctx context.Context, create *Attachment) (*Atta
 	if !base.UIDMatcher.MatchString(create.UID) {
 		return nil, errors.New("invalid uid")
 	}
-	return s.driver.CreateAttachment(ctx, create)
+	if !create.SharedContent {
+		return s.driver.CreateAttachment(ctx, create)
+	}
+
+	var attachment *Attachment
+	if err := s.lockAttachmentContent(ctx, []*Attachment{create}, func(tx AttachmentContentTx) error {
+		shared, err := attachmentContentShared(ctx, tx, create)
+		if err != nil {
+			return errors.Wrap(err, "failed to find attachments sharing the content")
+		}
+		if !shared {
+			return ErrAttachmentContentReleased
+		}
+		attachment, err = tx.CreateAttachment(ctx, create)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return attachment, nil
 }
 
 func (s *Store) ListAttachments(ctx context.Context, find *FindAttachment) ([]*Attachment, error) {
@@ -121,6 +193,23 @@ func (s *Store) GetAttachment(ctx context.Context, find *FindAttachment) (*Attac
 	return attachments[0], nil
 }
 
+// ListAttachmentUsage sums attachment sizes per creator, largest first.
+func (s *Store) ListAttachmentUsage(ctx context.Context, find *FindAttachmentUsage) ([]*AttachmentUsage, error) {
+	return s.driver.ListAttachmentUsage(ctx, find)
+}
+
+// GetUserAttachmentUsage returns the attachment storage used by a user.
+func (s *Store) GetUserAttachmentUsage(ctx context.Context, userID int32) (*AttachmentUsage, error) {
+	usages, err := s.driver.ListAttachmentUsage(ctx, &FindAttachmentUsage{CreatorID: &userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(usages) == 0 {
+		return &AttachmentUsage{CreatorID: userID}, nil
+	}
+	return usages[0], nil
+}
+
 func (s *Store) UpdateAttachment(ctx context.Context, update *UpdateAttachment) error {
 	if update.UID != nil && !base.UIDMatcher.MatchString(*update.UID) {
 		return errors.New("invalid uid")
@@ -128,6 +217,33 @@ func (s *Store) UpdateAttachment(ctx context.Context, update *UpdateAttachment)
 	return s.driver.UpdateAttachment(ctx, update)
 }
 
+// UpdateAttachmentPayload applies update to the latest payload of an
+// attachment and saves it in one transaction holding the attachment row, so
+// background jobs writing different payload fields keep each other's results.
+// It reports false, without calling update, when the attachment is gone.
+func (s *Store) UpdateAttachmentPayload(ctx context.Context, id int32, update func(*storepb.AttachmentPayload) error) (bool, error) {
+	found := false
+	err := s.driver.LockAttachment(ctx, id, func(tx AttachmentContentTx) error {
+		attachments, err := tx.ListAttachments(ctx, &FindAttachment{ID: &id})
+		if err != nil {
+			return err
+		}
+		if len(attachments) == 0 {
+			return nil
+		}
+		found = true
+		payload := attachments[0].Payload
+		if payload == nil {
+			payload = &storepb.AttachmentPayload{}
+		}
+		if err := update(payload); err != nil {
+			return err
+		}
+		return tx.UpdateAttachment(ctx, &UpdateAttachment{ID: id, Payload: payload})
+	})
+	return found, err
+}
+
 func (s *Store) DeleteAttachment(ctx context.Context, delete *DeleteAttachment) error {
 	attachment, err := s.GetAttachment(ctx, &FindAttachment{ID: &delete.ID})
 	if err != nil {
@@ -136,22 +252,19 @@ func (s *Store) DeleteAttachment(ctx context.Context, delete *DeleteAttachment)
 	if attachment == nil {
 		return errors.New("attachment not found")
 	}
-
-	if err := s.DeleteAttachmentStorage(ctx, attachment); err != nil {
-		if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
-			return errors.Wrap(err, "failed to delete local file")
-		}
-		slog.Warn("Failed to delete attachment storage", slog.Any("err", err))
+	if shouldFailDeleteAttachmentStorage(ctx) && attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
+		return errors.Wrap(ErrDeleteAttachmentStorageFailpoint, "failed to delete local file")
 	}
 
-	return s.driver.DeleteAttachment(ctx, delete)
+	return s.DeleteAttachments(ctx, []*Attachment{attachment})
 }
 
+// DeleteAttachments deletes the attachment rows, then the stored objects no
+// other attachment references any more. The reference check and the row
+// deletes share one transaction locking the content digests, and objects are
+// deleted only after it commits, so a concurrent reuse either sees the rows
+// gone or keeps the object alive.
 func (s *Store) DeleteAttachments(ctx context.Context, attachments []*Attachment) error {
-	if len(attachments) == 0 {
-		return nil
-	}
-
 	deletes := make([]*DeleteAttachment, 0, len(attachments))
 	for _, attachment := range attachments {
 		if attachment == nil {
@@ -163,58 +276,210 @@ func (s *Store) DeleteAttachments(ctx context.Context, attachments []*Attachment
 		return nil
 	}
 
-	if err := s.driver.DeleteAttachments(ctx, deletes); err != nil {
+	released, err := s.releaseAttachmentContent(ctx, attachments, func(tx AttachmentContentTx) error {
+		return tx.DeleteAttachments(ctx, deletes)
+	})
+	if err != nil {
+		return err
+	}
+	for _, failure := range s.deleteReleasedAttachmentObjects(ctx, attachments, released) {
+		if failure.attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
+			return errors.Wrap(failure.err, "failed to delete local file")
+		}
+		slog.Warn("Failed to delete attachment storage", slog.Any("err", failure.err))
+	}
+	return nil
+}
+
+// AttachmentCleanupError reports attachments whose stored objects could not
+// be deleted after their rows were.
+type AttachmentCleanupError struct {
+	// AttachmentIDs are the attachments whose objects were left behind.
+	AttachmentIDs []int32
+	// Err is the first failure.
+	Err error
+}
+
+func (e *AttachmentCleanupError) Error() string {
+	return fmt.Sprintf("failed to delete the storage of %d attachment(s), first attachment_id=%d: %v", len(e.AttachmentIDs), e.AttachmentIDs[0], e.Err)
+}
+
+func (e *AttachmentCleanupError) Unwrap() error {
+	return e.Err
+}
+
+// ReleaseAttachmentContent deletes the stored objects of attachments whose
+// rows were deleted elsewhere, such as with their creator, unless other
+// attachments still reference the content. Like DeleteAttachments, the
+// reference check holds the content lock and objects are deleted only after
+// it is released. Objects that could not be deleted are reported with an
+// *AttachmentCleanupError.
+func (s *Store) ReleaseAttachmentContent(ctx context.Context, attachments []*Attachment) error {
+	released, err := s.releaseAttachmentContent(ctx, attachments, nil)
+	if err != nil {
 		return err
 	}
+	var failures []attachmentCleanupFailure
+	if shouldFailDeleteAttachmentStorage(ctx) {
+		for _, attachment := range released {
+			failures = append(failures, attachmentCleanupFailure{attachment: attachment, err: ErrDeleteAttachmentStorageFailpoint})
+		}
+	} else {
+		failures = s.deleteReleasedAttachmentObjects(ctx, attachments, released)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	cleanupErr := &AttachmentCleanupError{Err: failures[0].err}
+	for _, failure := range failures {
+		cleanupErr.AttachmentIDs = append(cleanupErr.AttachmentIDs, failure.attachment.ID)
+	}
+	return cleanupErr
+}
+
+// releaseAttachmentContent runs deleteRows, when set, in a transaction
+// locking the content digests of the attachments, and returns those whose
+// content no other attachment references once it commits.
+func (s *Store) releaseAttachmentContent(ctx context.Context, attachments []*Attachment, deleteRows func(AttachmentContentTx) error) ([]*Attachment, error) {
+	var released []*Attachment
+	if err := s.lockAttachmentContent(ctx, attachments, func(tx AttachmentContentTx) error {
+		if deleteRows != nil {
+			if err := deleteRows(tx); err != nil {
+				return err
+			}
+		}
+		locations := map[string]bool{}
+		for _, attachment := range attachments {
+			if attachment == nil {
+				continue
+			}
+			shared, err := attachmentContentShared(ctx, tx, attachment)
+			if err != nil {
+				return errors.Wrap(err, "failed to find attachments sharing the content")
+			}
+			location := attachmentContentLocation(attachment)
+			if shared || (location != "" && locations[location]) {
+				continue
+			}
+			locations[location] = true
+			released = append(released, attachment)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+type attachmentCleanupFailure struct {
+	attachment *Attachment
+	err        error
+}
 
-	instanceStorageSetting, instanceStorageSettingErr := s.getAttachmentStorageCleanupInstanceSetting(ctx, attachments)
+// deleteReleasedAttachmentObjects deletes the objects of the released
+// attachments and the derived caches of the others.
+func (s *Store) deleteReleasedAttachmentObjects(ctx context.Context, attachments []*Attachment, released []*Attachment) []attachmentCleanupFailure {
+	isReleased := make(map[int32]bool, len(released))
+	for _, attachment := range released {
+		isReleased[attachment.ID] = true
+	}
+	var failures []attachmentCleanupFailure
+	instanceStorageSetting, instanceStorageSettingErr := s.getAttachmentStorageCleanupInstanceSetting(ctx, released)
 	for _, attachment := range attachments {
 		if attachment == nil {
 			continue
 		}
+		if !isReleased[attachment.ID] {
+			// Deduplicated content stays until its last attachment is deleted.
+			s.deleteAttachmentDerivedCaches(ctx, attachment)
+			continue
+		}
 		var err error
 		if instanceStorageSettingErr != nil && AttachmentNeedsInstanceStorageSetting(attachment) {
 			err = instanceStorageSettingErr
 		} else {
-			err = s.deleteAttachmentStorageImpl(ctx, attachment, instanceStorageSetting)
+			err = s.deleteAttachmentObject(ctx, attachment, instanceStorageSetting)
 		}
 		if err != nil {
-			if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
-				return errors.Wrap(err, "failed to delete local file")
-			}
-			slog.Warn("Failed to delete attachment storage", slog.Any("err", err))
+			failures = append(failures, attachmentCleanupFailure{attachment: attachment, err: err})
 		}
 	}
-
-	return nil
-}
-
-func (s *Store) DeleteAttachmentStorage(ctx context.Context, attachment *Attachment) error {
-	return s.deleteAttachmentStorageImpl(ctx, attachment, nil)
+	return failures
 }
 
-// DeleteAttachmentStorageWithInstanceSetting deletes attachment storage using a preloaded instance storage setting.
-func (s *Store) DeleteAttachmentStorageWithInstanceSetting(ctx context.Context, attachment *Attachment, instanceStorageSetting *storepb.InstanceStorageSetting) error {
-	return s.deleteAttachmentStorageImpl(ctx, attachment, instanceStorageSetting)
+// MoveAttachmentContent points attachment at the content of migrated, and
+// reports whether the previous content lost its last reference so its object
+// can be deleted. When migrated reuses content, the update fails with
+// ErrAttachmentContentReleased if that content was deleted concurrently.
+func (s *Store) MoveAttachmentContent(ctx context.Context, attachment *Attachment, migrated *Attachment, update *UpdateAttachment) (bool, error) {
+	released := false
+	err := s.lockAttachmentContent(ctx, []*Attachment{attachment, migrated}, func(tx AttachmentContentTx) error {
+		if migrated.SharedContent {
+			shared, err := attachmentContentShared(ctx, tx, migrated)
+			if err != nil {
+				return errors.Wrap(err, "failed to find attachments sharing the content")
+			}
+			if !shared {
+				return ErrAttachmentContentReleased
+			}
+		}
+		if err := tx.UpdateAttachment(ctx, update); err != nil {
+			return err
+		}
+		shared, err := attachmentContentShared(ctx, tx, attachment)
+		if err != nil {
+			return errors.Wrap(err, "failed to find attachments sharing the content")
+		}
+		released = !shared
+		return nil
+	})
+	return released, err
 }
 
-func (s *Store) deleteAttachmentStorageImpl(ctx context.Context, attachment *Attachment, instanceStorageSetting *storepb.InstanceStorageSetting) error {
-	if attachment == nil {
-		return nil
-	}
-	if shouldFailDeleteAttachmentStorage(ctx) {
-		return ErrDeleteAttachmentStorageFailpoint
+// lockAttachmentContent runs fn in a transaction that serializes reference
+// checks on the stored content of the attachments.
+func (s *Store) lockAttachmentContent(ctx context.Context, attachments []*Attachment, fn func(AttachmentContentTx) error) error {
+	digests := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		if attachment != nil && attachment.SHA256 != "" && !slices.Contains(digests, attachment.SHA256) {
+			digests = append(digests, attachment.SHA256)
+		}
 	}
+	// A stable lock order keeps transactions locking several digests from
+	// deadlocking each other.
+	slices.Sort(digests)
+	return s.driver.LockAttachmentContent(ctx, digests, fn)
+}
 
+// deleteAttachmentObject deletes the stored object of an attachment and its
+// derived caches, regardless of other attachments referencing it.
+func (s *Store) deleteAttachmentObject(ctx context.Context, attachment *Attachment, instanceStorageSetting *storepb.InstanceStorageSetting) error {
 	if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
+		driver, err := s.StorageDriver(ctx, builtinStorage(storepb.StorageType_STORAGE_TYPE_LOCAL))
+		if err != nil {
+			return errors.Wrap(err, "failed to create local storage driver")
+		}
+		if err := driver.DeleteObject(ctx, attachment.Reference); err != nil {
+			return errors.Wrap(err, "failed to delete local file")
+		}
+	} else if attachment.StorageType == storepb.AttachmentStorageType_OBJECT {
 		if err := func() error {
-			p := filepath.FromSlash(attachment.Reference)
-			if !filepath.IsAbs(p) {
-				p = filepath.Join(s.profile.Data, p)
+			storageObject := attachment.Payload.GetStorageObject()
+			if storageObject == nil {
+				return errors.New("storage object payload is missing")
 			}
-			err := os.Remove(p)
-			if err != nil && !os.IsNotExist(err) {
-				return errors.Wrap(err, "failed to delete local file")
+			if instanceStorageSetting == nil {
+				var err error
+				if instanceStorageSetting, err = s.GetInstanceStorageSetting(ctx); err != nil {
+					return errors.Wrap(err, "failed to get instance storage setting")
+				}
+			}
+			driver, err := s.ResolveStorageDriver(ctx, instanceStorageSetting, storageObject.StorageId, nil)
+			if err != nil {
+				return errors.Wrap(err, "failed to resolve storage driver")
+			}
+			if err := driver.DeleteObject(ctx, storageObject.Key); err != nil {
+				return errors.Wrap(err, "failed to delete storage object")
 			}
 			return nil
 		}(); err != nil {
@@ -259,7 +524,7 @@ func (s *Store) deleteAttachmentStorageImpl(ctx context.Context, attachment *Att
 		}
 	}
 
-	s.deleteAttachmentDerivedCaches(attachment)
+	s.deleteAttachmentDerivedCaches(ctx, attachment)
 	return nil
 }
 
@@ -276,50 +541,136 @@ func (s *Store) getAttachmentStorageCleanupInstanceSetting(ctx context.Context,
 	return nil, nil
 }
 
-// ResolveAttachmentS3Driver resolves the storage driver referenced by an S3
-// attachment payload, validating the payload and supplying the instance setting.
-func (s *Store) ResolveAttachmentS3Driver(ctx context.Context, attachment *Attachment) (storage.Driver, *storepb.AttachmentPayload_S3Object, error) {
+// ResolveAttachmentDriver resolves the storage driver holding an attachment's
+// content together with the object key within that storage. Database
+// attachments have no driver and return an error.
+func (s *Store) ResolveAttachmentDriver(ctx context.Context, attachment *Attachment) (storage.Driver, string, error) {
 	if attachment == nil {
-		return nil, nil, errors.New("attachment is missing")
-	}
-	if attachment.Payload == nil {
-		return nil, nil, errors.New("attachment payload is missing")
+		return nil, "", errors.New("attachment is missing")
 	}
-	s3Object := attachment.Payload.GetS3Object()
-	if s3Object == nil {
-		return nil, nil, errors.New("S3 object payload is missing")
-	}
-	if s3Object.Key == "" {
-		return nil, nil, errors.New("S3 object key is missing")
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_LOCAL:
+		driver, err := s.StorageDriver(ctx, builtinStorage(storepb.StorageType_STORAGE_TYPE_LOCAL))
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to create local storage driver")
+		}
+		return driver, attachment.Reference, nil
+	case storepb.AttachmentStorageType_S3:
+		s3Object := attachment.Payload.GetS3Object()
+		if s3Object == nil {
+			return nil, "", errors.New("S3 object payload is missing")
+		}
+		if s3Object.Key == "" {
+			return nil, "", errors.New("S3 object key is missing")
+		}
+		instanceStorageSetting, err := s.GetInstanceStorageSetting(ctx)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to get instance storage setting")
+		}
+		resolvedStorage, err := ResolveStorage(instanceStorageSetting, s3Object.StorageId, s3Object.S3Config)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to resolve storage")
+		}
+		driver, err := s.StorageDriver(ctx, resolvedStorage)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to create storage driver")
+		}
+		return driver, s3Object.Key, nil
+	case storepb.AttachmentStorageType_OBJECT:
+		storageObject := attachment.Payload.GetStorageObject()
+		if storageObject == nil {
+			return nil, "", errors.New("storage object payload is missing")
+		}
+		if storageObject.Key == "" {
+			return nil, "", errors.New("storage object key is missing")
+		}
+		instanceStorageSetting, err := s.GetInstanceStorageSetting(ctx)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to get instance storage setting")
+		}
+		driver, err := s.ResolveStorageDriver(ctx, instanceStorageSetting, storageObject.StorageId, nil)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to resolve storage driver")
+		}
+		return driver, storageObject.Key, nil
+	default:
+		return nil, "", errors.Errorf("attachment storage type %s has no driver", attachment.StorageType)
 	}
+}
 
-	instanceStorageSetting, err := s.GetInstanceStorageSetting(ctx)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to get instance storage setting")
+// AttachmentContentShared reports whether another attachment references the
+// stored content of attachment. Identical uploads share one object per
+// storage, so the object may only be deleted with its last reference.
+func (s *Store) AttachmentContentShared(ctx context.Context, attachment *Attachment) (bool, error) {
+	return attachmentContentShared(ctx, s.driver, attachment)
+}
+
+type attachmentLister interface {
+	ListAttachments(ctx context.Context, find *FindAttachment) ([]*Attachment, error)
+}
+
+func attachmentContentShared(ctx context.Context, lister attachmentLister, attachment *Attachment) (bool, error) {
+	location := attachmentContentLocation(attachment)
+	if attachment.SHA256 == "" || location == "" {
+		return false, nil
 	}
-	resolvedStorage, err := ResolveStorage(instanceStorageSetting, s3Object.StorageId, s3Object.S3Config)
+	siblings, err := lister.ListAttachments(ctx, &FindAttachment{SHA256: &attachment.SHA256})
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to resolve storage")
+		return false, err
 	}
-	driver, err := s.StorageDriver(ctx, resolvedStorage)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to create storage driver")
+	for _, sibling := range siblings {
+		if sibling.ID != attachment.ID && attachmentContentLocation(sibling) == location {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// attachmentContentLocation identifies the stored object holding the content
+// of an attachment, or returns an empty string for content that is never
+// shared, such as database blobs and external links.
+func attachmentContentLocation(attachment *Attachment) string {
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_LOCAL:
+		return "local:" + attachment.Reference
+	case storepb.AttachmentStorageType_S3:
+		s3Object := attachment.Payload.GetS3Object()
+		if s3Object == nil {
+			return ""
+		}
+		return fmt.Sprintf("s3:%s:%s:%s:%s", s3Object.StorageId, s3Object.S3Config.GetEndpoint(), s3Object.S3Config.GetBucket(), s3Object.Key)
+	case storepb.AttachmentStorageType_OBJECT:
+		storageObject := attachment.Payload.GetStorageObject()
+		if storageObject == nil {
+			return ""
+		}
+		return fmt.Sprintf("object:%s:%s", storageObject.StorageId, storageObject.Key)
+	default:
+		return ""
 	}
-	return driver, s3Object, nil
 }
 
 // AttachmentNeedsInstanceStorageSetting reports whether cleanup should load
-// the configured storage registry for an S3 attachment.
+// the configured storage registry for an S3 or storage object attachment.
 func AttachmentNeedsInstanceStorageSetting(attachment *Attachment) bool {
-	if attachment == nil || attachment.StorageType != storepb.AttachmentStorageType_S3 {
+	if attachment == nil {
+		return false
+	}
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_S3:
+		return attachment.Payload.GetS3Object() != nil
+	case storepb.AttachmentStorageType_OBJECT:
+		return attachment.Payload.GetStorageObject() != nil
+	default:
 		return false
 	}
-	return attachment.Payload.GetS3Object() != nil
 }
 
-func (s *Store) deleteAttachmentDerivedCaches(attachment *Attachment) {
+func (s *Store) deleteAttachmentDerivedCaches(ctx context.Context, attachment *Attachment) {
+	s.deleteAttachmentVariantsBestEffort(ctx, attachment)
 	for _, cachePath := range []string{
 		filepath.Join(s.profile.Data, thumbnailCacheFolder, attachment.UID+".jpeg"),
+		filepath.Join(s.profile.Data, thumbnailCacheFolder, attachment.UID+".v2.jpeg"),
 		filepath.Join(s.profile.Data, motionCacheFolder, attachment.UID+".mp4"),
 	} {
 		if err := os.Remove(cachePath); err != nil && !os.IsNotExist(err) {
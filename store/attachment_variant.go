@@ -0,0 +1,205 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/storage"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// AttachmentVariantFolder is the folder, relative to the root of a storage,
+// that cached attachment variants are written to.
+const AttachmentVariantFolder = ".variants"
+
+// AttachmentPosterVariant is the variant name of the poster frame generated
+// for a video attachment. Posters are generated once in the background rather
+// than on request, so they are never evicted from the cache.
+const AttachmentPosterVariant = "poster.jpeg"
+
+// AttachmentVariant is a resized or converted copy of an attachment image,
+// cached in the storage holding the attachment.
+type AttachmentVariant struct {
+	ID           int32
+	AttachmentID int32
+	// Name identifies the variant within its attachment, such as "medium.webp".
+	Name string
+	Type string
+	Size int64
+	// Reference is the object key of the variant in its storage.
+	Reference  string
+	CreatedTs  int64
+	AccessedTs int64
+}
+
+// FindAttachmentVariant specifies filter criteria for querying attachment
+// variants. Results are ordered by last access, most recent first.
+type FindAttachmentVariant struct {
+	ID           *int32
+	AttachmentID *int32
+	Name         *string
+	// AccessedBefore matches variants last served before the timestamp.
+	AccessedBefore *int64
+
+	Limit *int
+}
+
+// UpdateAttachmentVariant contains the fields that can be updated on an attachment variant.
+type UpdateAttachmentVariant struct {
+	ID         int32
+	AccessedTs *int64
+}
+
+// DeleteAttachmentVariant specifies the attachment variant to delete.
+type DeleteAttachmentVariant struct {
+	ID int32
+}
+
+// UpsertAttachmentVariant records a generated variant, replacing an existing
+// variant of the same attachment and name.
+func (s *Store) UpsertAttachmentVariant(ctx context.Context, upsert *AttachmentVariant) (*AttachmentVariant, error) {
+	return s.driver.UpsertAttachmentVariant(ctx, upsert)
+}
+
+// ListAttachmentVariants returns attachment variants matching the filter criteria.
+func (s *Store) ListAttachmentVariants(ctx context.Context, find *FindAttachmentVariant) ([]*AttachmentVariant, error) {
+	return s.driver.ListAttachmentVariants(ctx, find)
+}
+
+// GetAttachmentVariant returns the first attachment variant matching the filter, or nil if none found.
+func (s *Store) GetAttachmentVariant(ctx context.Context, find *FindAttachmentVariant) (*AttachmentVariant, error) {
+	limit := 1
+	find.Limit = &limit
+	list, err := s.ListAttachmentVariants(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// UpdateAttachmentVariant updates an attachment variant.
+func (s *Store) UpdateAttachmentVariant(ctx context.Context, update *UpdateAttachmentVariant) error {
+	return s.driver.UpdateAttachmentVariant(ctx, update)
+}
+
+// DeleteAttachmentVariant deletes an attachment variant record. Use
+// DeleteAttachmentVariantStorage to remove the cached object.
+func (s *Store) DeleteAttachmentVariant(ctx context.Context, delete *DeleteAttachmentVariant) error {
+	return s.driver.DeleteAttachmentVariant(ctx, delete)
+}
+
+// AttachmentVariantKey returns the object key a variant of an attachment is cached under.
+func AttachmentVariantKey(attachment *Attachment, name string) string {
+	return path.Join(AttachmentVariantFolder, attachment.UID, name)
+}
+
+// ResolveAttachmentVariantDriver returns the storage driver caching the
+// variants of an attachment: the storage of the attachment itself, or the
+// data directory for attachments kept in the database.
+func (s *Store) ResolveAttachmentVariantDriver(ctx context.Context, attachment *Attachment) (storage.Driver, error) {
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_LOCAL, storepb.AttachmentStorageType_S3, storepb.AttachmentStorageType_OBJECT:
+		driver, _, err := s.ResolveAttachmentDriver(ctx, attachment)
+		return driver, err
+	default:
+		driver, err := s.StorageDriver(ctx, builtinStorage(storepb.StorageType_STORAGE_TYPE_LOCAL))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create local storage driver")
+		}
+		return driver, nil
+	}
+}
+
+// DeleteAttachmentVariantStorage removes a cached variant object.
+func (s *Store) DeleteAttachmentVariantStorage(ctx context.Context, attachment *Attachment, variant *AttachmentVariant) error {
+	driver, err := s.ResolveAttachmentVariantDriver(ctx, attachment)
+	if err != nil {
+		return err
+	}
+	if err := driver.DeleteObject(ctx, variant.Reference); err != nil {
+		return errors.Wrap(err, "failed to delete attachment variant")
+	}
+	return nil
+}
+
+// DeleteAttachmentVariants removes every cached variant of an attachment,
+// such as when the attachment is deleted or moved to another storage.
+func (s *Store) DeleteAttachmentVariants(ctx context.Context, attachment *Attachment) error {
+	variants, err := s.ListAttachmentVariants(ctx, &FindAttachmentVariant{AttachmentID: &attachment.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list attachment variants")
+	}
+	for _, variant := range variants {
+		if err := s.DeleteAttachmentVariantStorage(ctx, attachment, variant); err != nil {
+			return err
+		}
+		if err := s.DeleteAttachmentVariant(ctx, &DeleteAttachmentVariant{ID: variant.ID}); err != nil {
+			return errors.Wrap(err, "failed to delete attachment variant record")
+		}
+	}
+	return nil
+}
+
+// RelocateAttachmentVariants moves the variants of an attachment that was
+// migrated from the storage of source to the storage of target. Posters are
+// copied to the new storage; other variants are dropped and generated again
+// when requested.
+func (s *Store) RelocateAttachmentVariants(ctx context.Context, source, target *Attachment) error {
+	variants, err := s.ListAttachmentVariants(ctx, &FindAttachmentVariant{AttachmentID: &source.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list attachment variants")
+	}
+	var posters []*AttachmentVariant
+	for _, variant := range variants {
+		if variant.Name != AttachmentPosterVariant {
+			continue
+		}
+		sourceDriver, err := s.ResolveAttachmentVariantDriver(ctx, source)
+		if err != nil {
+			return err
+		}
+		blob, err := sourceDriver.GetObject(ctx, variant.Reference)
+		if err != nil {
+			return errors.Wrap(err, "failed to read attachment poster")
+		}
+		targetDriver, err := s.ResolveAttachmentVariantDriver(ctx, target)
+		if err != nil {
+			return err
+		}
+		reference, err := targetDriver.UploadObject(ctx, AttachmentVariantKey(target, variant.Name), variant.Type, bytes.NewReader(blob))
+		if err != nil {
+			return errors.Wrap(err, "failed to copy attachment poster")
+		}
+		posters = append(posters, &AttachmentVariant{
+			AttachmentID: target.ID,
+			Name:         variant.Name,
+			Type:         variant.Type,
+			Size:         int64(len(blob)),
+			Reference:    reference,
+		})
+	}
+	if err := s.DeleteAttachmentVariants(ctx, source); err != nil {
+		return err
+	}
+	for _, poster := range posters {
+		if _, err := s.UpsertAttachmentVariant(ctx, poster); err != nil {
+			return errors.Wrap(err, "failed to record attachment poster")
+		}
+	}
+	return nil
+}
+
+// deleteAttachmentVariantsBestEffort removes the variants of a deleted
+// attachment, leaving anything it cannot remove to the eviction runner.
+func (s *Store) deleteAttachmentVariantsBestEffort(ctx context.Context, attachment *Attachment) {
+	if err := s.DeleteAttachmentVariants(ctx, attachment); err != nil {
+		slog.Warn("Failed to delete attachment variants", slog.String("attachment", attachment.UID), slog.Any("err", err))
+	}
+}
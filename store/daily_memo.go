@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+)
+
+// DailyMemoDateLayout is the layout of DailyMemo dates.
+const DailyMemoDateLayout = "2006-01-02"
+
+// DailyMemo indexes the daily note of a user: the one memo that every request
+// for the user and day resolves to.
+type DailyMemo struct {
+	CreatorID int32
+	// Date is the calendar day in the user's time zone, as 2006-01-02.
+	Date      string
+	MemoID    int32
+	CreatedTs int64
+}
+
+// FindDailyMemo specifies filter criteria for querying daily memos. Results
+// are ordered by date.
+type FindDailyMemo struct {
+	CreatorID *int32
+	Date      *string
+	MemoID    *int32
+	// StartDate and EndDate bound the dates, both inclusive.
+	StartDate *string
+	EndDate   *string
+}
+
+// DeleteDailyMemo specifies the daily memo to delete.
+type DeleteDailyMemo struct {
+	CreatorID int32
+	Date      string
+}
+
+// CreateDailyMemo records the daily memo unless the user already has one for
+// the date. It reports whether the daily memo was recorded; when it was not,
+// the existing daily memo wins and the caller must use it instead.
+func (s *Store) CreateDailyMemo(ctx context.Context, create *DailyMemo) (bool, error) {
+	return s.driver.CreateDailyMemo(ctx, create)
+}
+
+// ListDailyMemos returns daily memos matching the filter criteria.
+func (s *Store) ListDailyMemos(ctx context.Context, find *FindDailyMemo) ([]*DailyMemo, error) {
+	return s.driver.ListDailyMemos(ctx, find)
+}
+
+// GetDailyMemo returns the daily memo of the user for the date, or nil if none found.
+func (s *Store) GetDailyMemo(ctx context.Context, creatorID int32, date string) (*DailyMemo, error) {
+	list, err := s.ListDailyMemos(ctx, &FindDailyMemo{CreatorID: &creatorID, Date: &date})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// DeleteDailyMemo removes the daily memo index entry. The memo itself is kept.
+func (s *Store) DeleteDailyMemo(ctx context.Context, delete *DeleteDailyMemo) error {
+	return s.driver.DeleteDailyMemo(ctx, delete)
+}
@@ -15,8 +15,12 @@ import (
 )
 
 func (d *DB) CreateAttachment(ctx context.Context, create *store.Attachment) (*store.Attachment, error) {
-	fields := []string{"`uid`", "`filename`", "`blob`", "`type`", "`size`", "`creator_id`", "`memo_id`", "`storage_type`", "`reference`", "`payload`"}
-	placeholder := []string{"?", "?", "?", "?", "?", "?", "?", "?", "?", "?"}
+	return createAttachment(ctx, d.db, create)
+}
+
+func createAttachment(ctx context.Context, q attachmentQuerier, create *store.Attachment) (*store.Attachment, error) {
+	fields := []string{"`uid`", "`filename`", "`blob`", "`type`", "`size`", "`creator_id`", "`memo_id`", "`storage_type`", "`reference`", "`payload`", "`sha256`"}
+	placeholder := []string{"?", "?", "?", "?", "?", "?", "?", "?", "?", "?", "?"}
 	storageType := ""
 	if create.StorageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
 		storageType = create.StorageType.String()
@@ -29,10 +33,10 @@ func (d *DB) CreateAttachment(ctx context.Context, create *store.Attachment) (*s
 		}
 		payloadString = string(bytes)
 	}
-	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString}
+	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString, create.SHA256}
 
 	stmt := "INSERT INTO `attachment` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := q.ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -43,15 +47,22 @@ func (d *DB) CreateAttachment(ctx context.Context, create *store.Attachment) (*s
 	}
 
 	id32 := int32(id)
-	return d.GetAttachment(ctx, &store.FindAttachment{ID: &id32})
+	return getAttachment(ctx, q, &store.FindAttachment{ID: &id32})
 }
 
 func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([]*store.Attachment, error) {
+	return listAttachments(ctx, d.db, find)
+}
+
+func listAttachments(ctx context.Context, q attachmentQuerier, find *store.FindAttachment) ([]*store.Attachment, error) {
 	where, args := []string{"1 = 1"}, []any{}
 
 	if v := find.ID; v != nil {
 		where, args = append(where, "`attachment`.`id` = ?"), append(args, *v)
 	}
+	if v := find.IDAfter; v != nil {
+		where, args = append(where, "`attachment`.`id` > ?"), append(args, *v)
+	}
 	if v := find.UID; v != nil {
 		where, args = append(where, "`attachment`.`uid` = ?"), append(args, *v)
 	}
@@ -64,6 +75,9 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 	if v := find.FilenameSearch; v != nil {
 		where, args = append(where, "`attachment`.`filename` LIKE ?"), append(args, "%"+*v+"%")
 	}
+	if v := find.SHA256; v != nil {
+		where, args = append(where, "`attachment`.`sha256` = ?"), append(args, *v)
+	}
 	if v := find.MemoID; v != nil {
 		where, args = append(where, "`attachment`.`memo_id` = ?"), append(args, *v)
 	}
@@ -103,6 +117,7 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 		"`attachment`.`storage_type` AS `storage_type`",
 		"`attachment`.`reference` AS `reference`",
 		"`attachment`.`payload` AS `payload`",
+		"`attachment`.`sha256` AS `sha256`",
 		"CASE WHEN `memo`.`uid` IS NOT NULL THEN `memo`.`uid` ELSE NULL END AS `memo_uid`",
 	}
 	if find.GetBlob {
@@ -111,8 +126,12 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 
 	query := "SELECT " + strings.Join(fields, ", ") + " FROM `attachment`" + " " +
 		"LEFT JOIN `memo` ON `attachment`.`memo_id` = `memo`.`id`" + " " +
-		"WHERE " + strings.Join(where, " AND ") + " " +
-		"ORDER BY `updated_ts` DESC"
+		"WHERE " + strings.Join(where, " AND ") + " "
+	if find.IDAfter != nil {
+		query += "ORDER BY `attachment`.`id` ASC"
+	} else {
+		query += "ORDER BY `updated_ts` DESC"
+	}
 	if find.Limit != nil {
 		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
 		if find.Offset != nil {
@@ -120,7 +139,7 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 		}
 	}
 
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := q.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +164,7 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 			&storageType,
 			&attachment.Reference,
 			&payloadBytes,
+			&attachment.SHA256,
 			&attachment.MemoUID,
 		}
 		if find.GetBlob {
@@ -173,8 +193,8 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 	return list, nil
 }
 
-func (d *DB) GetAttachment(ctx context.Context, find *store.FindAttachment) (*store.Attachment, error) {
-	list, err := d.ListAttachments(ctx, find)
+func getAttachment(ctx context.Context, q attachmentQuerier, find *store.FindAttachment) (*store.Attachment, error) {
+	list, err := listAttachments(ctx, q, find)
 	if err != nil {
 		return nil, err
 	}
@@ -185,7 +205,42 @@ func (d *DB) GetAttachment(ctx context.Context, find *store.FindAttachment) (*st
 	return list[0], nil
 }
 
+func (d *DB) ListAttachmentUsage(ctx context.Context, find *store.FindAttachmentUsage) ([]*store.AttachmentUsage, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.CreatorID; v != nil {
+		where, args = append(where, "`creator_id` = ?"), append(args, *v)
+	}
+	query := "SELECT `creator_id`, COUNT(*), COALESCE(SUM(`size`), 0) FROM `attachment` WHERE " + strings.Join(where, " AND ") +
+		" GROUP BY `creator_id` ORDER BY COALESCE(SUM(`size`), 0) DESC, `creator_id` ASC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.AttachmentUsage, 0)
+	for rows.Next() {
+		usage := &store.AttachmentUsage{}
+		if err := rows.Scan(&usage.CreatorID, &usage.AttachmentCount, &usage.TotalSize); err != nil {
+			return nil, err
+		}
+		list = append(list, usage)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
 func (d *DB) UpdateAttachment(ctx context.Context, update *store.UpdateAttachment) error {
+	return updateAttachment(ctx, d.db, update)
+}
+
+func updateAttachment(ctx context.Context, q attachmentQuerier, update *store.UpdateAttachment) error {
 	set, args := []string{}, []any{}
 
 	if v := update.UID; v != nil {
@@ -207,10 +262,18 @@ func (d *DB) UpdateAttachment(ctx context.Context, update *store.UpdateAttachmen
 		}
 		set, args = append(set, "`payload` = ?"), append(args, string(bytes))
 	}
+	if v := update.Storage; v != nil {
+		storageType := ""
+		if v.Type != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+			storageType = v.Type.String()
+		}
+		set = append(set, "`storage_type` = ?", "`reference` = ?", "`blob` = ?", "`sha256` = ?")
+		args = append(args, storageType, v.Reference, v.Blob, v.SHA256)
+	}
 
 	args = append(args, update.ID)
 	stmt := "UPDATE `attachment` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := q.ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return err
 	}
@@ -220,27 +283,34 @@ func (d *DB) UpdateAttachment(ctx context.Context, update *store.UpdateAttachmen
 	return nil
 }
 
-func (d *DB) DeleteAttachment(ctx context.Context, delete *store.DeleteAttachment) error {
-	stmt := "DELETE FROM `attachment` WHERE `id` = ?"
-	result, err := d.db.ExecContext(ctx, stmt, delete.ID)
-	if err != nil {
-		return err
-	}
-	if _, err := result.RowsAffected(); err != nil {
-		return err
-	}
-
-	return nil
+// LockAttachmentContent runs fn in a transaction that first locks the
+// attachment rows carrying each digest, so reference checks on shared content
+// and the writes depending on them are serialized per digest.
+func (d *DB) LockAttachmentContent(ctx context.Context, digests []string, fn func(store.AttachmentContentTx) error) error {
+	return d.runAttachmentTx(ctx, func(tx *sql.Tx) error {
+		for _, digest := range digests {
+			if _, err := tx.ExecContext(ctx, "SELECT `id` FROM `attachment` WHERE `sha256` = ? FOR UPDATE", digest); err != nil {
+				return errors.Wrap(err, "failed to lock attachment content")
+			}
+		}
+		return nil
+	}, fn)
 }
 
-func (d *DB) DeleteAttachments(ctx context.Context, deletes []*store.DeleteAttachment) error {
-	if len(deletes) == 0 {
+// LockAttachment runs fn in a transaction that first locks the attachment row.
+func (d *DB) LockAttachment(ctx context.Context, id int32, fn func(store.AttachmentContentTx) error) error {
+	return d.runAttachmentTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT `id` FROM `attachment` WHERE `id` = ? FOR UPDATE", id); err != nil {
+			return errors.Wrap(err, "failed to lock attachment")
+		}
 		return nil
-	}
+	}, fn)
+}
 
+func (d *DB) runAttachmentTx(ctx context.Context, lock func(*sql.Tx) error, fn func(store.AttachmentContentTx) error) error {
 	tx, err := d.db.BeginTx(ctx, nil)
 	if err != nil {
-		return errors.Wrap(err, "failed to start attachment delete transaction")
+		return errors.Wrap(err, "failed to start attachment transaction")
 	}
 	defer func() {
 		if tx != nil {
@@ -248,20 +318,49 @@ func (d *DB) DeleteAttachments(ctx context.Context, deletes []*store.DeleteAttac
 		}
 	}()
 
-	stmt := "DELETE FROM `attachment` WHERE `id` = ?"
-	for _, delete := range deletes {
-		result, err := tx.ExecContext(ctx, stmt, delete.ID)
-		if err != nil {
-			return err
-		}
-		if _, err := result.RowsAffected(); err != nil {
-			return err
-		}
+	if err := lock(tx); err != nil {
+		return err
+	}
+	if err := fn(&attachmentContentTx{tx: tx}); err != nil {
+		return err
 	}
-
 	if err := tx.Commit(); err != nil {
 		return err
 	}
 	tx = nil
 	return nil
 }
+
+// attachmentQuerier is satisfied by both *sql.DB and *sql.Tx so attachment
+// statements can run on their own or inside LockAttachmentContent.
+type attachmentQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type attachmentContentTx struct {
+	tx *sql.Tx
+}
+
+func (t *attachmentContentTx) CreateAttachment(ctx context.Context, create *store.Attachment) (*store.Attachment, error) {
+	return createAttachment(ctx, t.tx, create)
+}
+
+func (t *attachmentContentTx) ListAttachments(ctx context.Context, find *store.FindAttachment) ([]*store.Attachment, error) {
+	return listAttachments(ctx, t.tx, find)
+}
+
+func (t *attachmentContentTx) UpdateAttachment(ctx context.Context, update *store.UpdateAttachment) error {
+	return updateAttachment(ctx, t.tx, update)
+}
+
+func (t *attachmentContentTx) DeleteAttachments(ctx context.Context, deletes []*store.DeleteAttachment) error {
+	stmt := "DELETE FROM `attachment` WHERE `id` = ?"
+	for _, delete := range deletes {
+		if _, err := t.tx.ExecContext(ctx, stmt, delete.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
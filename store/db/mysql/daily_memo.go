@@ -0,0 +1,86 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateDailyMemo(ctx context.Context, create *store.DailyMemo) (bool, error) {
+	stmt := "INSERT IGNORE INTO `daily_memo` (`creator_id`, `date`, `memo_id`) VALUES (?, ?, ?)"
+	result, err := d.db.ExecContext(ctx, stmt, create.CreatorID, create.Date, create.MemoID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	list, err := d.ListDailyMemos(ctx, &store.FindDailyMemo{CreatorID: &create.CreatorID, Date: &create.Date})
+	if err != nil {
+		return false, err
+	}
+	if len(list) == 0 {
+		return false, errors.Errorf("failed to create daily memo")
+	}
+	create.CreatedTs = list[0].CreatedTs
+	return true, nil
+}
+
+func (d *DB) ListDailyMemos(ctx context.Context, find *store.FindDailyMemo) ([]*store.DailyMemo, error) {
+	where, args := []string{"1 = 1"}, []any{}
+
+	if find.CreatorID != nil {
+		where, args = append(where, "`creator_id` = ?"), append(args, *find.CreatorID)
+	}
+	if find.Date != nil {
+		where, args = append(where, "`date` = ?"), append(args, *find.Date)
+	}
+	if find.MemoID != nil {
+		where, args = append(where, "`memo_id` = ?"), append(args, *find.MemoID)
+	}
+	if find.StartDate != nil {
+		where, args = append(where, "`date` >= ?"), append(args, *find.StartDate)
+	}
+	if find.EndDate != nil {
+		where, args = append(where, "`date` <= ?"), append(args, *find.EndDate)
+	}
+
+	query := "SELECT `creator_id`, `date`, `memo_id`, `created_ts` FROM `daily_memo` WHERE " + strings.Join(where, " AND ") + " ORDER BY `date` ASC, `creator_id` ASC"
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.DailyMemo{}
+	for rows.Next() {
+		dailyMemo := &store.DailyMemo{}
+		if err := rows.Scan(
+			&dailyMemo.CreatorID,
+			&dailyMemo.Date,
+			&dailyMemo.MemoID,
+			&dailyMemo.CreatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, dailyMemo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) DeleteDailyMemo(ctx context.Context, delete *store.DeleteDailyMemo) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `daily_memo` WHERE `creator_id` = ? AND `date` = ?", delete.CreatorID, delete.Date)
+	return err
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+// ApplyTagMutation atomically rewrites memo tags and replaces user tag settings.
+func (d *DB) ApplyTagMutation(ctx context.Context, rewrites []*store.MemoTagRewrite, settings []*store.UserSetting) error {
+	tx, err := d.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return errors.Wrap(err, "failed to begin tag transaction")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, rewrite := range rewrites {
+		var content string
+		if err := tx.QueryRowContext(ctx, "SELECT `content` FROM `memo` WHERE `id` = ? FOR UPDATE", rewrite.MemoID).Scan(&content); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.Wrap(store.ErrMemoMutationConflict, "memo no longer exists")
+			}
+			return errors.Wrap(err, "failed to lock memo")
+		}
+		if content != rewrite.ExpectedContent {
+			return errors.Wrap(store.ErrMemoMutationConflict, "memo changed while rewriting tags")
+		}
+		if err := applyMemoUpdate(ctx, tx, &store.UpdateMemo{
+			ID:      rewrite.MemoID,
+			Content: &rewrite.Content,
+			Payload: rewrite.Payload,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, setting := range settings {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO `user_setting` (`user_id`, `key`, `value`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `value` = ?",
+			setting.UserID, setting.Key.String(), setting.Value, setting.Value); err != nil {
+			return errors.Wrap(err, "failed to update tag setting")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit tag transaction")
+	}
+	return nil
+}
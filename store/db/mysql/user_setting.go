@@ -18,6 +18,26 @@ func (d *DB) UpsertUserSetting(ctx context.Context, upsert *store.UserSetting) (
 	return upsert, nil
 }
 
+// SwapUserSetting reports a swap that leaves the value unchanged as not
+// stored, since MySQL counts changed rows only.
+func (d *DB) SwapUserSetting(ctx context.Context, swap *store.UserSetting, oldValue *string) (bool, error) {
+	stmt := "INSERT IGNORE INTO `user_setting` (`user_id`, `key`, `value`) VALUES (?, ?, ?)"
+	args := []any{swap.UserID, swap.Key.String(), swap.Value}
+	if oldValue != nil {
+		stmt = "UPDATE `user_setting` SET `value` = ? WHERE `user_id` = ? AND `key` = ? AND `value` = ?"
+		args = []any{swap.Value, swap.UserID, swap.Key.String(), *oldValue}
+	}
+	result, err := d.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
 func (d *DB) ListUserSettings(ctx context.Context, find *store.FindUserSetting) ([]*store.UserSetting, error) {
 	where, args := []string{"1 = 1"}, []any{}
 
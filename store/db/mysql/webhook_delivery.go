@@ -0,0 +1,155 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateWebhookDelivery(ctx context.Context, create *store.WebhookDelivery) (*store.WebhookDelivery, error) {
+	fields := []string{"`uid`", "`creator_id`", "`webhook_id`", "`activity_type`", "`payload`", "`status`", "`next_attempt_ts`"}
+	placeholder := []string{"?", "?", "?", "?", "?", "?", "?"}
+	args := []any{create.UID, create.CreatorID, create.WebhookID, create.ActivityType, create.Payload, create.Status.String(), create.NextAttemptTs}
+
+	fields = append(fields, "`response_excerpt`", "`last_error`")
+	placeholder = append(placeholder, "?", "?")
+	args = append(args, create.ResponseExcerpt, create.LastError)
+
+	stmt := "INSERT INTO `webhook_delivery` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
+	result, err := d.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	rawID, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	id := int32(rawID)
+	list, err := d.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{ID: &id})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to create webhook delivery")
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListWebhookDeliveries(ctx context.Context, find *store.FindWebhookDelivery) ([]*store.WebhookDelivery, error) {
+	where, args := []string{"1 = 1"}, []any{}
+
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.UID != nil {
+		where, args = append(where, "`uid` = ?"), append(args, *find.UID)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "`creator_id` = ?"), append(args, *find.CreatorID)
+	}
+	if find.WebhookID != nil {
+		where, args = append(where, "`webhook_id` = ?"), append(args, *find.WebhookID)
+	}
+	if find.Status != nil {
+		where, args = append(where, "`status` = ?"), append(args, find.Status.String())
+	}
+	if find.NextAttemptBefore != nil {
+		where, args = append(where, "`next_attempt_ts` <= ?"), append(args, *find.NextAttemptBefore)
+	}
+
+	orderBy := "`id` DESC"
+	if find.OrderByNextAttempt {
+		orderBy = "`next_attempt_ts` ASC, `id` ASC"
+	}
+	query := "SELECT `id`, `uid`, `creator_id`, `webhook_id`, `activity_type`, `payload`, `status`, `attempts`, `next_attempt_ts`, `response_status_code`, `latency_ms`, `response_excerpt`, `last_error`, `created_ts`, `updated_ts` FROM `webhook_delivery` WHERE " + strings.Join(where, " AND ") + " ORDER BY " + orderBy
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+		if find.Offset != nil {
+			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
+		}
+	}
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.WebhookDelivery{}
+	for rows.Next() {
+		delivery := &store.WebhookDelivery{}
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.UID,
+			&delivery.CreatorID,
+			&delivery.WebhookID,
+			&delivery.ActivityType,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptTs,
+			&delivery.ResponseStatusCode,
+			&delivery.LatencyMs,
+			&delivery.ResponseExcerpt,
+			&delivery.LastError,
+			&delivery.CreatedTs,
+			&delivery.UpdatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) UpdateWebhookDelivery(ctx context.Context, update *store.UpdateWebhookDelivery) error {
+	set, args := []string{"`updated_ts` = UNIX_TIMESTAMP()"}, []any{}
+	if update.Status != nil {
+		set, args = append(set, "`status` = ?"), append(args, update.Status.String())
+	}
+	if update.Attempts != nil {
+		set, args = append(set, "`attempts` = ?"), append(args, *update.Attempts)
+	}
+	if update.NextAttemptTs != nil {
+		set, args = append(set, "`next_attempt_ts` = ?"), append(args, *update.NextAttemptTs)
+	}
+	if update.ResponseStatusCode != nil {
+		set, args = append(set, "`response_status_code` = ?"), append(args, *update.ResponseStatusCode)
+	}
+	if update.LatencyMs != nil {
+		set, args = append(set, "`latency_ms` = ?"), append(args, *update.LatencyMs)
+	}
+	if update.ResponseExcerpt != nil {
+		set, args = append(set, "`response_excerpt` = ?"), append(args, *update.ResponseExcerpt)
+	}
+	if update.LastError != nil {
+		set, args = append(set, "`last_error` = ?"), append(args, *update.LastError)
+	}
+	args = append(args, update.ID)
+
+	_, err := d.db.ExecContext(ctx, "UPDATE `webhook_delivery` SET "+strings.Join(set, ", ")+" WHERE `id` = ?", args...)
+	return err
+}
+
+func (d *DB) DeleteWebhookDeliveries(ctx context.Context, delete *store.DeleteWebhookDelivery) error {
+	where, args := []string{"1 = 1"}, []any{}
+	if delete.CreatorID != nil {
+		where, args = append(where, "`creator_id` = ?"), append(args, *delete.CreatorID)
+	}
+	if delete.WebhookID != nil {
+		where, args = append(where, "`webhook_id` = ?"), append(args, *delete.WebhookID)
+	}
+	if delete.CreatedBefore != nil {
+		where, args = append(where, "`created_ts` < ?"), append(args, *delete.CreatedBefore)
+	}
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `webhook_delivery` WHERE "+strings.Join(where, " AND "), args...)
+	return err
+}
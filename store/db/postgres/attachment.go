@@ -15,7 +15,11 @@ import (
 )
 
 func (d *DB) CreateAttachment(ctx context.Context, create *store.Attachment) (*store.Attachment, error) {
-	fields := []string{"uid", "filename", "blob", "type", "size", "creator_id", "memo_id", "storage_type", "reference", "payload"}
+	return createAttachment(ctx, d.db, create)
+}
+
+func createAttachment(ctx context.Context, q attachmentQuerier, create *store.Attachment) (*store.Attachment, error) {
+	fields := []string{"uid", "filename", "blob", "type", "size", "creator_id", "memo_id", "storage_type", "reference", "payload", "sha256"}
 	storageType := ""
 	if create.StorageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
 		storageType = create.StorageType.String()
@@ -28,21 +32,28 @@ func (d *DB) CreateAttachment(ctx context.Context, create *store.Attachment) (*s
 		}
 		payloadString = string(bytes)
 	}
-	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString}
+	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString, create.SHA256}
 
 	stmt := "INSERT INTO attachment (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(&create.ID, &create.CreatedTs, &create.UpdatedTs); err != nil {
+	if err := q.QueryRowContext(ctx, stmt, args...).Scan(&create.ID, &create.CreatedTs, &create.UpdatedTs); err != nil {
 		return nil, err
 	}
 	return create, nil
 }
 
 func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([]*store.Attachment, error) {
+	return listAttachments(ctx, d.db, find)
+}
+
+func listAttachments(ctx context.Context, q attachmentQuerier, find *store.FindAttachment) ([]*store.Attachment, error) {
 	where, args := []string{"1 = 1"}, []any{}
 
 	if v := find.ID; v != nil {
 		where, args = append(where, "attachment.id = "+placeholder(len(args)+1)), append(args, *v)
 	}
+	if v := find.IDAfter; v != nil {
+		where, args = append(where, "attachment.id > "+placeholder(len(args)+1)), append(args, *v)
+	}
 	if v := find.UID; v != nil {
 		where, args = append(where, "attachment.uid = "+placeholder(len(args)+1)), append(args, *v)
 	}
@@ -55,6 +66,9 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 	if v := find.FilenameSearch; v != nil {
 		where, args = append(where, "attachment.filename LIKE "+placeholder(len(args)+1)), append(args, fmt.Sprintf("%%%s%%", *v))
 	}
+	if v := find.SHA256; v != nil {
+		where, args = append(where, "attachment.sha256 = "+placeholder(len(args)+1)), append(args, *v)
+	}
 	if v := find.MemoID; v != nil {
 		where, args = append(where, "attachment.memo_id = "+placeholder(len(args)+1)), append(args, *v)
 	}
@@ -92,20 +106,25 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 		"attachment.storage_type AS storage_type",
 		"attachment.reference AS reference",
 		"attachment.payload AS payload",
+		"attachment.sha256 AS sha256",
 		"CASE WHEN memo.uid IS NOT NULL THEN memo.uid ELSE NULL END AS memo_uid",
 	}
 	if find.GetBlob {
 		fields = append(fields, "attachment.blob AS blob")
 	}
 
+	orderBy := "attachment.updated_ts DESC"
+	if find.IDAfter != nil {
+		orderBy = "attachment.id ASC"
+	}
 	query := fmt.Sprintf(`
 		SELECT
 			%s
 		FROM attachment
 		LEFT JOIN memo ON attachment.memo_id = memo.id
 		WHERE %s
-		ORDER BY attachment.updated_ts DESC
-	`, strings.Join(fields, ", "), strings.Join(where, " AND "))
+		ORDER BY %s
+	`, strings.Join(fields, ", "), strings.Join(where, " AND "), orderBy)
 	if find.Limit != nil {
 		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
 		if find.Offset != nil {
@@ -113,7 +132,7 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 		}
 	}
 
-	rows, err := d.db.QueryContext(ctx, query, args...)
+	rows, err := q.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +157,7 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 			&storageType,
 			&attachment.Reference,
 			&payloadBytes,
+			&attachment.SHA256,
 			&attachment.MemoUID,
 		}
 		if find.GetBlob {
@@ -166,7 +186,42 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 	return list, nil
 }
 
+func (d *DB) ListAttachmentUsage(ctx context.Context, find *store.FindAttachmentUsage) ([]*store.AttachmentUsage, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.CreatorID; v != nil {
+		where, args = append(where, "creator_id = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	query := "SELECT creator_id, COUNT(*), COALESCE(SUM(size), 0) FROM attachment WHERE " + strings.Join(where, " AND ") +
+		" GROUP BY creator_id ORDER BY COALESCE(SUM(size), 0) DESC, creator_id ASC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.AttachmentUsage, 0)
+	for rows.Next() {
+		usage := &store.AttachmentUsage{}
+		if err := rows.Scan(&usage.CreatorID, &usage.AttachmentCount, &usage.TotalSize); err != nil {
+			return nil, err
+		}
+		list = append(list, usage)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
 func (d *DB) UpdateAttachment(ctx context.Context, update *store.UpdateAttachment) error {
+	return updateAttachment(ctx, d.db, update)
+}
+
+func updateAttachment(ctx context.Context, q attachmentQuerier, update *store.UpdateAttachment) error {
 	set, args := []string{}, []any{}
 
 	if v := update.UID; v != nil {
@@ -188,10 +243,18 @@ func (d *DB) UpdateAttachment(ctx context.Context, update *store.UpdateAttachmen
 		}
 		set, args = append(set, "payload = "+placeholder(len(args)+1)), append(args, string(bytes))
 	}
+	if v := update.Storage; v != nil {
+		storageType := ""
+		if v.Type != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+			storageType = v.Type.String()
+		}
+		set = append(set, "storage_type = "+placeholder(len(args)+1), "reference = "+placeholder(len(args)+2), "blob = "+placeholder(len(args)+3), "sha256 = "+placeholder(len(args)+4))
+		args = append(args, storageType, v.Reference, v.Blob, v.SHA256)
+	}
 
 	stmt := `UPDATE attachment SET ` + strings.Join(set, ", ") + ` WHERE id = ` + placeholder(len(args)+1)
 	args = append(args, update.ID)
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := q.ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return err
 	}
@@ -201,26 +264,34 @@ func (d *DB) UpdateAttachment(ctx context.Context, update *store.UpdateAttachmen
 	return nil
 }
 
-func (d *DB) DeleteAttachment(ctx context.Context, delete *store.DeleteAttachment) error {
-	stmt := `DELETE FROM attachment WHERE id = $1`
-	result, err := d.db.ExecContext(ctx, stmt, delete.ID)
-	if err != nil {
-		return err
-	}
-	if _, err := result.RowsAffected(); err != nil {
-		return err
-	}
-	return nil
+// LockAttachmentContent runs fn in a transaction that first locks the
+// attachment rows carrying each digest, so reference checks on shared content
+// and the writes depending on them are serialized per digest.
+func (d *DB) LockAttachmentContent(ctx context.Context, digests []string, fn func(store.AttachmentContentTx) error) error {
+	return d.runAttachmentTx(ctx, func(tx *sql.Tx) error {
+		for _, digest := range digests {
+			if _, err := tx.ExecContext(ctx, "SELECT id FROM attachment WHERE sha256 = "+placeholder(1)+" FOR UPDATE", digest); err != nil {
+				return errors.Wrap(err, "failed to lock attachment content")
+			}
+		}
+		return nil
+	}, fn)
 }
 
-func (d *DB) DeleteAttachments(ctx context.Context, deletes []*store.DeleteAttachment) error {
-	if len(deletes) == 0 {
+// LockAttachment runs fn in a transaction that first locks the attachment row.
+func (d *DB) LockAttachment(ctx context.Context, id int32, fn func(store.AttachmentContentTx) error) error {
+	return d.runAttachmentTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT id FROM attachment WHERE id = "+placeholder(1)+" FOR UPDATE", id); err != nil {
+			return errors.Wrap(err, "failed to lock attachment")
+		}
 		return nil
-	}
+	}, fn)
+}
 
+func (d *DB) runAttachmentTx(ctx context.Context, lock func(*sql.Tx) error, fn func(store.AttachmentContentTx) error) error {
 	tx, err := d.db.BeginTx(ctx, nil)
 	if err != nil {
-		return errors.Wrap(err, "failed to start attachment delete transaction")
+		return errors.Wrap(err, "failed to start attachment transaction")
 	}
 	defer func() {
 		if tx != nil {
@@ -228,20 +299,49 @@ func (d *DB) DeleteAttachments(ctx context.Context, deletes []*store.DeleteAttac
 		}
 	}()
 
-	stmt := `DELETE FROM attachment WHERE id = $1`
-	for _, delete := range deletes {
-		result, err := tx.ExecContext(ctx, stmt, delete.ID)
-		if err != nil {
-			return err
-		}
-		if _, err := result.RowsAffected(); err != nil {
-			return err
-		}
+	if err := lock(tx); err != nil {
+		return err
+	}
+	if err := fn(&attachmentContentTx{tx: tx}); err != nil {
+		return err
 	}
-
 	if err := tx.Commit(); err != nil {
 		return err
 	}
 	tx = nil
 	return nil
 }
+
+// attachmentQuerier is satisfied by both *sql.DB and *sql.Tx so attachment
+// statements can run on their own or inside LockAttachmentContent.
+type attachmentQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type attachmentContentTx struct {
+	tx *sql.Tx
+}
+
+func (t *attachmentContentTx) CreateAttachment(ctx context.Context, create *store.Attachment) (*store.Attachment, error) {
+	return createAttachment(ctx, t.tx, create)
+}
+
+func (t *attachmentContentTx) ListAttachments(ctx context.Context, find *store.FindAttachment) ([]*store.Attachment, error) {
+	return listAttachments(ctx, t.tx, find)
+}
+
+func (t *attachmentContentTx) UpdateAttachment(ctx context.Context, update *store.UpdateAttachment) error {
+	return updateAttachment(ctx, t.tx, update)
+}
+
+func (t *attachmentContentTx) DeleteAttachments(ctx context.Context, deletes []*store.DeleteAttachment) error {
+	stmt := `DELETE FROM attachment WHERE id = $1`
+	for _, delete := range deletes {
+		if _, err := t.tx.ExecContext(ctx, stmt, delete.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
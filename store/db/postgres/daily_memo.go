@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateDailyMemo(ctx context.Context, create *store.DailyMemo) (bool, error) {
+	stmt := "INSERT INTO daily_memo (creator_id, date, memo_id) VALUES (" + placeholders(3) + ") " +
+		"ON CONFLICT (creator_id, date) DO NOTHING RETURNING created_ts"
+	if err := d.db.QueryRowContext(ctx, stmt, create.CreatorID, create.Date, create.MemoID).Scan(&create.CreatedTs); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (d *DB) ListDailyMemos(ctx context.Context, find *store.FindDailyMemo) ([]*store.DailyMemo, error) {
+	where, args := []string{"1 = 1"}, []any{}
+
+	if find.CreatorID != nil {
+		where, args = append(where, "creator_id = "+placeholder(len(args)+1)), append(args, *find.CreatorID)
+	}
+	if find.Date != nil {
+		where, args = append(where, "date = "+placeholder(len(args)+1)), append(args, *find.Date)
+	}
+	if find.MemoID != nil {
+		where, args = append(where, "memo_id = "+placeholder(len(args)+1)), append(args, *find.MemoID)
+	}
+	if find.StartDate != nil {
+		where, args = append(where, "date >= "+placeholder(len(args)+1)), append(args, *find.StartDate)
+	}
+	if find.EndDate != nil {
+		where, args = append(where, "date <= "+placeholder(len(args)+1)), append(args, *find.EndDate)
+	}
+
+	query := "SELECT creator_id, date, memo_id, created_ts FROM daily_memo WHERE " + strings.Join(where, " AND ") + " ORDER BY date ASC, creator_id ASC"
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.DailyMemo{}
+	for rows.Next() {
+		dailyMemo := &store.DailyMemo{}
+		if err := rows.Scan(
+			&dailyMemo.CreatorID,
+			&dailyMemo.Date,
+			&dailyMemo.MemoID,
+			&dailyMemo.CreatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, dailyMemo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) DeleteDailyMemo(ctx context.Context, delete *store.DeleteDailyMemo) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM daily_memo WHERE creator_id = "+placeholder(1)+" AND date = "+placeholder(2), delete.CreatorID, delete.Date)
+	return err
+}
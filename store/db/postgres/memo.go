@@ -218,6 +218,9 @@ func (d *DB) DeleteMemo(ctx context.Context, delete *store.DeleteMemo) error {
 	if _, err := tx.ExecContext(ctx, "DELETE FROM reaction WHERE memo_id = "+placeholder(1), delete.ID); err != nil {
 		return errors.Wrap(err, "failed to delete memo reactions")
 	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM daily_memo WHERE memo_id = "+placeholder(1), delete.ID); err != nil {
+		return errors.Wrap(err, "failed to delete daily memo")
+	}
 	if err := tx.Commit(); err != nil {
 		return errors.Wrap(err, "failed to commit memo delete transaction")
 	}
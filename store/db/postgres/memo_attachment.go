@@ -94,11 +94,11 @@ func (d *DB) ApplyMemoMutation(ctx context.Context, mutation *store.MemoMutation
 }
 
 func replaceMemoReferenceRelations(ctx context.Context, tx *sql.Tx, memoID int32, relations []*store.MemoRelation) error {
-	if _, err := tx.ExecContext(ctx, `DELETE FROM memo_relation WHERE memo_id = $1 AND type = $2`, memoID, store.MemoRelationReference); err != nil {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM memo_relation WHERE memo_id = $1 AND type IN ($2, $3)`, memoID, store.MemoRelationReference, store.MemoRelationEmbed); err != nil {
 		return errors.Wrap(err, "failed to delete memo reference relations")
 	}
 	for _, relation := range relations {
-		if relation == nil || relation.MemoID != memoID || relation.Type != store.MemoRelationReference {
+		if relation == nil || relation.MemoID != memoID || (relation.Type != store.MemoRelationReference && relation.Type != store.MemoRelationEmbed) {
 			return errors.New("invalid memo reference relation mutation")
 		}
 		if _, err := tx.ExecContext(ctx, `
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateUploadSession(ctx context.Context, create *store.UploadSession) (*store.UploadSession, error) {
+	payloadString := "{}"
+	if create.Payload != nil {
+		bytes, err := protojson.Marshal(create.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal upload session payload")
+		}
+		payloadString = string(bytes)
+	}
+	fields := []string{"uid", "creator_id", "filename", "type", "size", "received_size", "payload", "expires_ts"}
+	args := []any{create.UID, create.CreatorID, create.Filename, create.Type, create.Size, create.ReceivedSize, payloadString, create.ExpiresTs}
+
+	stmt := "INSERT INTO upload_session (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
+	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+		&create.UpdatedTs,
+	); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListUploadSessions(ctx context.Context, find *store.FindUploadSession) ([]*store.UploadSession, error) {
+	where, args := []string{"1 = 1"}, []any{}
+
+	if find.ID != nil {
+		where, args = append(where, "id = "+placeholder(len(args)+1)), append(args, *find.ID)
+	}
+	if find.UID != nil {
+		where, args = append(where, "uid = "+placeholder(len(args)+1)), append(args, *find.UID)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "creator_id = "+placeholder(len(args)+1)), append(args, *find.CreatorID)
+	}
+	if find.ExpiresBefore != nil {
+		where, args = append(where, "expires_ts < "+placeholder(len(args)+1)), append(args, *find.ExpiresBefore)
+	}
+
+	query := "SELECT id, uid, creator_id, filename, type, size, received_size, payload, expires_ts, created_ts, updated_ts FROM upload_session WHERE " + strings.Join(where, " AND ") + " ORDER BY id ASC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+	}
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.UploadSession{}
+	for rows.Next() {
+		session := &store.UploadSession{}
+		var payloadBytes []byte
+		if err := rows.Scan(
+			&session.ID,
+			&session.UID,
+			&session.CreatorID,
+			&session.Filename,
+			&session.Type,
+			&session.Size,
+			&session.ReceivedSize,
+			&payloadBytes,
+			&session.ExpiresTs,
+			&session.CreatedTs,
+			&session.UpdatedTs,
+		); err != nil {
+			return nil, err
+		}
+		payload := &storepb.UploadSessionPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, err
+		}
+		session.Payload = payload
+		list = append(list, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) UpdateUploadSession(ctx context.Context, update *store.UpdateUploadSession) error {
+	set, args := []string{"updated_ts = EXTRACT(EPOCH FROM NOW())"}, []any{}
+	if update.ReceivedSize != nil {
+		set, args = append(set, "received_size = "+placeholder(len(args)+1)), append(args, *update.ReceivedSize)
+	}
+	if update.Payload != nil {
+		bytes, err := protojson.Marshal(update.Payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal upload session payload")
+		}
+		set, args = append(set, "payload = "+placeholder(len(args)+1)), append(args, string(bytes))
+	}
+	if update.ExpiresTs != nil {
+		set, args = append(set, "expires_ts = "+placeholder(len(args)+1)), append(args, *update.ExpiresTs)
+	}
+	args = append(args, update.ID)
+
+	_, err := d.db.ExecContext(ctx, "UPDATE upload_session SET "+strings.Join(set, ", ")+" WHERE id = "+placeholder(len(args)), args...)
+	return err
+}
+
+func (d *DB) DeleteUploadSession(ctx context.Context, delete *store.DeleteUploadSession) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM upload_session WHERE id = "+placeholder(1), delete.ID)
+	return err
+}
@@ -25,6 +25,30 @@ func (d *DB) UpsertUserSetting(ctx context.Context, upsert *store.UserSetting) (
 	return upsert, nil
 }
 
+func (d *DB) SwapUserSetting(ctx context.Context, swap *store.UserSetting, oldValue *string) (bool, error) {
+	stmt := `
+		INSERT INTO user_setting (
+			user_id, key, value
+		)
+		VALUES ($1, $2, $3)
+		ON CONFLICT(user_id, key) DO NOTHING
+	`
+	args := []any{swap.UserID, swap.Key.String(), swap.Value}
+	if oldValue != nil {
+		stmt = "UPDATE user_setting SET value = $1 WHERE user_id = $2 AND key = $3 AND value = $4"
+		args = []any{swap.Value, swap.UserID, swap.Key.String(), *oldValue}
+	}
+	result, err := d.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
 func (d *DB) ListUserSettings(ctx context.Context, find *store.FindUserSetting) ([]*store.UserSetting, error) {
 	where, args := []string{"1 = 1"}, []any{}
 
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertAttachmentVariant(ctx context.Context, upsert *store.AttachmentVariant) (*store.AttachmentVariant, error) {
+	stmt := "INSERT INTO `attachment_variant` (`attachment_id`, `name`, `type`, `size`, `reference`) VALUES (?, ?, ?, ?, ?) " +
+		"ON CONFLICT(`attachment_id`, `name`) DO UPDATE SET `type` = excluded.`type`, `size` = excluded.`size`, `reference` = excluded.`reference`, `accessed_ts` = strftime('%s', 'now') " +
+		"RETURNING `id`, `created_ts`, `accessed_ts`"
+	if err := d.db.QueryRowContext(ctx, stmt, upsert.AttachmentID, upsert.Name, upsert.Type, upsert.Size, upsert.Reference).Scan(
+		&upsert.ID,
+		&upsert.CreatedTs,
+		&upsert.AccessedTs,
+	); err != nil {
+		return nil, err
+	}
+	return upsert, nil
+}
+
+func (d *DB) ListAttachmentVariants(ctx context.Context, find *store.FindAttachmentVariant) ([]*store.AttachmentVariant, error) {
+	where, args := []string{"1 = 1"}, []any{}
+
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.AttachmentID != nil {
+		where, args = append(where, "`attachment_id` = ?"), append(args, *find.AttachmentID)
+	}
+	if find.Name != nil {
+		where, args = append(where, "`name` = ?"), append(args, *find.Name)
+	}
+	if find.AccessedBefore != nil {
+		where, args = append(where, "`accessed_ts` < ?"), append(args, *find.AccessedBefore)
+	}
+
+	query := "SELECT `id`, `attachment_id`, `name`, `type`, `size`, `reference`, `created_ts`, `accessed_ts` FROM `attachment_variant` WHERE " + strings.Join(where, " AND ") + " ORDER BY `accessed_ts` DESC, `id` DESC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+	}
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.AttachmentVariant{}
+	for rows.Next() {
+		variant := &store.AttachmentVariant{}
+		if err := rows.Scan(
+			&variant.ID,
+			&variant.AttachmentID,
+			&variant.Name,
+			&variant.Type,
+			&variant.Size,
+			&variant.Reference,
+			&variant.CreatedTs,
+			&variant.AccessedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, variant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) UpdateAttachmentVariant(ctx context.Context, update *store.UpdateAttachmentVariant) error {
+	set, args := []string{}, []any{}
+	if update.AccessedTs != nil {
+		set, args = append(set, "`accessed_ts` = ?"), append(args, *update.AccessedTs)
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	args = append(args, update.ID)
+
+	_, err := d.db.ExecContext(ctx, "UPDATE `attachment_variant` SET "+strings.Join(set, ", ")+" WHERE `id` = ?", args...)
+	return err
+}
+
+func (d *DB) DeleteAttachmentVariant(ctx context.Context, delete *store.DeleteAttachmentVariant) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `attachment_variant` WHERE `id` = ?", delete.ID)
+	return err
+}
@@ -103,11 +103,11 @@ func (d *DB) ApplyMemoMutation(ctx context.Context, mutation *store.MemoMutation
 }
 
 func replaceMemoReferenceRelations(ctx context.Context, executor memoUpdateExecer, memoID int32, relations []*store.MemoRelation) error {
-	if _, err := executor.ExecContext(ctx, `DELETE FROM memo_relation WHERE memo_id = ? AND type = ?`, memoID, store.MemoRelationReference); err != nil {
+	if _, err := executor.ExecContext(ctx, `DELETE FROM memo_relation WHERE memo_id = ? AND type IN (?, ?)`, memoID, store.MemoRelationReference, store.MemoRelationEmbed); err != nil {
 		return errors.Wrap(err, "failed to delete memo reference relations")
 	}
 	for _, relation := range relations {
-		if relation == nil || relation.MemoID != memoID || relation.Type != store.MemoRelationReference {
+		if relation == nil || relation.MemoID != memoID || (relation.Type != store.MemoRelationReference && relation.Type != store.MemoRelationEmbed) {
 			return errors.New("invalid memo reference relation mutation")
 		}
 		if _, err := executor.ExecContext(ctx, `
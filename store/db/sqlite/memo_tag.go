@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+// ApplyTagMutation atomically rewrites memo tags and replaces user tag settings.
+func (d *DB) ApplyTagMutation(ctx context.Context, rewrites []*store.MemoTagRewrite, settings []*store.UserSetting) error {
+	// BEGIN IMMEDIATE avoids SQLITE_BUSY on deferred write upgrades (issue #6186).
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get database connection")
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return errors.Wrap(err, "failed to begin tag transaction")
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK")
+		}
+	}()
+
+	for _, rewrite := range rewrites {
+		var content string
+		if err := conn.QueryRowContext(ctx, `SELECT content FROM memo WHERE id = ?`, rewrite.MemoID).Scan(&content); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.Wrap(store.ErrMemoMutationConflict, "memo no longer exists")
+			}
+			return errors.Wrap(err, "failed to lock memo")
+		}
+		if content != rewrite.ExpectedContent {
+			return errors.Wrap(store.ErrMemoMutationConflict, "memo changed while rewriting tags")
+		}
+		if err := applyMemoUpdate(ctx, conn, &store.UpdateMemo{
+			ID:      rewrite.MemoID,
+			Content: &rewrite.Content,
+			Payload: rewrite.Payload,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, setting := range settings {
+		if _, err := conn.ExecContext(ctx, `
+			INSERT INTO user_setting (user_id, key, value)
+			VALUES (?, ?, ?)
+			ON CONFLICT(user_id, key) DO UPDATE SET value = EXCLUDED.value
+		`, setting.UserID, setting.Key.String(), setting.Value); err != nil {
+			return errors.Wrap(err, "failed to update tag setting")
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return errors.Wrap(err, "failed to commit tag transaction")
+	}
+	committed = true
+	return nil
+}
@@ -19,6 +19,7 @@ type deleteUserTargetSet struct {
 	attachmentIDs   []int32
 	userSettingKeys []storepb.UserSetting_Key
 	inboxIDs        []int32
+	uploadSessions  []*store.UploadSession
 }
 
 func (d *DB) DeleteUser(ctx context.Context, delete *store.DeleteUser) (*store.DeleteUserResult, error) {
@@ -50,6 +51,7 @@ func (d *DB) DeleteUser(ctx context.Context, delete *store.DeleteUser) (*store.D
 	return &store.DeleteUserResult{
 		Attachments:     targets.attachments,
 		UserSettingKeys: targets.userSettingKeys,
+		UploadSessions:  targets.uploadSessions,
 	}, nil
 }
 
@@ -81,6 +83,12 @@ func collectDeleteUserTargets(ctx context.Context, tx *sql.Tx, userID int32) (*d
 	}
 	targets.inboxIDs = inboxIDs
 
+	uploadSessions, err := listDeleteUserUploadSessions(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	targets.uploadSessions = uploadSessions
+
 	return targets, nil
 }
 
@@ -111,6 +119,15 @@ func deleteUserTargetsTx(ctx context.Context, tx *sql.Tx, userID int32, targets
 	if err := deleteUserIdentitiesTx(ctx, tx, userID); err != nil {
 		return err
 	}
+	if err := deleteWebhookDeliveriesTx(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err := deleteDailyMemosTx(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err := deleteUploadSessionsTx(ctx, tx, userID); err != nil {
+		return err
+	}
 	if err := deleteUserSettingsTx(ctx, tx, userID); err != nil {
 		return err
 	}
@@ -173,7 +190,8 @@ func listDeleteUserAttachments(ctx context.Context, tx *sql.Tx, userID int32, me
 			memo_id,
 			storage_type,
 			reference,
-			payload
+			payload,
+			sha256
 		FROM attachment
 		WHERE creator_id = `+deleteUserPlaceholder(1), []any{userID}, seen, &attachments); err != nil {
 		return nil, err
@@ -189,7 +207,8 @@ func listDeleteUserAttachments(ctx context.Context, tx *sql.Tx, userID int32, me
 				memo_id,
 				storage_type,
 				reference,
-				payload
+				payload,
+				sha256
 			FROM attachment
 			WHERE memo_id IN `+clause, args, seen, &attachments); err != nil {
 			return nil, err
@@ -211,7 +230,7 @@ func appendDeleteUserAttachments(ctx context.Context, tx *sql.Tx, query string,
 		var memoID sql.NullInt32
 		var storageType string
 		var payloadBytes []byte
-		if err := rows.Scan(&attachment.ID, &attachment.UID, &attachment.CreatorID, &memoID, &storageType, &attachment.Reference, &payloadBytes); err != nil {
+		if err := rows.Scan(&attachment.ID, &attachment.UID, &attachment.CreatorID, &memoID, &storageType, &attachment.Reference, &payloadBytes, &attachment.SHA256); err != nil {
 			return err
 		}
 		if _, exists := seen[attachment.ID]; exists {
@@ -427,6 +446,50 @@ func deleteUserIdentitiesTx(ctx context.Context, tx *sql.Tx, userID int32) error
 	return err
 }
 
+func deleteWebhookDeliveriesTx(ctx context.Context, tx *sql.Tx, userID int32) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM webhook_delivery WHERE creator_id = `+deleteUserPlaceholder(1), userID)
+	return err
+}
+
+func deleteDailyMemosTx(ctx context.Context, tx *sql.Tx, userID int32) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM daily_memo WHERE creator_id = `+deleteUserPlaceholder(1), userID)
+	return err
+}
+
+// listDeleteUserUploadSessions returns the upload sessions of the user, whose
+// staged content is discarded once the user is deleted.
+func listDeleteUserUploadSessions(ctx context.Context, tx *sql.Tx, userID int32) ([]*store.UploadSession, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id, uid, creator_id, payload FROM upload_session WHERE creator_id = `+deleteUserPlaceholder(1), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]*store.UploadSession, 0)
+	for rows.Next() {
+		session := &store.UploadSession{}
+		var payloadBytes []byte
+		if err := rows.Scan(&session.ID, &session.UID, &session.CreatorID, &payloadBytes); err != nil {
+			return nil, err
+		}
+		payload := &storepb.UploadSessionPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, err
+		}
+		session.Payload = payload
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func deleteUploadSessionsTx(ctx context.Context, tx *sql.Tx, userID int32) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM upload_session WHERE creator_id = `+deleteUserPlaceholder(1), userID)
+	return err
+}
+
 func deleteUserSettingsTx(ctx context.Context, tx *sql.Tx, userID int32) error {
 	_, err := tx.ExecContext(ctx, `DELETE FROM user_setting WHERE user_id = `+deleteUserPlaceholder(1), userID)
 	return err
@@ -26,6 +26,8 @@ const (
 	maxTranscriptionModelLength       = 256
 	maxTranscriptionLanguageLength    = 32
 	maxTranscriptionPromptLength      = 4096
+	maxAIProviderTimeoutSeconds       = 3600
+	maxAIProviderConcurrentRequests   = 64
 )
 
 var (
@@ -297,7 +299,7 @@ func validateAndNormalizeDeploymentInstanceSetting(setting *storepb.InstanceSett
 		default:
 			return errors.New("accessSetting.accessMode must be PRIVATE or PUBLIC")
 		}
-	case storepb.InstanceSettingKey_BASIC, storepb.InstanceSettingKey_TAGS:
+	case storepb.InstanceSettingKey_BASIC, storepb.InstanceSettingKey_TAGS, storepb.InstanceSettingKey_MEMO_TEMPLATES:
 		return errors.Errorf("key %s cannot be deployment configured", setting.Key)
 	default:
 		return errors.Errorf("unsupported instance setting key %s", setting.Key)
@@ -314,8 +316,8 @@ func normalizeDeploymentAISetting(setting *storepb.InstanceAISetting) error {
 		provider.Id = strings.TrimSpace(provider.Id)
 		provider.Title = strings.TrimSpace(provider.Title)
 		provider.Endpoint = strings.TrimSpace(provider.Endpoint)
-		if provider.Id == "" || provider.Title == "" || provider.ApiKey == "" {
-			return errors.Errorf("aiSetting.providers[%d] requires id, title, and apiKey", i)
+		if provider.Id == "" || provider.Title == "" {
+			return errors.Errorf("aiSetting.providers[%d] requires id and title", i)
 		}
 		if _, ok := providers[provider.Id]; ok {
 			return errors.Errorf("aiSetting provider ID %q is duplicated", provider.Id)
@@ -330,9 +332,25 @@ func normalizeDeploymentAISetting(setting *storepb.InstanceAISetting) error {
 			if provider.Endpoint == "" {
 				provider.Endpoint = "https://generativelanguage.googleapis.com/v1beta"
 			}
+		case storepb.AIProviderType_OPENAI_COMPATIBLE:
+			if provider.Endpoint == "" {
+				return errors.Errorf("aiSetting provider %q requires endpoint", provider.Id)
+			}
+		case storepb.AIProviderType_OLLAMA:
+			if provider.Endpoint == "" {
+				provider.Endpoint = "http://localhost:11434"
+			}
 		default:
 			return errors.Errorf("aiSetting provider %q has unsupported type", provider.Id)
 		}
+		// Self-hosted servers commonly run without authentication.
+		if provider.ApiKey == "" && (provider.Type == storepb.AIProviderType_OPENAI || provider.Type == storepb.AIProviderType_GEMINI) {
+			return errors.Errorf("aiSetting provider %q requires apiKey", provider.Id)
+		}
+		if provider.TimeoutSeconds < 0 || provider.TimeoutSeconds > maxAIProviderTimeoutSeconds ||
+			provider.MaxConcurrentRequests < 0 || provider.MaxConcurrentRequests > maxAIProviderConcurrentRequests {
+			return errors.Errorf("aiSetting provider %q timeout or concurrency limit is out of range", provider.Id)
+		}
 	}
 	if transcription := setting.Transcription; transcription != nil {
 		transcription.ProviderId = strings.TrimSpace(transcription.ProviderId)
@@ -348,6 +366,19 @@ func normalizeDeploymentAISetting(setting *storepb.InstanceAISetting) error {
 			return errors.New("aiSetting transcription configuration exceeds a supported length limit")
 		}
 	}
+	if imageAnalysis := setting.ImageAnalysis; imageAnalysis != nil {
+		imageAnalysis.ProviderId = strings.TrimSpace(imageAnalysis.ProviderId)
+		imageAnalysis.Model = strings.TrimSpace(imageAnalysis.Model)
+		imageAnalysis.Language = strings.TrimSpace(imageAnalysis.Language)
+		if imageAnalysis.Engine == storepb.ImageAnalysisConfig_AI_PROVIDER {
+			if _, ok := providers[imageAnalysis.ProviderId]; !ok {
+				return errors.Errorf("aiSetting imageAnalysis providerId %q does not reference a provider", imageAnalysis.ProviderId)
+			}
+		}
+		if len(imageAnalysis.Model) > maxTranscriptionModelLength || len(imageAnalysis.Language) > maxTranscriptionLanguageLength {
+			return errors.New("aiSetting imageAnalysis configuration exceeds a supported length limit")
+		}
+	}
 	return nil
 }
 
@@ -235,6 +235,7 @@ func TestLoadDeploymentConfigurationSupportsEveryProvisionableSettingGroup(t *te
 			Key: storepb.InstanceSettingKey_AI,
 			Value: &storepb.InstanceSetting_AiSetting{AiSetting: &storepb.InstanceAISetting{Providers: []*storepb.AIProviderConfig{
 				{Id: "primary", Title: "Primary", Type: storepb.AIProviderType_OPENAI, ApiKey: "ai-secret"},
+				{Id: "local", Title: "Local", Type: storepb.AIProviderType_OLLAMA, MaxConcurrentRequests: 1},
 			}}},
 		},
 		"memos-instance-setting-access.json": {
@@ -253,8 +254,9 @@ func TestLoadDeploymentConfigurationSupportsEveryProvisionableSettingGroup(t *te
 	}
 	ai, err := stores.GetInstanceAISetting(ctx)
 	require.NoError(t, err)
-	require.Len(t, ai.Providers, 1)
+	require.Len(t, ai.Providers, 2)
 	assert.Equal(t, "https://api.openai.com/v1", ai.Providers[0].Endpoint)
+	assert.Equal(t, "http://localhost:11434", ai.Providers[1].Endpoint)
 	access, err := stores.GetInstanceAccessSetting(ctx)
 	require.NoError(t, err)
 	assert.Equal(t, storepb.InstanceAccessMode_INSTANCE_ACCESS_MODE_PUBLIC, access.AccessMode)
@@ -273,6 +275,9 @@ func TestLoadDeploymentConfigurationRejectsInvalidSettingResources(t *testing.T)
 		{name: "unspecified access mode", content: `{"key":"ACCESS","accessSetting":{}}`, errorString: "accessSetting.accessMode must be PRIVATE or PUBLIC"},
 		{name: "mismatched access oneof", content: `{"key":"ACCESS","generalSetting":{}}`, errorString: "accessSetting must be populated"},
 		{name: "unknown field", content: `{"key":"GENERAL","generalSetting":{},"typo":true}`, errorString: `unknown field "typo"`},
+		{name: "OpenAI provider without key", content: `{"key":"AI","aiSetting":{"providers":[{"id":"p","title":"P","type":"OPENAI"}]}}`, errorString: `provider "p" requires apiKey`},
+		{name: "OpenAI-compatible provider without endpoint", content: `{"key":"AI","aiSetting":{"providers":[{"id":"p","title":"P","type":"OPENAI_COMPATIBLE"}]}}`, errorString: `provider "p" requires endpoint`},
+		{name: "AI provider timeout out of range", content: `{"key":"AI","aiSetting":{"providers":[{"id":"p","title":"P","type":"OLLAMA","timeoutSeconds":-1}]}}`, errorString: "out of range"},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
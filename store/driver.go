@@ -22,8 +22,12 @@ type Driver interface {
 	CreateAttachment(ctx context.Context, create *Attachment) (*Attachment, error)
 	ListAttachments(ctx context.Context, find *FindAttachment) ([]*Attachment, error)
 	UpdateAttachment(ctx context.Context, update *UpdateAttachment) error
-	DeleteAttachment(ctx context.Context, delete *DeleteAttachment) error
-	DeleteAttachments(ctx context.Context, deletes []*DeleteAttachment) error
+	ListAttachmentUsage(ctx context.Context, find *FindAttachmentUsage) ([]*AttachmentUsage, error)
+	// LockAttachmentContent runs fn in a transaction holding a lock on the
+	// attachment rows of each digest. Digests are sorted and unique.
+	LockAttachmentContent(ctx context.Context, digests []string, fn func(AttachmentContentTx) error) error
+	// LockAttachment runs fn in a transaction holding a lock on the attachment row.
+	LockAttachment(ctx context.Context, id int32, fn func(AttachmentContentTx) error) error
 	ApplyMemoMutation(ctx context.Context, mutation *MemoMutation) error
 
 	// Memo model related methods.
@@ -31,6 +35,7 @@ type Driver interface {
 	ListMemos(ctx context.Context, find *FindMemo) ([]*Memo, error)
 	UpdateMemo(ctx context.Context, update *UpdateMemo) error
 	DeleteMemo(ctx context.Context, delete *DeleteMemo) error
+	ApplyTagMutation(ctx context.Context, rewrites []*MemoTagRewrite, settings []*UserSetting) error
 
 	// MemoRelation model related methods.
 	UpsertMemoRelation(ctx context.Context, create *MemoRelation) (*MemoRelation, error)
@@ -52,6 +57,10 @@ type Driver interface {
 
 	// UserSetting model related methods.
 	UpsertUserSetting(ctx context.Context, upsert *UserSetting) (*UserSetting, error)
+	// SwapUserSetting stores swap only if the stored value still equals
+	// oldValue, or if there is none when oldValue is nil. It reports whether
+	// swap was stored.
+	SwapUserSetting(ctx context.Context, swap *UserSetting, oldValue *string) (bool, error)
 	ListUserSettings(ctx context.Context, find *FindUserSetting) ([]*UserSetting, error)
 	DeleteUserSettings(ctx context.Context, delete *DeleteUserSetting) error
 	GetUserByPATHash(ctx context.Context, tokenHash string) (*PATQueryResult, error)
@@ -82,9 +91,32 @@ type Driver interface {
 	GetMemoShare(ctx context.Context, find *FindMemoShare) (*MemoShare, error)
 	DeleteMemoShare(ctx context.Context, delete *DeleteMemoShare) error
 
+	// WebhookDelivery model related methods.
+	CreateWebhookDelivery(ctx context.Context, create *WebhookDelivery) (*WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, find *FindWebhookDelivery) ([]*WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, update *UpdateWebhookDelivery) error
+	DeleteWebhookDeliveries(ctx context.Context, delete *DeleteWebhookDelivery) error
+
+	// UploadSession model related methods.
+	CreateUploadSession(ctx context.Context, create *UploadSession) (*UploadSession, error)
+	ListUploadSessions(ctx context.Context, find *FindUploadSession) ([]*UploadSession, error)
+	UpdateUploadSession(ctx context.Context, update *UpdateUploadSession) error
+	DeleteUploadSession(ctx context.Context, delete *DeleteUploadSession) error
+
+	// AttachmentVariant model related methods.
+	UpsertAttachmentVariant(ctx context.Context, upsert *AttachmentVariant) (*AttachmentVariant, error)
+	ListAttachmentVariants(ctx context.Context, find *FindAttachmentVariant) ([]*AttachmentVariant, error)
+	UpdateAttachmentVariant(ctx context.Context, update *UpdateAttachmentVariant) error
+	DeleteAttachmentVariant(ctx context.Context, delete *DeleteAttachmentVariant) error
+
 	// UserIdentity model related methods.
 	CreateUserIdentity(ctx context.Context, create *UserIdentity) (*UserIdentity, error)
 	CreateUserWithIdentity(ctx context.Context, createUser *User, createIdentity *UserIdentity) (*User, error)
 	ListUserIdentities(ctx context.Context, find *FindUserIdentity) ([]*UserIdentity, error)
 	DeleteUserIdentities(ctx context.Context, delete *DeleteUserIdentity) error
+
+	// DailyMemo model related methods.
+	CreateDailyMemo(ctx context.Context, create *DailyMemo) (bool, error)
+	ListDailyMemos(ctx context.Context, find *FindDailyMemo) ([]*DailyMemo, error)
+	DeleteDailyMemo(ctx context.Context, delete *DeleteDailyMemo) error
 }
@@ -49,6 +49,8 @@ func (s *Store) UpsertInstanceSetting(ctx context.Context, upsert *storepb.Insta
 		valueBytes, err = protojson.Marshal(upsert.GetAiSetting())
 	} else if upsert.Key == storepb.InstanceSettingKey_ACCESS {
 		valueBytes, err = protojson.Marshal(upsert.GetAccessSetting())
+	} else if upsert.Key == storepb.InstanceSettingKey_MEMO_TEMPLATES {
+		valueBytes, err = protojson.Marshal(upsert.GetMemoTemplatesSetting())
 	} else {
 		return nil, errors.Errorf("unsupported instance setting key: %v", upsert.Key)
 	}
@@ -451,6 +453,12 @@ func convertInstanceSettingFromRaw(instanceSettingRaw *InstanceSetting) (*storep
 			return nil, err
 		}
 		instanceSetting.Value = &storepb.InstanceSetting_AccessSetting{AccessSetting: accessSetting}
+	case storepb.InstanceSettingKey_MEMO_TEMPLATES.String():
+		memoTemplatesSetting := &storepb.InstanceMemoTemplatesSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(instanceSettingRaw.Value), memoTemplatesSetting); err != nil {
+			return nil, err
+		}
+		instanceSetting.Value = &storepb.InstanceSetting_MemoTemplatesSetting{MemoTemplatesSetting: memoTemplatesSetting}
 	default:
 		// Skip unsupported instance setting key.
 		return nil, nil
@@ -21,9 +21,9 @@ type MemoAttachmentBinding struct {
 }
 
 // MemoMutation atomically updates a memo, its attachment bindings, and its
-// reference relations. Removed attachment rows are detached in the transaction
-// and are deleted from storage separately, so a storage failure remains
-// retriable.
+// reference and embed relations. Removed attachment rows are detached in the
+// transaction and are deleted from storage separately, so a storage failure
+// remains retriable.
 type MemoMutation struct {
 	MemoID                    int32
 	MemoCreatorID             int32
@@ -11,6 +11,8 @@ const (
 	MemoRelationReference MemoRelationType = "REFERENCE"
 	// MemoRelationComment is the type for a comment memo relation.
 	MemoRelationComment MemoRelationType = "COMMENT"
+	// MemoRelationEmbed is the type for a memo embedded with ![[memo]].
+	MemoRelationEmbed MemoRelationType = "EMBED"
 )
 
 type MemoRelation struct {
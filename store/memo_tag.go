@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// MemoTagRewrite replaces the content and payload of one memo whose tags were
+// renamed, merged or deleted. ExpectedContent is the content the rewrite was
+// computed from; the driver rejects the batch if it changed since.
+type MemoTagRewrite struct {
+	MemoID          int32
+	ExpectedContent string
+	Content         string
+	Payload         *storepb.MemoPayload
+}
+
+// TagMutation atomically rewrites tags in memo contents and replaces the tag
+// metadata settings of the affected users.
+type TagMutation struct {
+	Memos        []*MemoTagRewrite
+	UserSettings []*storepb.UserSetting
+}
+
+// ApplyTagMutation applies every memo rewrite and user setting of the
+// mutation in one transaction. A memo whose content changed after the
+// mutation was prepared fails the whole batch with ErrMemoMutationConflict.
+func (s *Store) ApplyTagMutation(ctx context.Context, mutation *TagMutation) error {
+	if mutation == nil {
+		return errors.New("tag mutation is required")
+	}
+	settings := make([]*UserSetting, 0, len(mutation.UserSettings))
+	for _, setting := range mutation.UserSettings {
+		if setting.GetKey() != storepb.UserSetting_TAGS {
+			return errors.New("tag mutation only replaces tag settings")
+		}
+		raw, err := convertUserSettingToRaw(setting)
+		if err != nil {
+			return err
+		}
+		settings = append(settings, raw)
+	}
+	if err := s.driver.ApplyTagMutation(ctx, mutation.Memos, settings); err != nil {
+		return err
+	}
+	for _, setting := range mutation.UserSettings {
+		s.userSettingCache.Set(ctx, getUserSettingCacheKey(setting.UserId, setting.Key.String()), setting)
+	}
+	return nil
+}
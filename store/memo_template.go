@@ -0,0 +1,177 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// UpdateMemoTemplate holds the memo template fields to update. Nil fields are
+// left unchanged; a non-nil empty Tags clears the tags.
+type UpdateMemoTemplate struct {
+	Title      *string
+	Content    *string
+	Tags       []string
+	Visibility *string
+}
+
+// ListMemoTemplates returns the memo templates of the user, or the
+// instance-wide templates when userID is nil.
+func (s *Store) ListMemoTemplates(ctx context.Context, userID *int32) ([]*storepb.MemoTemplate, error) {
+	var templates []*storepb.MemoTemplate
+	if userID == nil {
+		instanceSetting, err := s.GetInstanceSetting(ctx, &FindInstanceSetting{
+			Name: storepb.InstanceSettingKey_MEMO_TEMPLATES.String(),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "get memo templates instance setting")
+		}
+		templates = instanceSetting.GetMemoTemplatesSetting().GetTemplates()
+	} else {
+		userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+			UserID: userID,
+			Key:    storepb.UserSetting_MEMO_TEMPLATES,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "get memo templates user setting")
+		}
+		templates = userSetting.GetMemoTemplates().GetTemplates()
+	}
+
+	// Clone so callers never mutate the cached setting.
+	clonedTemplates := make([]*storepb.MemoTemplate, 0, len(templates))
+	for _, template := range templates {
+		if template != nil {
+			clonedTemplates = append(clonedTemplates, proto.CloneOf(template))
+		}
+	}
+	return clonedTemplates, nil
+}
+
+// GetMemoTemplate returns the memo template carrying the ID, or nil when there
+// is none. A nil userID selects the instance-wide templates.
+func (s *Store) GetMemoTemplate(ctx context.Context, userID *int32, templateID string) (*storepb.MemoTemplate, error) {
+	templates, err := s.ListMemoTemplates(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, template := range templates {
+		if template.GetId() == templateID {
+			return template, nil
+		}
+	}
+	return nil, nil
+}
+
+// AddMemoTemplate appends a new memo template. A nil userID adds an
+// instance-wide template.
+func (s *Store) AddMemoTemplate(ctx context.Context, userID *int32, template *storepb.MemoTemplate) error {
+	s.memoTemplateMu.Lock()
+	defer s.memoTemplateMu.Unlock()
+
+	existing, err := s.ListMemoTemplates(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "get existing memo templates")
+	}
+	if err := s.upsertMemoTemplates(ctx, userID, append(existing, template)); err != nil {
+		return errors.Wrap(err, "add memo template")
+	}
+	return nil
+}
+
+// UpdateMemoTemplate applies the update to the memo template carrying the ID.
+// It returns nil when no matching memo template is found.
+func (s *Store) UpdateMemoTemplate(ctx context.Context, userID *int32, templateID string, update *UpdateMemoTemplate) (*storepb.MemoTemplate, error) {
+	s.memoTemplateMu.Lock()
+	defer s.memoTemplateMu.Unlock()
+
+	templates, err := s.ListMemoTemplates(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get existing memo templates")
+	}
+
+	var updatedTemplate *storepb.MemoTemplate
+	for _, template := range templates {
+		if template.GetId() != templateID {
+			continue
+		}
+		if update.Title != nil {
+			template.Title = *update.Title
+		}
+		if update.Content != nil {
+			template.Content = *update.Content
+		}
+		if update.Tags != nil {
+			template.Tags = update.Tags
+		}
+		if update.Visibility != nil {
+			template.Visibility = *update.Visibility
+		}
+		updatedTemplate = template
+	}
+	if updatedTemplate == nil {
+		return nil, nil
+	}
+
+	if err := s.upsertMemoTemplates(ctx, userID, templates); err != nil {
+		return nil, errors.Wrap(err, "update memo template")
+	}
+	return updatedTemplate, nil
+}
+
+// RemoveMemoTemplate removes the memo template carrying the ID.
+// It reports whether a matching memo template was found.
+func (s *Store) RemoveMemoTemplate(ctx context.Context, userID *int32, templateID string) (bool, error) {
+	s.memoTemplateMu.Lock()
+	defer s.memoTemplateMu.Unlock()
+
+	existing, err := s.ListMemoTemplates(ctx, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "get existing memo templates")
+	}
+
+	found := false
+	templates := make([]*storepb.MemoTemplate, 0, len(existing))
+	for _, template := range existing {
+		if template.GetId() == templateID {
+			found = true
+			continue
+		}
+		templates = append(templates, template)
+	}
+	if !found {
+		return false, nil
+	}
+
+	if err := s.upsertMemoTemplates(ctx, userID, templates); err != nil {
+		return false, errors.Wrap(err, "remove memo template")
+	}
+	return true, nil
+}
+
+func (s *Store) upsertMemoTemplates(ctx context.Context, userID *int32, templates []*storepb.MemoTemplate) error {
+	if userID == nil {
+		_, err := s.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+			Key: storepb.InstanceSettingKey_MEMO_TEMPLATES,
+			Value: &storepb.InstanceSetting_MemoTemplatesSetting{
+				MemoTemplatesSetting: &storepb.InstanceMemoTemplatesSetting{
+					Templates: templates,
+				},
+			},
+		})
+		return errors.Wrap(err, "upsert memo templates instance setting")
+	}
+	_, err := s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: *userID,
+		Key:    storepb.UserSetting_MEMO_TEMPLATES,
+		Value: &storepb.UserSetting_MemoTemplates{
+			MemoTemplates: &storepb.MemoTemplatesUserSetting{
+				Templates: templates,
+			},
+		},
+	})
+	return errors.Wrap(err, "upsert memo templates user setting")
+}
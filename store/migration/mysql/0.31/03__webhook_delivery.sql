@@ -0,0 +1,22 @@
+-- webhook_delivery is the persistent queue and history of user webhook deliveries.
+-- payload holds the serialized request body so retries resend the original event.
+CREATE TABLE `webhook_delivery` (
+  `id`                   INT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `uid`                  VARCHAR(255) NOT NULL UNIQUE,
+  `creator_id`           INT          NOT NULL,
+  `webhook_id`           VARCHAR(256) NOT NULL,
+  `activity_type`        VARCHAR(256) NOT NULL,
+  `payload`              LONGTEXT     NOT NULL,
+  `status`               VARCHAR(32)  NOT NULL DEFAULT 'PENDING',
+  `attempts`             INT          NOT NULL DEFAULT 0,
+  `next_attempt_ts`      BIGINT       NOT NULL DEFAULT 0,
+  `response_status_code` INT          NOT NULL DEFAULT 0,
+  `latency_ms`           BIGINT       NOT NULL DEFAULT 0,
+  `response_excerpt`     TEXT         NOT NULL,
+  `last_error`           TEXT         NOT NULL,
+  `created_ts`           BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP()),
+  `updated_ts`           BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP())
+);
+
+CREATE INDEX `idx_webhook_delivery_creator_id_webhook_id` ON `webhook_delivery`(`creator_id`, `webhook_id`);
+CREATE INDEX `idx_webhook_delivery_status_next_attempt_ts` ON `webhook_delivery`(`status`, `next_attempt_ts`);
@@ -0,0 +1,17 @@
+-- upload_session tracks resumable attachment uploads until they are finalized.
+-- payload records where the received chunks are accumulated.
+CREATE TABLE `upload_session` (
+  `id`            INT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `uid`           VARCHAR(255) NOT NULL UNIQUE,
+  `creator_id`    INT          NOT NULL,
+  `filename`      TEXT         NOT NULL,
+  `type`          VARCHAR(255) NOT NULL,
+  `size`          BIGINT       NOT NULL,
+  `received_size` BIGINT       NOT NULL DEFAULT 0,
+  `payload`       TEXT         NOT NULL,
+  `expires_ts`    BIGINT       NOT NULL,
+  `created_ts`    BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP()),
+  `updated_ts`    BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP())
+);
+
+CREATE INDEX `idx_upload_session_expires_ts` ON `upload_session`(`expires_ts`);
@@ -0,0 +1,16 @@
+-- attachment_variant records resized and converted copies of attachment images
+-- cached in the storage of their attachment, so they can be evicted by age and
+-- total size.
+CREATE TABLE `attachment_variant` (
+  `id`            INT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `attachment_id` INT          NOT NULL,
+  `name`          VARCHAR(64)  NOT NULL,
+  `type`          VARCHAR(255) NOT NULL,
+  `size`          BIGINT       NOT NULL,
+  `reference`     TEXT         NOT NULL,
+  `created_ts`    BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP()),
+  `accessed_ts`   BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP()),
+  UNIQUE(`attachment_id`, `name`)
+);
+
+CREATE INDEX `idx_attachment_variant_accessed_ts` ON `attachment_variant`(`accessed_ts`);
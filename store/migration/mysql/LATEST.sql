@@ -64,9 +64,12 @@ CREATE TABLE `attachment` (
   `memo_id` INT DEFAULT NULL,
   `storage_type` VARCHAR(256) NOT NULL DEFAULT '',
   `reference` TEXT NOT NULL DEFAULT (''),
-  `payload` TEXT NOT NULL
+  `payload` TEXT NOT NULL,
+  `sha256` VARCHAR(64) NOT NULL DEFAULT ''
 );
 
+CREATE INDEX `idx_attachment_sha256` ON `attachment`(`sha256`);
+
 -- idp
 CREATE TABLE `idp` (
   `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
@@ -123,3 +126,68 @@ CREATE TABLE `user_identity` (
 );
 
 CREATE INDEX `idx_user_identity_user_id` ON `user_identity`(`user_id`);
+
+-- webhook_delivery
+CREATE TABLE `webhook_delivery` (
+  `id`                   INT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `uid`                  VARCHAR(255) NOT NULL UNIQUE,
+  `creator_id`           INT          NOT NULL,
+  `webhook_id`           VARCHAR(256) NOT NULL,
+  `activity_type`        VARCHAR(256) NOT NULL,
+  `payload`              LONGTEXT     NOT NULL,
+  `status`               VARCHAR(32)  NOT NULL DEFAULT 'PENDING',
+  `attempts`             INT          NOT NULL DEFAULT 0,
+  `next_attempt_ts`      BIGINT       NOT NULL DEFAULT 0,
+  `response_status_code` INT          NOT NULL DEFAULT 0,
+  `latency_ms`           BIGINT       NOT NULL DEFAULT 0,
+  `response_excerpt`     TEXT         NOT NULL,
+  `last_error`           TEXT         NOT NULL,
+  `created_ts`           BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP()),
+  `updated_ts`           BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP())
+);
+
+CREATE INDEX `idx_webhook_delivery_creator_id_webhook_id` ON `webhook_delivery`(`creator_id`, `webhook_id`);
+CREATE INDEX `idx_webhook_delivery_status_next_attempt_ts` ON `webhook_delivery`(`status`, `next_attempt_ts`);
+
+-- upload_session
+CREATE TABLE `upload_session` (
+  `id`            INT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `uid`           VARCHAR(255) NOT NULL UNIQUE,
+  `creator_id`    INT          NOT NULL,
+  `filename`      TEXT         NOT NULL,
+  `type`          VARCHAR(255) NOT NULL,
+  `size`          BIGINT       NOT NULL,
+  `received_size` BIGINT       NOT NULL DEFAULT 0,
+  `payload`       TEXT         NOT NULL,
+  `expires_ts`    BIGINT       NOT NULL,
+  `created_ts`    BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP()),
+  `updated_ts`    BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP())
+);
+
+CREATE INDEX `idx_upload_session_expires_ts` ON `upload_session`(`expires_ts`);
+
+-- attachment_variant
+CREATE TABLE `attachment_variant` (
+  `id`            INT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `attachment_id` INT          NOT NULL,
+  `name`          VARCHAR(64)  NOT NULL,
+  `type`          VARCHAR(255) NOT NULL,
+  `size`          BIGINT       NOT NULL,
+  `reference`     TEXT         NOT NULL,
+  `created_ts`    BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP()),
+  `accessed_ts`   BIGINT       NOT NULL DEFAULT (UNIX_TIMESTAMP()),
+  UNIQUE(`attachment_id`, `name`)
+);
+
+CREATE INDEX `idx_attachment_variant_accessed_ts` ON `attachment_variant`(`accessed_ts`);
+
+-- daily_memo
+CREATE TABLE `daily_memo` (
+  `creator_id` INT         NOT NULL,
+  `date`       VARCHAR(10) NOT NULL,
+  `memo_id`    INT         NOT NULL,
+  `created_ts` BIGINT      NOT NULL DEFAULT (UNIX_TIMESTAMP()),
+  PRIMARY KEY (`creator_id`, `date`)
+);
+
+CREATE INDEX `idx_daily_memo_memo_id` ON `daily_memo`(`memo_id`);
@@ -0,0 +1,17 @@
+-- upload_session tracks resumable attachment uploads until they are finalized.
+-- payload records where the received chunks are accumulated.
+CREATE TABLE upload_session (
+  id            SERIAL  PRIMARY KEY,
+  uid           TEXT    NOT NULL UNIQUE,
+  creator_id    INTEGER NOT NULL,
+  filename      TEXT    NOT NULL,
+  type          TEXT    NOT NULL,
+  size          BIGINT  NOT NULL,
+  received_size BIGINT  NOT NULL DEFAULT 0,
+  payload       TEXT    NOT NULL DEFAULT '{}',
+  expires_ts    BIGINT  NOT NULL,
+  created_ts    BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts    BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())
+);
+
+CREATE INDEX idx_upload_session_expires_ts ON upload_session(expires_ts);
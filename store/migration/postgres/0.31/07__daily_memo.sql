@@ -0,0 +1,11 @@
+-- daily_memo indexes the daily note of each user and day, so creating a daily
+-- note is atomic and every append lands in the same memo.
+CREATE TABLE daily_memo (
+  creator_id INTEGER NOT NULL,
+  date       TEXT    NOT NULL,
+  memo_id    INTEGER NOT NULL,
+  created_ts BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  PRIMARY KEY (creator_id, date)
+);
+
+CREATE INDEX idx_daily_memo_memo_id ON daily_memo(memo_id);
@@ -64,9 +64,12 @@ CREATE TABLE attachment (
   memo_id INTEGER DEFAULT NULL,
   storage_type TEXT NOT NULL DEFAULT '',
   reference TEXT NOT NULL DEFAULT '',
-  payload TEXT NOT NULL DEFAULT '{}'
+  payload TEXT NOT NULL DEFAULT '{}',
+  sha256 TEXT NOT NULL DEFAULT ''
 );
 
+CREATE INDEX idx_attachment_sha256 ON attachment(sha256);
+
 -- idp
 CREATE TABLE idp (
   id SERIAL PRIMARY KEY,
@@ -123,3 +126,68 @@ CREATE TABLE user_identity (
 );
 
 CREATE INDEX idx_user_identity_user_id ON user_identity(user_id);
+
+-- webhook_delivery
+CREATE TABLE webhook_delivery (
+  id                   SERIAL  PRIMARY KEY,
+  uid                  TEXT    NOT NULL UNIQUE,
+  creator_id           INTEGER NOT NULL,
+  webhook_id           TEXT    NOT NULL,
+  activity_type        TEXT    NOT NULL,
+  payload              TEXT    NOT NULL,
+  status               TEXT    NOT NULL DEFAULT 'PENDING',
+  attempts             INTEGER NOT NULL DEFAULT 0,
+  next_attempt_ts      BIGINT  NOT NULL DEFAULT 0,
+  response_status_code INTEGER NOT NULL DEFAULT 0,
+  latency_ms           BIGINT  NOT NULL DEFAULT 0,
+  response_excerpt     TEXT    NOT NULL DEFAULT '',
+  last_error           TEXT    NOT NULL DEFAULT '',
+  created_ts           BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts           BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())
+);
+
+CREATE INDEX idx_webhook_delivery_creator_id_webhook_id ON webhook_delivery(creator_id, webhook_id);
+CREATE INDEX idx_webhook_delivery_status_next_attempt_ts ON webhook_delivery(status, next_attempt_ts);
+
+-- upload_session
+CREATE TABLE upload_session (
+  id            SERIAL  PRIMARY KEY,
+  uid           TEXT    NOT NULL UNIQUE,
+  creator_id    INTEGER NOT NULL,
+  filename      TEXT    NOT NULL,
+  type          TEXT    NOT NULL,
+  size          BIGINT  NOT NULL,
+  received_size BIGINT  NOT NULL DEFAULT 0,
+  payload       TEXT    NOT NULL DEFAULT '{}',
+  expires_ts    BIGINT  NOT NULL,
+  created_ts    BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts    BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())
+);
+
+CREATE INDEX idx_upload_session_expires_ts ON upload_session(expires_ts);
+
+-- attachment_variant
+CREATE TABLE attachment_variant (
+  id            SERIAL  PRIMARY KEY,
+  attachment_id INTEGER NOT NULL,
+  name          TEXT    NOT NULL,
+  type          TEXT    NOT NULL,
+  size          BIGINT  NOT NULL,
+  reference     TEXT    NOT NULL,
+  created_ts    BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  accessed_ts   BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  UNIQUE(attachment_id, name)
+);
+
+CREATE INDEX idx_attachment_variant_accessed_ts ON attachment_variant(accessed_ts);
+
+-- daily_memo
+CREATE TABLE daily_memo (
+  creator_id INTEGER NOT NULL,
+  date       TEXT    NOT NULL,
+  memo_id    INTEGER NOT NULL,
+  created_ts BIGINT  NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  PRIMARY KEY (creator_id, date)
+);
+
+CREATE INDEX idx_daily_memo_memo_id ON daily_memo(memo_id);
@@ -0,0 +1,22 @@
+-- webhook_delivery is the persistent queue and history of user webhook deliveries.
+-- payload holds the serialized request body so retries resend the original event.
+CREATE TABLE webhook_delivery (
+  id                   INTEGER PRIMARY KEY AUTOINCREMENT,
+  uid                  TEXT    NOT NULL UNIQUE,
+  creator_id           INTEGER NOT NULL,
+  webhook_id           TEXT    NOT NULL,
+  activity_type        TEXT    NOT NULL,
+  payload              TEXT    NOT NULL,
+  status               TEXT    NOT NULL DEFAULT 'PENDING',
+  attempts             INTEGER NOT NULL DEFAULT 0,
+  next_attempt_ts      BIGINT  NOT NULL DEFAULT 0,
+  response_status_code INTEGER NOT NULL DEFAULT 0,
+  latency_ms           BIGINT  NOT NULL DEFAULT 0,
+  response_excerpt     TEXT    NOT NULL DEFAULT '',
+  last_error           TEXT    NOT NULL DEFAULT '',
+  created_ts           BIGINT  NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts           BIGINT  NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+
+CREATE INDEX idx_webhook_delivery_creator_id_webhook_id ON webhook_delivery(creator_id, webhook_id);
+CREATE INDEX idx_webhook_delivery_status_next_attempt_ts ON webhook_delivery(status, next_attempt_ts);
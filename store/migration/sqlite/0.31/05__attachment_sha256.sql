@@ -0,0 +1,5 @@
+-- Records the SHA-256 digest of attachment content so identical uploads can
+-- share one stored object. Existing attachments keep an empty digest.
+ALTER TABLE attachment ADD COLUMN sha256 TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_attachment_sha256 ON attachment(sha256);
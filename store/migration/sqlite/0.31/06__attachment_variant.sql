@@ -0,0 +1,16 @@
+-- attachment_variant records resized and converted copies of attachment images
+-- cached in the storage of their attachment, so they can be evicted by age and
+-- total size.
+CREATE TABLE attachment_variant (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT,
+  attachment_id INTEGER NOT NULL,
+  name          TEXT    NOT NULL,
+  type          TEXT    NOT NULL,
+  size          BIGINT  NOT NULL,
+  reference     TEXT    NOT NULL,
+  created_ts    BIGINT  NOT NULL DEFAULT (strftime('%s', 'now')),
+  accessed_ts   BIGINT  NOT NULL DEFAULT (strftime('%s', 'now')),
+  UNIQUE(attachment_id, name)
+);
+
+CREATE INDEX idx_attachment_variant_accessed_ts ON attachment_variant(accessed_ts);
@@ -4,6 +4,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"io"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -148,6 +153,7 @@ func PrepareInstanceStorageSettingUpdate(incoming, existing *storepb.InstanceSto
 			previous = nil
 		}
 
+		backfillStorageSecrets(storage, previous)
 		if storage.GetS3Config() != nil && storage.GetS3Config().AccessKeySecret == "" {
 			if previous != nil && previous.GetS3Config() != nil {
 				storage.GetS3Config().AccessKeySecret = previous.GetS3Config().AccessKeySecret
@@ -266,8 +272,13 @@ func (s *Store) ResolveStorageDriver(
 
 // StorageDriver returns the driver for a resolved storage, reusing cached
 // clients so request paths do not rebuild an S3 client (config load, HTTP
-// transport) per call. ID-less legacy storages are not cached.
+// transport) or reconnect SFTP per call. ID-less legacy storages are not cached.
+// The built-in local storage resolves to the server data directory.
 func (s *Store) StorageDriver(ctx context.Context, resolvedStorage *storepb.Storage) (storage.Driver, error) {
+	if resolvedStorage.GetType() == storepb.StorageType_STORAGE_TYPE_LOCAL && resolvedStorage.GetLocalConfig().GetPath() == "" {
+		resolvedStorage = proto.CloneOf(resolvedStorage)
+		resolvedStorage.Config = &storepb.Storage_LocalConfig{LocalConfig: &storepb.StorageLocalConfig{Path: s.profile.Data}}
+	}
 	if resolvedStorage.GetId() == "" {
 		return storage.NewDriver(ctx, resolvedStorage)
 	}
@@ -318,11 +329,19 @@ func newStorageDriverCacheKey(resolvedStorage *storepb.Storage) (storageDriverCa
 
 // resetStorageDriverCache drops cached storage drivers; call whenever the
 // STORAGE setting may have changed (credentials or transport options can
-// rotate under an unchanged storage ID).
+// rotate under an unchanged storage ID). Drivers holding connections are
+// closed once they leave the cache.
 func (s *Store) resetStorageDriverCache() {
 	s.storageDriverMu.Lock()
 	defer s.storageDriverMu.Unlock()
 	s.storageDriverGeneration++
+	for _, driver := range s.storageDriverCache {
+		if closer, ok := driver.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				slog.Warn("failed to close storage driver", slog.Any("err", err))
+			}
+		}
+	}
 	s.storageDriverCache = nil
 }
 
@@ -339,6 +358,46 @@ func FindStorage(setting *storepb.InstanceStorageSetting, storageID string) *sto
 	return nil
 }
 
+// FindStorageOrBuiltin returns the configured storage with the given ID, or
+// the built-in database or local storage when it is not registered.
+func FindStorageOrBuiltin(setting *storepb.InstanceStorageSetting, storageID string) *storepb.Storage {
+	if configuredStorage := FindStorage(setting, storageID); configuredStorage != nil {
+		return configuredStorage
+	}
+	switch storageID {
+	case databaseStorageID:
+		return builtinStorage(storepb.StorageType_STORAGE_TYPE_DATABASE)
+	case localStorageID:
+		return builtinStorage(storepb.StorageType_STORAGE_TYPE_LOCAL)
+	default:
+		return nil
+	}
+}
+
+// AttachmentStorageID returns the ID of the storage holding an attachment's
+// content, or an empty string for external links.
+func AttachmentStorageID(setting *storepb.InstanceStorageSetting, attachment *Attachment) string {
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED:
+		return databaseStorageID
+	case storepb.AttachmentStorageType_LOCAL:
+		return localStorageID
+	case storepb.AttachmentStorageType_S3:
+		s3Object := attachment.Payload.GetS3Object()
+		if s3Object == nil {
+			return ""
+		}
+		if resolvedStorage, err := ResolveStorage(setting, s3Object.StorageId, s3Object.S3Config); err == nil && resolvedStorage.Id != "" {
+			return resolvedStorage.Id
+		}
+		return s3Object.StorageId
+	case storepb.AttachmentStorageType_OBJECT:
+		return attachment.Payload.GetStorageObject().GetStorageId()
+	default:
+		return ""
+	}
+}
+
 // GetDefaultStorage returns the storage used for new attachments.
 func GetDefaultStorage(setting *storepb.InstanceStorageSetting) *storepb.Storage {
 	if setting == nil {
@@ -354,7 +413,13 @@ func StorageNamespaceEqual(a, b *storepb.Storage) bool {
 	if a == nil || b == nil || a.Type != b.Type {
 		return false
 	}
-	if a.Type != storepb.StorageType_STORAGE_TYPE_S3 {
+	switch a.Type {
+	case storepb.StorageType_STORAGE_TYPE_S3:
+	case storepb.StorageType_STORAGE_TYPE_LOCAL:
+		return normalizeLocalPath(a.GetLocalConfig().GetPath()) == normalizeLocalPath(b.GetLocalConfig().GetPath())
+	case storepb.StorageType_STORAGE_TYPE_WEBDAV, storepb.StorageType_STORAGE_TYPE_SFTP:
+		return storageNamespace(a) != "" && storageNamespace(a) == storageNamespace(b)
+	default:
 		return true
 	}
 	aConfig, bConfig := a.GetS3Config(), b.GetS3Config()
@@ -392,8 +457,17 @@ func normalizeStorage(storage *storepb.Storage) {
 	if storage == nil {
 		return
 	}
-	if storage.Type == storepb.StorageType_STORAGE_TYPE_UNSPECIFIED && storage.GetS3Config() != nil {
-		storage.Type = storepb.StorageType_STORAGE_TYPE_S3
+	if storage.Type == storepb.StorageType_STORAGE_TYPE_UNSPECIFIED {
+		switch storage.Config.(type) {
+		case *storepb.Storage_S3Config:
+			storage.Type = storepb.StorageType_STORAGE_TYPE_S3
+		case *storepb.Storage_LocalConfig:
+			storage.Type = storepb.StorageType_STORAGE_TYPE_LOCAL
+		case *storepb.Storage_WebdavConfig:
+			storage.Type = storepb.StorageType_STORAGE_TYPE_WEBDAV
+		case *storepb.Storage_SftpConfig:
+			storage.Type = storepb.StorageType_STORAGE_TYPE_SFTP
+		}
 	}
 	if storage.Id == "" {
 		storage.Id = storageID(storage)
@@ -411,7 +485,20 @@ func storageID(storage *storepb.Storage) string {
 	case storepb.StorageType_STORAGE_TYPE_DATABASE:
 		return databaseStorageID
 	case storepb.StorageType_STORAGE_TYPE_LOCAL:
+		if path := normalizeLocalPath(storage.GetLocalConfig().GetPath()); path != "" {
+			return "local-" + namespaceHash(path)
+		}
 		return localStorageID
+	case storepb.StorageType_STORAGE_TYPE_WEBDAV:
+		if namespace := storageNamespace(storage); namespace != "" {
+			return "webdav-" + namespaceHash(namespace)
+		}
+		return ""
+	case storepb.StorageType_STORAGE_TYPE_SFTP:
+		if namespace := storageNamespace(storage); namespace != "" {
+			return "sftp-" + namespaceHash(namespace)
+		}
+		return ""
 	case storepb.StorageType_STORAGE_TYPE_S3:
 		config := storage.GetS3Config()
 		if config == nil {
@@ -422,13 +509,39 @@ func storageID(storage *storepb.Storage) string {
 			strings.TrimSpace(config.Region),
 			strings.TrimSpace(config.Bucket),
 		}, "\x00")
-		hash := sha256.Sum256([]byte(namespace))
-		return "s3-" + hex.EncodeToString(hash[:])[:16]
+		return "s3-" + namespaceHash(namespace)
 	default:
 		return ""
 	}
 }
 
+// storageNamespace identifies the remote location of a WebDAV or SFTP storage.
+// Credentials are excluded so they can rotate without changing identity.
+func storageNamespace(storage *storepb.Storage) string {
+	switch storage.Type {
+	case storepb.StorageType_STORAGE_TYPE_WEBDAV:
+		return normalizeEndpoint(storage.GetWebdavConfig().GetEndpoint())
+	case storepb.StorageType_STORAGE_TYPE_SFTP:
+		config := storage.GetSftpConfig()
+		host := strings.TrimSpace(config.GetHost())
+		if host == "" {
+			return ""
+		}
+		port := config.GetPort()
+		if port == 0 {
+			port = 22
+		}
+		return net.JoinHostPort(host, strconv.Itoa(int(port))) + "\x00" + normalizeLocalPath(config.GetPath())
+	default:
+		return ""
+	}
+}
+
+func namespaceHash(namespace string) string {
+	hash := sha256.Sum256([]byte(namespace))
+	return hex.EncodeToString(hash[:])[:16]
+}
+
 func defaultStorageName(storage *storepb.Storage) string {
 	if storage == nil {
 		return ""
@@ -437,7 +550,20 @@ func defaultStorageName(storage *storepb.Storage) string {
 	case storepb.StorageType_STORAGE_TYPE_DATABASE:
 		return "Database"
 	case storepb.StorageType_STORAGE_TYPE_LOCAL:
+		if path := normalizeLocalPath(storage.GetLocalConfig().GetPath()); path != "" {
+			return path
+		}
 		return "Local"
+	case storepb.StorageType_STORAGE_TYPE_WEBDAV:
+		if endpoint := normalizeEndpoint(storage.GetWebdavConfig().GetEndpoint()); endpoint != "" {
+			return endpoint
+		}
+		return "WebDAV"
+	case storepb.StorageType_STORAGE_TYPE_SFTP:
+		if host := strings.TrimSpace(storage.GetSftpConfig().GetHost()); host != "" {
+			return host
+		}
+		return "SFTP"
 	case storepb.StorageType_STORAGE_TYPE_S3:
 		if bucket := strings.TrimSpace(storage.GetS3Config().GetBucket()); bucket != "" {
 			return bucket
@@ -477,6 +603,23 @@ func findStorageCredentialSource(storages []*storepb.Storage, target *storepb.St
 	return nil
 }
 
+// backfillStorageSecrets keeps the stored WebDAV and SFTP secrets when an
+// update omits them, since the API never returns them to clients.
+func backfillStorageSecrets(storage, previous *storepb.Storage) {
+	if previous == nil {
+		return
+	}
+	if config, previousConfig := storage.GetWebdavConfig(), previous.GetWebdavConfig(); config != nil && previousConfig != nil && config.Password == "" {
+		config.Password = previousConfig.Password
+	}
+	if config, previousConfig := storage.GetSftpConfig(), previous.GetSftpConfig(); config != nil && previousConfig != nil {
+		if config.Password == "" && config.PrivateKey == "" {
+			config.Password = previousConfig.Password
+			config.PrivateKey = previousConfig.PrivateKey
+		}
+	}
+}
+
 func findLegacyStorageCredentialSource(storages []*storepb.Storage, accessKeyID string) *storepb.Storage {
 	for _, storage := range storages {
 		config := storage.GetS3Config()
@@ -535,8 +678,24 @@ func validateInstanceStorages(setting *storepb.InstanceStorageSetting) error {
 		if storage.Type == storepb.StorageType_STORAGE_TYPE_UNSPECIFIED {
 			return errors.Errorf("storage %q type is required", storage.Id)
 		}
-		if storage.Type == storepb.StorageType_STORAGE_TYPE_S3 && storage.GetS3Config() == nil {
-			return errors.Errorf("storage %q S3 config is required", storage.Id)
+		switch storage.Type {
+		case storepb.StorageType_STORAGE_TYPE_S3:
+			if storage.GetS3Config() == nil {
+				return errors.Errorf("storage %q S3 config is required", storage.Id)
+			}
+		case storepb.StorageType_STORAGE_TYPE_LOCAL:
+			if path := storage.GetLocalConfig().GetPath(); path != "" && !filepath.IsAbs(path) {
+				return errors.Errorf("storage %q local path must be absolute", storage.Id)
+			}
+		case storepb.StorageType_STORAGE_TYPE_WEBDAV:
+			if storage.GetWebdavConfig().GetEndpoint() == "" {
+				return errors.Errorf("storage %q WebDAV endpoint is required", storage.Id)
+			}
+		case storepb.StorageType_STORAGE_TYPE_SFTP:
+			if storage.GetSftpConfig().GetHost() == "" || storage.GetSftpConfig().GetUsername() == "" {
+				return errors.Errorf("storage %q SFTP host and username are required", storage.Id)
+			}
+		default:
 		}
 	}
 	if !seenIDs[setting.DefaultStorageId] {
@@ -548,3 +707,10 @@ func validateInstanceStorages(setting *storepb.InstanceStorageSetting) error {
 func normalizeEndpoint(endpoint string) string {
 	return strings.TrimRight(strings.TrimSpace(endpoint), "/")
 }
+
+func normalizeLocalPath(path string) string {
+	if path = strings.TrimSpace(path); path == "" {
+		return ""
+	}
+	return filepath.Clean(path)
+}
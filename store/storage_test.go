@@ -188,6 +188,90 @@ func TestPrepareInstanceStorageSettingUpdateRejectsInvalidCanonicalRegistry(t *t
 	})
 }
 
+func TestPrepareInstanceStorageSettingUpdateReidentifiesMovedWebDAVAndSFTPStorages(t *testing.T) {
+	webdavStorage := func(endpoint, password string) *storepb.Storage {
+		return &storepb.Storage{
+			Id:   "nas-webdav",
+			Type: storepb.StorageType_STORAGE_TYPE_WEBDAV,
+			Config: &storepb.Storage_WebdavConfig{WebdavConfig: &storepb.StorageWebDAVConfig{
+				Endpoint: endpoint,
+				Username: "memos",
+				Password: password,
+			}},
+		}
+	}
+	sftpStorage := func(path, password string) *storepb.Storage {
+		return &storepb.Storage{
+			Id:   "nas-sftp",
+			Type: storepb.StorageType_STORAGE_TYPE_SFTP,
+			Config: &storepb.Storage_SftpConfig{SftpConfig: &storepb.StorageSFTPConfig{
+				Host:     "nas.example.com",
+				Username: "memos",
+				Password: password,
+				Path:     path,
+			}},
+		}
+	}
+	existing := &storepb.InstanceStorageSetting{
+		Storages:         []*storepb.Storage{webdavStorage("https://nas.example.com/dav", "dav-secret"), sftpStorage("/volume1/memos", "ssh-secret")},
+		DefaultStorageId: "nas-webdav",
+	}
+	store.NormalizeInstanceStorageSetting(existing)
+
+	incoming := &storepb.InstanceStorageSetting{
+		Storages:         []*storepb.Storage{webdavStorage("https://nas.example.com/dav/", ""), sftpStorage("/volume2/memos", "")},
+		DefaultStorageId: "nas-webdav",
+	}
+	require.NoError(t, store.PrepareInstanceStorageSettingUpdate(incoming, existing))
+
+	// A trailing slash keeps the WebDAV namespace, so its password carries over.
+	require.Equal(t, "dav-secret", store.FindStorage(incoming, "nas-webdav").GetWebdavConfig().GetPassword())
+	// A new SFTP directory is a new namespace; the previous one stays registered.
+	require.Equal(t, "/volume1/memos", store.FindStorage(incoming, "nas-sftp").GetSftpConfig().GetPath())
+	require.Len(t, incoming.Storages, 3)
+}
+
+func TestPrepareInstanceStorageSettingUpdateRejectsIncompleteObjectStorages(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage *storepb.Storage
+		wantErr string
+	}{
+		{
+			name:    "WebDAV without endpoint",
+			storage: &storepb.Storage{Id: "nas", Type: storepb.StorageType_STORAGE_TYPE_WEBDAV},
+			wantErr: `storage "nas" WebDAV endpoint is required`,
+		},
+		{
+			name: "SFTP without username",
+			storage: &storepb.Storage{
+				Id:     "nas",
+				Type:   storepb.StorageType_STORAGE_TYPE_SFTP,
+				Config: &storepb.Storage_SftpConfig{SftpConfig: &storepb.StorageSFTPConfig{Host: "nas.example.com"}},
+			},
+			wantErr: `storage "nas" SFTP host and username are required`,
+		},
+		{
+			name: "relative local path",
+			storage: &storepb.Storage{
+				Id:     "nas",
+				Type:   storepb.StorageType_STORAGE_TYPE_LOCAL,
+				Config: &storepb.Storage_LocalConfig{LocalConfig: &storepb.StorageLocalConfig{Path: "mnt/nas"}},
+			},
+			wantErr: `storage "nas" local path must be absolute`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := store.PrepareInstanceStorageSettingUpdate(&storepb.InstanceStorageSetting{
+				Storages:         []*storepb.Storage{test.storage},
+				DefaultStorageId: test.storage.Id,
+			}, nil)
+			require.EqualError(t, err, test.wantErr)
+		})
+	}
+}
+
 func TestNormalizeInstanceStorageSettingKeepsMostRecentlyActivatedStorageFirst(t *testing.T) {
 	recent := s3Storage("recent", "recent-bucket")
 	old := s3Storage("old", "old-bucket")
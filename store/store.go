@@ -20,6 +20,10 @@ type Store struct {
 	refreshTokenMu sync.Mutex
 	patMu          sync.Mutex
 	memoViewMu     sync.Mutex
+	memoTemplateMu sync.Mutex
+
+	// uploadSessionMutexes holds a *sync.Mutex per upload session UID.
+	uploadSessionMutexes sync.Map
 
 	deploymentConfigMu sync.RWMutex
 	deploymentConfig   *deploymentConfiguration
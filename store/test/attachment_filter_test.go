@@ -100,6 +100,78 @@ func TestAttachmentFilterFilenameUnicode(t *testing.T) {
 	require.Len(t, attachments, 1)
 }
 
+// =============================================================================
+// Transcript Field Tests
+// Schema: transcript (string, supports contains)
+// =============================================================================
+
+func TestAttachmentFilterTranscriptContains(t *testing.T) {
+	t.Parallel()
+	tc := NewAttachmentFilterTestContext(t)
+	defer tc.Close()
+
+	tc.CreateAttachment(NewAttachmentBuilder(tc.CreatorID).Filename("standup.webm").MimeType("audio/webm").Transcript("Standup: ship the release"))
+	tc.CreateAttachment(NewAttachmentBuilder(tc.CreatorID).Filename("call.webm").MimeType("audio/webm").Transcript("Call with the design team"))
+	tc.CreateAttachment(NewAttachmentBuilder(tc.CreatorID).Filename("untranscribed.webm").MimeType("audio/webm"))
+
+	attachments := tc.ListWithFilter(`transcript.contains("release")`)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "standup.webm", attachments[0].Filename)
+	require.Equal(t, "Standup: ship the release", attachments[0].Payload.GetTranscript().GetText())
+
+	attachments = tc.ListWithFilter(`transcript.contains("THE")`)
+	require.Len(t, attachments, 2)
+
+	attachments = tc.ListWithFilter(`mime_type == "audio/webm" && !transcript.contains("release")`)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "call.webm", attachments[0].Filename)
+}
+
+// =============================================================================
+// Image Analysis Field Tests
+// Schema: image_text, image_caption (string, supports contains)
+// =============================================================================
+
+func TestAttachmentFilterImageAnalysisContains(t *testing.T) {
+	t.Parallel()
+	tc := NewAttachmentFilterTestContext(t)
+	defer tc.Close()
+
+	tc.CreateAttachment(NewAttachmentBuilder(tc.CreatorID).Filename("receipt.png").MimeType("image/png").ImageAnalysis("TOTAL 42.00 EUR", "A paper receipt from a cafe"))
+	tc.CreateAttachment(NewAttachmentBuilder(tc.CreatorID).Filename("beach.jpg").MimeType("image/jpeg").ImageAnalysis("", "A sunny beach with palm trees"))
+	tc.CreateAttachment(NewAttachmentBuilder(tc.CreatorID).Filename("unanalyzed.png").MimeType("image/png"))
+
+	attachments := tc.ListWithFilter(`image_text.contains("total")`)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "receipt.png", attachments[0].Filename)
+
+	attachments = tc.ListWithFilter(`image_caption.contains("beach")`)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "beach.jpg", attachments[0].Filename)
+
+	attachments = tc.ListWithFilter(`image_text.contains("cafe") || image_caption.contains("cafe")`)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "receipt.png", attachments[0].Filename)
+}
+
+func TestAttachmentFilterDocumentTextContains(t *testing.T) {
+	t.Parallel()
+	tc := NewAttachmentFilterTestContext(t)
+	defer tc.Close()
+
+	tc.CreateAttachment(NewAttachmentBuilder(tc.CreatorID).Filename("contract.pdf").MimeType("application/pdf").DocumentText("Service agreement between both parties"))
+	tc.CreateAttachment(NewAttachmentBuilder(tc.CreatorID).Filename("budget.csv").MimeType("text/csv").DocumentText("item,amount\ncoffee,3"))
+	tc.CreateAttachment(NewAttachmentBuilder(tc.CreatorID).Filename("unextracted.pdf").MimeType("application/pdf"))
+
+	attachments := tc.ListWithFilter(`document_text.contains("AGREEMENT")`)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "contract.pdf", attachments[0].Filename)
+
+	attachments = tc.ListWithFilter(`document_text.startsWith("item")`)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "budget.csv", attachments[0].Filename)
+}
+
 // =============================================================================
 // Mime Type Field Tests
 // Schema: mime_type (string, ==, !=)
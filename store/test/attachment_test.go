@@ -3,6 +3,8 @@ package test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -74,6 +76,18 @@ func TestAttachmentNeedsInstanceStorageSetting(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "storage object attachment",
+			attachment: &store.Attachment{
+				StorageType: storepb.AttachmentStorageType_OBJECT,
+				Payload: &storepb.AttachmentPayload{
+					Payload: &storepb.AttachmentPayload_StorageObject_{
+						StorageObject: &storepb.AttachmentPayload_StorageObject{StorageId: "webdav-nas", Key: "assets/a.txt"},
+					},
+				},
+			},
+			want: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -445,6 +459,173 @@ func TestAttachmentUpdate(t *testing.T) {
 	ts.Close()
 }
 
+func TestAttachmentContentShared(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+
+	digest := "5d41402abc4b2a76b9719d911017c592aabbccddeeff00112233445566778899"
+	createLocal := func(reference string) *store.Attachment {
+		attachment, err := ts.CreateAttachment(ctx, &store.Attachment{
+			UID:         shortuuid.New(),
+			CreatorID:   101,
+			Filename:    "shared.txt",
+			Type:        "text/plain",
+			Size:        5,
+			StorageType: storepb.AttachmentStorageType_LOCAL,
+			Reference:   reference,
+			SHA256:      digest,
+		})
+		require.NoError(t, err)
+		return attachment
+	}
+	first := createLocal("assets/shared.txt")
+	second := createLocal("assets/shared.txt")
+	elsewhere := createLocal("assets/other.txt")
+
+	found, err := ts.ListAttachments(ctx, &store.FindAttachment{SHA256: &digest})
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	require.Equal(t, digest, found[0].SHA256)
+
+	shared, err := ts.AttachmentContentShared(ctx, first)
+	require.NoError(t, err)
+	require.True(t, shared)
+	shared, err = ts.AttachmentContentShared(ctx, elsewhere)
+	require.NoError(t, err)
+	require.False(t, shared, "content at another location is not shared")
+
+	require.NoError(t, ts.DeleteAttachments(ctx, []*store.Attachment{second}))
+	shared, err = ts.AttachmentContentShared(ctx, first)
+	require.NoError(t, err)
+	require.False(t, shared)
+
+	// Database blobs are never shared, whatever their digest.
+	blob, err := ts.CreateAttachment(ctx, &store.Attachment{UID: shortuuid.New(), CreatorID: 101, Filename: "blob.txt", Blob: []byte("hello"), SHA256: digest})
+	require.NoError(t, err)
+	shared, err = ts.AttachmentContentShared(ctx, blob)
+	require.NoError(t, err)
+	require.False(t, shared)
+
+	ts.Close()
+}
+
+func TestSharedAttachmentContentRelease(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+
+	digest := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	reference := "assets/release.txt"
+	path := ts.UploadSessionStagingPath(reference)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+	source := &store.Attachment{
+		UID:         shortuuid.New(),
+		CreatorID:   101,
+		Filename:    "release.txt",
+		Type:        "text/plain",
+		Size:        5,
+		StorageType: storepb.AttachmentStorageType_LOCAL,
+		Reference:   reference,
+		SHA256:      digest,
+	}
+	first, err := ts.CreateAttachment(ctx, source)
+	require.NoError(t, err)
+	reuse := func() (*store.Attachment, error) {
+		return ts.CreateAttachment(ctx, &store.Attachment{
+			UID:           shortuuid.New(),
+			CreatorID:     102,
+			Filename:      "reused.txt",
+			Type:          "text/plain",
+			Size:          5,
+			StorageType:   storepb.AttachmentStorageType_LOCAL,
+			Reference:     reference,
+			SHA256:        digest,
+			SharedContent: true,
+		})
+	}
+	second, err := reuse()
+	require.NoError(t, err)
+
+	// Concurrent deletes of the last two references still release the object.
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, attachment := range []*store.Attachment{first, second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: attachment.ID})
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// Reusing content whose last reference is gone fails instead of leaving
+	// a dangling reference.
+	_, err = reuse()
+	require.ErrorIs(t, err, store.ErrAttachmentContentReleased)
+	found, err := ts.ListAttachments(ctx, &store.FindAttachment{SHA256: &digest})
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	ts.Close()
+}
+
+func TestUpdateAttachmentPayload(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+
+	attachment, err := ts.CreateAttachment(ctx, &store.Attachment{
+		UID:       shortuuid.New(),
+		CreatorID: 101,
+		Filename:  "voice.m4a",
+		Type:      "audio/mp4",
+		Blob:      []byte("audio"),
+		Size:      5,
+	})
+	require.NoError(t, err)
+
+	// Concurrent updates of different payload fields keep each other's result.
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	updates := []func(*storepb.AttachmentPayload) error{
+		func(payload *storepb.AttachmentPayload) error {
+			payload.Transcript = &storepb.AudioTranscript{Text: "hello"}
+			return nil
+		},
+		func(payload *storepb.AttachmentPayload) error {
+			payload.MediaPreview = &storepb.MediaPreview{WaveformPeaks: []float32{0.5}}
+			return nil
+		},
+	}
+	for i, update := range updates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = ts.UpdateAttachmentPayload(ctx, attachment.ID, update)
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	updated, err := ts.GetAttachment(ctx, &store.FindAttachment{ID: &attachment.ID})
+	require.NoError(t, err)
+	require.Equal(t, "hello", updated.Payload.GetTranscript().GetText())
+	require.Equal(t, []float32{0.5}, updated.Payload.GetMediaPreview().GetWaveformPeaks())
+
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: attachment.ID}))
+	found, err := ts.UpdateAttachmentPayload(ctx, attachment.ID, updates[0])
+	require.NoError(t, err)
+	require.False(t, found)
+
+	ts.Close()
+}
+
 func TestAttachmentGetByUID(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -622,3 +803,40 @@ func TestMemoMutationConcurrentUpdatesSameMemoNoSQLiteBusy(t *testing.T) {
 		require.ErrorIs(t, err, store.ErrMemoMutationConflict)
 	}
 }
+
+func TestListAttachmentUsage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+
+	for creatorID, sizes := range map[int32][]int64{101: {10, 20}, 102: {100}, 103: {5, 5, 5}} {
+		for _, size := range sizes {
+			_, err := ts.CreateAttachment(ctx, &store.Attachment{
+				UID:       shortuuid.New(),
+				CreatorID: creatorID,
+				Filename:  "usage.txt",
+				Type:      "text/plain",
+				Blob:      make([]byte, size),
+				Size:      size,
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	limit := 2
+	usages, err := ts.ListAttachmentUsage(ctx, &store.FindAttachmentUsage{Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, []*store.AttachmentUsage{
+		{CreatorID: 102, AttachmentCount: 1, TotalSize: 100},
+		{CreatorID: 101, AttachmentCount: 2, TotalSize: 30},
+	}, usages)
+
+	usage, err := ts.GetUserAttachmentUsage(ctx, 103)
+	require.NoError(t, err)
+	require.Equal(t, &store.AttachmentUsage{CreatorID: 103, AttachmentCount: 3, TotalSize: 15}, usage)
+	usage, err = ts.GetUserAttachmentUsage(ctx, 104)
+	require.NoError(t, err)
+	require.Zero(t, usage.TotalSize)
+
+	ts.Close()
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestAttachmentVariantStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	attachment, err := ts.CreateAttachment(ctx, &store.Attachment{
+		UID:       "variant-source",
+		CreatorID: user.ID,
+		Filename:  "photo.png",
+		Blob:      []byte("png"),
+		Type:      "image/png",
+		Size:      3,
+	})
+	require.NoError(t, err)
+
+	small, err := ts.UpsertAttachmentVariant(ctx, &store.AttachmentVariant{
+		AttachmentID: attachment.ID,
+		Name:         "small.jpeg",
+		Type:         "image/jpeg",
+		Size:         10,
+		Reference:    store.AttachmentVariantKey(attachment, "small.jpeg"),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, small.ID)
+	require.NotZero(t, small.AccessedTs)
+	require.Equal(t, ".variants/variant-source/small.jpeg", small.Reference)
+
+	// Upserting the same name replaces the recorded variant.
+	replaced, err := ts.UpsertAttachmentVariant(ctx, &store.AttachmentVariant{
+		AttachmentID: attachment.ID,
+		Name:         "small.jpeg",
+		Type:         "image/jpeg",
+		Size:         20,
+		Reference:    small.Reference,
+	})
+	require.NoError(t, err)
+	require.Equal(t, small.ID, replaced.ID)
+	require.Equal(t, int64(20), replaced.Size)
+
+	large, err := ts.UpsertAttachmentVariant(ctx, &store.AttachmentVariant{
+		AttachmentID: attachment.ID,
+		Name:         "large.webp",
+		Type:         "image/webp",
+		Size:         30,
+		Reference:    store.AttachmentVariantKey(attachment, "large.webp"),
+	})
+	require.NoError(t, err)
+
+	accessedTs := int64(100)
+	require.NoError(t, ts.UpdateAttachmentVariant(ctx, &store.UpdateAttachmentVariant{ID: small.ID, AccessedTs: &accessedTs}))
+	variants, err := ts.ListAttachmentVariants(ctx, &store.FindAttachmentVariant{AttachmentID: &attachment.ID})
+	require.NoError(t, err)
+	require.Len(t, variants, 2)
+	require.Equal(t, large.ID, variants[0].ID)
+	require.Equal(t, int64(100), variants[1].AccessedTs)
+
+	accessedBefore := int64(200)
+	stale, err := ts.ListAttachmentVariants(ctx, &store.FindAttachmentVariant{AccessedBefore: &accessedBefore})
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	require.Equal(t, small.ID, stale[0].ID)
+
+	require.NoError(t, ts.DeleteAttachmentVariant(ctx, &store.DeleteAttachmentVariant{ID: small.ID}))
+	name := "small.jpeg"
+	found, err := ts.GetAttachmentVariant(ctx, &store.FindAttachmentVariant{AttachmentID: &attachment.ID, Name: &name})
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	ts.Close()
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestDailyMemoStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	first, err := ts.CreateMemo(ctx, &store.Memo{UID: "daily-first", CreatorID: user.ID, Content: "first", Visibility: store.Private})
+	require.NoError(t, err)
+	second, err := ts.CreateMemo(ctx, &store.Memo{UID: "daily-second", CreatorID: user.ID, Content: "second", Visibility: store.Private})
+	require.NoError(t, err)
+
+	created, err := ts.CreateDailyMemo(ctx, &store.DailyMemo{CreatorID: user.ID, Date: "2026-03-02", MemoID: first.ID})
+	require.NoError(t, err)
+	require.True(t, created)
+
+	// The first daily memo of a day wins; later ones are not recorded.
+	created, err = ts.CreateDailyMemo(ctx, &store.DailyMemo{CreatorID: user.ID, Date: "2026-03-02", MemoID: second.ID})
+	require.NoError(t, err)
+	require.False(t, created)
+	dailyMemo, err := ts.GetDailyMemo(ctx, user.ID, "2026-03-02")
+	require.NoError(t, err)
+	require.Equal(t, first.ID, dailyMemo.MemoID)
+	require.NotZero(t, dailyMemo.CreatedTs)
+
+	created, err = ts.CreateDailyMemo(ctx, &store.DailyMemo{CreatorID: user.ID, Date: "2026-04-01", MemoID: second.ID})
+	require.NoError(t, err)
+	require.True(t, created)
+	startDate, endDate := "2026-03-01", "2026-03-31"
+	list, err := ts.ListDailyMemos(ctx, &store.FindDailyMemo{CreatorID: &user.ID, StartDate: &startDate, EndDate: &endDate})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "2026-03-02", list[0].Date)
+
+	// Deleting the memo drops its daily memo entry.
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: first.ID}))
+	dailyMemo, err = ts.GetDailyMemo(ctx, user.ID, "2026-03-02")
+	require.NoError(t, err)
+	require.Nil(t, dailyMemo)
+
+	require.NoError(t, ts.DeleteDailyMemo(ctx, &store.DeleteDailyMemo{CreatorID: user.ID, Date: "2026-04-01"}))
+	list, err = ts.ListDailyMemos(ctx, &store.FindDailyMemo{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Empty(t, list)
+	ts.Close()
+}
@@ -79,6 +79,14 @@ func (b *MemoBuilder) Tags(tags ...string) *MemoBuilder {
 	return b
 }
 
+func (b *MemoBuilder) Mentions(usernames ...string) *MemoBuilder {
+	if b.memo.Payload == nil {
+		b.memo.Payload = &storepb.MemoPayload{}
+	}
+	b.memo.Payload.Mentions = usernames
+	return b
+}
+
 func (b *MemoBuilder) Property(fn func(*storepb.MemoPayload_Property)) *MemoBuilder {
 	if b.memo.Payload == nil {
 		b.memo.Payload = &storepb.MemoPayload{}
@@ -90,6 +98,14 @@ func (b *MemoBuilder) Property(fn func(*storepb.MemoPayload_Property)) *MemoBuil
 	return b
 }
 
+func (b *MemoBuilder) Properties(properties map[string]*storepb.MemoPayload_PropertyValue) *MemoBuilder {
+	if b.memo.Payload == nil {
+		b.memo.Payload = &storepb.MemoPayload{}
+	}
+	b.memo.Payload.Properties = properties
+	return b
+}
+
 func (b *MemoBuilder) Build() *store.Memo {
 	return b.memo
 }
@@ -131,6 +147,30 @@ func (b *AttachmentBuilder) Size(size int64) *AttachmentBuilder {
 	return b
 }
 
+func (b *AttachmentBuilder) Transcript(text string) *AttachmentBuilder {
+	if b.attachment.Payload == nil {
+		b.attachment.Payload = &storepb.AttachmentPayload{}
+	}
+	b.attachment.Payload.Transcript = &storepb.AudioTranscript{Text: text}
+	return b
+}
+
+func (b *AttachmentBuilder) ImageAnalysis(text, caption string) *AttachmentBuilder {
+	if b.attachment.Payload == nil {
+		b.attachment.Payload = &storepb.AttachmentPayload{}
+	}
+	b.attachment.Payload.ImageAnalysis = &storepb.ImageAnalysis{Text: text, Caption: caption}
+	return b
+}
+
+func (b *AttachmentBuilder) DocumentText(text string) *AttachmentBuilder {
+	if b.attachment.Payload == nil {
+		b.attachment.Payload = &storepb.AttachmentPayload{}
+	}
+	b.attachment.Payload.Document = &storepb.DocumentExtraction{Text: text}
+	return b
+}
+
 func (b *AttachmentBuilder) Build() *store.Attachment {
 	return b.attachment
 }
@@ -290,6 +290,157 @@ func TestMemoFilterContentMatches(t *testing.T) {
 	require.Len(t, memos, 0)
 }
 
+// =============================================================================
+// Transcript Field Tests
+// Schema: transcript (string, text matching over linked attachments)
+// =============================================================================
+
+func TestMemoFilterTranscript(t *testing.T) {
+	t.Parallel()
+	tc := NewMemoFilterTestContext(t)
+	defer tc.Close()
+
+	voiceMemo := tc.CreateMemo(NewMemoBuilder("memo-voice", tc.User.ID).Content("Voice note"))
+	tc.CreateMemo(NewMemoBuilder("memo-text", tc.User.ID).Content("Written note"))
+	_, err := tc.Store.CreateAttachment(tc.Ctx, NewAttachmentBuilder(tc.User.ID).
+		Filename("recording.webm").
+		MimeType("audio/webm").
+		MemoID(&voiceMemo.ID).
+		Transcript("Discussed the quarterly roadmap").
+		Build())
+	require.NoError(t, err)
+	// An unlinked transcript must not surface any memo.
+	_, err = tc.Store.CreateAttachment(tc.Ctx, NewAttachmentBuilder(tc.User.ID).
+		Filename("draft.webm").
+		MimeType("audio/webm").
+		Transcript("Unlinked budget review").
+		Build())
+	require.NoError(t, err)
+
+	memos := tc.ListWithFilter(`transcript.contains("ROADMAP")`)
+	require.Equal(t, []string{"memo-voice"}, uids(memos))
+
+	memos = tc.ListWithFilter(`transcript.startsWith("Discussed")`)
+	require.Equal(t, []string{"memo-voice"}, uids(memos))
+
+	memos = tc.ListWithFilter(`transcript.contains("budget")`)
+	require.Empty(t, memos)
+
+	// Plain content search also finds memos by their transcripts.
+	memos = tc.ListWithFilter(`content.contains("roadmap")`)
+	require.Equal(t, []string{"memo-voice"}, uids(memos))
+
+	memos = tc.ListWithFilter(`content.contains("note")`)
+	require.Len(t, memos, 2)
+
+	memos = tc.ListWithFilter(`!content.contains("roadmap")`)
+	require.Equal(t, []string{"memo-text"}, uids(memos))
+}
+
+func TestMemoFilterDocumentText(t *testing.T) {
+	t.Parallel()
+	tc := NewMemoFilterTestContext(t)
+	defer tc.Close()
+
+	contractMemo := tc.CreateMemo(NewMemoBuilder("memo-contract", tc.User.ID).Content("Signed today"))
+	tc.CreateMemo(NewMemoBuilder("memo-plain", tc.User.ID).Content("Nothing attached"))
+	_, err := tc.Store.CreateAttachment(tc.Ctx, NewAttachmentBuilder(tc.User.ID).
+		Filename("contract.pdf").
+		MimeType("application/pdf").
+		MemoID(&contractMemo.ID).
+		DocumentText("Service agreement with a termination clause").
+		Build())
+	require.NoError(t, err)
+
+	memos := tc.ListWithFilter(`document_text.contains("Termination")`)
+	require.Equal(t, []string{"memo-contract"}, uids(memos))
+
+	// Plain content search also finds memos by their attached documents.
+	memos = tc.ListWithFilter(`content.contains("agreement")`)
+	require.Equal(t, []string{"memo-contract"}, uids(memos))
+
+	memos = tc.ListWithFilter(`!content.contains("agreement")`)
+	require.Equal(t, []string{"memo-plain"}, uids(memos))
+}
+
+func TestMemoFilterMentions(t *testing.T) {
+	t.Parallel()
+	tc := NewMemoFilterTestContext(t)
+	defer tc.Close()
+
+	tc.CreateMemo(NewMemoBuilder("memo-alice", tc.User.ID).Content("Hi @alice").Mentions("alice"))
+	tc.CreateMemo(NewMemoBuilder("memo-both", tc.User.ID).Content("@alice and @bob").Mentions("alice", "bob"))
+	tc.CreateMemo(NewMemoBuilder("memo-none", tc.User.ID).Content("Nobody"))
+
+	memos := tc.ListWithFilter(`"users/alice" in mentions`)
+	require.ElementsMatch(t, []string{"memo-alice", "memo-both"}, uids(memos))
+
+	memos = tc.ListWithFilter(`"users/bob" in mentions && "users/alice" in mentions`)
+	require.Equal(t, []string{"memo-both"}, uids(memos))
+
+	memos = tc.ListWithFilter(`size(mentions) == 0`)
+	require.Equal(t, []string{"memo-none"}, uids(memos))
+
+	// Values must be user resource names.
+	memos = tc.ListWithFilter(`"alice" in mentions`)
+	require.Empty(t, memos)
+}
+
+func TestMemoFilterReferences(t *testing.T) {
+	t.Parallel()
+	tc := NewMemoFilterTestContext(t)
+	defer tc.Close()
+
+	target := tc.CreateMemo(NewMemoBuilder("memo-target", tc.User.ID).Content("Target"))
+	source := tc.CreateMemo(NewMemoBuilder("memo-source", tc.User.ID).Content("Links to [[memo-target]]"))
+	tc.CreateMemo(NewMemoBuilder("memo-other", tc.User.ID).Content("Unrelated"))
+	_, err := tc.Store.UpsertMemoRelation(tc.Ctx, &store.MemoRelation{MemoID: source.ID, RelatedMemoID: target.ID, Type: store.MemoRelationReference})
+	require.NoError(t, err)
+
+	memos := tc.ListWithFilter(`"memos/memo-target" in references`)
+	require.Equal(t, []string{"memo-source"}, uids(memos))
+
+	memos = tc.ListWithFilter(`"memos/memo-source" in referenced_by`)
+	require.Equal(t, []string{"memo-target"}, uids(memos))
+
+	memos = tc.ListWithFilter(`size(referenced_by) == 0 && size(references) == 0`)
+	require.Equal(t, []string{"memo-other"}, uids(memos))
+
+	memos = tc.ListWithFilter(`"memos/memo-other" in references`)
+	require.Empty(t, memos)
+}
+
+func TestMemoFilterProps(t *testing.T) {
+	t.Parallel()
+	tc := NewMemoFilterTestContext(t)
+	defer tc.Close()
+
+	now := time.Now().Unix()
+	tc.CreateMemo(NewMemoBuilder("memo-done", tc.User.ID).Properties(map[string]*storepb.MemoPayload_PropertyValue{
+		"status":   {Kind: &storepb.MemoPayload_PropertyValue_StringValue{StringValue: "done"}},
+		"priority": {Kind: &storepb.MemoPayload_PropertyValue_NumberValue{NumberValue: 3}},
+		"due":      {Kind: &storepb.MemoPayload_PropertyValue_DateTs{DateTs: now - 86400}},
+		"shared":   {Kind: &storepb.MemoPayload_PropertyValue_BoolValue{BoolValue: true}},
+		"people":   {Kind: &storepb.MemoPayload_PropertyValue_ListValue{ListValue: &storepb.MemoPayload_StringList{Values: []string{"alice", "bob"}}}},
+	}))
+	tc.CreateMemo(NewMemoBuilder("memo-todo", tc.User.ID).Properties(map[string]*storepb.MemoPayload_PropertyValue{
+		"status":   {Kind: &storepb.MemoPayload_PropertyValue_StringValue{StringValue: "todo"}},
+		"priority": {Kind: &storepb.MemoPayload_PropertyValue_NumberValue{NumberValue: 1.5}},
+		"due":      {Kind: &storepb.MemoPayload_PropertyValue_DateTs{DateTs: now + 86400}},
+		"shared":   {Kind: &storepb.MemoPayload_PropertyValue_BoolValue{BoolValue: false}},
+	}))
+	tc.CreateMemo(NewMemoBuilder("memo-plain", tc.User.ID).Content("No properties"))
+
+	require.Equal(t, []string{"memo-done"}, uids(tc.ListWithFilter(`props.status == "done"`)))
+	require.Equal(t, []string{"memo-todo"}, uids(tc.ListWithFilter(`props["status"] != "done"`)))
+	require.Equal(t, []string{"memo-done"}, uids(tc.ListWithFilter(`props.due < now`)))
+	require.Equal(t, []string{"memo-todo"}, uids(tc.ListWithFilter(`props.due > now && props.priority < 2`)))
+	require.Equal(t, []string{"memo-done"}, uids(tc.ListWithFilter(`props.shared`)))
+	require.Equal(t, []string{"memo-todo"}, uids(tc.ListWithFilter(`props.shared == false`)))
+	require.Equal(t, []string{"memo-done"}, uids(tc.ListWithFilter(`"bob" in props.people`)))
+	require.Empty(t, tc.ListWithFilter(`props.missing == "done"`))
+}
+
 // =============================================================================
 // Visibility Field Tests
 // Schema: visibility (string, ==, !=)
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestApplyTagMutation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	first, err := ts.CreateMemo(ctx, &store.Memo{UID: "tag-first", CreatorID: user.ID, Content: "#old one", Visibility: store.Private})
+	require.NoError(t, err)
+	second, err := ts.CreateMemo(ctx, &store.Memo{UID: "tag-second", CreatorID: user.ID, Content: "#old two", Visibility: store.Private})
+	require.NoError(t, err)
+	settings := []*storepb.UserSetting{{
+		UserId: user.ID,
+		Key:    storepb.UserSetting_TAGS,
+		Value: &storepb.UserSetting_Tags{Tags: &storepb.TagsUserSetting{Tags: map[string]*storepb.UserTagMetadata{
+			"new": {BlurContent: true},
+		}}},
+	}}
+
+	// A memo that changed since the mutation was prepared rolls back the batch.
+	err = ts.ApplyTagMutation(ctx, &store.TagMutation{
+		Memos: []*store.MemoTagRewrite{
+			{MemoID: first.ID, ExpectedContent: "#old one", Content: "#new one", Payload: &storepb.MemoPayload{Tags: []string{"new"}}},
+			{MemoID: second.ID, ExpectedContent: "#old edited", Content: "#new two", Payload: &storepb.MemoPayload{Tags: []string{"new"}}},
+		},
+		UserSettings: settings,
+	})
+	require.ErrorIs(t, err, store.ErrMemoMutationConflict)
+	memo, err := ts.GetMemo(ctx, &store.FindMemo{ID: &first.ID})
+	require.NoError(t, err)
+	require.Equal(t, "#old one", memo.Content)
+	setting, err := ts.GetUserSetting(ctx, &store.FindUserSetting{UserID: &user.ID, Key: storepb.UserSetting_TAGS})
+	require.NoError(t, err)
+	require.Empty(t, setting.GetTags().GetTags())
+
+	err = ts.ApplyTagMutation(ctx, &store.TagMutation{
+		Memos: []*store.MemoTagRewrite{
+			{MemoID: first.ID, ExpectedContent: "#old one", Content: "#new one", Payload: &storepb.MemoPayload{Tags: []string{"new"}}},
+			{MemoID: second.ID, ExpectedContent: "#old two", Content: "#new two", Payload: &storepb.MemoPayload{Tags: []string{"new"}}},
+		},
+		UserSettings: settings,
+	})
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &second.ID})
+	require.NoError(t, err)
+	require.Equal(t, "#new two", memo.Content)
+	require.Equal(t, []string{"new"}, memo.Payload.Tags)
+	setting, err = ts.GetUserSetting(ctx, &store.FindUserSetting{UserID: &user.ID, Key: storepb.UserSetting_TAGS})
+	require.NoError(t, err)
+	require.True(t, setting.GetTags().GetTags()["new"].BlurContent)
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestUploadSessionStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	session, err := ts.CreateUploadSession(ctx, &store.UploadSession{
+		UID:       "session",
+		CreatorID: user.ID,
+		Filename:  "video.mp4",
+		Type:      "video/mp4",
+		Size:      100,
+		Payload: &storepb.UploadSessionPayload{
+			AttachmentUid: "attachment",
+			ChunkSize:     64,
+			Target:        &storepb.UploadSessionPayload_StagingPath{StagingPath: ".upload_sessions/session.part"},
+		},
+		ExpiresTs: 1000,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, session.ID)
+	require.NotZero(t, session.CreatedTs)
+
+	uid := "session"
+	found, err := ts.GetUploadSession(ctx, &store.FindUploadSession{UID: &uid})
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.Equal(t, int64(100), found.Size)
+	require.Zero(t, found.ReceivedSize)
+	require.Equal(t, "attachment", found.Payload.AttachmentUid)
+	require.Equal(t, ".upload_sessions/session.part", found.Payload.GetStagingPath())
+
+	receivedSize := int64(64)
+	expiresTs := int64(2000)
+	found.Payload.Target = &storepb.UploadSessionPayload_MultipartUpload_{
+		MultipartUpload: &storepb.UploadSessionPayload_MultipartUpload{
+			Key:      "assets/video.mp4",
+			UploadId: "upload",
+			Parts:    []*storepb.UploadSessionPayload_MultipartUpload_Part{{PartNumber: 1, Etag: "etag"}},
+		},
+	}
+	require.NoError(t, ts.UpdateUploadSession(ctx, &store.UpdateUploadSession{
+		ID:           session.ID,
+		ReceivedSize: &receivedSize,
+		Payload:      found.Payload,
+		ExpiresTs:    &expiresTs,
+	}))
+	found, err = ts.GetUploadSession(ctx, &store.FindUploadSession{ID: &session.ID})
+	require.NoError(t, err)
+	require.Equal(t, receivedSize, found.ReceivedSize)
+	require.Equal(t, expiresTs, found.ExpiresTs)
+	require.Equal(t, "etag", found.Payload.GetMultipartUpload().GetParts()[0].GetEtag())
+
+	// Only sessions that expired before the timestamp are matched.
+	expiresBefore := int64(2000)
+	expired, err := ts.ListUploadSessions(ctx, &store.FindUploadSession{ExpiresBefore: &expiresBefore})
+	require.NoError(t, err)
+	require.Empty(t, expired)
+	expiresBefore = 2001
+	expired, err = ts.ListUploadSessions(ctx, &store.FindUploadSession{ExpiresBefore: &expiresBefore})
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+
+	require.NoError(t, ts.DeleteUploadSession(ctx, &store.DeleteUploadSession{ID: session.ID}))
+	found, err = ts.GetUploadSession(ctx, &store.FindUploadSession{ID: &session.ID})
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
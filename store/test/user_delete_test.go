@@ -2,8 +2,13 @@ package test
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/lithammer/shortuuid/v4"
 	"github.com/stretchr/testify/require"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -245,3 +250,66 @@ func TestDeleteUserCleansRelatedData(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, setting)
 }
+
+func TestDeleteUserReleasesAttachmentContentUnderLock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+
+	digest := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	for i := range 10 {
+		owner, err := createTestingUserWithRole(ctx, ts, fmt.Sprintf("release-owner-%d", i), store.RoleUser)
+		require.NoError(t, err)
+		reference := fmt.Sprintf("assets/user-release-%d.txt", i)
+		path := ts.UploadSessionStagingPath(reference)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+		attachment := &store.Attachment{
+			UID:         shortuuid.New(),
+			CreatorID:   owner.ID,
+			Filename:    "release.txt",
+			Type:        "text/plain",
+			Size:        5,
+			StorageType: storepb.AttachmentStorageType_LOCAL,
+			Reference:   reference,
+			SHA256:      digest,
+		}
+		_, err = ts.CreateAttachment(ctx, attachment)
+		require.NoError(t, err)
+
+		// An upload reusing the content races the deletion of its owner:
+		// either the reuse fails or the object outlives the owner.
+		var wg sync.WaitGroup
+		var reuseErr, deleteErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			reused := *attachment
+			reused.UID = shortuuid.New()
+			reused.CreatorID = 101
+			reused.SharedContent = true
+			_, reuseErr = ts.CreateAttachment(ctx, &reused)
+		}()
+		go func() {
+			defer wg.Done()
+			result, err := ts.DeleteUser(ctx, &store.DeleteUser{ID: owner.ID})
+			if err != nil {
+				deleteErr = err
+				return
+			}
+			deleteErr = ts.ReleaseAttachmentContent(ctx, result.Attachments)
+		}()
+		wg.Wait()
+		require.NoError(t, deleteErr)
+
+		_, statErr := os.Stat(path)
+		if reuseErr != nil {
+			require.ErrorIs(t, reuseErr, store.ErrAttachmentContentReleased)
+			require.ErrorIs(t, statErr, os.ErrNotExist)
+		} else {
+			require.NoError(t, statErr)
+		}
+	}
+}
@@ -323,6 +323,56 @@ func TestUserSettingWebhooks(t *testing.T) {
 	ts.Close()
 }
 
+func TestModifyUserWebhookConcurrently(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	require.NoError(t, ts.AddUserWebhook(ctx, user.ID, &storepb.WebhooksUserSetting_Webhook{
+		Id:    "webhook-1",
+		Title: "Deploy Hook",
+		Url:   "https://example.com/webhook",
+	}))
+
+	// Counting failures races edits of other fields; neither undoes the other.
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 5 {
+				_, err := ts.ModifyUserWebhook(ctx, user.ID, "webhook-1", func(hook *storepb.WebhooksUserSetting_Webhook) bool {
+					hook.FailureCount++
+					return true
+				})
+				require.NoError(t, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := ts.ModifyUserWebhook(ctx, user.ID, "webhook-1", func(hook *storepb.WebhooksUserSetting_Webhook) bool {
+				hook.EventTypes = append(hook.EventTypes, "event-"+strconv.Itoa(i))
+				return true
+			})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	webhooks, err := ts.GetUserWebhooks(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	require.Equal(t, int32(20), webhooks[0].FailureCount)
+	require.ElementsMatch(t, []string{"event-0", "event-1", "event-2", "event-3"}, webhooks[0].EventTypes)
+
+	missing, err := ts.ModifyUserWebhook(ctx, user.ID, "webhook-2", func(*storepb.WebhooksUserSetting_Webhook) bool { return true })
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	ts.Close()
+}
+
 func TestUserSettingMemoViews(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -1104,3 +1154,50 @@ func TestUserSettingJSONFieldsEdgeCases(t *testing.T) {
 
 	ts.Close()
 }
+
+func TestMemoTemplates(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	require.NoError(t, ts.AddMemoTemplate(ctx, &user.ID, &storepb.MemoTemplate{Id: "standup", Title: "Standup", Content: "{{date}}"}))
+	require.NoError(t, ts.AddMemoTemplate(ctx, nil, &storepb.MemoTemplate{Id: "shared", Title: "Shared", Content: "shared"}))
+
+	templates, err := ts.ListMemoTemplates(ctx, &user.ID)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	require.Equal(t, "standup", templates[0].Id)
+	instanceTemplates, err := ts.ListMemoTemplates(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, instanceTemplates, 1)
+	require.Equal(t, "shared", instanceTemplates[0].Id)
+
+	// Listed templates are copies of the cached setting.
+	templates[0].Title = "Mutated"
+	title := "Daily"
+	updated, err := ts.UpdateMemoTemplate(ctx, &user.ID, "standup", &store.UpdateMemoTemplate{Title: &title, Tags: []string{"daily"}})
+	require.NoError(t, err)
+	require.Equal(t, "Daily", updated.Title)
+	require.Equal(t, "{{date}}", updated.Content)
+	template, err := ts.GetMemoTemplate(ctx, &user.ID, "standup")
+	require.NoError(t, err)
+	require.Equal(t, []string{"daily"}, template.Tags)
+
+	missing, err := ts.UpdateMemoTemplate(ctx, nil, "standup", &store.UpdateMemoTemplate{Title: &title})
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	found, err := ts.RemoveMemoTemplate(ctx, nil, "shared")
+	require.NoError(t, err)
+	require.True(t, found)
+	instanceTemplates, err = ts.ListMemoTemplates(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, instanceTemplates)
+	templates, err = ts.ListMemoTemplates(ctx, &user.ID)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+
+	ts.Close()
+}
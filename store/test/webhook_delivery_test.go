@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestWebhookDeliveryStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	first, err := ts.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		UID:           "msg_first",
+		CreatorID:     user.ID,
+		WebhookID:     "hook",
+		ActivityType:  "memos.memo.created",
+		Payload:       `{"activityType":"memos.memo.created"}`,
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: 100,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, first.ID)
+	require.NotZero(t, first.CreatedTs)
+	second, err := ts.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		UID:           "msg_second",
+		CreatorID:     user.ID,
+		WebhookID:     "hook",
+		ActivityType:  "memos.memo.updated",
+		Payload:       `{"activityType":"memos.memo.updated"}`,
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: 50,
+	})
+	require.NoError(t, err)
+
+	// History is listed newest first.
+	webhookID := "hook"
+	deliveries, err := ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{CreatorID: &user.ID, WebhookID: &webhookID})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.Equal(t, second.ID, deliveries[0].ID)
+	require.Equal(t, store.WebhookDeliveryPending, deliveries[0].Status)
+
+	// The queue is ordered by the next attempt and limited to due deliveries.
+	pending := store.WebhookDeliveryPending
+	now := int64(75)
+	due, err := ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{Status: &pending, NextAttemptBefore: &now, OrderByNextAttempt: true})
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, second.ID, due[0].ID)
+
+	succeeded := store.WebhookDeliverySucceeded
+	attempts := int32(1)
+	statusCode := int32(200)
+	latencyMs := int64(12)
+	excerpt := `{"code":0}`
+	require.NoError(t, ts.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDelivery{
+		ID:                 second.ID,
+		Status:             &succeeded,
+		Attempts:           &attempts,
+		ResponseStatusCode: &statusCode,
+		LatencyMs:          &latencyMs,
+		ResponseExcerpt:    &excerpt,
+	}))
+	uid := "msg_second"
+	updated, err := ts.GetWebhookDelivery(ctx, &store.FindWebhookDelivery{UID: &uid})
+	require.NoError(t, err)
+	require.Equal(t, store.WebhookDeliverySucceeded, updated.Status)
+	require.Equal(t, int32(1), updated.Attempts)
+	require.Equal(t, int32(200), updated.ResponseStatusCode)
+	require.Equal(t, int64(12), updated.LatencyMs)
+	require.Equal(t, excerpt, updated.ResponseExcerpt)
+
+	require.NoError(t, ts.DeleteWebhookDeliveries(ctx, &store.DeleteWebhookDelivery{CreatorID: &user.ID, WebhookID: &webhookID}))
+	deliveries, err = ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+	ts.Close()
+}
@@ -0,0 +1,183 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/storage"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// UploadSessionStagingFolder is the folder, relative to the data directory,
+// that staged upload chunks are appended to.
+const UploadSessionStagingFolder = ".upload_sessions"
+
+// UploadSession is a resumable attachment upload that has not been finalized yet.
+type UploadSession struct {
+	ID        int32
+	UID       string
+	CreatorID int32
+	Filename  string
+	Type      string
+	// Size is the total size announced when the session was created.
+	Size int64
+	// ReceivedSize is the number of bytes received so far.
+	ReceivedSize int64
+	Payload      *storepb.UploadSessionPayload
+	// ExpiresTs is when an idle session is discarded.
+	ExpiresTs int64
+	CreatedTs int64
+	UpdatedTs int64
+}
+
+// FindUploadSession specifies filter criteria for querying upload sessions.
+type FindUploadSession struct {
+	ID        *int32
+	UID       *string
+	CreatorID *int32
+	// ExpiresBefore matches sessions that expired before the timestamp.
+	ExpiresBefore *int64
+
+	Limit *int
+}
+
+// UpdateUploadSession contains the fields that can be updated on an upload session.
+type UpdateUploadSession struct {
+	ID           int32
+	ReceivedSize *int64
+	Payload      *storepb.UploadSessionPayload
+	ExpiresTs    *int64
+}
+
+// DeleteUploadSession specifies the upload session to delete.
+type DeleteUploadSession struct {
+	ID int32
+}
+
+// CreateUploadSession creates a new upload session.
+func (s *Store) CreateUploadSession(ctx context.Context, create *UploadSession) (*UploadSession, error) {
+	return s.driver.CreateUploadSession(ctx, create)
+}
+
+// ListUploadSessions returns upload sessions matching the filter criteria.
+func (s *Store) ListUploadSessions(ctx context.Context, find *FindUploadSession) ([]*UploadSession, error) {
+	return s.driver.ListUploadSessions(ctx, find)
+}
+
+// GetUploadSession returns the first upload session matching the filter, or nil if none found.
+func (s *Store) GetUploadSession(ctx context.Context, find *FindUploadSession) (*UploadSession, error) {
+	limit := 1
+	find.Limit = &limit
+	list, err := s.ListUploadSessions(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// UpdateUploadSession updates an upload session.
+func (s *Store) UpdateUploadSession(ctx context.Context, update *UpdateUploadSession) error {
+	return s.driver.UpdateUploadSession(ctx, update)
+}
+
+// DeleteUploadSession deletes an upload session record. Use
+// DeleteUploadSessionStorage to discard the content received so far.
+func (s *Store) DeleteUploadSession(ctx context.Context, delete *DeleteUploadSession) error {
+	return s.driver.DeleteUploadSession(ctx, delete)
+}
+
+// LockUploadSession serializes the chunk writes, completion and removal of an
+// upload session, returning the function that releases the lock.
+func (s *Store) LockUploadSession(uid string) func() {
+	value, _ := s.uploadSessionMutexes.LoadOrStore(uid, &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+// ForgetUploadSessionLock drops the lock of a deleted upload session. Callers
+// still holding it see the session gone once they re-read it.
+func (s *Store) ForgetUploadSessionLock(uid string) {
+	s.uploadSessionMutexes.Delete(uid)
+}
+
+// UploadSessionStagingPath returns the absolute path of a staged upload file.
+func (s *Store) UploadSessionStagingPath(stagingPath string) string {
+	return filepath.Join(s.profile.Data, filepath.FromSlash(stagingPath))
+}
+
+// ResolveUploadSessionMultipartDriver returns the multipart-capable driver of
+// the storage receiving an upload session.
+func (s *Store) ResolveUploadSessionMultipartDriver(ctx context.Context, multipartUpload *storepb.UploadSessionPayload_MultipartUpload) (storage.MultipartDriver, error) {
+	instanceStorageSetting, err := s.GetInstanceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance storage setting")
+	}
+	driver, err := s.ResolveStorageDriver(ctx, instanceStorageSetting, multipartUpload.GetStorageId(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve storage driver")
+	}
+	multipartDriver, ok := driver.(storage.MultipartDriver)
+	if !ok {
+		return nil, errors.Errorf("storage %q does not support multipart uploads", multipartUpload.GetStorageId())
+	}
+	return multipartDriver, nil
+}
+
+// ResolveUploadSessionPresignDriver returns the presign-capable driver of the
+// storage receiving a direct upload session.
+func (s *Store) ResolveUploadSessionPresignDriver(ctx context.Context, directUpload *storepb.UploadSessionPayload_DirectUpload) (storage.PresignDriver, error) {
+	instanceStorageSetting, err := s.GetInstanceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance storage setting")
+	}
+	driver, err := s.ResolveStorageDriver(ctx, instanceStorageSetting, directUpload.GetStorageId(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve storage driver")
+	}
+	presignDriver, ok := driver.(storage.PresignDriver)
+	if !ok {
+		return nil, errors.Errorf("storage %q does not support presigned transfers", directUpload.GetStorageId())
+	}
+	return presignDriver, nil
+}
+
+// DeleteUploadSessionStorage discards the content an upload session received:
+// the staged file is removed, an S3 multipart upload is aborted, and a
+// directly uploaded object is deleted.
+func (s *Store) DeleteUploadSessionStorage(ctx context.Context, session *UploadSession) error {
+	if session == nil || session.Payload == nil {
+		return nil
+	}
+	if stagingPath := session.Payload.GetStagingPath(); stagingPath != "" {
+		if err := os.Remove(s.UploadSessionStagingPath(stagingPath)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to delete staged upload")
+		}
+	}
+	if multipartUpload := session.Payload.GetMultipartUpload(); multipartUpload != nil && multipartUpload.UploadId != "" {
+		driver, err := s.ResolveUploadSessionMultipartDriver(ctx, multipartUpload)
+		if err != nil {
+			return err
+		}
+		if err := driver.AbortMultipartUpload(ctx, multipartUpload.Key, multipartUpload.UploadId); err != nil {
+			return errors.Wrap(err, "failed to abort multipart upload")
+		}
+	}
+	if directUpload := session.Payload.GetDirectUpload(); directUpload != nil {
+		driver, err := s.ResolveUploadSessionPresignDriver(ctx, directUpload)
+		if err != nil {
+			return err
+		}
+		if err := driver.DeleteObject(ctx, directUpload.Key); err != nil {
+			return errors.Wrap(err, "failed to delete uploaded object")
+		}
+	}
+	return nil
+}
@@ -20,6 +20,9 @@ type deleteUserFailpointKey struct{}
 type DeleteUserResult struct {
 	Attachments     []*Attachment
 	UserSettingKeys []storepb.UserSetting_Key
+	// UploadSessions are the deleted upload sessions of the user, whose
+	// storage is discarded with DeleteUploadSessionStorage.
+	UploadSessions []*UploadSession
 }
 
 // WithDeleteUserFailpoint is a test-only helper that forces DeleteUser to roll back.
@@ -357,93 +357,125 @@ func (s *Store) GetUserWebhooks(ctx context.Context, userID int32) ([]*storepb.W
 
 // AddUserWebhook adds a new webhook for the user.
 func (s *Store) AddUserWebhook(ctx context.Context, userID int32, webhook *storepb.WebhooksUserSetting_Webhook) error {
-	existingWebhooks, err := s.GetUserWebhooks(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	// Check if webhook already exists, update if it does
-	var updatedWebhooks []*storepb.WebhooksUserSetting_Webhook
-	webhookExists := false
-	for _, existing := range existingWebhooks {
-		if existing.Id == webhook.Id {
-			updatedWebhooks = append(updatedWebhooks, webhook)
-			webhookExists = true
-		} else {
-			updatedWebhooks = append(updatedWebhooks, existing)
+	return s.modifyUserWebhooks(ctx, userID, func(webhooks []*storepb.WebhooksUserSetting_Webhook) ([]*storepb.WebhooksUserSetting_Webhook, bool) {
+		// Replace the webhook if it already exists.
+		for i, existing := range webhooks {
+			if existing.Id == webhook.Id {
+				webhooks[i] = webhook
+				return webhooks, true
+			}
 		}
-	}
-
-	// If webhook doesn't exist, add it
-	if !webhookExists {
-		updatedWebhooks = append(updatedWebhooks, webhook)
-	}
-
-	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
-		UserId: userID,
-		Key:    storepb.UserSetting_WEBHOOKS,
-		Value: &storepb.UserSetting_Webhooks{
-			Webhooks: &storepb.WebhooksUserSetting{
-				Webhooks: updatedWebhooks,
-			},
-		},
+		return append(webhooks, webhook), true
 	})
-
-	return err
 }
 
 // RemoveUserWebhook removes the webhook of the user.
 func (s *Store) RemoveUserWebhook(ctx context.Context, userID int32, webhookID string) error {
-	oldWebhooks, err := s.GetUserWebhooks(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	newWebhooks := make([]*storepb.WebhooksUserSetting_Webhook, 0, len(oldWebhooks))
-	for _, webhook := range oldWebhooks {
-		if webhookID != webhook.Id {
-			newWebhooks = append(newWebhooks, webhook)
+	return s.modifyUserWebhooks(ctx, userID, func(webhooks []*storepb.WebhooksUserSetting_Webhook) ([]*storepb.WebhooksUserSetting_Webhook, bool) {
+		newWebhooks := make([]*storepb.WebhooksUserSetting_Webhook, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			if webhookID != webhook.Id {
+				newWebhooks = append(newWebhooks, webhook)
+			}
 		}
-	}
-
-	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
-		UserId: userID,
-		Key:    storepb.UserSetting_WEBHOOKS,
-		Value: &storepb.UserSetting_Webhooks{
-			Webhooks: &storepb.WebhooksUserSetting{
-				Webhooks: newWebhooks,
-			},
-		},
+		return newWebhooks, len(newWebhooks) != len(webhooks)
 	})
-
-	return err
 }
 
 // UpdateUserWebhook updates an existing webhook for the user.
 func (s *Store) UpdateUserWebhook(ctx context.Context, userID int32, webhook *storepb.WebhooksUserSetting_Webhook) error {
-	webhooks, err := s.GetUserWebhooks(ctx, userID)
+	return s.modifyUserWebhooks(ctx, userID, func(webhooks []*storepb.WebhooksUserSetting_Webhook) ([]*storepb.WebhooksUserSetting_Webhook, bool) {
+		for i, existing := range webhooks {
+			if existing.Id == webhook.Id {
+				webhooks[i] = webhook
+				return webhooks, true
+			}
+		}
+		return webhooks, false
+	})
+}
+
+// ModifyUserWebhook applies modify to the latest stored state of a webhook
+// and returns the webhook as stored, or nil when the user has no such
+// webhook. modify reports whether it changed the webhook, and may run more
+// than once when other writers update the webhooks of the user meanwhile.
+func (s *Store) ModifyUserWebhook(ctx context.Context, userID int32, webhookID string, modify func(*storepb.WebhooksUserSetting_Webhook) bool) (*storepb.WebhooksUserSetting_Webhook, error) {
+	var modified *storepb.WebhooksUserSetting_Webhook
+	err := s.modifyUserWebhooks(ctx, userID, func(webhooks []*storepb.WebhooksUserSetting_Webhook) ([]*storepb.WebhooksUserSetting_Webhook, bool) {
+		modified = nil
+		for _, webhook := range webhooks {
+			if webhook.Id == webhookID {
+				modified = webhook
+				return webhooks, modify(webhook)
+			}
+		}
+		return webhooks, false
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return modified, nil
+}
 
-	for i, existing := range webhooks {
-		if existing.Id == webhook.Id {
-			webhooks[i] = webhook
-			break
+// maxUserSettingSwapAttempts bounds the retries of a user setting update
+// that keeps racing other writers.
+const maxUserSettingSwapAttempts = 20
+
+// modifyUserWebhooks rewrites the webhooks of the user with modify, applied
+// to the latest stored webhooks, unless modify reports no change. The write
+// only succeeds when the setting is unchanged since it was read, and is
+// retried otherwise, so concurrent writers never undo each other.
+func (s *Store) modifyUserWebhooks(ctx context.Context, userID int32, modify func([]*storepb.WebhooksUserSetting_Webhook) ([]*storepb.WebhooksUserSetting_Webhook, bool)) error {
+	for range maxUserSettingSwapAttempts {
+		// Read the stored value, bypassing the cache, to compare against it.
+		list, err := s.driver.ListUserSettings(ctx, &FindUserSetting{
+			UserID: &userID,
+			Key:    storepb.UserSetting_WEBHOOKS,
+		})
+		if err != nil {
+			return err
+		}
+		var oldValue *string
+		webhooks := []*storepb.WebhooksUserSetting_Webhook{}
+		if len(list) > 0 {
+			oldValue = &list[0].Value
+			userSetting, err := convertUserSettingFromRaw(list[0])
+			if err != nil {
+				return err
+			}
+			webhooks = userSetting.GetWebhooks().GetWebhooks()
 		}
-	}
 
-	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
-		UserId: userID,
-		Key:    storepb.UserSetting_WEBHOOKS,
-		Value: &storepb.UserSetting_Webhooks{
-			Webhooks: &storepb.WebhooksUserSetting{
-				Webhooks: webhooks,
+		webhooks, changed := modify(webhooks)
+		if !changed {
+			return nil
+		}
+		userSetting := &storepb.UserSetting{
+			UserId: userID,
+			Key:    storepb.UserSetting_WEBHOOKS,
+			Value: &storepb.UserSetting_Webhooks{
+				Webhooks: &storepb.WebhooksUserSetting{
+					Webhooks: webhooks,
+				},
 			},
-		},
-	})
-
-	return err
+		}
+		raw, err := convertUserSettingToRaw(userSetting)
+		if err != nil {
+			return err
+		}
+		if oldValue != nil && *oldValue == raw.Value {
+			return nil
+		}
+		swapped, err := s.driver.SwapUserSetting(ctx, raw, oldValue)
+		if err != nil {
+			return err
+		}
+		if swapped {
+			s.userSettingCache.Set(ctx, getUserSettingCacheKey(userID, storepb.UserSetting_WEBHOOKS.String()), userSetting)
+			return nil
+		}
+	}
+	return errors.New("webhooks changed concurrently")
 }
 
 // GetUserMemoViews returns the memo views of the user.
@@ -610,6 +642,12 @@ func convertUserSettingFromRaw(raw *UserSetting) (*storepb.UserSetting, error) {
 			return nil, errors.Wrap(err, "unmarshal tags user setting")
 		}
 		userSetting.Value = &storepb.UserSetting_Tags{Tags: tagsUserSetting}
+	case storepb.UserSetting_MEMO_TEMPLATES:
+		memoTemplatesUserSetting := &storepb.MemoTemplatesUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), memoTemplatesUserSetting); err != nil {
+			return nil, errors.Wrap(err, "unmarshal memo templates user setting")
+		}
+		userSetting.Value = &storepb.UserSetting_MemoTemplates{MemoTemplates: memoTemplatesUserSetting}
 	case storepb.UserSetting_REFRESH_TOKENS:
 		refreshTokensUserSetting := &storepb.RefreshTokensUserSetting{}
 		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), refreshTokensUserSetting); err != nil {
@@ -662,6 +700,12 @@ func convertUserSettingToRaw(userSetting *storepb.UserSetting) (*UserSetting, er
 			return nil, errors.Wrap(err, "marshal tags user setting")
 		}
 		raw.Value = string(value)
+	case storepb.UserSetting_MEMO_TEMPLATES:
+		value, err := protojson.Marshal(userSetting.GetMemoTemplates())
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal memo templates user setting")
+		}
+		raw.Value = string(value)
 	case storepb.UserSetting_REFRESH_TOKENS:
 		refreshTokensUserSetting := userSetting.GetRefreshTokens()
 		value, err := protojson.Marshal(refreshTokensUserSetting)
@@ -0,0 +1,115 @@
+package store
+
+import "context"
+
+// WebhookDeliveryStatus is the state of a queued webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is waiting for its first attempt or a retry.
+	WebhookDeliveryPending WebhookDeliveryStatus = "PENDING"
+	// WebhookDeliverySucceeded was accepted by the receiver.
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	// WebhookDeliveryFailed gave up after exhausting its retries.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "FAILED"
+)
+
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// WebhookDelivery is one event queued for a user webhook together with the
+// outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID        int32
+	UID       string
+	CreatorID int32 // The owner of the webhook.
+	WebhookID string
+	// ActivityType is the event type, e.g. "memos.memo.created".
+	ActivityType string
+	// Payload is the serialized request body.
+	Payload       string
+	Status        WebhookDeliveryStatus
+	Attempts      int32
+	NextAttemptTs int64
+	CreatedTs     int64
+	UpdatedTs     int64
+
+	// Latest attempt details.
+	ResponseStatusCode int32
+	LatencyMs          int64
+	ResponseExcerpt    string
+	LastError          string
+}
+
+// FindWebhookDelivery specifies filter criteria for querying deliveries.
+type FindWebhookDelivery struct {
+	ID        *int32
+	UID       *string
+	CreatorID *int32
+	WebhookID *string
+	Status    *WebhookDeliveryStatus
+	// NextAttemptBefore matches deliveries due at or before the timestamp.
+	NextAttemptBefore *int64
+
+	// OrderByNextAttempt orders by next_attempt_ts ascending instead of newest first.
+	OrderByNextAttempt bool
+
+	// Pagination
+	Limit  *int
+	Offset *int
+}
+
+// UpdateWebhookDelivery contains the fields that can be updated on a delivery.
+type UpdateWebhookDelivery struct {
+	ID                 int32
+	Status             *WebhookDeliveryStatus
+	Attempts           *int32
+	NextAttemptTs      *int64
+	ResponseStatusCode *int32
+	LatencyMs          *int64
+	ResponseExcerpt    *string
+	LastError          *string
+}
+
+// DeleteWebhookDelivery specifies which deliveries to delete.
+type DeleteWebhookDelivery struct {
+	CreatorID *int32
+	WebhookID *string
+	// CreatedBefore matches deliveries created before the timestamp.
+	CreatedBefore *int64
+}
+
+// CreateWebhookDelivery queues a new delivery.
+func (s *Store) CreateWebhookDelivery(ctx context.Context, create *WebhookDelivery) (*WebhookDelivery, error) {
+	return s.driver.CreateWebhookDelivery(ctx, create)
+}
+
+// ListWebhookDeliveries returns deliveries matching the filter criteria.
+func (s *Store) ListWebhookDeliveries(ctx context.Context, find *FindWebhookDelivery) ([]*WebhookDelivery, error) {
+	return s.driver.ListWebhookDeliveries(ctx, find)
+}
+
+// GetWebhookDelivery returns the first delivery matching the filter, or nil if none found.
+func (s *Store) GetWebhookDelivery(ctx context.Context, find *FindWebhookDelivery) (*WebhookDelivery, error) {
+	limit := 1
+	find.Limit = &limit
+	list, err := s.ListWebhookDeliveries(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// UpdateWebhookDelivery updates a delivery.
+func (s *Store) UpdateWebhookDelivery(ctx context.Context, update *UpdateWebhookDelivery) error {
+	return s.driver.UpdateWebhookDelivery(ctx, update)
+}
+
+// DeleteWebhookDeliveries removes deliveries matching the criteria.
+func (s *Store) DeleteWebhookDeliveries(ctx context.Context, delete *DeleteWebhookDelivery) error {
+	return s.driver.DeleteWebhookDeliveries(ctx, delete)
+}